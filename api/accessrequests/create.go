@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accessrequests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Create requests time-boxed access to the target targetId for the calling
+// user. The justification and the duration of the access are set with
+// WithJustification and WithDurationSeconds.
+func (c *Client) Create(ctx context.Context, targetId string, opt ...Option) (*AccessRequestCreateResult, error) {
+	if targetId == "" {
+		return nil, fmt.Errorf("empty targetId value passed into Create request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.postMap["target_id"] = targetId
+
+	req, err := c.client.NewRequest(ctx, "POST", "access-requests", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Create request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Create call: %w", err)
+	}
+
+	target := new(AccessRequestCreateResult)
+	target.Item = new(AccessRequest)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Create response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
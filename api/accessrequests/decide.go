@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accessrequests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// WithComment sets the comment which is recorded with the approval or denial
+// of an access request.
+func WithComment(comment string) Option {
+	return func(o *options) {
+		o.postMap["comment"] = comment
+	}
+}
+
+// Approve approves the pending access request accessRequestId, which grants
+// its user access to its target until it expires. WithComment can be used
+// to record a comment with the approval.
+func (c *Client) Approve(ctx context.Context, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	return c.doAction(ctx, "Approve", "approve", accessRequestId, version, opt...)
+}
+
+// Deny denies the pending access request accessRequestId. WithComment can be
+// used to record a comment with the denial.
+func (c *Client) Deny(ctx context.Context, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	return c.doAction(ctx, "Deny", "deny", accessRequestId, version, opt...)
+}
+
+// Cancel cancels the pending or approved access request accessRequestId. The
+// access granted by an approved access request is revoked.
+func (c *Client) Cancel(ctx context.Context, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	return c.doAction(ctx, "Cancel", "cancel", accessRequestId, version, opt...)
+}
+
+func (c *Client) doAction(ctx context.Context, funcName, apiAction, accessRequestId string, version uint32, opt ...Option) (*AccessRequestUpdateResult, error) {
+	if accessRequestId == "" {
+		return nil, fmt.Errorf("empty accessRequestId value passed into %s request", funcName)
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if version == 0 {
+		if !opts.withAutomaticVersioning {
+			return nil, fmt.Errorf("zero version number passed into %s request", funcName)
+		}
+		existingAccessRequest, existingErr := c.Read(ctx, accessRequestId, opt...)
+		if existingErr != nil {
+			if api.AsServerError(existingErr) != nil {
+				return nil, fmt.Errorf("error from controller when performing initial check-and-set read: %w", existingErr)
+			}
+			return nil, fmt.Errorf("error performing initial check-and-set read: %w", existingErr)
+		}
+		if existingAccessRequest == nil {
+			return nil, errors.New("nil resource response found when performing initial check-and-set read")
+		}
+		if existingAccessRequest.Item == nil {
+			return nil, errors.New("nil resource found when performing initial check-and-set read")
+		}
+		version = existingAccessRequest.Item.Version
+	}
+
+	opts.postMap["version"] = version
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("access-requests/%s:%s", accessRequestId, apiAction), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", funcName, err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", funcName, err)
+	}
+
+	target := new(AccessRequestUpdateResult)
+	target.Item = new(AccessRequest)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", funcName, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
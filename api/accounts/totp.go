@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// TotpEnrollResult is the result of enrolling a TOTP second factor. It
+// contains the account and the shared secret to add to an authenticator app.
+type TotpEnrollResult struct {
+	Item     *Account `json:"item,omitempty"`
+	Secret   string   `json:"secret,omitempty"`
+	Url      string   `json:"url,omitempty"`
+	response *api.Response
+}
+
+func (n TotpEnrollResult) GetItem() *Account {
+	return n.Item
+}
+
+func (n TotpEnrollResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// EnrollTotp enrolls a TOTP second factor for a password account. The second
+// factor is required to authenticate once the enrollment is verified with
+// VerifyTotp.
+func (c *Client) EnrollTotp(ctx context.Context, accountId string, opt ...Option) (*TotpEnrollResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into EnrollTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in EnrollTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:enroll-totp", accountId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating EnrollTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during EnrollTotp call: %w", err)
+	}
+
+	target := new(TotpEnrollResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding EnrollTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// VerifyTotp verifies the TOTP enrollment of a password account with a code
+// generated from the shared secret returned by EnrollTotp.
+func (c *Client) VerifyTotp(ctx context.Context, accountId, code string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into VerifyTotp request")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("empty code value passed into VerifyTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in VerifyTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]any{
+		"code": code,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:verify-totp", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating VerifyTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during VerifyTotp call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding VerifyTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
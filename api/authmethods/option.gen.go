@@ -26,6 +26,8 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput      bool
 	withFilter              string
+	withPageSize            uint32
+	withPageToken           string
 	withRecursive           bool
 }
 
@@ -50,6 +52,12 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withPageToken != "" {
+		opts.queryMap["page_token"] = opts.withPageToken
+	}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
 	}
@@ -83,6 +91,22 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API the maximum number of items to return in a single
+// page of a listing operation. The controller may return fewer items.
+func WithPageSize(size uint32) Option {
+	return func(o *options) {
+		o.withPageSize = size
+	}
+}
+
+// WithPageToken tells the API to return the page of a listing operation
+// following the page the token was returned with.
+func WithPageToken(token string) Option {
+	return func(o *options) {
+		o.withPageToken = token
+	}
+}
+
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
 func WithRecursive(recurse bool) Option {
@@ -223,6 +247,30 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithAuthTokenTimeToLiveSeconds(inAuthTokenTimeToLiveSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_live_seconds"] = inAuthTokenTimeToLiveSeconds
+	}
+}
+
+func DefaultAuthTokenTimeToLiveSeconds() Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_live_seconds"] = nil
+	}
+}
+
+func WithAuthTokenTimeToStaleSeconds(inAuthTokenTimeToStaleSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_stale_seconds"] = inAuthTokenTimeToStaleSeconds
+	}
+}
+
+func DefaultAuthTokenTimeToStaleSeconds() Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_stale_seconds"] = nil
+	}
+}
+
 func WithLdapAuthMethodBindDn(inBindDn string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -691,6 +739,54 @@ func DefaultOidcAuthMethodIssuer() Option {
 	}
 }
 
+func WithPasswordAuthMethodLockoutDurationSeconds(inLockoutDurationSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = inLockoutDurationSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutDurationSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodLockoutThreshold(inLockoutThreshold uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = inLockoutThreshold
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutThreshold() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithOidcAuthMethodMaxAge(inMaxAge uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -715,6 +811,30 @@ func DefaultOidcAuthMethodMaxAge() Option {
 	}
 }
 
+func WithPasswordAuthMethodMaxPasswordAgeDays(inMaxPasswordAgeDays uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_days"] = inMaxPasswordAgeDays
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMaxPasswordAgeDays() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_days"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithLdapAuthMethodMaximumPageSize(inMaximumPageSize uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -763,6 +883,30 @@ func DefaultPasswordAuthMethodMinLoginNameLength() Option {
 	}
 }
 
+func WithPasswordAuthMethodMinPasswordCharacterClasses(inMinPasswordCharacterClasses uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_password_character_classes"] = inMinPasswordCharacterClasses
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMinPasswordCharacterClasses() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["min_password_character_classes"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithPasswordAuthMethodMinPasswordLength(inMinPasswordLength uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -799,6 +943,30 @@ func DefaultName() Option {
 	}
 }
 
+func WithPasswordAuthMethodPasswordHistoryDepth(inPasswordHistoryDepth uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_depth"] = inPasswordHistoryDepth
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordHistoryDepth() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_depth"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithOidcAuthMethodPrompts(inPrompts []string) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
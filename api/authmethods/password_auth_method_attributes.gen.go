@@ -11,8 +11,13 @@ import (
 )
 
 type PasswordAuthMethodAttributes struct {
-	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
-	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+	MinLoginNameLength          uint32 `json:"min_login_name_length,omitempty"`
+	MinPasswordLength           uint32 `json:"min_password_length,omitempty"`
+	MinPasswordCharacterClasses uint32 `json:"min_password_character_classes,omitempty"`
+	PasswordHistoryDepth        uint32 `json:"password_history_depth,omitempty"`
+	MaxPasswordAgeDays          uint32 `json:"max_password_age_days,omitempty"`
+	LockoutThreshold            uint32 `json:"lockout_threshold,omitempty"`
+	LockoutDurationSeconds      uint32 `json:"lockout_duration_seconds,omitempty"`
 }
 
 func AttributesMapToPasswordAuthMethodAttributes(in map[string]interface{}) (*PasswordAuthMethodAttributes, error) {
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// RevokeTokensResult is the result of revoking the auth tokens of an auth
+// method. It contains the ids of the deleted auth tokens and of the canceled
+// sessions.
+type RevokeTokensResult struct {
+	AuthTokenIds []string `json:"auth_token_ids,omitempty"`
+	SessionIds   []string `json:"session_ids,omitempty"`
+	response     *api.Response
+}
+
+func (n RevokeTokensResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RevokeTokens deletes all auth tokens issued by an auth method and cancels
+// the sessions created with them.
+func (c *Client) RevokeTokens(ctx context.Context, authMethodId string, opt ...Option) (*RevokeTokensResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into RevokeTokens request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RevokeTokens request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:revoke-tokens", authMethodId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RevokeTokens request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RevokeTokens call: %w", err)
+	}
+
+	target := new(RevokeTokensResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RevokeTokens response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -5,7 +5,9 @@
 package authtokens
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
@@ -60,14 +62,19 @@ func (n AuthTokenDeleteResult) GetResponse() *api.Response {
 }
 
 type AuthTokenListResult struct {
-	Items    []*AuthToken
-	response *api.Response
+	Items         []*AuthToken
+	NextPageToken string `json:"next_page_token,omitempty"`
+	response      *api.Response
 }
 
 func (n AuthTokenListResult) GetItems() []*AuthToken {
 	return n.Items
 }
 
+func (n AuthTokenListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n AuthTokenListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -173,7 +180,47 @@ func (c *Client) Delete(ctx context.Context, id string, opt ...Option) (*AuthTok
 	return target, nil
 }
 
+// List returns every item in the collection, following the page tokens
+// returned by the controller until the listing is complete. Use ListPage to
+// retrieve a single page of items.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*AuthTokenListResult, error) {
+	target, err := c.ListPage(ctx, scopeId, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if target.NextPageToken == "" {
+		return target, nil
+	}
+
+	items, _ := target.response.Map["items"].([]interface{})
+	for target.NextPageToken != "" {
+		page, err := c.ListPage(ctx, scopeId, append(opt, WithPageToken(target.NextPageToken))...)
+		if err != nil {
+			return nil, err
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		if pageItems, ok := page.response.Map["items"].([]interface{}); ok {
+			items = append(items, pageItems...)
+		}
+	}
+
+	// Present the combined pages as a single response so that callers
+	// inspecting the raw response see every item.
+	target.response.Map["items"] = items
+	delete(target.response.Map, "next_page_token")
+	body, err := json.Marshal(target.response.Map)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding combined List response: %w", err)
+	}
+	target.response.Body = bytes.NewBuffer(body)
+	return target, nil
+}
+
+// ListPage returns a single page of items in the collection. The
+// NextPageToken of the result can be passed to WithPageToken to retrieve the
+// following page; it is empty once the listing is complete.
+func (c *Client) ListPage(ctx context.Context, scopeId string, opt ...Option) (*AuthTokenListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
 	}
@@ -45,8 +45,10 @@ const (
 	EnvBoundaryRateLimit     = "BOUNDARY_RATE_LIMIT"
 	EnvBoundarySRVLookup     = "BOUNDARY_SRV_LOOKUP"
 
-	AsciiCastMimeType = "application/x-asciicast"
-	StreamChunkSize   = 1024 * 64 // stream chuck buffer size
+	AsciiCastMimeType  = "application/x-asciicast"
+	TranscriptMimeType = "text/plain"
+	JsonlMimeType      = "application/jsonl"
+	StreamChunkSize    = 1024 * 64 // stream chuck buffer size
 )
 
 // Config is used to configure the creation of the client
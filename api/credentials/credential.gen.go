@@ -5,7 +5,9 @@
 package credentials
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -61,14 +63,19 @@ func (n CredentialDeleteResult) GetResponse() *api.Response {
 }
 
 type CredentialListResult struct {
-	Items    []*Credential
-	response *api.Response
+	Items         []*Credential
+	NextPageToken string `json:"next_page_token,omitempty"`
+	response      *api.Response
 }
 
 func (n CredentialListResult) GetItems() []*Credential {
 	return n.Items
 }
 
+func (n CredentialListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
 func (n CredentialListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -286,7 +293,47 @@ func (c *Client) Delete(ctx context.Context, id string, opt ...Option) (*Credent
 	return target, nil
 }
 
+// List returns every item in the collection, following the page tokens
+// returned by the controller until the listing is complete. Use ListPage to
+// retrieve a single page of items.
 func (c *Client) List(ctx context.Context, credentialStoreId string, opt ...Option) (*CredentialListResult, error) {
+	target, err := c.ListPage(ctx, credentialStoreId, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if target.NextPageToken == "" {
+		return target, nil
+	}
+
+	items, _ := target.response.Map["items"].([]interface{})
+	for target.NextPageToken != "" {
+		page, err := c.ListPage(ctx, credentialStoreId, append(opt, WithPageToken(target.NextPageToken))...)
+		if err != nil {
+			return nil, err
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		if pageItems, ok := page.response.Map["items"].([]interface{}); ok {
+			items = append(items, pageItems...)
+		}
+	}
+
+	// Present the combined pages as a single response so that callers
+	// inspecting the raw response see every item.
+	target.response.Map["items"] = items
+	delete(target.response.Map, "next_page_token")
+	body, err := json.Marshal(target.response.Map)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding combined List response: %w", err)
+	}
+	target.response.Body = bytes.NewBuffer(body)
+	return target, nil
+}
+
+// ListPage returns a single page of items in the collection. The
+// NextPageToken of the result can be passed to WithPageToken to retrieve the
+// following page; it is empty once the listing is complete.
+func (c *Client) ListPage(ctx context.Context, credentialStoreId string, opt ...Option) (*CredentialListResult, error) {
 	if credentialStoreId == "" {
 		return nil, fmt.Errorf("empty credentialStoreId value passed into List request")
 	}
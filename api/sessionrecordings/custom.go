@@ -14,8 +14,31 @@ import (
 	"github.com/hashicorp/boundary/api"
 )
 
+// SearchResult is the result of a search of session recordings.
+type SearchResult struct {
+	Items    []*SearchMatch
+	response *api.Response
+}
+
+func (n SearchResult) GetItems() []*SearchMatch {
+	return n.Items
+}
+
+func (n SearchResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// WithMimeType sets the mime type a session recording resource is downloaded
+// as. Supported mime types are api.AsciiCastMimeType, api.TranscriptMimeType
+// and api.JsonlMimeType.
+func WithMimeType(mimeType string) Option {
+	return func(o *options) {
+		o.queryMap["mime_type"] = mimeType
+	}
+}
+
 // Download will of course download the request session recording resource.
-// Currently it always requests a mime-type of asciicast.
+// It requests a mime-type of asciicast unless WithMimeType is used.
 func (c *Client) Download(ctx context.Context, contentId string, opt ...Option) (io.ReadCloser, error) {
 	switch {
 	case contentId == "":
@@ -30,8 +53,10 @@ func (c *Client) Download(ctx context.Context, contentId string, opt ...Option)
 	if err != nil {
 		return nil, fmt.Errorf("error creating download request: %w", err)
 	}
-	opts.queryMap["mime_type"] = api.AsciiCastMimeType
-	req.Header.Set("Accept", api.AsciiCastMimeType)
+	if opts.queryMap["mime_type"] == "" {
+		opts.queryMap["mime_type"] = api.AsciiCastMimeType
+	}
+	req.Header.Set("Accept", opts.queryMap["mime_type"])
 
 	if len(opts.queryMap) > 0 {
 		q := url.Values{}
@@ -57,3 +82,48 @@ func (c *Client) Download(ctx context.Context, contentId string, opt ...Option)
 	}
 	return resp.HttpResponse().Body, nil
 }
+
+// Search returns the lines of output of the indexed session recordings in
+// the scope which contain query, ignoring case. Use WithRecursive to also
+// search the session recordings of child scopes.
+func (c *Client) Search(ctx context.Context, scopeId, query string, opt ...Option) (*SearchResult, error) {
+	switch {
+	case scopeId == "":
+		return nil, fmt.Errorf("empty scopeId value passed into Search request")
+	case query == "":
+		return nil, fmt.Errorf("empty query value passed into Search request")
+	case c.client == nil:
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+	opts.queryMap["query"] = query
+
+	req, err := c.client.NewRequest(ctx, "GET", "session-recordings:search", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Search request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Search call: %w", err)
+	}
+
+	target := new(SearchResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Search response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
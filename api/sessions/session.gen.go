@@ -5,7 +5,9 @@
 package sessions
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"time"
@@ -69,14 +71,29 @@ func (n SessionDeleteResult) GetResponse() *api.Response {
 }
 
 type SessionListResult struct {
-	Items    []*Session
-	response *api.Response
+	Items         []*Session
+	NextPageToken string   `json:"next_page_token,omitempty"`
+	RefreshToken  string   `json:"refresh_token,omitempty"`
+	RemovedIds    []string `json:"removed_ids,omitempty"`
+	response      *api.Response
 }
 
 func (n SessionListResult) GetItems() []*Session {
 	return n.Items
 }
 
+func (n SessionListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
+func (n SessionListResult) GetRefreshToken() string {
+	return n.RefreshToken
+}
+
+func (n SessionListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n SessionListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -140,7 +157,55 @@ func (c *Client) Read(ctx context.Context, id string, opt ...Option) (*SessionRe
 	return target, nil
 }
 
+// List returns every item in the collection, following the page tokens
+// returned by the controller until the listing is complete. Use ListPage to
+// retrieve a single page of items.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*SessionListResult, error) {
+	target, err := c.ListPage(ctx, scopeId, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if target.NextPageToken == "" {
+		return target, nil
+	}
+
+	items, _ := target.response.Map["items"].([]interface{})
+	for target.NextPageToken != "" {
+		page, err := c.ListPage(ctx, scopeId, append(opt, WithPageToken(target.NextPageToken))...)
+		if err != nil {
+			return nil, err
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		target.RefreshToken = page.RefreshToken
+		target.RemovedIds = append(target.RemovedIds, page.RemovedIds...)
+		if pageItems, ok := page.response.Map["items"].([]interface{}); ok {
+			items = append(items, pageItems...)
+		}
+	}
+
+	// Present the combined pages as a single response so that callers
+	// inspecting the raw response see every item.
+	target.response.Map["items"] = items
+	delete(target.response.Map, "next_page_token")
+	if target.RefreshToken != "" {
+		target.response.Map["refresh_token"] = target.RefreshToken
+	}
+	if len(target.RemovedIds) > 0 {
+		target.response.Map["removed_ids"] = target.RemovedIds
+	}
+	body, err := json.Marshal(target.response.Map)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding combined List response: %w", err)
+	}
+	target.response.Body = bytes.NewBuffer(body)
+	return target, nil
+}
+
+// ListPage returns a single page of items in the collection. The
+// NextPageToken of the result can be passed to WithPageToken to retrieve the
+// following page; it is empty once the listing is complete.
+func (c *Client) ListPage(ctx context.Context, scopeId string, opt ...Option) (*SessionListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
 	}
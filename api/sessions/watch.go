@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// Output is output sent to the client of a watched session.
+type Output struct {
+	Time time.Time `json:"time,omitempty"`
+	Data []byte    `json:"data,omitempty"`
+}
+
+// WatchResult is the output of a watched session since the requested
+// position.
+type WatchResult struct {
+	WatchId  string    `json:"watch_id,omitempty"`
+	Output   []*Output `json:"output,omitempty"`
+	Position uint64    `json:"position,string,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	response *api.Response
+}
+
+func (n WatchResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// Watch returns the output sent to the client of an active SSH session. If
+// watchId is empty a new watch of the session is created; otherwise the
+// output following position is returned, where watchId and position are
+// taken from the previous result. The request waits for a while for output
+// to become available, and returns once the session is no longer active.
+func (c *Client) Watch(ctx context.Context, sessionId, watchId string, position uint64, opt ...Option) (*WatchResult, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("empty sessionId value passed into Watch request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in Watch request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]any{}
+	if watchId != "" {
+		reqBody["watch_id"] = watchId
+		reqBody["position"] = strconv.FormatUint(position, 10)
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("sessions/%s:watch", sessionId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Watch request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Watch call: %w", err)
+	}
+
+	target := new(WatchResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Watch response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -26,7 +26,10 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput      bool
 	withFilter              string
+	withPageSize            uint32
+	withPageToken           string
 	withRecursive           bool
+	withRefreshToken        string
 }
 
 func getDefaultOptions() options {
@@ -50,9 +53,18 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
 	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withPageToken != "" {
+		opts.queryMap["page_token"] = opts.withPageToken
+	}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
 	}
+	if opts.withRefreshToken != "" {
+		opts.queryMap["refresh_token"] = opts.withRefreshToken
+	}
 	return opts, apiOpts
 }
 
@@ -83,6 +95,31 @@ func WithFilter(filter string) Option {
 	}
 }
 
+// WithPageSize tells the API the maximum number of items to return in a single
+// page of a listing operation. The controller may return fewer items.
+func WithPageSize(size uint32) Option {
+	return func(o *options) {
+		o.withPageSize = size
+	}
+}
+
+// WithPageToken tells the API to return the page of a listing operation
+// following the page the token was returned with.
+func WithPageToken(token string) Option {
+	return func(o *options) {
+		o.withPageToken = token
+	}
+}
+
+// WithRefreshToken tells the API to only return the items which changed since
+// the listing operation the token was returned with, along with the ids of the
+// items which were removed since.
+func WithRefreshToken(token string) Option {
+	return func(o *options) {
+		o.withRefreshToken = token
+	}
+}
+
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
 func WithRecursive(recurse bool) Option {
@@ -265,6 +302,30 @@ func WithHostId(inHostId string) Option {
 	}
 }
 
+func WithSshTargetHostKeys(inHostKeys string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_keys"] = inHostKeys
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetHostKeys() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["host_keys"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithIngressWorkerFilter(inIngressWorkerFilter string) Option {
 	return func(o *options) {
 		o.postMap["ingress_worker_filter"] = inIngressWorkerFilter
@@ -301,6 +362,54 @@ func DefaultName() Option {
 	}
 }
 
+func WithSshTargetRecordingCompression(inRecordingCompression string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["recording_compression"] = inRecordingCompression
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetRecordingCompression() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["recording_compression"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithSshTargetRecordingCompressionLevel(inRecordingCompressionLevel uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["recording_compression_level"] = inRecordingCompressionLevel
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultSshTargetRecordingCompressionLevel() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["recording_compression_level"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithScopeId(inScopeId string) Option {
 	return func(o *options) {
 		o.postMap["scope_id"] = inScopeId
@@ -325,6 +434,18 @@ func DefaultSessionConnectionLimit() Option {
 	}
 }
 
+func WithSessionIdleTimeoutSeconds(inSessionIdleTimeoutSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = inSessionIdleTimeoutSeconds
+	}
+}
+
+func DefaultSessionIdleTimeoutSeconds() Option {
+	return func(o *options) {
+		o.postMap["session_idle_timeout_seconds"] = nil
+	}
+}
+
 func WithSessionMaxSeconds(inSessionMaxSeconds uint32) Option {
 	return func(o *options) {
 		o.postMap["session_max_seconds"] = inSessionMaxSeconds
@@ -11,10 +11,13 @@ import (
 )
 
 type SshTargetAttributes struct {
-	DefaultPort            uint32 `json:"default_port,omitempty"`
-	DefaultClientPort      uint32 `json:"default_client_port,omitempty"`
-	StorageBucketId        string `json:"storage_bucket_id,omitempty"`
-	EnableSessionRecording bool   `json:"enable_session_recording,omitempty"`
+	DefaultPort               uint32 `json:"default_port,omitempty"`
+	DefaultClientPort         uint32 `json:"default_client_port,omitempty"`
+	StorageBucketId           string `json:"storage_bucket_id,omitempty"`
+	EnableSessionRecording    bool   `json:"enable_session_recording,omitempty"`
+	RecordingCompression      string `json:"recording_compression,omitempty"`
+	RecordingCompressionLevel uint32 `json:"recording_compression_level,omitempty"`
+	HostKeys                  string `json:"host_keys,omitempty"`
 }
 
 func AttributesMapToSshTargetAttributes(in map[string]interface{}) (*SshTargetAttributes, error) {
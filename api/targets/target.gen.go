@@ -5,7 +5,9 @@
 package targets
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -29,6 +31,7 @@ type Target struct {
 	HostSources                            []*HostSource          `json:"host_sources,omitempty"`
 	SessionMaxSeconds                      uint32                 `json:"session_max_seconds,omitempty"`
 	SessionConnectionLimit                 int32                  `json:"session_connection_limit,omitempty"`
+	SessionIdleTimeoutSeconds              uint32                 `json:"session_idle_timeout_seconds,omitempty"`
 	WorkerFilter                           string                 `json:"worker_filter,omitempty"`
 	EgressWorkerFilter                     string                 `json:"egress_worker_filter,omitempty"`
 	IngressWorkerFilter                    string                 `json:"ingress_worker_filter,omitempty"`
@@ -73,14 +76,29 @@ func (n TargetDeleteResult) GetResponse() *api.Response {
 }
 
 type TargetListResult struct {
-	Items    []*Target
-	response *api.Response
+	Items         []*Target
+	NextPageToken string   `json:"next_page_token,omitempty"`
+	RefreshToken  string   `json:"refresh_token,omitempty"`
+	RemovedIds    []string `json:"removed_ids,omitempty"`
+	response      *api.Response
 }
 
 func (n TargetListResult) GetItems() []*Target {
 	return n.Items
 }
 
+func (n TargetListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+
+func (n TargetListResult) GetRefreshToken() string {
+	return n.RefreshToken
+}
+
+func (n TargetListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+
 func (n TargetListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -298,7 +316,55 @@ func (c *Client) Delete(ctx context.Context, id string, opt ...Option) (*TargetD
 	return target, nil
 }
 
+// List returns every item in the collection, following the page tokens
+// returned by the controller until the listing is complete. Use ListPage to
+// retrieve a single page of items.
 func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*TargetListResult, error) {
+	target, err := c.ListPage(ctx, scopeId, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if target.NextPageToken == "" {
+		return target, nil
+	}
+
+	items, _ := target.response.Map["items"].([]interface{})
+	for target.NextPageToken != "" {
+		page, err := c.ListPage(ctx, scopeId, append(opt, WithPageToken(target.NextPageToken))...)
+		if err != nil {
+			return nil, err
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		target.RefreshToken = page.RefreshToken
+		target.RemovedIds = append(target.RemovedIds, page.RemovedIds...)
+		if pageItems, ok := page.response.Map["items"].([]interface{}); ok {
+			items = append(items, pageItems...)
+		}
+	}
+
+	// Present the combined pages as a single response so that callers
+	// inspecting the raw response see every item.
+	target.response.Map["items"] = items
+	delete(target.response.Map, "next_page_token")
+	if target.RefreshToken != "" {
+		target.response.Map["refresh_token"] = target.RefreshToken
+	}
+	if len(target.RemovedIds) > 0 {
+		target.response.Map["removed_ids"] = target.RemovedIds
+	}
+	body, err := json.Marshal(target.response.Map)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding combined List response: %w", err)
+	}
+	target.response.Body = bytes.NewBuffer(body)
+	return target, nil
+}
+
+// ListPage returns a single page of items in the collection. The
+// NextPageToken of the result can be passed to WithPageToken to retrieve the
+// following page; it is empty once the listing is complete.
+func (c *Client) ListPage(ctx context.Context, scopeId string, opt ...Option) (*TargetListResult, error) {
 	if scopeId == "" {
 		return nil, fmt.Errorf("empty scopeId value passed into List request")
 	}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// GrantExplanation describes how a single grant of a user was evaluated
+// against a resource and action.
+type GrantExplanation struct {
+	RoleId     string `json:"role_id,omitempty"`
+	ScopeId    string `json:"scope_id,omitempty"`
+	Grant      string `json:"grant,omitempty"`
+	Matched    bool   `json:"matched,omitempty"`
+	Authorized bool   `json:"authorized,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// ExplainPermissionsResult is the result of explaining the permissions of a
+// user on a resource.
+type ExplainPermissionsResult struct {
+	ResourceId        string              `json:"resource_id,omitempty"`
+	ResourceType      string              `json:"resource_type,omitempty"`
+	ScopeId           string              `json:"scope_id,omitempty"`
+	Action            string              `json:"action,omitempty"`
+	Authorized        bool                `json:"authorized,omitempty"`
+	Grants            []*GrantExplanation `json:"grants,omitempty"`
+	AuthorizedActions []string            `json:"authorized_actions,omitempty"`
+	response          *api.Response
+}
+
+func (n ExplainPermissionsResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ExplainPermissions evaluates the grants of a user against a resource. If
+// action is not empty, the result explains how each grant of the user was
+// evaluated when authorizing the action. The result always contains every
+// action the user can perform on the resource. If clientIp is not empty, it
+// is used to evaluate grants restricted to source CIDRs.
+func (c *Client) ExplainPermissions(ctx context.Context, userId, resourceId, action, clientIp string, opt ...Option) (*ExplainPermissionsResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into ExplainPermissions request")
+	}
+	if resourceId == "" {
+		return nil, fmt.Errorf("empty resourceId value passed into ExplainPermissions request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ExplainPermissions request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]any{
+		"resource_id": resourceId,
+	}
+	if action != "" {
+		reqBody["action"] = action
+	}
+	if clientIp != "" {
+		reqBody["client_ip"] = clientIp
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("users/%s:explain-permissions", userId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ExplainPermissions request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ExplainPermissions call: %w", err)
+	}
+
+	target := new(ExplainPermissionsResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ExplainPermissions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// RevokeTokensResult is the result of revoking the auth tokens of a user. It
+// contains the ids of the deleted auth tokens and of the canceled sessions.
+type RevokeTokensResult struct {
+	AuthTokenIds []string `json:"auth_token_ids,omitempty"`
+	SessionIds   []string `json:"session_ids,omitempty"`
+	response     *api.Response
+}
+
+func (n RevokeTokensResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RevokeTokens deletes all auth tokens of a user and cancels the sessions of
+// the user.
+func (c *Client) RevokeTokens(ctx context.Context, userId string, opt ...Option) (*RevokeTokensResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into RevokeTokens request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RevokeTokens request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("users/%s:revoke-tokens", userId), map[string]any{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RevokeTokens request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RevokeTokens call: %w", err)
+	}
+
+	target := new(RevokeTokensResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RevokeTokens response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -35,6 +35,8 @@ const (
 	GrantsField                                 = "grants"
 	GrantStringsField                           = "grant_strings"
 	PrimaryAuthMethodIdField                    = "primary_auth_method_id"
+	AuthTokenTimeToLiveSecondsField             = "auth_token_time_to_live_seconds"
+	AuthTokenTimeToStaleSecondsField            = "auth_token_time_to_stale_seconds"
 	TargetIdField                               = "target_id"
 	HostIdField                                 = "host_id"
 	HostSetIdField                              = "host_set_id"
@@ -48,6 +50,7 @@ const (
 	StatesField                                 = "states"
 	SessionConnectionLimitField                 = "session_connection_limit"
 	SessionMaxSecondsField                      = "session_max_seconds"
+	SessionIdleTimeoutSecondsField              = "session_idle_timeout_seconds"
 	WorkerFilterField                           = "worker_filter"
 	EgressWorkerFilterField                     = "egress_worker_filter"
 	IngressWorkerFilterField                    = "ingress_worker_filter"
@@ -102,6 +105,7 @@ const (
 	AttributesAddressField                      = "attributes.address"
 	SecretsField                                = "secrets"
 	MimeTypeField                               = "mime_type"
+	QueryField                                  = "query"
 	MimeTypesField                              = "mime_types"
 	SessionIdField                              = "session_id"
 	StorageBucketIdField                        = "storage_bucket_id"
@@ -115,4 +119,9 @@ const (
 	ConnectionRecordingsField                   = "connection_recordings"
 	CreateTimeValues                            = "create_time_values"
 	DefaultPortField                            = "default_port"
+	JustificationField                          = "justification"
+	DurationSecondsField                        = "duration_seconds"
+	ApproverIdField                             = "approver_id"
+	DecisionCommentField                        = "decision_comment"
+	DecisionTimeField                           = "decision_time"
 )
@@ -18,6 +18,7 @@ type (
 	ContextMaxRequestSizeType      struct{}
 	ContextOriginalRequestPathType struct{}
 	ContextAuthTokenPublicIdType   struct{}
+	ContextEncryptedAuthTokenType  struct{}
 )
 
 var (
@@ -35,6 +36,10 @@ var (
 	// about clashing string identifiers
 	ContextAuthTokenPublicIdKey ContextAuthTokenPublicIdType
 
+	// ContextEncryptedAuthTokenKey is a value to keep linters from complaining
+	// about clashing string identifiers
+	ContextEncryptedAuthTokenKey ContextEncryptedAuthTokenType
+
 	// ContextOriginalRequestPathTypeKey is a value to keep linters from complaining
 	// about clashing string identifiers
 	ContextOriginalRequestPathTypeKey ContextOriginalRequestPathType
@@ -109,7 +109,7 @@ const (
 
 	// TcpTargetPrefix is the prefix for TCP targets
 	TcpTargetPrefix = "ttcp"
-	// SshTargetPrefix is the prefix for TCP targets
+	// SshTargetPrefix is the prefix for SSH targets
 	SshTargetPrefix = "tssh"
 
 	// WorkerPrefix is the prefix for workers
@@ -124,6 +124,15 @@ const (
 	ConnectionRecordingPrefix = "cr"
 	// ChannelRecordingPrefix is the prefix for channel recordings
 	ChannelRecordingPrefix = "chr"
+
+	// TargetAliasPrefix is the prefix for target aliases
+	TargetAliasPrefix = "alt"
+
+	// StoragePolicyPrefix is the prefix for storage policies
+	StoragePolicyPrefix = "pst"
+
+	// AccessRequestPrefix is the prefix for access requests
+	AccessRequestPrefix = "areq"
 )
 
 type ResourceInfo struct {
@@ -312,6 +321,21 @@ var prefixToResourceType = map[string]ResourceInfo{
 		Type:    resource.SessionRecording,
 		Subtype: UnknownSubtype,
 	},
+
+	TargetAliasPrefix: {
+		Type:    resource.Alias,
+		Subtype: UnknownSubtype,
+	},
+
+	StoragePolicyPrefix: {
+		Type:    resource.Policy,
+		Subtype: UnknownSubtype,
+	},
+
+	AccessRequestPrefix: {
+		Type:    resource.AccessRequest,
+		Subtype: UnknownSubtype,
+	},
 }
 
 var resourceTypeToPrefixes map[resource.Type][]string = func() map[resource.Type][]string {
@@ -1,6 +1,6 @@
 module github.com/hashicorp/boundary
 
-go 1.21
+go 1.22
 
 replace github.com/hashicorp/boundary/api => ./api
 
@@ -50,6 +50,7 @@ require (
 	github.com/jackc/pgconn v1.14.1
 	github.com/jackc/pgx/v4 v4.18.1 // indirect
 	github.com/jefferai/keyring v1.1.7-0.20220316160357-58a74bb55891
+	github.com/klauspost/compress v1.18.0
 	github.com/kr/pretty v0.3.1
 	github.com/kr/text v0.2.0
 	github.com/mattn/go-colorable v0.1.13
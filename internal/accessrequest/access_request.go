@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/accessrequest/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"google.golang.org/protobuf/proto"
+)
+
+// MaxDuration is the longest duration of access which can be requested.
+const MaxDuration = 7 * 24 * time.Hour
+
+// Status is the status of an access request.
+type Status string
+
+const (
+	// StatusPending is the status of an access request which has not been
+	// decided on yet.
+	StatusPending Status = "pending"
+	// StatusApproved is the status of an access request whose user has been
+	// granted access to the target.
+	StatusApproved Status = "approved"
+	// StatusDenied is the status of an access request which was denied.
+	StatusDenied Status = "denied"
+	// StatusCanceled is the status of an access request which was canceled
+	// before it was decided on or before it expired.
+	StatusCanceled Status = "canceled"
+	// StatusExpired is the status of an approved access request whose access
+	// expired.
+	StatusExpired Status = "expired"
+)
+
+// String returns a string representation of the status.
+func (s Status) String() string {
+	return string(s)
+}
+
+// An AccessRequest is a request by a user for time-boxed access to a target.
+// Once the request is approved, the user is granted the read and
+// authorize-session actions on the target until the request expires.
+type AccessRequest struct {
+	*store.AccessRequest
+	tableName string `gorm:"-"`
+}
+
+// NewAccessRequest creates a new in memory AccessRequest by the user userId
+// for access to the target targetId of the project projectId for duration.
+// No options are currently supported.
+func NewAccessRequest(ctx context.Context, projectId, targetId, userId, justification string, duration time.Duration, _ ...Option) (*AccessRequest, error) {
+	const op = "accessrequest.NewAccessRequest"
+	switch {
+	case projectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	case targetId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no target id")
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no user id")
+	}
+	if err := validateJustification(ctx, justification); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := validateDuration(ctx, duration); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return &AccessRequest{
+		AccessRequest: &store.AccessRequest{
+			ProjectId:       projectId,
+			TargetId:        targetId,
+			UserId:          userId,
+			Justification:   justification,
+			DurationSeconds: uint32(duration / time.Second),
+		},
+	}, nil
+}
+
+func validateJustification(ctx context.Context, justification string) error {
+	const op = "accessrequest.validateJustification"
+	if strings.TrimSpace(justification) == "" {
+		return errors.New(ctx, errors.InvalidParameter, op, "no justification")
+	}
+	return nil
+}
+
+func validateDuration(ctx context.Context, duration time.Duration) error {
+	const op = "accessrequest.validateDuration"
+	switch {
+	case duration < time.Second:
+		return errors.New(ctx, errors.InvalidParameter, op, "duration must be at least one second")
+	case duration > MaxDuration:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("duration must not be greater than %s", MaxDuration))
+	}
+	return nil
+}
+
+// Grant returns the canonical grant which is materialized for the user of an
+// approved access request. The grant is scoped to the project of the access
+// request.
+func (ar *AccessRequest) Grant() string {
+	return fmt.Sprintf("ids=%s;actions=%s,%s", ar.GetTargetId(), action.Read, action.AuthorizeSession)
+}
+
+func (ar *AccessRequest) clone() *AccessRequest {
+	cp := proto.Clone(ar.AccessRequest)
+	return &AccessRequest{
+		AccessRequest: cp.(*store.AccessRequest),
+	}
+}
+
+// TableName returns the table name for the access request.
+func (ar *AccessRequest) TableName() string {
+	if ar.tableName != "" {
+		return ar.tableName
+	}
+	return "access_request"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (ar *AccessRequest) SetTableName(n string) {
+	ar.tableName = n
+}
+
+func allocAccessRequest() *AccessRequest {
+	return &AccessRequest{
+		AccessRequest: &store.AccessRequest{},
+	}
+}
+
+func newAccessRequestMetadata(ar *AccessRequest, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{ar.GetPublicId()},
+		"resource-type":      []string{"access request"},
+		"op-type":            []string{op.String()},
+	}
+	if ar.ProjectId != "" {
+		metadata["project-id"] = []string{ar.ProjectId}
+	}
+	return metadata
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package accessrequest provides just-in-time access to targets.
+//
+// An access request is a request by a user for time-boxed access to a
+// target, with a justification and the duration of the access. An access
+// request is pending until an approver approves or denies it. Approvers are
+// the users, groups, and managed groups which are granted the approve or deny
+// action on access requests through roles. Users cannot decide on their own
+// access requests.
+//
+// When an access request is approved, a temporary grant of the read and
+// authorize-session actions on the target is materialized for the user. The
+// grant is part of the grants of the user until the access request expires,
+// after the requested duration, or is canceled. Expired access requests are
+// marked as expired, and their grants are deleted, by a job registered with
+// RegisterJobs.
+//
+// Every change to the status of an access request is audited.
+//
+// # Repository
+//
+// A repository provides methods for creating, retrieving, approving,
+// denying, canceling, and expiring access requests. A new repository should
+// be created for each transaction. For example:
+//
+//	var wrapper wrapping.Wrapper
+//	... init wrapper...
+//
+//	// db implements both the reader and writer interfaces.
+//	db, _ := db.Open(db.Postgres, url)
+//
+//	var repo *accessrequest.Repository
+//
+//	repo, _ = accessrequest.NewRepository(ctx, db, db, kms)
+//
+//	ar, _ := accessrequest.NewAccessRequest(ctx, projectId, targetId, userId, "incident 1234", time.Hour)
+//	ar, _ = repo.CreateAccessRequest(ctx, ar)
+//	ar, _, _ = repo.ApproveAccessRequest(ctx, ar.PublicId, ar.Version, approverId)
+package accessrequest
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accessrequests"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+)
+
+// writeAuditEvent writes an audit event for the operation on ar. Every
+// change to the status of an access request, and to the access it grants,
+// is audited, including the changes which are not made through the API.
+func writeAuditEvent(ctx context.Context, op errors.Op, operation string, ar *AccessRequest) {
+	details := &pb.AccessRequest{
+		Id:              ar.GetPublicId(),
+		ScopeId:         ar.GetProjectId(),
+		Scope:           &scopes.ScopeInfo{Id: ar.GetProjectId()},
+		TargetId:        ar.GetTargetId(),
+		UserId:          ar.GetUserId(),
+		Justification:   ar.GetJustification(),
+		DurationSeconds: ar.GetDurationSeconds(),
+		Status:          ar.GetStatus(),
+		ApproverId:      ar.GetApproverId(),
+		DecisionComment: ar.GetDecisionComment(),
+		DecisionTime:    ar.GetDecisionTime().GetTimestamp(),
+		ExpirationTime:  ar.GetExpirationTime().GetTimestamp(),
+		CreatedTime:     ar.GetCreateTime().GetTimestamp(),
+		UpdatedTime:     ar.GetUpdateTime().GetTimestamp(),
+		Version:         ar.GetVersion(),
+	}
+	if err := event.WriteAudit(ctx, event.Op(op), event.WithRequest(&event.Request{
+		Operation: operation,
+		Endpoint:  fmt.Sprintf("access-requests/%s", ar.GetPublicId()),
+		Details:   details,
+	})); err != nil {
+		// error was NOT event'd above...
+		_ = errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("error writing access request audit event"))
+	}
+}
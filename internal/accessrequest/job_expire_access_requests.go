@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	expireAccessRequestsJobName        = "access_request_expire_access_requests"
+	expireAccessRequestsJobRunInterval = time.Minute
+)
+
+// ExpireAccessRequestsJob is the recurring job that expires the approved
+// access requests whose expiration time passed, and deletes the grants
+// which were materialized for them. The grants of an access request are not
+// effective after its expiration time even before the job runs. An audit
+// event is written for every expired access request.
+// The ExpireAccessRequestsJob is not thread safe,
+// an attempt to Run the job concurrently will result in an JobAlreadyRunning error.
+type ExpireAccessRequestsJob struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+
+	running    ua.Bool
+	numExpired int
+}
+
+// newExpireAccessRequestsJob creates a new in-memory ExpireAccessRequestsJob.
+func newExpireAccessRequestsJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms) (*ExpireAccessRequestsJob, error) {
+	const op = "accessrequest.newExpireAccessRequestsJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+	return &ExpireAccessRequestsJob{
+		reader: r,
+		writer: w,
+		kms:    kms,
+	}, nil
+}
+
+// Status returns the current status of the expire access requests job.
+// Total and Completed are the number of access requests expired by the last
+// run.
+func (j *ExpireAccessRequestsJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: j.numExpired,
+		Total:     j.numExpired,
+	}
+}
+
+// Run expires the approved access requests whose expiration time passed.
+// Can not be run in parallel, if Run is invoked while already running an
+// error with code JobAlreadyRunning will be returned.
+func (j *ExpireAccessRequestsJob) Run(ctx context.Context) error {
+	const op = "accessrequest.(ExpireAccessRequestsJob).Run"
+	if !j.running.CAS(j.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer j.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	repo, err := NewRepository(ctx, j.reader, j.writer, j.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	expired, err := repo.ExpireAccessRequests(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	j.numExpired = len(expired)
+	for _, ar := range expired {
+		event.WriteSysEvent(ctx, op, "expired access request",
+			"access request id", ar.GetPublicId(), "target id", ar.GetTargetId(), "user id", ar.GetUserId())
+	}
+	return nil
+}
+
+// NextRunIn returns the default run frequency of the expire access requests
+// job.
+func (j *ExpireAccessRequestsJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return expireAccessRequestsJobRunInterval, nil
+}
+
+// Name is the unique name of the job.
+func (j *ExpireAccessRequestsJob) Name() string {
+	return expireAccessRequestsJobName
+}
+
+// Description is the human readable description of the job.
+func (j *ExpireAccessRequestsJob) Description() string {
+	return "Periodically expires approved access requests and deletes the grants materialized for them."
+}
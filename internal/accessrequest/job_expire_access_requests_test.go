@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExpireAccessRequestsJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+
+	type args struct {
+		r   db.Reader
+		w   db.Writer
+		kms *kms.Kms
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name:        "nil reader",
+			args:        args{w: rw, kms: kmsCache},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil writer",
+			args:        args{r: rw, kms: kmsCache},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil kms",
+			args:        args{r: rw, w: rw},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "valid",
+			args: args{r: rw, w: rw, kms: kmsCache},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			got, err := newExpireAccessRequestsJob(ctx, tt.args.r, tt.args.w, tt.args.kms)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.args.r, got.reader)
+			assert.Equal(tt.args.w, got.writer)
+			assert.Equal(tt.args.kms, got.kms)
+		})
+	}
+}
+
+func TestExpireAccessRequestsJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	requester := iam.TestUser(t, iamRepo, "global")
+	approver := iam.TestUser(t, iamRepo, "global")
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	// approve approves a new access request. If expired is set, the access
+	// it grants is marked as having expired an hour ago.
+	approve := func(t *testing.T, expired bool) *AccessRequest {
+		t.Helper()
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		ar, _, err := repo.ApproveAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion(), approver.GetPublicId())
+		require.NoError(t, err)
+		if expired {
+			_, err := rw.Exec(ctx, `
+update access_request
+   set decision_time   = now() - interval '2 hours',
+       expiration_time = now() - interval '1 hour'
+ where public_id = ?`, []any{ar.GetPublicId()})
+			require.NoError(t, err)
+		}
+		return ar
+	}
+	status := func(t *testing.T, id string) string {
+		t.Helper()
+		ar, err := repo.LookupAccessRequest(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, ar)
+		return ar.GetStatus()
+	}
+
+	expiredAr := approve(t, true)
+	activeAr := approve(t, false)
+	pendingAr := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+
+	job, err := newExpireAccessRequestsJob(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	sched := scheduler.TestScheduler(t, conn, wrapper)
+	require.NoError(t, sched.RegisterJob(ctx, job))
+
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numExpired)
+	assert.Equal(t, StatusExpired.String(), status(t, expiredAr.GetPublicId()))
+	assert.Equal(t, StatusApproved.String(), status(t, activeAr.GetPublicId()))
+	assert.Equal(t, StatusPending.String(), status(t, pendingAr.GetPublicId()))
+
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.numExpired)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/accessrequest/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithComment", func(t *testing.T) {
+		opts := getOpts(WithComment("approved for the incident"))
+		testOpts := getDefaultOptions()
+		testOpts.withComment = "approved for the incident"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("areq_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "areq_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		updateTime := time.Now()
+		createTime := time.Now()
+		opts := getOpts(WithStartPageAfterItem(&AccessRequest{
+			AccessRequest: &store.AccessRequest{
+				PublicId:   "areq_1234567890",
+				CreateTime: timestamp.New(createTime),
+				UpdateTime: timestamp.New(updateTime),
+			},
+		}))
+		assert.Equal(opts.withStartPageAfterItem.GetPublicId(), "areq_1234567890")
+		assert.Equal(opts.withStartPageAfterItem.GetUpdateTime(), timestamp.New(updateTime))
+		assert.Equal(opts.withStartPageAfterItem.GetCreateTime(), timestamp.New(createTime))
+	})
+}
@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+func newAccessRequestId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.AccessRequestPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "accessrequest.newAccessRequestId")
+	}
+	return id, nil
+}
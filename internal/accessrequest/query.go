@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+const (
+	approveAccessRequestQuery = `
+		update access_request
+		   set status           = 'approved',
+		       approver_id      = @approver_id,
+		       decision_comment = nullif(@decision_comment, ''),
+		       decision_time    = now(),
+		       expiration_time  = now() + make_interval(secs => duration_seconds)
+		 where public_id = @public_id
+		   and version   = @version
+		   and status    = 'pending'
+		returning *;
+	`
+
+	insertAccessRequestGrantQuery = `
+		insert into access_request_grant
+		  (access_request_id, user_id, project_id, canonical_grant, expiration_time)
+		select public_id, user_id, project_id, @canonical_grant, expiration_time
+		  from access_request
+		 where public_id = @public_id;
+	`
+
+	denyAccessRequestQuery = `
+		update access_request
+		   set status           = 'denied',
+		       approver_id      = @approver_id,
+		       decision_comment = nullif(@decision_comment, ''),
+		       decision_time    = now()
+		 where public_id = @public_id
+		   and version   = @version
+		   and status    = 'pending'
+		returning *;
+	`
+
+	cancelAccessRequestQuery = `
+		update access_request
+		   set status = 'canceled'
+		 where public_id = @public_id
+		   and version   = @version
+		   and status in ('pending', 'approved')
+		returning *;
+	`
+
+	deleteAccessRequestGrantQuery = `
+		delete from access_request_grant
+		 where access_request_id = @public_id;
+	`
+
+	expireAccessRequestsQuery = `
+		with
+		expired as (
+		  update access_request
+		     set status = 'expired'
+		   where status = 'approved'
+		     and expiration_time <= now()
+		  returning *
+		),
+		deleted_grants as (
+		  delete from access_request_grant
+		   where access_request_id in (select public_id from expired)
+		)
+		select * from expired;
+	`
+)
@@ -0,0 +1,395 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/accessrequest/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAccessRequest inserts ar into the repository and returns a new
+// AccessRequest containing the access request's PublicId. ar is not changed.
+// ar must contain a valid ProjectId, TargetId, UserId, Justification and
+// DurationSeconds. ar must not contain a PublicId. The PublicId is generated
+// and assigned by this method. WithPublicId is the only valid option.
+//
+// The status of the new access request is pending. Both ar.CreateTime and
+// ar.UpdateTime are ignored.
+func (r *Repository) CreateAccessRequest(ctx context.Context, ar *AccessRequest, opt ...Option) (*AccessRequest, error) {
+	const op = "accessrequest.(Repository).CreateAccessRequest"
+	switch {
+	case ar == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil AccessRequest")
+	case ar.AccessRequest == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded AccessRequest")
+	case ar.ProjectId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	case ar.TargetId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no target id")
+	case ar.UserId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no user id")
+	case ar.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	// Only the fields set by NewAccessRequest are inserted, so a new access
+	// request is always pending.
+	ar, err := NewAccessRequest(ctx, ar.ProjectId, ar.TargetId, ar.UserId, ar.Justification, time.Duration(ar.DurationSeconds)*time.Second)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, globals.AccessRequestPrefix+"_") {
+			return nil, errors.New(ctx,
+				errors.InvalidPublicId,
+				op,
+				fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, globals.AccessRequestPrefix),
+			)
+		}
+		ar.PublicId = opts.withPublicId
+	} else {
+		id, err := newAccessRequestId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		ar.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, ar.ProjectId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAccessRequestMetadata(ar, oplog.OpType_OP_TYPE_CREATE)
+
+	var newAccessRequest *AccessRequest
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAccessRequest = ar.clone()
+			if err := w.Create(ctx, newAccessRequest, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("for target: %s", ar.TargetId)))
+	}
+	writeAuditEvent(ctx, op, "create", newAccessRequest)
+	return newAccessRequest, nil
+}
+
+// LookupAccessRequest returns the AccessRequest for id. Returns nil, nil if
+// no AccessRequest is found for id.
+func (r *Repository) LookupAccessRequest(ctx context.Context, id string, _ ...Option) (*AccessRequest, error) {
+	const op = "accessrequest.(Repository).LookupAccessRequest"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	ar := allocAccessRequest()
+	ar.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, ar); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	return ar, nil
+}
+
+// ListAccessRequests returns a slice of AccessRequests for the project IDs
+// ordered by create time and public id. WithLimit and WithStartPageAfterItem
+// are the only options supported.
+func (r *Repository) ListAccessRequests(ctx context.Context, projectIds []string, opt ...Option) ([]*AccessRequest, error) {
+	const op = "accessrequest.(Repository).ListAccessRequests"
+	if len(projectIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no project id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var requests []*AccessRequest
+	where, args := "project_id in (?)", []any{projectIds}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &requests, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return requests, nil
+}
+
+// ApproveAccessRequest approves the pending access request id on behalf of
+// the user approverId, and grants the user of the access request the read
+// and authorize-session actions on its target until the access request
+// expires. The access request expires after the duration which was
+// requested, counted from the approval. It returns the updated
+// AccessRequest and a count of the number of records updated. The count is
+// zero if version doesn't match the version of the access request.
+//
+// Users cannot approve their own access requests. WithComment is the only
+// valid option.
+func (r *Repository) ApproveAccessRequest(ctx context.Context, id string, version uint32, approverId string, opt ...Option) (*AccessRequest, int, error) {
+	const op = "accessrequest.(Repository).ApproveAccessRequest"
+	switch {
+	case id == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no version")
+	case approverId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no approver id")
+	}
+	opts := getOpts(opt...)
+
+	var approved *AccessRequest
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if err := checkDecision(ctx, reader, id, approverId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			var err error
+			approved, err = updateAccessRequest(ctx, w, approveAccessRequestQuery,
+				sql.Named("public_id", id),
+				sql.Named("version", version),
+				sql.Named("approver_id", approverId),
+				sql.Named("decision_comment", opts.withComment),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if approved == nil {
+				return nil
+			}
+			rowsInserted, err := w.Exec(ctx, insertAccessRequestGrantQuery, []any{
+				sql.Named("public_id", id),
+				sql.Named("canonical_grant", approved.Grant()),
+			})
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to insert access request grant"))
+			}
+			if rowsInserted != 1 {
+				return errors.New(ctx, errors.UnexpectedRowsAffected, op, fmt.Sprintf("inserted %d access request grants", rowsInserted))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", id)))
+	}
+	if approved == nil {
+		return nil, db.NoRowsAffected, nil
+	}
+	writeAuditEvent(ctx, op, "approve", approved)
+	return approved, 1, nil
+}
+
+// DenyAccessRequest denies the pending access request id on behalf of the
+// user approverId. It returns the updated AccessRequest and a count of the
+// number of records updated. The count is zero if version doesn't match the
+// version of the access request.
+//
+// Users cannot deny their own access requests. WithComment is the only valid
+// option.
+func (r *Repository) DenyAccessRequest(ctx context.Context, id string, version uint32, approverId string, opt ...Option) (*AccessRequest, int, error) {
+	const op = "accessrequest.(Repository).DenyAccessRequest"
+	switch {
+	case id == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no version")
+	case approverId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no approver id")
+	}
+	opts := getOpts(opt...)
+
+	var denied *AccessRequest
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if err := checkDecision(ctx, reader, id, approverId); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			var err error
+			denied, err = updateAccessRequest(ctx, w, denyAccessRequestQuery,
+				sql.Named("public_id", id),
+				sql.Named("version", version),
+				sql.Named("approver_id", approverId),
+				sql.Named("decision_comment", opts.withComment),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", id)))
+	}
+	if denied == nil {
+		return nil, db.NoRowsAffected, nil
+	}
+	writeAuditEvent(ctx, op, "deny", denied)
+	return denied, 1, nil
+}
+
+// CancelAccessRequest cancels the pending or approved access request id. The
+// access granted by an approved access request is revoked. It returns the
+// updated AccessRequest and a count of the number of records updated. The
+// count is zero if version doesn't match the version of the access request.
+func (r *Repository) CancelAccessRequest(ctx context.Context, id string, version uint32, _ ...Option) (*AccessRequest, int, error) {
+	const op = "accessrequest.(Repository).CancelAccessRequest"
+	switch {
+	case id == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case version == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no version")
+	}
+
+	var canceled *AccessRequest
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current := allocAccessRequest()
+			current.PublicId = id
+			if err := reader.LookupByPublicId(ctx, current); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if s := Status(current.Status); s != StatusPending && s != StatusApproved {
+				return errors.New(ctx, errors.Conflict, op, fmt.Sprintf("access request is %s", s))
+			}
+			var err error
+			canceled, err = updateAccessRequest(ctx, w, cancelAccessRequestQuery,
+				sql.Named("public_id", id),
+				sql.Named("version", version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if canceled == nil {
+				return nil
+			}
+			if _, err := w.Exec(ctx, deleteAccessRequestGrantQuery, []any{sql.Named("public_id", id)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete access request grant"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", id)))
+	}
+	if canceled == nil {
+		return nil, db.NoRowsAffected, nil
+	}
+	writeAuditEvent(ctx, op, "cancel", canceled)
+	return canceled, 1, nil
+}
+
+// ExpireAccessRequests expires the approved access requests whose expiration
+// time passed, revokes the access they granted, and returns them.
+func (r *Repository) ExpireAccessRequests(ctx context.Context) ([]*AccessRequest, error) {
+	const op = "accessrequest.(Repository).ExpireAccessRequests"
+	var expired []*AccessRequest
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rows, err := w.Query(ctx, expireAccessRequestsQuery, nil)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			expired = nil
+			for rows.Next() {
+				ar := &store.AccessRequest{}
+				if err := reader.ScanRows(ctx, rows, ar); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+				}
+				expired = append(expired, &AccessRequest{AccessRequest: ar})
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for _, ar := range expired {
+		writeAuditEvent(ctx, op, "expire", ar)
+	}
+	return expired, nil
+}
+
+// checkDecision checks that the access request id is pending and that
+// approverId is not the user who requested access.
+func checkDecision(ctx context.Context, reader db.Reader, id, approverId string) error {
+	const op = "accessrequest.checkDecision"
+	current := allocAccessRequest()
+	current.PublicId = id
+	if err := reader.LookupByPublicId(ctx, current); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case current.UserId == approverId:
+		return errors.New(ctx, errors.Forbidden, op, "users cannot decide on their own access requests")
+	case Status(current.Status) != StatusPending:
+		return errors.New(ctx, errors.Conflict, op, fmt.Sprintf("access request is %s", current.Status))
+	}
+	return nil
+}
+
+// updateAccessRequest runs query, which updates a single access request and
+// returns it. It returns nil if no access request was updated.
+func updateAccessRequest(ctx context.Context, w db.Writer, query string, args ...any) (*AccessRequest, error) {
+	const op = "accessrequest.updateAccessRequest"
+	rows, err := w.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var updated *AccessRequest
+	for rows.Next() {
+		if updated != nil {
+			return nil, errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+		}
+		ar := &store.AccessRequest{}
+		if err := w.ScanRows(ctx, rows, ar); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		updated = &AccessRequest{AccessRequest: ar}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return updated, nil
+}
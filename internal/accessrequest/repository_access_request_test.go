@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAccessRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	user := iam.TestUser(t, iamRepo, "global")
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		in          *AccessRequest
+		wantErrCode errors.Code
+	}{
+		{
+			name:        "nil access request",
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "public id set",
+			in: func() *AccessRequest {
+				ar, err := NewAccessRequest(ctx, prj.GetPublicId(), tar.GetPublicId(), user.GetPublicId(), "on call", time.Hour)
+				require.NoError(t, err)
+				ar.PublicId = "areq_1234567890"
+				return ar
+			}(),
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name: "target not in project",
+			in: func() *AccessRequest {
+				_, otherPrj := iam.TestScopes(t, iamRepo)
+				ar, err := NewAccessRequest(ctx, otherPrj.GetPublicId(), tar.GetPublicId(), user.GetPublicId(), "on call", time.Hour)
+				require.NoError(t, err)
+				return ar
+			}(),
+			wantErrCode: errors.NotSpecificIntegrity,
+		},
+		{
+			name: "valid",
+			in: func() *AccessRequest {
+				ar, err := NewAccessRequest(ctx, prj.GetPublicId(), tar.GetPublicId(), user.GetPublicId(), "on call", time.Hour)
+				require.NoError(t, err)
+				return ar
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.CreateAccessRequest(ctx, tt.in)
+			if tt.wantErrCode != 0 {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.NotEmpty(got.GetPublicId())
+			assert.Equal(StatusPending.String(), got.GetStatus())
+			assert.Equal(uint32(1), got.GetVersion())
+			assert.Empty(got.GetApproverId())
+			assert.Nil(got.GetExpirationTime())
+
+			found, err := repo.LookupAccessRequest(ctx, got.GetPublicId())
+			require.NoError(err)
+			assert.Equal(got.GetPublicId(), found.GetPublicId())
+			assert.Equal(tt.in.GetJustification(), found.GetJustification())
+		})
+	}
+}
+
+func TestRepository_ApproveAccessRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	requester := iam.TestUser(t, iamRepo, "global")
+	approver := iam.TestUser(t, iamRepo, "global")
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("self approval", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		got, n, err := repo.ApproveAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion(), requester.GetPublicId())
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.Forbidden), err), "Unexpected error %s", err)
+		assert.Nil(got)
+		assert.Zero(n)
+	})
+	t.Run("wrong version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		got, n, err := repo.ApproveAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion()+1, approver.GetPublicId())
+		require.NoError(err)
+		assert.Nil(got)
+		assert.Zero(n)
+	})
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		got, n, err := repo.ApproveAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion(), approver.GetPublicId(), WithComment("approved"))
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(1, n)
+		assert.Equal(StatusApproved.String(), got.GetStatus())
+		assert.Equal(approver.GetPublicId(), got.GetApproverId())
+		assert.Equal("approved", got.GetDecisionComment())
+		require.NotNil(got.GetDecisionTime())
+		require.NotNil(got.GetExpirationTime())
+		assert.Equal(time.Hour, got.GetExpirationTime().AsTime().Sub(got.GetDecisionTime().AsTime()))
+
+		grants, err := iamRepo.GrantsForUser(ctx, requester.GetPublicId())
+		require.NoError(err)
+		assert.Contains(grants, perms.GrantTuple{
+			RoleId:  got.GetPublicId(),
+			ScopeId: prj.GetPublicId(),
+			Grant:   got.Grant(),
+		})
+
+		// A decided access request cannot be decided on again.
+		_, _, err = repo.DenyAccessRequest(ctx, got.GetPublicId(), got.GetVersion(), approver.GetPublicId())
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.Conflict), err), "Unexpected error %s", err)
+	})
+}
+
+func TestRepository_DenyAccessRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	requester := iam.TestUser(t, iamRepo, "global")
+	approver := iam.TestUser(t, iamRepo, "global")
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+	got, n, err := repo.DenyAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion(), approver.GetPublicId(), WithComment("not on call"))
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(1, n)
+	assert.Equal(StatusDenied.String(), got.GetStatus())
+	assert.Equal("not on call", got.GetDecisionComment())
+	assert.Nil(got.GetExpirationTime())
+
+	grants, err := iamRepo.GrantsForUser(ctx, requester.GetPublicId())
+	require.NoError(err)
+	for _, g := range grants {
+		assert.NotEqual(ar.GetPublicId(), g.RoleId)
+	}
+}
+
+func TestRepository_CancelAccessRequest(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	requester := iam.TestUser(t, iamRepo, "global")
+	approver := iam.TestUser(t, iamRepo, "global")
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("pending", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		got, n, err := repo.CancelAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion())
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal(StatusCanceled.String(), got.GetStatus())
+
+		_, _, err = repo.CancelAccessRequest(ctx, got.GetPublicId(), got.GetVersion())
+		require.Error(err)
+		assert.Truef(errors.Match(errors.T(errors.Conflict), err), "Unexpected error %s", err)
+	})
+	t.Run("approved", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+		approved, _, err := repo.ApproveAccessRequest(ctx, ar.GetPublicId(), ar.GetVersion(), approver.GetPublicId())
+		require.NoError(err)
+		got, n, err := repo.CancelAccessRequest(ctx, approved.GetPublicId(), approved.GetVersion())
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal(StatusCanceled.String(), got.GetStatus())
+
+		grants, err := iamRepo.GrantsForUser(ctx, requester.GetPublicId())
+		require.NoError(err)
+		for _, g := range grants {
+			assert.NotEqual(ar.GetPublicId(), g.RoleId)
+		}
+	})
+}
+
+func TestRepository_ExpireAccessRequests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	requester := iam.TestUser(t, iamRepo, "global")
+	approver := iam.TestUser(t, iamRepo, "global")
+
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	assert, require := assert.New(t), require.New(t)
+
+	expiring := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+	expiring, _, err = repo.ApproveAccessRequest(ctx, expiring.GetPublicId(), expiring.GetVersion(), approver.GetPublicId())
+	require.NoError(err)
+	current := TestAccessRequest(t, rw, prj.GetPublicId(), tar.GetPublicId(), requester.GetPublicId())
+	current, _, err = repo.ApproveAccessRequest(ctx, current.GetPublicId(), current.GetVersion(), approver.GetPublicId())
+	require.NoError(err)
+
+	// Move the access of the first access request into the past.
+	_, err = rw.Exec(ctx,
+		"update access_request set decision_time = now() - interval '2 hours', expiration_time = now() - interval '1 hour' where public_id = ?",
+		[]any{expiring.GetPublicId()})
+	require.NoError(err)
+	// The expiration time of a grant is immutable, so the grant is replaced.
+	_, err = rw.Exec(ctx,
+		"delete from access_request_grant where access_request_id = ?",
+		[]any{expiring.GetPublicId()})
+	require.NoError(err)
+	_, err = rw.Exec(ctx, `
+insert into access_request_grant
+  (access_request_id, user_id, project_id, canonical_grant, expiration_time)
+values
+  (?, ?, ?, ?, now() - interval '1 hour')`,
+		[]any{expiring.GetPublicId(), requester.GetPublicId(), prj.GetPublicId(), expiring.Grant()})
+	require.NoError(err)
+
+	// The grant of the expired access request is not effective, even before
+	// it is deleted.
+	grants, err := iamRepo.GrantsForUser(ctx, requester.GetPublicId())
+	require.NoError(err)
+	var roleIds []string
+	for _, g := range grants {
+		roleIds = append(roleIds, g.RoleId)
+	}
+	assert.NotContains(roleIds, expiring.GetPublicId())
+	assert.Contains(roleIds, current.GetPublicId())
+
+	expired, err := repo.ExpireAccessRequests(ctx)
+	require.NoError(err)
+	require.Len(expired, 1)
+	assert.Equal(expiring.GetPublicId(), expired[0].GetPublicId())
+	assert.Equal(StatusExpired.String(), expired[0].GetStatus())
+
+	var count int
+	rows, err := rw.Query(ctx, "select count(*) from access_request_grant where access_request_id = ?", []any{expiring.GetPublicId()})
+	require.NoError(err)
+	defer rows.Close()
+	for rows.Next() {
+		require.NoError(rows.Scan(&count))
+	}
+	assert.Zero(count)
+
+	expired, err = repo.ExpireAccessRequests(ctx)
+	require.NoError(err)
+	assert.Empty(expired)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAccessRequest creates a pending access request by userId for access
+// to the target targetId of the project projectId for an hour. If any errors
+// are encountered during the creation of the access request, the test will
+// fail.
+func TestAccessRequest(t testing.TB, rw *db.Db, projectId, targetId, userId string) *AccessRequest {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+
+	ar, err := NewAccessRequest(ctx, projectId, targetId, userId, "test justification", time.Hour)
+	require.NoError(err)
+	ar.PublicId, err = newAccessRequestId(ctx)
+	require.NoError(err)
+	require.NoError(rw.Create(ctx, ar))
+	return ar
+}
@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package alias contains the types shared by the different alias subtypes.
+package alias
+
+// Domain defines the domain for the alias package
+const Domain = "alias"
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/alias/target/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An Alias is a globally unique value which resolves to a target and,
+// optionally, a host. It is owned by the global scope.
+type Alias struct {
+	*store.Alias
+	tableName string `gorm:"-"`
+}
+
+// NewAlias creates a new in memory Alias assigned to scopeId with the
+// provided value. Name, description, destination id, and host id are the
+// only valid options. All other options are ignored.
+func NewAlias(ctx context.Context, scopeId, value string, opt ...Option) (*Alias, error) {
+	const op = "target.NewAlias"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case value == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no value")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withHostId != "" && opts.withDestinationId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "host id requires a destination id")
+	}
+	a := &Alias{
+		Alias: &store.Alias{
+			ScopeId:       scopeId,
+			Value:         value,
+			Name:          opts.withName,
+			Description:   opts.withDescription,
+			DestinationId: opts.withDestinationId,
+			HostId:        opts.withHostId,
+		},
+	}
+	return a, nil
+}
+
+func (a *Alias) clone() *Alias {
+	cp := proto.Clone(a.Alias)
+	return &Alias{
+		Alias: cp.(*store.Alias),
+	}
+}
+
+// TableName returns the table name for the target alias.
+func (a *Alias) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return "alias_target"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (a *Alias) SetTableName(n string) {
+	a.tableName = n
+}
+
+func allocAlias() *Alias {
+	return &Alias{
+		Alias: &store.Alias{},
+	}
+}
+
+func newAliasMetadata(a *Alias, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{a.GetPublicId()},
+		"resource-type":      []string{"target alias"},
+		"op-type":            []string{op.String()},
+	}
+	if a.ScopeId != "" {
+		metadata["scope-id"] = []string{a.ScopeId}
+	}
+	return metadata
+}
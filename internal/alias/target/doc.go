@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package target provides an alias which resolves to a target and,
+// optionally, a host of that target.
+//
+// A target alias has a globally unique, dns-like value which can be used in
+// place of a target's public id when authorizing a session. Target aliases
+// are owned by the global scope. Deleting the target an alias points at does
+// not delete the alias; its destination and host are cleared instead.
+//
+// # Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting target aliases. A new repository should be created for each
+// transaction. For example:
+//
+//	var wrapper wrapping.Wrapper
+//	... init wrapper...
+//
+//	// db implements both the reader and writer interfaces.
+//	db, _ := db.Open(db.Postgres, url)
+//
+//	var repo *target.Repository
+//
+//	repo, _ = target.NewRepository(ctx, db, db, kms)
+//
+//	a, _ := target.NewAlias(ctx, "global", "prod.db.example.com", target.WithDestinationId(targetId))
+//	a, _ = repo.CreateAlias(ctx, a)
+//
+//	a, _ = repo.LookupAliasByValue(ctx, "prod.db.example.com")
+package target
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/alias/target/store"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDestinationId", func(t *testing.T) {
+		opts := getOpts(WithDestinationId("ttcp_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withDestinationId = "ttcp_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithHostId", func(t *testing.T) {
+		opts := getOpts(WithHostId("hst_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withHostId = "hst_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("alt_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "alt_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		updateTime := time.Now()
+		createTime := time.Now()
+		opts := getOpts(WithStartPageAfterItem(&Alias{
+			Alias: &store.Alias{
+				PublicId:   "alt_1234567890",
+				CreateTime: timestamp.New(createTime),
+				UpdateTime: timestamp.New(updateTime),
+			},
+		}))
+		assert.Equal(opts.withStartPageAfterItem.GetPublicId(), "alt_1234567890")
+		assert.Equal(opts.withStartPageAfterItem.GetUpdateTime(), timestamp.New(updateTime))
+		assert.Equal(opts.withStartPageAfterItem.GetCreateTime(), timestamp.New(createTime))
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/alias"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+func init() {
+	globals.RegisterPrefixToResourceInfo(globals.TargetAliasPrefix, resource.Alias, alias.Domain, Subtype)
+}
+
+// PublicId prefixes for the resources in the target alias package.
+const (
+	Subtype = globals.Subtype("target")
+)
+
+func newAliasId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.TargetAliasPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "target.newAliasId")
+	}
+	return id, nil
+}
@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreateAlias inserts a into the repository and returns a new Alias
+// containing the alias's PublicId. a is not changed. a must contain a valid
+// ScopeId and Value. a must not contain a PublicId. The PublicId is
+// generated and assigned by this method. WithPublicId is the only valid
+// option.
+//
+// a.Value must be unique across all aliases. If a.Name is set, it must be
+// unique within a.ScopeId. If a.HostId is set, a.DestinationId must also be
+// set.
+//
+// Both a.CreateTime and a.UpdateTime are ignored.
+func (r *Repository) CreateAlias(ctx context.Context, a *Alias, opt ...Option) (*Alias, error) {
+	const op = "target.(Repository).CreateAlias"
+	switch {
+	case a == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case a.Value == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no value")
+	case a.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	case a.HostId != "" && a.DestinationId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "host id requires a destination id")
+	}
+	a = a.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, globals.TargetAliasPrefix+"_") {
+			return nil, errors.New(ctx,
+				errors.InvalidPublicId,
+				op,
+				fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, globals.TargetAliasPrefix),
+			)
+		}
+		a.PublicId = opts.withPublicId
+	} else {
+		id, err := newAliasId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		a.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_CREATE)
+
+	var newAlias *Alias
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newAlias = a.clone()
+			if err := w.Create(ctx, newAlias, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("alias value %s or name %s already exists", a.Value, a.Name)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", a.ScopeId)))
+	}
+	return newAlias, nil
+}
+
+// UpdateAlias updates the repository entry for a.PublicId with the values in
+// a for the fields listed in fieldMask. It returns a new Alias containing the
+// updated values and a count of the number of records updated. a is not
+// changed.
+//
+// a must contain a valid PublicId and ScopeId. Only a.Name, a.Description,
+// a.Value, a.DestinationId, and a.HostId can be updated. a.Value cannot be
+// unset. Unsetting a.DestinationId also unsets a.HostId.
+//
+// An attribute of a will be set to NULL in the database if the attribute
+// in a is the zero value and it is included in fieldMask.
+func (r *Repository) UpdateAlias(ctx context.Context, a *Alias, version uint32, fieldMask []string, opt ...Option) (*Alias, int, error) {
+	const op = "target.(Repository).UpdateAlias"
+	switch {
+	case a == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Alias")
+	case a.Alias == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Alias")
+	case a.PublicId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case a.ScopeId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case len(fieldMask) == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	a = a.clone()
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && a.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && a.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && a.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && a.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("value", f) && a.Value == "":
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "value cannot be unset")
+		case strings.EqualFold("value", f) && a.Value != "":
+			dbMask = append(dbMask, "value")
+		case strings.EqualFold("destination_id", f) && a.DestinationId == "":
+			nullFields = append(nullFields, "destination_id")
+		case strings.EqualFold("destination_id", f) && a.DestinationId != "":
+			dbMask = append(dbMask, "destination_id")
+		case strings.EqualFold("host_id", f) && a.HostId == "":
+			nullFields = append(nullFields, "host_id")
+		case strings.EqualFold("host_id", f) && a.HostId != "":
+			dbMask = append(dbMask, "host_id")
+
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+	if slices.Contains(nullFields, "destination_id") {
+		// An alias without a destination cannot have a host.
+		if slices.Contains(dbMask, "host_id") {
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "host id requires a destination id")
+		}
+		if !slices.Contains(nullFields, "host_id") {
+			nullFields = append(nullFields, "host_id")
+		}
+		a.HostId = ""
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedAlias *Alias
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			returnedAlias = a.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedAlias,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if rowsUpdated == 0 {
+				return nil
+			}
+			// Read the alias back so fields which were not in the field mask
+			// reflect what is stored.
+			returnedAlias = allocAlias()
+			returnedAlias.PublicId = a.PublicId
+			if err := reader.LookupByPublicId(ctx, returnedAlias); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s: alias value %s or name %s already exists", a.PublicId, a.Value, a.Name)))
+		}
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", a.PublicId)))
+	}
+
+	return returnedAlias, rowsUpdated, nil
+}
+
+// LookupAlias returns the Alias for id. Returns nil, nil if no Alias is
+// found for id.
+func (r *Repository) LookupAlias(ctx context.Context, id string, opt ...Option) (*Alias, error) {
+	const op = "target.(Repository).LookupAlias"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	a := allocAlias()
+	a.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	return a, nil
+}
+
+// LookupAliasByValue returns the Alias with the provided value. Returns nil,
+// nil if no Alias is found for value.
+func (r *Repository) LookupAliasByValue(ctx context.Context, value string, opt ...Option) (*Alias, error) {
+	const op = "target.(Repository).LookupAliasByValue"
+	if value == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no value")
+	}
+	a := allocAlias()
+	if err := r.reader.LookupWhere(ctx, a, "value = ?", []any{value}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", value)))
+	}
+	return a, nil
+}
+
+// ListAliases returns a slice of Aliases for the scope IDs ordered by create
+// time and public id. WithLimit and WithStartPageAfterItem are the only
+// options supported.
+func (r *Repository) ListAliases(ctx context.Context, scopeIds []string, opt ...Option) ([]*Alias, error) {
+	const op = "target.(Repository).ListAliases"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var aliases []*Alias
+	where, args := "scope_id in (?)", []any{scopeIds}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &aliases, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return aliases, nil
+}
+
+// DeleteAlias deletes id from the repository returning a count of the
+// number of records deleted.
+func (r *Repository) DeleteAlias(ctx context.Context, id string, opt ...Option) (int, error) {
+	const op = "target.(Repository).DeleteAlias"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+
+	a := allocAlias()
+	a.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	if a.ScopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, a.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newAliasMetadata(a, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteAlias := a.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deleteAlias, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", a.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
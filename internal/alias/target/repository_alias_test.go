@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateAlias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	cat := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, cat.GetPublicId(), 1)[0]
+
+	tests := []struct {
+		name      string
+		in        *Alias
+		opts      []Option
+		wantIsErr errors.Code
+	}{
+		{
+			name:      "nil-alias",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-embedded-alias",
+			in:        &Alias{},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "no-value",
+			in:        &Alias{Alias: allocAlias().Alias},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "valid-no-options",
+			in:   newTestAlias(t, "valid.no.options"),
+		},
+		{
+			name: "valid-with-name-and-description",
+			in:   newTestAlias(t, "valid.with.name", WithName("name"), WithDescription("description")),
+		},
+		{
+			name: "valid-with-destination",
+			in:   newTestAlias(t, "valid.with.destination", WithDestinationId(tar.GetPublicId())),
+		},
+		{
+			name: "valid-with-destination-and-host",
+			in:   newTestAlias(t, "valid.with.host", WithDestinationId(tar.GetPublicId()), WithHostId(h.GetPublicId())),
+		},
+		{
+			name: "host-without-destination",
+			in: func() *Alias {
+				a := newTestAlias(t, "host.without.destination")
+				a.HostId = h.GetPublicId()
+				return a
+			}(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "invalid-value",
+			in:        newTestAlias(t, "Invalid_Value"),
+			wantIsErr: errors.CheckConstraint,
+		},
+		{
+			name:      "wrong-public-id-prefix",
+			in:        newTestAlias(t, "wrong.prefix"),
+			opts:      []Option{WithPublicId("ttcp_1234567890")},
+			wantIsErr: errors.InvalidPublicId,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := repo.CreateAlias(ctx, tt.in, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "want err: %q got: %q", tt.wantIsErr, err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(tt.in.PublicId)
+			assert.NotSame(tt.in, got)
+			assert.True(strings.HasPrefix(got.GetPublicId(), "alt_"))
+			assert.Equal(tt.in.Value, got.Value)
+			assert.Equal(tt.in.Name, got.Name)
+			assert.Equal(tt.in.Description, got.Description)
+			assert.Equal(tt.in.DestinationId, got.DestinationId)
+			assert.Equal(tt.in.HostId, got.HostId)
+			assert.Equal(got.CreateTime, got.UpdateTime)
+		})
+	}
+
+	t.Run("invalid-duplicate-values", func(t *testing.T) {
+		assert := assert.New(t)
+		in := newTestAlias(t, "duplicate.value")
+		got, err := repo.CreateAlias(ctx, in)
+		require.NoError(t, err)
+		assert.NotNil(got)
+
+		got2, err := repo.CreateAlias(ctx, in)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "want err code: %v got err: %v", errors.NotUnique, err)
+		assert.Nil(got2)
+	})
+}
+
+func TestRepository_UpdateAlias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+	cat := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, cat.GetPublicId(), 1)[0]
+
+	t.Run("value-and-name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, rw, "update.value", WithName("name"))
+		in := a.clone()
+		in.Value = "updated.value"
+		in.Name = ""
+		got, n, err := repo.UpdateAlias(ctx, in, a.GetVersion(), []string{"value", "name"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Equal("updated.value", got.GetValue())
+		assert.Empty(got.GetName())
+		assert.Equal(a.GetVersion()+1, got.GetVersion())
+
+		found, err := repo.LookupAliasByValue(ctx, "updated.value")
+		require.NoError(err)
+		assert.Equal(a.GetPublicId(), found.GetPublicId())
+	})
+
+	t.Run("clearing-destination-clears-host", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, rw, "clear.destination", WithDestinationId(tar.GetPublicId()), WithHostId(h.GetPublicId()))
+		in := a.clone()
+		in.DestinationId = ""
+		got, n, err := repo.UpdateAlias(ctx, in, a.GetVersion(), []string{"destination_id"})
+		require.NoError(err)
+		assert.Equal(1, n)
+		assert.Empty(got.GetDestinationId())
+		assert.Empty(got.GetHostId())
+	})
+
+	t.Run("host-without-destination", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, rw, "host.without.destination", WithDestinationId(tar.GetPublicId()))
+		in := a.clone()
+		in.DestinationId = ""
+		in.HostId = h.GetPublicId()
+		got, n, err := repo.UpdateAlias(ctx, in, a.GetVersion(), []string{"destination_id", "host_id"})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+		assert.Zero(n)
+		assert.Nil(got)
+	})
+
+	t.Run("unset-value", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, rw, "unset.value")
+		in := a.clone()
+		in.Value = ""
+		_, _, err := repo.UpdateAlias(ctx, in, a.GetVersion(), []string{"value"})
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+	})
+
+	t.Run("invalid-field-mask", func(t *testing.T) {
+		assert := assert.New(t)
+		a := TestAlias(t, rw, "invalid.field.mask")
+		_, _, err := repo.UpdateAlias(ctx, a.clone(), a.GetVersion(), []string{"scope_id"})
+		assert.Truef(errors.Match(errors.T(errors.InvalidFieldMask), err), "want err code: %v got err: %v", errors.InvalidFieldMask, err)
+	})
+
+	t.Run("wrong-version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestAlias(t, rw, "wrong.version")
+		in := a.clone()
+		in.Name = "new name"
+		got, n, err := repo.UpdateAlias(ctx, in, a.GetVersion()+1, []string{"name"})
+		require.NoError(err)
+		assert.Zero(n)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_LookupAlias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	a := TestAlias(t, rw, "lookup.alias")
+	badId, err := newAliasId(ctx)
+	require.NoError(t, err)
+
+	t.Run("by-id", func(t *testing.T) {
+		assert := assert.New(t)
+		got, err := repo.LookupAlias(ctx, a.GetPublicId())
+		assert.NoError(err)
+		assert.Equal(a.GetValue(), got.GetValue())
+
+		got, err = repo.LookupAlias(ctx, badId)
+		assert.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAlias(ctx, "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+	})
+
+	t.Run("by-value", func(t *testing.T) {
+		assert := assert.New(t)
+		got, err := repo.LookupAliasByValue(ctx, a.GetValue())
+		assert.NoError(err)
+		assert.Equal(a.GetPublicId(), got.GetPublicId())
+
+		got, err = repo.LookupAliasByValue(ctx, "unknown.alias")
+		assert.NoError(err)
+		assert.Nil(got)
+
+		_, err = repo.LookupAliasByValue(ctx, "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+	})
+}
+
+func TestRepository_DeleteAlias(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	a := TestAlias(t, rw, "delete.alias")
+	badId, err := newAliasId(ctx)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		id      string
+		want    int
+		wantErr errors.Code
+	}{
+		{
+			name: "found",
+			id:   a.GetPublicId(),
+			want: 1,
+		},
+		{
+			name: "not-found",
+			id:   badId,
+			want: 0,
+		},
+		{
+			name:    "bad-public-id",
+			id:      "",
+			want:    0,
+			wantErr: errors.InvalidParameter,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			got, err := repo.DeleteAlias(ctx, tt.id)
+			if tt.wantErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantErr), err), "want err: %q got: %q", tt.wantErr, err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.want, got, "row count")
+		})
+	}
+}
+
+func TestRepository_ListAliases(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	var want []*Alias
+	for _, v := range []string{"one.alias", "two.alias", "three.alias"} {
+		want = append(want, TestAlias(t, rw, v))
+	}
+
+	got, err := repo.ListAliases(ctx, []string{"global"})
+	require.NoError(t, err)
+	assert.Len(t, got, len(want))
+
+	page, err := repo.ListAliases(ctx, []string{"global"}, WithLimit(1))
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, want[0].GetPublicId(), page[0].GetPublicId())
+
+	page, err = repo.ListAliases(ctx, []string{"global"}, WithLimit(5), WithStartPageAfterItem(page[0]))
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, want[1].GetPublicId(), page[0].GetPublicId())
+	assert.Equal(t, want[2].GetPublicId(), page[1].GetPublicId())
+
+	_, err = repo.ListAliases(ctx, nil)
+	assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "want err code: %v got err: %v", errors.InvalidParameter, err)
+}
+
+func newTestAlias(t *testing.T, value string, opt ...Option) *Alias {
+	t.Helper()
+	a, err := NewAlias(context.Background(), "global", value, opt...)
+	require.NoError(t, err)
+	return a
+}
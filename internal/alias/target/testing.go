@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package target
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAlias creates a target alias in the global scope with the provided
+// value. WithName, WithDescription, WithDestinationId, and WithHostId are
+// supported. If any errors are encountered during the creation of the alias,
+// the test will fail.
+func TestAlias(t testing.TB, rw *db.Db, value string, opt ...Option) *Alias {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+
+	a, err := NewAlias(ctx, "global", value, opt...)
+	require.NoError(err)
+	a.PublicId, err = newAliasId(ctx)
+	require.NoError(err)
+	require.NoError(rw.Create(ctx, a))
+	return a
+}
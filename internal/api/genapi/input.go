@@ -7,7 +7,9 @@ import (
 	"text/template"
 
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accessrequests"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/credentiallibraries"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/hostsets"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/managedgroups"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/policies"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/session_recordings"
@@ -122,6 +125,10 @@ type structInfo struct {
 	// listing
 	recursiveListing bool
 
+	// refreshableListing indicates that the collection supports refresh
+	// tokens when listing
+	refreshableListing bool
+
 	// extraFields allows specifying extra options that will be created for a
 	// given type, e.g. arguments only valid for one call or purpose and not
 	// conveyed within the item itself
@@ -720,6 +727,125 @@ var inputStructs = []*structInfo{
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 	},
 
+	// Alias related resources
+	{
+		inProto: &aliases.AuthorizeSessionArguments{},
+		outFile: "aliases/authorize_session_arguments.gen.go",
+	},
+	{
+		inProto:        &aliases.TargetAliasAttributes{},
+		outFile:        "aliases/target_alias_attributes.gen.go",
+		subtypeName:    "TargetAlias",
+		parentTypeName: "Alias",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto: &aliases.Alias{},
+		outFile: "aliases/alias.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			template.Must(template.New("").Funcs(
+				template.FuncMap{
+					"snakeCase": snakeCase,
+					"funcName": func() string {
+						return "Create"
+					},
+					"apiAction": func() string {
+						return ""
+					},
+					"extraRequiredParams": func() []requiredParam {
+						return []requiredParam{
+							{
+								Name:     "resourceType",
+								Typ:      "string",
+								PostType: "type",
+							},
+						}
+					},
+				},
+			).Parse(createTemplateStr)),
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		fieldOverrides: []fieldInfo{
+			{
+				Name:        "Value",
+				SkipDefault: true,
+			},
+		},
+		pluralResourceName:  "aliases",
+		parentTypeName:      "scope",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
+
+	// Policy related resources
+	{
+		inProto:        &policies.StoragePolicyAttributes{},
+		outFile:        "policies/storage_policy_attributes.gen.go",
+		subtypeName:    "StoragePolicy",
+		parentTypeName: "Policy",
+		templates: []*template.Template{
+			mapstructureConversionTemplate,
+		},
+	},
+	{
+		inProto: &policies.Policy{},
+		outFile: "policies/policy.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			template.Must(template.New("").Funcs(
+				template.FuncMap{
+					"snakeCase": snakeCase,
+					"funcName": func() string {
+						return "Create"
+					},
+					"apiAction": func() string {
+						return ""
+					},
+					"extraRequiredParams": func() []requiredParam {
+						return []requiredParam{
+							{
+								Name:     "resourceType",
+								Typ:      "string",
+								PostType: "type",
+							},
+						}
+					},
+				},
+			).Parse(createTemplateStr)),
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "policies",
+		parentTypeName:      "scope",
+		versionEnabled:      true,
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		recursiveListing:    true,
+	},
+
+	// Access request related resources
+	{
+		inProto: &accessrequests.AccessRequest{},
+		outFile: "accessrequests/access_request.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pluralResourceName:  "access-requests",
+		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
+		versionEnabled:      true,
+		recursiveListing:    true,
+	},
+
 	// Storage related resources
 	{
 		inProto: &storagebuckets.StorageBucket{},
@@ -983,6 +1109,7 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: []string{CreateResponseType, ReadResponseType, UpdateResponseType, DeleteResponseType, ListResponseType},
 		recursiveListing:    true,
+		refreshableListing:  true,
 	},
 	{
 		inProto: &sessions.SessionState{},
@@ -1020,6 +1147,7 @@ var inputStructs = []*structInfo{
 		fieldFilter:         []string{"private_key"},
 		versionEnabled:      true,
 		recursiveListing:    true,
+		refreshableListing:  true,
 	},
 	{
 		inProto: &session_recordings.User{},
@@ -1157,6 +1285,10 @@ var inputStructs = []*structInfo{
 			{Name: "BytesDown", JsonTags: []string{"string"}},
 		},
 	},
+	{
+		inProto: &session_recordings.SearchMatch{},
+		outFile: "sessionrecordings/search_match.gen.go",
+	},
 	{
 		// this must be the last block of session recording blocks, otherwise
 		// the bits beyond inProto and outFile will get overwritten by
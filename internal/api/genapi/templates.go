@@ -52,6 +52,7 @@ type templateInput struct {
 	CreateResponseTypes   []string
 	SkipListFiltering     bool
 	RecursiveListing      bool
+	RefreshableListing    bool
 	Subtype               string
 }
 
@@ -72,6 +73,7 @@ func fillTemplates() {
 			CreateResponseTypes: in.createResponseTypes,
 			SkipListFiltering:   in.skipListFiltering,
 			RecursiveListing:    in.recursiveListing,
+			RefreshableListing:  in.refreshableListing,
 			Subtype:             in.subtype,
 		}
 		if in.packageOverride != "" {
@@ -194,11 +196,12 @@ func fillTemplates() {
 		}
 
 		input := templateInput{
-			Package:           pkg,
-			Fields:            fields,
-			SkipListFiltering: inputMap[pkg].skipListFiltering,
-			RecursiveListing:  inputMap[pkg].recursiveListing,
-			VersionEnabled:    inputMap[pkg].versionEnabled,
+			Package:            pkg,
+			Fields:             fields,
+			SkipListFiltering:  inputMap[pkg].skipListFiltering,
+			RecursiveListing:   inputMap[pkg].recursiveListing,
+			RefreshableListing: inputMap[pkg].refreshableListing,
+			VersionEnabled:     inputMap[pkg].versionEnabled,
 		}
 
 		if err := optionTemplate.Execute(outBuf, input); err != nil {
@@ -227,7 +230,55 @@ var listTemplate = template.Must(template.New("").Funcs(
 		"snakeCase": snakeCase,
 	},
 ).Parse(`
+// List returns every item in the collection, following the page tokens
+// returned by the controller until the listing is complete. Use ListPage to
+// retrieve a single page of items.
 func (c *Client) List(ctx context.Context, {{ .CollectionFunctionArg }} string, opt... Option) (*{{ .Name }}ListResult, error) {
+	target, err := c.ListPage(ctx, {{ .CollectionFunctionArg }}, opt...)
+	if err != nil {
+		return nil, err
+	}
+	if target.NextPageToken == "" {
+		return target, nil
+	}
+
+	items, _ := target.response.Map["items"].([]interface{})
+	for target.NextPageToken != "" {
+		page, err := c.ListPage(ctx, {{ .CollectionFunctionArg }}, append(opt, WithPageToken(target.NextPageToken))...)
+		if err != nil {
+			return nil, err
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken{{ if .RefreshableListing }}
+		target.RefreshToken = page.RefreshToken
+		target.RemovedIds = append(target.RemovedIds, page.RemovedIds...){{ end }}
+		if pageItems, ok := page.response.Map["items"].([]interface{}); ok {
+			items = append(items, pageItems...)
+		}
+	}
+
+	// Present the combined pages as a single response so that callers
+	// inspecting the raw response see every item.
+	target.response.Map["items"] = items
+	delete(target.response.Map, "next_page_token"){{ if .RefreshableListing }}
+	if target.RefreshToken != "" {
+		target.response.Map["refresh_token"] = target.RefreshToken
+	}
+	if len(target.RemovedIds) > 0 {
+		target.response.Map["removed_ids"] = target.RemovedIds
+	}{{ end }}
+	body, err := json.Marshal(target.response.Map)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding combined List response: %w", err)
+	}
+	target.response.Body = bytes.NewBuffer(body)
+	return target, nil
+}
+
+// ListPage returns a single page of items in the collection. The
+// NextPageToken of the result can be passed to WithPageToken to retrieve the
+// following page; it is empty once the listing is complete.
+func (c *Client) ListPage(ctx context.Context, {{ .CollectionFunctionArg }} string, opt... Option) (*{{ .Name }}ListResult, error) {
 	if {{ .CollectionFunctionArg }} == "" {
 		return nil, fmt.Errorf("empty {{ .CollectionFunctionArg }} value passed into List request")
 	}
@@ -652,6 +703,9 @@ func (n {{ .Name }}DeleteResult) GetResponse() *api.Response {
 {{ if ( hasResponseType .CreateResponseTypes "list" ) }}
 type {{ .Name }}ListResult struct {
 	Items []*{{ .Name }}
+	NextPageToken string `, "`json:\"next_page_token,omitempty\"`", `{{ if .RefreshableListing }}
+	RefreshToken string `, "`json:\"refresh_token,omitempty\"`", `
+	RemovedIds []string `, "`json:\"removed_ids,omitempty\"`", `{{ end }}
 	response *api.Response
 }
 
@@ -659,6 +713,18 @@ func (n {{ .Name }}ListResult) GetItems() []*{{ .Name }} {
 	return n.Items
 }
 
+func (n {{ .Name }}ListResult) GetNextPageToken() string {
+	return n.NextPageToken
+}
+{{ if .RefreshableListing }}
+func (n {{ .Name }}ListResult) GetRefreshToken() string {
+	return n.RefreshToken
+}
+
+func (n {{ .Name }}ListResult) GetRemovedIds() []string {
+	return n.RemovedIds
+}
+{{ end }}
 func (n {{ .Name }}ListResult) GetResponse() *api.Response {
 	return n.response
 }
@@ -717,7 +783,10 @@ type options struct {
 	withAutomaticVersioning bool
 	withSkipCurlOutput bool
 	withFilter string
+	withPageSize uint32
+	withPageToken string
 	{{ if .RecursiveListing }} withRecursive bool {{ end }}
+	{{ if .RefreshableListing }} withRefreshToken string {{ end }}
 }
 
 func getDefaultOptions() options {
@@ -740,9 +809,18 @@ func getOpts(opt ...Option) (options, []api.Option) {
 	}
 	if opts.withFilter != "" {
 		opts.queryMap["filter"] = opts.withFilter
+	}
+	if opts.withPageSize != 0 {
+		opts.queryMap["page_size"] = strconv.FormatUint(uint64(opts.withPageSize), 10)
+	}
+	if opts.withPageToken != "" {
+		opts.queryMap["page_token"] = opts.withPageToken
 	}{{ if .RecursiveListing }}
 	if opts.withRecursive {
 		opts.queryMap["recursive"] = strconv.FormatBool(opts.withRecursive)
+	} {{ end }}{{ if .RefreshableListing }}
+	if opts.withRefreshToken != "" {
+		opts.queryMap["refresh_token"] = opts.withRefreshToken
 	} {{ end }}
 	return opts, apiOpts
 }
@@ -777,6 +855,32 @@ func WithFilter(filter string) Option {
 	}
 }
 {{ end }}
+// WithPageSize tells the API the maximum number of items to return in a single
+// page of a listing operation. The controller may return fewer items.
+func WithPageSize(size uint32) Option {
+	return func(o *options) {
+		o.withPageSize = size
+	}
+}
+
+// WithPageToken tells the API to return the page of a listing operation
+// following the page the token was returned with.
+func WithPageToken(token string) Option {
+	return func(o *options) {
+		o.withPageToken = token
+	}
+}
+
+{{ if .RefreshableListing }}
+// WithRefreshToken tells the API to only return the items which changed since
+// the listing operation the token was returned with, along with the ids of the
+// items which were removed since.
+func WithRefreshToken(token string) Option {
+	return func(o *options) {
+		o.withRefreshToken = token
+	}
+}
+{{ end }}
 {{ if .RecursiveListing }}
 // WithRecursive tells the API to use recursion for listing operations on this
 // resource
@@ -223,6 +223,7 @@ func TestSelfReadingDifferentOutputFields(t *testing.T) {
 		tc.Controller().IamRepoFn,
 		tc.Controller().AuthTokenRepoFn,
 		tc.Controller().LdapRepoFn,
+		tc.Controller().SessionRepoFn,
 	)
 	require.NoError(t, err)
 
@@ -11,6 +11,7 @@ import (
 	"net/url"
 
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
 )
 
 type options struct {
@@ -48,6 +49,7 @@ type options struct {
 	withPublicId             string
 	withDerefAliases         DerefAliasType
 	withMaximumPageSize      uint32
+	withStartPageAfterItem   pagination.Item
 }
 
 // Option - how options are passed as args
@@ -413,3 +415,12 @@ func (d DerefAliasType) IsValid(ctx context.Context) error {
 		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q is not a valid ldap dereference alias type", d))
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(_ context.Context, item pagination.Item) Option {
+	return func(o *options) error {
+		o.withStartPageAfterItem = item
+		return nil
+	}
+}
@@ -33,8 +33,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt
 }
 
 // ListAuthMethods returns a slice of AuthMethods for the scopeId. The
-// WithUnauthenticatedUser, WithLimit and WithOrder options are supported and
-// all other options are ignored.
+// WithUnauthenticatedUser, WithLimit, WithOrder and WithStartPageAfterItem
+// options are supported and all other options are ignored.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "ldap.(Repository).ListAuthMethods"
 	if len(scopeIds) == 0 {
@@ -101,9 +101,9 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
-			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time asc, public_id asc"))
 		} else {
-			dbArgs = append(dbArgs, db.WithOrder("create_time"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time, public_id"))
 		}
 	}
 
@@ -114,6 +114,10 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		where, args = append(where, "public_id = ?"), append(args, authMethodId)
 	default:
 		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
+		if opts.withStartPageAfterItem != nil {
+			where = append(where, "(create_time, public_id) > (?, ?)")
+			args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+		}
 	}
 
 	if opts.withUnauthenticatedUser {
@@ -201,6 +205,8 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		}
 		am.DereferenceAliases = agg.DereferenceAliases
 		am.MaximumPageSize = agg.MaximumPageSize
+		am.AuthTokenTimeToLiveSeconds = agg.AuthTokenTimeToLiveSeconds
+		am.AuthTokenTimeToStaleSeconds = agg.AuthTokenTimeToStaleSeconds
 		authMethods = append(authMethods, &am)
 	}
 	return authMethods, nil
@@ -210,41 +216,43 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 // the value object can have multiple values like Urls and Certs, then the
 // string field is delimited with the aggregateDelimiter of "|"
 type authMethodAgg struct {
-	PublicId                 string `gorm:"primary_key"`
-	ScopeId                  string
-	IsPrimaryAuthMethod      bool
-	Name                     string
-	Description              string
-	CreateTime               *timestamp.Timestamp
-	UpdateTime               *timestamp.Timestamp
-	Version                  uint32
-	State                    string
-	StartTLS                 bool
-	InsecureTLS              bool
-	DiscoverDn               bool
-	AnonGroupSearch          bool
-	UpnDomain                string
-	Urls                     string
-	Certs                    string
-	UserDn                   string
-	UserAttr                 string
-	UserFilter               string
-	EnableGroups             bool
-	UseTokenGroups           bool
-	GroupDn                  string
-	GroupAttr                string
-	GroupFilter              string
-	ClientCertificateKey     []byte
-	ClientCertificateKeyHmac []byte
-	ClientCertificateKeyId   string
-	ClientCertificateCert    []byte
-	BindDn                   string
-	BindPassword             []byte
-	BindPasswordHmac         []byte
-	BindKeyId                string
-	AccountAttributeMap      string
-	DereferenceAliases       string
-	MaximumPageSize          uint32
+	PublicId                    string `gorm:"primary_key"`
+	ScopeId                     string
+	IsPrimaryAuthMethod         bool
+	Name                        string
+	Description                 string
+	CreateTime                  *timestamp.Timestamp
+	UpdateTime                  *timestamp.Timestamp
+	Version                     uint32
+	State                       string
+	StartTLS                    bool
+	InsecureTLS                 bool
+	DiscoverDn                  bool
+	AnonGroupSearch             bool
+	UpnDomain                   string
+	Urls                        string
+	Certs                       string
+	UserDn                      string
+	UserAttr                    string
+	UserFilter                  string
+	EnableGroups                bool
+	UseTokenGroups              bool
+	GroupDn                     string
+	GroupAttr                   string
+	GroupFilter                 string
+	ClientCertificateKey        []byte
+	ClientCertificateKeyHmac    []byte
+	ClientCertificateKeyId      string
+	ClientCertificateCert       []byte
+	BindDn                      string
+	BindPassword                []byte
+	BindPasswordHmac            []byte
+	BindKeyId                   string
+	AccountAttributeMap         string
+	DereferenceAliases          string
+	MaximumPageSize             uint32
+	AuthTokenTimeToLiveSeconds  uint32
+	AuthTokenTimeToStaleSeconds uint32
 }
 
 // TableName returns the table name for gorm
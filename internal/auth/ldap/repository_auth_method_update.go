@@ -47,6 +47,9 @@ const (
 	GroupNamesField           = "GroupNames"
 	DerefAliasesField         = "DereferenceAliases"
 	MaximumPageSizeField      = "MaximumPageSize"
+
+	AuthTokenTimeToLiveSecondsField  = "AuthTokenTimeToLiveSeconds"
+	AuthTokenTimeToStaleSecondsField = "AuthTokenTimeToStaleSeconds"
 )
 
 // isEmpty returns true if all the args are empty.  Only supports checking
@@ -120,6 +123,9 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 			AccountAttributeMapsField: am.AccountAttributeMaps,
 			DerefAliasesField:         am.DereferenceAliases,
 			MaximumPageSizeField:      am.MaximumPageSize,
+
+			AuthTokenTimeToLiveSecondsField:  am.AuthTokenTimeToLiveSeconds,
+			AuthTokenTimeToStaleSecondsField: am.AuthTokenTimeToStaleSeconds,
 		},
 		fieldMaskPaths,
 		[]string{
@@ -641,6 +647,8 @@ func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
 		case strings.EqualFold(AccountAttributeMapsField, f):
 		case strings.EqualFold(DerefAliasesField, f):
 		case strings.EqualFold(MaximumPageSizeField, f):
+		case strings.EqualFold(AuthTokenTimeToLiveSecondsField, f):
+		case strings.EqualFold(AuthTokenTimeToStaleSecondsField, f):
 		default:
 			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %q", f))
 		}
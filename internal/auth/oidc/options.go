@@ -8,6 +8,7 @@ import (
 	"net/url"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/pagination"
 )
 
 // getOpts - iterate the inbound Options and return a struct.
@@ -49,6 +50,7 @@ type options struct {
 	withOperationalState    AuthMethodState
 	withAccountClaimMap     map[string]AccountToClaim
 	withReader              db.Reader
+	withStartPageAfterItem  pagination.Item
 }
 
 func getDefaultOptions() options {
@@ -240,3 +242,11 @@ func WithPrompts(prompt ...PromptParam) Option {
 		o.withPrompts = prompt
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = item
+	}
+}
@@ -133,7 +133,9 @@ func (r *Repository) LookupAccount(ctx context.Context, withPublicId string, opt
 	return a, nil
 }
 
-// ListAccounts in an auth method and supports WithLimit option.
+// ListAccounts in an auth method and supports the WithLimit and
+// WithStartPageAfterItem options. Accounts are ordered by create time and
+// public id.
 func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Account, error) {
 	const op = "oidc.(Repository).ListAccounts"
 	if withAuthMethodId == "" {
@@ -146,7 +148,12 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		limit = opts.withLimit
 	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit))
+	where, args := "auth_method_id = ?", []any{withAuthMethodId}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -30,8 +30,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, opt
 }
 
 // ListAuthMethods returns a slice of AuthMethods for the scopeId. The
-// WithUnauthenticatedUser, WithLimit and WithOrder options are supported and
-// all other options are ignored.
+// WithUnauthenticatedUser, WithLimit, WithOrder and WithStartPageAfterItem
+// options are supported and all other options are ignored.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "oidc.(Repository).ListAuthMethods"
 	if len(scopeIds) == 0 {
@@ -95,9 +95,9 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
-			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time asc, public_id asc"))
 		} else {
-			dbArgs = append(dbArgs, db.WithOrder("create_time"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time, public_id"))
 		}
 	}
 
@@ -108,6 +108,10 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		where, args = append(where, "public_id = ?"), append(args, authMethodId)
 	default:
 		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
+		if opts.withStartPageAfterItem != nil {
+			where = append(where, "(create_time, public_id) > (?, ?)")
+			args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+		}
 	}
 
 	if opts.withUnauthenticatedUser {
@@ -152,6 +156,8 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		am.KeyId = agg.KeyId
 		am.MaxAge = int32(agg.MaxAge)
 		am.ApiUrl = agg.ApiUrl
+		am.AuthTokenTimeToLiveSeconds = agg.AuthTokenTimeToLiveSeconds
+		am.AuthTokenTimeToStaleSeconds = agg.AuthTokenTimeToStaleSeconds
 		if agg.Algs != "" {
 			am.SigningAlgs = strings.Split(agg.Algs, aggregateDelimiter)
 		}
@@ -202,6 +208,8 @@ type authMethodAgg struct {
 	ClaimsScopes                      string
 	AccountClaimMaps                  string
 	Prompts                           string
+	AuthTokenTimeToLiveSeconds        uint32
+	AuthTokenTimeToStaleSeconds       uint32
 }
 
 // TableName returns the table name for gorm
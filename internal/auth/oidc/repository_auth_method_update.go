@@ -41,6 +41,8 @@ const (
 	UserinfoClaimsField                    = "UserinfoClaims"
 	KeyIdField                             = "KeyId"
 	PromptsField                           = "Prompts"
+	AuthTokenTimeToLiveSecondsField        = "AuthTokenTimeToLiveSeconds"
+	AuthTokenTimeToStaleSecondsField       = "AuthTokenTimeToStaleSeconds"
 )
 
 // UpdateAuthMethod will retrieve the auth method from the repository,
@@ -111,6 +113,9 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, am *AuthMethod, versi
 			ClaimsScopesField:     am.ClaimsScopes,
 			AccountClaimMapsField: am.AccountClaimMaps,
 			PromptsField:          am.Prompts,
+
+			AuthTokenTimeToLiveSecondsField:  am.AuthTokenTimeToLiveSeconds,
+			AuthTokenTimeToStaleSecondsField: am.AuthTokenTimeToStaleSeconds,
 		},
 		fieldMaskPaths,
 		nil,
@@ -615,6 +620,8 @@ func validateFieldMask(ctx context.Context, fieldMaskPaths []string) error {
 		case strings.EqualFold(ClaimsScopesField, f):
 		case strings.EqualFold(AccountClaimMapsField, f):
 		case strings.EqualFold(PromptsField, f):
+		case strings.EqualFold(AuthTokenTimeToLiveSecondsField, f):
+		case strings.EqualFold(AuthTokenTimeToStaleSecondsField, f):
 		default:
 			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -641,6 +648,10 @@ func applyUpdate(new, orig *AuthMethod, fieldMaskPaths []string) *AuthMethod {
 			cp.MaxAge = new.MaxAge
 		case ApiUrlField:
 			cp.ApiUrl = new.ApiUrl
+		case AuthTokenTimeToLiveSecondsField:
+			cp.AuthTokenTimeToLiveSeconds = new.AuthTokenTimeToLiveSeconds
+		case AuthTokenTimeToStaleSecondsField:
+			cp.AuthTokenTimeToStaleSeconds = new.AuthTokenTimeToStaleSeconds
 		case SigningAlgsField:
 			switch {
 			case len(new.SigningAlgs) == 0:
@@ -96,7 +96,9 @@ func (r *Repository) LookupManagedGroup(ctx context.Context, withPublicId string
 	return a, nil
 }
 
-// ListManagedGroups in an auth method and supports WithLimit option.
+// ListManagedGroups in an auth method and supports the WithLimit and
+// WithStartPageAfterItem options. Managed groups are ordered by create time
+// and public id.
 func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*ManagedGroup, error) {
 	const op = "oidc.(Repository).ListManagedGroups"
 	if withAuthMethodId == "" {
@@ -109,7 +111,12 @@ func (r *Repository) ListManagedGroups(ctx context.Context, withAuthMethodId str
 		limit = opts.withLimit
 	}
 	var mgs []*ManagedGroup
-	err := r.reader.SearchWhere(ctx, &mgs, "auth_method_id = ?", []any{withAuthMethodId}, db.WithLimit(limit))
+	where, args := "auth_method_id = ?", []any{withAuthMethodId}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &mgs, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
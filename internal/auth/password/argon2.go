@@ -208,6 +208,17 @@ func (c *Argon2Credential) decrypt(ctx context.Context, cipher wrapping.Wrapper)
 	return nil
 }
 
+// argon2CredentialHistory is a retired Argon2Credential of an Account which
+// is kept to prevent the reuse of recent passwords.
+type argon2CredentialHistory struct {
+	*Argon2Credential
+}
+
+// TableName returns the table name.
+func (c *argon2CredentialHistory) TableName() string {
+	return "auth_password_argon2_cred_history"
+}
+
 func (c *Argon2Credential) oplog(op oplog.OpType) oplog.Metadata {
 	metadata := oplog.Metadata{
 		"resource-public-id":  []string{c.PrivateId},
@@ -3,6 +3,8 @@
 
 package password
 
+import "github.com/hashicorp/boundary/internal/pagination"
+
 // GetOpts - iterate the inbound Options and return a struct.
 func GetOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -17,16 +19,17 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName              string
-	withDescription       string
-	WithLoginName         string
-	withLimit             int
-	withConfig            Configuration
-	withPublicId          string
-	password              string
-	withPassword          bool
-	withOrderByCreateTime bool
-	ascending             bool
+	withName               string
+	withDescription        string
+	WithLoginName          string
+	withLimit              int
+	withConfig             Configuration
+	withPublicId           string
+	password               string
+	withPassword           bool
+	withOrderByCreateTime  bool
+	ascending              bool
+	withStartPageAfterItem pagination.Item
 }
 
 func getDefaultOptions() options {
@@ -95,3 +98,11 @@ func WithOrderByCreateTime(ascending bool) Option {
 		o.ascending = ascending
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = item
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// defaultLockoutDuration is the time an account stays locked when the auth
+// method has a lockout threshold but no lockout duration.
+const defaultLockoutDuration = 15 * time.Minute
+
+// characterClasses returns the number of character classes in s. The
+// character classes are lowercase letters, uppercase letters, digits and
+// symbols, which are all characters not in one of the other classes.
+func characterClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// checkComplexity returns an error with code PasswordTooSimple if password
+// contains fewer character classes than required by c.
+func (c *currentConfig) checkComplexity(ctx context.Context, password string) error {
+	const op = "password.(currentConfig).checkComplexity"
+	if c.MinPasswordCharacterClasses > characterClasses(password) {
+		return errors.New(ctx, errors.PasswordTooSimple, op, fmt.Sprintf("must contain at least %d of lowercase letters, uppercase letters, digits and symbols", c.MinPasswordCharacterClasses))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_characterClasses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"abcdefgh", 1},
+		{"ABCDEFGH", 1},
+		{"12345678", 1},
+		{"!@#$%^&*", 1},
+		{"abcdEFGH", 2},
+		{"abcd1234", 2},
+		{"abcD1234", 3},
+		{"abcD123!", 4},
+		{"pässwörd Ü1", 4},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, characterClasses(tt.in), "characterClasses(%q)", tt.in)
+	}
+}
+
+func Test_currentConfig_checkComplexity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cc := &currentConfig{}
+	assert.NoError(t, cc.checkComplexity(ctx, "abcdefgh"))
+
+	cc.MinPasswordCharacterClasses = 3
+	err := cc.checkComplexity(ctx, "abcdEFGH")
+	assert.Truef(t, errors.Match(errors.T(errors.PasswordTooSimple), err), "Unexpected error %s", err)
+	assert.NoError(t, cc.checkComplexity(ctx, "abcdEFG1"))
+}
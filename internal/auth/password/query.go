@@ -23,16 +23,29 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
-  from auth_password_argon2_cred cred,
-       auth_password_argon2_conf conf,
-       auth_password_account acct,
-       auth_password_method meth
+       meth.password_conf_id = cred.password_conf_id as is_current_conf,
+       coalesce(meth.lockout_threshold, 0) as lockout_threshold,
+       coalesce(meth.lockout_duration_seconds, 0) as lockout_duration_seconds,
+       coalesce(lo.failed_attempts, 0) as failed_attempts,
+       coalesce(lo.locked_until > now(), false) as is_locked,
+       coalesce(cred.create_time + make_interval(days => meth.max_password_age_days) < now(), false) as is_password_expired,
+       exists (
+         select 1
+           from auth_password_totp totp
+          where totp.password_account_id = acct.public_id
+            and totp.verify_time is not null
+       ) as has_verified_totp
+  from auth_password_argon2_cred cred
+  join auth_password_argon2_conf conf
+    on cred.password_conf_id = conf.private_id
+  join auth_password_account acct
+    on cred.password_account_id = acct.public_id
+  join auth_password_method meth
+    on acct.auth_method_id = meth.public_id
+  left join auth_password_account_lockout lo
+    on acct.public_id = lo.password_account_id
  where acct.auth_method_id = @auth_method_id
-   and acct.login_name = @login_name
-   and cred.password_conf_id = conf.private_id
-   and cred.password_account_id = acct.public_id
-   and acct.auth_method_id = meth.public_id ;
+   and acct.login_name = @login_name ;
 `
 	currentConfigForAccountQuery = `
 select *
@@ -44,4 +57,115 @@ select *
         where public_id = @public_id
     );
 `
+
+	recordFailedAttemptQuery = `
+insert into auth_password_account_lockout as lo
+       (password_account_id, failed_attempts, last_failed_attempt_time)
+values (@password_account_id, 1, now())
+    on conflict (password_account_id) do update
+   set failed_attempts          = lo.failed_attempts + 1,
+       last_failed_attempt_time = now()
+returning failed_attempts;
+`
+	lockAccountQuery = `
+update auth_password_account_lockout
+   set failed_attempts = 0,
+       locked_until    = now() + make_interval(secs => @lockout_duration_seconds)
+ where password_account_id = @password_account_id;
+`
+	clearFailedAttemptsQuery = `
+delete from auth_password_account_lockout
+ where password_account_id = @password_account_id;
+`
+	accountLockoutQuery = `
+select coalesce(meth.lockout_threshold, 0) as lockout_threshold,
+       coalesce(meth.lockout_duration_seconds, 0) as lockout_duration_seconds,
+       coalesce(lo.failed_attempts, 0) as failed_attempts,
+       coalesce(lo.locked_until > now(), false) as is_locked
+  from auth_password_account acct
+  join auth_password_method meth
+    on acct.auth_method_id = meth.public_id
+  left join auth_password_account_lockout lo
+    on acct.public_id = lo.password_account_id
+ where acct.public_id = @password_account_id;
+`
+	passwordHistoryQuery = `
+with creds (private_id, password_account_id, password_conf_id, salt, derived_key, key_id, retire_time) as (
+  select private_id, password_account_id, password_conf_id, salt, derived_key, key_id, 'infinity'::timestamptz
+    from auth_password_argon2_cred
+   where password_account_id = @password_account_id
+   union all
+  select private_id, password_account_id, password_conf_id, salt, derived_key, key_id, retire_time
+    from auth_password_argon2_cred_history
+   where password_account_id = @password_account_id
+)
+select creds.private_id,          -- Argon2Credential.PrivateId
+       creds.password_account_id, -- Argon2Credential.PasswordAccountId
+       creds.password_conf_id,    -- Argon2Credential.PasswordConfId
+       creds.salt,                -- Argon2Credential.CtSalt/Salt
+       creds.derived_key,         -- Argon2Credential.DerivedKey
+       creds.key_id,              -- Argon2Credential.KeyId
+       conf.key_length,           -- Argon2Configuration.KeyLength
+       conf.iterations,           -- Argon2Configuration.Iterations
+       conf.memory,               -- Argon2Configuration.Memory
+       conf.threads               -- Argon2Configuration.Threads
+  from creds
+  join auth_password_argon2_conf conf
+    on creds.password_conf_id = conf.private_id
+ order by creds.retire_time desc
+ limit @limit;
+`
+	retireCredentialQuery = `
+insert into auth_password_argon2_cred_history
+       (private_id, password_account_id, password_conf_id, create_time, salt, derived_key, key_id)
+select private_id, password_account_id, password_conf_id, create_time, salt, derived_key, key_id
+  from auth_password_argon2_cred
+ where password_account_id = @password_account_id;
+`
+	prunePasswordHistoryQuery = `
+delete from auth_password_argon2_cred_history
+ where password_account_id = @password_account_id
+   and private_id not in (
+       select private_id
+         from auth_password_argon2_cred_history
+        where password_account_id = @password_account_id
+        order by retire_time desc
+        limit @limit
+   );
+`
+	createTotpChallengeQuery = `
+insert into auth_password_totp_challenge
+       (private_id, password_account_id, expiration_time)
+select @private_id, password_account_id, now() + make_interval(secs => @duration_seconds)
+  from auth_password_totp
+ where password_account_id = @password_account_id
+   and verify_time is not null;
+`
+	deleteTotpChallengesQuery = `
+delete from auth_password_totp_challenge
+ where password_account_id = @password_account_id;
+`
+	recordFailedTotpAttemptQuery = `
+update auth_password_totp_challenge
+   set failed_attempts = failed_attempts + 1
+ where private_id = @private_id
+returning failed_attempts;
+`
+	deleteTotpChallengeQuery = `
+delete from auth_password_totp_challenge
+ where private_id = @private_id;
+`
+	verifyTotpQuery = `
+update auth_password_totp
+   set verify_time    = now(),
+       last_time_step = @time_step
+ where password_account_id = @password_account_id
+   and verify_time is null;
+`
+	updateTotpTimeStepQuery = `
+update auth_password_totp
+   set last_time_step = @time_step
+ where password_account_id = @password_account_id
+   and (last_time_step is null or last_time_step < @time_step);
+`
 )
@@ -112,8 +112,8 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ..
 	return r.lookupAuthMethod(ctx, publicId)
 }
 
-// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit and
-// WithOrder options are the only option supported.
+// ListAuthMethods returns a slice of AuthMethods for the scopeId. WithLimit,
+// WithOrder and WithStartPageAfterItem options are the only options supported.
 func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt ...Option) ([]*AuthMethod, error) {
 	const op = "password.(Repository).ListAuthMethods"
 	if len(scopeIds) == 0 {
@@ -177,9 +177,12 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // not be set to null, but instead use the default values returned by
 // NewAuthMethod.  fieldMaskPaths provides field_mask.proto paths for fields
 // that should be updated.  Fields will be set to NULL if the field is a zero
-// value and included in fieldMask. Name, Description, MinPasswordLength,
-// and MinLoginNameLength are the only updatable fields, If no updatable fields
-// are included in the fieldMaskPaths, then an error is returned.
+// value and included in fieldMask. Setting one of the password policy fields
+// MinPasswordCharacterClasses, PasswordHistoryDepth, MaxPasswordAgeDays,
+// LockoutThreshold and LockoutDurationSeconds to NULL disables the policy.
+// Name, Description, MinPasswordLength, MinLoginNameLength and the password
+// policy fields are the only updatable fields, If no updatable fields are
+// included in the fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "password.(Repository).UpdateAuthMethod"
 	if authMethod == nil {
@@ -197,6 +200,13 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		case strings.EqualFold("Description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("MinPasswordCharacterClasses", f):
+		case strings.EqualFold("PasswordHistoryDepth", f):
+		case strings.EqualFold("MaxPasswordAgeDays", f):
+		case strings.EqualFold("LockoutThreshold", f):
+		case strings.EqualFold("LockoutDurationSeconds", f):
+		case strings.EqualFold("AuthTokenTimeToLiveSeconds", f):
+		case strings.EqualFold("AuthTokenTimeToStaleSeconds", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, f)
 		}
@@ -204,10 +214,17 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                        authMethod.Name,
+			"Description":                 authMethod.Description,
+			"MinPasswordLength":           authMethod.MinPasswordLength,
+			"MinLoginNameLength":          authMethod.MinLoginNameLength,
+			"MinPasswordCharacterClasses": authMethod.MinPasswordCharacterClasses,
+			"PasswordHistoryDepth":        authMethod.PasswordHistoryDepth,
+			"MaxPasswordAgeDays":          authMethod.MaxPasswordAgeDays,
+			"LockoutThreshold":            authMethod.LockoutThreshold,
+			"LockoutDurationSeconds":      authMethod.LockoutDurationSeconds,
+			"AuthTokenTimeToLiveSeconds":  authMethod.AuthTokenTimeToLiveSeconds,
+			"AuthTokenTimeToStaleSeconds": authMethod.AuthTokenTimeToStaleSeconds,
 		},
 		fieldMaskPaths,
 		nil,
@@ -321,9 +338,9 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 
 	if opts.withOrderByCreateTime {
 		if opts.ascending {
-			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time asc, public_id asc"))
 		} else {
-			dbArgs = append(dbArgs, db.WithOrder("create_time"))
+			dbArgs = append(dbArgs, db.WithOrder("create_time, public_id"))
 		}
 	}
 
@@ -334,6 +351,10 @@ func (r *Repository) getAuthMethods(ctx context.Context, authMethodId string, sc
 		where, args = append(where, "public_id = ?"), append(args, authMethodId)
 	default:
 		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
+		if opts.withStartPageAfterItem != nil {
+			where = append(where, "(create_time, public_id) > (?, ?)")
+			args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+		}
 	}
 
 	var views []*authMethodView
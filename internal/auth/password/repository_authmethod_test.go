@@ -678,6 +678,42 @@ func TestRepository_UpdateAuthMethod(t *testing.T) {
 			wantRowsUpdate:   1,
 			skipVersionCheck: true,
 		},
+		{
+			name: "change password policy",
+			args: args{
+				updates: &store.AuthMethod{
+					MinPasswordCharacterClasses: 3,
+					PasswordHistoryDepth:        5,
+					MaxPasswordAgeDays:          90,
+					LockoutThreshold:            5,
+					LockoutDurationSeconds:      900,
+				},
+				fieldMaskPaths: []string{"MinPasswordCharacterClasses", "PasswordHistoryDepth", "MaxPasswordAgeDays", "LockoutThreshold", "LockoutDurationSeconds"},
+			},
+			wantErr:        false,
+			wantRowsUpdate: 1,
+		},
+		{
+			name: "null password policy",
+			args: args{
+				updates:        &store.AuthMethod{},
+				fieldMaskPaths: []string{"MinPasswordCharacterClasses", "PasswordHistoryDepth", "MaxPasswordAgeDays", "LockoutThreshold", "LockoutDurationSeconds"},
+			},
+			wantErr:          false,
+			wantRowsUpdate:   1,
+			skipVersionCheck: true,
+		},
+		{
+			name: "invalid character classes",
+			args: args{
+				updates: &store.AuthMethod{
+					MinPasswordCharacterClasses: 5,
+				},
+				fieldMaskPaths: []string{"MinPasswordCharacterClasses"},
+			},
+			wantErr:        true,
+			wantRowsUpdate: 0,
+		},
 		{
 			name: "noop update",
 			args: args{
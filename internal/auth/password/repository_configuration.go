@@ -123,9 +123,11 @@ func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon
 }
 
 type currentConfig struct {
-	ConfType           string
-	MinLoginNameLength int
-	MinPasswordLength  int
+	ConfType                    string
+	MinLoginNameLength          int
+	MinPasswordLength           int
+	MinPasswordCharacterClasses int
+	PasswordHistoryDepth        int
 
 	*Argon2Configuration
 }
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// UnlockAccount clears the failed authentication attempts of accountId and
+// unlocks it if it is locked. The account is returned.
+//
+// Returns nil, error with code RecordNotFound if the account doesn't exist.
+func (r *Repository) UnlockAccount(ctx context.Context, accountId string, _ ...Option) (*Account, error) {
+	const op = "password.(Repository).UnlockAccount"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	if err := r.clearFailedAttempts(ctx, accountId); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return acct, nil
+}
+
+// accountLockout is the lockout state of an account and the lockout
+// settings of its auth method.
+type accountLockout struct {
+	LockoutThreshold       int
+	LockoutDurationSeconds int
+	FailedAttempts         int
+	IsLocked               bool
+}
+
+// lookupLockout returns the lockout state of accountId or nil if the account
+// doesn't exist.
+func (r *Repository) lookupLockout(ctx context.Context, accountId string) (*accountLockout, error) {
+	const op = "password.(Repository).lookupLockout"
+	rows, err := r.reader.Query(ctx, accountLockoutQuery, []any{sql.Named("password_account_id", accountId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var lo *accountLockout
+	for rows.Next() {
+		lo = &accountLockout{}
+		if err := rows.Scan(&lo.LockoutThreshold, &lo.LockoutDurationSeconds, &lo.FailedAttempts, &lo.IsLocked); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return lo, nil
+}
+
+// recordFailedAttempt increments the number of consecutive failed
+// authentication attempts of accountId. The account is locked for
+// lockoutDurationSeconds once the number of failed attempts reaches
+// lockoutThreshold, which resets the number of failed attempts.
+func (r *Repository) recordFailedAttempt(ctx context.Context, accountId string, lockoutThreshold, lockoutDurationSeconds int) error {
+	const op = "password.(Repository).recordFailedAttempt"
+	if lockoutDurationSeconds <= 0 {
+		lockoutDurationSeconds = int(defaultLockoutDuration.Seconds())
+	}
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rows, err := w.Query(ctx, recordFailedAttemptQuery, []any{sql.Named("password_account_id", accountId)})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			var failedAttempts int
+			for rows.Next() {
+				if err := rows.Scan(&failedAttempts); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if failedAttempts < lockoutThreshold {
+				return nil
+			}
+			if _, err := w.Exec(ctx, lockAccountQuery, []any{
+				sql.Named("password_account_id", accountId),
+				sql.Named("lockout_duration_seconds", lockoutDurationSeconds),
+			}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to lock account"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// clearFailedAttempts deletes the failed authentication attempts and the
+// lock of accountId.
+func (r *Repository) clearFailedAttempts(ctx context.Context, accountId string) error {
+	const op = "password.(Repository).clearFailedAttempts"
+	if _, err := r.writer.Exec(ctx, clearFailedAttemptsQuery, []any{sql.Named("password_account_id", accountId)}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AuthenticateLockout(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	authMethod.LockoutThreshold = 3
+	authMethod.LockoutDurationSeconds = 3600
+	_, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"LockoutThreshold", "LockoutDurationSeconds"})
+	require.NoError(t, err)
+
+	passwd := "12345678"
+	createAccount := func(t *testing.T, ln string) *Account {
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{
+				AuthMethodId: authMethod.PublicId,
+				LoginName:    ln,
+			},
+		}, WithPassword(passwd))
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+		return acct
+	}
+	authenticate := func(ln, pw string) (*Account, error) {
+		return repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, ln, pw)
+	}
+
+	t.Run("locked-after-threshold", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := createAccount(t, "lockedafterthreshold")
+		for i := 0; i < 3; i++ {
+			got, err := authenticate(acct.LoginName, "wrong password")
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		got, err := authenticate(acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.AccountLocked), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+	t.Run("success-resets-failed-attempts", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := createAccount(t, "successresets")
+		for i := 0; i < 2; i++ {
+			got, err := authenticate(acct.LoginName, "wrong password")
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		got, err := authenticate(acct.LoginName, passwd)
+		require.NoError(err)
+		assert.NotNil(got)
+
+		for i := 0; i < 2; i++ {
+			got, err := authenticate(acct.LoginName, "wrong password")
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		got, err = authenticate(acct.LoginName, passwd)
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+	t.Run("lock-expires", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := createAccount(t, "lockexpires")
+		for i := 0; i < 3; i++ {
+			_, err := authenticate(acct.LoginName, "wrong password")
+			require.NoError(err)
+		}
+		_, err := authenticate(acct.LoginName, passwd)
+		require.Truef(errors.Match(errors.T(errors.AccountLocked), err), "Unexpected error %s", err)
+
+		_, err = rw.Exec(ctx, "update auth_password_account_lockout set locked_until = now() - interval '1 second' where password_account_id = ?", []any{acct.PublicId})
+		require.NoError(err)
+		got, err := authenticate(acct.LoginName, passwd)
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+}
+
+func TestRepository_UnlockAccount(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	authMethod.LockoutThreshold = 1
+	_, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"LockoutThreshold"})
+	require.NoError(t, err)
+
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+		Account: &store.Account{
+			AuthMethodId: authMethod.PublicId,
+			LoginName:    "kazmierczak",
+		},
+	}, WithPassword(passwd))
+	require.NoError(t, err)
+
+	t.Run("missing-account-id", func(t *testing.T) {
+		got, err := repo.UnlockAccount(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("account-not-found", func(t *testing.T) {
+		got, err := repo.UnlockAccount(ctx, "acctpw_1234567890")
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("unlock", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong password")
+		require.NoError(err)
+		assert.Nil(got)
+		_, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		require.Truef(errors.Match(errors.T(errors.AccountLocked), err), "Unexpected error %s", err)
+
+		unlocked, err := repo.UnlockAccount(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotNil(unlocked)
+		assert.Equal(acct.PublicId, unlocked.PublicId)
+		assert.Equal(acct.Version, unlocked.Version)
+
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+	t.Run("unlock-not-locked", func(t *testing.T) {
+		got, err := repo.UnlockAccount(ctx, acct.PublicId)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+}
@@ -21,13 +21,31 @@ type authAccount struct {
 	*Account
 	*Argon2Credential
 	*Argon2Configuration
-	IsCurrentConf bool
+	IsCurrentConf          bool
+	LockoutThreshold       int
+	LockoutDurationSeconds int
+	FailedAttempts         int
+	IsLocked               bool
+	IsPasswordExpired      bool
+	HasVerifiedTotp        bool
+}
+
+type historicCredential struct {
+	*Argon2Credential
+	*Argon2Configuration
 }
 
 // Authenticate authenticates loginName and password match for loginName in
 // authMethodId. The account for the loginName is returned if authentication
 // is successful. Returns nil if authentication fails.
 //
+// If the auth method has a lockout threshold, each failed authentication
+// attempt is recorded and the account is locked once the number of
+// consecutive failed attempts reaches the threshold. Returns an error with
+// code AccountLocked if the account is locked. A successful authentication
+// clears the failed attempts. Returns an error with code PasswordExpired if
+// the password is older than the maximum password age of the auth method.
+//
 // The CredentialId in the returned account represents a user's current
 // password. A new CredentialId is generated when a user's password is
 // changed and the old one is deleted.
@@ -62,6 +80,9 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 	if acct == nil {
 		return nil, nil
 	}
+	if acct.IsPasswordExpired {
+		return nil, errors.New(ctx, errors.PasswordExpired, op, "password expired", errors.WithoutEvent())
+	}
 
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
@@ -113,6 +134,9 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 // Returns nil, db.ErrorRecordNotFound if the account doesn't exist.
 // Returns nil, nil if old does not match the stored password for accountId.
 // Returns nil, error with code PasswordsEqual if old and new are equal.
+// Returns nil, error with code PasswordTooSimple if new does not contain
+// enough character classes. Returns nil, error with code PasswordReused if
+// new equals one of the recent passwords of accountId.
 func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old, new string, version uint32) (*Account, error) {
 	const op = "password.(Repository).ChangePassword"
 	if accountId == "" {
@@ -166,6 +190,12 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	if cc.MinPasswordLength > len(new) {
 		return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", cc.MinPasswordLength))
 	}
+	if err := cc.checkComplexity(ctx, new); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if err := r.checkPasswordHistory(ctx, scopeId, accountId, new, cc.PasswordHistoryDepth); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
 	newCred, err := newArgon2Credential(ctx, accountId, new, cc.argon2())
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -191,6 +221,9 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 				return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated account and %d rows updated", rowsUpdated))
 			}
 
+			if err := retireCredential(ctx, w, accountId, cc.PasswordHistoryDepth); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 			rowsDeleted, err := w.Delete(ctx, oldCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 			if err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -213,6 +246,10 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	return updatedAccount, nil
 }
 
+// authenticate returns the account for loginName in authMethodId if
+// password matches the stored password. Returns nil if the account does not
+// exist or password does not match. Returns an error with code AccountLocked
+// if the account is locked.
 func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*authAccount, error) {
 	const op = "password.(Repository).authenticate"
 	var accts []authAccount
@@ -241,6 +278,10 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 		acct = accts[0]
 	}
 
+	if acct.IsLocked {
+		return nil, errors.New(ctx, errors.AccountLocked, op, "account is locked", errors.WithoutEvent())
+	}
+
 	// We don't pass a wrapper in here because for ecryption we want to indicate the expected key ID
 	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(acct.GetKeyId()))
 	if err != nil {
@@ -254,13 +295,91 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 	inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
 	if subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 0 {
 		// authentication failed, password does not match
+		if acct.LockoutThreshold > 0 {
+			if err := r.recordFailedAttempt(ctx, acct.PublicId, acct.LockoutThreshold, acct.LockoutDurationSeconds); err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+		}
 		return nil, nil
 	}
+	// The failed attempts of accounts with a TOTP second factor are only
+	// cleared once the second factor is completed, otherwise logging in again
+	// would reset the invalid codes counted towards the lockout.
+	if acct.FailedAttempts > 0 && !acct.HasVerifiedTotp {
+		if err := r.clearFailedAttempts(ctx, acct.PublicId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
 	return &acct, nil
 }
 
+// checkPasswordHistory returns an error with code PasswordReused if password
+// matches the current password of accountId or one of its retired
+// passwords. Only the depth most recent passwords are checked.
+func (r *Repository) checkPasswordHistory(ctx context.Context, scopeId, accountId, password string, depth int) error {
+	const op = "password.(Repository).checkPasswordHistory"
+	if depth <= 0 {
+		return nil
+	}
+
+	var creds []historicCredential
+	rows, err := r.reader.Query(ctx, passwordHistoryQuery, []any{sql.Named("password_account_id", accountId), sql.Named("limit", depth)})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hc historicCredential
+		if err := r.reader.ScanRows(ctx, rows, &hc); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		creds = append(creds, hc)
+	}
+
+	for _, c := range creds {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(c.GetKeyId()))
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := c.decrypt(ctx, databaseWrapper); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt credential"))
+		}
+		inputKey := argon2.IDKey([]byte(password), c.Salt, c.Iterations, c.Memory, uint8(c.Threads), c.KeyLength)
+		if subtle.ConstantTimeCompare(inputKey, c.DerivedKey) == 1 {
+			return errors.New(ctx, errors.PasswordReused, op, fmt.Sprintf("must not equal one of the last %d passwords", depth))
+		}
+	}
+	return nil
+}
+
+// retireCredential copies the current credential of accountId into the
+// password history if the password history depth is greater than one and
+// removes the retired credentials which are no longer needed for the depth.
+// It must be called in the transaction which replaces the credential.
+func retireCredential(ctx context.Context, w db.Writer, accountId string, depth int) error {
+	const op = "password.retireCredential"
+	if depth > 1 {
+		if _, err := w.Exec(ctx, retireCredentialQuery, []any{sql.Named("password_account_id", accountId)}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("unable to retire credential"))
+		}
+	}
+	// The current credential is one of the depth most recent passwords.
+	keep := depth - 1
+	if keep < 0 {
+		keep = 0
+	}
+	if _, err := w.Exec(ctx, prunePasswordHistoryQuery, []any{sql.Named("password_account_id", accountId), sql.Named("limit", keep)}); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to prune password history"))
+	}
+	return nil
+}
+
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
+// Returns nil, error with code PasswordTooSimple if password does not
+// contain enough character classes. Returns nil, error with code
+// PasswordReused if password equals one of the recent passwords of
+// accountId.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
 	if accountId == "" {
@@ -283,6 +402,7 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 	}
 
 	var newCred *Argon2Credential
+	var historyDepth int
 	if password != "" {
 		cc, err := r.currentConfigForAccount(ctx, accountId)
 		if err != nil {
@@ -294,6 +414,13 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 		if cc.MinPasswordLength > len(password) {
 			return nil, errors.New(ctx, errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
 		}
+		if err := cc.checkComplexity(ctx, password); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		if err := r.checkPasswordHistory(ctx, scopeId, accountId, password, cc.PasswordHistoryDepth); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		historyDepth = cc.PasswordHistoryDepth
 		newCred, err = newArgon2Credential(ctx, accountId, password, cc.argon2())
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
@@ -325,6 +452,11 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 				}
 			}
 			if oldCred.PrivateId != "" {
+				if newCred != nil {
+					if err := retireCredential(ctx, w, accountId, historyDepth); err != nil {
+						return errors.Wrap(ctx, err, op)
+					}
+				}
 				dCred := oldCred.clone()
 				rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 				if err != nil {
@@ -563,3 +563,111 @@ func TestRepository_SetPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	authMethod.MinPasswordCharacterClasses = 3
+	authMethod.PasswordHistoryDepth = 3
+	authMethod.MaxPasswordAgeDays = 30
+	_, _, err = repo.UpdateAuthMethod(ctx, authMethod, authMethod.Version, []string{"MinPasswordCharacterClasses", "PasswordHistoryDepth", "MaxPasswordAgeDays"})
+	require.NoError(t, err)
+
+	createAccount := func(t *testing.T, ln, pw string) (*Account, error) {
+		return repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{
+				AuthMethodId: authMethod.PublicId,
+				LoginName:    ln,
+			},
+		}, WithPassword(pw))
+	}
+
+	t.Run("create-too-simple", func(t *testing.T) {
+		acct, err := createAccount(t, "createtoosimple", "abcdefgh1")
+		assert.Truef(t, errors.Match(errors.T(errors.PasswordTooSimple), err), "Unexpected error %s", err)
+		assert.Nil(t, acct)
+	})
+	t.Run("change-and-set-too-simple", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := createAccount(t, "changetoosimple", "Abcdefgh1")
+		require.NoError(err)
+
+		got, err := repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Abcdefgh1", "abcdefgh12", acct.Version)
+		assert.Truef(errors.Match(errors.T(errors.PasswordTooSimple), err), "Unexpected error %s", err)
+		assert.Nil(got)
+
+		got, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "ABCDEFGH12", acct.Version)
+		assert.Truef(errors.Match(errors.T(errors.PasswordTooSimple), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+	t.Run("history", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := createAccount(t, "history", "Password1")
+		require.NoError(err)
+
+		acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password1", "Password2", acct.Version)
+		require.NoError(err)
+		require.NotNil(acct)
+		acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", acct.Version)
+		require.NoError(err)
+		require.NotNil(acct)
+
+		// The current and the two previous passwords can't be reused.
+		for _, pw := range []string{"Password1", "Password2"} {
+			got, err := repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", pw, acct.Version)
+			assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "Unexpected error %s", err)
+			assert.Nil(got)
+		}
+		got, err := repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", acct.Version)
+		assert.Truef(errors.Match(errors.T(errors.PasswordReused), err), "Unexpected error %s", err)
+		assert.Nil(got)
+
+		acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "Password3", "Password4", acct.Version)
+		require.NoError(err)
+		require.NotNil(acct)
+
+		// Password1 is no longer one of the three most recent passwords.
+		acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "Password1", acct.Version)
+		require.NoError(err)
+		require.NotNil(acct)
+
+		var retired []*argon2CredentialHistory
+		require.NoError(rw.SearchWhere(ctx, &retired, "password_account_id = ?", []any{acct.PublicId}))
+		assert.Len(retired, 2)
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := createAccount(t, "expired", "Password1")
+		require.NoError(err)
+
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, "Password1")
+		require.NoError(err)
+		assert.NotNil(got)
+
+		// create_time is immutable
+		_, err = rw.Exec(ctx, "alter table auth_password_argon2_cred disable trigger immutable_columns", nil)
+		require.NoError(err)
+		_, err = rw.Exec(ctx, "update auth_password_argon2_cred set create_time = now() - interval '31 days' where password_account_id = ?", []any{acct.PublicId})
+		require.NoError(err)
+		_, err = rw.Exec(ctx, "alter table auth_password_argon2_cred enable trigger immutable_columns", nil)
+		require.NoError(err)
+
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, "Password1")
+		assert.Truef(errors.Match(errors.T(errors.PasswordExpired), err), "Unexpected error %s", err)
+		assert.Nil(got)
+
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, "wrong password")
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
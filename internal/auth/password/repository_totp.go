@@ -0,0 +1,347 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// EnrollTotp enrolls a new TOTP second factor for accountId. The returned
+// Totp contains the unencrypted shared secret which must be handed to the
+// owner of the account. The second factor is required to authenticate once
+// the enrollment is verified with VerifyTotp. An existing unverified
+// enrollment is replaced.
+//
+// Returns nil, error with code RecordNotFound if the account doesn't exist
+// and nil, error with code NotUnique if the account has a verified TOTP
+// enrollment.
+func (r *Repository) EnrollTotp(ctx context.Context, scopeId, accountId string, _ ...Option) (*Totp, error) {
+	const op = "password.(Repository).EnrollTotp"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case accountId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	existing, err := r.lookupTotp(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if existing.IsVerified() {
+		return nil, errors.New(ctx, errors.NotUnique, op, "totp already enrolled")
+	}
+
+	t := allocTotp()
+	t.PasswordAccountId = accountId
+	t.Secret = make([]byte, totpSecretLength)
+	if _, err := rand.Read(t.Secret); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Io))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var newTotp *Totp
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if existing != nil {
+				if _, err := w.Delete(ctx, existing); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete unverified totp"))
+				}
+			}
+			newTotp = t.clone()
+			if err := w.Create(ctx, newTotp); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create totp"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return newTotp, nil
+}
+
+// VerifyTotp verifies the TOTP enrollment of accountId with code. The
+// account is returned.
+//
+// Returns nil, error with code RecordNotFound if the account has no TOTP
+// enrollment, nil, error with code NotUnique if the enrollment is already
+// verified and nil, error with code InvalidTotpCode if code is not valid.
+func (r *Repository) VerifyTotp(ctx context.Context, scopeId, accountId, code string, _ ...Option) (*Account, error) {
+	const op = "password.(Repository).VerifyTotp"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case accountId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	case code == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing code")
+	}
+
+	t, err := r.lookupTotp(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	switch {
+	case t == nil:
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "totp not enrolled")
+	case t.IsVerified():
+		return nil, errors.New(ctx, errors.NotUnique, op, "totp already verified")
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(t.GetKeyId()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	timeStep := validateTotpCode(t.GetSecret(), code, time.Now(), 0)
+	if timeStep == 0 {
+		return nil, errors.New(ctx, errors.InvalidTotpCode, op, "invalid code", errors.WithoutEvent())
+	}
+
+	rowsUpdated, err := r.writer.Exec(ctx, verifyTotpQuery, []any{
+		sql.Named("password_account_id", accountId),
+		sql.Named("time_step", timeStep),
+	})
+	switch {
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to verify totp"))
+	case rowsUpdated == 0:
+		return nil, errors.New(ctx, errors.NotUnique, op, "totp already verified")
+	}
+
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, "account not found")
+	}
+	return acct, nil
+}
+
+// CreateTotpChallenge creates a TOTP challenge for accountId which must be
+// completed with AuthenticateTotp. Any previous challenge of the account is
+// deleted. CreateTotpChallenge returns nil if the account has no verified
+// TOTP enrollment.
+func (r *Repository) CreateTotpChallenge(ctx context.Context, accountId string, _ ...Option) (*TotpChallenge, error) {
+	const op = "password.(Repository).CreateTotpChallenge"
+	if accountId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing account id")
+	}
+	id, err := newTotpChallengeId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var challenge *TotpChallenge
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, deleteTotpChallengesQuery, []any{sql.Named("password_account_id", accountId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete previous totp challenges"))
+			}
+			rowsInserted, err := w.Exec(ctx, createTotpChallengeQuery, []any{
+				sql.Named("private_id", id),
+				sql.Named("password_account_id", accountId),
+				sql.Named("duration_seconds", totpChallengeDuration.Seconds()),
+			})
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create totp challenge"))
+			}
+			if rowsInserted == 0 {
+				// the account has no verified totp enrollment
+				return nil
+			}
+			challenge = allocTotpChallenge()
+			if err := reader.LookupWhere(ctx, challenge, "private_id = ?", []any{id}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to read totp challenge"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return challenge, nil
+}
+
+// AuthenticateTotp completes the TOTP challenge challengeId of an account in
+// authMethodId with code. It returns the authenticated account or nil if the
+// challenge doesn't exist, is expired, the account is locked or code is not
+// valid. The challenge is deleted once it is completed or after too many
+// invalid codes. Invalid codes count towards the lockout of the account.
+func (r *Repository) AuthenticateTotp(ctx context.Context, scopeId, authMethodId, challengeId, code string, _ ...Option) (*Account, error) {
+	const op = "password.(Repository).AuthenticateTotp"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case authMethodId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	case challengeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing challenge id")
+	case code == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing code")
+	}
+
+	challenge := allocTotpChallenge()
+	if err := r.reader.LookupWhere(ctx, challenge, "private_id = ? and expiration_time > now()", []any{challengeId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	acct, err := r.LookupAccount(ctx, challenge.GetPasswordAccountId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil || acct.GetAuthMethodId() != authMethodId {
+		return nil, nil
+	}
+	lo, err := r.lookupLockout(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if lo == nil || lo.IsLocked {
+		return nil, nil
+	}
+	t, err := r.lookupTotp(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !t.IsVerified() {
+		return nil, nil
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(t.GetKeyId()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	timeStep := validateTotpCode(t.GetSecret(), code, time.Now(), t.GetLastTimeStep())
+	if timeStep == 0 {
+		if err := r.recordFailedTotpAttempt(ctx, challengeId); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		// Invalid codes also count towards the lockout of the account, since
+		// a new challenge is created every time the password is entered.
+		// Accounts of auth methods without a lockout threshold are locked
+		// after maxTotpChallengeAttempts consecutive invalid codes.
+		threshold := lo.LockoutThreshold
+		if threshold <= 0 {
+			threshold = maxTotpChallengeAttempts
+		}
+		if err := r.recordFailedAttempt(ctx, acct.GetPublicId(), threshold, lo.LockoutDurationSeconds); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return nil, nil
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsDeleted, err := w.Exec(ctx, deleteTotpChallengeQuery, []any{sql.Named("private_id", challengeId)})
+			switch {
+			case err != nil:
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete totp challenge"))
+			case rowsDeleted == 0:
+				return errors.New(ctx, errors.InvalidTotpCode, op, "totp challenge already completed", errors.WithoutEvent())
+			}
+			rowsUpdated, err := w.Exec(ctx, updateTotpTimeStepQuery, []any{
+				sql.Named("password_account_id", acct.GetPublicId()),
+				sql.Named("time_step", timeStep),
+			})
+			switch {
+			case err != nil:
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update totp"))
+			case rowsUpdated == 0:
+				return errors.New(ctx, errors.InvalidTotpCode, op, "totp code already used", errors.WithoutEvent())
+			}
+			return nil
+		},
+	)
+	switch {
+	case errors.Match(errors.T(errors.InvalidTotpCode), err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if lo.FailedAttempts > 0 {
+		if err := r.clearFailedAttempts(ctx, acct.GetPublicId()); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+	}
+	return acct, nil
+}
+
+// recordFailedTotpAttempt increments the number of invalid codes submitted
+// for challengeId and deletes the challenge once the number reaches
+// maxTotpChallengeAttempts.
+func (r *Repository) recordFailedTotpAttempt(ctx context.Context, challengeId string) error {
+	const op = "password.(Repository).recordFailedTotpAttempt"
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rows, err := w.Query(ctx, recordFailedTotpAttemptQuery, []any{sql.Named("private_id", challengeId)})
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			defer rows.Close()
+			var failedAttempts int
+			for rows.Next() {
+				if err := rows.Scan(&failedAttempts); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if failedAttempts < maxTotpChallengeAttempts {
+				return nil
+			}
+			if _, err := w.Exec(ctx, deleteTotpChallengeQuery, []any{sql.Named("private_id", challengeId)}); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete totp challenge"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// lookupTotp returns the TOTP enrollment of accountId or nil if the account
+// has none. The shared secret is not decrypted.
+func (r *Repository) lookupTotp(ctx context.Context, accountId string) (*Totp, error) {
+	const op = "password.(Repository).lookupTotp"
+	t := allocTotp()
+	if err := r.reader.LookupWhere(ctx, t, "password_account_id = ?", []any{accountId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return t, nil
+}
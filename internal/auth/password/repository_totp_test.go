@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_EnrollTotp(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	t.Run("missing-scope-id", func(t *testing.T) {
+		got, err := repo.EnrollTotp(ctx, "", "acctpw_1234567890")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("missing-account-id", func(t *testing.T) {
+		got, err := repo.EnrollTotp(ctx, o.GetPublicId(), "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("account-not-found", func(t *testing.T) {
+		got, err := repo.EnrollTotp(ctx, o.GetPublicId(), "acctpw_1234567890")
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("enroll", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "enroll")
+		got, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(acct.PublicId, got.PasswordAccountId)
+		assert.Len(got.GetSecret(), totpSecretLength)
+		assert.NotEmpty(got.GetCtSecret())
+		assert.NotEmpty(got.GetKeyId())
+		assert.False(got.IsVerified())
+
+		// an unverified enrollment is replaced
+		again, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		require.NotNil(again)
+		assert.NotEqual(got.GetSecret(), again.GetSecret())
+	})
+	t.Run("already-verified", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "alreadyverified")
+		totp, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		_, err = repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, TestTotpCode(t, totp.GetSecret(), time.Now()))
+		require.NoError(err)
+
+		got, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_VerifyTotp(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethod := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	t.Run("missing-code", func(t *testing.T) {
+		got, err := repo.VerifyTotp(ctx, o.GetPublicId(), "acctpw_1234567890", "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("not-enrolled", func(t *testing.T) {
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "notenrolled")
+		got, err := repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, "123456")
+		assert.Truef(t, errors.Match(errors.T(errors.RecordNotFound), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("invalid-code", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "invalidcode")
+		totp, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		got, err := repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, TestTotpCode(t, totp.GetSecret(), time.Now().Add(-time.Hour)))
+		assert.Truef(errors.Match(errors.T(errors.InvalidTotpCode), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+	t.Run("verify", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "verify")
+		totp, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		code := TestTotpCode(t, totp.GetSecret(), time.Now())
+		got, err := repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, code)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(acct.PublicId, got.PublicId)
+
+		stored, err := repo.lookupTotp(ctx, acct.PublicId)
+		require.NoError(err)
+		assert.True(stored.IsVerified())
+		assert.NotZero(stored.GetLastTimeStep())
+
+		got, err = repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, code)
+		assert.Truef(errors.Match(errors.T(errors.NotUnique), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_AuthenticateTotp(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	authMethods := TestAuthMethods(t, conn, o.GetPublicId(), 2)
+	authMethod := authMethods[0]
+
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	passwd := "12345678"
+	enroll := func(t *testing.T, ln string) (*Account, *Totp) {
+		t.Helper()
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{
+				AuthMethodId: authMethod.PublicId,
+				LoginName:    ln,
+			},
+		}, WithPassword(passwd))
+		require.NoError(t, err)
+		totp, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(t, err)
+		_, err = repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, TestTotpCode(t, totp.GetSecret(), time.Now().Add(-30*time.Second)))
+		require.NoError(t, err)
+		return acct, totp
+	}
+
+	t.Run("no-challenge-without-verified-totp", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := TestAccount(t, conn, authMethod.GetPublicId(), "unverified")
+		_, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		got, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("authenticate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, totp := enroll(t, "authenticate")
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotNil(challenge)
+		assert.Equal(acct.PublicId, challenge.GetPasswordAccountId())
+		assert.NotEmpty(challenge.GetPrivateId())
+
+		code := TestTotpCode(t, totp.GetSecret(), time.Now())
+		got, err := repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), code)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(acct.PublicId, got.PublicId)
+
+		// the challenge can only be completed once
+		got, err = repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), code)
+		require.NoError(err)
+		assert.Nil(got)
+
+		// a code can only be used once
+		challenge, err = repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		got, err = repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), code)
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("wrong-auth-method", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, totp := enroll(t, "wrongauthmethod")
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		got, err := repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethods[1].PublicId, challenge.GetPrivateId(), TestTotpCode(t, totp.GetSecret(), time.Now()))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("too-many-invalid-codes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, totp := enroll(t, "toomanyinvalidcodes")
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		for i := 0; i < maxTotpChallengeAttempts; i++ {
+			got, err := repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), "000000")
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		got, err := repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), TestTotpCode(t, totp.GetSecret(), time.Now()))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("invalid-codes-lock-account", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, totp := enroll(t, "invalidcodeslockaccount")
+		// Entering the password again creates a new challenge, but must not
+		// reset the invalid codes counted towards the lockout.
+		for i := 0; i < maxTotpChallengeAttempts; i++ {
+			got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+			require.NoError(err)
+			require.NotNil(got)
+			challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+			require.NoError(err)
+			require.NotNil(challenge)
+			got, err = repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), "000000")
+			require.NoError(err)
+			assert.Nil(got)
+		}
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), authMethod.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.AccountLocked), err), "Unexpected error %s", err)
+		assert.Nil(got)
+
+		// challenges can't be completed while the account is locked
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotNil(challenge)
+		got, err = repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), TestTotpCode(t, totp.GetSecret(), time.Now()))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("lockout-threshold", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		am := authMethods[1]
+		am.LockoutThreshold = 2
+		am.LockoutDurationSeconds = 3600
+		_, _, err := repo.UpdateAuthMethod(ctx, am, am.Version, []string{"LockoutThreshold", "LockoutDurationSeconds"})
+		require.NoError(err)
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{
+				AuthMethodId: am.PublicId,
+				LoginName:    "lockoutthreshold",
+			},
+		}, WithPassword(passwd))
+		require.NoError(err)
+		totp, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		_, err = repo.VerifyTotp(ctx, o.GetPublicId(), acct.PublicId, TestTotpCode(t, totp.GetSecret(), time.Now().Add(-30*time.Second)))
+		require.NoError(err)
+
+		// a wrong password and an invalid code both count towards the
+		// threshold of the auth method
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "wrong password")
+		require.NoError(err)
+		assert.Nil(got)
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		require.NoError(err)
+		require.NotNil(got)
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		got, err = repo.AuthenticateTotp(ctx, o.GetPublicId(), am.PublicId, challenge.GetPrivateId(), "000000")
+		require.NoError(err)
+		assert.Nil(got)
+		got, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.AccountLocked), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+	t.Run("expired-challenge", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, totp := enroll(t, "expiredchallenge")
+		challenge, err := repo.CreateTotpChallenge(ctx, acct.PublicId)
+		require.NoError(err)
+		_, err = rw.Exec(ctx, "alter table auth_password_totp_challenge disable trigger immutable_columns", nil)
+		require.NoError(err)
+		_, err = rw.Exec(ctx, "update auth_password_totp_challenge set create_time = now() - interval '1 hour', expiration_time = now() - interval '1 second' where private_id = ?", []any{challenge.GetPrivateId()})
+		require.NoError(err)
+		_, err = rw.Exec(ctx, "alter table auth_password_totp_challenge enable trigger immutable_columns", nil)
+		require.NoError(err)
+
+		got, err := repo.AuthenticateTotp(ctx, o.GetPublicId(), authMethod.PublicId, challenge.GetPrivateId(), TestTotpCode(t, totp.GetSecret(), time.Now()))
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
@@ -88,3 +88,143 @@ func TestRewrap_argon2ConfigRewrapFn(t *testing.T) {
 		assert.NotEqual(t, cred.GetCtSalt(), got.GetCtSalt())
 	})
 }
+
+func TestRewrap_argon2CredentialHistoryRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	t.Run("errors-on-query-error", func(t *testing.T) {
+		conn, mock := db.TestSetupWithMock(t)
+		wrapper := db.TestWrapper(t)
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_schema_version" WHERE 1=1 ORDER BY "kms_schema_version"\."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_oplog_schema_version" WHERE 1=1 ORDER BY "kms_oplog_schema_version"."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		rw := db.New(conn)
+		mock.ExpectQuery(
+			`SELECT \* FROM "auth_password_argon2_cred_history" WHERE key_id=\$1`,
+		).WillReturnError(errors.New("Query error"))
+		err := argon2CredentialHistoryRewrapFn(ctx, "some_id", "some_scope", rw, rw, kmsCache)
+		require.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		conn, _ := db.TestSetup(t, "postgres")
+
+		rw := db.New(conn)
+		wrapper := db.TestWrapper(t)
+
+		org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		aut := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+		kmsCache := kms.TestKms(t, conn, wrapper)
+
+		repo, err := NewRepository(ctx, rw, rw, kmsCache)
+		require.NoError(t, err)
+		aut.PasswordHistoryDepth = 2
+		_, _, err = repo.UpdateAuthMethod(ctx, aut, aut.Version, []string{"PasswordHistoryDepth"})
+		require.NoError(t, err)
+		acct, err := repo.CreateAccount(ctx, org.GetPublicId(), &Account{
+			Account: &store.Account{
+				AuthMethodId: aut.PublicId,
+				LoginName:    "name",
+			},
+		}, WithPassword("this is a password"))
+		require.NoError(t, err)
+
+		// retire the credential of the account
+		_, err = repo.SetPassword(ctx, org.GetPublicId(), acct.PublicId, "this is another password", acct.Version)
+		require.NoError(t, err)
+		var retired []*argon2CredentialHistory
+		require.NoError(t, rw.SearchWhere(ctx, &retired, "password_account_id = ?", []any{acct.PublicId}))
+		require.Len(t, retired, 1)
+		cred := retired[0]
+
+		// now things are stored in the db, we can rotate and rewrap
+		assert.NoError(t, kmsCache.RotateKeys(ctx, org.Scope.GetPublicId()))
+		assert.NoError(t, argon2CredentialHistoryRewrapFn(ctx, cred.KeyId, org.Scope.GetPublicId(), rw, rw, kmsCache))
+
+		got := &argon2CredentialHistory{
+			Argon2Credential: &Argon2Credential{
+				Argon2Credential: &store.Argon2Credential{
+					PrivateId: cred.PrivateId,
+				},
+			},
+		}
+		assert.NoError(t, rw.LookupById(ctx, got))
+
+		// fetch the new key version
+		kmsWrapper, err := kmsCache.GetWrapper(ctx, org.Scope.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(got.GetKeyId()))
+		assert.NoError(t, err)
+		newKeyVersion, err := kmsWrapper.KeyId(ctx)
+		assert.NoError(t, err)
+
+		// decrypt both with their key versions and check to make sure things match
+		oldWrapper, err := kmsCache.GetWrapper(ctx, org.Scope.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(cred.GetKeyId()))
+		assert.NoError(t, err)
+		assert.NoError(t, cred.decrypt(ctx, oldWrapper))
+		assert.NoError(t, got.decrypt(ctx, kmsWrapper))
+		assert.NotEmpty(t, got.GetKeyId())
+		assert.NotEqual(t, cred.GetKeyId(), got.GetKeyId())
+		assert.Equal(t, newKeyVersion, got.GetKeyId())
+		assert.Equal(t, cred.GetSalt(), got.GetSalt())
+		assert.NotEqual(t, cred.GetCtSalt(), got.GetCtSalt())
+	})
+}
+
+func TestRewrap_totpRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	t.Run("errors-on-query-error", func(t *testing.T) {
+		conn, mock := db.TestSetupWithMock(t)
+		wrapper := db.TestWrapper(t)
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_schema_version" WHERE 1=1 ORDER BY "kms_schema_version"\."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_oplog_schema_version" WHERE 1=1 ORDER BY "kms_oplog_schema_version"."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		rw := db.New(conn)
+		mock.ExpectQuery(
+			`SELECT \* FROM "auth_password_totp" WHERE key_id=\$1`,
+		).WillReturnError(errors.New("Query error"))
+		err := totpRewrapFn(ctx, "some_id", "some_scope", rw, rw, kmsCache)
+		require.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		conn, _ := db.TestSetup(t, "postgres")
+
+		rw := db.New(conn)
+		wrapper := db.TestWrapper(t)
+
+		org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+		aut := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+		acct := TestAccount(t, conn, aut.GetPublicId(), "name")
+		kmsCache := kms.TestKms(t, conn, wrapper)
+
+		repo, err := NewRepository(ctx, rw, rw, kmsCache)
+		require.NoError(t, err)
+		totp, err := repo.EnrollTotp(ctx, org.GetPublicId(), acct.PublicId)
+		require.NoError(t, err)
+
+		// now things are stored in the db, we can rotate and rewrap
+		assert.NoError(t, kmsCache.RotateKeys(ctx, org.Scope.GetPublicId()))
+		assert.NoError(t, totpRewrapFn(ctx, totp.KeyId, org.Scope.GetPublicId(), rw, rw, kmsCache))
+
+		got := allocTotp()
+		assert.NoError(t, rw.LookupWhere(ctx, got, "password_account_id = ?", []any{acct.PublicId}))
+
+		// fetch the new key version
+		kmsWrapper, err := kmsCache.GetWrapper(ctx, org.Scope.GetPublicId(), kms.KeyPurposeDatabase, kms.WithKeyId(got.GetKeyId()))
+		assert.NoError(t, err)
+		newKeyVersion, err := kmsWrapper.KeyId(ctx)
+		assert.NoError(t, err)
+
+		// decrypt with the new key version and check to make sure things match
+		assert.NoError(t, got.decrypt(ctx, kmsWrapper))
+		assert.NotEmpty(t, got.GetKeyId())
+		assert.NotEqual(t, totp.GetKeyId(), got.GetKeyId())
+		assert.Equal(t, newKeyVersion, got.GetKeyId())
+		assert.Equal(t, totp.GetSecret(), got.GetSecret())
+		assert.NotEqual(t, totp.GetCtSecret(), got.GetCtSecret())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/stretchr/testify/assert"
@@ -98,3 +99,9 @@ func TestAccount(t testing.TB, conn *db.DB, authMethodId, loginName string, opt
 	require.NoError(err2)
 	return cat
 }
+
+// TestTotpCode returns the TOTP code for secret at the provided time.
+func TestTotpCode(t testing.TB, secret []byte, at time.Time) string {
+	t.Helper()
+	return totpCode(secret, totpTimeStep(at))
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// The parameters of the TOTP codes as defined in RFC 6238. These are the
+// defaults of authenticator apps.
+const (
+	totpSecretLength = 20
+	totpPeriod       = 30
+	totpDigits       = 6
+	// totpSkew is the number of time steps before and after the current
+	// time step for which codes are accepted to allow for clock drift.
+	totpSkew = 1
+	// totpIssuer is the issuer shown by authenticator apps.
+	totpIssuer = "Boundary"
+)
+
+const (
+	// totpChallengeDuration is the time a TOTP challenge can be completed
+	// after the password step.
+	totpChallengeDuration = 5 * time.Minute
+	// maxTotpChallengeAttempts is the number of invalid codes after which a
+	// TOTP challenge is deleted. It is also the number of consecutive invalid
+	// codes after which an account is locked if its auth method has no
+	// lockout threshold.
+	maxTotpChallengeAttempts = 5
+)
+
+// totpEncoding is the encoding of TOTP secrets understood by authenticator
+// apps.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// A Totp is a time-based one-time password (TOTP) second factor enrolled by
+// an Account.
+type Totp struct {
+	*store.Totp
+	tableName string
+}
+
+func allocTotp() *Totp {
+	return &Totp{
+		Totp: &store.Totp{},
+	}
+}
+
+func (t *Totp) clone() *Totp {
+	cp := proto.Clone(t.Totp)
+	return &Totp{
+		Totp: cp.(*store.Totp),
+	}
+}
+
+// TableName returns the table name.
+func (t *Totp) TableName() string {
+	if t != nil && t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_totp"
+}
+
+// SetTableName sets the table name.
+func (t *Totp) SetTableName(n string) {
+	t.tableName = n
+}
+
+// IsVerified reports whether the enrollment was verified with a code.
+func (t *Totp) IsVerified() bool {
+	return t != nil && t.GetVerifyTime() != nil
+}
+
+// EncodedSecret returns the shared secret encoded for authenticator apps.
+func (t *Totp) EncodedSecret() string {
+	return totpEncoding.EncodeToString(t.GetSecret())
+}
+
+// Url returns the otpauth URL of the enrollment for loginName. Authenticator
+// apps usually scan it as a QR code.
+func (t *Totp) Url(loginName string) string {
+	v := url.Values{}
+	v.Set("secret", t.EncodedSecret())
+	v.Set("issuer", totpIssuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(totpPeriod))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + totpIssuer + ":" + loginName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+func (t *Totp) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	keyId, err := cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("error reading cipher key id"))
+	}
+	t.KeyId = keyId
+	return nil
+}
+
+func (t *Totp) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(Totp).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.Totp, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// A TotpChallenge is a pending authentication of an Account which passed
+// the password step and must be completed with a TOTP code.
+type TotpChallenge struct {
+	*store.TotpChallenge
+	tableName string
+}
+
+func allocTotpChallenge() *TotpChallenge {
+	return &TotpChallenge{
+		TotpChallenge: &store.TotpChallenge{},
+	}
+}
+
+// TableName returns the table name.
+func (c *TotpChallenge) TableName() string {
+	if c != nil && c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_totp_challenge"
+}
+
+// SetTableName sets the table name.
+func (c *TotpChallenge) SetTableName(n string) {
+	c.tableName = n
+}
+
+// totpTimeStep returns the RFC 6238 time step of t.
+func totpTimeStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// totpCode returns the code for secret at timeStep as defined in RFC 4226
+// and RFC 6238.
+func totpCode(secret []byte, timeStep int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(timeStep))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// validateTotpCode returns the time step of code for secret at now if code is
+// valid and its time step is after lastTimeStep. It returns 0 if code is not
+// valid.
+func validateTotpCode(secret []byte, code string, now time.Time, lastTimeStep int64) int64 {
+	if len(code) != totpDigits {
+		return 0
+	}
+	current := totpTimeStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastTimeStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step
+		}
+	}
+	return 0
+}
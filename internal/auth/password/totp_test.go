@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package password
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_totpCode(t *testing.T) {
+	t.Parallel()
+	// Test vectors from RFC 6238 Appendix B for SHA1, truncated to six digits.
+	secret := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, totpCode(secret, totpTimeStep(time.Unix(tt.unix, 0))), "totpCode at %d", tt.unix)
+	}
+}
+
+func Test_validateTotpCode(t *testing.T) {
+	t.Parallel()
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111111, 0)
+	step := totpTimeStep(now)
+	code := func(at time.Time) string {
+		return totpCode(secret, totpTimeStep(at))
+	}
+
+	assert.Equal(t, step, validateTotpCode(secret, code(now), now, 0))
+	assert.Equal(t, step-1, validateTotpCode(secret, code(now.Add(-totpPeriod*time.Second)), now, 0))
+	assert.Equal(t, step+1, validateTotpCode(secret, code(now.Add(totpPeriod*time.Second)), now, 0))
+	assert.Zero(t, validateTotpCode(secret, code(now.Add(-2*totpPeriod*time.Second)), now, 0), "too old")
+	assert.Zero(t, validateTotpCode(secret, code(now.Add(2*totpPeriod*time.Second)), now, 0), "too new")
+	assert.Zero(t, validateTotpCode(secret, code(now), now, step), "replayed")
+	assert.Zero(t, validateTotpCode(secret, "", now, 0), "empty")
+	assert.Zero(t, validateTotpCode(secret, "1234567", now, 0), "too long")
+	assert.Zero(t, validateTotpCode([]byte("another secret"), code(now), now, 0), "wrong secret")
+}
+
+func TestTotp_Url(t *testing.T) {
+	t.Parallel()
+	totp := &Totp{
+		Totp: &store.Totp{
+			Secret: []byte("12345678901234567890"),
+		},
+	}
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", totp.EncodedSecret())
+
+	u, err := url.Parse(totp.Url("jim"))
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Boundary:jim", u.Path)
+	q := u.Query()
+	assert.Equal(t, totp.EncodedSecret(), q.Get("secret"))
+	assert.Equal(t, "Boundary", q.Get("issuer"))
+	assert.Equal(t, "6", q.Get("digits"))
+	assert.Equal(t, "30", q.Get("period"))
+}
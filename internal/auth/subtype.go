@@ -15,6 +15,8 @@ type AuthMethod interface {
 	boundary.Resource
 	GetScopeId() string
 	GetIsPrimaryAuthMethod() bool
+	GetAuthTokenTimeToLiveSeconds() uint32
+	GetAuthTokenTimeToStaleSeconds() uint32
 }
 
 type Account interface {
@@ -29,7 +29,12 @@ import (
 // them easily convertable to vanilla AuthTokens when required.
 type authTokenView struct {
 	*store.AuthToken
-	tableName string `gorm:"-"`
+	// TimeToLiveSeconds and TimeToStaleSeconds are the auth token lifetimes
+	// set on the token's auth method or its scope, or zero if neither sets
+	// them.
+	TimeToLiveSeconds  int64
+	TimeToStaleSeconds int64
+	tableName          string `gorm:"-"`
 }
 
 // allocAuthTokenView is just easier/better than leaking the underlying type
@@ -167,3 +172,52 @@ func EncryptToken(ctx context.Context, kmsCache *kms.Kms, scopeId, publicId, tok
 
 	return globals.ServiceTokenV1 + encoded, nil
 }
+
+// DecryptToken decrypts a token value encrypted with EncryptToken for the auth
+// token with the provided public id. The decrypted token must still be
+// validated against the auth token, for example with ValidateToken.
+func DecryptToken(ctx context.Context, kmsCache *kms.Kms, scopeId, publicId, encryptedToken string) (string, error) {
+	const op = "authtoken.DecryptToken"
+	switch {
+	case kmsCache == nil:
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	case scopeId == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
+	case publicId == "":
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	case len(encryptedToken) < len(globals.ServiceTokenV1):
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing encrypted token")
+	}
+
+	tokenWrapper, err := kmsCache.GetWrapper(ctx, scopeId, kms.KeyPurposeTokens)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to get wrapper for tokens"))
+	}
+
+	version := encryptedToken[0:len(globals.ServiceTokenV1)]
+	switch version {
+	case globals.ServiceTokenV1:
+	default:
+		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown token encryption version %q", version))
+	}
+	marshaledToken, err := base58.FastBase58Decoding(encryptedToken[len(globals.ServiceTokenV1):])
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("error unmarshaling base58 token"))
+	}
+
+	blobInfo := new(wrapping.BlobInfo)
+	if err := proto.Unmarshal(marshaledToken, blobInfo); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("error decoding encrypted token"))
+	}
+
+	s1Bytes, err := tokenWrapper.Decrypt(ctx, blobInfo, wrapping.WithAad([]byte(publicId)))
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("error decrypting encrypted token"))
+	}
+
+	var s1Info tokens.S1TokenInfo
+	if err := proto.Unmarshal(s1Bytes, &s1Info); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("error unmarshaling token info"))
+	}
+	return s1Info.Token, nil
+}
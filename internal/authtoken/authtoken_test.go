@@ -244,3 +244,33 @@ func TestAuthToken_DbDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDecryptToken(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+
+	encrypted, err := EncryptToken(ctx, kmsCache, org.GetPublicId(), at.GetPublicId(), at.GetToken())
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		got, err := DecryptToken(ctx, kmsCache, org.GetPublicId(), at.GetPublicId(), encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, at.GetToken(), got)
+	})
+	t.Run("other-public-id", func(t *testing.T) {
+		_, err := DecryptToken(ctx, kmsCache, org.GetPublicId(), "at_1234567890", encrypted)
+		assert.Error(t, err)
+	})
+	t.Run("unknown-version", func(t *testing.T) {
+		_, err := DecryptToken(ctx, kmsCache, org.GetPublicId(), at.GetPublicId(), "s9"+encrypted[2:])
+		assert.Error(t, err)
+	})
+	t.Run("missing-encrypted-token", func(t *testing.T) {
+		_, err := DecryptToken(ctx, kmsCache, org.GetPublicId(), at.GetPublicId(), "")
+		assert.Error(t, err)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/stretchr/testify/assert"
 )
@@ -71,4 +72,13 @@ func Test_GetOpts(t *testing.T) {
 		opts = getOpts(WithIamOptions(iam.WithName("foobar")))
 		assert.NotEmpty(opts.withIamOptions)
 	})
+	t.Run("WithReaderWriter", func(t *testing.T) {
+		assert := assert.New(t)
+		rw := &db.Db{}
+		opts := getOpts(WithReaderWriter(rw, rw))
+		testOpts := getDefaultOptions()
+		testOpts.withReader = rw
+		testOpts.withWriter = rw
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package authtoken
+
+const (
+	// tokenDurationsQuery returns the auth token time to live and time to
+	// stale of an auth method. Values set on the auth method override the
+	// values set on its scope. A zero value is returned if neither is set.
+	tokenDurationsQuery = `
+select coalesce(am.auth_token_time_to_live_seconds,  s.auth_token_time_to_live_seconds,  0) as time_to_live_seconds,
+       coalesce(am.auth_token_time_to_stale_seconds, s.auth_token_time_to_stale_seconds, 0) as time_to_stale_seconds
+  from auth_method am
+  join iam_scope s
+    on s.public_id = am.scope_id
+ where am.public_id = @auth_method_id;
+`
+
+	// deleteUserAuthTokensQuery deletes every auth token of a user and
+	// returns their ids.
+	deleteUserAuthTokensQuery = `
+delete from auth_token
+ where auth_account_id in (select public_id
+                             from auth_account
+                            where iam_user_id = @iam_user_id)
+returning public_id;
+`
+
+	// deleteAuthMethodAuthTokensQuery deletes every auth token issued by an
+	// auth method and returns their ids.
+	deleteAuthMethodAuthTokensQuery = `
+delete from auth_token
+ where auth_account_id in (select public_id
+                             from auth_account
+                            where auth_method_id = @auth_method_id)
+returning public_id;
+`
+)
@@ -5,6 +5,7 @@ package authtoken
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -61,6 +62,8 @@ func NewRepository(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms,
 // Auth Token.  The returned auth token contains the auth token value. The
 // provided IAM User ID must be associated to the provided auth account id or an
 // error will be returned.  The Auth Token will have a Status of "issued".
+// The expiration time is based on the time to live set on the account's auth
+// method or its scope, falling back to the repository's time to live.
 // The WithStatus and WithPublicId options are supported and all other options
 // are ignored.
 func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User, withAuthAccountId string, opt ...Option) (*AuthToken, error) {
@@ -101,14 +104,6 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get database wrapper"))
 	}
 
-	// We truncate the expiration time to the nearest second to make testing in different platforms with
-	// different time resolutions easier.
-	expiration, err := ptypes.TimestampProto(time.Now().Add(r.timeToLiveDuration).Truncate(time.Second))
-	if err != nil {
-		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidTimeStamp))
-	}
-	at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
-
 	var newAuthToken *AuthToken
 	_, err = r.writer.DoTx(
 		ctx,
@@ -128,6 +123,18 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 			at.AuthMethodId = acct.GetAuthMethodId()
 			at.IamUserId = acct.GetIamUserId()
 
+			timeToLive, _, err := r.tokenDurations(ctx, read, acct.GetAuthMethodId())
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// We truncate the expiration time to the nearest second to make testing in different platforms with
+			// different time resolutions easier.
+			expiration, err := ptypes.TimestampProto(time.Now().Add(timeToLive).Truncate(time.Second))
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidTimeStamp))
+			}
+			at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
+
 			newAuthToken = at.clone()
 			if err := newAuthToken.encrypt(ctx, databaseWrapper); err != nil {
 				return errors.Wrap(ctx, err, op)
@@ -156,40 +163,54 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 	if id == "" {
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
+	at, _, _, err := r.lookupAuthToken(ctx, id, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return at, nil
+}
+
+// lookupAuthToken returns the AuthToken for the provided id along with its
+// time to live and time to stale, which are resolved like in CreateAuthToken.
+// Returns nil, 0, 0, nil if no AuthToken is found for id.
+func (r *Repository) lookupAuthToken(ctx context.Context, id string, opt ...Option) (*AuthToken, time.Duration, time.Duration, error) {
+	const op = "authtoken.(Repository).lookupAuthToken"
 	opts := getOpts(opt...)
 
-	// use the view, to bring in the required account columns. Just don't forget
-	// to convert it before returning it.
+	// use the view, to bring in the required account columns and the token
+	// lifetimes. Just don't forget to convert it before returning it.
 	atv := allocAuthTokenView()
 	atv.PublicId = id
 	if err := r.reader.LookupByPublicId(ctx, atv); err != nil {
 		if errors.IsNotFoundError(err) {
-			return nil, nil
+			return nil, 0, 0, nil
 		}
-		return nil, errors.Wrap(ctx, err, op)
+		return nil, 0, 0, errors.Wrap(ctx, err, op)
 	}
 
 	at := atv.toAuthToken()
 	if opts.withTokenValue {
 		databaseWrapper, err := r.kms.GetWrapper(ctx, at.GetScopeId(), kms.KeyPurposeDatabase, kms.WithKeyId(at.GetKeyId()))
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+			return nil, 0, 0, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
 		}
 		if err := at.decrypt(ctx, databaseWrapper); err != nil {
-			return nil, errors.Wrap(ctx, err, op)
+			return nil, 0, 0, errors.Wrap(ctx, err, op)
 		}
 	}
 
 	at.CtToken = nil
 	at.KeyId = ""
-	return at, nil
+	timeToLive, timeToStale := r.resolveTokenDurations(atv.TimeToLiveSeconds, atv.TimeToStaleSeconds)
+	return at, timeToLive, timeToStale, nil
 }
 
 // ValidateToken returns a token from storage if the auth token with the provided id and token exists.  The
 // approximate last accessed time may be updated depending on how long it has been since the last time the token
 // was validated.  If a token is returned it is guaranteed to be valid. For security reasons, the actual token
 // value is not included in the returned AuthToken. If no valid auth token is found nil, nil is returned.
-// All options are ignored.
+// The time to live and time to stale of the token are resolved from its auth method and scope like in
+// CreateAuthToken. All options are ignored.
 //
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
@@ -201,7 +222,9 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 		return nil, errors.New(ctx, errors.InvalidPublicId, op, "missing public id")
 	}
 
-	retAT, err := r.LookupAuthToken(ctx, id, withTokenValue())
+	// The durations are resolved with every lookup so changes to the auth
+	// method or scope settings apply to tokens which have already been issued.
+	retAT, timeToLive, timeToStale, err := r.lookupAuthToken(ctx, id, withTokenValue())
 	if err != nil {
 		retAT = nil
 		if errors.IsNotFoundError(err) {
@@ -222,12 +245,15 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
 	}
-
+	created, err := ptypes.Timestamp(retAT.GetCreateTime().GetTimestamp())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("create time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
 	now := time.Now()
 	sinceLastAccessed := now.Sub(lastAccessed) + timeSkew
 	// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
 	// if it can be eliminated.
-	if now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= r.timeToStaleDuration {
+	if now.After(exp.Add(-timeSkew)) || now.After(created.Add(timeToLive-timeSkew)) || sinceLastAccessed >= timeToStale {
 		// If the token has expired or has become too stale, delete it from the DB.
 		_, err = r.writer.DoTx(
 			ctx,
@@ -291,7 +317,8 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 }
 
 // ListAuthTokens lists auth tokens in the given scopes and supports the
-// WithLimit option.
+// WithLimit and WithStartPageAfterItem options. Auth tokens are ordered by
+// create time and public id.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
 	const op = "authtoken.(Repository).ListAuthTokens"
 	if len(withScopeIds) == 0 {
@@ -301,8 +328,14 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string,
 
 	// use the view, to bring in the required account columns. Just don't forget
 	// to convert them before returning them
+	where := "auth_account_id in (select public_id from auth_account where scope_id in (?))"
+	args := []any{withScopeIds}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
 	var atvs []*authTokenView
-	if err := r.reader.SearchWhere(ctx, &atvs, "auth_account_id in (select public_id from auth_account where scope_id in (?))", []any{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &atvs, where, args, db.WithLimit(opts.withLimit), db.WithOrder("create_time asc, public_id asc")); err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	authTokens := make([]*AuthToken, 0, len(atvs))
@@ -360,6 +393,90 @@ func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Opti
 	return rowsDeleted, nil
 }
 
+// DeleteUserAuthTokens deletes every auth token of the user with the provided
+// id and returns the ids of the deleted tokens.
+// Supported Options:
+//   - WithReaderWriter
+func (r *Repository) DeleteUserAuthTokens(ctx context.Context, userId string, opt ...Option) ([]string, error) {
+	const op = "authtoken.(Repository).DeleteUserAuthTokens"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	ids, err := r.deleteAuthTokens(ctx, deleteUserAuthTokensQuery, []any{sql.Named("iam_user_id", userId)}, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(userId))
+	}
+	return ids, nil
+}
+
+// DeleteAuthMethodAuthTokens deletes every auth token issued by the auth
+// method with the provided id and returns the ids of the deleted tokens.
+// Supported Options:
+//   - WithReaderWriter
+func (r *Repository) DeleteAuthMethodAuthTokens(ctx context.Context, authMethodId string, opt ...Option) ([]string, error) {
+	const op = "authtoken.(Repository).DeleteAuthMethodAuthTokens"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	ids, err := r.deleteAuthTokens(ctx, deleteAuthMethodAuthTokensQuery, []any{sql.Named("auth_method_id", authMethodId)}, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(authMethodId))
+	}
+	return ids, nil
+}
+
+// deleteAuthTokens runs query in the transaction passed with
+// WithReaderWriter, or in a new transaction if none was passed.
+func (r *Repository) deleteAuthTokens(ctx context.Context, query string, args []any, opt ...Option) ([]string, error) {
+	const op = "authtoken.(Repository).deleteAuthTokens"
+	opts := getOpts(opt...)
+	if opts.withWriter != nil {
+		ids, err := queryDeletedIds(ctx, opts.withWriter, query, args)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return ids, nil
+	}
+	var ids []string
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			ids, err = queryDeletedIds(ctx, w, query, args)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
+// queryDeletedIds runs a delete query returning the ids of the deleted auth
+// tokens. Tokens are not replicated, so they don't need oplog entries.
+func queryDeletedIds(ctx context.Context, w db.Writer, query string, args []any) ([]string, error) {
+	const op = "authtoken.queryDeletedIds"
+	rows, err := w.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // IssueAuthToken will retrieve the "pending" token and update it's status to
 // "issued".  If the token has already been issued, an error is returned with a
 // nil token.  If no token is found for the tokenRequestId an error is returned
@@ -446,3 +563,41 @@ func (r *Repository) CloseExpiredPendingTokens(ctx context.Context) (int, error)
 	}
 	return tokensClosed, nil
 }
+
+// tokenDurations returns the time to live and time to stale of auth tokens
+// issued by authMethodId. Durations set on the auth method override the
+// durations set on its scope, which override the repository's durations.
+func (r *Repository) tokenDurations(ctx context.Context, reader db.Reader, authMethodId string) (time.Duration, time.Duration, error) {
+	const op = "authtoken.(Repository).tokenDurations"
+	timeToLive, timeToStale := r.timeToLiveDuration, r.timeToStaleDuration
+	rows, err := reader.Query(ctx, tokenDurationsQuery, []any{sql.Named("auth_method_id", authMethodId)})
+	if err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ttlSeconds, staleSeconds int64
+		if err := rows.Scan(&ttlSeconds, &staleSeconds); err != nil {
+			return 0, 0, errors.Wrap(ctx, err, op)
+		}
+		timeToLive, timeToStale = r.resolveTokenDurations(ttlSeconds, staleSeconds)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, errors.Wrap(ctx, err, op)
+	}
+	return timeToLive, timeToStale, nil
+}
+
+// resolveTokenDurations returns the time to live and time to stale in seconds
+// set on an auth method or its scope, using the repository's durations for
+// those which are zero.
+func (r *Repository) resolveTokenDurations(ttlSeconds, staleSeconds int64) (time.Duration, time.Duration) {
+	timeToLive, timeToStale := r.timeToLiveDuration, r.timeToStaleDuration
+	if ttlSeconds > 0 {
+		timeToLive = time.Duration(ttlSeconds) * time.Second
+	}
+	if staleSeconds > 0 {
+		timeToStale = time.Duration(staleSeconds) * time.Second
+	}
+	return timeToLive, timeToStale
+}
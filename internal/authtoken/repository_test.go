@@ -5,6 +5,7 @@ package authtoken
 
 import (
 	"context"
+	stderrors "errors"
 	"sort"
 	"testing"
 	"time"
@@ -573,6 +574,80 @@ func TestRepository_ValidateToken_expired(t *testing.T) {
 	}
 }
 
+func TestRepository_TokenDurations(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	baseAT := TestAuthToken(t, conn, kms, org.GetPublicId())
+	aAcct := allocAuthAccount()
+	aAcct.PublicId = baseAT.GetAuthAccountId()
+	require.NoError(t, rw.LookupByPublicId(ctx, aAcct))
+	iamUser, _, err := iamRepo.LookupUser(ctx, aAcct.GetIamUserId())
+	require.NoError(t, err)
+
+	pwRepo, err := password.NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	expiresIn := func(t *testing.T, at *AuthToken) time.Duration {
+		t.Helper()
+		exp, err := ptypes.Timestamp(at.GetExpirationTime().GetTimestamp())
+		require.NoError(t, err)
+		return time.Until(exp)
+	}
+
+	t.Run("repository-default", func(t *testing.T) {
+		at, err := repo.CreateAuthToken(ctx, iamUser, baseAT.GetAuthAccountId())
+		require.NoError(t, err)
+		assert.InDelta(t, defaultTokenTimeToLiveDuration, expiresIn(t, at), float64(time.Minute))
+	})
+	t.Run("scope", func(t *testing.T) {
+		org.AuthTokenTimeToLiveSeconds = 3600
+		updated, _, err := iamRepo.UpdateScope(ctx, org, org.Version, []string{"AuthTokenTimeToLiveSeconds"})
+		require.NoError(t, err)
+		org = updated
+
+		at, err := repo.CreateAuthToken(ctx, iamUser, baseAT.GetAuthAccountId())
+		require.NoError(t, err)
+		assert.InDelta(t, time.Hour, expiresIn(t, at), float64(time.Minute))
+	})
+	t.Run("auth-method-overrides-scope", func(t *testing.T) {
+		am, err := pwRepo.LookupAuthMethod(ctx, baseAT.GetAuthMethodId())
+		require.NoError(t, err)
+		am.AuthTokenTimeToLiveSeconds = 600
+		_, _, err = pwRepo.UpdateAuthMethod(ctx, am, am.Version, []string{"AuthTokenTimeToLiveSeconds"})
+		require.NoError(t, err)
+
+		at, err := repo.CreateAuthToken(ctx, iamUser, baseAT.GetAuthAccountId())
+		require.NoError(t, err)
+		assert.InDelta(t, 10*time.Minute, expiresIn(t, at), float64(time.Minute))
+	})
+	t.Run("resolved-at-validation", func(t *testing.T) {
+		at, err := repo.CreateAuthToken(ctx, iamUser, baseAT.GetAuthAccountId())
+		require.NoError(t, err)
+		got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(t, err)
+		require.NotNil(t, got)
+
+		am, err := pwRepo.LookupAuthMethod(ctx, baseAT.GetAuthMethodId())
+		require.NoError(t, err)
+		am.AuthTokenTimeToStaleSeconds = 1
+		_, _, err = pwRepo.UpdateAuthMethod(ctx, am, am.Version, []string{"AuthTokenTimeToStaleSeconds"})
+		require.NoError(t, err)
+
+		time.Sleep(1500 * time.Millisecond)
+		got, err = repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
 func TestRepository_DeleteAuthToken(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -636,6 +711,95 @@ func TestRepository_DeleteAuthToken(t *testing.T) {
 	}
 }
 
+func TestRepository_DeleteUserAuthTokens(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("missing-user-id", func(t *testing.T) {
+		got, err := repo.DeleteUserAuthTokens(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.DeleteUserAuthTokens(ctx, at.GetIamUserId())
+		require.NoError(err)
+		assert.Equal([]string{at.GetPublicId()}, got)
+
+		found, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		found, err = repo.LookupAuthToken(ctx, other.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+
+		got, err = repo.DeleteUserAuthTokens(ctx, at.GetIamUserId())
+		require.NoError(err)
+		assert.Empty(got)
+	})
+	t.Run("with-reader-writer", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		// The delete runs in the caller's transaction, so it is rolled back
+		// with it.
+		errRollback := stderrors.New("rollback")
+		_, err := rw.DoTx(ctx, 1, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+			got, err := repo.DeleteUserAuthTokens(ctx, at.GetIamUserId(), WithReaderWriter(r, w))
+			require.NoError(err)
+			assert.Equal([]string{at.GetPublicId()}, got)
+			return errRollback
+		})
+		require.ErrorIs(err, errRollback)
+
+		found, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+}
+
+func TestRepository_DeleteAuthMethodAuthTokens(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("missing-auth-method-id", func(t *testing.T) {
+		got, err := repo.DeleteAuthMethodAuthTokens(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.DeleteAuthMethodAuthTokens(ctx, at.GetAuthMethodId())
+		require.NoError(err)
+		assert.Equal([]string{at.GetPublicId()}, got)
+
+		found, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		found, err = repo.LookupAuthToken(ctx, other.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+}
+
 func TestRepository_ListAuthTokens(t *testing.T) {
 	ctx := context.Background()
 	conn, _ := db.TestSetup(t, "postgres")
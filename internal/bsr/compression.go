@@ -5,7 +5,13 @@ package bsr
 
 import (
 	"bytes"
+	"compress/gzip"
+	"fmt"
 	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
@@ -19,6 +25,7 @@ type Compression uint8
 const (
 	NoCompression Compression = iota
 	GzipCompression
+	ZstdCompression
 )
 
 func (c Compression) String() string {
@@ -27,6 +34,8 @@ func (c Compression) String() string {
 		return "no compression"
 	case GzipCompression:
 		return "gzip"
+	case ZstdCompression:
+		return "zstd"
 	default:
 		return "unknown compression"
 	}
@@ -35,12 +44,52 @@ func (c Compression) String() string {
 // ValidCompression checks if a given Compression is valid.
 func ValidCompression(c Compression) bool {
 	switch c {
-	case NoCompression, GzipCompression:
+	case NoCompression, GzipCompression, ZstdCompression:
 		return true
 	}
 	return false
 }
 
+// ParseCompression returns the Compression named s, which is one of "none",
+// "gzip" or "zstd".
+func ParseCompression(s string) (Compression, error) {
+	const op = "bsr.ParseCompression"
+	switch strings.ToLower(s) {
+	case "none":
+		return NoCompression, nil
+	case "gzip":
+		return GzipCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	}
+	return NoCompression, fmt.Errorf("%s: unknown compression %q: %w", op, s, ErrInvalidParameter)
+}
+
+// Compression levels. DefaultCompressionLevel selects the default level of
+// a Compression. Gzip supports levels 1 (fastest) to 9 (best compression).
+// Zstd supports the levels 1 (fastest) to 22 (best compression) of the zstd
+// command line tool, which are mapped to the closest level supported by the
+// encoder.
+const (
+	DefaultCompressionLevel = 0
+	MaxGzipCompressionLevel = gzip.BestCompression
+	MaxZstdCompressionLevel = 22
+)
+
+// ValidCompressionLevel checks if level is a valid level for the Compression
+// c. NoCompression only supports DefaultCompressionLevel.
+func ValidCompressionLevel(c Compression, level int) bool {
+	switch c {
+	case NoCompression:
+		return level == DefaultCompressionLevel
+	case GzipCompression:
+		return level >= DefaultCompressionLevel && level <= MaxGzipCompressionLevel
+	case ZstdCompression:
+		return level >= DefaultCompressionLevel && level <= MaxZstdCompressionLevel
+	}
+	return false
+}
+
 type nullCompressionWriter struct {
 	*bytes.Buffer
 }
@@ -64,3 +113,76 @@ func (w *nullCompressionReader) Close() error {
 func newNullCompressionReader(b *bytes.Buffer) io.ReadCloser {
 	return &nullCompressionReader{Buffer: b}
 }
+
+func newGzipCompressionWriter(b *bytes.Buffer, level int) (io.WriteCloser, error) {
+	if level == DefaultCompressionLevel {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(b, level)
+}
+
+// zstdEncoders caches a zstd.Encoder per compression level. Encoders are
+// expensive to create, but safe for concurrent use of EncodeAll.
+var zstdEncoders sync.Map
+
+func zstdEncoder(level int) (*zstd.Encoder, error) {
+	if e, ok := zstdEncoders.Load(level); ok {
+		return e.(*zstd.Encoder), nil
+	}
+	l := zstd.SpeedDefault
+	if level != DefaultCompressionLevel {
+		l = zstd.EncoderLevelFromZstd(level)
+	}
+	// Chunks are already protected by a CRC.
+	e, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(l), zstd.WithEncoderCRC(false))
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := zstdEncoders.LoadOrStore(level, e)
+	return actual.(*zstd.Encoder), nil
+}
+
+// zstdDecoder returns the zstd.Decoder shared by all chunk decoders. It is
+// safe for concurrent use of DecodeAll and never decodes more than
+// MaxChunkDataLength bytes.
+var zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(MaxChunkDataLength))
+})
+
+// zstdCompressionWriter compresses the data written to it into a single zstd
+// frame, which is written to the buffer on Close.
+type zstdCompressionWriter struct {
+	enc  *zstd.Encoder
+	b    *bytes.Buffer
+	data []byte
+}
+
+func (w *zstdCompressionWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func (w *zstdCompressionWriter) Close() error {
+	_, err := w.b.Write(w.enc.EncodeAll(w.data, w.b.AvailableBuffer()))
+	return err
+}
+
+func newZstdCompressionWriter(b *bytes.Buffer, level int) (io.WriteCloser, error) {
+	enc, err := zstdEncoder(level)
+	if err != nil {
+		return nil, err
+	}
+	return &zstdCompressionWriter{enc: enc, b: b}, nil
+}
+
+func newZstdCompressionReader(b *bytes.Buffer) (io.ReadCloser, error) {
+	dec, err := zstdDecoder()
+	if err != nil {
+		return nil, err
+	}
+	data, err := dec.DecodeAll(b.Bytes(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return newNullCompressionReader(bytes.NewBuffer(data)), nil
+}
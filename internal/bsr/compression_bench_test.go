@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package bsr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// benchCompressions are the compressions and levels compared by the
+// compression benchmarks.
+var benchCompressions = []struct {
+	c     Compression
+	level int
+}{
+	{NoCompression, DefaultCompressionLevel},
+	{GzipCompression, 1},
+	{GzipCompression, DefaultCompressionLevel},
+	{GzipCompression, MaxGzipCompressionLevel},
+	{ZstdCompression, 1},
+	{ZstdCompression, DefaultCompressionLevel},
+	{ZstdCompression, 9},
+	{ZstdCompression, 19},
+}
+
+// newTerminalChunks returns n chunks of chunkSize bytes of text resembling
+// the output of a shell, which unlike zeroed data does not compress to
+// almost nothing.
+func newTerminalChunks(n, chunkSize int) []Chunk {
+	words := []string{
+		"drwxr-xr-x", "-rw-r--r--", "root", "ubuntu", "4096", "Mar", "16", "10:47",
+		"bin", "etc", "home", "var", "log", "syslog", "error:", "warning:", "ok",
+		"\x1b[01;34m", "\x1b[0m", "$", "sudo", "systemctl", "status", "nginx",
+	}
+	r := rand.New(rand.NewSource(1))
+	chunks := make([]Chunk, n)
+	for i := range chunks {
+		var b bytes.Buffer
+		for b.Len() < chunkSize {
+			b.WriteString(words[r.Intn(len(words))])
+			if r.Intn(8) == 0 {
+				b.WriteString("\r\n")
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		c := newTestChunk(0)
+		c.Data = b.Bytes()[:chunkSize]
+		chunks[i] = c
+	}
+	return chunks
+}
+
+func BenchmarkEncodeCompression(b *testing.B) {
+	ctx := context.Background()
+	for _, chunkSize := range []int{256, 4096, 65536} {
+		chunks := newTerminalChunks(250, chunkSize)
+		for _, bc := range benchCompressions {
+			b.Run(fmt.Sprintf("%s-%d/%d", bc.c, bc.level, chunkSize), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(chunks) * chunkSize))
+				var encoded int
+				for i := 0; i < b.N; i++ {
+					var buf bytes.Buffer
+					enc, err := NewChunkEncoder(ctx, &buf, bc.c, NoEncryption, WithCompressionLevel(bc.level))
+					if err != nil {
+						b.Fatal("NewChunkEncoder:", err)
+					}
+					for _, c := range chunks {
+						if _, err := enc.Encode(ctx, c); err != nil {
+							b.Fatal("Encode:", err)
+						}
+					}
+					encoded = buf.Len()
+				}
+				b.ReportMetric(float64(len(chunks)*chunkSize)/float64(encoded), "ratio")
+			})
+		}
+	}
+}
+
+func BenchmarkDecodeCompression(b *testing.B) {
+	ctx := context.Background()
+	for _, chunkSize := range []int{256, 4096, 65536} {
+		chunks := newTerminalChunks(250, chunkSize)
+		for _, bc := range benchCompressions {
+			b.Run(fmt.Sprintf("%s-%d/%d", bc.c, bc.level, chunkSize), func(b *testing.B) {
+				var buf bytes.Buffer
+				enc, err := NewChunkEncoder(ctx, &buf, bc.c, NoEncryption, WithCompressionLevel(bc.level))
+				if err != nil {
+					b.Fatal("NewChunkEncoder:", err)
+				}
+				h, err := NewHeader(ctx, "TEST", Inbound, NewTimestamp(time.Now()), bc.c, NoEncryption, "sess_123456789")
+				if err != nil {
+					b.Fatal("NewHeader:", err)
+				}
+				for _, c := range append([]Chunk{h}, chunks...) {
+					if _, err := enc.Encode(ctx, c); err != nil {
+						b.Fatal("Encode:", err)
+					}
+				}
+				encoded := buf.Bytes()
+
+				b.ReportAllocs()
+				b.SetBytes(int64(len(chunks) * chunkSize))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					dec, err := NewChunkDecoder(ctx, bytes.NewReader(encoded))
+					if err != nil {
+						b.Fatal("NewChunkDecoder:", err)
+					}
+					for {
+						if _, err := dec.Decode(ctx); err != nil {
+							if err == io.EOF {
+								break
+							}
+							b.Fatal("Decode:", err)
+						}
+					}
+				}
+			})
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package bsr_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/boundary/internal/bsr"
@@ -26,6 +27,11 @@ func TestValidCompression(t *testing.T) {
 			bsr.GzipCompression,
 			true,
 		},
+		{
+			bsr.ZstdCompression.String(),
+			bsr.ZstdCompression,
+			true,
+		},
 		{
 			"something else",
 			bsr.Compression(255),
@@ -57,6 +63,11 @@ func TestCompressionString(t *testing.T) {
 			bsr.GzipCompression,
 			"gzip",
 		},
+		{
+			bsr.ZstdCompression.String(),
+			bsr.ZstdCompression,
+			"zstd",
+		},
 		{
 			"something else",
 			bsr.Compression(255),
@@ -71,3 +82,55 @@ func TestCompressionString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCompression(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    bsr.Compression
+		wantErr string
+	}{
+		{"none", bsr.NoCompression, ""},
+		{"gzip", bsr.GzipCompression, ""},
+		{"zstd", bsr.ZstdCompression, ""},
+		{"ZSTD", bsr.ZstdCompression, ""},
+		{"", bsr.NoCompression, `bsr.ParseCompression: unknown compression "": invalid parameter`},
+		{"lz4", bsr.NoCompression, `bsr.ParseCompression: unknown compression "lz4": invalid parameter`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := bsr.ParseCompression(tc.in)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidCompressionLevel(t *testing.T) {
+	cases := []struct {
+		c     bsr.Compression
+		level int
+		want  bool
+	}{
+		{bsr.NoCompression, bsr.DefaultCompressionLevel, true},
+		{bsr.NoCompression, 1, false},
+		{bsr.GzipCompression, bsr.DefaultCompressionLevel, true},
+		{bsr.GzipCompression, bsr.MaxGzipCompressionLevel, true},
+		{bsr.GzipCompression, bsr.MaxGzipCompressionLevel + 1, false},
+		{bsr.GzipCompression, -1, false},
+		{bsr.ZstdCompression, bsr.DefaultCompressionLevel, true},
+		{bsr.ZstdCompression, bsr.MaxZstdCompressionLevel, true},
+		{bsr.ZstdCompression, bsr.MaxZstdCompressionLevel + 1, false},
+		{bsr.Compression(255), bsr.DefaultCompressionLevel, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s-%d", tc.c, tc.level), func(t *testing.T) {
+			assert.Equal(t, tc.want, bsr.ValidCompressionLevel(tc.c, tc.level))
+		})
+	}
+}
@@ -92,3 +92,155 @@ func ToAsciicast(ctx context.Context, session *bsr.Session, tmp storage.TempFile
 		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedProtocol)
 	}
 }
+
+// ToTranscript accepts a bsr.Session and will convert the underlying BSR channel
+// file of a shell or exec session program into a plain text transcript.
+// Each line of the transcript is prefixed with the time the line started and the
+// direction of the data.
+// The tempFs will be used to write the transcript to disk
+// It returns an io.Reader to the converted transcript.
+// This supports the following options:
+//   - WithChannelId to indicate this conversion should occur on a channel on a multiplexed session
+func ToTranscript(ctx context.Context, session *bsr.Session, tmp storage.TempFile, connectionId string, options ...Option) (io.ReadCloser, error) {
+	const op = "convert.ToTranscript"
+
+	switch {
+	case is.Nil(session):
+		return nil, fmt.Errorf("%s: missing session: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(session.Meta):
+		return nil, fmt.Errorf("%s: missing session meta: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(tmp):
+		return nil, fmt.Errorf("%s: missing temp file: %w", op, bsr.ErrInvalidParameter)
+	case connectionId == "":
+		return nil, fmt.Errorf("%s: missing connection id: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	opts := getOpts(options...)
+
+	switch session.Meta.Protocol {
+	case ssh.Protocol:
+		ch, closeChannel, err := openChannel(ctx, session, connectionId, opts.withChannelId)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer closeChannel()
+
+		switch chs := ch.Summary.(type) {
+		case *ssh.ChannelSummary:
+			switch chs.SessionProgram {
+			case ssh.Shell, ssh.Exec:
+				inScanner, err := ch.OpenMessageScanner(ctx, bsr.Inbound)
+				if err != nil {
+					if !is.Nil(inScanner) {
+						inScanner.Close()
+					}
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				defer inScanner.Close()
+
+				outScanner, err := ch.OpenMessageScanner(ctx, bsr.Outbound)
+				if err != nil {
+					if !is.Nil(outScanner) {
+						outScanner.Close()
+					}
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				defer outScanner.Close()
+				return sshChannelToTranscript(ctx, inScanner, outScanner, tmp)
+			case "":
+				return nil, fmt.Errorf("%s: session program not set for transcript conversion", op)
+			default:
+				return nil, fmt.Errorf("%s: unsupported %q session program for transcript conversion", op, chs.SessionProgram)
+			}
+		default:
+			return nil, fmt.Errorf("%s: unexpected error occurred with channel summary. possibly a malformed Boundary Session Recording", op)
+		}
+
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedProtocol)
+	}
+}
+
+// ToJsonl accepts a bsr.Session and will convert the underlying BSR channel
+// request files into a stream of JSON lines, one for every request sent in
+// either direction of the channel.
+// The tempFs will be used to write the JSON lines to disk
+// It returns an io.Reader to the converted JSON lines.
+// This supports the following options:
+//   - WithChannelId to indicate this conversion should occur on a channel on a multiplexed session
+func ToJsonl(ctx context.Context, session *bsr.Session, tmp storage.TempFile, connectionId string, options ...Option) (io.ReadCloser, error) {
+	const op = "convert.ToJsonl"
+
+	switch {
+	case is.Nil(session):
+		return nil, fmt.Errorf("%s: missing session: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(session.Meta):
+		return nil, fmt.Errorf("%s: missing session meta: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(tmp):
+		return nil, fmt.Errorf("%s: missing temp file: %w", op, bsr.ErrInvalidParameter)
+	case connectionId == "":
+		return nil, fmt.Errorf("%s: missing connection id: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	opts := getOpts(options...)
+
+	switch session.Meta.Protocol {
+	case ssh.Protocol:
+		ch, closeChannel, err := openChannel(ctx, session, connectionId, opts.withChannelId)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer closeChannel()
+
+		switch ch.Summary.(type) {
+		case *ssh.ChannelSummary:
+			inScanner, err := ch.OpenRequestScanner(ctx, bsr.Inbound)
+			if err != nil {
+				if !is.Nil(inScanner) {
+					inScanner.Close()
+				}
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			defer inScanner.Close()
+
+			outScanner, err := ch.OpenRequestScanner(ctx, bsr.Outbound)
+			if err != nil {
+				if !is.Nil(outScanner) {
+					outScanner.Close()
+				}
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			defer outScanner.Close()
+			return sshChannelToJsonl(ctx, inScanner, outScanner, tmp)
+		default:
+			return nil, fmt.Errorf("%s: unexpected error occurred with channel summary. possibly a malformed Boundary Session Recording", op)
+		}
+
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrUnsupportedProtocol)
+	}
+}
+
+// openChannel opens the channel chanId of the connection connectionId of
+// the ssh session. The returned func closes the channel and its connection
+// and must be called once the channel is no longer used.
+func openChannel(ctx context.Context, session *bsr.Session, connectionId, chanId string) (*bsr.Channel, func(), error) {
+	if chanId == "" {
+		return nil, nil, fmt.Errorf("protocol %q requires channel id to convert: %w", ssh.Protocol, bsr.ErrInvalidParameter)
+	}
+
+	conn, err := session.OpenConnection(ctx, connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch, err := conn.OpenChannel(ctx, chanId)
+	if err != nil {
+		conn.Close(ctx)
+		return nil, nil, err
+	}
+	return ch, func() {
+		ch.Close(ctx)
+		conn.Close(ctx)
+	}, nil
+}
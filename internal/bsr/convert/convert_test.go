@@ -562,3 +562,261 @@ func TestConvert_ToAsciicast_Scanner(t *testing.T) {
 		})
 	}
 }
+
+// testOpenSession writes a session recording with a single connection and
+// channel with the channel summary chs and opens it. The data files of the
+// channel in writeFiles are written for both directions; they can be
+// "requests" and "messages".
+func testOpenSession(t *testing.T, fs *fstest.MemFS, id, connectionId string, protocol bsr.Protocol, chs bsr.ChannelSummary, writeFiles ...string) *bsr.Session {
+	t.Helper()
+	ctx := context.Background()
+
+	keys, err := kms.CreateKeys(ctx, kms.TestWrapper(t), fmt.Sprintf("s_%s", id))
+	require.NoError(t, err)
+	keyFn := func(w kms.WrappedKeys) (kms.UnwrappedKeys, error) {
+		return kms.UnwrappedKeys{
+			BsrKey:  keys.BsrKey,
+			PrivKey: keys.PrivKey,
+		}, nil
+	}
+
+	srm := &bsr.SessionRecordingMeta{
+		Id:       fmt.Sprintf("sr_%s", id),
+		Protocol: protocol,
+	}
+	sesh, err := bsr.NewSession(ctx, srm, bsr.TestSessionMeta(fmt.Sprintf("s_%s", id)), fs, keys, bsr.WithSupportsMultiplex(true))
+	require.NoError(t, err)
+	require.NoError(t, sesh.EncodeSummary(ctx, &bsr.BaseSessionSummary{Id: srm.Id}))
+
+	conn, err := sesh.NewConnection(ctx, &bsr.ConnectionRecordingMeta{Id: connectionId})
+	require.NoError(t, err)
+	require.NoError(t, conn.EncodeSummary(ctx, &bsr.BaseConnectionSummary{
+		Id:           connectionId,
+		ChannelCount: 1,
+	}))
+
+	ch, err := conn.NewChannel(ctx, &bsr.ChannelRecordingMeta{
+		Id:   chs.GetId(),
+		Type: "chan",
+	})
+	require.NoError(t, err)
+	require.NoError(t, ch.EncodeSummary(ctx, chs))
+
+	for _, f := range writeFiles {
+		for _, d := range []bsr.Direction{bsr.Inbound, bsr.Outbound} {
+			var w io.Writer
+			switch f {
+			case "requests":
+				w, err = ch.NewRequestsWriter(ctx, d)
+			case "messages":
+				w, err = ch.NewMessagesWriter(ctx, d)
+			}
+			require.NoError(t, err)
+			require.NoError(t, writeToChannels(ctx, w, testChunks(fmt.Sprintf("s_%s", id), d, protocol)...))
+			require.NoError(t, w.(io.Closer).Close())
+		}
+	}
+
+	ch.Close(ctx)
+	conn.Close(ctx)
+	sesh.Close(ctx)
+
+	opSesh, err := bsr.OpenSession(ctx, srm.Id, fs, keyFn)
+	require.NoError(t, err)
+	require.NotNil(t, opSesh)
+	return opSesh
+}
+
+func TestConvert_ToTranscript(t *testing.T) {
+	ctx := context.Background()
+
+	fs := &fstest.MemFS{}
+	tmpfile, err := fstest.NewTempFile(t.Name())
+	require.NoError(t, err)
+
+	connectionId := "test_connection"
+	channelId := "test_channel"
+	summary := func(p ssh.SessionProgram) bsr.ChannelSummary {
+		return &ssh.ChannelSummary{
+			ChannelSummary: &bsr.BaseChannelSummary{
+				Id:                    channelId,
+				ConnectionRecordingId: connectionId,
+			},
+			SessionProgram: p,
+		}
+	}
+
+	cases := []struct {
+		name       string
+		id         string
+		protocol   bsr.Protocol
+		chs        bsr.ChannelSummary
+		writeFiles []string
+		channelId  string
+		wantErr    error
+	}{
+		{
+			name:       "shell",
+			id:         "91234567890",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Shell),
+			writeFiles: []string{"messages"},
+			channelId:  channelId,
+		},
+		{
+			name:       "exec",
+			id:         "91234567891",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Exec),
+			writeFiles: []string{"messages"},
+			channelId:  channelId,
+		},
+		{
+			name:       "unsupported session program - subsystem",
+			id:         "91234567892",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Subsystem),
+			writeFiles: []string{"messages"},
+			channelId:  channelId,
+			wantErr:    errors.New("convert.ToTranscript: unsupported \"subsystem\" session program for transcript conversion"),
+		},
+		{
+			name:       "nil session program",
+			id:         "91234567893",
+			protocol:   ssh.Protocol,
+			chs:        summary(""),
+			writeFiles: []string{"messages"},
+			channelId:  channelId,
+			wantErr:    errors.New("convert.ToTranscript: session program not set for transcript conversion"),
+		},
+		{
+			name:      "missing messages data file",
+			id:        "91234567894",
+			protocol:  ssh.Protocol,
+			chs:       summary(ssh.Shell),
+			channelId: channelId,
+			wantErr:   errors.New("convert.ToTranscript: bsr.(Channel).OpenMessageScanner: file messages-inbound.data does not exist: does not exist"),
+		},
+		{
+			name:       "missing channel id",
+			id:         "91234567895",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Shell),
+			writeFiles: []string{"messages"},
+			wantErr:    errors.New("convert.ToTranscript: protocol \"BSSH\" requires channel id to convert: invalid parameter"),
+		},
+		{
+			name:       "unsupported protocol",
+			id:         "91234567896",
+			protocol:   bsr.Protocol("UNSUPPORTED_PROTOCOL"),
+			chs:        &bsr.BaseChannelSummary{Id: channelId, ConnectionRecordingId: connectionId},
+			writeFiles: []string{"messages"},
+			channelId:  channelId,
+			wantErr:    errors.New("convert.ToTranscript: unsupported protocol"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opSesh := testOpenSession(t, fs, tc.id, connectionId, tc.protocol, tc.chs, tc.writeFiles...)
+
+			r, err := convert.ToTranscript(ctx, opSesh, tmpfile, connectionId, convert.WithChannelId(tc.channelId))
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Empty(t, got)
+		})
+	}
+}
+
+func TestConvert_ToJsonl(t *testing.T) {
+	ctx := context.Background()
+
+	fs := &fstest.MemFS{}
+	tmpfile, err := fstest.NewTempFile(t.Name())
+	require.NoError(t, err)
+
+	connectionId := "test_connection"
+	channelId := "test_channel"
+	summary := func(p ssh.SessionProgram) bsr.ChannelSummary {
+		return &ssh.ChannelSummary{
+			ChannelSummary: &bsr.BaseChannelSummary{
+				Id:                    channelId,
+				ConnectionRecordingId: connectionId,
+			},
+			SessionProgram: p,
+		}
+	}
+
+	cases := []struct {
+		name       string
+		id         string
+		protocol   bsr.Protocol
+		chs        bsr.ChannelSummary
+		writeFiles []string
+		channelId  string
+		wantErr    error
+	}{
+		{
+			name:       "shell",
+			id:         "10123456789",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Shell),
+			writeFiles: []string{"requests"},
+			channelId:  channelId,
+		},
+		{
+			name:       "subsystem",
+			id:         "10123456790",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Subsystem),
+			writeFiles: []string{"requests"},
+			channelId:  channelId,
+		},
+		{
+			name:      "missing requests data file",
+			id:        "10123456791",
+			protocol:  ssh.Protocol,
+			chs:       summary(ssh.Shell),
+			channelId: channelId,
+			wantErr:   errors.New("convert.ToJsonl: bsr.(Channel).OpenRequestScanner: file requests-inbound.data does not exist: does not exist"),
+		},
+		{
+			name:       "missing channel id",
+			id:         "10123456792",
+			protocol:   ssh.Protocol,
+			chs:        summary(ssh.Shell),
+			writeFiles: []string{"requests"},
+			wantErr:    errors.New("convert.ToJsonl: protocol \"BSSH\" requires channel id to convert: invalid parameter"),
+		},
+		{
+			name:       "unsupported protocol",
+			id:         "10123456793",
+			protocol:   bsr.Protocol("UNSUPPORTED_PROTOCOL"),
+			chs:        &bsr.BaseChannelSummary{Id: channelId, ConnectionRecordingId: connectionId},
+			writeFiles: []string{"requests"},
+			channelId:  channelId,
+			wantErr:    errors.New("convert.ToJsonl: unsupported protocol"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opSesh := testOpenSession(t, fs, tc.id, connectionId, tc.protocol, tc.chs, tc.writeFiles...)
+
+			r, err := convert.ToJsonl(ctx, opSesh, tmpfile, connectionId, convert.WithChannelId(tc.channelId))
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Empty(t, got)
+		})
+	}
+}
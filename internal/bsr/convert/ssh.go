@@ -14,6 +14,8 @@ import (
 	"github.com/hashicorp/boundary/internal/bsr/convert/internal/asciicast"
 	"github.com/hashicorp/boundary/internal/bsr/internal/is"
 	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // sshChannelToAsciicast will convert a recording of an ssh channel from a BSR
@@ -141,6 +143,167 @@ func sshChannelToAsciicast(ctx context.Context, requestScanner *bsr.ChunkScanner
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	return rewind(w)
+}
+
+// sshChannelToTranscript will convert a recording of an ssh channel from a BSR
+// into a plain text transcript. This expects two bsr.ChunkScanners. One for
+// the recording of inbound messages and one for the recording of outbound
+// messages. The data of both is written as lines of text in the order it was
+// recorded, each prefixed with the time the line started and the direction of
+// the data. Control characters and invalid UTF-8 are escaped. This also
+// expects a io.ReadWriteSeeker that will be used to write the transcript.
+// This is then reset and returned as a io.ReadCloser. The caller should call
+// Close on the returned io.ReadCloser after reading the transcript.
+func sshChannelToTranscript(ctx context.Context, inboundScanner *bsr.ChunkScanner, outboundScanner *bsr.ChunkScanner, w io.ReadWriteSeeker) (io.ReadCloser, error) {
+	const op = "convert.sshChannelToTranscript"
+
+	switch {
+	case is.Nil(inboundScanner):
+		return nil, fmt.Errorf("%s: missing inbound scanner: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(outboundScanner):
+		return nil, fmt.Errorf("%s: missing outbound scanner: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(w):
+		return nil, fmt.Errorf("%s: missing read write seeker: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	tw := newTranscriptWriter(w)
+	if err := chunkWalkOrdered(ctx, inboundScanner, outboundScanner, func(ctx context.Context, c bsr.Chunk) error {
+		switch c.GetProtocol() {
+		case ssh.Protocol:
+			switch c.GetType() {
+			case ssh.DataChunkType:
+				cc := c.(*ssh.DataChunk)
+				return tw.write(cc.GetDirection(), cc.GetTimestamp().AsTime(), cc.Data)
+			}
+			return nil
+		default:
+			return ErrUnsupportedProtocol
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tw.flushAll(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rewind(w)
+}
+
+// sshRequest is implemented by the chunks of every ssh request, which embed
+// the protobuf message of the request.
+type sshRequest interface {
+	proto.Message
+	GetRequestType() string
+}
+
+// jsonlRequest is a line of the JSON lines conversion of the requests of an
+// ssh channel.
+type jsonlRequest struct {
+	Timestamp   time.Time       `json:"timestamp"`
+	Direction   string          `json:"direction"`
+	RequestType string          `json:"request_type"`
+	Request     json.RawMessage `json:"request"`
+}
+
+// sshChannelToJsonl will convert the requests of an ssh channel from a BSR
+// into JSON lines. This expects two bsr.ChunkScanners. One for the recording
+// of inbound requests and one for the recording of outbound requests. Every
+// request is written as a line containing a JSON object with the time,
+// direction and type of the request and the fields of the request, in the
+// order the requests were recorded. This also expects a io.ReadWriteSeeker
+// that will be used to write the JSON lines. This is then reset and returned
+// as a io.ReadCloser. The caller should call Close on the returned
+// io.ReadCloser after reading the JSON lines.
+func sshChannelToJsonl(ctx context.Context, inboundScanner *bsr.ChunkScanner, outboundScanner *bsr.ChunkScanner, w io.ReadWriteSeeker) (io.ReadCloser, error) {
+	const op = "convert.sshChannelToJsonl"
+
+	switch {
+	case is.Nil(inboundScanner):
+		return nil, fmt.Errorf("%s: missing inbound scanner: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(outboundScanner):
+		return nil, fmt.Errorf("%s: missing outbound scanner: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(w):
+		return nil, fmt.Errorf("%s: missing read write seeker: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	enc := json.NewEncoder(w)
+	marshaler := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	if err := chunkWalkOrdered(ctx, inboundScanner, outboundScanner, func(ctx context.Context, c bsr.Chunk) error {
+		switch c.GetProtocol() {
+		case ssh.Protocol:
+			switch c.GetType() {
+			case bsr.ChunkHeader, bsr.ChunkEnd:
+				return nil
+			}
+			r, ok := c.(sshRequest)
+			if !ok {
+				return fmt.Errorf("unexpected %q chunk in requests: %w", c.GetType(), ErrMalformedBsr)
+			}
+			data, err := marshaler.Marshal(r)
+			if err != nil {
+				return err
+			}
+			return enc.Encode(&jsonlRequest{
+				Timestamp:   c.GetTimestamp().AsTime().UTC(),
+				Direction:   c.GetDirection().String(),
+				RequestType: r.GetRequestType(),
+				Request:     data,
+			})
+		default:
+			return ErrUnsupportedProtocol
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rewind(w)
+}
+
+// chunkWalkOrdered steps through the chunks returned by both ChunkScanners
+// in the order of their timestamps and calls the provided ChunkReadFunc f
+// for each. Chunks with the same timestamp are passed to f with the chunk of
+// s1 first. The walk terminates early if f or either scanner returns an
+// error, otherwise it terminates once both scanners reach io.EOF.
+func chunkWalkOrdered(ctx context.Context, s1, s2 *bsr.ChunkScanner, f bsr.ChunkReadFunc) error {
+	next := func(s *bsr.ChunkScanner) (bsr.Chunk, error) {
+		c, err := s.Scan(ctx)
+		if err == io.EOF {
+			return nil, nil
+		}
+		return c, err
+	}
+
+	c1, err := next(s1)
+	if err != nil {
+		return err
+	}
+	c2, err := next(s2)
+	if err != nil {
+		return err
+	}
+	for c1 != nil || c2 != nil {
+		if c2 == nil || (c1 != nil && !c2.GetTimestamp().AsTime().Before(c1.GetTimestamp().AsTime())) {
+			if err := f(ctx, c1); err != nil {
+				return err
+			}
+			if c1, err = next(s1); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := f(ctx, c2); err != nil {
+			return err
+		}
+		if c2, err = next(s2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewind seeks w back to its start and returns it as an io.ReadCloser.
+func rewind(w io.ReadWriteSeeker) (io.ReadCloser, error) {
 	if _, err := w.Seek(0, io.SeekStart); err != nil {
 		return nil, err
 	}
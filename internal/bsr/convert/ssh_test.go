@@ -9,6 +9,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -854,3 +855,322 @@ func Test_sshChannelToAsciicast(t *testing.T) {
 		})
 	}
 }
+
+func Test_sshChannelToTranscript(t *testing.T) {
+	ctx := context.Background()
+
+	ts := time.Date(2023, time.March, 16, 10, 47, 3, 14, time.UTC)
+	newW := func() io.ReadWriteSeeker {
+		f, err := os.CreateTemp("", "*.txt")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			os.Remove(f.Name())
+		})
+		return f
+	}
+	newScanner := func(d bsr.Direction, chunks ...bsr.Chunk) *bsr.ChunkScanner {
+		var buf bytes.Buffer
+		buf.Write(bsr.Magic.Bytes())
+		enc, err := bsr.NewChunkEncoder(ctx, &buf, bsr.NoCompression, bsr.NoEncryption)
+		require.NoError(t, err)
+
+		chunks = append([]bsr.Chunk{
+			&bsr.HeaderChunk{
+				BaseChunk: &bsr.BaseChunk{
+					Protocol:  ssh.Protocol,
+					Direction: d,
+					Timestamp: bsr.NewTimestamp(ts),
+					Type:      bsr.ChunkHeader,
+				},
+				Compression: bsr.NoCompression,
+				Encryption:  bsr.NoEncryption,
+				SessionId:   "sess_123456789",
+			},
+		}, chunks...)
+		chunks = append(chunks, &bsr.EndChunk{
+			BaseChunk: &bsr.BaseChunk{
+				Protocol:  ssh.Protocol,
+				Direction: d,
+				Timestamp: bsr.NewTimestamp(ts.Add(time.Second)),
+				Type:      bsr.ChunkEnd,
+			},
+		})
+		for _, c := range chunks {
+			_, err := enc.Encode(ctx, c)
+			require.NoError(t, err)
+		}
+		s, err := bsr.NewChunkScanner(ctx, bytes.NewBuffer(buf.Bytes()))
+		require.NoError(t, err)
+		return s
+	}
+	data := func(d bsr.Direction, offset time.Duration, s string) bsr.Chunk {
+		return &ssh.DataChunk{
+			BaseChunk: &bsr.BaseChunk{
+				Protocol:  ssh.Protocol,
+				Direction: d,
+				Timestamp: bsr.NewTimestamp(ts.Add(offset)),
+				Type:      ssh.DataChunkType,
+			},
+			Data: []byte(s),
+		}
+	}
+
+	cases := []struct {
+		name            string
+		inboundScanner  *bsr.ChunkScanner
+		outboundScanner *bsr.ChunkScanner
+		w               io.ReadWriteSeeker
+		want            string
+		wantErr         error
+	}{
+		{
+			name:            "no-messages",
+			inboundScanner:  newScanner(bsr.Inbound),
+			outboundScanner: newScanner(bsr.Outbound),
+			w:               newW(),
+			want:            "",
+		},
+		{
+			name: "shell",
+			inboundScanner: newScanner(bsr.Inbound,
+				data(bsr.Inbound, time.Millisecond, "l"),
+				data(bsr.Inbound, 2*time.Millisecond, "s\r"),
+				data(bsr.Inbound, 5*time.Millisecond, "\nexit"),
+			),
+			outboundScanner: newScanner(bsr.Outbound,
+				data(bsr.Outbound, 3*time.Millisecond, "ls\r\n"),
+				data(bsr.Outbound, 4*time.Millisecond, "a.txt\tb\x1b[0m\\\r\n$ "),
+			),
+			w: newW(),
+			want: "2023-03-16T10:47:03.001000Z inbound  ls\n" +
+				"2023-03-16T10:47:03.003000Z outbound ls\n" +
+				"2023-03-16T10:47:03.004000Z outbound a.txt\tb\\x1b[0m\\\\\n" +
+				"2023-03-16T10:47:03.004000Z outbound $ \n" +
+				"2023-03-16T10:47:03.005000Z inbound  exit\n",
+		},
+		{
+			name:           "binary",
+			inboundScanner: newScanner(bsr.Inbound),
+			outboundScanner: newScanner(bsr.Outbound,
+				data(bsr.Outbound, time.Millisecond, "\x00\xff\u00e9\u200b\n"),
+			),
+			w:    newW(),
+			want: "2023-03-16T10:47:03.001000Z outbound \\x00\\xff\u00e9\\u200b\n",
+		},
+		{
+			name:            "nil-inbound-scanner",
+			outboundScanner: newScanner(bsr.Outbound),
+			w:               newW(),
+			wantErr:         errors.New("convert.sshChannelToTranscript: missing inbound scanner: invalid parameter"),
+		},
+		{
+			name:           "nil-outbound-scanner",
+			inboundScanner: newScanner(bsr.Inbound),
+			w:              newW(),
+			wantErr:        errors.New("convert.sshChannelToTranscript: missing outbound scanner: invalid parameter"),
+		},
+		{
+			name:            "nil-writer",
+			inboundScanner:  newScanner(bsr.Inbound),
+			outboundScanner: newScanner(bsr.Outbound),
+			wantErr:         errors.New("convert.sshChannelToTranscript: missing read write seeker: invalid parameter"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := sshChannelToTranscript(ctx, tc.inboundScanner, tc.outboundScanner, tc.w)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(got))
+
+			err = r.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_sshChannelToJsonl(t *testing.T) {
+	ctx := context.Background()
+
+	ts := time.Date(2023, time.March, 16, 10, 47, 3, 14, time.UTC)
+	newW := func() io.ReadWriteSeeker {
+		f, err := os.CreateTemp("", "*.jsonl")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			os.Remove(f.Name())
+		})
+		return f
+	}
+	newScanner := func(chunks ...bsr.Chunk) *bsr.ChunkScanner {
+		var buf bytes.Buffer
+		buf.Write(bsr.Magic.Bytes())
+		enc, err := bsr.NewChunkEncoder(ctx, &buf, bsr.NoCompression, bsr.NoEncryption)
+		require.NoError(t, err)
+
+		for _, c := range chunks {
+			_, err := enc.Encode(ctx, c)
+			require.NoError(t, err)
+		}
+		s, err := bsr.NewChunkScanner(ctx, bytes.NewBuffer(buf.Bytes()))
+		require.NoError(t, err)
+		return s
+	}
+	base := func(d bsr.Direction, offset time.Duration, ct bsr.ChunkType) *bsr.BaseChunk {
+		return &bsr.BaseChunk{
+			Protocol:  ssh.Protocol,
+			Direction: d,
+			Timestamp: bsr.NewTimestamp(ts.Add(offset)),
+			Type:      ct,
+		}
+	}
+	header := func(d bsr.Direction) bsr.Chunk {
+		return &bsr.HeaderChunk{
+			BaseChunk:   base(d, 0, bsr.ChunkHeader),
+			Compression: bsr.NoCompression,
+			Encryption:  bsr.NoEncryption,
+			SessionId:   "sess_123456789",
+		}
+	}
+	end := func(d bsr.Direction) bsr.Chunk {
+		return &bsr.EndChunk{BaseChunk: base(d, time.Minute, bsr.ChunkEnd)}
+	}
+
+	cases := []struct {
+		name            string
+		inboundScanner  *bsr.ChunkScanner
+		outboundScanner *bsr.ChunkScanner
+		w               io.ReadWriteSeeker
+		want            []string
+		wantErr         error
+	}{
+		{
+			name:            "no-requests",
+			inboundScanner:  newScanner(header(bsr.Inbound), end(bsr.Inbound)),
+			outboundScanner: newScanner(header(bsr.Outbound), end(bsr.Outbound)),
+			w:               newW(),
+		},
+		{
+			name: "requests",
+			inboundScanner: newScanner(
+				header(bsr.Inbound),
+				&ssh.PtyRequest{
+					BaseChunk: base(bsr.Inbound, time.Millisecond, ssh.PtyReqChunkType),
+					PtyRequest: &sshv1.PtyRequest{
+						RequestType:             ssh.PtyRequestType,
+						WantReply:               true,
+						TermEnvVar:              "xterm",
+						TerminalWidthCharacters: 80,
+						TerminalHeightRows:      24,
+					},
+				},
+				&ssh.EnvRequest{
+					BaseChunk: base(bsr.Inbound, 2*time.Millisecond, ssh.EnvReqChunkType),
+					EnvRequest: &sshv1.EnvRequest{
+						RequestType:   ssh.EnvRequestType,
+						VariableName:  "LANG",
+						VariableValue: "C",
+					},
+				},
+				&ssh.ExecRequest{
+					BaseChunk: base(bsr.Inbound, 3*time.Millisecond, ssh.ExecReqChunkType),
+					ExecRequest: &sshv1.ExecRequest{
+						RequestType: ssh.ExecRequestType,
+						WantReply:   true,
+						Command:     "uptime",
+					},
+				},
+				&ssh.WindowChangeRequest{
+					BaseChunk: base(bsr.Inbound, 5*time.Millisecond, ssh.WindowChangeReqChunkType),
+					WindowChangeRequest: &sshv1.WindowChangeRequest{
+						RequestType:          ssh.WindowChangeRequestType,
+						TerminalWidthColumns: 120,
+						TerminalHeightRows:   40,
+					},
+				},
+				end(bsr.Inbound),
+			),
+			outboundScanner: newScanner(
+				header(bsr.Outbound),
+				&ssh.ExitStatusRequest{
+					BaseChunk: base(bsr.Outbound, 4*time.Millisecond, ssh.ExitStatusReqChunkType),
+					ExitStatusRequest: &sshv1.ExitStatusRequest{
+						RequestType: ssh.ExitStatusRequestType,
+					},
+				},
+				end(bsr.Outbound),
+			),
+			w: newW(),
+			want: []string{
+				`{"timestamp":"2023-03-16T10:47:03.001000014Z","direction":"inbound","request_type":"pty-req","request":{"request_type":"pty-req","want_reply":true,"term_env_var":"xterm","terminal_width_characters":80,"terminal_height_rows":24,"terminal_width_pixels":0,"terminal_height_pixels":0,"encoded_terminal_mode":""}}`,
+				`{"timestamp":"2023-03-16T10:47:03.002000014Z","direction":"inbound","request_type":"env","request":{"request_type":"env","want_reply":false,"variable_name":"LANG","variable_value":"C"}}`,
+				`{"timestamp":"2023-03-16T10:47:03.003000014Z","direction":"inbound","request_type":"exec","request":{"request_type":"exec","want_reply":true,"command":"uptime"}}`,
+				`{"timestamp":"2023-03-16T10:47:03.004000014Z","direction":"outbound","request_type":"exit-status","request":{"request_type":"exit-status","want_reply":false,"exit_status":0}}`,
+				`{"timestamp":"2023-03-16T10:47:03.005000014Z","direction":"inbound","request_type":"window-change","request":{"request_type":"window-change","want_reply":false,"terminal_width_columns":120,"terminal_height_rows":40,"terminal_width_pixels":0,"terminal_height_pixels":0}}`,
+			},
+		},
+		{
+			name: "data-chunk",
+			inboundScanner: newScanner(
+				header(bsr.Inbound),
+				&ssh.DataChunk{
+					BaseChunk: base(bsr.Inbound, time.Millisecond, ssh.DataChunkType),
+					Data:      []byte("data"),
+				},
+				end(bsr.Inbound),
+			),
+			outboundScanner: newScanner(header(bsr.Outbound), end(bsr.Outbound)),
+			w:               newW(),
+			wantErr:         errors.New("convert.sshChannelToJsonl: unexpected \"DATA\" chunk in requests: malformed bsr data file"),
+		},
+		{
+			name:            "nil-inbound-scanner",
+			outboundScanner: newScanner(header(bsr.Outbound), end(bsr.Outbound)),
+			w:               newW(),
+			wantErr:         errors.New("convert.sshChannelToJsonl: missing inbound scanner: invalid parameter"),
+		},
+		{
+			name:           "nil-outbound-scanner",
+			inboundScanner: newScanner(header(bsr.Inbound), end(bsr.Inbound)),
+			w:              newW(),
+			wantErr:        errors.New("convert.sshChannelToJsonl: missing outbound scanner: invalid parameter"),
+		},
+		{
+			name:            "nil-writer",
+			inboundScanner:  newScanner(header(bsr.Inbound), end(bsr.Inbound)),
+			outboundScanner: newScanner(header(bsr.Outbound), end(bsr.Outbound)),
+			wantErr:         errors.New("convert.sshChannelToJsonl: missing read write seeker: invalid parameter"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := sshChannelToJsonl(ctx, tc.inboundScanner, tc.outboundScanner, tc.w)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+
+			lines := strings.Split(strings.TrimSuffix(string(got), "\n"), "\n")
+			if len(tc.want) == 0 {
+				require.Empty(t, string(got))
+			} else {
+				require.Len(t, lines, len(tc.want))
+				for i := range tc.want {
+					require.JSONEq(t, tc.want[i], lines[i])
+				}
+			}
+
+			err = r.Close()
+			require.NoError(t, err)
+		})
+	}
+}
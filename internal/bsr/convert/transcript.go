@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package convert
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+)
+
+// transcriptTimeFormat is the format of the time each transcript line is
+// prefixed with.
+const transcriptTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// transcriptWriter writes the data of a channel as lines of text. Data is
+// buffered per direction until a line ends, so the data of one direction
+// does not break up the lines of the other.
+type transcriptWriter struct {
+	w     io.Writer
+	lines map[bsr.Direction]*transcriptLine
+}
+
+// transcriptLine is the line of a direction which has not ended yet.
+type transcriptLine struct {
+	start  time.Time
+	data   []byte
+	open   bool
+	lastCR bool
+}
+
+func newTranscriptWriter(w io.Writer) *transcriptWriter {
+	return &transcriptWriter{
+		w:     w,
+		lines: make(map[bsr.Direction]*transcriptLine),
+	}
+}
+
+// write adds data sent at ts in direction d to the transcript. Every line
+// ended by the data is written. A line ends with a carriage return, a line
+// feed or both.
+func (tw *transcriptWriter) write(d bsr.Direction, ts time.Time, data []byte) error {
+	l, ok := tw.lines[d]
+	if !ok {
+		l = &transcriptLine{}
+		tw.lines[d] = l
+	}
+	for _, b := range data {
+		switch {
+		case b == '\n' && l.lastCR:
+			l.lastCR = false
+			continue
+		case b == '\n' || b == '\r':
+			l.lastCR = b == '\r'
+			if !l.open {
+				l.start = ts
+			}
+			if err := tw.flush(d, l); err != nil {
+				return err
+			}
+			continue
+		}
+		l.lastCR = false
+		if !l.open {
+			l.open = true
+			l.start = ts
+		}
+		l.data = append(l.data, b)
+	}
+	return nil
+}
+
+// flushAll writes the lines which have not ended in the order they started.
+func (tw *transcriptWriter) flushAll() error {
+	dirs := make([]bsr.Direction, 0, len(tw.lines))
+	for d, l := range tw.lines {
+		if l.open {
+			dirs = append(dirs, d)
+		}
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		return tw.lines[dirs[i]].start.Before(tw.lines[dirs[j]].start)
+	})
+	for _, d := range dirs {
+		if err := tw.flush(d, tw.lines[d]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tw *transcriptWriter) flush(d bsr.Direction, l *transcriptLine) error {
+	if _, err := fmt.Fprintf(tw.w, "%s %-8s %s\n", l.start.UTC().Format(transcriptTimeFormat), d.String(), escapeTranscript(l.data)); err != nil {
+		return err
+	}
+	l.data = l.data[:0]
+	l.open = false
+	return nil
+}
+
+// escapeTranscript returns data as text. Backslashes, control characters
+// other than tabs and invalid UTF-8 are escaped so terminal escape sequences
+// and binary data cannot affect how the transcript is displayed.
+func escapeTranscript(data []byte) string {
+	var sb strings.Builder
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			fmt.Fprintf(&sb, `\x%02x`, data[0])
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\t' || unicode.IsPrint(r):
+			sb.WriteRune(r)
+		case r < utf8.RuneSelf:
+			fmt.Fprintf(&sb, `\x%02x`, r)
+		default:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		}
+		data = data[size:]
+	}
+	return sb.String()
+}
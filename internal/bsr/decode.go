@@ -182,6 +182,11 @@ func (d *ChunkDecoder) Decode(ctx context.Context) (Chunk, error) {
 			if err != nil {
 				return nil, fmt.Errorf("%s: %w: %w", op, err, ErrChunkDecode)
 			}
+		case ZstdCompression:
+			decompressor, err = newZstdCompressionReader(decompressBuf)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w: %w", op, err, ErrChunkDecode)
+			}
 		default:
 			decompressor = newNullCompressionReader(decompressBuf)
 		}
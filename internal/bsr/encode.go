@@ -5,7 +5,6 @@ package bsr
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"encoding/binary"
 	"fmt"
@@ -43,13 +42,17 @@ var encodeCachePool = &sync.Pool{
 // ChunkEncoder will encode a chunk and write it to the writer.
 // It will compress the chunk data based on the compression.
 type ChunkEncoder struct {
-	w           io.Writer
-	compression Compression
-	encryption  Encryption
+	w                io.Writer
+	compression      Compression
+	compressionLevel int
+	encryption       Encryption
 }
 
 // NewChunkEncoder creates a ChunkEncoder.
-func NewChunkEncoder(ctx context.Context, w io.Writer, c Compression, e Encryption) (*ChunkEncoder, error) {
+//
+// WithCompressionLevel is the only supported option. The default level of
+// the compression is used if it is not provided.
+func NewChunkEncoder(ctx context.Context, w io.Writer, c Compression, e Encryption, opt ...Option) (*ChunkEncoder, error) {
 	const op = "bsr.NewChunkEncoder"
 
 	if w == nil {
@@ -60,14 +63,20 @@ func NewChunkEncoder(ctx context.Context, w io.Writer, c Compression, e Encrypti
 		return nil, fmt.Errorf("%s: invalid compression: %w", op, ErrInvalidParameter)
 	}
 
+	opts := getOpts(opt...)
+	if !ValidCompressionLevel(c, opts.withCompressionLevel) {
+		return nil, fmt.Errorf("%s: invalid compression level %d for %s: %w", op, opts.withCompressionLevel, c, ErrInvalidParameter)
+	}
+
 	if !ValidEncryption(e) {
 		return nil, fmt.Errorf("%s: invalid encryption: %w", op, ErrInvalidParameter)
 	}
 
 	return &ChunkEncoder{
-		w:           w,
-		compression: c,
-		encryption:  e,
+		w:                w,
+		compression:      c,
+		compressionLevel: opts.withCompressionLevel,
+		encryption:       e,
 	}, nil
 }
 
@@ -93,7 +102,13 @@ func (e ChunkEncoder) Encode(ctx context.Context, c Chunk) (int, error) {
 	default:
 		switch e.compression {
 		case GzipCompression:
-			compressor = gzip.NewWriter(encode.compress)
+			if compressor, err = newGzipCompressionWriter(encode.compress, e.compressionLevel); err != nil {
+				return 0, err
+			}
+		case ZstdCompression:
+			if compressor, err = newZstdCompressionWriter(encode.compress, e.compressionLevel); err != nil {
+				return 0, err
+			}
 		default:
 			compressor = newNullCompressionWriter(encode.compress)
 		}
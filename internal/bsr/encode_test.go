@@ -392,3 +392,130 @@ func TestChunkEncoderErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestChunkEncoderCompressionLevelErrors(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name  string
+		c     bsr.Compression
+		level int
+		want  error
+	}{
+		{
+			"no-compression-level",
+			bsr.NoCompression,
+			1,
+			errors.New("bsr.NewChunkEncoder: invalid compression level 1 for no compression: invalid parameter"),
+		},
+		{
+			"gzip-negative-level",
+			bsr.GzipCompression,
+			-1,
+			errors.New("bsr.NewChunkEncoder: invalid compression level -1 for gzip: invalid parameter"),
+		},
+		{
+			"gzip-level-too-high",
+			bsr.GzipCompression,
+			10,
+			errors.New("bsr.NewChunkEncoder: invalid compression level 10 for gzip: invalid parameter"),
+		},
+		{
+			"zstd-level-too-high",
+			bsr.ZstdCompression,
+			23,
+			errors.New("bsr.NewChunkEncoder: invalid compression level 23 for zstd: invalid parameter"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := bsr.NewChunkEncoder(ctx, &buf, tc.c, bsr.NoEncryption, bsr.WithCompressionLevel(tc.level))
+			require.EqualError(t, tc.want, err.Error())
+		})
+	}
+}
+
+func TestChunkEncoderCompressionLevels(t *testing.T) {
+	ctx := context.Background()
+
+	ts := time.Date(2023, time.March, 16, 10, 47, 3, 14, time.UTC)
+	data := bytes.Repeat([]byte("drwxr-xr-x 2 root root 4096 Mar 16 10:47 bin\r\n"), 100)
+
+	cases := []struct {
+		c     bsr.Compression
+		level int
+	}{
+		{bsr.NoCompression, bsr.DefaultCompressionLevel},
+		{bsr.GzipCompression, bsr.DefaultCompressionLevel},
+		{bsr.GzipCompression, 1},
+		{bsr.GzipCompression, bsr.MaxGzipCompressionLevel},
+		{bsr.ZstdCompression, bsr.DefaultCompressionLevel},
+		{bsr.ZstdCompression, 1},
+		{bsr.ZstdCompression, 3},
+		{bsr.ZstdCompression, 9},
+		{bsr.ZstdCompression, bsr.MaxZstdCompressionLevel},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s-%d", tc.c, tc.level), func(t *testing.T) {
+			chunks := []bsr.Chunk{
+				&bsr.HeaderChunk{
+					BaseChunk: &bsr.BaseChunk{
+						Protocol:  "TEST",
+						Direction: bsr.Inbound,
+						Timestamp: bsr.NewTimestamp(ts),
+						Type:      bsr.ChunkHeader,
+					},
+					Compression: tc.c,
+					Encryption:  bsr.NoEncryption,
+					SessionId:   "sess_123456789",
+				},
+				&testChunk{
+					BaseChunk: &bsr.BaseChunk{
+						Protocol:  "TEST",
+						Direction: bsr.Inbound,
+						Timestamp: bsr.NewTimestamp(ts),
+						Type:      "TEST",
+					},
+					Data: data,
+				},
+				&bsr.EndChunk{
+					BaseChunk: &bsr.BaseChunk{
+						Protocol:  "TEST",
+						Direction: bsr.Inbound,
+						Timestamp: bsr.NewTimestamp(ts.Add(time.Nanosecond * 5)),
+						Type:      bsr.ChunkEnd,
+					},
+				},
+			}
+
+			var buf bytes.Buffer
+			enc, err := bsr.NewChunkEncoder(ctx, &buf, tc.c, bsr.NoEncryption, bsr.WithCompressionLevel(tc.level))
+			require.NoError(t, err)
+			for _, c := range chunks {
+				_, err := enc.Encode(ctx, c)
+				require.NoError(t, err)
+			}
+			require.NoError(t, enc.Close())
+			if tc.c != bsr.NoCompression {
+				assert.Less(t, buf.Len(), len(data))
+			}
+
+			dec, err := bsr.NewChunkDecoder(ctx, &buf)
+			require.NoError(t, err)
+			var got []bsr.Chunk
+			for {
+				c, err := dec.Decode(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				got = append(got, c)
+			}
+			require.Len(t, got, len(chunks))
+			assert.Equal(t, chunks, got)
+		})
+	}
+}
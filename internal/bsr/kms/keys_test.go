@@ -669,6 +669,71 @@ func TestBsrKeys_UnwrapPrivKey(t *testing.T) {
 	}
 }
 
+func TestNewKeyUnwrapCallbackFunc(t *testing.T) {
+	t.Parallel()
+	testCtx := context.Background()
+	testBsrWrapper := kms.TestWrapper(t)
+	keys, err := kms.CreateKeys(testCtx, testBsrWrapper, "session-id")
+	require.NoError(t, err)
+	wrapped := kms.WrappedKeys{
+		WrappedBsrKey:  keys.WrappedBsrKey,
+		WrappedPrivKey: keys.WrappedPrivKey,
+	}
+
+	tests := []struct {
+		name            string
+		bsrWrapper      wrapping.Wrapper
+		wrapped         kms.WrappedKeys
+		wantErr         bool
+		wantErrMatch    error
+		wantErrContains string
+	}{
+		{
+			name:       "success",
+			bsrWrapper: testBsrWrapper,
+			wrapped:    wrapped,
+		},
+		{
+			name:            "missing-bsr-wrapper",
+			wrapped:         wrapped,
+			wantErr:         true,
+			wantErrMatch:    kms.ErrInvalidParameter,
+			wantErrContains: "missing bsr wrapper",
+		},
+		{
+			name:            "missing-wrapped-bsr-key",
+			bsrWrapper:      testBsrWrapper,
+			wrapped:         kms.WrappedKeys{WrappedPrivKey: keys.WrappedPrivKey},
+			wantErr:         true,
+			wantErrContains: "kms.NewKeyUnwrapCallbackFunc",
+		},
+		{
+			name:            "wrong-bsr-wrapper",
+			bsrWrapper:      kms.TestWrapper(t),
+			wrapped:         wrapped,
+			wantErr:         true,
+			wantErrContains: "kms.NewKeyUnwrapCallbackFunc",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := kms.NewKeyUnwrapCallbackFunc(testCtx, tc.bsrWrapper)(tc.wrapped)
+			if tc.wantErr {
+				require.Error(err)
+				if tc.wantErrMatch != nil {
+					assert.ErrorIs(err, tc.wantErrMatch)
+				}
+				assert.Contains(err.Error(), tc.wantErrContains)
+				return
+			}
+			require.NoError(err)
+			assert.True(proto.Equal(keys.BsrKey, got.BsrKey))
+			assert.True(proto.Equal(keys.PrivKey, got.PrivKey))
+		})
+	}
+}
+
 func TestBsrKeys_VerifyPubBsrSignature(t *testing.T) {
 	t.Parallel()
 	testCtx := context.Background()
@@ -22,6 +22,7 @@ type options struct {
 	withSupportsMultiplex bool
 	withKeys              *kms.Keys
 	withSha256Sum         []byte
+	withCompressionLevel  int
 }
 
 func getDefaultOptions() options {
@@ -29,6 +30,7 @@ func getDefaultOptions() options {
 		withSupportsMultiplex: false,
 		withKeys:              nil,
 		withSha256Sum:         nil,
+		withCompressionLevel:  DefaultCompressionLevel,
 	}
 }
 
@@ -53,3 +55,11 @@ func WithSha256Sum(b []byte) Option {
 		o.withSha256Sum = b
 	}
 }
+
+// WithCompressionLevel is used to provide the level of the compression of
+// chunks.
+func WithCompressionLevel(l int) Option {
+	return func(o *options) {
+		o.withCompressionLevel = l
+	}
+}
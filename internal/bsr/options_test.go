@@ -54,4 +54,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withSha256Sum = sum
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithCompressionLevel", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithCompressionLevel(3))
+		testOpts := getDefaultOptions()
+		testOpts.withCompressionLevel = 3
+		assert.Equal(opts, testOpts)
+	})
 }
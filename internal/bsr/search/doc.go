@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package search provides a searchable index of the output recorded in a
+// BSR. The index of a session recording contains the lines of text written
+// to the client by each of its channels, along with the time each line was
+// written, and is stored alongside the BSR in its storage bucket.
+package search
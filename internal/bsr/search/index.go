@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package search
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/internal/is"
+	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/storage"
+)
+
+// IndexFileName is the name of the file in the container of a BSR which
+// the index of the session recording is stored in.
+const IndexFileName = "search.index.gz"
+
+// bsrFileNameTemplate is the name of the container of a BSR.
+const bsrFileNameTemplate = "%s.bsr"
+
+// indexVersion is the version of the encoding of an Index.
+const indexVersion = 1
+
+// Channel identifies a channel recording whose output is indexed.
+type Channel struct {
+	ConnectionRecordingId string
+	ChannelRecordingId    string
+}
+
+// Line is a line of text written to the client by a channel.
+type Line struct {
+	ConnectionRecordingId string `json:"connection_recording_id"`
+	ChannelRecordingId    string `json:"channel_recording_id"`
+	// Time is the time the line started.
+	Time time.Time `json:"time"`
+	// Offset is the duration between the start of the channel and the
+	// start of the line.
+	Offset time.Duration `json:"offset"`
+	Text   string        `json:"text"`
+}
+
+// Index is a searchable index of the output of the channels of a session
+// recording.
+type Index struct {
+	Version            int     `json:"version"`
+	SessionRecordingId string  `json:"session_recording_id"`
+	Lines              []*Line `json:"lines"`
+	// Tokens maps each lower case token of the lines to the positions in
+	// Lines of the lines containing it.
+	Tokens map[string][]int `json:"tokens"`
+}
+
+// Build builds the index of the output of channels of the session recording.
+// The outbound messages of each channel are scanned for data, which is split
+// into lines of text. Channels without outbound data, like the channels of
+// subsystems, add no lines to the index.
+func Build(ctx context.Context, session *bsr.Session, channels ...Channel) (*Index, error) {
+	const op = "search.Build"
+	switch {
+	case is.Nil(session):
+		return nil, fmt.Errorf("%s: missing session: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(session.Meta):
+		return nil, fmt.Errorf("%s: missing session meta: %w", op, bsr.ErrInvalidParameter)
+	case session.Meta.Protocol != ssh.Protocol:
+		return nil, fmt.Errorf("%s: unsupported %q protocol: %w", op, session.Meta.Protocol, bsr.ErrNotSupported)
+	}
+
+	idx := &Index{
+		Version:            indexVersion,
+		SessionRecordingId: session.Meta.Id,
+		Tokens:             make(map[string][]int),
+	}
+	for _, c := range channels {
+		if err := idx.addChannel(ctx, session, c); err != nil {
+			return nil, fmt.Errorf("%s: channel %s: %w", op, c.ChannelRecordingId, err)
+		}
+	}
+	return idx, nil
+}
+
+func (idx *Index) addChannel(ctx context.Context, session *bsr.Session, c Channel) error {
+	conn, err := session.OpenConnection(ctx, c.ConnectionRecordingId)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	ch, err := conn.OpenChannel(ctx, c.ChannelRecordingId)
+	if err != nil {
+		return err
+	}
+	defer ch.Close(ctx)
+
+	var start time.Time
+	if !is.Nil(ch.Summary) {
+		start = ch.Summary.GetStartTime()
+	}
+	lb := newLineBuilder(func(ts time.Time, text string) {
+		if start.IsZero() {
+			start = ts
+		}
+		idx.add(&Line{
+			ConnectionRecordingId: c.ConnectionRecordingId,
+			ChannelRecordingId:    c.ChannelRecordingId,
+			Time:                  ts.UTC(),
+			Offset:                ts.Sub(start),
+			Text:                  text,
+		})
+	})
+
+	scanner, err := ch.OpenMessageScanner(ctx, bsr.Outbound)
+	if err != nil {
+		return err
+	}
+	defer scanner.Close()
+	if err := bsr.ChunkWalk(ctx, scanner, func(_ context.Context, chunk bsr.Chunk) error {
+		if chunk.GetType() == ssh.DataChunkType {
+			data, ok := chunk.(*ssh.DataChunk)
+			if !ok {
+				return fmt.Errorf("unexpected %T data chunk: %w", chunk, bsr.ErrChunkDecode)
+			}
+			lb.write(data.GetTimestamp().AsTime(), data.Data)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	lb.flush()
+	return nil
+}
+
+// add adds l to the lines of the index.
+func (idx *Index) add(l *Line) {
+	pos := len(idx.Lines)
+	idx.Lines = append(idx.Lines, l)
+	seen := make(map[string]bool)
+	for _, t := range tokenize(l.Text) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		idx.Tokens[t] = append(idx.Tokens[t], pos)
+	}
+}
+
+// Search returns the lines of the index which contain query, ignoring
+// case, in the order they were written by each channel. Lines are only
+// compared to query if each of the tokens of query is part of a token of
+// the line.
+func (idx *Index) Search(query string) []*Line {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	// candidates is nil until the first token of the query is looked up,
+	// in which case every line is a candidate.
+	var candidates map[int]bool
+	for _, qt := range tokenize(query) {
+		found := make(map[int]bool)
+		for t, positions := range idx.Tokens {
+			if !strings.Contains(t, qt) {
+				continue
+			}
+			for _, pos := range positions {
+				if candidates == nil || candidates[pos] {
+					found[pos] = true
+				}
+			}
+		}
+		if len(found) == 0 {
+			return nil
+		}
+		candidates = found
+	}
+
+	var matches []*Line
+	for pos, l := range idx.Lines {
+		if candidates != nil && !candidates[pos] {
+			continue
+		}
+		if strings.Contains(strings.ToLower(l.Text), query) {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
+// Encode writes the index to w as gzip compressed JSON.
+func (idx *Index) Encode(w io.Writer) error {
+	const op = "search.(Index).Encode"
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(idx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Decode reads an index written by Encode from r.
+func Decode(r io.Reader) (*Index, error) {
+	const op = "search.Decode"
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer zr.Close()
+	idx := &Index{}
+	if err := json.NewDecoder(zr).Decode(idx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if idx.Version != indexVersion {
+		return nil, fmt.Errorf("%s: unsupported index version %d: %w", op, idx.Version, bsr.ErrNotSupported)
+	}
+	if idx.Tokens == nil {
+		idx.Tokens = make(map[string][]int)
+	}
+	return idx, nil
+}
+
+// Write writes the index to the BSR container of its session recording,
+// which is created in f. The index file is synced before Write returns.
+func Write(ctx context.Context, f storage.FS, idx *Index) error {
+	const op = "search.Write"
+	switch {
+	case f == nil:
+		return fmt.Errorf("%s: missing storage: %w", op, bsr.ErrInvalidParameter)
+	case is.Nil(idx):
+		return fmt.Errorf("%s: missing index: %w", op, bsr.ErrInvalidParameter)
+	case idx.SessionRecordingId == "":
+		return fmt.Errorf("%s: missing session recording id: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	c, err := f.New(ctx, fmt.Sprintf(bsrFileNameTemplate, idx.SessionRecordingId))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer c.Close()
+	w, err := c.OpenFile(ctx, IndexFileName,
+		storage.WithCreateFile(),
+		storage.WithFileAccessMode(storage.ReadWrite),
+		storage.WithCloseSyncMode(storage.Synchronous))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := idx.Encode(w); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Read reads the index of the session recording from its BSR container in f.
+func Read(ctx context.Context, f storage.FS, sessionRecordingId string) (*Index, error) {
+	const op = "search.Read"
+	switch {
+	case f == nil:
+		return nil, fmt.Errorf("%s: missing storage: %w", op, bsr.ErrInvalidParameter)
+	case sessionRecordingId == "":
+		return nil, fmt.Errorf("%s: missing session recording id: %w", op, bsr.ErrInvalidParameter)
+	}
+
+	c, err := f.Open(ctx, fmt.Sprintf(bsrFileNameTemplate, sessionRecordingId))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer c.Close()
+	r, err := c.OpenFile(ctx, IndexFileName, storage.WithFileAccessMode(storage.ReadOnly))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer r.Close()
+	idx, err := Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return idx, nil
+}
+
+// tokenize splits s into lower case tokens of letters and digits.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package search_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/internal/fstest"
+	"github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/bsr/search"
+	"github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChannel is a channel of a test session recording and the output
+// written by it.
+type testChannel struct {
+	connectionId string
+	channelId    string
+	output       []string
+}
+
+// testOpenSession writes a session recording with the channels to fs and
+// opens it. Each output of a channel is written a second after the previous
+// one, starting at start.
+func testOpenSession(t *testing.T, fs *fstest.MemFS, id string, start time.Time, channels ...testChannel) *bsr.Session {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+
+	keys, err := kms.CreateKeys(ctx, kms.TestWrapper(t), "s_"+id)
+	require.NoError(err)
+	keyFn := func(w kms.WrappedKeys) (kms.UnwrappedKeys, error) {
+		return kms.UnwrappedKeys{
+			BsrKey:  keys.BsrKey,
+			PrivKey: keys.PrivKey,
+		}, nil
+	}
+
+	srm := &bsr.SessionRecordingMeta{Id: "sr_" + id, Protocol: ssh.Protocol}
+	sesh, err := bsr.NewSession(ctx, srm, bsr.TestSessionMeta("s_"+id), fs, keys, bsr.WithSupportsMultiplex(true))
+	require.NoError(err)
+
+	conns := make(map[string]*bsr.Connection)
+	for _, c := range channels {
+		conn, ok := conns[c.connectionId]
+		if !ok {
+			conn, err = sesh.NewConnection(ctx, &bsr.ConnectionRecordingMeta{Id: c.connectionId})
+			require.NoError(err)
+			conns[c.connectionId] = conn
+		}
+		ch, err := conn.NewChannel(ctx, &bsr.ChannelRecordingMeta{Id: c.channelId, Type: "session"})
+		require.NoError(err)
+
+		ts := bsr.NewTimestamp(start)
+		w, err := ch.NewMessagesWriter(ctx, bsr.Outbound)
+		require.NoError(err)
+		_, err = w.Write(bsr.Magic.Bytes())
+		require.NoError(err)
+		enc, err := bsr.NewChunkEncoder(ctx, w, bsr.NoCompression, bsr.NoEncryption)
+		require.NoError(err)
+		h, err := bsr.NewHeader(ctx, ssh.Protocol, bsr.Outbound, ts, bsr.NoCompression, bsr.NoEncryption, "s_"+id)
+		require.NoError(err)
+		chunks := []bsr.Chunk{h}
+		for i, o := range c.output {
+			d, err := ssh.NewDataChunk(ctx, bsr.Outbound, bsr.NewTimestamp(start.Add(time.Duration(i)*time.Second)), []byte(o))
+			require.NoError(err)
+			chunks = append(chunks, d)
+		}
+		end, err := bsr.NewEnd(ctx, ssh.Protocol, bsr.Outbound, ts)
+		require.NoError(err)
+		for _, c := range append(chunks, end) {
+			_, err := enc.Encode(ctx, c)
+			require.NoError(err)
+		}
+		require.NoError(enc.Close())
+
+		require.NoError(ch.EncodeSummary(ctx, &ssh.ChannelSummary{
+			ChannelSummary: &bsr.BaseChannelSummary{
+				Id:                    c.channelId,
+				ConnectionRecordingId: c.connectionId,
+				ChannelType:           "session",
+				StartTime:             start,
+			},
+			SessionProgram: ssh.Shell,
+		}))
+		require.NoError(ch.Close(ctx))
+	}
+	for id, conn := range conns {
+		require.NoError(conn.EncodeSummary(ctx, &bsr.BaseConnectionSummary{Id: id}))
+		require.NoError(conn.Close(ctx))
+	}
+	require.NoError(sesh.EncodeSummary(ctx, &bsr.BaseSessionSummary{Id: srm.Id, ConnectionCount: uint64(len(conns))}))
+	require.NoError(sesh.Close(ctx))
+
+	opSesh, err := bsr.OpenSession(ctx, srm.Id, fs, keyFn)
+	require.NoError(err)
+	return opSesh
+}
+
+func TestBuild(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	shell := testChannel{
+		connectionId: "cr_1",
+		channelId:    "chr_1",
+		output:       []string{"$ ", "sudo cat /etc/shadow\r\n", "\x1b[31mPermission denied\x1b[0m\r\n$ "},
+	}
+	exec := testChannel{
+		connectionId: "cr_2",
+		channelId:    "chr_2",
+		output:       []string{"Linux host 5.15.0\n", "Permission denied\n"},
+	}
+	sesh := testOpenSession(t, &fstest.MemFS{}, "1234567890", start, shell, exec)
+
+	idx, err := search.Build(ctx, sesh,
+		search.Channel{ConnectionRecordingId: shell.connectionId, ChannelRecordingId: shell.channelId},
+		search.Channel{ConnectionRecordingId: exec.connectionId, ChannelRecordingId: exec.channelId},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "sr_1234567890", idx.SessionRecordingId)
+
+	line := func(c testChannel, offset time.Duration, text string) *search.Line {
+		return &search.Line{
+			ConnectionRecordingId: c.connectionId,
+			ChannelRecordingId:    c.channelId,
+			Time:                  start.Add(offset),
+			Offset:                offset,
+			Text:                  text,
+		}
+	}
+	assert.Equal(t, []*search.Line{
+		line(shell, 0, "$ sudo cat /etc/shadow"),
+		line(shell, 2*time.Second, "Permission denied"),
+		line(shell, 2*time.Second, "$"),
+		line(exec, 0, "Linux host 5.15.0"),
+		line(exec, time.Second, "Permission denied"),
+	}, idx.Lines)
+
+	cases := []struct {
+		query string
+		want  []*search.Line
+	}{
+		{query: "shadow", want: []*search.Line{idx.Lines[0]}},
+		{query: "cat /etc/shadow", want: []*search.Line{idx.Lines[0]}},
+		{query: "SUDO", want: []*search.Line{idx.Lines[0]}},
+		{query: "  sudo  ", want: []*search.Line{idx.Lines[0]}},
+		{query: "etc/sha", want: []*search.Line{idx.Lines[0]}},
+		{query: "permission denied", want: []*search.Line{idx.Lines[1], idx.Lines[4]}},
+		{query: "5.15", want: []*search.Line{idx.Lines[3]}},
+		{query: "$", want: []*search.Line{idx.Lines[0], idx.Lines[2]}},
+		{query: "denied permission"},
+		{query: "shadow denied"},
+		{query: "missing"},
+		{query: ""},
+		{query: "   "},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.want, idx.Search(tc.query))
+		})
+	}
+
+	t.Run("encode-decode", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, idx.Encode(&buf))
+		got, err := search.Decode(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, idx, got)
+		assert.Equal(t, []*search.Line{got.Lines[0]}, got.Search("shadow"))
+	})
+}
+
+func TestWriteRead(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	sesh := testOpenSession(t, &fstest.MemFS{}, "1234567892", start, testChannel{
+		connectionId: "cr_1",
+		channelId:    "chr_1",
+		output:       []string{"uname -a\n"},
+	})
+	idx, err := search.Build(ctx, sesh, search.Channel{ConnectionRecordingId: "cr_1", ChannelRecordingId: "chr_1"})
+	require.NoError(t, err)
+
+	fs := &fstest.MemFS{}
+	require.NoError(t, search.Write(ctx, fs, idx))
+	require.Contains(t, fs.Containers, "sr_1234567892.bsr")
+
+	got, err := search.Read(ctx, fs, "sr_1234567892")
+	require.NoError(t, err)
+	assert.Equal(t, idx, got)
+
+	_, err = search.Read(ctx, fs, "sr_missing")
+	assert.Error(t, err)
+	_, err = search.Read(ctx, fs, "")
+	assert.ErrorIs(t, err, bsr.ErrInvalidParameter)
+	assert.ErrorIs(t, search.Write(ctx, fs, &search.Index{}), bsr.ErrInvalidParameter)
+	assert.ErrorIs(t, search.Write(ctx, nil, idx), bsr.ErrInvalidParameter)
+}
+
+func TestBuild_Errors(t *testing.T) {
+	ctx := context.Background()
+	sesh := testOpenSession(t, &fstest.MemFS{}, "1234567891", time.Now(), testChannel{connectionId: "cr_1", channelId: "chr_1"})
+
+	_, err := search.Build(ctx, nil)
+	assert.ErrorIs(t, err, bsr.ErrInvalidParameter)
+
+	_, err = search.Build(ctx, sesh, search.Channel{ConnectionRecordingId: "cr_1", ChannelRecordingId: "chr_missing"})
+	assert.Error(t, err)
+
+	_, err = search.Build(ctx, sesh, search.Channel{ConnectionRecordingId: "cr_missing", ChannelRecordingId: "chr_1"})
+	assert.Error(t, err)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, err := search.Decode(bytes.NewReader([]byte("not gzip")))
+	assert.Error(t, err)
+
+	_, err = search.Decode(bytes.NewReader(nil))
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, (&search.Index{Version: 2}).Encode(&buf))
+	_, err = search.Decode(&buf)
+	assert.ErrorIs(t, err, bsr.ErrNotSupported)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package search
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxLineLength is the maximum length in bytes of an indexed line. Longer
+// lines are split.
+const maxLineLength = 4096
+
+// escapeState is the state of parsing a terminal escape sequence.
+type escapeState int
+
+const (
+	stateText escapeState = iota
+	// stateEscape follows an ESC.
+	stateEscape
+	// stateCsi follows a control sequence introducer (ESC [).
+	stateCsi
+	// stateString follows the start of a control string (e.g. ESC ]),
+	// which is terminated by a BEL or a string terminator (ESC \).
+	stateString
+	// stateStringEscape follows an ESC within a control string.
+	stateStringEscape
+)
+
+// lineBuilder splits the output of a channel into lines of text. Terminal
+// escape sequences and control characters are removed and a backspace
+// removes the previous character of the line. Escape sequences and lines
+// can span the data of several chunks.
+type lineBuilder struct {
+	emit func(start time.Time, text string)
+
+	buf    []byte
+	start  time.Time
+	state  escapeState
+	lastCR bool
+}
+
+func newLineBuilder(emit func(start time.Time, text string)) *lineBuilder {
+	return &lineBuilder{emit: emit}
+}
+
+// write adds data written at ts to the current line. Every line ended by
+// the data is emitted. A line ends with a carriage return, a line feed or
+// both.
+func (lb *lineBuilder) write(ts time.Time, data []byte) {
+	for _, b := range data {
+		switch lb.state {
+		case stateEscape:
+			switch {
+			case b == '[':
+				lb.state = stateCsi
+			case b == ']' || b == 'P' || b == 'X' || b == '^' || b == '_':
+				lb.state = stateString
+			case b >= 0x20 && b <= 0x2f:
+				// intermediate bytes, e.g. selecting a character set
+			default:
+				lb.state = stateText
+			}
+			continue
+		case stateCsi:
+			if b >= 0x40 && b <= 0x7e {
+				lb.state = stateText
+			}
+			continue
+		case stateString:
+			switch b {
+			case 0x07:
+				lb.state = stateText
+			case 0x1b:
+				lb.state = stateStringEscape
+			}
+			continue
+		case stateStringEscape:
+			lb.state = stateString
+			if b == '\\' {
+				lb.state = stateText
+			}
+			continue
+		}
+
+		switch {
+		case b == '\n' && lb.lastCR:
+			lb.lastCR = false
+			continue
+		case b == '\n' || b == '\r':
+			lb.lastCR = b == '\r'
+			lb.flush()
+			continue
+		}
+		lb.lastCR = false
+		switch {
+		case b == 0x1b:
+			lb.state = stateEscape
+		case b == '\b' || b == 0x7f:
+			if _, n := utf8.DecodeLastRune(lb.buf); n > 0 {
+				lb.buf = lb.buf[:len(lb.buf)-n]
+			}
+		case b == '\t':
+			lb.append(ts, ' ')
+		case b < 0x20:
+		default:
+			lb.append(ts, b)
+		}
+	}
+}
+
+func (lb *lineBuilder) append(ts time.Time, b byte) {
+	if len(lb.buf) == 0 {
+		lb.start = ts
+	}
+	lb.buf = append(lb.buf, b)
+	if len(lb.buf) >= maxLineLength {
+		lb.flush()
+	}
+}
+
+// flush emits the current line unless it is blank.
+func (lb *lineBuilder) flush() {
+	text := strings.TrimRightFunc(strings.ToValidUTF8(string(lb.buf), string(utf8.RuneError)), unicode.IsSpace)
+	lb.buf = lb.buf[:0]
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	lb.emit(lb.start, text)
+}
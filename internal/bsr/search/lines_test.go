@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineBuilder(t *testing.T) {
+	start := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		data      []string
+		wantLines []string
+		wantTimes []time.Time
+	}{
+		{
+			name:      "single-line",
+			data:      []string{"hello world\n"},
+			wantLines: []string{"hello world"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "crlf",
+			data:      []string{"one\r\ntwo\r\n"},
+			wantLines: []string{"one", "two"},
+			wantTimes: []time.Time{start, start},
+		},
+		{
+			name:      "unterminated",
+			data:      []string{"$ "},
+			wantLines: []string{"$"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "blank-lines",
+			data:      []string{"\n\n  \t\none\n\n"},
+			wantLines: []string{"one"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "split-across-chunks",
+			data:      []string{"sudo ", "cat /etc/", "shadow\r", "\n"},
+			wantLines: []string{"sudo cat /etc/shadow"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "line-starts-in-later-chunk",
+			data:      []string{"one\n", "two\n"},
+			wantLines: []string{"one", "two"},
+			wantTimes: []time.Time{start, start.Add(time.Second)},
+		},
+		{
+			name:      "csi",
+			data:      []string{"\x1b[01;34mdir\x1b[0m  file\n"},
+			wantLines: []string{"dir  file"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "csi-split-across-chunks",
+			data:      []string{"a\x1b[", "01;3", "4mb\n"},
+			wantLines: []string{"ab"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "osc",
+			data:      []string{"\x1b]0;user@host: ~\x07$ ls\n\x1b]0;title\x1b\\done\n"},
+			wantLines: []string{"$ ls", "done"},
+			wantTimes: []time.Time{start, start},
+		},
+		{
+			name:      "charset",
+			data:      []string{"\x1b(Bok\n"},
+			wantLines: []string{"ok"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "backspace",
+			data:      []string{"lss\b \b\n", "pwe\x7fd\n"},
+			wantLines: []string{"ls", "pwd"},
+			wantTimes: []time.Time{start, start.Add(time.Second)},
+		},
+		{
+			name:      "multi-byte-backspace",
+			data:      []string{"café\b\n"},
+			wantLines: []string{"caf"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "control-characters",
+			data:      []string{"a\x07b\tc\x00\n"},
+			wantLines: []string{"ab c"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "invalid-utf8",
+			data:      []string{"a\xffb\n"},
+			wantLines: []string{"a�b"},
+			wantTimes: []time.Time{start},
+		},
+		{
+			name:      "long-line",
+			data:      []string{strings.Repeat("a", maxLineLength+1) + "\n"},
+			wantLines: []string{strings.Repeat("a", maxLineLength), "a"},
+			wantTimes: []time.Time{start, start},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var lines []string
+			var times []time.Time
+			lb := newLineBuilder(func(ts time.Time, text string) {
+				lines = append(lines, text)
+				times = append(times, ts)
+			})
+			for i, d := range tc.data {
+				lb.write(start.Add(time.Duration(i)*time.Second), []byte(d))
+			}
+			lb.flush()
+			assert.Equal(t, tc.wantLines, lines)
+			assert.Equal(t, tc.wantTimes, times)
+		})
+	}
+}
@@ -38,6 +38,7 @@ const (
 	EnabledPluginLoopback
 	EnabledPluginAws
 	EnabledPluginHostAzure
+	EnabledPluginFilesystem
 )
 
 func (e EnabledPlugin) String() string {
@@ -48,6 +49,8 @@ func (e EnabledPlugin) String() string {
 		return "AWS"
 	case EnabledPluginHostAzure:
 		return "Azure"
+	case EnabledPluginFilesystem:
+		return "Filesystem"
 	default:
 		return ""
 	}
@@ -117,6 +120,7 @@ type Command struct {
 	FlagVersion           int
 	FlagRecursive         bool
 	FlagFilter            string
+	FlagPageSize          uint
 	FlagTags              map[string][]string
 	FlagOutputFile        string // the output file for the command
 	FlagNoClobber         bool   // Don't clobber the output file
@@ -54,6 +54,10 @@ import (
 const (
 	// File name to use for storing workerAuth requests
 	WorkerAuthReqFile = "auth_request_token"
+
+	// eventerCloseTimeout is how long the pending events of the eventer's
+	// sinks are sent for when the server shuts down.
+	eventerCloseTimeout = 30 * time.Second
 )
 
 func init() {
@@ -128,6 +132,7 @@ type Server struct {
 
 	EnabledPlugins []EnabledPlugin
 	HostPlugins    map[string]plgpb.HostPluginServiceClient
+	StoragePlugins map[string]plgpb.StoragePluginServiceClient
 
 	DevOidcSetup oidcSetup
 	DevLdapSetup ldapSetup
@@ -733,6 +738,14 @@ func (b *Server) RunShutdownFuncs() error {
 			mErr = errors.Join(mErr, err)
 		}
 	}
+	// The eventer is closed last, so the shutdown funcs can still send events.
+	if b.Eventer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), eventerCloseTimeout)
+		defer cancel()
+		if err := b.Eventer.Close(ctx); err != nil {
+			mErr = errors.Join(mErr, fmt.Errorf("Error closing the eventer: %w", err))
+		}
+	}
 	return mErr
 }
 
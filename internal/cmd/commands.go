@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/commands/accessrequestscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/aliasescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/credentialstorescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
 	"github.com/hashicorp/boundary/internal/cmd/commands/dev"
+	"github.com/hashicorp/boundary/internal/cmd/commands/events"
 	"github.com/hashicorp/boundary/internal/cmd/commands/genericcmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/groupscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogscmd"
@@ -23,6 +26,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/logout"
 	"github.com/hashicorp/boundary/internal/cmd/commands/managedgroupscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/policiescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
@@ -87,6 +91,48 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"access-requests": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"access-requests read": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read",
+			}, nil
+		},
+		"access-requests list": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "list",
+			}, nil
+		},
+		"access-requests request": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "request",
+			}, nil
+		},
+		"access-requests approve": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "approve",
+			}, nil
+		},
+		"access-requests deny": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "deny",
+			}, nil
+		},
+		"access-requests cancel": func() (cli.Command, error) {
+			return &accessrequestscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "cancel",
+			}, nil
+		},
+
 		"accounts": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -122,6 +168,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "change-password",
 			}, nil
 		},
+		"accounts unlock": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "unlock",
+			}, nil
+		},
+		"accounts enroll-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "enroll-totp",
+			}, nil
+		},
+		"accounts verify-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "verify-totp",
+			}, nil
+		},
 		"accounts create": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -171,6 +235,54 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"aliases": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"aliases read": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read",
+			}, nil
+		},
+		"aliases delete": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "delete",
+			}, nil
+		},
+		"aliases list": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "list",
+			}, nil
+		},
+		"aliases create": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}, nil
+		},
+		"aliases create target": func() (cli.Command, error) {
+			return &aliasescmd.TargetCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}, nil
+		},
+		"aliases update": func() (cli.Command, error) {
+			return &aliasescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}, nil
+		},
+		"aliases update target": func() (cli.Command, error) {
+			return &aliasescmd.TargetCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}, nil
+		},
+
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -188,6 +300,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "delete",
 			}, nil
 		},
+		"auth-methods revoke-tokens": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "revoke-tokens",
+			}, nil
+		},
 		"auth-methods list": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -567,6 +685,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"events": func() (cli.Command, error) {
+			return &events.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"events verify": func() (cli.Command, error) {
+			return &events.VerifyCommand{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -873,6 +1002,54 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"policies": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
+		"policies read": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "read",
+			}, nil
+		},
+		"policies delete": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "delete",
+			}, nil
+		},
+		"policies list": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "list",
+			}, nil
+		},
+		"policies create": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}, nil
+		},
+		"policies create storage": func() (cli.Command, error) {
+			return &policiescmd.StorageCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "create",
+			}, nil
+		},
+		"policies update": func() (cli.Command, error) {
+			return &policiescmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}, nil
+		},
+		"policies update storage": func() (cli.Command, error) {
+			return &policiescmd.StorageCommand{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "update",
+			}, nil
+		},
+
 		"read": func() (cli.Command, error) {
 			return &genericcmd.Command{
 				Command: base.NewCommand(ui, opts...),
@@ -1031,6 +1208,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "cancel",
 			}, nil
 		},
+		"sessions watch": func() (cli.Command, error) {
+			return &sessionscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "watch",
+			}, nil
+		},
 
 		"session-recordings": func() (cli.Command, error) {
 			return &sessionrecordingscmd.Command{
@@ -1054,6 +1237,11 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui, opts...),
 			}, nil
 		},
+		"session-recordings search": func() (cli.Command, error) {
+			return &sessionrecordingscmd.SearchCommand{
+				Command: base.NewCommand(ui, opts...),
+			}, nil
+		},
 
 		"storage-buckets": func() (cli.Command, error) {
 			return &storagebucketscmd.Command{
@@ -1253,6 +1441,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users revoke-tokens": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "revoke-tokens",
+			}, nil
+		},
+		"users explain-permissions": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui, opts...),
+				Func:    "explain-permissions",
+			}, nil
+		},
 
 		"workers": func() (cli.Command, error) {
 			return &workerscmd.Command{
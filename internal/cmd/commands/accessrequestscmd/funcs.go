@@ -0,0 +1,345 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequestscmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/accessrequests"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+const (
+	flagTargetId      = "target-id"
+	flagJustification = "justification"
+	flagDuration      = "duration"
+	flagComment       = "comment"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"request": {flagTargetId, flagJustification, flagDuration},
+		"approve": {"id", "version", flagComment},
+		"deny":    {"id", "version", flagComment},
+		"cancel":  {"id", "version"},
+	}
+}
+
+type extraCmdVars struct {
+	flagTargetId      string
+	flagJustification string
+	flagDuration      time.Duration
+	flagComment       string
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "request":
+		return "Request time-boxed access to a target"
+	default:
+		return ""
+	}
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case flagTargetId:
+			f.StringVar(&base.StringVar{
+				Name:   flagTargetId,
+				Target: &c.flagTargetId,
+				Usage:  "The ID of the target to request access to.",
+			})
+		case flagJustification:
+			f.StringVar(&base.StringVar{
+				Name:   flagJustification,
+				Target: &c.flagJustification,
+				Usage:  "The reason access to the target is needed.",
+			})
+		case flagDuration:
+			f.DurationVar(&base.DurationVar{
+				Name:   flagDuration,
+				Target: &c.flagDuration,
+				Usage:  `How long access to the target is needed for, e.g. "1h" or "30m". The duration starts when the request is approved.`,
+			})
+		case flagComment:
+			f.StringVar(&base.StringVar{
+				Name:   flagComment,
+				Target: &c.flagComment,
+				Usage:  "An optional comment to record with the decision.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]accessrequests.Option) bool {
+	switch c.Func {
+	case "request":
+		switch {
+		case c.flagTargetId == "":
+			c.PrintCliError(errors.New("Target ID must be passed in via -target-id"))
+			return false
+		case c.flagJustification == "":
+			c.PrintCliError(errors.New("Justification must be passed in via -justification"))
+			return false
+		case c.flagDuration < time.Second:
+			c.PrintCliError(errors.New("Duration of at least one second must be passed in via -duration"))
+			return false
+		}
+		*opts = append(*opts,
+			accessrequests.WithJustification(c.flagJustification),
+			accessrequests.WithDurationSeconds(uint32(c.flagDuration/time.Second)),
+		)
+	case "approve", "deny":
+		if c.flagComment != "" {
+			*opts = append(*opts, accessrequests.WithComment(c.flagComment))
+		}
+	}
+	return true
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary access-requests [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary access requests. Example:",
+			"",
+			"    Request access to a target for an hour:",
+			"",
+			`      $ boundary access-requests request -target-id ttcp_1234567890 -justification "incident 1234" -duration 1h`,
+			"",
+			"  Please see the access-requests subcommand help for detailed usage information.",
+		})
+
+	case "request":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests request [options] [args]",
+			"",
+			"  Request time-boxed access to a target. Once the request is approved, the target can be read and connected to until the requested duration passes. Example:",
+			"",
+			`    $ boundary access-requests request -target-id ttcp_1234567890 -justification "incident 1234" -duration 1h`,
+			"",
+			"",
+		})
+
+	case "approve":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests approve [options] [args]",
+			"",
+			"  Approve the pending access request specified by ID. Users cannot approve their own access requests. Example:",
+			"",
+			`    $ boundary access-requests approve -id areq_1234567890 -comment "approved for incident 1234"`,
+			"",
+			"",
+		})
+
+	case "deny":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests deny [options] [args]",
+			"",
+			"  Deny the pending access request specified by ID. Users cannot deny their own access requests. Example:",
+			"",
+			`    $ boundary access-requests deny -id areq_1234567890 -comment "not on call"`,
+			"",
+			"",
+		})
+
+	case "cancel":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary access-requests cancel [options] [args]",
+			"",
+			"  Cancel the pending or approved access request specified by ID. The access granted by an approved access request is revoked. Example:",
+			"",
+			`    $ boundary access-requests cancel -id areq_1234567890`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+
+	return helpStr + c.Flags().Help()
+}
+
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *accessrequests.AccessRequest, origItems []*accessrequests.AccessRequest, origError error, accessRequestClient *accessrequests.Client, version uint32, opts []accessrequests.Option) (*api.Response, *accessrequests.AccessRequest, []*accessrequests.AccessRequest, error) {
+	switch c.Func {
+	case "request":
+		result, err := accessRequestClient.Create(c.Context, c.flagTargetId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "approve":
+		result, err := accessRequestClient.Approve(c.Context, c.FlagId, version, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "deny":
+		result, err := accessRequestClient.Deny(c.Context, c.FlagId, version, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "cancel":
+		result, err := accessRequestClient.Cancel(c.Context, c.FlagId, version, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func (c *Command) printListTable(items []*accessrequests.AccessRequest) string {
+	if len(items) == 0 {
+		return "No access requests found"
+	}
+	var output []string
+	output = []string{
+		"",
+		"Access request information:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if item.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", item.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && item.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", item.ScopeId),
+			)
+		}
+		if item.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", item.Version),
+			)
+		}
+		if item.Status != "" {
+			output = append(output,
+				fmt.Sprintf("    Status:              %s", item.Status),
+			)
+		}
+		if item.TargetId != "" {
+			output = append(output,
+				fmt.Sprintf("    Target ID:           %s", item.TargetId),
+			)
+		}
+		if item.UserId != "" {
+			output = append(output,
+				fmt.Sprintf("    User ID:             %s", item.UserId),
+			)
+		}
+		if !item.CreatedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Created Time:        %s", item.CreatedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if !item.ExpirationTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Expiration Time:     %s", item.ExpirationTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(item.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, item.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *accessrequests.AccessRequest, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if item.TargetId != "" {
+		nonAttributeMap["Target ID"] = item.TargetId
+	}
+	if item.UserId != "" {
+		nonAttributeMap["User ID"] = item.UserId
+	}
+	if item.Justification != "" {
+		nonAttributeMap["Justification"] = item.Justification
+	}
+	if item.DurationSeconds != 0 {
+		nonAttributeMap["Duration"] = (time.Duration(item.DurationSeconds) * time.Second).String()
+	}
+	if item.Status != "" {
+		nonAttributeMap["Status"] = item.Status
+	}
+	if item.ApproverId != "" {
+		nonAttributeMap["Approver ID"] = item.ApproverId
+	}
+	if item.DecisionComment != "" {
+		nonAttributeMap["Decision Comment"] = item.DecisionComment
+	}
+	if !item.DecisionTime.IsZero() {
+		nonAttributeMap["Decision Time"] = item.DecisionTime.Local().Format(time.RFC1123)
+	}
+	if !item.ExpirationTime.IsZero() {
+		nonAttributeMap["Expiration Time"] = item.ExpirationTime.Local().Format(time.RFC1123)
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Access request information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
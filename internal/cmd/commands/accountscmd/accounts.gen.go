@@ -96,7 +96,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"auth-method-id", "filter"},
+	"list": {"auth-method-id", "filter", "page-size"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -194,6 +194,10 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, accounts.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	var version uint32
 
 	switch c.Func {
@@ -24,18 +24,24 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagPassword        string
 	flagCurrentPassword string
 	flagNewPassword     string
+	flagCode            string
+	totpEnrollResult    *accounts.TotpEnrollResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"change-password": {"id", "current-password", "new-password", "version"},
 		"set-password":    {"id", "password", "version"},
+		"unlock":          {"id"},
+		"enroll-totp":     {"id"},
+		"verify-totp":     {"id", "code"},
 	}
 }
 
@@ -47,6 +53,15 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "set-password":
 		return "Directly set the password on an account"
 
+	case "unlock":
+		return "Unlock an account locked after too many failed authentication attempts"
+
+	case "enroll-totp":
+		return "Enroll a TOTP second factor for an account"
+
+	case "verify-totp":
+		return "Verify the TOTP second factor enrolled for an account"
+
 	default:
 		return ""
 	}
@@ -91,6 +106,42 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "unlock":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts unlock [options] [args]",
+			"",
+			"  This command allows unlocking password-type accounts which are locked after too many failed authentication attempts. Example:",
+			"",
+			"    Unlock a password-type account:",
+			"",
+			`      $ boundary accounts unlock -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enroll-totp [options] [args]",
+			"",
+			"  This command allows enrolling a TOTP second factor for password-type accounts. The returned secret or URL must be added to an authenticator app and the enrollment verified with \"boundary accounts verify-totp\" before it is required to authenticate. Example:",
+			"",
+			"    Enroll a TOTP second factor for a password-type account:",
+			"",
+			`      $ boundary accounts enroll-totp -id acctpw_1234567890`,
+			"",
+			"",
+		})
+	case "verify-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts verify-totp [options] [args]",
+			"",
+			"  This command allows verifying the TOTP second factor enrolled for password-type accounts with a code from the authenticator app. Once verified, the code is required to authenticate. Example:",
+			"",
+			"    Verify the TOTP second factor of a password-type account:",
+			"",
+			`      $ boundary accounts verify-totp -id acctpw_1234567890 -code <empty, to be read by stdin>`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -116,6 +167,12 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagNewPassword,
 				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "code":
+			f.StringVar(&base.StringVar{
+				Name:   "code",
+				Target: &c.flagCode,
+				Usage:  "The TOTP code from the authenticator app. If not specified, the command will prompt for the code to be entered in a non-echoing way.",
+			})
 		}
 	}
 }
@@ -224,6 +281,17 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]accounts.O
 		}
 	}
 
+	if strutil.StrListContains(flagsMap[c.Func], "code") && c.flagCode == "" {
+		fmt.Print("Please enter the TOTP code (it will be hidden): ")
+		value, err := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+			return false
+		}
+		c.flagCode = strings.TrimSpace(value)
+	}
+
 	return true
 }
 
@@ -241,10 +309,57 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *accou
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "unlock":
+		result, err := accountClient.Unlock(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "enroll-totp":
+		result, err := accountClient.EnrollTotp(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.totpEnrollResult = result
+		return result.GetResponse(), result.GetItem(), nil, err
+	case "verify-totp":
+		result, err := accountClient.VerifyTotp(c.Context, c.FlagId, c.flagCode, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return result.GetResponse(), result.GetItem(), nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "enroll-totp":
+		result := c.totpEnrollResult
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printItemTable(result.GetItem(), result.GetResponse()))
+			c.UI.Output(base.WrapForHelpText([]string{
+				"",
+				"TOTP enrollment:",
+				fmt.Sprintf("  Secret:    %s", result.Secret),
+				fmt.Sprintf("  URL:       %s", result.Url),
+				"",
+				"  Add the secret or URL to an authenticator app and run \"boundary accounts verify-totp\" with a generated code to complete the enrollment.",
+			}))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(result.GetResponse()); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*accounts.Account) string {
 	if len(items) == 0 {
 		return "No accounts found"
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package aliasescmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraSynopsisFunc = extraSynopsisFuncImpl
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	if c.Func == "" {
+		// The generic synopsis pluralizes by appending an "s"
+		return "Manage Boundary aliases"
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary aliases [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary alias resources. Example:",
+			"",
+			"    Read an alias:",
+			"",
+			`      $ boundary aliases read -id alt_1234567890`,
+			"",
+			"  Please see the aliases subcommand help for detailed usage information.",
+		})
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary aliases create [type] [sub command] [options] [args]",
+			"",
+			"  This command allows create operations on Boundary alias resources. Example:",
+			"",
+			"    Create a target-type alias:",
+			"",
+			`      $ boundary aliases create target -value prod-db.example -destination-id ttcp_1234567890`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary aliases update [type] [sub command] [options] [args]",
+			"",
+			"  This command allows update operations on Boundary alias resources. Example:",
+			"",
+			"    Update a target-type alias:",
+			"",
+			`      $ boundary aliases update target -id alt_1234567890 -name devops -description "For DevOps usage"`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) printListTable(items []*aliases.Alias) string {
+	if len(items) == 0 {
+		return "No aliases found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Alias information:",
+	}
+	for i, m := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if m.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", m.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && m.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", m.ScopeId),
+			)
+		}
+		if m.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", m.Version),
+			)
+		}
+		if m.Type != "" {
+			output = append(output,
+				fmt.Sprintf("    Type:                %s", m.Type),
+			)
+		}
+		if m.Value != "" {
+			output = append(output,
+				fmt.Sprintf("    Value:               %s", m.Value),
+			)
+		}
+		if m.DestinationId != "" {
+			output = append(output,
+				fmt.Sprintf("    Destination ID:      %s", m.DestinationId),
+			)
+		}
+		if m.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", m.Name),
+			)
+		}
+		if m.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", m.Description),
+			)
+		}
+		if len(m.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, m.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *aliases.Alias, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Type != "" {
+		nonAttributeMap["Type"] = item.Type
+	}
+	if item.Value != "" {
+		nonAttributeMap["Value"] = item.Value
+	}
+	if item.DestinationId != "" {
+		nonAttributeMap["Destination ID"] = item.DestinationId
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Alias information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if attrs, err := item.GetTargetAliasAttributes(); err == nil && attrs.AuthorizeSessionArguments != nil {
+		ret = append(ret,
+			"",
+			"  Authorize Session Arguments:",
+			base.WrapMap(4, maxLength, map[string]any{
+				"Host ID": attrs.AuthorizeSessionArguments.HostId,
+			}),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package aliasescmd
+
+import (
+	"github.com/hashicorp/boundary/api/aliases"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraTargetFlagsFunc = extraTargetFlagsFuncImpl
+	extraTargetActionsFlagsMapFunc = extraTargetActionsFlagsMapFuncImpl
+	extraTargetFlagsHandlingFunc = extraTargetFlagHandlingFuncImpl
+}
+
+const (
+	valueFlagName         = "value"
+	destinationIdFlagName = "destination-id"
+	hostIdFlagName        = "authorize-session-host-id"
+)
+
+type extraTargetCmdVars struct {
+	flagValue         string
+	flagDestinationId string
+	flagHostId        string
+}
+
+func extraTargetActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			valueFlagName,
+			destinationIdFlagName,
+			hostIdFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraTargetFlagsFuncImpl(c *TargetCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("Target Alias Options")
+
+	for _, name := range flagsTargetMap[c.Func] {
+		switch name {
+		case valueFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   valueFlagName,
+				Target: &c.flagValue,
+				Usage:  "The value of the alias, a lower case dns-like name such as prod-db.example. The value must be globally unique.",
+			})
+		case destinationIdFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   destinationIdFlagName,
+				Target: &c.flagDestinationId,
+				Usage:  "The ID of the target the alias points to.",
+			})
+		case hostIdFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   hostIdFlagName,
+				Target: &c.flagHostId,
+				Usage:  "The ID of the host to use when a session is authorized using this alias. Requires a destination ID.",
+			})
+		}
+	}
+}
+
+func extraTargetFlagHandlingFuncImpl(c *TargetCommand, _ *base.FlagSets, opts *[]aliases.Option) bool {
+	switch c.flagValue {
+	case "":
+	default:
+		*opts = append(*opts, aliases.WithValue(c.flagValue))
+	}
+	switch c.flagDestinationId {
+	case "":
+	case "null":
+		*opts = append(*opts, aliases.DefaultDestinationId())
+	default:
+		*opts = append(*opts, aliases.WithDestinationId(c.flagDestinationId))
+	}
+	switch c.flagHostId {
+	case "":
+	case "null":
+		*opts = append(*opts, aliases.DefaultAttributes())
+	default:
+		*opts = append(*opts, aliases.WithAttributes(map[string]any{
+			"authorize_session_arguments": map[string]any{
+				"host_id": c.flagHostId,
+			},
+		}))
+	}
+
+	return true
+}
+
+func (c *TargetCommand) extraTargetHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary aliases create target [options] [args]",
+			"",
+			"  Create a target-type alias. Example:",
+			"",
+			`    $ boundary aliases create target -value prod-db.example -destination-id ttcp_1234567890`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary aliases update target [options] [args]",
+			"",
+			"  Update a target-type alias given its ID. Example:",
+			"",
+			`    $ boundary aliases update target -id alt_1234567890 -value prod-db.example`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
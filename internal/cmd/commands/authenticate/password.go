@@ -29,6 +29,7 @@ var (
 var (
 	envPassword  = "BOUNDARY_AUTHENTICATE_PASSWORD_PASSWORD"
 	envLoginName = "BOUNDARY_AUTHENTICATE_PASSWORD_LOGIN_NAME"
+	envTotpCode  = "BOUNDARY_AUTHENTICATE_PASSWORD_TOTP_CODE"
 )
 
 type PasswordCommand struct {
@@ -36,6 +37,7 @@ type PasswordCommand struct {
 
 	flagLoginName string
 	flagPassword  string
+	flagTotpCode  string
 
 	Opts       []common.Option
 	parsedOpts *common.Options
@@ -53,6 +55,8 @@ func (c *PasswordCommand) Help() string {
 		"",
 		`    $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo`,
 		"",
+		"  If the account has a TOTP second factor, the command prompts for the current code unless it is provided with -totp-code.",
+		"",
 		"",
 	}) + c.Flags().Help()
 }
@@ -75,6 +79,13 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 		Usage:  "The password associated with the login name. If blank, the command will prompt for the password to be entered interactively in a non-echoing way. Otherwise, this can refer to a file on disk (file://) from which a password will be read or an env var (env://) from which the password will be read.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "totp-code",
+		Target: &c.flagTotpCode,
+		EnvVar: envTotpCode,
+		Usage:  "The current TOTP code of the account, if the account has a TOTP second factor. If blank and a code is required, the command will prompt for the code to be entered interactively.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "auth-method-id",
 		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
@@ -199,5 +210,34 @@ func (c *PasswordCommand) Run(args []string) int {
 		return base.CommandCliError
 	}
 
+	// An account with a TOTP second factor receives a challenge which must be
+	// completed with the current code before a token is issued.
+	if challengeId, ok := result.Attributes["challenge_id"].(string); ok && challengeId != "" {
+		if c.flagTotpCode == "" {
+			fmt.Print("Please enter the TOTP code (it will be hidden): ")
+			value, err := password.Read(os.Stdin)
+			fmt.Print("\n")
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+				return base.CommandUserError
+			}
+			c.flagTotpCode = strings.TrimSpace(value)
+		}
+
+		result, err = aClient.Authenticate(c.Context, c.FlagAuthMethodId, "totp",
+			map[string]any{
+				"challenge_id": challengeId,
+				"code":         c.flagTotpCode,
+			})
+		if err != nil {
+			if apiErr := api.AsServerError(err); apiErr != nil {
+				c.PrintApiError(apiErr, "Error from controller when performing authentication")
+				return base.CommandApiError
+			}
+			c.PrintCliError(fmt.Errorf("Error trying to perform authentication: %w", err))
+			return base.CommandCliError
+		}
+	}
+
 	return saveAndOrPrintToken(c.Command, result)
 }
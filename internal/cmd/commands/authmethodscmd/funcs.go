@@ -6,6 +6,7 @@ package authmethodscmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/boundary/api"
@@ -15,7 +16,91 @@ import (
 )
 
 func init() {
-	extraOidcSynopsisFunc = extraSynopsisFuncImpl
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+	extraOidcSynopsisFunc = extraOidcSynopsisFuncImpl
+}
+
+type extraCmdVars struct {
+	revokeTokensResult *authmethods.RevokeTokensResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"revoke-tokens": {"id"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "revoke-tokens":
+		return "Revoke all auth tokens issued by an auth method"
+
+	default:
+		return ""
+	}
+}
+
+const (
+	authTokenTimeToLiveFlagName  = "auth-token-time-to-live-seconds"
+	authTokenTimeToStaleFlagName = "auth-token-time-to-stale-seconds"
+)
+
+// authTokenLifetimeFlagNames are the flags shared by the create and update
+// subcommands of every auth method type.
+var authTokenLifetimeFlagNames = []string{
+	authTokenTimeToLiveFlagName,
+	authTokenTimeToStaleFlagName,
+}
+
+type authTokenLifetimeCmdVars struct {
+	flagAuthTokenTimeToLive  string
+	flagAuthTokenTimeToStale string
+}
+
+func (v *authTokenLifetimeCmdVars) authTokenLifetimeFlags(f *base.FlagSet, name string) {
+	switch name {
+	case authTokenTimeToLiveFlagName:
+		f.StringVar(&base.StringVar{
+			Name:   authTokenTimeToLiveFlagName,
+			Target: &v.flagAuthTokenTimeToLive,
+			Usage:  "The number of seconds auth tokens issued by the auth method are valid for. If unset, the scope's value or the controller's configured value is used",
+		})
+	case authTokenTimeToStaleFlagName:
+		f.StringVar(&base.StringVar{
+			Name:   authTokenTimeToStaleFlagName,
+			Target: &v.flagAuthTokenTimeToStale,
+			Usage:  "The number of seconds auth tokens issued by the auth method can go unused before they become invalid. If unset, the scope's value or the controller's configured value is used",
+		})
+	}
+}
+
+func (v *authTokenLifetimeCmdVars) authTokenLifetimeOptions(opts *[]authmethods.Option) error {
+	switch v.flagAuthTokenTimeToLive {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultAuthTokenTimeToLiveSeconds())
+	default:
+		val, err := strconv.ParseUint(v.flagAuthTokenTimeToLive, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing %q: %w", v.flagAuthTokenTimeToLive, err)
+		}
+		*opts = append(*opts, authmethods.WithAuthTokenTimeToLiveSeconds(uint32(val)))
+	}
+	switch v.flagAuthTokenTimeToStale {
+	case "":
+	case "null":
+		*opts = append(*opts, authmethods.DefaultAuthTokenTimeToStaleSeconds())
+	default:
+		val, err := strconv.ParseUint(v.flagAuthTokenTimeToStale, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Error parsing %q: %w", v.flagAuthTokenTimeToStale, err)
+		}
+		*opts = append(*opts, authmethods.WithAuthTokenTimeToStaleSeconds(uint32(val)))
+	}
+	return nil
 }
 
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
@@ -57,11 +142,75 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods revoke-tokens [options] [args]",
+			"",
+			"  Delete all auth tokens issued by an auth method and cancel the sessions created with them. Example:",
+			"",
+			`    $ boundary auth-methods revoke-tokens -id ampw_1234567890`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
 
-func extraSynopsisFuncImpl(c *OidcCommand) string {
+func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *authmethods.AuthMethod, origItems []*authmethods.AuthMethod, origError error, amClient *authmethods.Client, _ uint32, opts []authmethods.Option) (*api.Response, *authmethods.AuthMethod, []*authmethods.AuthMethod, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		result, err := amClient.RevokeTokens(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.revokeTokensResult = result
+		return result.GetResponse(), nil, nil, err
+	}
+	return origResp, origItem, origItems, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printRevokeTokensTable(c.revokeTokensResult))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult.GetResponse()); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func printRevokeTokensTable(result *authmethods.RevokeTokensResult) string {
+	ret := []string{
+		"",
+		"Revoked auth tokens:",
+	}
+	if len(result.AuthTokenIds) > 0 {
+		ret = append(ret, base.WrapSlice(2, result.AuthTokenIds))
+	} else {
+		ret = append(ret, "  No auth tokens found")
+	}
+	ret = append(ret,
+		"",
+		"Canceled sessions:",
+	)
+	if len(result.SessionIds) > 0 {
+		ret = append(ret, base.WrapSlice(2, result.SessionIds))
+	} else {
+		ret = append(ret, "  No sessions found")
+	}
+	return base.WrapForHelpText(ret)
+}
+
+func extraOidcSynopsisFuncImpl(c *OidcCommand) string {
 	switch c.Func {
 	case "change-state":
 		return "Change the active state of an auth method"
@@ -164,6 +313,12 @@ func printItemTable(item *authmethods.AuthMethod, resp *api.Response) string {
 			nonAttributeMap["Is Primary For Scope"] = item.IsPrimary
 		}
 	}
+	if item.AuthTokenTimeToLiveSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Live (Seconds)"] = item.AuthTokenTimeToLiveSeconds
+	}
+	if item.AuthTokenTimeToStaleSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Stale (Seconds)"] = item.AuthTokenTimeToStaleSeconds
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
 
@@ -219,6 +374,11 @@ func printItemTable(item *authmethods.AuthMethod, resp *api.Response) string {
 }
 
 var keySubstMap = map[string]string{
-	"min_login_name_length": "Minimum Login Name Length",
-	"min_password_length":   "Minimum Password Length",
+	"min_login_name_length":          "Minimum Login Name Length",
+	"min_password_length":            "Minimum Password Length",
+	"min_password_character_classes": "Minimum Password Character Classes",
+	"password_history_depth":         "Password History Depth",
+	"max_password_age_days":          "Maximum Password Age (Days)",
+	"lockout_threshold":              "Lockout Threshold",
+	"lockout_duration_seconds":       "Lockout Duration (Seconds)",
 }
@@ -47,6 +47,7 @@ type extraLdapCmdVars struct {
 	flagAccountAttributeMaps []string
 	flagMaxPageSize          uint
 	flagDerefAliases         string
+	authTokenLifetimeCmdVars
 }
 
 const (
@@ -100,6 +101,8 @@ func extraLdapActionsFlagsMapFuncImpl() map[string][]string {
 			stateFlagName,
 			maxPageSizeFlagName,
 			derefAliasesFlagName,
+			authTokenTimeToLiveFlagName,
+			authTokenTimeToStaleFlagName,
 		},
 	}
 	flags["update"] = flags["create"]
@@ -243,6 +246,8 @@ func extraLdapFlagsFuncImpl(c *LdapCommand, set *base.FlagSets, _ *base.FlagSet)
 				Target: &c.flagDerefAliases,
 				Usage:  "Control how aliases are dereferenced when performing the search. Possible values are: never, finding, searching, and always (optional).",
 			})
+		default:
+			c.authTokenLifetimeFlags(f, name)
 		}
 	}
 }
@@ -536,6 +541,10 @@ func extraLdapFlagHandlingFuncImpl(c *LdapCommand, _ *base.FlagSets, opts *[]aut
 	default:
 		*opts = append(*opts, authmethods.WithLdapAuthMethodState(c.flagState))
 	}
+	if err := c.authTokenLifetimeOptions(opts); err != nil {
+		c.UI.Error(err.Error())
+		return false
+	}
 	return true
 }
 
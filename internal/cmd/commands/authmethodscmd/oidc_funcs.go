@@ -34,6 +34,7 @@ type extraOidcCmdVars struct {
 	flagDisableDiscoveredConfigValidation bool
 	flagDryRun                            bool
 	flagPrompts                           []string
+	authTokenLifetimeCmdVars
 }
 
 const (
@@ -68,6 +69,8 @@ func extraOidcActionsFlagsMapFuncImpl() map[string][]string {
 			claimsScopes,
 			accountClaimMaps,
 			promptsFlagName,
+			authTokenTimeToLiveFlagName,
+			authTokenTimeToStaleFlagName,
 		},
 		"change-state": {
 			idFlagName,
@@ -168,6 +171,8 @@ func extraOidcFlagsFuncImpl(c *OidcCommand, set *base.FlagSets, _ *base.FlagSet)
 				Target: &c.flagPrompts,
 				Usage:  "The optional prompt parameter that can be included in the authentication request to control the behavior of the authentication flow.",
 			})
+		default:
+			c.authTokenLifetimeFlags(f, name)
 		}
 	}
 }
@@ -299,6 +304,10 @@ func extraOidcFlagHandlingFuncImpl(c *OidcCommand, f *base.FlagSets, opts *[]aut
 	default:
 		*opts = append(*opts, authmethods.WithOidcAuthMethodPrompts(c.flagPrompts))
 	}
+	if err := c.authTokenLifetimeOptions(opts); err != nil {
+		c.UI.Error(err.Error())
+		return false
+	}
 
 	return true
 }
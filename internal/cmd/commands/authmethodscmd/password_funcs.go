@@ -18,14 +18,30 @@ func init() {
 }
 
 type extraPasswordCmdVars struct {
-	flagMinLoginNameLength string
-	flagMinPasswordLength  string
+	flagMinLoginNameLength          string
+	flagMinPasswordLength           string
+	flagMinPasswordCharacterClasses string
+	flagPasswordHistoryDepth        string
+	flagMaxPasswordAgeDays          string
+	flagLockoutThreshold            string
+	flagLockoutDurationSeconds      string
+	authTokenLifetimeCmdVars
+}
+
+var passwordPolicyFlags = []string{
+	"min-login-name-length",
+	"min-password-length",
+	"min-password-character-classes",
+	"password-history-depth",
+	"max-password-age-days",
+	"lockout-threshold",
+	"lockout-duration-seconds",
 }
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"min-login-name-length", "min-password-length"},
-		"update": {"min-login-name-length", "min-password-length"},
+		"create": append(append([]string{}, passwordPolicyFlags...), authTokenLifetimeFlagNames...),
+		"update": append(append([]string{}, passwordPolicyFlags...), authTokenLifetimeFlagNames...),
 	}
 }
 
@@ -74,6 +90,38 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagMinPasswordLength,
 				Usage:  "The minimum length of passwords",
 			})
+		case "min-password-character-classes":
+			f.StringVar(&base.StringVar{
+				Name:   "min-password-character-classes",
+				Target: &c.flagMinPasswordCharacterClasses,
+				Usage:  "The minimum number of character classes (lowercase letters, uppercase letters, digits and symbols) passwords must contain",
+			})
+		case "password-history-depth":
+			f.StringVar(&base.StringVar{
+				Name:   "password-history-depth",
+				Target: &c.flagPasswordHistoryDepth,
+				Usage:  "The number of recent passwords of an account, including the current one, which cannot be reused",
+			})
+		case "max-password-age-days":
+			f.StringVar(&base.StringVar{
+				Name:   "max-password-age-days",
+				Target: &c.flagMaxPasswordAgeDays,
+				Usage:  "The number of days after which a password expires and must be changed",
+			})
+		case "lockout-threshold":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-threshold",
+				Target: &c.flagLockoutThreshold,
+				Usage:  "The number of consecutive failed authentication attempts after which an account is locked",
+			})
+		case "lockout-duration-seconds":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-duration-seconds",
+				Target: &c.flagLockoutDurationSeconds,
+				Usage:  "The number of seconds an account stays locked after reaching the lockout threshold",
+			})
+		default:
+			c.authTokenLifetimeFlags(f, name)
 		}
 	}
 }
@@ -86,35 +134,39 @@ func extraPasswordFlagHandlingFuncImpl(c *PasswordCommand, _ *base.FlagSets, opt
 		}
 		attributes[name] = value
 	}
-	switch c.flagMinLoginNameLength {
-	case "":
-	case "null":
-		addAttribute("min_login_name_length", nil)
-	default:
-		length, err := strconv.ParseUint(c.flagMinLoginNameLength, 10, 32)
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMinLoginNameLength, err))
-			return false
+	for _, f := range []struct {
+		value     string
+		attribute string
+	}{
+		{c.flagMinLoginNameLength, "min_login_name_length"},
+		{c.flagMinPasswordLength, "min_password_length"},
+		{c.flagMinPasswordCharacterClasses, "min_password_character_classes"},
+		{c.flagPasswordHistoryDepth, "password_history_depth"},
+		{c.flagMaxPasswordAgeDays, "max_password_age_days"},
+		{c.flagLockoutThreshold, "lockout_threshold"},
+		{c.flagLockoutDurationSeconds, "lockout_duration_seconds"},
+	} {
+		switch f.value {
+		case "":
+		case "null":
+			addAttribute(f.attribute, nil)
+		default:
+			v, err := strconv.ParseUint(f.value, 10, 32)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", f.value, err))
+				return false
+			}
+			addAttribute(f.attribute, uint32(v))
 		}
-		addAttribute("min_login_name_length", uint32(length))
-	}
-
-	switch c.flagMinPasswordLength {
-	case "":
-	case "null":
-		addAttribute("min_password_length", nil)
-	default:
-		length, err := strconv.ParseUint(c.flagMinPasswordLength, 10, 32)
-		if err != nil {
-			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMinPasswordLength, err))
-			return false
-		}
-		addAttribute("min_password_length", uint32(length))
 	}
 
 	if attributes != nil {
 		*opts = append(*opts, authmethods.WithAttributes(attributes))
 	}
+	if err := c.authTokenLifetimeOptions(opts); err != nil {
+		c.UI.Error(err.Error())
+		return false
+	}
 
 	return true
 }
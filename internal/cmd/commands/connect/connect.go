@@ -135,7 +135,7 @@ func (c *Command) Help() string {
 	switch c.Func {
 	case "connect":
 		return base.WrapForHelpText([]string{
-			"Usage: boundary connect [options] [args]",
+			"Usage: boundary connect [options] [alias] [args]",
 			"",
 			`  This command performs a target authorization (or consumes an existing authorization token) and launches a proxied connection.`,
 			"",
@@ -143,12 +143,16 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary connect -target-id ttcp_1234567890`,
 			"",
+			"  A target can also be addressed by one of its aliases, which must follow all options:",
+			"",
+			`      $ boundary connect -listen-port 5432 prod-db.example`,
+			"",
 			"",
 		}) + c.Flags().Help()
 
 	default:
 		return base.WrapForHelpText([]string{
-			fmt.Sprintf("Usage: boundary connect %s [options] [args]", c.Func),
+			fmt.Sprintf("Usage: boundary connect %s [options] [alias] [args]", c.Func),
 			"",
 			fmt.Sprintf(`  This command performs a target authorization (or consumes an existing authorization token) and launches a proxied %s connection.`, c.Func),
 			"",
@@ -156,6 +160,10 @@ func (c *Command) Help() string {
 			"",
 			fmt.Sprintf(`      $ boundary connect %s -target-id ttcp_1234567890`, c.Func),
 			"",
+			"  A target can also be addressed by one of its aliases, which must follow all options:",
+			"",
+			fmt.Sprintf(`      $ boundary connect %s prod-db.example`, c.Func),
+			"",
 			"",
 		}) + c.Flags().Help()
 	}
@@ -277,6 +285,22 @@ func (c *Command) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
+	// A positional argument names a target alias, which the controller
+	// resolves to the alias's destination when authorizing the session.
+	// Parsing stops at the alias, so anything following it is rejected
+	// rather than silently ignored.
+	if aliasArgs := f.Args(); len(aliasArgs) > 0 {
+		switch {
+		case len(aliasArgs) > 1:
+			c.PrintCliError(fmt.Errorf("Unexpected arguments after target alias %q: %s. Options must come before the alias, and arguments for the executed client after --.", aliasArgs[0], strings.Join(aliasArgs[1:], " ")))
+			return base.CommandUserError
+		case c.flagAuthzToken != "", c.flagTargetId != "", c.flagTargetName != "":
+			c.PrintCliError(errors.New("A target alias cannot be specified along with -authz-token, -target-id, or -target-name"))
+			return base.CommandUserError
+		}
+		c.flagTargetId = aliasArgs[0]
+	}
+
 	switch {
 	case c.flagAuthzToken != "":
 		switch {
@@ -110,6 +110,7 @@ type Command struct {
 	flagWorkerAuthCaCertificateLifetime  time.Duration
 	flagWorkerAuthDebuggingEnabled       bool
 	flagWorkerRecordingStorageDir        string
+	flagWorkerFilesystemStorageDir       string
 	flagBsrKey                           string
 }
 
@@ -407,6 +408,12 @@ func (c *Command) Flags() *base.FlagSets {
 		Usage:  "Specifies the directory to store worker session recordings in dev mode. If not provided a temp directory will be created. Session recording is an Enterprise-only feature.",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "worker-filesystem-storage-dir",
+		Target: &c.flagWorkerFilesystemStorageDir,
+		Usage:  "Specifies the directory the built-in filesystem storage plugin stores storage buckets in. If not provided the filesystem storage plugin is not available on the worker.",
+	})
+
 	f.BoolVar(&base.BoolVar{
 		Name:   "worker-auth-storage-skip-cleanup",
 		Target: &c.flagWorkerAuthStorageSkipCleanup,
@@ -506,6 +513,7 @@ func (c *Command) Run(args []string) int {
 	if !c.flagControllerOnly {
 		c.Config.Worker.AuthStoragePath = c.flagWorkerAuthStorageDir
 		c.Config.Worker.RecordingStoragePath = c.flagWorkerRecordingStorageDir
+		c.Config.Worker.FilesystemStoragePath = c.flagWorkerFilesystemStorageDir
 
 		if c.Config.Worker.RecordingStoragePath == "" {
 			// Create a temp dir for recording storage
@@ -787,7 +795,7 @@ func (c *Command) Run(args []string) int {
 	}
 
 	{
-		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginAws, base.EnabledPluginHostAzure)
+		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginAws, base.EnabledPluginHostAzure, base.EnabledPluginFilesystem)
 		conf := &controller.Config{
 			RawConfig: c.Config,
 			Server:    c.Server,
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package events
+
+import (
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+var _ cli.Command = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Work with event files written by Boundary"
+}
+
+func (c *Command) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary events <subcommand> [options] [args]",
+		"",
+		"  This command groups subcommands for operators working with the event files written by Boundary's file sinks. Example:",
+		"",
+		"    Verify the hash chain of an audit event file:",
+		"",
+		"      $ boundary events verify -file=audit.log -public-key-file=audit-chain.pub",
+		"",
+		"  Please see the individual subcommand help for detailed usage information.",
+	})
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
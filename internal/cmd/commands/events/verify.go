@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package events
+
+import (
+	"compress/gzip"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*VerifyCommand)(nil)
+	_ cli.CommandAutocomplete = (*VerifyCommand)(nil)
+)
+
+type VerifyCommand struct {
+	*base.Command
+
+	flagFile          string
+	flagPublicKeyFile string
+	flagRotated       bool
+}
+
+func (c *VerifyCommand) Synopsis() string {
+	return "Verify the hash chain of an audit event file"
+}
+
+func (c *VerifyCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary events verify [options]",
+		"",
+		"  Verify the hash chain of an event file written by a file sink with a hash_chain block. Lines which were removed, reordered or modified are reported, as well as checkpoints whose signature is invalid. Example:",
+		"",
+		"    $ boundary events verify -file=audit.log -public-key-file=audit-chain.pub",
+		"",
+		"  The hash chain of a file is expected to start at sequence 1, otherwise lines were removed from its start. Once the file sink rotated the file, the chain of the current and the rotated files continues from the previous file, so use -rotated to verify such a file, which may be compressed with gzip, from its first line. Events written after the last checkpoint are reported as unsealed, since changes to them can't be detected when the rest of the hash chain is rewritten. The command exits with status 2 when problems are found.",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *VerifyCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "The event file to verify.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "public-key-file",
+		Target:     &c.flagPublicKeyFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "A PEM file with the ed25519 public key of the hash chain's signing key. If not set, the signatures of checkpoints aren't verified.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "rotated",
+		Target: &c.flagRotated,
+		Usage:  "If set, the file is expected to continue the hash chain of a rotated file, so a chain which doesn't start at sequence 1 isn't reported as a problem.",
+	})
+
+	return set
+}
+
+func (c *VerifyCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VerifyCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VerifyCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	c.flagFile = strings.TrimSpace(c.flagFile)
+	if c.flagFile == "" {
+		c.UI.Error("Missing required parameter -file")
+		return base.CommandUserError
+	}
+
+	var publicKey ed25519.PublicKey
+	if c.flagPublicKeyFile != "" {
+		b, err := os.ReadFile(strings.TrimSpace(c.flagPublicKeyFile))
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error reading public key file: %w", err).Error())
+			return base.CommandUserError
+		}
+		publicKey, err = event.ParseHashChainPublicKey(b)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error parsing public key: %w", err).Error())
+			return base.CommandUserError
+		}
+	}
+
+	file, err := os.Open(c.flagFile)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error opening event file: %w", err).Error())
+		return base.CommandUserError
+	}
+	defer file.Close()
+	var r io.Reader = file
+	// rotated files may have been compressed by the file sink
+	if strings.HasSuffix(c.flagFile, ".gz") {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error decompressing event file: %w", err).Error())
+			return base.CommandUserError
+		}
+		r = zr
+	}
+
+	var opts []event.Option
+	if c.flagRotated {
+		opts = append(opts, event.WithRotated())
+	}
+	report, err := event.VerifyHashChain(r, publicKey, opts...)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error verifying event file: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	ret := base.CommandSuccess
+	if !report.Valid() {
+		ret = base.CommandCliError
+	}
+
+	if base.Format(c.UI) == "json" {
+		b, err := base.JsonFormatter{}.Format(report)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+		return ret
+	}
+
+	nonAttributeMap := map[string]any{
+		"Lines":               report.Lines,
+		"Events":              report.Events,
+		"Checkpoints":         report.Checkpoints,
+		"First Sequence":      report.FirstSeq,
+		"Last Sequence":       report.LastSeq,
+		"Unsealed Events":     report.UnsealedEvents,
+		"Signatures Verified": report.SignaturesVerified,
+	}
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	result := "valid"
+	if !report.Valid() {
+		result = fmt.Sprintf("%d problems found", len(report.Problems))
+	}
+	out := []string{
+		"",
+		fmt.Sprintf("Hash chain of %s: %s", c.flagFile, result),
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+	if len(report.Problems) > 0 {
+		out = append(out,
+			"",
+			"  Problems:",
+		)
+		for _, p := range report.Problems {
+			out = append(out, fmt.Sprintf("    Line %d: %s", p.Line, p.Message))
+		}
+	}
+	c.UI.Output(base.WrapForHelpText(out))
+
+	if !report.SignaturesVerified {
+		c.UI.Warn("The signatures of checkpoints were not verified since no -public-key-file was given.")
+	}
+	return ret
+}
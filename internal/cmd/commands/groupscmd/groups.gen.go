@@ -106,7 +106,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"scope-id", "filter", "recursive"},
+	"list": {"scope-id", "filter", "recursive", "page-size"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -209,6 +209,10 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, groups.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, groups.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	var version uint32
 
 	switch c.Func {
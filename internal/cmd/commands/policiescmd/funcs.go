@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package policiescmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/policies"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraSynopsisFunc = extraSynopsisFuncImpl
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	if c.Func == "" {
+		// The generic synopsis pluralizes by appending an "s"
+		return "Manage Boundary policies"
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary policies [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary policy resources. Example:",
+			"",
+			"    Read a policy:",
+			"",
+			`      $ boundary policies read -id pst_1234567890`,
+			"",
+			"  Please see the policies subcommand help for detailed usage information.",
+		})
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary policies create [type] [sub command] [options] [args]",
+			"",
+			"  This command allows create operations on Boundary policy resources. Example:",
+			"",
+			"    Create a storage-type policy:",
+			"",
+			`      $ boundary policies create storage -scope-id global -retain-for-days 30 -delete-after-days 90`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary policies update [type] [sub command] [options] [args]",
+			"",
+			"  This command allows update operations on Boundary policy resources. Example:",
+			"",
+			"    Update a storage-type policy:",
+			"",
+			`      $ boundary policies update storage -id pst_1234567890 -legal-hold true`,
+			"",
+			"  Please see the typed subcommand help for detailed usage information.",
+		})
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) printListTable(items []*policies.Policy) string {
+	if len(items) == 0 {
+		return "No policies found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Policy information:",
+	}
+	for i, m := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if m.Id != "" {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", m.Id),
+			)
+		} else {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", "(not available)"),
+			)
+		}
+		if c.FlagRecursive && m.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", m.ScopeId),
+			)
+		}
+		if m.Version > 0 {
+			output = append(output,
+				fmt.Sprintf("    Version:             %d", m.Version),
+			)
+		}
+		if m.Type != "" {
+			output = append(output,
+				fmt.Sprintf("    Type:                %s", m.Type),
+			)
+		}
+		if m.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", m.Name),
+			)
+		}
+		if m.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", m.Description),
+			)
+		}
+		if len(m.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, m.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(item *policies.Policy, resp *api.Response) string {
+	nonAttributeMap := map[string]any{}
+	if item.Id != "" {
+		nonAttributeMap["ID"] = item.Id
+	}
+	if item.Version != 0 {
+		nonAttributeMap["Version"] = item.Version
+	}
+	if !item.CreatedTime.IsZero() {
+		nonAttributeMap["Created Time"] = item.CreatedTime.Local().Format(time.RFC1123)
+	}
+	if !item.UpdatedTime.IsZero() {
+		nonAttributeMap["Updated Time"] = item.UpdatedTime.Local().Format(time.RFC1123)
+	}
+	if item.Name != "" {
+		nonAttributeMap["Name"] = item.Name
+	}
+	if item.Description != "" {
+		nonAttributeMap["Description"] = item.Description
+	}
+	if item.Type != "" {
+		nonAttributeMap["Type"] = item.Type
+	}
+
+	var attributeMap map[string]any
+	if attrs, err := item.GetStoragePolicyAttributes(); err == nil {
+		attributeMap = map[string]any{
+			"Retain For Days":   attrs.RetainForDays,
+			"Delete After Days": attrs.DeleteAfterDays,
+			"Legal Hold":        attrs.LegalHold,
+		}
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, attributeMap, nil)
+
+	ret := []string{
+		"",
+		"Policy information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if item.Scope != nil {
+		ret = append(ret,
+			"",
+			"  Scope:",
+			base.ScopeInfoForOutput(item.Scope, maxLength),
+		)
+	}
+
+	if len(item.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, item.AuthorizedActions),
+		)
+	}
+
+	if len(attributeMap) > 0 {
+		ret = append(ret,
+			"",
+			"  Attributes:",
+			base.WrapMap(4, maxLength, attributeMap),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package policiescmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/boundary/api/policies"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraStorageFlagsFunc = extraStorageFlagsFuncImpl
+	extraStorageActionsFlagsMapFunc = extraStorageActionsFlagsMapFuncImpl
+	extraStorageFlagsHandlingFunc = extraStorageFlagHandlingFuncImpl
+}
+
+const (
+	retainForDaysFlagName   = "retain-for-days"
+	deleteAfterDaysFlagName = "delete-after-days"
+	legalHoldFlagName       = "legal-hold"
+)
+
+type extraStorageCmdVars struct {
+	flagRetainForDays   string
+	flagDeleteAfterDays string
+	flagLegalHold       string
+}
+
+func extraStorageActionsFlagsMapFuncImpl() map[string][]string {
+	flags := map[string][]string{
+		"create": {
+			retainForDaysFlagName,
+			deleteAfterDaysFlagName,
+			legalHoldFlagName,
+		},
+	}
+	flags["update"] = flags["create"]
+	return flags
+}
+
+func extraStorageFlagsFuncImpl(c *StorageCommand, set *base.FlagSets, _ *base.FlagSet) {
+	f := set.NewFlagSet("Storage Policy Options")
+
+	for _, name := range flagsStorageMap[c.Func] {
+		switch name {
+		case retainForDaysFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   retainForDaysFlagName,
+				Target: &c.flagRetainForDays,
+				Usage:  "The number of days session recordings must be retained for. -1 retains session recordings forever.",
+			})
+		case deleteAfterDaysFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   deleteAfterDaysFlagName,
+				Target: &c.flagDeleteAfterDays,
+				Usage:  "The number of days after which session recordings are deleted. 0 never deletes session recordings.",
+			})
+		case legalHoldFlagName:
+			f.StringVar(&base.StringVar{
+				Name:   legalHoldFlagName,
+				Target: &c.flagLegalHold,
+				Usage:  "A boolean indicating if session recordings are under a legal hold, which prevents them from being deleted.",
+			})
+		}
+	}
+}
+
+func extraStorageFlagHandlingFuncImpl(c *StorageCommand, _ *base.FlagSets, opts *[]policies.Option) bool {
+	attrs := map[string]any{}
+
+	switch c.flagRetainForDays {
+	case "":
+	case "null":
+		attrs["retain_for_days"] = nil
+	default:
+		days, err := strconv.ParseInt(c.flagRetainForDays, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagRetainForDays, err))
+			return false
+		}
+		attrs["retain_for_days"] = int32(days)
+	}
+
+	switch c.flagDeleteAfterDays {
+	case "":
+	case "null":
+		attrs["delete_after_days"] = nil
+	default:
+		days, err := strconv.ParseInt(c.flagDeleteAfterDays, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagDeleteAfterDays, err))
+			return false
+		}
+		attrs["delete_after_days"] = int32(days)
+	}
+
+	switch c.flagLegalHold {
+	case "":
+	case "false":
+		attrs["legal_hold"] = false
+	case "true":
+		attrs["legal_hold"] = true
+	default:
+		c.UI.Error(fmt.Sprintf("Invalid bool value for legal-hold %v", c.flagLegalHold))
+		return false
+	}
+
+	if len(attrs) > 0 {
+		*opts = append(*opts, policies.WithAttributes(attrs))
+	}
+
+	return true
+}
+
+func (c *StorageCommand) extraStorageHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary policies create storage [options] [args]",
+			"",
+			"  Create a storage-type policy. Example:",
+			"",
+			`    $ boundary policies create storage -scope-id global -retain-for-days 30 -delete-after-days 90`,
+			"",
+			"",
+		})
+
+	case "update":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary policies update storage [options] [args]",
+			"",
+			"  Update a storage-type policy given its ID. Example:",
+			"",
+			`    $ boundary policies update storage -id pst_1234567890 -legal-hold true`,
+			"",
+			"",
+		})
+	}
+	return helpStr + c.Flags().Help()
+}
@@ -6,6 +6,7 @@ package scopescmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/boundary/api"
@@ -17,6 +18,8 @@ const (
 	flagPrimaryAuthMethodIdName     = "primary-auth-method-id"
 	flagSkipAdminRoleCreationName   = "skip-admin-role-creation"
 	flagSkipDefaultRoleCreationName = "skip-default-role-creation"
+	flagAuthTokenTimeToLiveName     = "auth-token-time-to-live-seconds"
+	flagAuthTokenTimeToStaleName    = "auth-token-time-to-stale-seconds"
 )
 
 func init() {
@@ -27,8 +30,8 @@ func init() {
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName},
-		"update": {flagPrimaryAuthMethodIdName},
+		"create": {flagSkipAdminRoleCreationName, flagSkipDefaultRoleCreationName, flagAuthTokenTimeToLiveName, flagAuthTokenTimeToStaleName},
+		"update": {flagPrimaryAuthMethodIdName, flagAuthTokenTimeToLiveName, flagAuthTokenTimeToStaleName},
 	}
 }
 
@@ -36,6 +39,8 @@ type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
 	flagPrimaryAuthMethodId     string
+	flagAuthTokenTimeToLive     string
+	flagAuthTokenTimeToStale    string
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -59,6 +64,18 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagPrimaryAuthMethodId,
 				Usage:  "If set, the primary auth method id for the scope.  A primary auth method is allowed to create users on first login and is also used as a source for account full name and email for a scope's users",
 			})
+		case flagAuthTokenTimeToLiveName:
+			f.StringVar(&base.StringVar{
+				Name:   flagAuthTokenTimeToLiveName,
+				Target: &c.flagAuthTokenTimeToLive,
+				Usage:  "The number of seconds auth tokens issued by auth methods in the scope are valid for. Auth methods can override it. If unset, the controller's configured value is used",
+			})
+		case flagAuthTokenTimeToStaleName:
+			f.StringVar(&base.StringVar{
+				Name:   flagAuthTokenTimeToStaleName,
+				Target: &c.flagAuthTokenTimeToStale,
+				Usage:  "The number of seconds auth tokens issued by auth methods in the scope can go unused before they become invalid. Auth methods can override it. If unset, the controller's configured value is used",
+			})
 		}
 	}
 }
@@ -73,6 +90,30 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]scopes.Opt
 	if c.flagPrimaryAuthMethodId != "" {
 		*opts = append(*opts, scopes.WithPrimaryAuthMethodId(c.flagPrimaryAuthMethodId))
 	}
+	switch c.flagAuthTokenTimeToLive {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultAuthTokenTimeToLiveSeconds())
+	default:
+		val, err := strconv.ParseUint(c.flagAuthTokenTimeToLive, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToLive, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithAuthTokenTimeToLiveSeconds(uint32(val)))
+	}
+	switch c.flagAuthTokenTimeToStale {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultAuthTokenTimeToStaleSeconds())
+	default:
+		val, err := strconv.ParseUint(c.flagAuthTokenTimeToStale, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToStale, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithAuthTokenTimeToStaleSeconds(uint32(val)))
+	}
 
 	return true
 }
@@ -154,6 +195,12 @@ func printItemTable(item *scopes.Scope, resp *api.Response) string {
 	if item.PrimaryAuthMethodId != "" {
 		nonAttributeMap["Primary Auth Method ID"] = item.PrimaryAuthMethodId
 	}
+	if item.AuthTokenTimeToLiveSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Live (Seconds)"] = item.AuthTokenTimeToLiveSeconds
+	}
+	if item.AuthTokenTimeToStaleSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Stale (Seconds)"] = item.AuthTokenTimeToStaleSeconds
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -126,6 +126,72 @@ listener "tcp" {
 	tls_disable = true
 }
 
+listener "tcp" {
+	address = "127.0.0.1:9501"
+	purpose = "cluster"
+}
+`
+
+	ratelimitConfigOverridesReload = `
+disable_mlock = true
+
+telemetry {
+	prometheus_retention_time = "24h"
+	disable_hostname = true
+}
+
+controller {
+	name = "test-controller"
+	description = "A default controller created for tests"
+	database {
+		url = "%s"
+	}
+
+	api_rate_limit {
+		resources = ["*"]
+		actions   = ["*"]
+		per       = "total"
+		limit     = 5
+		period    = "1m"
+	}
+
+	api_rate_limit {
+		resources = ["*"]
+		actions   = ["list"]
+		per       = "total"
+		limit     = 100
+		period    = "1m"
+		users     = ["u_1234567890"]
+	}
+}
+
+kms "aead" {
+	purpose = "root"
+	aead_type = "aes-gcm"
+	key = "%s"
+	key_id = "global_root"
+}
+
+kms "aead" {
+	purpose = "worker-auth"
+	aead_type = "aes-gcm"
+	key = "%s"
+	key_id = "global_worker-auth"
+}
+
+kms "aead" {
+	purpose = "recovery"
+	aead_type = "aes-gcm"
+	key = "%s"
+	key_id = "global_recovery"
+}
+
+listener "tcp" {
+	purpose = "api"
+	address = "127.0.0.1:9500"
+	tls_disable = true
+}
+
 listener "tcp" {
 	address = "127.0.0.1:9501"
 	purpose = "cluster"
@@ -282,6 +348,82 @@ func TestRealodControllerRateLimits(t *testing.T) {
 	wg.Wait()
 }
 
+func TestRealodControllerRateLimitsOverrides(t *testing.T) {
+	td := t.TempDir()
+
+	controllerKey := config.DevKeyGeneration()
+
+	closeDB, url, _, err := getInitDatabase(t, controllerKey)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, closeDB()) })
+
+	cmd := testServerCommand(t, testServerCommandOpts{})
+
+	workerAuthKey := config.DevKeyGeneration()
+	recoveryKey := config.DevKeyGeneration()
+	cfgHcl := fmt.Sprintf(ratelimitConfig, url, controllerKey, workerAuthKey, recoveryKey)
+	require.NoError(t, os.WriteFile(td+"/config.hcl", []byte(cfgHcl), 0o644))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		args := []string{"-config", td + "/config.hcl"}
+		exitCode := cmd.Run(args)
+		if exitCode != 0 {
+			output := cmd.UI.(*cli.MockUi).ErrorWriter.String() + cmd.UI.(*cli.MockUi).OutputWriter.String()
+			t.Errorf("got a non-zero exit status: %s", output)
+		}
+	}()
+
+	// Wait until things are up and running (or timeout).
+	select {
+	case <-cmd.startedCh:
+	case <-time.After(15 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	// Change config so it is ready for reloading
+	cfgHcl = fmt.Sprintf(ratelimitConfigOverridesReload, url, controllerKey, workerAuthKey, recoveryKey)
+	require.NoError(t, os.WriteFile(td+"/config.hcl", []byte(cfgHcl), 0o644))
+
+	c := http.Client{}
+	r, err := c.Do(func() *http.Request {
+		r, err := http.NewRequest(http.MethodGet, `http://127.0.0.1:9500/v1/targets`, nil)
+		require.NoError(t, err)
+		return r
+	}())
+	require.NoError(t, err)
+	// unauthed request, so we expect a 400
+	assert.Equal(t, http.StatusBadRequest, r.StatusCode)
+	assert.Equal(t, `limit=2, remaining=1, reset=60`, r.Header.Get("Ratelimit"))
+
+	cmd.SighupCh <- struct{}{}
+	select {
+	case <-cmd.reloadedCh:
+	case <-time.After(15 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	// Make another request, the limit should have reset. Overrides don't apply
+	// to requests without an auth token, so the new default limit should get
+	// reported via the headers.
+	r, err = c.Do(func() *http.Request {
+		r, err := http.NewRequest(http.MethodGet, `http://127.0.0.1:9500/v1/targets`, nil)
+		require.NoError(t, err)
+		return r
+	}())
+	require.NoError(t, err)
+	// unauthed request, so we expect a 400
+	assert.Equal(t, http.StatusBadRequest, r.StatusCode)
+	assert.Equal(t, `limit=5, remaining=4, reset=60`, r.Header.Get("Ratelimit"))
+	assert.Equal(t, `5;w=60;comment="total", 1500;w=30;comment="ip-address", 150;w=30;comment="auth-token"`, r.Header.Get("Ratelimit-Policy"))
+
+	cmd.ShutdownCh <- struct{}{}
+	wg.Wait()
+}
+
 func TestRealodControllerRateLimitsSameConfig(t *testing.T) {
 	td := t.TempDir()
 
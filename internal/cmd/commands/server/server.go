@@ -494,7 +494,7 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
-	c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginAws)
+	c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginAws, base.EnabledPluginFilesystem)
 	if c.Config.Controller != nil {
 		c.EnabledPlugins = append(c.EnabledPlugins, base.EnabledPluginHostAzure)
 		if err := c.StartController(c.Context); err != nil {
@@ -845,6 +845,10 @@ func (c *Command) Reload(newConf *config.Config) error {
 		reloadErrors = stderrors.Join(reloadErrors, fmt.Errorf("failed to reload controller api rate limits: %w", err))
 	}
 
+	if err := c.reloadEventer(newConf); err != nil {
+		reloadErrors = stderrors.Join(reloadErrors, fmt.Errorf("failed to reload event sinks: %w", err))
+	}
+
 	if newConf != nil && c.worker != nil {
 		workerReloadErr := func() error {
 			if newConf.Controller != nil {
@@ -963,6 +967,21 @@ func (c *Command) reloadControllerRateLimits(newConfig *config.Config) error {
 	return c.controller.ReloadRateLimiter(newConfig)
 }
 
+// reloadEventer applies the rotation settings of the file sinks in the new
+// config and reopens the sinks, so files rotated by an external tool are
+// recreated.
+func (c *Command) reloadEventer(newConfig *config.Config) error {
+	if c.Eventer == nil {
+		return nil
+	}
+	if newConfig != nil && newConfig.Eventing != nil {
+		if err := c.Eventer.ReloadFileSinks(newConfig.Eventing.Sinks); err != nil {
+			return err
+		}
+	}
+	return c.Eventer.Reopen()
+}
+
 // acquireSchemaManager returns a schema manager and generally acquires a shared lock on
 // the database. This is done as a mechanism to disallow running migration commands
 // while the database is in use.
@@ -23,12 +23,33 @@ var (
 	_ cli.CommandAutocomplete = (*DownloadCommand)(nil)
 )
 
+// Supported download formats and the default extension of their download
+// files (the extension is not used when an output file is specified).
 const (
-	castExt = ".cast" // default download file extension (is overridden when an output file is specified)
+	formatAsciicast  = "asciicast"
+	formatTranscript = "transcript"
+	formatJsonl      = "jsonl"
+
+	castExt       = ".cast"
+	transcriptExt = ".txt"
+	jsonlExt      = ".jsonl"
 )
 
+// downloadFormats maps the supported download formats to their mime type
+// and default file extension.
+var downloadFormats = map[string]struct {
+	mimeType string
+	ext      string
+}{
+	formatAsciicast:  {mimeType: api.AsciiCastMimeType, ext: castExt},
+	formatTranscript: {mimeType: api.TranscriptMimeType, ext: transcriptExt},
+	formatJsonl:      {mimeType: api.JsonlMimeType, ext: jsonlExt},
+}
+
 type DownloadCommand struct {
 	*base.Command
+
+	flagFormat string
 }
 
 func (c *DownloadCommand) Synopsis() string {
@@ -43,6 +64,10 @@ func (c *DownloadCommand) Help() string {
 		"",
 		`    $ boundary session-recordings download -id chr_u6e9wJ8B8H`,
 		"",
+		"  Download a channel recording as a plain text transcript of its input and output:",
+		"",
+		`    $ boundary session-recordings download -id chr_u6e9wJ8B8H -format transcript`,
+		"",
 		"",
 	}) + c.Flags().Help()
 }
@@ -59,9 +84,18 @@ func (c *DownloadCommand) Flags() *base.FlagSets {
 	f.StringVar(&base.StringVar{
 		Name:    "output",
 		Target:  &c.FlagOutputFile,
-		Usage:   "An optional output file for the download. If not provided the recording id will be used with a \".cast\" extension. Use \"-\" for stdout.",
+		Usage:   "An optional output file for the download. If not provided the recording id will be used with an extension for the format, e.g. \".cast\". Use \"-\" for stdout.",
 		Aliases: []string{"o"},
 	})
+	f.StringVar(&base.StringVar{
+		Name:       "format",
+		Target:     &c.flagFormat,
+		Default:    formatAsciicast,
+		Completion: complete.PredictSet(formatAsciicast, formatTranscript, formatJsonl),
+		Usage: `The format of the download. "asciicast" is an asciinema recording of the output of a shell. ` +
+			`"transcript" is a plain text transcript of the input and output, each line prefixed with its time and direction. ` +
+			`"jsonl" is a JSON object per line for every SSH request, such as exec, pty-req, env, window-change or exit-status.`,
+	})
 	f.BoolVar(&base.BoolVar{
 		Name:    "no-clobber",
 		Target:  &c.FlagNoClobber,
@@ -87,10 +121,14 @@ func (c *DownloadCommand) Run(args []string) int {
 		return base.CommandUserError
 	}
 
+	format, ok := downloadFormats[c.flagFormat]
 	switch {
 	case c.FlagId == "":
 		c.PrintCliError(errors.New("ID must be provided via -id"))
 		return base.CommandUserError
+	case !ok:
+		c.PrintCliError(fmt.Errorf("Unsupported format %q, must be one of %q, %q or %q", c.flagFormat, formatAsciicast, formatTranscript, formatJsonl))
+		return base.CommandUserError
 	}
 
 	client, err := c.Client()
@@ -107,7 +145,7 @@ func (c *DownloadCommand) Run(args []string) int {
 	}
 
 	sClient := sessionrecordings.NewClient(client)
-	result, err := sClient.Download(c.Context, c.FlagId)
+	result, err := sClient.Download(c.Context, c.FlagId, sessionrecordings.WithMimeType(format.mimeType))
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when downloading session recording")
@@ -146,7 +184,7 @@ func (c *DownloadCommand) Run(args []string) int {
 		}
 		defer outFile.Close()
 	default:
-		fileName := getNextFileName(c.FlagId)
+		fileName := getNextFileName(c.FlagId, format.ext)
 		outFile, err = os.Create(fileName)
 		if err != nil {
 			c.PrintCliError(fmt.Errorf("Unable to create download file %q: %w", fileName, err))
@@ -162,13 +200,13 @@ func (c *DownloadCommand) Run(args []string) int {
 	return base.CommandSuccess
 }
 
-func getNextFileName(baseName string) string {
-	if _, err := os.Stat(baseName + castExt); os.IsNotExist(err) {
-		return baseName + castExt
+func getNextFileName(baseName, ext string) string {
+	if _, err := os.Stat(baseName + ext); os.IsNotExist(err) {
+		return baseName + ext
 	}
 	startIndex := 1
 	for {
-		fileName := baseName + castExt + "." + strconv.Itoa(startIndex)
+		fileName := baseName + ext + "." + strconv.Itoa(startIndex)
 		if _, err := os.Stat(fileName); os.IsNotExist(err) {
 			return fileName
 		}
@@ -37,6 +37,10 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			`      $ boundary session-recordings download -id chr_1234567890`,
 			"",
+			"    Search the output of session recordings:",
+			"",
+			`      $ boundary session-recordings search -scope-id global -recursive -query "sudo"`,
+			"",
 
 			"  Please see the sessions subcommand help for detailed usage information.",
 		})
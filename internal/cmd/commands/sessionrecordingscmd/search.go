@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package sessionrecordingscmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/sessionrecordings"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*SearchCommand)(nil)
+	_ cli.CommandAutocomplete = (*SearchCommand)(nil)
+)
+
+var searchFlags = map[string][]string{
+	"search": {"scope-id", "recursive"},
+}
+
+type SearchCommand struct {
+	*base.Command
+
+	flagQuery string
+}
+
+func (c *SearchCommand) Synopsis() string {
+	return wordwrap.WrapString("Search the output of session recordings", base.TermWidth)
+}
+
+func (c *SearchCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary session-recordings search [args]",
+		"",
+		"  Search the output of the indexed session recordings of a scope for a string, ignoring case. Example:",
+		"",
+		`    $ boundary session-recordings search -scope-id o_1234567890 -query "sudo rm"`,
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *SearchCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "session recording", searchFlags, "search")
+
+	f.StringVar(&base.StringVar{
+		Name:   "query",
+		Target: &c.flagQuery,
+		Usage:  "The string to search the output of the session recordings for.",
+	})
+	return set
+}
+
+func (c *SearchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *SearchCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *SearchCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	switch {
+	case c.FlagScopeId == "":
+		c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+		return base.CommandUserError
+	case c.flagQuery == "":
+		c.PrintCliError(errors.New("Query must be provided via -query"))
+		return base.CommandUserError
+	}
+
+	client, err := c.Client()
+	if c.WrapperCleanupFunc != nil {
+		defer func() {
+			if err := c.WrapperCleanupFunc(); err != nil {
+				c.PrintCliError(fmt.Errorf("Error cleaning kms wrapper: %w", err))
+			}
+		}()
+	}
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %w", err))
+		return base.CommandCliError
+	}
+
+	var opts []sessionrecordings.Option
+	if c.FlagRecursive {
+		opts = append(opts, sessionrecordings.WithRecursive(true))
+	}
+
+	sClient := sessionrecordings.NewClient(client)
+	result, err := sClient.Search(c.Context, c.FlagScopeId, c.flagQuery, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, "Error from controller when searching session recordings")
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Search error: %w", err))
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		if ok := c.PrintJsonItems(result.GetResponse()); !ok {
+			return base.CommandCliError
+		}
+	case "table":
+		c.UI.Output(printSearchTable(result.GetItems()))
+	}
+	return base.CommandSuccess
+}
+
+func printSearchTable(items []*sessionrecordings.SearchMatch) string {
+	if len(items) == 0 {
+		return "No matches found"
+	}
+	output := []string{
+		"",
+		"Session Recording Search Matches:",
+	}
+	for i, item := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Session Recording ID:      %s", item.SessionRecordingId),
+			fmt.Sprintf("    Connection Recording ID: %s", item.ConnectionRecordingId),
+			fmt.Sprintf("    Channel Recording ID:    %s", item.ChannelRecordingId),
+		)
+		if !item.Time.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Time:                    %s", item.Time.Local().Format(time.RFC1123)),
+			)
+		}
+		output = append(output,
+			fmt.Sprintf("    Offset:                  %s", item.Offset.Duration),
+			fmt.Sprintf("    Line:                    %s", item.Line),
+		)
+	}
+	return base.WrapForHelpText(output)
+}
@@ -91,7 +91,7 @@ var flagsMap = map[string][]string{
 
 	"read": {"id"},
 
-	"list": {"scope-id", "recursive"},
+	"list": {"scope-id", "recursive", "page-size"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -174,6 +174,10 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, sessionrecordings.WithRecursive(true))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, sessionrecordings.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	var version uint32
 
 	if ok := extraFlagsHandlingFunc(c, f, &opts); !ok {
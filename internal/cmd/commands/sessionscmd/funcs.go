@@ -6,6 +6,7 @@ package sessionscmd
 import (
 	"fmt"
 	"net"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -24,12 +25,14 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"cancel": {"id"},
 		"list":   {flagIncludeTerminated},
+		"watch":  {"id"},
 	}
 }
 
@@ -84,6 +87,17 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "watch":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions watch [options] [args]",
+			"",
+			"  Watch the output of the active SSH session specified by ID. The output sent to the client of the session is written as-is until the session is no longer active. Watching is read-only and the watch is audited. Example:",
+			"",
+			`    $ boundary sessions watch -id s_1234567890`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -99,10 +113,48 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *sessi
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "watch":
+		return nil, nil, nil, c.watch(sessionClient, opts)
 	}
 	return origResp, origItem, origItems, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "watch":
+		// The output of the session has already been written
+		return true, nil
+	}
+	return false, nil
+}
+
+// watch writes the output of the session to stdout until the session is no
+// longer active or the command is interrupted.
+func (c *Command) watch(sessionClient *sessions.Client, opts []sessions.Option) error {
+	var watchId string
+	var position uint64
+	for {
+		result, err := sessionClient.Watch(c.Context, c.FlagId, watchId, position, opts...)
+		if err != nil {
+			if c.Context.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, o := range result.Output {
+			if _, err := os.Stdout.Write(o.Data); err != nil {
+				return err
+			}
+		}
+		watchId, position = result.WatchId, result.Position
+		switch result.Status {
+		case "pending", "active":
+		default:
+			return nil
+		}
+	}
+}
+
 func (c *Command) printListTable(items []*sessions.Session) string {
 	if len(items) == 0 {
 		return "No sessions found"
@@ -106,7 +106,7 @@ var flagsMap = map[string][]string{
 
 	"delete": {"id"},
 
-	"list": {"scope-id", "filter", "recursive"},
+	"list": {"scope-id", "filter", "recursive", "page-size"},
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -241,6 +241,10 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, storagebuckets.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, storagebuckets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	switch c.FlagPluginId {
 	case "":
 	default:
@@ -539,6 +539,9 @@ func printItemTable(item *targets.Target, resp *api.Response) string {
 		if resp.Map[globals.SessionMaxSecondsField] != nil {
 			nonAttributeMap["Session Max Seconds"] = item.SessionMaxSeconds
 		}
+		if resp.Map[globals.SessionIdleTimeoutSecondsField] != nil {
+			nonAttributeMap["Session Idle Timeout Seconds"] = item.SessionIdleTimeoutSeconds
+		}
 	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
@@ -4,6 +4,7 @@
 package targetscmd
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/api/targets"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-secure-stdlib/parseutil"
 )
 
 func init() {
@@ -23,29 +25,33 @@ func init() {
 func extraSshActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
 		"create": {
-			"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit",
+			"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout-seconds",
 			"egress-worker-filter", "ingress-worker-filter", "enable-session-recording",
-			"storage-bucket-id",
+			"storage-bucket-id", "recording-compression", "recording-compression-level", "host-keys",
 		},
 		"update": {
-			"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit",
+			"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout-seconds",
 			"worker-filter", "egress-worker-filter", "ingress-worker-filter", "enable-session-recording",
-			"storage-bucket-id",
+			"storage-bucket-id", "recording-compression", "recording-compression-level", "host-keys",
 		},
 	}
 }
 
 type extraSshCmdVars struct {
-	flagDefaultPort            string
-	flagDefaultClientPort      string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
-	flagWorkerFilter           string
-	flagEgressWorkerFilter     string
-	flagIngressWorkerFilter    string
-	flagAddress                string
-	flagStorageBucketId        string
-	flagEnableSessionRecording string
+	flagDefaultPort               string
+	flagDefaultClientPort         string
+	flagSessionMaxSeconds         string
+	flagSessionConnectionLimit    string
+	flagSessionIdleTimeoutSeconds string
+	flagWorkerFilter              string
+	flagEgressWorkerFilter        string
+	flagIngressWorkerFilter       string
+	flagAddress                   string
+	flagStorageBucketId           string
+	flagEnableSessionRecording    string
+	flagRecordingCompression      string
+	flagRecordingCompressionLevel string
+	flagHostKeys                  string
 }
 
 func (c *SshCommand) extraSshHelpFunc(helpMap map[string]func() string) string {
@@ -111,6 +117,12 @@ func extraSshFlagsFuncImpl(c *SshCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "session-idle-timeout-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout-seconds",
+				Target: &c.flagSessionIdleTimeoutSeconds,
+				Usage:  `The maximum time a connection in the session may go without sending or receiving data before it is closed. Can be specified as an integer number of seconds or a duration string. 0 disables the idle timeout.`,
+			})
 		case "worker-filter":
 			fs.StringVar(&base.StringVar{
 				Name:   "worker-filter",
@@ -141,6 +153,24 @@ func extraSshFlagsFuncImpl(c *SshCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagEnableSessionRecording,
 				Usage:  "A boolean indicating if session recording is enabled for this target.",
 			})
+		case "recording-compression":
+			fs.StringVar(&base.StringVar{
+				Name:   "recording-compression",
+				Target: &c.flagRecordingCompression,
+				Usage:  `The compression used for session recordings of this target. One of "none", "gzip" or "zstd".`,
+			})
+		case "recording-compression-level":
+			fs.StringVar(&base.StringVar{
+				Name:   "recording-compression-level",
+				Target: &c.flagRecordingCompressionLevel,
+				Usage:  "The level of the recording compression. Gzip supports levels 1 to 9 and zstd levels 1 to 22.",
+			})
+		case "host-keys":
+			fs.StringVar(&base.StringVar{
+				Name:   "host-keys",
+				Target: &c.flagHostKeys,
+				Usage:  "The public keys of the hosts of this target in the OpenSSH authorized_keys format, one per line. Workers only connect to hosts presenting one of these keys. This can refer to a file on disk (file://) from which the value will be read or an env var (env://) from which the value will be read.",
+			})
 		}
 	}
 }
@@ -205,6 +235,26 @@ func extraSshFlagsHandlingFuncImpl(c *SshCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagSessionIdleTimeoutSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeoutSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeoutSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeoutSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
 	switch c.flagWorkerFilter {
 	case "":
 	case "null":
@@ -267,6 +317,40 @@ func extraSshFlagsHandlingFuncImpl(c *SshCommand, _ *base.FlagSets, opts *[]targ
 		return false
 	}
 
+	switch c.flagRecordingCompression {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSshTargetRecordingCompression())
+	default:
+		*opts = append(*opts, targets.WithSshTargetRecordingCompression(c.flagRecordingCompression))
+	}
+
+	switch c.flagRecordingCompressionLevel {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSshTargetRecordingCompressionLevel())
+	default:
+		level, err := strconv.ParseUint(c.flagRecordingCompressionLevel, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagRecordingCompressionLevel, err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSshTargetRecordingCompressionLevel(uint32(level)))
+	}
+
+	switch c.flagHostKeys {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSshTargetHostKeys())
+	default:
+		hostKeys, err := parseutil.ParsePath(c.flagHostKeys)
+		if err != nil && !errors.Is(err, parseutil.ErrNotAUrl) {
+			c.UI.Error(fmt.Sprintf("Error parsing host keys flag: %v", err))
+			return false
+		}
+		*opts = append(*opts, targets.WithSshTargetHostKeys(hostKeys))
+	}
+
 	return true
 }
 
@@ -21,20 +21,21 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "egress-worker-filter", "ingress-worker-filter"},
-		"update": {"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
+		"create": {"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout-seconds", "egress-worker-filter", "ingress-worker-filter"},
+		"update": {"address", "default-port", "default-client-port", "session-max-seconds", "session-connection-limit", "session-idle-timeout-seconds", "worker-filter", "egress-worker-filter", "ingress-worker-filter"},
 	}
 }
 
 type extraTcpCmdVars struct {
-	flagDefaultPort            string
-	flagDefaultClientPort      string
-	flagSessionMaxSeconds      string
-	flagSessionConnectionLimit string
-	flagWorkerFilter           string
-	flagEgressWorkerFilter     string
-	flagIngressWorkerFilter    string
-	flagAddress                string
+	flagDefaultPort               string
+	flagDefaultClientPort         string
+	flagSessionMaxSeconds         string
+	flagSessionConnectionLimit    string
+	flagSessionIdleTimeoutSeconds string
+	flagWorkerFilter              string
+	flagEgressWorkerFilter        string
+	flagIngressWorkerFilter       string
+	flagAddress                   string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -100,6 +101,12 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSessionConnectionLimit,
 				Usage:  "The maximum number of connections allowed for a session. -1 means unlimited.",
 			})
+		case "session-idle-timeout-seconds":
+			fs.StringVar(&base.StringVar{
+				Name:   "session-idle-timeout-seconds",
+				Target: &c.flagSessionIdleTimeoutSeconds,
+				Usage:  `The maximum time a connection in the session may go without sending or receiving data before it is closed. Can be specified as an integer number of seconds or a duration string. 0 disables the idle timeout.`,
+			})
 		case "worker-filter":
 			fs.StringVar(&base.StringVar{
 				Name:   "worker-filter",
@@ -182,6 +189,26 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, _ *base.FlagSets, opts *[]targ
 		*opts = append(*opts, targets.WithSessionConnectionLimit(int32(limit)))
 	}
 
+	switch c.flagSessionIdleTimeoutSeconds {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultSessionIdleTimeoutSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagSessionIdleTimeoutSeconds, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagSessionIdleTimeoutSeconds)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionIdleTimeoutSeconds, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithSessionIdleTimeoutSeconds(final))
+	}
+
 	switch c.flagWorkerFilter {
 	case "":
 	case "null":
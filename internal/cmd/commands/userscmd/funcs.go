@@ -20,17 +20,25 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
-	flagAccounts []string
+	flagAccounts             []string
+	flagResourceId           string
+	flagAction               string
+	flagClientIp             string
+	revokeTokensResult       *users.RevokeTokensResult
+	explainPermissionsResult *users.ExplainPermissionsResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"add-accounts":    {"id", "account", "version"},
-		"set-accounts":    {"id", "account", "version"},
-		"remove-accounts": {"id", "account", "version"},
+		"add-accounts":        {"id", "account", "version"},
+		"set-accounts":        {"id", "account", "version"},
+		"remove-accounts":     {"id", "account", "version"},
+		"revoke-tokens":       {"id"},
+		"explain-permissions": {"id", "resource-id", "action", "client-ip"},
 	}
 }
 
@@ -47,6 +55,12 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+
+	case "revoke-tokens":
+		return "Revoke all auth tokens of a user"
+
+	case "explain-permissions":
+		return "Explain the permissions a user has on a resource"
 	}
 
 	return ""
@@ -88,6 +102,28 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "revoke-tokens":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users revoke-tokens [options] [args]",
+			"",
+			"  Delete all auth tokens of a user and cancel the sessions of the user. Example:",
+			"",
+			`    $ boundary users revoke-tokens -id u_1234567890`,
+			"",
+			"",
+		})
+
+	case "explain-permissions":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users explain-permissions [options] [args]",
+			"",
+			`  Explain the permissions a user has on a resource given the IDs of the user and the resource. If the "action" flag is specified, every grant of the user considered while authorizing the action is listed along with whether and why it matched. The actions the user can perform on the resource are always listed. Example:`,
+			"",
+			`    $ boundary users explain-permissions -id u_1234567890 -resource-id ttcp_1234567890 -action authorize-session`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -103,6 +139,24 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagAccounts,
 				Usage:  "The accounts to add, remove, or set. May be specified multiple times.",
 			})
+		case "resource-id":
+			f.StringVar(&base.StringVar{
+				Name:   "resource-id",
+				Target: &c.flagResourceId,
+				Usage:  "The ID of the resource to explain the user's permissions on.",
+			})
+		case "action":
+			f.StringVar(&base.StringVar{
+				Name:   "action",
+				Target: &c.flagAction,
+				Usage:  "The action to explain the authorization of.",
+			})
+		case "client-ip":
+			f.StringVar(&base.StringVar{
+				Name:   "client-ip",
+				Target: &c.flagClientIp,
+				Usage:  "The client IP to evaluate grants restricted to source CIDRs against.",
+			})
 		}
 	}
 }
@@ -125,6 +179,12 @@ func extraFlagsHandlingFuncImpl(c *Command, _ *base.FlagSets, opts *[]users.Opti
 				c.flagAccounts = nil
 			}
 		}
+
+	case "explain-permissions":
+		if c.flagResourceId == "" {
+			c.UI.Error("No resource ID supplied via -resource-id")
+			return false
+		}
 	}
 
 	return true
@@ -150,10 +210,56 @@ func executeExtraActionsImpl(c *Command, origResp *api.Response, origItem *users
 			return nil, nil, nil, err
 		}
 		return result.GetResponse(), result.GetItem(), nil, err
+	case "revoke-tokens":
+		result, err := userClient.RevokeTokens(c.Context, c.FlagId, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.revokeTokensResult = result
+		return result.GetResponse(), nil, nil, err
+	case "explain-permissions":
+		result, err := userClient.ExplainPermissions(c.Context, c.FlagId, c.flagResourceId, c.flagAction, c.flagClientIp, opts...)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.explainPermissionsResult = result
+		return result.GetResponse(), nil, nil, err
 	}
 	return origResp, origItem, origItems, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "revoke-tokens":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printRevokeTokensTable(c.revokeTokensResult))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.revokeTokensResult.GetResponse()); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+
+	case "explain-permissions":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printExplainPermissionsTable(c.explainPermissionsResult))
+			return true, nil
+
+		case "json":
+			if ok := c.PrintJsonItem(c.explainPermissionsResult.GetResponse()); !ok {
+				return false, fmt.Errorf("Error formatting as JSON")
+			}
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*users.User) string {
 	if len(items) == 0 {
 		return "No users found"
@@ -316,3 +422,81 @@ func printItemTable(item *users.User, resp *api.Response) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func printRevokeTokensTable(result *users.RevokeTokensResult) string {
+	ret := []string{
+		"",
+		"Revoked auth tokens:",
+	}
+	if len(result.AuthTokenIds) > 0 {
+		ret = append(ret, base.WrapSlice(2, result.AuthTokenIds))
+	} else {
+		ret = append(ret, "  No auth tokens found")
+	}
+	ret = append(ret,
+		"",
+		"Canceled sessions:",
+	)
+	if len(result.SessionIds) > 0 {
+		ret = append(ret, base.WrapSlice(2, result.SessionIds))
+	} else {
+		ret = append(ret, "  No sessions found")
+	}
+	return base.WrapForHelpText(ret)
+}
+
+func printExplainPermissionsTable(result *users.ExplainPermissionsResult) string {
+	nonAttributeMap := map[string]any{
+		"Resource ID":   result.ResourceId,
+		"Resource Type": result.ResourceType,
+		"Scope ID":      result.ScopeId,
+	}
+	if result.Action != "" {
+		nonAttributeMap["Action"] = result.Action
+		nonAttributeMap["Authorized"] = result.Authorized
+	}
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Permissions explanation:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if result.Action != "" {
+		ret = append(ret,
+			"",
+			"  Grants:",
+		)
+		if len(result.Grants) == 0 {
+			ret = append(ret, "    No grants found")
+		}
+		for _, g := range result.Grants {
+			grantMap := map[string]any{
+				"Grant":      g.Grant,
+				"Scope ID":   g.ScopeId,
+				"Matched":    g.Matched,
+				"Authorized": g.Authorized,
+				"Reason":     g.Reason,
+			}
+			if g.RoleId != "" {
+				grantMap["Role ID"] = g.RoleId
+			}
+			ret = append(ret,
+				base.WrapMap(4, base.MaxAttributesLength(grantMap, nil, nil), grantMap),
+				"",
+			)
+		}
+	}
+
+	ret = append(ret,
+		"",
+		"  Authorized Actions:",
+	)
+	if len(result.AuthorizedActions) > 0 {
+		ret = append(ret, base.WrapSlice(4, result.AuthorizedActions))
+	} else {
+		ret = append(ret, "    No authorized actions")
+	}
+	return base.WrapForHelpText(ret)
+}
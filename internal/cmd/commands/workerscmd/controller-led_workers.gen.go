@@ -182,6 +182,10 @@ func (c *ControllerLedCommand) Run(args []string) int {
 		opts = append(opts, workers.WithFilter(c.FlagFilter))
 	}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, workers.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	var version uint32
 
 	if ok := extraControllerLedFlagsHandlingFunc(c, f, &opts); !ok {
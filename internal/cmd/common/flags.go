@@ -115,6 +115,12 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 					Target: &c.FlagFilter,
 					Usage:  "If set, the list operation will be filtered before being returned. The filter operates against each item in the list. Using single quotes is recommended as filters contain double quotes. See https://www.boundaryproject.io/docs/concepts/filtering/resource-listing for details.",
 				})
+			case "page-size":
+				f.UintVar(&base.UintVar{
+					Name:   "page-size",
+					Target: &c.FlagPageSize,
+					Usage:  "The maximum number of items to request from the controller in a single page. All pages are retrieved; this only controls the size of each request. If not set, the controller's maximum page size is used.",
+				})
 			}
 		}
 	}
@@ -44,6 +44,9 @@ func HelpMap(resType string) map[string]func() string {
 		resource.Worker.String():           "w",
 		resource.SessionRecording.String(): "sr",
 		resource.StorageBucket.String():    "sb",
+		resource.Alias.String():            "alt",
+		resource.Policy.String():           "pst",
+		resource.AccessRequest.String():    "areq",
 	}
 	return map[string]func() string{
 		"base": func() string {
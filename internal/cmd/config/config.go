@@ -219,6 +219,20 @@ type Controller struct {
 	ApiRateLimiterMaxQuotas int               `hcl:"api_rate_limit_max_quotas"`
 	ApiRateLimitDisable     bool              `hcl:"api_rate_limit_disable"`
 
+	// ApiRateLimitShared enforces the API rate limits across the controllers
+	// of a cluster, by sharing the usage of quotas through the database.
+	ApiRateLimitShared bool `hcl:"api_rate_limit_shared"`
+
+	// ApiRateLimitSyncInterval is the interval at which the usage of shared
+	// quotas is synchronized with the database. If zero,
+	// ratelimit.DefaultSyncInterval is used.
+	ApiRateLimitSyncInterval         any           `hcl:"api_rate_limit_sync_interval"`
+	ApiRateLimitSyncIntervalDuration time.Duration `hcl:"-"`
+
+	// MaxPageSize is the maximum number of items returned in a single page
+	// of a list request. If zero, the default maximum page size is used.
+	MaxPageSize int `hcl:"max_page_size"`
+
 	// License is the license used by HCP builds
 	License string `hcl:"license"`
 }
@@ -286,6 +300,12 @@ type Worker struct {
 	// they are sync'ed to the corresponding storage bucket. The path must already exist.
 	RecordingStoragePath string `hcl:"recording_storage_path"`
 
+	// FilesystemStoragePath represents the location the built-in filesystem
+	// storage plugin stores the objects of its storage buckets. The
+	// filesystem storage plugin is only available on workers which set it.
+	// The path must already exist.
+	FilesystemStoragePath string `hcl:"filesystem_storage_path"`
+
 	// ControllerGeneratedActivationToken is a controller-generated activation
 	// token used to register this worker to the cluster. It can be a path, env
 	// var, or direct value.
@@ -696,6 +716,24 @@ func Parse(d string) (*Config, error) {
 		if result.Controller.ApiRateLimiterMaxQuotas <= 0 {
 			result.Controller.ApiRateLimiterMaxQuotas = ratelimit.DefaultLimiterMaxQuotas()
 		}
+
+		if !util.IsNil(result.Controller.ApiRateLimitSyncInterval) {
+			t, err := parseutil.ParseDurationSecond(result.Controller.ApiRateLimitSyncInterval)
+			if err != nil {
+				return result, err
+			}
+			result.Controller.ApiRateLimitSyncIntervalDuration = t
+		}
+		if result.Controller.ApiRateLimitSyncIntervalDuration < 0 {
+			return nil, errors.New("Controller api rate limit sync interval value is negative")
+		}
+		if result.Controller.ApiRateLimitShared && result.Controller.ApiRateLimitDisable {
+			return nil, errors.New("Controller api rate limits can't be shared when they're disabled")
+		}
+
+		if result.Controller.MaxPageSize < 0 {
+			return nil, errors.New("Controller max page size must not be negative")
+		}
 	}
 
 	// Parse worker tags
@@ -1064,6 +1102,10 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 				s.Type = event.StderrSink
 			case s.FileConfig != nil:
 				s.Type = event.FileSink
+			case s.HttpConfig != nil:
+				s.Type = event.HttpSink
+			case s.SyslogConfig != nil:
+				s.Type = event.SyslogSink
 			default:
 				return nil, fmt.Errorf("sink type could not be determined")
 			}
@@ -1085,6 +1127,24 @@ func parseEventing(eventObj *ast.ObjectItem) (*event.EventerConfig, error) {
 			}
 		}
 
+		// parse the duration string specified in a hash chain config into a time.Duration
+		if s.FileConfig != nil && s.FileConfig.HashChain != nil && s.FileConfig.HashChain.CheckpointIntervalHCL != "" {
+			var err error
+			s.FileConfig.HashChain.CheckpointInterval, err = parseutil.ParseDurationSecond(s.FileConfig.HashChain.CheckpointIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse checkpoint interval %s", s.FileConfig.HashChain.CheckpointIntervalHCL)
+			}
+		}
+
+		// parse the duration string specified in an http config into a time.Duration
+		if s.HttpConfig != nil && s.HttpConfig.BatchIntervalHCL != "" {
+			var err error
+			s.HttpConfig.BatchInterval, err = parseutil.ParseDurationSecond(s.HttpConfig.BatchIntervalHCL)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse batch interval %s", s.HttpConfig.BatchIntervalHCL)
+			}
+		}
+
 		// parse map into event types
 		if s.AuditConfig != nil && s.AuditConfig.FilterOverridesHCL != nil {
 			s.AuditConfig.FilterOverrides = make(map[event.DataClassification]event.FilterOperation, len(s.AuditConfig.FilterOverridesHCL))
@@ -1306,6 +1306,165 @@ func TestController_EventingConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "file_sink_rotation",
+			config: []string{
+				`events {
+					sink {
+						name = "rotated-sink"
+						format = "cloudevents-json"
+						event_types = ["audit"]
+						file {
+							path = "/var/log/boundary"
+							file_name = "audit.ndjson"
+							rotate_bytes = 104857600
+							rotate_duration = "24h"
+							rotate_max_files = 7
+							rotate_compress = true
+						}
+					}
+				}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "file",
+						Name:       "rotated-sink",
+						Format:     "cloudevents-json",
+						EventTypes: []event.Type{"audit"},
+						FileConfig: &event.FileSinkTypeConfig{
+							Path:              "/var/log/boundary",
+							FileName:          "audit.ndjson",
+							RotateBytes:       104857600,
+							RotateDurationHCL: "24h",
+							RotateDuration:    24 * time.Hour,
+							RotateMaxFiles:    7,
+							RotateCompress:    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "hash_chain",
+			config: []string{
+				`events {
+					audit_enabled = true
+					sink {
+						name = "audit-sink"
+						format = "cloudevents-json"
+						event_types = ["audit"]
+						file {
+							file_name = "audit.log"
+							hash_chain {
+								signing_key_file = "/etc/boundary/audit-chain.pem"
+								checkpoint_events = 500
+								checkpoint_interval = "30s"
+							}
+						}
+					}
+				}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				AuditEnabled: true,
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "file",
+						Name:       "audit-sink",
+						Format:     "cloudevents-json",
+						EventTypes: []event.Type{"audit"},
+						FileConfig: &event.FileSinkTypeConfig{
+							FileName: "audit.log",
+							HashChain: &event.HashChainConfig{
+								SigningKeyFile:        "/etc/boundary/audit-chain.pem",
+								CheckpointEvents:      500,
+								CheckpointIntervalHCL: "30s",
+								CheckpointInterval:    30 * time.Second,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "http_sink",
+			config: []string{
+				`events {
+					audit_enabled = true
+					sink {
+						name = "siem-sink"
+						format = "cloudevents-json"
+						event_types = ["audit"]
+						http {
+							url = "https://siem.example.com/events"
+							headers = {
+								Authorization = "Bearer token"
+							}
+							batch_size = 50
+							batch_interval = "10s"
+							delivery_guarantee = "enforced"
+							spool_path = "/var/spool/boundary"
+						}
+					}
+				}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				AuditEnabled: true,
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "http",
+						Name:       "siem-sink",
+						Format:     "cloudevents-json",
+						EventTypes: []event.Type{"audit"},
+						HttpConfig: &event.HttpSinkTypeConfig{
+							Url: "https://siem.example.com/events",
+							Headers: map[string]string{
+								"Authorization": "Bearer token",
+							},
+							BatchSize:         50,
+							BatchIntervalHCL:  "10s",
+							BatchInterval:     10 * time.Second,
+							DeliveryGuarantee: event.Enforced,
+							SpoolPath:         "/var/spool/boundary",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "syslog_sink",
+			config: []string{
+				`events {
+					sink {
+						name = "syslog-sink"
+						format = "hclog-json"
+						event_types = ["error", "system"]
+						syslog {
+							network = "tcp"
+							address = "syslog.example.com:514"
+							facility = "daemon"
+							tag = "boundary"
+						}
+					}
+				}`,
+			},
+			wantEventerConfig: &event.EventerConfig{
+				Sinks: []*event.SinkConfig{
+					{
+						Type:       "syslog",
+						Name:       "syslog-sink",
+						Format:     "hclog-json",
+						EventTypes: []event.Type{"error", "system"},
+						SyslogConfig: &event.SyslogSinkTypeConfig{
+							Network:  "tcp",
+							Address:  "syslog.example.com:514",
+							Facility: "daemon",
+							Tag:      "boundary",
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1554,6 +1713,56 @@ func TestControllerDescription(t *testing.T) {
 	}
 }
 
+func TestControllerMaxPageSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		expPageSize int
+		expErrStr   string
+	}{
+		{
+			name: "unset",
+			in: `
+			controller {
+				name = "example-controller"
+			}`,
+			expPageSize: 0,
+		},
+		{
+			name: "set",
+			in: `
+			controller {
+				name = "example-controller"
+				max_page_size = 50
+			}`,
+			expPageSize: 50,
+		},
+		{
+			name: "negative",
+			in: `
+			controller {
+				name = "example-controller"
+				max_page_size = -1
+			}`,
+			expErrStr: "Controller max page size must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(tt.in)
+			if tt.expErrStr != "" {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.Equal(t, tt.expPageSize, c.Controller.MaxPageSize)
+		})
+	}
+}
+
 func TestControllerApiRateLimits(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1738,6 +1947,50 @@ func TestControllerApiRateLimits(t *testing.T) {
 			},
 			expErr: false,
 		},
+		{
+			name: "Rate limit overrides for users and roles",
+			in: `
+			controller {
+				api_rate_limit {
+					resources = ["*"]
+					actions   = ["list"]
+					per       = "auth-token"
+					limit     = 1000
+					period    = "30s"
+					users     = ["u_1234567890"]
+				}
+
+				api_rate_limit {
+					resources = ["*"]
+					actions   = ["list"]
+					per       = "auth-token"
+					limit     = 500
+					period    = "30s"
+					roles     = ["r_1234567890", "r_0987654321"]
+				}
+			}`,
+			expLimits: ratelimit.Configs{
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					PeriodHCL: "30s",
+					Period:    30 * time.Second,
+					Users:     []string{"u_1234567890"},
+				},
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     500,
+					PeriodHCL: "30s",
+					Period:    30 * time.Second,
+					Roles:     []string{"r_1234567890", "r_0987654321"},
+				},
+			},
+			expErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1756,6 +2009,85 @@ func TestControllerApiRateLimits(t *testing.T) {
 	}
 }
 
+func TestControllerApiRateLimitShared(t *testing.T) {
+	tests := []struct {
+		name            string
+		in              string
+		expShared       bool
+		expSyncInterval time.Duration
+		expErrStr       string
+	}{
+		{
+			name: "Not shared",
+			in: `
+			controller {
+			}`,
+		},
+		{
+			name: "Shared",
+			in: `
+			controller {
+				api_rate_limit_shared = true
+			}`,
+			expShared: true,
+		},
+		{
+			name: "Shared with sync interval",
+			in: `
+			controller {
+				api_rate_limit_shared        = true
+				api_rate_limit_sync_interval = "500ms"
+			}`,
+			expShared:       true,
+			expSyncInterval: 500 * time.Millisecond,
+		},
+		{
+			name: "Sync interval in seconds",
+			in: `
+			controller {
+				api_rate_limit_shared        = true
+				api_rate_limit_sync_interval = 2
+			}`,
+			expShared:       true,
+			expSyncInterval: 2 * time.Second,
+		},
+		{
+			name: "Negative sync interval",
+			in: `
+			controller {
+				api_rate_limit_shared        = true
+				api_rate_limit_sync_interval = "-1s"
+			}`,
+			expErrStr: "Controller api rate limit sync interval value is negative",
+		},
+		{
+			name: "Shared and disabled",
+			in: `
+			controller {
+				api_rate_limit_shared  = true
+				api_rate_limit_disable = true
+			}`,
+			expErrStr: "Controller api rate limits can't be shared when they're disabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Parse(tt.in)
+			if tt.expErrStr != "" {
+				require.EqualError(t, err, tt.expErrStr)
+				require.Nil(t, c)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, c)
+			require.NotNil(t, c.Controller)
+			require.Equal(t, tt.expShared, c.Controller.ApiRateLimitShared)
+			require.Equal(t, tt.expSyncInterval, c.Controller.ApiRateLimitSyncIntervalDuration)
+		})
+	}
+}
+
 func TestWorkerDescription(t *testing.T) {
 	tests := []struct {
 		name           string
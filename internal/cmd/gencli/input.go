@@ -91,6 +91,18 @@ type cmdInfo struct {
 }
 
 var inputStructs = map[string][]*cmdInfo{
+	"accessrequests": {
+		{
+			ResourceType:        resource.AccessRequest.String(),
+			Pkg:                 "accessrequests",
+			StdActions:          []string{"read", "list"},
+			Container:           "Scope",
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			HasId:               true,
+			VersionedActions:    []string{"approve", "deny", "cancel"},
+		},
+	},
 	"accounts": {
 		{
 			ResourceType:        resource.Account.String(),
@@ -147,15 +159,42 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
-	"authmethods": {
+	"aliases": {
 		{
-			ResourceType:     resource.AuthMethod.String(),
-			Pkg:              "authmethods",
+			ResourceType:     resource.Alias.String(),
+			Pkg:              "aliases",
 			StdActions:       []string{"read", "delete", "list"},
 			HasExtraHelpFunc: true,
 			Container:        "Scope",
 			HasId:            true,
 		},
+		{
+			ResourceType:         resource.Alias.String(),
+			Pkg:                  "aliases",
+			StdActions:           []string{"create", "update"},
+			SubActionPrefix:      "target",
+			HasExtraCommandVars:  true,
+			SkipNormalHelp:       true,
+			HasExtraHelpFunc:     true,
+			HasId:                true,
+			HasName:              true,
+			HasDescription:       true,
+			Container:            "Scope",
+			VersionedActions:     []string{"update"},
+			NeedsSubtypeInCreate: true,
+			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
+		},
+	},
+	"authmethods": {
+		{
+			ResourceType:        resource.AuthMethod.String(),
+			Pkg:                 "authmethods",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			Container:           "Scope",
+			HasId:               true,
+		},
 		{
 			ResourceType:         resource.AuthMethod.String(),
 			Pkg:                  "authmethods",
@@ -530,6 +569,32 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"policies": {
+		{
+			ResourceType:     resource.Policy.String(),
+			Pkg:              "policies",
+			StdActions:       []string{"read", "delete", "list"},
+			HasExtraHelpFunc: true,
+			Container:        "Scope",
+			HasId:            true,
+		},
+		{
+			ResourceType:         resource.Policy.String(),
+			Pkg:                  "policies",
+			StdActions:           []string{"create", "update"},
+			SubActionPrefix:      "storage",
+			HasExtraCommandVars:  true,
+			SkipNormalHelp:       true,
+			HasExtraHelpFunc:     true,
+			HasId:                true,
+			HasName:              true,
+			HasDescription:       true,
+			Container:            "Scope",
+			VersionedActions:     []string{"update"},
+			NeedsSubtypeInCreate: true,
+			PrefixAttributeFieldErrorsWithSubactionPrefix: true,
+		},
+	},
 	"roles": {
 		{
 			ResourceType:        resource.Role.String(),
@@ -194,7 +194,7 @@ var flags{{ camelCase .SubActionPrefix }}Map = map[string][]string{
 	"delete": {"id"},
 	{{ end }}
 	{{ if eq $action "list" }}
-	"list": { "{{ kebabCase $input.Container }}-id"{{ if not $input.SkipFiltering }},  "filter" {{ end }} {{ if (eq $input.Container "Scope") }}, "recursive"{{ end }} },
+	"list": { "{{ kebabCase $input.Container }}-id"{{ if not $input.SkipFiltering }},  "filter" {{ end }} {{ if (eq $input.Container "Scope") }}, "recursive"{{ end }}, "page-size" },
 	{{ end }}
 	{{ end }}
 	{{ end }}
@@ -387,6 +387,10 @@ func (c *{{ camelCase .SubActionPrefix }}Command) Run(args []string) int {
 	}
 	{{ end }}
 
+	if c.FlagPageSize != 0 {
+		opts = append(opts, {{ .Pkg }}.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
 	{{ if .HasScopeName }}
 	switch c.FlagScopeName {
 	case "":
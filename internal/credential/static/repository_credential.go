@@ -6,6 +6,7 @@ package static
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/go-dbw"
 )
 
@@ -716,7 +718,8 @@ func (r *Repository) UpdateJsonCredential(ctx context.Context,
 }
 
 // ListCredentials returns a slice of UsernamePasswordCredentials, SshPrivateKeyCredentials, and JsonCredentials
-// for the storeId. WithLimit is the only option supported.
+// for the storeId ordered by create time and public id. WithLimit and
+// WithStartPageAfterItem are the only options supported.
 // TODO: This should hit a view and return the interface type...
 func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ...Option) ([]credential.Static, error) {
 	const op = "static.(Repository).ListCredentials"
@@ -730,20 +733,27 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		limit = opts.withLimit
 	}
 
+	where, args := "store_id = ?", []any{storeId}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	dbOpts := []db.Option{db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc")}
+
 	var upCreds []*UsernamePasswordCredential
-	err := r.reader.SearchWhere(ctx, &upCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err := r.reader.SearchWhere(ctx, &upCreds, where, args, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
 	var spkCreds []*SshPrivateKeyCredential
-	err = r.reader.SearchWhere(ctx, &spkCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err = r.reader.SearchWhere(ctx, &spkCreds, where, args, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
 	var jsonCreds []*JsonCredential
-	err = r.reader.SearchWhere(ctx, &jsonCreds, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	err = r.reader.SearchWhere(ctx, &jsonCreds, where, args, dbOpts...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -775,6 +785,14 @@ func (r *Repository) ListCredentials(ctx context.Context, storeId string, opt ..
 		ret = append(ret, c)
 	}
 
+	// Each credential type was limited separately, so merge them into a
+	// single ordered list before applying the limit.
+	sort.Slice(ret, func(i, j int) bool {
+		return pagination.Less(ret[i], ret[j])
+	})
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
 	return ret, nil
 }
 
@@ -3,7 +3,10 @@
 
 package vault
 
-import "github.com/hashicorp/boundary/globals"
+import (
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/pagination"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -45,6 +48,7 @@ type options struct {
 	withCriticalOptions           string
 	withExtensions                string
 	withAdditionalValidPrincipals []string
+	withStartPageAfterItem        pagination.Item
 }
 
 func getDefaultOptions() options {
@@ -238,3 +242,11 @@ func WithAdditionalValidPrincipals(p []string) Option {
 		o.withAdditionalValidPrincipals = p
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = item
+	}
+}
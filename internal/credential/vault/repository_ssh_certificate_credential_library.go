@@ -307,7 +307,8 @@ func (r *Repository) LookupSSHCertificateCredentialLibrary(ctx context.Context,
 }
 
 // ListSSHCertificateCredentialLibraries returns a slice of SSHCertificateCredentialLibraries for the
-// storeId. WithLimit is the only option supported.
+// storeId ordered by create time and public id. WithLimit and
+// WithStartPageAfterItem are the only options supported.
 func (r *Repository) ListSSHCertificateCredentialLibraries(ctx context.Context, storeId string, opt ...Option) ([]*SSHCertificateCredentialLibrary, error) {
 	const op = "vault.(Repository).ListSSHCertificateCredentialLibraries"
 	if storeId == "" {
@@ -320,7 +321,12 @@ func (r *Repository) ListSSHCertificateCredentialLibraries(ctx context.Context,
 		limit = opts.withLimit
 	}
 	var libs []*SSHCertificateCredentialLibrary
-	err := r.reader.SearchWhere(ctx, &libs, "store_id = ?", []any{storeId}, db.WithLimit(limit))
+	where, args := "store_id = ?", []any{storeId}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &libs, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
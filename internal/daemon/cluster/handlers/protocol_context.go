@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	intglobals "github.com/hashicorp/boundary/internal/globals"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/session"
+	pluginstorage "github.com/hashicorp/boundary/internal/storage/plugin"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	"github.com/hashicorp/nodeenrollment"
+	"github.com/hashicorp/nodeenrollment/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// targetProtocolContext provides the protocol context for connections to ssh
+// targets. Connections to any other type of target are proxied as tcp and
+// don't need any protocol context.
+func targetProtocolContext(
+	ctx context.Context,
+	sessionRepo *session.Repository,
+	serversRepo *server.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	recordingRepoFn common.RecordingRepoFactory,
+	storageBucketRepoFn common.PluginStorageBucketRepoFactory,
+	kmsCache *kms.Kms,
+	req *pbs.AuthorizeConnectionRequest,
+	route []string,
+	connectionId string,
+	controllerExt intglobals.ControllerExtension,
+) (*anypb.Any, error) {
+	sessInfo, _, err := sessionRepo.LookupSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	if sessInfo == nil {
+		return nil, status.Errorf(codes.Internal, "Invalid session info in lookup session response")
+	}
+	if !strings.HasPrefix(sessInfo.TargetId, ssh.TargetPrefix+"_") {
+		return noProtocolContext(ctx, sessionRepo, serversRepo, workerAuthRepoFn, recordingRepoFn, storageBucketRepoFn, kmsCache, req, route, connectionId, controllerExt)
+	}
+	return sshProtocolContext(ctx, sessionRepo, workerAuthRepoFn, recordingRepoFn, storageBucketRepoFn, kmsCache, sessInfo, route, connectionId)
+}
+
+// sshProtocolContext returns a pbs.SshProtocolContext containing the injected
+// application credentials of the session, the host key the egress worker
+// presents to the client and the host keys of the target the worker accepts
+// from the endpoint. When the egress worker has node credentials the
+// secrets are encrypted so that only that worker is able to read them. When
+// the target of the session has session recording enabled, the connection is
+// recorded and the protocol context also contains the ids of the recording.
+func sshProtocolContext(
+	ctx context.Context,
+	sessionRepo *session.Repository,
+	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
+	recordingRepoFn common.RecordingRepoFactory,
+	storageBucketRepoFn common.PluginStorageBucketRepoFactory,
+	kmsCache *kms.Kms,
+	sessInfo *session.Session,
+	route []string,
+	connectionId string,
+) (*anypb.Any, error) {
+	if len(route) == 0 {
+		return nil, status.Error(codes.Internal, "Empty route for ssh connection.")
+	}
+
+	creds, err := sessionRepo.ListSessionCredentials(ctx, sessInfo.ProjectId, sessInfo.PublicId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error retrieving session credentials: %s", err)
+	}
+	if len(creds) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "No injected application credentials found for ssh session.")
+	}
+	hostKeys, err := sessionRepo.LookupTargetHostKeys(ctx, sessInfo.PublicId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error looking up target host keys: %s", err)
+	}
+	if hostKeys == "" {
+		return nil, status.Error(codes.FailedPrecondition, "No host keys configured for ssh target.")
+	}
+	secrets := &pbs.SshProtocolSecrets{
+		HostKey: sessInfo.CertificatePrivateKey,
+	}
+	for _, c := range creds {
+		m := &pbs.Credential{}
+		if err := proto.Unmarshal(c, m); err != nil {
+			return nil, status.Errorf(codes.Internal, "Error unmarshaling credentials: %s", err)
+		}
+		secrets.Credentials = append(secrets.Credentials, m)
+	}
+
+	pc := &pbs.SshProtocolContext{
+		SessionId: sessInfo.PublicId,
+		HostKeys:  hostKeys,
+	}
+	if err := startConnectionRecording(ctx, recordingRepoFn, storageBucketRepoFn, kmsCache, sessInfo, connectionId, pc, secrets); err != nil {
+		return nil, err
+	}
+
+	workerAuthRepo, err := workerAuthRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error getting worker auth repo: %v", err)
+	}
+	// The last worker in the route is the one which proxies to the endpoint.
+	egressWorkerId := route[len(route)-1]
+	workerAuthSet, err := workerAuthRepo.FindWorkerAuthByWorkerId(ctx, egressWorkerId)
+	switch {
+	case errors.IsNotFoundError(err):
+		// KMS workers don't have node credentials which can be used to
+		// encrypt the secrets.
+		pc.Secrets = secrets
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Error looking up worker auth for worker %q: %v", egressWorkerId, err)
+	default:
+		nodeInfo, err := types.LoadNodeInformation(ctx, workerAuthRepo, workerAuthSet.Current.GetWorkerKeyIdentifier())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error loading node information for worker %q: %v", egressWorkerId, err)
+		}
+		if pc.EncryptedSecrets, err = nodeenrollment.EncryptMessage(ctx, secrets, nodeInfo); err != nil {
+			return nil, status.Errorf(codes.Internal, "Error encrypting ssh secrets: %v", err)
+		}
+	}
+
+	ret, err := anypb.New(pc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error marshaling ssh protocol context: %v", err)
+	}
+	return ret, nil
+}
+
+// startConnectionRecording starts the recording of the connection when the
+// target of the session has session recording enabled. The ids of the
+// session and connection recording and the compression the worker uses for
+// the recording are set in pc. The connection which creates the session
+// recording also receives the metadata of the session, the storage bucket and
+// the keys the worker uses to write the recording.
+func startConnectionRecording(
+	ctx context.Context,
+	recordingRepoFn common.RecordingRepoFactory,
+	storageBucketRepoFn common.PluginStorageBucketRepoFactory,
+	kmsCache *kms.Kms,
+	sessInfo *session.Session,
+	connectionId string,
+	pc *pbs.SshProtocolContext,
+	secrets *pbs.SshProtocolSecrets,
+) error {
+	if recordingRepoFn == nil || storageBucketRepoFn == nil {
+		return nil
+	}
+	recordingRepo, err := recordingRepoFn()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error getting recording repo: %v", err)
+	}
+	cr, created, err := recordingRepo.StartConnectionRecording(ctx, sessInfo.PublicId, connectionId)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error starting connection recording: %v", err)
+	}
+	if cr == nil {
+		// session recording is not enabled for the target
+		return nil
+	}
+	pc.SessionRecordingId = cr.GetRecordingSessionId()
+	pc.ConnectionRecordingId = cr.GetPublicId()
+	if pc.RecordingCompression, pc.RecordingCompressionLevel, err = recordingRepo.LookupRecordingCompression(ctx, cr.GetRecordingSessionId()); err != nil {
+		return status.Errorf(codes.Internal, "Error looking up recording compression: %v", err)
+	}
+	if !created {
+		return nil
+	}
+
+	bsrWrapper := kmsCache.GetExternalWrappers(ctx).Bsr()
+	if bsrWrapper == nil {
+		return status.Error(codes.FailedPrecondition, "No BSR KMS is configured, unable to record session.")
+	}
+	sr, err := recordingRepo.LookupSessionRecording(ctx, cr.GetRecordingSessionId())
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error looking up session recording: %v", err)
+	}
+	if sr == nil {
+		return status.Errorf(codes.Internal, "Session recording %q not found.", cr.GetRecordingSessionId())
+	}
+	if pc.SessionMeta, err = json.Marshal(sr.SessionMeta); err != nil {
+		return status.Errorf(codes.Internal, "Error marshaling session recording metadata: %v", err)
+	}
+
+	storageBucketRepo, err := storageBucketRepoFn()
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error getting storage bucket repo: %v", err)
+	}
+	sb, plg, err := storageBucketRepo.LookupStorageBucket(ctx, sr.GetStorageBucketId())
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error looking up storage bucket: %v", err)
+	}
+	if sb == nil {
+		return status.Errorf(codes.FailedPrecondition, "Storage bucket %q not found.", sr.GetStorageBucketId())
+	}
+	if secrets.StorageBucket, err = pluginstorage.ToPluginStorageBucket(ctx, sb, plg); err != nil {
+		return status.Errorf(codes.Internal, "Error converting storage bucket: %v", err)
+	}
+
+	keys, err := bsrkms.CreateKeys(ctx, bsrWrapper, sr.GetPublicId())
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error creating session recording keys: %v", err)
+	}
+	if secrets.RecordingKeys, err = sessionRecordingKeys(keys); err != nil {
+		return status.Errorf(codes.Internal, "Error marshaling session recording keys: %v", err)
+	}
+	return nil
+}
+
+// sessionRecordingKeys marshals the keys of a session recording.
+func sessionRecordingKeys(keys *bsrkms.Keys) (*pbs.SessionRecordingKeys, error) {
+	ret := &pbs.SessionRecordingKeys{}
+	for _, f := range []struct {
+		dst *[]byte
+		src proto.Message
+	}{
+		{&ret.WrappedBsrKey, keys.WrappedBsrKey},
+		{&ret.WrappedPrivKey, keys.WrappedPrivKey},
+		{&ret.BsrKey, keys.BsrKey},
+		{&ret.PrivKey, keys.PrivKey},
+		{&ret.PubKey, keys.PubKey},
+		{&ret.PubKeySelfSignature, keys.PubKeySelfSignature},
+		{&ret.PubKeyBsrSignature, keys.PubKeyBsrSignature},
+	} {
+		b, err := proto.Marshal(f.src)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = b
+	}
+	return ret, nil
+}
@@ -21,6 +21,20 @@ import (
 
 var upstreamMessageHandler sync.Map
 
+// originatingWorkerKeyIdContextKey is the context key of the key id of the
+// worker which sent an encrypted upstream message.
+type originatingWorkerKeyIdContextKey struct{}
+
+// originatingWorkerKeyIdFromContext returns the key id of the worker which
+// sent the encrypted upstream message being handled. Only encrypted messages
+// have an originating worker key id in their context, since only they prove
+// that they were sent by the worker: they can only be decrypted with the
+// types.NodeInformation of the worker.
+func originatingWorkerKeyIdFromContext(ctx context.Context) (string, bool) {
+	keyId, ok := ctx.Value(originatingWorkerKeyIdContextKey{}).(string)
+	return keyId, ok && keyId != ""
+}
+
 // UpstreamMessageHandler defines a handler for an UpstreamMessageRequest(s).
 //
 // See controllerUpstreamMessageServiceServer.UpstreamMessage for how this is
@@ -106,7 +120,9 @@ func NewControllerUpstreamMessageServiceServer(
 // It decrypts the request using the originating workers ID using its
 // types.NodeCredentials. If there's a registered UpstreamMessageHandler for the
 // underlying message's protoreflect.FullName; then the msg is handled by the
-// handler otherwise an codes.Unimplemented status error is returned
+// handler otherwise an codes.Unimplemented status error is returned. Handlers
+// of encrypted messages can get the originating worker key id from their
+// context.
 func (s *controllerUpstreamMessageServiceServer) UpstreamMessage(ctx context.Context, req *pbs.UpstreamMessageRequest) (*pbs.UpstreamMessageResponse, error) {
 	const op = "handlers.(controllerUpstreamMessageServiceServer).UpstreamMessage"
 	switch {
@@ -143,6 +159,7 @@ func (s *controllerUpstreamMessageServiceServer) UpstreamMessage(ctx context.Con
 		if err := nodeenrollment.DecryptMessage(ctx, req.GetCt(), nodeInfo, msg); err != nil {
 			return nil, status.Errorf(codes.Internal, "%s: error decrypting request message: %v", op, err)
 		}
+		ctx = context.WithValue(ctx, originatingWorkerKeyIdContextKey{}, req.GetOriginatingWorkerKeyId())
 	}
 	clonedMsg := proto.Clone(msg)
 	if err := event.WriteAudit(ctx, "handlers.(controllerUpstreamMessageServiceServer).UpstreamMessage",
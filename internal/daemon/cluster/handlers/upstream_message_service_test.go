@@ -19,6 +19,8 @@ import (
 	"github.com/hashicorp/nodeenrollment/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
@@ -275,6 +277,21 @@ func Test_controllerUpstreamMessageServiceServer_UpstreamMessage(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "rpc error: code = Unknown desc = fail-echo-unencrypted",
 		},
+		{
+			name:             "success-encrypted-originating-worker-key-id",
+			controllerServer: testController,
+			req: &pbs.UpstreamMessageRequest{
+				OriginatingWorkerKeyId: initKeyId,
+				MsgType:                pbs.MsgType_MSG_TYPE_ECHO,
+				Message: func() *pbs.UpstreamMessageRequest_Ct {
+					r := &pbs.EchoUpstreamMessageRequest{Msg: "ping"}
+					ct, err := nodeenrollment.EncryptMessage(testCtx, r, nodeInfo)
+					require.NoError(t, err)
+					return &pbs.UpstreamMessageRequest_Ct{Ct: ct}
+				}(),
+			},
+			setupHandlers: TestRegisterHandlerFn(t, pbs.MsgType_MSG_TYPE_ECHO, &originatingWorkerKeyIdHandler{wantKeyId: initKeyId}),
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -493,3 +510,17 @@ func Test_RegisterUpstreamMessageTypeSpecifier(t *testing.T) {
 		})
 	}
 }
+
+// originatingWorkerKeyIdHandler is an encrypted echo handler which fails
+// unless the originating worker key id in its context is wantKeyId.
+type originatingWorkerKeyIdHandler struct {
+	TestMockEncryptedUpstreamMessageHandler
+	wantKeyId string
+}
+
+func (h *originatingWorkerKeyIdHandler) Handler(ctx context.Context, request proto.Message) (proto.Message, error) {
+	if keyId, ok := originatingWorkerKeyIdFromContext(ctx); !ok || keyId != h.wantKeyId {
+		return nil, status.Errorf(codes.Unauthenticated, "unexpected originating worker key id %q", keyId)
+	}
+	return h.TestMockEncryptedUpstreamMessageHandler.Handler(ctx, request)
+}
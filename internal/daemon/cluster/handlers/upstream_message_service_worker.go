@@ -223,6 +223,8 @@ func toMsgType(ctx context.Context, m proto.Message) (pbs.MsgType, error) {
 	switch t := m.(type) {
 	case *pbs.EchoUpstreamMessageRequest, *pbs.EchoUpstreamMessageResponse:
 		return pbs.MsgType_MSG_TYPE_ECHO, nil
+	case *pbs.SendSessionOutputRequest, *pbs.SendSessionOutputResponse:
+		return pbs.MsgType_MSG_TYPE_SEND_SESSION_OUTPUT, nil
 	default:
 		if entMsgTypeResolver != nil {
 			return entMsgTypeResolver(ctx, m)
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func init() {
+	// Workers need the type specifier to send the output of watched sessions.
+	// Controllers replace it with the handler when they register it.
+	if err := registerUpstreamMessageTypeSpecifier(context.Background(), pbs.MsgType_MSG_TYPE_SEND_SESSION_OUTPUT, &sessionOutputHandler{}); err != nil {
+		panic(err)
+	}
+}
+
+// sessionOutputHandler handles the output of watched sessions sent by
+// workers. The output is sent as an encrypted upstream message, so the worker
+// is identified by the key id it authenticated the message with instead of a
+// worker id it claims, including when the message is forwarded by upstream
+// workers.
+type sessionOutputHandler struct {
+	serversRepoFn common.ServersRepoFactory
+	sessionRepoFn session.RepositoryFactory
+}
+
+var _ UpstreamMessageHandler = (*sessionOutputHandler)(nil)
+
+// NewSessionOutputHandler returns an UpstreamMessageHandler for the
+// SendSessionOutputRequest upstream messages of workers.
+func NewSessionOutputHandler(ctx context.Context, serversRepoFn common.ServersRepoFactory, sessionRepoFn session.RepositoryFactory) (UpstreamMessageHandler, error) {
+	const op = "handlers.NewSessionOutputHandler"
+	switch {
+	case serversRepoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing servers repo factory")
+	case sessionRepoFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session repo factory")
+	}
+	return &sessionOutputHandler{
+		serversRepoFn: serversRepoFn,
+		sessionRepoFn: sessionRepoFn,
+	}, nil
+}
+
+// Handler adds the output of the session to the output of its watches.
+func (h *sessionOutputHandler) Handler(ctx context.Context, request proto.Message) (proto.Message, error) {
+	const op = "handlers.(sessionOutputHandler).Handler"
+	req, ok := request.(*pbs.SendSessionOutputRequest)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "Unexpected request type %T.", request)
+	}
+	switch {
+	case req.GetSessionId() == "":
+		return nil, status.Error(codes.InvalidArgument, "Missing session id.")
+	case len(req.GetOutput()) == 0:
+		return nil, status.Error(codes.InvalidArgument, "Missing output.")
+	}
+	keyId, ok := originatingWorkerKeyIdFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Missing originating worker key id.")
+	}
+
+	serversRepo, err := h.serversRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting servers repo: %v", err)
+	}
+	workerId, err := serversRepo.LookupWorkerIdByKeyId(ctx, keyId)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error looking up worker id", "key_id", keyId))
+		return nil, status.Errorf(codes.Internal, "error looking up worker id: %v", err)
+	}
+	if workerId == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "No worker found for key id %q.", keyId)
+	}
+
+	sessRepo, err := h.sessionRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error getting session repo: %v", err)
+	}
+	if err := sessRepo.AddWatchOutput(ctx, req.GetSessionId(), workerId, req.GetOutput()); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error adding session output"))
+		return nil, status.Errorf(codes.Internal, "error adding session output: %v", err)
+	}
+	return &pbs.SendSessionOutputResponse{}, nil
+}
+
+// Encrypted returns true, since only encrypted messages identify the worker
+// which sent them.
+func (*sessionOutputHandler) Encrypted() bool { return true }
+
+// AllocRequest allocates a SendSessionOutputRequest.
+func (*sessionOutputHandler) AllocRequest() proto.Message {
+	return &pbs.SendSessionOutputRequest{}
+}
+
+// AllocResponse allocates a SendSessionOutputResponse.
+func (*sessionOutputHandler) AllocResponse() proto.Message {
+	return &pbs.SendSessionOutputResponse{}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSessionOutputHandler(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(ctx, rw, rw, kms)
+	}
+	sessionRepoFn := func(opts ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opts...)
+	}
+
+	var workerKeyId, otherWorkerKeyId string
+	worker := server.TestPkiWorker(t, conn, wrapper, server.WithTestPkiWorkerAuthorizedKeyId(&workerKeyId))
+	_ = server.TestPkiWorker(t, conn, wrapper, server.WithTestPkiWorkerAuthorizedKeyId(&otherWorkerKeyId))
+
+	sessRepo, err := sessionRepoFn()
+	require.NoError(t, err)
+	connRepo, err := session.NewConnectionRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	sess := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+	sess, _, err = sessRepo.ActivateSession(ctx, sess.PublicId, sess.Version, session.TestTofu(t))
+	require.NoError(t, err)
+	_, _, _, err = session.AuthorizeConnection(ctx, sessRepo, connRepo, sess.PublicId, worker.PublicId)
+	require.NoError(t, err)
+	watch, err := sessRepo.CreateWatch(ctx, sess.PublicId, sess.UserId)
+	require.NoError(t, err)
+
+	h, err := NewSessionOutputHandler(ctx, serversRepoFn, sessionRepoFn)
+	require.NoError(t, err)
+	withKeyId := func(keyId string) context.Context {
+		return context.WithValue(ctx, originatingWorkerKeyIdContextKey{}, keyId)
+	}
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		req      *pbs.SendSessionOutputRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "missing-session-id",
+			ctx:      withKeyId(workerKeyId),
+			req:      &pbs.SendSessionOutputRequest{Output: []byte("output")},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing-output",
+			ctx:      withKeyId(workerKeyId),
+			req:      &pbs.SendSessionOutputRequest{SessionId: sess.PublicId},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "missing-key-id",
+			ctx:      ctx,
+			req:      &pbs.SendSessionOutputRequest{SessionId: sess.PublicId, Output: []byte("unauthenticated")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "unknown-key-id",
+			ctx:      withKeyId("unknown"),
+			req:      &pbs.SendSessionOutputRequest{SessionId: sess.PublicId, Output: []byte("unknown")},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			// the output of workers which do not proxy the session is dropped
+			name: "other-worker",
+			ctx:  withKeyId(otherWorkerKeyId),
+			req:  &pbs.SendSessionOutputRequest{SessionId: sess.PublicId, Output: []byte("injected")},
+		},
+		{
+			name: "valid",
+			ctx:  withKeyId(workerKeyId),
+			req:  &pbs.SendSessionOutputRequest{SessionId: sess.PublicId, Output: []byte("output")},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := h.Handler(tc.ctx, tc.req)
+			if tc.wantCode != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, tc.wantCode.String(), status.Code(err).String())
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, &pbs.SendSessionOutputResponse{}, resp)
+		})
+	}
+
+	output, err := sessRepo.ListWatchOutput(ctx, watch.PublicId, 0)
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, []byte("output"), output[0].Data)
+}
+
+func TestNewSessionOutputHandler(t *testing.T) {
+	ctx := context.Background()
+	serversRepoFn := func() (*server.Repository, error) { return nil, nil }
+	sessionRepoFn := func(...session.Option) (*session.Repository, error) { return nil, nil }
+
+	_, err := NewSessionOutputHandler(ctx, nil, sessionRepoFn)
+	assert.Error(t, err)
+	_, err = NewSessionOutputHandler(ctx, serversRepoFn, nil)
+	assert.Error(t, err)
+	h, err := NewSessionOutputHandler(ctx, serversRepoFn, sessionRepoFn)
+	require.NoError(t, err)
+	assert.True(t, h.Encrypted())
+
+	// workers can send the output without registering the handler
+	msgType, err := toMsgType(ctx, &pbs.SendSessionOutputRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, pbs.MsgType_MSG_TYPE_SEND_SESSION_OUTPUT, msgType)
+	_, ok := getUpstreamMessageTypeSpecifier(ctx, msgType)
+	assert.True(t, ok)
+}
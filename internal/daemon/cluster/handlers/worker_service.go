@@ -13,10 +13,14 @@ import (
 	dcommon "github.com/hashicorp/boundary/internal/daemon/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	intglobals "github.com/hashicorp/boundary/internal/globals"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/hashicorp/boundary/internal/recording/store"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -36,6 +40,8 @@ type workerServiceServer struct {
 	workerAuthRepoFn    common.WorkerAuthRepoStorageFactory
 	sessionRepoFn       session.RepositoryFactory
 	connectionRepoFn    common.ConnectionRepoFactory
+	recordingRepoFn     common.RecordingRepoFactory
+	storageBucketRepoFn common.PluginStorageBucketRepoFactory
 	downstreams         common.Downstreamers
 	updateTimes         *sync.Map
 	kms                 *kms.Kms
@@ -54,11 +60,11 @@ var (
 	connectionRouteFn = singleHopConnectionRoute
 
 	// getProtocolContext populates the protocol specific context fields
-	// depending on the protocol used to for the boundary connection. Defaults
-	// to noProtocolContext since tcp connections are the only protocol schemes
-	// available in OSS and are a straight forward proxy with no additional
-	// fields needed.
-	getProtocolContext = noProtocolContext
+	// depending on the protocol used to for the boundary connection. tcp
+	// connections are a straight forward proxy with no additional fields
+	// needed, while ssh connections receive the credentials the worker
+	// injects when connecting to the endpoint.
+	getProtocolContext = targetProtocolContext
 )
 
 // singleHopConnectionRoute returns a route consisting of the singlehop worker (the root worker id)
@@ -71,6 +77,8 @@ func NewWorkerServiceServer(
 	workerAuthRepoFn common.WorkerAuthRepoStorageFactory,
 	sessionRepoFn session.RepositoryFactory,
 	connectionRepoFn common.ConnectionRepoFactory,
+	recordingRepoFn common.RecordingRepoFactory,
+	storageBucketRepoFn common.PluginStorageBucketRepoFactory,
 	downstreams common.Downstreamers,
 	updateTimes *sync.Map,
 	kms *kms.Kms,
@@ -82,6 +90,8 @@ func NewWorkerServiceServer(
 		workerAuthRepoFn:    workerAuthRepoFn,
 		sessionRepoFn:       sessionRepoFn,
 		connectionRepoFn:    connectionRepoFn,
+		recordingRepoFn:     recordingRepoFn,
+		storageBucketRepoFn: storageBucketRepoFn,
 		downstreams:         downstreams,
 		updateTimes:         updateTimes,
 		kms:                 kms,
@@ -330,6 +340,19 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		})
 	}
 
+	if len(stateReport) > 0 {
+		reportedSessionIds := make([]string, 0, len(stateReport))
+		for _, sr := range stateReport {
+			reportedSessionIds = append(reportedSessionIds, sr.SessionId)
+		}
+		// Failing to find the watched sessions only delays the output of the
+		// sessions until the next status, so it doesn't fail the status.
+		ret.WatchedSessionIds, err = sessRepo.ListWatchedSessionIds(ctx, reportedSessionIds)
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error getting watched sessions"))
+		}
+	}
+
 	return ret, nil
 }
 
@@ -387,6 +410,9 @@ func noProtocolContext(
 	*session.Repository,
 	*server.Repository,
 	common.WorkerAuthRepoStorageFactory,
+	common.RecordingRepoFactory,
+	common.PluginStorageBucketRepoFactory,
+	*kms.Kms,
 	*pbs.AuthorizeConnectionRequest,
 	[]string,
 	string,
@@ -511,18 +537,19 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 			Certificate: sessionInfo.Certificate,
 			PrivateKey:  sessionInfo.CertificatePrivateKey,
 		},
-		Status:          sessionInfo.States[0].Status.ProtoVal(),
-		Version:         sessionInfo.Version,
-		TofuToken:       string(sessionInfo.TofuToken),
-		Endpoint:        sessionInfo.Endpoint,
-		Expiration:      sessionInfo.ExpirationTime.Timestamp,
-		ConnectionLimit: sessionInfo.ConnectionLimit,
-		ConnectionsLeft: authzSummary.ConnectionLimit,
-		HostId:          sessionInfo.HostId,
-		HostSetId:       sessionInfo.HostSetId,
-		TargetId:        sessionInfo.TargetId,
-		UserId:          sessionInfo.UserId,
-		Credentials:     workerCreds,
+		Status:             sessionInfo.States[0].Status.ProtoVal(),
+		Version:            sessionInfo.Version,
+		TofuToken:          string(sessionInfo.TofuToken),
+		Endpoint:           sessionInfo.Endpoint,
+		Expiration:         sessionInfo.ExpirationTime.Timestamp,
+		ConnectionLimit:    sessionInfo.ConnectionLimit,
+		ConnectionsLeft:    authzSummary.ConnectionLimit,
+		HostId:             sessionInfo.HostId,
+		HostSetId:          sessionInfo.HostSetId,
+		TargetId:           sessionInfo.TargetId,
+		UserId:             sessionInfo.UserId,
+		Credentials:        workerCreds,
+		IdleTimeoutSeconds: sessionInfo.IdleTimeoutSeconds,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -640,6 +667,9 @@ func (ws *workerServiceServer) AuthorizeConnection(ctx context.Context, req *pbs
 		sessionRepo,
 		serversRepo,
 		ws.workerAuthRepoFn,
+		ws.recordingRepoFn,
+		ws.storageBucketRepoFn,
+		ws.kms,
 		req,
 		route,
 		ret.ConnectionId,
@@ -740,3 +770,65 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	return ret, nil
 }
+
+func (ws *workerServiceServer) CloseSessionRecording(ctx context.Context, req *pbs.CloseSessionRecordingRequest) (*pbs.CloseSessionRecordingResponse, error) {
+	const op = "workers.(workerServiceServer).CloseSessionRecording"
+	switch {
+	case req.GetSessionRecordingId() == "":
+		return nil, status.Error(codes.InvalidArgument, "Missing session recording id.")
+	case req.GetStartTime() == nil || req.GetEndTime() == nil:
+		return nil, status.Error(codes.InvalidArgument, "Missing session recording start or end time.")
+	}
+	if ws.recordingRepoFn == nil {
+		return nil, status.Error(codes.Unimplemented, "Session recording is not supported.")
+	}
+
+	sr := &recording.SessionRecording{
+		SessionRecording: &store.SessionRecording{
+			PublicId:     req.GetSessionRecordingId(),
+			StartTime:    &timestamp.Timestamp{Timestamp: req.GetStartTime()},
+			EndTime:      &timestamp.Timestamp{Timestamp: req.GetEndTime()},
+			ErrorDetails: req.GetErrorDetails(),
+		},
+	}
+	for _, c := range req.GetConnectionRecordings() {
+		cr := &recording.ConnectionRecording{
+			ConnectionRecording: &store.ConnectionRecording{
+				PublicId:  c.GetConnectionRecordingId(),
+				StartTime: &timestamp.Timestamp{Timestamp: c.GetStartTime()},
+				EndTime:   &timestamp.Timestamp{Timestamp: c.GetEndTime()},
+				BytesUp:   c.GetBytesUp(),
+				BytesDown: c.GetBytesDown(),
+			},
+		}
+		for _, ch := range c.GetChannelRecordings() {
+			cr.ChannelRecordings = append(cr.ChannelRecordings, &recording.ChannelRecording{
+				ChannelRecording: &store.ChannelRecording{
+					PublicId:    ch.GetChannelRecordingId(),
+					StartTime:   &timestamp.Timestamp{Timestamp: ch.GetStartTime()},
+					EndTime:     &timestamp.Timestamp{Timestamp: ch.GetEndTime()},
+					BytesUp:     ch.GetBytesUp(),
+					BytesDown:   ch.GetBytesDown(),
+					ChannelType: ch.GetChannelType(),
+				},
+				Program:       ch.GetProgram(),
+				SubsystemName: ch.GetSubsystemName(),
+				ExecProgram:   ch.GetExecProgram(),
+			})
+		}
+		sr.ConnectionRecordings = append(sr.ConnectionRecordings, cr)
+	}
+
+	recordingRepo, err := ws.recordingRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error getting recording repo: %v", err)
+	}
+	if _, err := recordingRepo.CloseSessionRecording(ctx, sr); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfo("session_recording_id", req.GetSessionRecordingId()))
+		if errors.Match(errors.T(errors.InvalidParameter), err) {
+			return nil, status.Errorf(codes.InvalidArgument, "Error closing session recording: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Error closing session recording: %v", err)
+	}
+	return &pbs.CloseSessionRecordingResponse{}, nil
+}
@@ -17,14 +17,15 @@ import (
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	authpb "github.com/hashicorp/boundary/internal/gen/controller/auth"
-	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -34,9 +35,6 @@ import (
 	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/boundary/internal/util/template"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
-	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
-	"github.com/mr-tron/base58"
-	"google.golang.org/protobuf/proto"
 )
 
 type TokenFormat uint32
@@ -68,6 +66,10 @@ type VerifyResults struct {
 	Error       error
 	Scope       *scopes.ScopeInfo
 
+	// GrantsHash is a hash of the grants of the user making the request. It
+	// is used to invalidate pagination tokens when the user's grants change.
+	GrantsHash []byte
+
 	// AuthenticatedFinished means that the request has passed through the
 	// authentication system successfully. This does _not_ indicate whether a
 	// token was provided on the request. Requests for `u_anon` will still have
@@ -246,6 +248,9 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 		}
 		ret.UserId = v.requestInfo.UserIdOverride
 		ret.UserData.User.Id = util.Pointer(ret.UserId)
+		// There are no grants to hash, but list pagination still needs a
+		// stable value to bind its tokens to
+		ret.GrantsHash = pagination.GrantsHash(nil)
 		if reqInfo != nil {
 			reqInfo.UserId = ret.UserId
 		}
@@ -320,13 +325,16 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	}
 
 	grants := make([]event.Grant, 0, len(grantTuples))
+	grantStrs := make([]string, 0, len(grantTuples))
 	for _, g := range grantTuples {
 		grants = append(grants, event.Grant{
 			Grant:   g.Grant,
 			RoleId:  g.RoleId,
 			ScopeId: g.ScopeId,
 		})
+		grantStrs = append(grantStrs, strings.Join([]string{g.RoleId, g.ScopeId, g.Grant}, "|"))
 	}
+	ret.GrantsHash = pagination.GrantsHash(grantStrs)
 	ea.UserInfo = &event.UserInfo{
 		UserId: ret.UserId,
 	}
@@ -385,56 +393,20 @@ func (v *verifier) decryptToken(ctx context.Context) {
 			return
 		}
 
-		tokenWrapper, err := v.kms.GetWrapper(v.ctx, at.GetScopeId(), kms.KeyPurposeTokens)
-		if err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to get wrapper for tokens; continuing as anonymous user"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-
-		version := v.requestInfo.EncryptedToken[0:len(globals.ServiceTokenV1)]
-		switch version {
-		case globals.ServiceTokenV1:
-		default:
-			event.WriteError(ctx, op, stderrors.New("unknown token encryption version; continuing as anonymous user"), event.WithInfo("version", version))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-		marshaledToken, err := base58.FastBase58Decoding(v.requestInfo.EncryptedToken[len(globals.ServiceTokenV1):])
-		if err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("error unmarshaling base58 token; continuing as anonymous user"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-
-		blobInfo := new(wrapping.BlobInfo)
-		if err := proto.Unmarshal(marshaledToken, blobInfo); err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("error decoding encrypted token; continuing as anonymous user"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-
-		s1Bytes, err := tokenWrapper.Decrypt(v.ctx, blobInfo, wrapping.WithAad([]byte(v.requestInfo.PublicId)))
+		token, err := authtoken.DecryptToken(v.ctx, v.kms, at.GetScopeId(), v.requestInfo.PublicId, v.requestInfo.EncryptedToken)
 		if err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("error decrypting encrypted token; continuing as anonymous user"))
-			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
-			return
-		}
-
-		var s1Info tokens.S1TokenInfo
-		if err := proto.Unmarshal(s1Bytes, &s1Info); err != nil {
-			event.WriteError(ctx, op, err, event.WithInfoMsg("error unmarshaling token info; continuing as anonymous user"))
+			event.WriteError(ctx, op, err, event.WithInfoMsg("unable to decrypt token; continuing as anonymous user"))
 			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
 			return
 		}
 
-		if v.requestInfo.TokenFormat == uint32(AuthTokenTypeUnknown) || s1Info.Token == "" || v.requestInfo.PublicId == "" {
+		if v.requestInfo.TokenFormat == uint32(AuthTokenTypeUnknown) || token == "" || v.requestInfo.PublicId == "" {
 			event.WriteError(ctx, op, stderrors.New("after parsing, could not find valid token; continuing as anonymous user"))
 			v.requestInfo.TokenFormat = uint32(AuthTokenTypeUnknown)
 			return
 		}
 
-		v.requestInfo.Token = s1Info.Token
+		v.requestInfo.Token = token
 		return
 
 	case uint32(AuthTokenTypeRecoveryKms):
@@ -667,7 +639,7 @@ func (v verifier) performAuthCheck(ctx context.Context) (
 	}
 
 	retAcl = perms.NewACL(parsedGrants...)
-	aclResults = retAcl.Allowed(*v.res, v.act, *userData.User.Id)
+	aclResults = retAcl.Allowed(*v.res, v.act, *userData.User.Id, v.aclOptions()...)
 	// We don't set authenticated above because setting this but not authorized
 	// is used for further permissions checks, such as during recursive listing.
 	// So we want to make sure any code relying on that has the full set of
@@ -724,7 +696,7 @@ func (r *VerifyResults) fetchActions(id string, typ resource.Type, availableActi
 
 	ret := make(action.ActionSet, len(availableActions))
 	for act := range availableActions {
-		if r.v.acl.Allowed(*res, act, *r.UserData.User.Id).Authorized {
+		if r.v.acl.Allowed(*res, act, *r.UserData.User.Id, r.v.aclOptions()...).Authorized {
 			ret.Add(act)
 		}
 	}
@@ -748,7 +720,7 @@ func (r *VerifyResults) FetchOutputFields(res perms.Resource, act action.Type) *
 		return ret
 	}
 
-	return r.v.acl.Allowed(res, act, *r.UserData.User.Id).OutputFields
+	return r.v.acl.Allowed(res, act, *r.UserData.User.Id, r.v.aclOptions()...).OutputFields
 }
 
 // ACL returns the perms.ACL of the verifier.
@@ -760,6 +732,24 @@ func (r *VerifyResults) ACL() perms.ACL {
 	return r.v.acl
 }
 
+// ACLOptions returns the options to pass to the perms.ACL of the verifier so
+// that the conditions of grants are evaluated against the request.
+func (r *VerifyResults) ACLOptions() []perms.Option {
+	if r.v == nil {
+		return nil
+	}
+
+	return r.v.aclOptions()
+}
+
+// aclOptions returns the options describing the request being verified, which
+// are used to evaluate the conditions of grants.
+func (v *verifier) aclOptions() []perms.Option {
+	return []perms.Option{
+		perms.WithClientIp(v.requestInfo.GetClientIp()),
+	}
+}
+
 // GetTokenFromRequest pulls the token from either the Authorization header or
 // split cookies and parses it. If it cannot be parsed successfully, the issue
 // is logged and we return blank, so logic will continue as the anonymous user.
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"github.com/hashicorp/boundary/internal/accessrequest"
+	talias "github.com/hashicorp/boundary/internal/alias/target"
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
@@ -13,6 +15,8 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/plugin"
+	spolicy "github.com/hashicorp/boundary/internal/policy/storage"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/session"
 	pluginstorage "github.com/hashicorp/boundary/internal/storage/plugin"
@@ -33,6 +37,10 @@ type (
 	ConnectionRepoFactory          func() (*session.ConnectionRepository, error)
 	WorkerAuthRepoStorageFactory   func() (*server.WorkerAuthRepositoryStorage, error)
 	PluginStorageBucketRepoFactory func() (*pluginstorage.Repository, error)
+	TargetAliasRepoFactory         func() (*talias.Repository, error)
+	RecordingRepoFactory           func() (*recording.Repository, error)
+	StoragePolicyRepoFactory       func() (*spolicy.Repository, error)
+	AccessRequestRepoFactory       func() (*accessrequest.Repository, error)
 )
 
 // Downstreamers provides at least a minimum interface that must be met by a
@@ -11,6 +11,8 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/hashicorp/boundary/internal/accessrequest"
+	talias "github.com/hashicorp/boundary/internal/alias/target"
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
@@ -33,9 +35,13 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	kmsjob "github.com/hashicorp/boundary/internal/kms/job"
+	"github.com/hashicorp/boundary/internal/pagination/purge"
 	"github.com/hashicorp/boundary/internal/plugin"
+	"github.com/hashicorp/boundary/internal/plugin/filesystem"
 	"github.com/hashicorp/boundary/internal/plugin/loopback"
+	spolicy "github.com/hashicorp/boundary/internal/policy/storage"
 	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/hashicorp/boundary/internal/recording"
 	"github.com/hashicorp/boundary/internal/scheduler"
 	"github.com/hashicorp/boundary/internal/scheduler/cleaner"
 	"github.com/hashicorp/boundary/internal/scheduler/job"
@@ -139,6 +145,10 @@ type Controller struct {
 	PluginStorageBucketRepoFn common.PluginStorageBucketRepoFactory
 	PluginRepoFn              common.PluginRepoFactory
 	TargetRepoFn              target.RepositoryFactory
+	TargetAliasRepoFn         common.TargetAliasRepoFactory
+	RecordingRepoFn           common.RecordingRepoFactory
+	StoragePolicyRepoFn       common.StoragePolicyRepoFactory
+	AccessRequestRepoFn       common.AccessRequestRepoFactory
 	WorkerAuthRepoStorageFn   common.WorkerAuthRepoStorageFactory
 
 	scheduler *scheduler.Scheduler
@@ -273,9 +283,33 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 				plugin.WithDescription("Provides an initial loopback storage and host plugin in Boundary"),
 				plugin.WithPublicId(conf.DevLoopbackPluginId),
 			}
-			if _, err = conf.RegisterPlugin(ctx, "loopback", plg, []plugin.PluginType{plugin.PluginTypeHost, plugin.PluginTypeStorage}, opts...); err != nil {
+			registered, err := conf.RegisterPlugin(ctx, "loopback", plg, []plugin.PluginType{plugin.PluginTypeHost, plugin.PluginTypeStorage}, opts...)
+			if err != nil {
 				return nil, err
 			}
+			if conf.StoragePlugins == nil {
+				conf.StoragePlugins = make(map[string]plgpb.StoragePluginServiceClient)
+			}
+			conf.StoragePlugins[registered.GetPublicId()] = loopback.NewWrappingPluginStorageClient(lp)
+		case enabledPlugin == base.EnabledPluginFilesystem:
+			// The filesystem storage plugin stores objects on workers, so
+			// the controller only registers it. The controller can only
+			// delete the objects when it shares its storage directory with a
+			// worker, as it does in dev mode.
+			registered, err := conf.RegisterPlugin(ctx, filesystem.PluginName, nil, []plugin.PluginType{plugin.PluginTypeStorage}, plugin.WithDescription("Built-in filesystem storage plugin"))
+			if err != nil {
+				return nil, fmt.Errorf("error registering %s storage plugin: %w", filesystem.PluginName, err)
+			}
+			if conf.RawConfig.Worker != nil && conf.RawConfig.Worker.FilesystemStoragePath != "" {
+				client, err := filesystem.NewStorageClient(conf.RawConfig.Worker.FilesystemStoragePath)
+				if err != nil {
+					return nil, fmt.Errorf("error creating %s storage plugin: %w", filesystem.PluginName, err)
+				}
+				if conf.StoragePlugins == nil {
+					conf.StoragePlugins = make(map[string]plgpb.StoragePluginServiceClient)
+				}
+				conf.StoragePlugins[registered.GetPublicId()] = client
+			}
 		case enabledPlugin == base.EnabledPluginHostAzure && !c.conf.SkipPlugins:
 			pluginType := strings.ToLower(enabledPlugin.String())
 			client, cleanup, err := external_plugins.CreateHostPlugin(
@@ -318,6 +352,9 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	if conf.HostPlugins == nil {
 		conf.HostPlugins = make(map[string]plgpb.HostPluginServiceClient)
 	}
+	if conf.StoragePlugins == nil {
+		conf.StoragePlugins = make(map[string]plgpb.StoragePluginServiceClient)
+	}
 
 	// Set up repo stuff
 	dbase := db.New(c.conf.Database)
@@ -419,6 +456,18 @@ func New(ctx context.Context, conf *Config) (*Controller, error) {
 	c.TargetRepoFn = func(o ...target.Option) (*target.Repository, error) {
 		return target.NewRepository(ctx, dbase, dbase, c.kms, o...)
 	}
+	c.TargetAliasRepoFn = func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, dbase, dbase, c.kms)
+	}
+	c.RecordingRepoFn = func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, dbase, dbase, c.kms)
+	}
+	c.StoragePolicyRepoFn = func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, dbase, dbase, c.kms)
+	}
+	c.AccessRequestRepoFn = func() (*accessrequest.Repository, error) {
+		return accessrequest.NewRepository(ctx, dbase, dbase, c.kms)
+	}
 	c.SessionRepoFn = func(opt ...session.Option) (*session.Repository, error) {
 		// Always add a secure random reader to the new session repository.
 		// Add it as the first option so that it can be overridden by users.
@@ -574,6 +623,12 @@ func (c *Controller) registerJobs() error {
 	if err := session.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.workerStatusGracePeriod); err != nil {
 		return err
 	}
+	if err := recording.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms, c.conf.StoragePlugins); err != nil {
+		return err
+	}
+	if err := accessrequest.RegisterJobs(c.baseContext, c.scheduler, rw, rw, c.kms); err != nil {
+		return err
+	}
 	var serverJobOpts []serversjob.Option
 	if c.conf.TestOverrideWorkerAuthCaCertificateLifetime > 0 {
 		serverJobOpts = append(serverJobOpts,
@@ -590,6 +645,9 @@ func (c *Controller) registerJobs() error {
 	if err := cleaner.RegisterJob(c.baseContext, c.scheduler, rw); err != nil {
 		return err
 	}
+	if err := purge.RegisterJob(c.baseContext, c.scheduler, rw); err != nil {
+		return err
+	}
 	if err := snapshot.RegisterJob(c.baseContext, c.scheduler, rw, rw); err != nil {
 		return err
 	}
@@ -108,8 +108,12 @@ func TestHandleImplementedPaths(t *testing.T) {
 
 	for verb, paths := range map[string][]string{
 		"GET": {
+			"v1/access-requests",
+			"v1/access-requests/someid",
 			"v1/accounts",
 			"v1/accounts/someid",
+			"v1/aliases",
+			"v1/aliases/someid",
 			"v1/auth-methods",
 			"v1/auth-methods/someid",
 			"v1/auth-methods/someid:authenticate:callback",
@@ -125,6 +129,8 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/host-sets/someid",
 			"v1/hosts",
 			"v1/hosts/someid",
+			"v1/policies",
+			"v1/policies/someid",
 			"v1/roles",
 			"v1/roles/someid",
 			"400_v1/sc\u200Bopes",
@@ -141,13 +147,16 @@ func TestHandleImplementedPaths(t *testing.T) {
 		},
 		"POST": {
 			// Creation end points
+			"v1/access-requests",
 			"v1/accounts",
+			"v1/aliases",
 			"v1/auth-methods",
 			"v1/credential-stores",
 			"v1/groups",
 			"v1/host-catalogs",
 			"v1/host-sets",
 			"v1/hosts",
+			"v1/policies",
 			"v1/roles",
 			"v1/scopes",
 			"v1/storage-buckets",
@@ -155,6 +164,9 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/users",
 
 			// custom methods
+			"v1/access-requests/someid:approve",
+			"v1/access-requests/someid:deny",
+			"v1/access-requests/someid:cancel",
 			"v1/accounts/someid:set-password",
 			"v1/accounts/someid:change-password",
 			"v1/auth-methods/someid:authenticate",
@@ -184,6 +196,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 		},
 		"DELETE": {
 			"v1/accounts/someid",
+			"v1/aliases/someid",
 			"v1/auth-methods/someid",
 			"v1/auth-tokens/someid",
 			"v1/credential-stores/someid",
@@ -191,6 +204,7 @@ func TestHandleImplementedPaths(t *testing.T) {
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
+			"v1/policies/someid",
 			"v1/roles/someid",
 			"v1/scopes/someid",
 			"v1/storage-buckets/someid",
@@ -199,12 +213,14 @@ func TestHandleImplementedPaths(t *testing.T) {
 		},
 		"PATCH": {
 			"v1/accounts/someid",
+			"v1/aliases/someid",
 			"v1/auth-methods/someid",
 			"v1/credential-stores/someid",
 			"v1/groups/someid",
 			"v1/host-catalogs/someid",
 			"v1/host-sets/someid",
 			"v1/hosts/someid",
+			"v1/policies/someid",
 			"v1/roles/someid",
 			"v1/scopes/someid",
 			"v1/storage-buckets/someid",
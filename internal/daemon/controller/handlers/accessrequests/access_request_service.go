@@ -0,0 +1,680 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/accessrequest"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accessrequests"
+	"google.golang.org/grpc/codes"
+)
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.NewActionSet(
+		action.NoOp,
+		action.Read,
+		action.ReadSelf,
+		action.Cancel,
+		action.CancelSelf,
+		action.Approve,
+		action.Deny,
+	)
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.NewActionSet(
+		action.Create,
+		action.List,
+	)
+)
+
+func init() {
+	action.RegisterResource(resource.AccessRequest, IdActions, CollectionActions)
+}
+
+// Service handles request as described by the pbs.AccessRequestServiceServer interface.
+type Service struct {
+	pbs.UnsafeAccessRequestServiceServer
+
+	repoFn       common.AccessRequestRepoFactory
+	targetRepoFn target.RepositoryFactory
+	iamRepoFn    common.IamRepoFactory
+	maxPageSize  uint
+}
+
+var _ pbs.AccessRequestServiceServer = (*Service)(nil)
+
+// NewService returns an access request service which handles access request related requests to boundary.
+func NewService(
+	ctx context.Context,
+	repoFn common.AccessRequestRepoFactory,
+	targetRepoFn target.RepositoryFactory,
+	iamRepoFn common.IamRepoFactory,
+	opt ...handlers.Option,
+) (Service, error) {
+	const op = "accessrequests.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing access request repository")
+	}
+	if targetRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing target repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, targetRepoFn: targetRepoFn, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
+}
+
+// ListAccessRequests implements the interface pbs.AccessRequestServiceServer.
+func (s Service) ListAccessRequests(ctx context.Context, req *pbs.ListAccessRequestsRequest) (*pbs.ListAccessRequestsResponse, error) {
+	if err := validateListRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.AccessRequest, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListAccessRequestsResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	res := perms.Resource{
+		Type: resource.AccessRequest,
+	}
+	protos := make(map[string]*pb.AccessRequest)
+	filterItemFn := func(ctx context.Context, item *accessrequest.AccessRequest) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetProjectId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
+		if len(authorizedActions) == 0 {
+			return false, nil
+		}
+		// Users which are only allowed to read their own access requests
+		// don't get to see the access requests of other users.
+		if item.GetUserId() != authResults.UserId && !authorizedActions.HasAction(action.Read) {
+			return false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetProjectId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return false, err
+		}
+
+		if !filter.Match(pbItem) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*accessrequest.AccessRequest, error) {
+		return s.listFromRepo(ctx, scopeIds, limit, prevPageLastItem)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.AccessRequest, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.AccessRequest, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAccessRequestsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// GetAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) GetAccessRequest(ctx context.Context, req *pbs.GetAccessRequestRequest) (*pbs.GetAccessRequestResponse, error) {
+	const op = "accessrequests.(Service).GetAccessRequest"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadSelf)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ar, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedActions := authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions)
+	outputFields, err := s.outputFields(ctx, op, authResults, authorizedActions, ar, action.Read)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := toProto(ctx, ar, outputOptions(authResults, authorizedActions, outputFields)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetAccessRequestResponse{Item: item}, nil
+}
+
+// CreateAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) CreateAccessRequest(ctx context.Context, req *pbs.CreateAccessRequestRequest) (*pbs.CreateAccessRequestResponse, error) {
+	const op = "accessrequests.(Service).CreateAccessRequest"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetTargetId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if authResults.UserId == globals.AnonymousUserId {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Access can only be requested by authenticated users.")
+	}
+	ar, err := s.createInRepo(ctx, authResults.Scope.GetId(), authResults.UserId, req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	authorizedActions := authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions)
+
+	item, err := toProto(ctx, ar, outputOptions(authResults, authorizedActions, outputFields)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CreateAccessRequestResponse{
+		Item: item,
+		Uri:  fmt.Sprintf("access-requests/%s", item.GetId()),
+	}, nil
+}
+
+// ApproveAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) ApproveAccessRequest(ctx context.Context, req *pbs.ApproveAccessRequestRequest) (*pbs.ApproveAccessRequestResponse, error) {
+	const op = "accessrequests.(Service).ApproveAccessRequest"
+
+	if err := validateDecisionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Approve)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDecision(ctx, repo, req.GetId(), authResults.UserId); err != nil {
+		return nil, err
+	}
+	ar, rowsUpdated, err := repo.ApproveAccessRequest(ctx, req.GetId(), req.GetVersion(), authResults.UserId, accessrequest.WithComment(req.GetComment()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to approve access request"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Access request %q doesn't exist or incorrect version provided.", req.GetId())
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	authorizedActions := authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions)
+
+	item, err := toProto(ctx, ar, outputOptions(authResults, authorizedActions, outputFields)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ApproveAccessRequestResponse{Item: item}, nil
+}
+
+// DenyAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) DenyAccessRequest(ctx context.Context, req *pbs.DenyAccessRequestRequest) (*pbs.DenyAccessRequestResponse, error) {
+	const op = "accessrequests.(Service).DenyAccessRequest"
+
+	if err := validateDecisionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Deny)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkDecision(ctx, repo, req.GetId(), authResults.UserId); err != nil {
+		return nil, err
+	}
+	ar, rowsUpdated, err := repo.DenyAccessRequest(ctx, req.GetId(), req.GetVersion(), authResults.UserId, accessrequest.WithComment(req.GetComment()))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to deny access request"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Access request %q doesn't exist or incorrect version provided.", req.GetId())
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	authorizedActions := authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions)
+
+	item, err := toProto(ctx, ar, outputOptions(authResults, authorizedActions, outputFields)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DenyAccessRequestResponse{Item: item}, nil
+}
+
+// CancelAccessRequest implements the interface pbs.AccessRequestServiceServer.
+func (s Service) CancelAccessRequest(ctx context.Context, req *pbs.CancelAccessRequestRequest) (*pbs.CancelAccessRequestResponse, error) {
+	const op = "accessrequests.(Service).CancelAccessRequest"
+
+	if err := validateCancelRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.CancelSelf)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ar, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedActions := authResults.FetchActionSetForId(ctx, ar.GetPublicId(), IdActions)
+	outputFields, err := s.outputFields(ctx, op, authResults, authorizedActions, ar, action.Cancel)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ar, rowsUpdated, err := repo.CancelAccessRequest(ctx, req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to cancel access request"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Access request %q doesn't exist or incorrect version provided.", req.GetId())
+	}
+
+	item, err := toProto(ctx, ar, outputOptions(authResults, authorizedActions, outputFields)...)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.CancelAccessRequestResponse{Item: item}, nil
+}
+
+// outputFields returns the output fields for the access request ar. Users
+// can always see all the fields of their own access requests, but need to be
+// granted the action a on the access requests of other users.
+func (s Service) outputFields(ctx context.Context, op errors.Op, authResults auth.VerifyResults, authorizedActions action.ActionSet, ar *accessrequest.AccessRequest, a action.Type) (*perms.OutputFields, error) {
+	if ar.GetUserId() != authResults.UserId {
+		if !authorizedActions.HasAction(a) {
+			return nil, handlers.ForbiddenError()
+		}
+		return authResults.FetchOutputFields(perms.Resource{
+			Id:      ar.GetPublicId(),
+			ScopeId: ar.GetProjectId(),
+			Type:    resource.AccessRequest,
+		}, a).SelfOrDefaults(authResults.UserId), nil
+	}
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+	return outputFields, nil
+}
+
+// checkDecision returns an error if the user userId requested the access
+// request id, as users cannot decide on their own access requests.
+func (s Service) checkDecision(ctx context.Context, repo *accessrequest.Repository, id, userId string) error {
+	const op = "accessrequests.(Service).checkDecision"
+	ar, err := repo.LookupAccessRequest(ctx, id)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if ar == nil {
+		return handlers.NotFoundErrorf("Access request %q doesn't exist.", id)
+	}
+	if ar.GetUserId() == userId {
+		return handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Users cannot approve or deny their own access requests.")
+	}
+	return nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, projectIds []string, limit int, prevPageLastItem pagination.Item) ([]*accessrequest.AccessRequest, error) {
+	const op = "accessrequests.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ars, err := repo.ListAccessRequests(ctx, projectIds, accessrequest.WithLimit(limit), accessrequest.WithStartPageAfterItem(prevPageLastItem))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ars, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*accessrequest.AccessRequest, error) {
+	const op = "accessrequests.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	ar, err := repo.LookupAccessRequest(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if ar == nil {
+		return nil, handlers.NotFoundErrorf("Access request %q doesn't exist.", id)
+	}
+	return ar, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, projectId, userId string, item *pb.AccessRequest) (*accessrequest.AccessRequest, error) {
+	const op = "accessrequests.(Service).createInRepo"
+	ar, err := accessrequest.NewAccessRequest(ctx, projectId, item.GetTargetId(), userId, item.GetJustification(), time.Duration(item.GetDurationSeconds())*time.Second)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build access request for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateAccessRequest(ctx, ar)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create access request"))
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create access request but no error returned from repository.")
+	}
+	return out, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.AccessRequest), auth.WithAction(a)}
+	switch a {
+	case action.List:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	case action.Create:
+		// Access requests are created in the project of the target which the
+		// user requests access to.
+		targetRepo, err := s.targetRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		t, err := targetRepo.LookupTarget(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if t == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = t.GetProjectId()
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		ar, err := repo.LookupAccessRequest(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if ar == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = ar.GetProjectId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func outputOptions(authResults auth.VerifyResults, authorizedActions action.ActionSet, outputFields *perms.OutputFields) []handlers.Option {
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
+	}
+	return outputOpts
+}
+
+func toProto(ctx context.Context, in *accessrequest.AccessRequest, opt ...handlers.Option) (*pb.AccessRequest, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building access request proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.AccessRequest{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetProjectId()
+	}
+	if outputFields.Has(globals.TargetIdField) {
+		out.TargetId = in.GetTargetId()
+	}
+	if outputFields.Has(globals.UserIdField) {
+		out.UserId = in.GetUserId()
+	}
+	if outputFields.Has(globals.JustificationField) {
+		out.Justification = in.GetJustification()
+	}
+	if outputFields.Has(globals.DurationSecondsField) {
+		out.DurationSeconds = in.GetDurationSeconds()
+	}
+	if outputFields.Has(globals.StatusField) {
+		out.Status = in.GetStatus()
+	}
+	if outputFields.Has(globals.ApproverIdField) {
+		out.ApproverId = in.GetApproverId()
+	}
+	if outputFields.Has(globals.DecisionCommentField) {
+		out.DecisionComment = in.GetDecisionComment()
+	}
+	if outputFields.Has(globals.DecisionTimeField) {
+		out.DecisionTime = in.GetDecisionTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.ExpirationTimeField) {
+		out.ExpirationTime = in.GetExpirationTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	return &out, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetAccessRequestRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.AccessRequestPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateAccessRequestRequest) error {
+	badFields := map[string]string{}
+	item := req.GetItem()
+	if !handlers.ValidId(handlers.Id(item.GetTargetId()), target.Prefixes()...) {
+		badFields[globals.TargetIdField] = "This field must be a valid target id."
+	}
+	if item.GetJustification() == "" {
+		badFields[globals.JustificationField] = "This is a required field."
+	}
+	switch {
+	case item.GetDurationSeconds() == 0:
+		badFields[globals.DurationSecondsField] = "This is a required field."
+	case time.Duration(item.GetDurationSeconds())*time.Second > accessrequest.MaxDuration:
+		badFields[globals.DurationSecondsField] = fmt.Sprintf("Must not be greater than %d.", int64(accessrequest.MaxDuration/time.Second))
+	}
+	if item.GetId() != "" {
+		badFields[globals.IdField] = "This is a read only field."
+	}
+	if item.GetScopeId() != "" {
+		badFields[globals.ScopeIdField] = "This is a read only field. The scope is the project of the target."
+	}
+	if item.GetUserId() != "" {
+		badFields[globals.UserIdField] = "This is a read only field."
+	}
+	if item.GetStatus() != "" {
+		badFields[globals.StatusField] = "This is a read only field."
+	}
+	if item.GetApproverId() != "" {
+		badFields[globals.ApproverIdField] = "This is a read only field."
+	}
+	if item.GetDecisionComment() != "" {
+		badFields[globals.DecisionCommentField] = "This is a read only field."
+	}
+	if item.GetDecisionTime() != nil {
+		badFields[globals.DecisionTimeField] = "This is a read only field."
+	}
+	if item.GetExpirationTime() != nil {
+		badFields[globals.ExpirationTimeField] = "This is a read only field."
+	}
+	if item.GetCreatedTime() != nil {
+		badFields[globals.CreatedTimeField] = "This is a read only field."
+	}
+	if item.GetUpdatedTime() != nil {
+		badFields[globals.UpdatedTimeField] = "This is a read only field."
+	}
+	if item.GetVersion() != 0 {
+		badFields[globals.VersionField] = "Cannot specify this field in a create request."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+type decisionRequest interface {
+	GetId() string
+	GetVersion() uint32
+}
+
+func validateDecisionRequest(req decisionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.AccessRequestPrefix) {
+		badFields[globals.IdField] = "Improperly formatted identifier."
+	}
+	if req.GetVersion() == 0 {
+		badFields[globals.VersionField] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateCancelRequest(req *pbs.CancelAccessRequestRequest) error {
+	return validateDecisionRequest(req)
+}
+
+func validateListRequest(ctx context.Context, req *pbs.ListAccessRequestsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix(), scope.Project.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org or project scope id."
+	}
+	if _, err := handlers.NewFilter(ctx, req.GetFilter()); err != nil {
+		badFields[globals.FilterField] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package accessrequests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/accessrequest"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/accessrequests"
+	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var testAuthorizedActions = []string{"no-op", "read", "read:self", "cancel", "cancel:self", "approve", "deny"}
+
+type testEnv struct {
+	ctx       context.Context
+	rw        *db.Db
+	iamRepoFn func() (*iam.Repository, error)
+	repo      *accessrequest.Repository
+	service   Service
+	project   *iam.Scope
+	target    target.Target
+	requester *iam.User
+	approver  *iam.User
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*accessrequest.Repository, error) {
+		return accessrequest.NewRepository(ctx, rw, rw, kms)
+	}
+	targetRepoFn := func(o ...target.Option) (*target.Repository, error) {
+		return target.NewRepository(ctx, rw, rw, kms, o...)
+	}
+	repo, err := repoFn()
+	require.NoError(t, err)
+	s, err := NewService(ctx, repoFn, targetRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	return &testEnv{
+		ctx:       ctx,
+		rw:        rw,
+		iamRepoFn: iamRepoFn,
+		repo:      repo,
+		service:   s,
+		project:   prj,
+		target:    tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target"),
+		requester: iam.TestUser(t, iamRepo, scope.Global.String()),
+		approver:  iam.TestUser(t, iamRepo, scope.Global.String()),
+	}
+}
+
+func (e *testEnv) authContext(userId string) context.Context {
+	return auth.DisabledAuthTestContext(e.iamRepoFn, e.project.GetPublicId(), auth.WithUserId(userId))
+}
+
+func (e *testEnv) scopeInfo() *scopepb.ScopeInfo {
+	return &scopepb.ScopeInfo{
+		Id:            e.project.GetPublicId(),
+		Type:          scope.Project.String(),
+		ParentScopeId: e.project.GetParentId(),
+	}
+}
+
+func TestGet(t *testing.T) {
+	e := newTestEnv(t)
+	ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+
+	cases := []struct {
+		name string
+		id   string
+		res  *pbs.GetAccessRequestResponse
+		err  error
+	}{
+		{
+			name: "success",
+			id:   ar.GetPublicId(),
+			res: &pbs.GetAccessRequestResponse{
+				Item: &pb.AccessRequest{
+					Id:                ar.GetPublicId(),
+					ScopeId:           e.project.GetPublicId(),
+					Scope:             e.scopeInfo(),
+					TargetId:          e.target.GetPublicId(),
+					UserId:            e.requester.GetPublicId(),
+					Justification:     ar.GetJustification(),
+					DurationSeconds:   ar.GetDurationSeconds(),
+					Status:            accessrequest.StatusPending.String(),
+					CreatedTime:       ar.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       ar.GetUpdateTime().GetTimestamp(),
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.AccessRequestPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.GetAccessRequestRequest{Id: tc.id}
+			got, gErr := e.service.GetAccessRequest(e.authContext(e.requester.GetPublicId()), req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "GetAccessRequest(%q) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				protocmp.IgnoreFields(&scopepb.ScopeInfo{}, "name", "description"),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	e := newTestEnv(t)
+	var want []string
+	for i := 0; i < 3; i++ {
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		want = append(want, ar.GetPublicId())
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListAccessRequestsRequest
+		want []string
+		err  error
+	}{
+		{
+			name: "project",
+			req:  &pbs.ListAccessRequestsRequest{ScopeId: e.project.GetPublicId()},
+			want: want,
+		},
+		{
+			name: "recursive from global",
+			req:  &pbs.ListAccessRequestsRequest{ScopeId: scope.Global.String(), Recursive: true},
+			want: want,
+		},
+		{
+			name: "filter",
+			req:  &pbs.ListAccessRequestsRequest{ScopeId: e.project.GetPublicId(), Filter: fmt.Sprintf(`"/item/id"==%q`, want[1])},
+			want: want[1:2],
+		},
+		{
+			name: "bad scope id",
+			req:  &pbs.ListAccessRequestsRequest{ScopeId: "bad_1234567890"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := e.service.ListAccessRequests(e.authContext(e.requester.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "ListAccessRequests(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			var gotIds []string
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			assert.ElementsMatch(t, tc.want, gotIds)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := newTestEnv(t)
+
+	cases := []struct {
+		name   string
+		userId string
+		item   *pb.AccessRequest
+		err    error
+	}{
+		{
+			name:   "success",
+			userId: e.requester.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        e.target.GetPublicId(),
+				Justification:   "incident 1234",
+				DurationSeconds: 3600,
+			},
+		},
+		{
+			name:   "anonymous user",
+			userId: globals.AnonymousUserId,
+			item: &pb.AccessRequest{
+				TargetId:        e.target.GetPublicId(),
+				Justification:   "incident 1234",
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.PermissionDenied),
+		},
+		{
+			name:   "unknown target",
+			userId: e.requester.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        fmt.Sprintf("%s_1234567890", globals.TcpTargetPrefix),
+				Justification:   "incident 1234",
+				DurationSeconds: 3600,
+			},
+			err: handlers.NotFoundError(),
+		},
+		{
+			name:   "no justification",
+			userId: e.requester.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        e.target.GetPublicId(),
+				DurationSeconds: 3600,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "duration too long",
+			userId: e.requester.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        e.target.GetPublicId(),
+				Justification:   "incident 1234",
+				DurationSeconds: uint32(accessrequest.MaxDuration.Seconds()) + 1,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:   "status set",
+			userId: e.requester.GetPublicId(),
+			item: &pb.AccessRequest{
+				TargetId:        e.target.GetPublicId(),
+				Justification:   "incident 1234",
+				DurationSeconds: 3600,
+				Status:          accessrequest.StatusApproved.String(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := &pbs.CreateAccessRequestRequest{Item: tc.item}
+			got, gErr := e.service.CreateAccessRequest(e.authContext(tc.userId), req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateAccessRequest(%q) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.Equal(fmt.Sprintf("access-requests/%s", item.GetId()), got.GetUri())
+			assert.Equal(e.project.GetPublicId(), item.GetScopeId())
+			assert.Equal(tc.userId, item.GetUserId())
+			assert.Equal(tc.item.GetTargetId(), item.GetTargetId())
+			assert.Equal(tc.item.GetJustification(), item.GetJustification())
+			assert.Equal(tc.item.GetDurationSeconds(), item.GetDurationSeconds())
+			assert.Equal(accessrequest.StatusPending.String(), item.GetStatus())
+			assert.Equal(uint32(1), item.GetVersion())
+		})
+	}
+}
+
+func TestApproveAndDeny(t *testing.T) {
+	e := newTestEnv(t)
+
+	t.Run("approve own request", func(t *testing.T) {
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		_, err := e.service.ApproveAccessRequest(e.authContext(e.requester.GetPublicId()), &pbs.ApproveAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "got error %v", err)
+	})
+	t.Run("deny own request", func(t *testing.T) {
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		_, err := e.service.DenyAccessRequest(e.authContext(e.requester.GetPublicId()), &pbs.DenyAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "got error %v", err)
+	})
+	t.Run("no version", func(t *testing.T) {
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		_, err := e.service.ApproveAccessRequest(e.authContext(e.approver.GetPublicId()), &pbs.ApproveAccessRequestRequest{Id: ar.GetPublicId()})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+	t.Run("wrong version", func(t *testing.T) {
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		_, err := e.service.ApproveAccessRequest(e.authContext(e.approver.GetPublicId()), &pbs.ApproveAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion() + 1})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()), "got error %v", err)
+	})
+	t.Run("approve", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		got, err := e.service.ApproveAccessRequest(e.authContext(e.approver.GetPublicId()), &pbs.ApproveAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion(), Comment: "approved"})
+		require.NoError(err)
+		item := got.GetItem()
+		assert.Equal(accessrequest.StatusApproved.String(), item.GetStatus())
+		assert.Equal(e.approver.GetPublicId(), item.GetApproverId())
+		assert.Equal("approved", item.GetDecisionComment())
+		assert.NotNil(item.GetDecisionTime())
+		assert.NotNil(item.GetExpirationTime())
+		assert.Equal(ar.GetVersion()+1, item.GetVersion())
+
+		// A decided access request cannot be decided on again.
+		_, err = e.service.DenyAccessRequest(e.authContext(e.approver.GetPublicId()), &pbs.DenyAccessRequestRequest{Id: item.GetId(), Version: item.GetVersion()})
+		require.Error(err)
+		assert.True(errors.Match(errors.T(errors.Conflict), err), "got error %v", err)
+	})
+	t.Run("deny", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		got, err := e.service.DenyAccessRequest(e.authContext(e.approver.GetPublicId()), &pbs.DenyAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion()})
+		require.NoError(err)
+		item := got.GetItem()
+		assert.Equal(accessrequest.StatusDenied.String(), item.GetStatus())
+		assert.Equal(e.approver.GetPublicId(), item.GetApproverId())
+		assert.Empty(item.GetDecisionComment())
+		assert.Nil(item.GetExpirationTime())
+	})
+}
+
+func TestCancel(t *testing.T) {
+	e := newTestEnv(t)
+
+	t.Run("pending", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		got, err := e.service.CancelAccessRequest(e.authContext(e.requester.GetPublicId()), &pbs.CancelAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion()})
+		require.NoError(err)
+		assert.Equal(accessrequest.StatusCanceled.String(), got.GetItem().GetStatus())
+	})
+	t.Run("approved", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ar := accessrequest.TestAccessRequest(t, e.rw, e.project.GetPublicId(), e.target.GetPublicId(), e.requester.GetPublicId())
+		ar, _, err := e.repo.ApproveAccessRequest(e.ctx, ar.GetPublicId(), ar.GetVersion(), e.approver.GetPublicId())
+		require.NoError(err)
+		got, err := e.service.CancelAccessRequest(e.authContext(e.requester.GetPublicId()), &pbs.CancelAccessRequestRequest{Id: ar.GetPublicId(), Version: ar.GetVersion()})
+		require.NoError(err)
+		assert.Equal(accessrequest.StatusCanceled.String(), got.GetItem().GetStatus())
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := e.service.CancelAccessRequest(e.authContext(e.requester.GetPublicId()), &pbs.CancelAccessRequestRequest{Id: fmt.Sprintf("%s_1234567890", globals.AccessRequestPrefix), Version: 1})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()), "got error %v", err)
+	})
+}
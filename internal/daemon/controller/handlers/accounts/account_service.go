@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -47,6 +48,7 @@ const (
 	loginNameKey         = "login_name"
 	newPasswordField     = "new_password"
 	currentPasswordField = "current_password"
+	codeField            = "code"
 
 	// oidc field names
 	issuerField     = "attributes.issuer"
@@ -78,6 +80,9 @@ var (
 			action.Delete,
 			action.SetPassword,
 			action.ChangePassword,
+			action.Unlock,
+			action.EnrollTotp,
+			action.VerifyTotp,
 		),
 		oidc.Subtype: action.NewActionSet(
 			action.NoOp,
@@ -118,15 +123,16 @@ func init() {
 type Service struct {
 	pbs.UnsafeAccountServiceServer
 
-	pwRepoFn   common.PasswordAuthRepoFactory
-	oidcRepoFn common.OidcAuthRepoFactory
-	ldapRepoFn common.LdapAuthRepoFactory
+	pwRepoFn    common.PasswordAuthRepoFactory
+	oidcRepoFn  common.OidcAuthRepoFactory
+	ldapRepoFn  common.LdapAuthRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.AccountServiceServer = (*Service)(nil)
 
 // NewService returns a account service which handles account related requests to boundary.
-func NewService(ctx context.Context, pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory, ldapRepo common.LdapAuthRepoFactory) (Service, error) {
+func NewService(ctx context.Context, pwRepo common.PasswordAuthRepoFactory, oidcRepo common.OidcAuthRepoFactory, ldapRepo common.LdapAuthRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "accounts.NewService"
 	switch {
 	case pwRepo == nil:
@@ -136,7 +142,7 @@ func NewService(ctx context.Context, pwRepo common.PasswordAuthRepoFactory, oidc
 	case ldapRepo == nil:
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing ldap repository")
 	}
-	return Service{pwRepoFn: pwRepo, oidcRepoFn: oidcRepo, ldapRepoFn: ldapRepo}, nil
+	return Service{pwRepoFn: pwRepo, oidcRepoFn: oidcRepo, ldapRepoFn: ldapRepo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListAccounts implements the interface pbs.AccountServiceServer.
@@ -148,30 +154,22 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListAccountsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Account, 0, len(ul))
 
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.Account,
 		Pin:     req.GetAuthMethodId(),
 	}
-	for _, acct := range ul {
-		res.Id = acct.GetPublicId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(acct.GetPublicId()).Subtype], requestauth.WithResource(&res)).Strings()
+	protos := make(map[string]*pb.Account)
+	filterItemFn := func(ctx context.Context, item auth.Account) (bool, error) {
+		res.Id = item.GetPublicId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(item.GetPublicId()).Subtype], requestauth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -184,22 +182,40 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, acct, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]auth.Account, error) {
+		return s.listFromRepo(ctx, req.GetAuthMethodId(), limit, prevPageLastItem)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Account, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Account, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
 	}
-	return &pbs.ListAccountsResponse{Items: finalItems}, nil
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAccountsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetAccount implements the interface pbs.AccountServiceServer.
@@ -416,6 +432,127 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	return &pbs.SetPasswordResponse{Item: item}, nil
 }
 
+// UnlockAccount implements the interface pbs.AccountServiceServer.
+func (s Service) UnlockAccount(ctx context.Context, req *pbs.UnlockAccountRequest) (*pbs.UnlockAccountResponse, error) {
+	const op = "accounts.(Service).UnlockAccount"
+
+	if err := validateUnlockAccountRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Unlock)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.unlockAccountInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(acct.GetPublicId()).Subtype]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UnlockAccountResponse{Item: item}, nil
+}
+
+// EnrollTotp implements the interface pbs.AccountServiceServer.
+func (s Service) EnrollTotp(ctx context.Context, req *pbs.EnrollTotpRequest) (*pbs.EnrollTotpResponse, error) {
+	const op = "accounts.(Service).EnrollTotp"
+
+	if err := validateEnrollTotpRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.EnrollTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, totp, err := s.enrollTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(acct.GetPublicId()).Subtype]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.EnrollTotpResponse{
+		Item:   item,
+		Secret: totp.EncodedSecret(),
+		Url:    totp.Url(acct.GetLoginName()),
+	}, nil
+}
+
+// VerifyTotp implements the interface pbs.AccountServiceServer.
+func (s Service) VerifyTotp(ctx context.Context, req *pbs.VerifyTotpRequest) (*pbs.VerifyTotpResponse, error) {
+	const op = "accounts.(Service).VerifyTotp"
+
+	if err := validateVerifyTotpRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.VerifyTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	acct, err := s.verifyTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, acct.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(acct.GetPublicId()).Subtype]).Strings()))
+	}
+
+	item, err := toProto(ctx, acct, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.VerifyTotpResponse{Item: item}, nil
+}
+
 // getFromRepo returns the account and, if available, managed groups the account
 // belongs to within the auth method
 func (s Service) getFromRepo(ctx context.Context, id string) (auth.Account, []string, error) {
@@ -512,6 +649,10 @@ func (s Service) createPwInRepo(ctx context.Context, am auth.AuthMethod, item *p
 	}
 	out, err := repo.CreateAccount(ctx, am.GetScopeId(), a, createOpts...)
 	if err != nil {
+		if errors.Match(errors.T(errors.PasswordTooSimple), err) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"attributes.password": "Password is too simple."})
+		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	if out == nil {
@@ -821,7 +962,7 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.Account, error) {
+func (s Service) listFromRepo(ctx context.Context, authMethodId string, limit int, prevPageLastItem pagination.Item) ([]auth.Account, error) {
 	const op = "accounts.(Service).listFromRepo"
 
 	var outUl []auth.Account
@@ -831,7 +972,7 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		pwl, err := pwRepo.ListAccounts(ctx, authMethodId, password.WithLimit(-1))
+		pwl, err := pwRepo.ListAccounts(ctx, authMethodId, password.WithLimit(limit), password.WithStartPageAfterItem(prevPageLastItem))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -843,7 +984,7 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		oidcl, err := oidcRepo.ListAccounts(ctx, authMethodId, oidc.WithLimit(-1))
+		oidcl, err := oidcRepo.ListAccounts(ctx, authMethodId, oidc.WithLimit(limit), oidc.WithStartPageAfterItem(prevPageLastItem))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -855,7 +996,7 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]auth.
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
-		ldapList, err := ldapRepo.ListAccounts(ctx, authMethodId, ldap.WithLimit(ctx, -1))
+		ldapList, err := ldapRepo.ListAccounts(ctx, authMethodId, ldap.WithLimit(ctx, limit), ldap.WithStartPageAfterItem(ctx, prevPageLastItem))
 		if err != nil {
 			return nil, errors.Wrap(ctx, err, op)
 		}
@@ -883,6 +1024,14 @@ func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, v
 		case errors.Match(errors.T(errors.PasswordsEqual), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"new_password": "New password equal to current password."})
+		case errors.Match(errors.T(errors.PasswordTooSimple), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password is too simple."})
+		case errors.Match(errors.T(errors.PasswordReused), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"new_password": "Password equal to a recent password."})
+		case errors.Match(errors.T(errors.AccountLocked), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Failed to change password: the account is locked.")
 		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -907,6 +1056,79 @@ func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, vers
 		case errors.Match(errors.T(errors.PasswordTooShort), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"password": "Password is too short."})
+		case errors.Match(errors.T(errors.PasswordTooSimple), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password is too simple."})
+		case errors.Match(errors.T(errors.PasswordReused), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"password": "Password equal to a recent password."})
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) unlockAccountInRepo(ctx context.Context, id string) (auth.Account, error) {
+	const op = "accounts.(Service).unlockAccountInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.UnlockAccount(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
+		}
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return out, nil
+}
+
+func (s Service) enrollTotpInRepo(ctx context.Context, scopeId, id string) (*password.Account, *password.Totp, error) {
+	const op = "accounts.(Service).enrollTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	totp, err := repo.EnrollTotp(ctx, scopeId, id)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, nil, handlers.NotFoundErrorf("Account not found.")
+		case errors.Match(errors.T(errors.NotUnique), err):
+			return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The account already has a verified TOTP enrollment.")
+		}
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	acct, err := repo.LookupAccount(ctx, id)
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	if acct == nil {
+		return nil, nil, handlers.NotFoundErrorf("Account not found.")
+	}
+	return acct, totp, nil
+}
+
+func (s Service) verifyTotpInRepo(ctx context.Context, scopeId, id, code string) (auth.Account, error) {
+	const op = "accounts.(Service).verifyTotpInRepo"
+
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.VerifyTotp(ctx, scopeId, id, code)
+	if err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("Account has no TOTP enrollment.")
+		case errors.Match(errors.T(errors.NotUnique), err):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "The TOTP enrollment of the account is already verified.")
+		case errors.Match(errors.T(errors.InvalidTotpCode), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{codeField: "Invalid code."})
 		}
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -1371,3 +1593,51 @@ func validateSetPasswordRequest(ctx context.Context, req *pbs.SetPasswordRequest
 	}
 	return nil
 }
+
+func validateUnlockAccountRequest(ctx context.Context, req *pbs.UnlockAccountRequest) error {
+	const op = "accounts.validateUnlockAccountRequest"
+	if req == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.PasswordAccountPreviousPrefix, globals.PasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateEnrollTotpRequest(ctx context.Context, req *pbs.EnrollTotpRequest) error {
+	const op = "accounts.validateEnrollTotpRequest"
+	if req == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.PasswordAccountPreviousPrefix, globals.PasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateVerifyTotpRequest(ctx context.Context, req *pbs.VerifyTotpRequest) error {
+	const op = "accounts.validateVerifyTotpRequest"
+	if req == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "nil request")
+	}
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.PasswordAccountPreviousPrefix, globals.PasswordAccountPrefix) {
+		badFields[idField] = "Improperly formatted identifier."
+	}
+	if req.GetCode() == "" {
+		badFields[codeField] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
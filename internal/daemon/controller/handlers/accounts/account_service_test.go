@@ -5,11 +5,13 @@ package accounts_test
 
 import (
 	"context"
+	"encoding/base32"
 	"errors"
 	"fmt"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -48,6 +50,9 @@ var (
 		action.Delete.String(),
 		action.SetPassword.String(),
 		action.ChangePassword.String(),
+		action.Unlock.String(),
+		action.EnrollTotp.String(),
+		action.VerifyTotp.String(),
 	}
 	oidcAuthorizedActions = []string{
 		action.NoOp.String(),
@@ -3236,3 +3241,188 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUnlockAccount(t *testing.T) {
+	ctx := context.TODO()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(ctx, rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(ctx, rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(ctx, pwRepoFn, oidcRepoFn, ldapRepoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	pwRepo, err := pwRepoFn()
+	require.NoError(t, err)
+	am.LockoutThreshold = 1
+	_, _, err = pwRepo.UpdateAuthMethod(ctx, am, am.Version, []string{"LockoutThreshold"})
+	require.NoError(t, err)
+	acct, err := password.NewAccount(ctx, am.GetPublicId(), password.WithLoginName("testusername"))
+	require.NoError(t, err)
+	acct, err = pwRepo.CreateAccount(ctx, o.GetPublicId(), acct, password.WithPassword("somepassword"))
+	require.NoError(t, err)
+
+	t.Run("unlock", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := pwRepo.Authenticate(ctx, o.GetPublicId(), am.GetPublicId(), acct.GetLoginName(), "wrongpassword")
+		require.NoError(err)
+		require.Nil(got)
+		_, err = pwRepo.Authenticate(ctx, o.GetPublicId(), am.GetPublicId(), acct.GetLoginName(), "somepassword")
+		require.Error(err)
+
+		unlockResp, err := tested.UnlockAccount(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.UnlockAccountRequest{
+			Id: acct.GetPublicId(),
+		})
+		require.NoError(err)
+		assert.Equal(acct.GetPublicId(), unlockResp.GetItem().GetId())
+		assert.Equal(acct.GetVersion(), unlockResp.GetItem().GetVersion())
+
+		got, err = pwRepo.Authenticate(ctx, o.GetPublicId(), am.GetPublicId(), acct.GetLoginName(), "somepassword")
+		require.NoError(err)
+		assert.NotNil(got)
+	})
+
+	badRequestCases := []struct {
+		name      string
+		accountId string
+	}{
+		{
+			name:      "empty account id",
+			accountId: "",
+		},
+		{
+			name:      "notfound old account id",
+			accountId: globals.PasswordAccountPreviousPrefix + "_DoesntExis",
+		},
+		{
+			name:      "notfound new account id",
+			accountId: globals.PasswordAccountPrefix + "_DoesntExis",
+		},
+		{
+			name:      "oidc account id",
+			accountId: globals.OidcAccountPrefix + "_1234567890",
+		},
+	}
+
+	for _, tt := range badRequestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			unlockResp, err := tested.UnlockAccount(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.UnlockAccountRequest{
+				Id: tt.accountId,
+			})
+			assert.Error(err)
+			assert.Nil(unlockResp)
+		})
+	}
+}
+
+func TestEnrollAndVerifyTotp(t *testing.T) {
+	ctx := context.TODO()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(ctx, rw, rw, kms)
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(ctx, rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(ctx, pwRepoFn, oidcRepoFn, ldapRepoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct := password.TestAccount(t, conn, am.GetPublicId(), "testusername")
+
+	t.Run("enroll-and-verify", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		enrollResp, err := tested.EnrollTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.EnrollTotpRequest{
+			Id: acct.GetPublicId(),
+		})
+		require.NoError(err)
+		assert.Equal(acct.GetPublicId(), enrollResp.GetItem().GetId())
+		assert.NotEmpty(enrollResp.GetSecret())
+		assert.Contains(enrollResp.GetUrl(), "otpauth://totp/")
+
+		_, err = tested.VerifyTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.VerifyTotpRequest{
+			Id:   acct.GetPublicId(),
+			Code: "000000",
+		})
+		assert.Error(err)
+
+		secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(enrollResp.GetSecret())
+		require.NoError(err)
+		verifyResp, err := tested.VerifyTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.VerifyTotpRequest{
+			Id:   acct.GetPublicId(),
+			Code: password.TestTotpCode(t, secret, time.Now()),
+		})
+		require.NoError(err)
+		assert.Equal(acct.GetPublicId(), verifyResp.GetItem().GetId())
+
+		// a verified enrollment can't be replaced
+		_, err = tested.EnrollTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.EnrollTotpRequest{
+			Id: acct.GetPublicId(),
+		})
+		assert.Error(err)
+	})
+
+	badRequestCases := []struct {
+		name      string
+		accountId string
+	}{
+		{
+			name:      "empty account id",
+			accountId: "",
+		},
+		{
+			name:      "notfound account id",
+			accountId: globals.PasswordAccountPrefix + "_DoesntExis",
+		},
+		{
+			name:      "oidc account id",
+			accountId: globals.OidcAccountPrefix + "_1234567890",
+		},
+	}
+
+	for _, tt := range badRequestCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			enrollResp, err := tested.EnrollTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.EnrollTotpRequest{
+				Id: tt.accountId,
+			})
+			assert.Error(err)
+			assert.Nil(enrollResp)
+
+			verifyResp, err := tested.VerifyTotp(requestauth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.VerifyTotpRequest{
+				Id:   tt.accountId,
+				Code: "123456",
+			})
+			assert.Error(err)
+			assert.Nil(verifyResp)
+		})
+	}
+}
@@ -0,0 +1,629 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/boundary/globals"
+	talias "github.com/hashicorp/boundary/internal/alias/target"
+	"github.com/hashicorp/boundary/internal/alias/target/store"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	valueField          = "value"
+	destinationIdField  = "destination_id"
+	hostIdField         = "attributes.authorize_session_arguments.host_id"
+	aliasTargetTypeName = "target"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// validValue matches lower case, dns-like alias values. It mirrors the
+	// wt_alias domain in the database.
+	validValue = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.NewActionSet(
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	)
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.NewActionSet(
+		action.Create,
+		action.List,
+	)
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.Alias{}},
+		handlers.MaskSource{&pb.Alias{}, &pb.AuthorizeSessionArguments{}},
+	); err != nil {
+		panic(err)
+	}
+
+	action.RegisterResource(resource.Alias, IdActions, CollectionActions)
+}
+
+// Service handles request as described by the pbs.AliasServiceServer interface.
+type Service struct {
+	pbs.UnsafeAliasServiceServer
+
+	repoFn      common.TargetAliasRepoFactory
+	iamRepoFn   common.IamRepoFactory
+	maxPageSize uint
+}
+
+var _ pbs.AliasServiceServer = (*Service)(nil)
+
+// NewService returns an alias service which handles alias related requests to boundary.
+func NewService(
+	ctx context.Context,
+	repoFn common.TargetAliasRepoFactory,
+	iamRepoFn common.IamRepoFactory,
+	opt ...handlers.Option,
+) (Service, error) {
+	const op = "aliases.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing target alias repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
+}
+
+// ListAliases implements the interface pbs.AliasServiceServer
+func (s Service) ListAliases(ctx context.Context, req *pbs.ListAliasesRequest) (*pbs.ListAliasesResponse, error) {
+	if err := validateListRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Alias, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListAliasesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	res := perms.Resource{
+		Type: resource.Alias,
+	}
+	protos := make(map[string]*pb.Alias)
+	filterItemFn := func(ctx context.Context, item *talias.Alias) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return false, err
+		}
+
+		filterable, err := subtypes.Filterable(ctx, pbItem)
+		if err != nil {
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*talias.Alias, error) {
+		return s.listFromRepo(ctx, scopeIds, limit, prevPageLastItem)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Alias, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Alias, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAliasesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// GetAlias implements the interface pbs.AliasServiceServer.
+func (s Service) GetAlias(ctx context.Context, req *pbs.GetAliasRequest) (*pbs.GetAliasResponse, error) {
+	const op = "aliases.(Service).GetAlias"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, a.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetAliasResponse{Item: item}, nil
+}
+
+// CreateAlias implements the interface pbs.AliasServiceServer.
+func (s Service) CreateAlias(ctx context.Context, req *pbs.CreateAliasRequest) (*pbs.CreateAliasResponse, error) {
+	const op = "aliases.(Service).CreateAlias"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, a.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreateAliasResponse{
+		Item: item,
+		Uri:  fmt.Sprintf("aliases/%s", item.GetId()),
+	}, nil
+}
+
+// UpdateAlias implements the interface pbs.AliasServiceServer.
+func (s Service) UpdateAlias(ctx context.Context, req *pbs.UpdateAliasRequest) (*pbs.UpdateAliasResponse, error) {
+	const op = "aliases.(Service).UpdateAlias"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	a, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, a.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, a, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdateAliasResponse{Item: item}, nil
+}
+
+// DeleteAlias implements the interface pbs.AliasServiceServer.
+func (s Service) DeleteAlias(ctx context.Context, req *pbs.DeleteAliasRequest) (*pbs.DeleteAliasResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, limit int, prevPageLastItem pagination.Item) ([]*talias.Alias, error) {
+	const op = "aliases.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	al, err := repo.ListAliases(ctx, scopeIds, talias.WithLimit(limit), talias.WithStartPageAfterItem(prevPageLastItem))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return al, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*talias.Alias, error) {
+	const op = "aliases.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	a, err := repo.LookupAlias(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if a == nil {
+		return nil, handlers.NotFoundErrorf("Alias %q doesn't exist.", id)
+	}
+	return a, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Alias) (*talias.Alias, error) {
+	const op = "aliases.(Service).createInRepo"
+	a, err := toStorageAlias(ctx, scopeId, item)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreateAlias(ctx, a)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create alias"))
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Alias) (*talias.Alias, error) {
+	const op = "aliases.(Service).updateInRepo"
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	// The value is required to build an alias but may not be part of the
+	// update, so only the fields in the mask are read from it.
+	a := &talias.Alias{
+		Alias: &store.Alias{
+			PublicId:      id,
+			ScopeId:       scopeId,
+			Value:         item.GetValue(),
+			Name:          item.GetName().GetValue(),
+			Description:   item.GetDescription().GetValue(),
+			DestinationId: item.GetDestinationId().GetValue(),
+			HostId:        item.GetTargetAliasAttributes().GetAuthorizeSessionArguments().GetHostId(),
+		},
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdateAlias(ctx, a, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update alias"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Alias %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "aliases.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	rows, err := repo.DeleteAlias(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete alias"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Alias), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		al, err := repo.LookupAlias(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if al == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = al.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *talias.Alias, opt ...handlers.Option) (*pb.Alias, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building alias proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Alias{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.TypeField) {
+		out.Type = aliasTargetTypeName
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(valueField) {
+		out.Value = in.GetValue()
+	}
+	if outputFields.Has(destinationIdField) && in.GetDestinationId() != "" {
+		out.DestinationId = wrapperspb.String(in.GetDestinationId())
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.AttributesField) && in.GetHostId() != "" {
+		out.Attrs = &pb.Alias_TargetAliasAttributes{
+			TargetAliasAttributes: &pb.TargetAliasAttributes{
+				AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+					HostId: in.GetHostId(),
+				},
+			},
+		}
+	}
+	return &out, nil
+}
+
+func toStorageAlias(ctx context.Context, scopeId string, in *pb.Alias) (*talias.Alias, error) {
+	const op = "aliases.toStorageAlias"
+	var opts []talias.Option
+	if in.GetName() != nil {
+		opts = append(opts, talias.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, talias.WithDescription(in.GetDescription().GetValue()))
+	}
+	if in.GetDestinationId() != nil {
+		opts = append(opts, talias.WithDestinationId(in.GetDestinationId().GetValue()))
+	}
+	if hostId := in.GetTargetAliasAttributes().GetAuthorizeSessionArguments().GetHostId(); hostId != "" {
+		opts = append(opts, talias.WithHostId(hostId))
+	}
+	a, err := talias.NewAlias(ctx, scopeId, in.GetValue(), opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to build alias for creation"))
+	}
+	return a, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetAliasRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.TargetAliasPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreateAliasRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if item.GetScopeId() != scope.Global.String() {
+			badFields[globals.ScopeIdField] = "This field must be 'global'."
+		}
+		if item.GetType() != aliasTargetTypeName {
+			badFields[globals.TypeField] = "This is a required field and must be a known alias type."
+		}
+		switch {
+		case item.GetValue() == "":
+			badFields[valueField] = "This field is required."
+		case !validValue.MatchString(item.GetValue()):
+			badFields[valueField] = "Must be a lower case, dns-like value."
+		}
+		validateDestination(item, badFields)
+		if item.GetTargetAliasAttributes().GetAuthorizeSessionArguments().GetHostId() != "" && item.GetDestinationId().GetValue() == "" {
+			badFields[hostIdField] = "A host ID can only be set when a destination ID is set."
+		}
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateAliasRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if item.GetType() != "" && item.GetType() != aliasTargetTypeName {
+			badFields[globals.TypeField] = "Cannot modify resource type."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), valueField) {
+			switch {
+			case item.GetValue() == "":
+				badFields[valueField] = "This is a required field and cannot be unset."
+			case !validValue.MatchString(item.GetValue()):
+				badFields[valueField] = "Must be a lower case, dns-like value."
+			}
+		}
+		validateDestination(item, badFields)
+		return badFields
+	}, globals.TargetAliasPrefix)
+}
+
+func validateDestination(item *pb.Alias, badFields map[string]string) {
+	if id := item.GetDestinationId().GetValue(); id != "" && !handlers.ValidId(handlers.Id(id), target.Prefixes()...) {
+		badFields[destinationIdField] = "Incorrectly formatted identifier."
+	}
+	if hostId := item.GetTargetAliasAttributes().GetAuthorizeSessionArguments().GetHostId(); hostId != "" {
+		switch globals.ResourceInfoFromPrefix(hostId).Subtype {
+		case static.Subtype, plugin.Subtype:
+		default:
+			badFields[hostIdField] = "Incorrectly formatted identifier."
+		}
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteAliasRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.TargetAliasPrefix)
+}
+
+func validateListRequest(ctx context.Context, req *pbs.ListAliasesRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() && !req.GetRecursive() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or the list operation must be recursive."
+	}
+	if _, err := handlers.NewFilter(ctx, req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
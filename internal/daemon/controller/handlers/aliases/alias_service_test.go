@@ -0,0 +1,566 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package aliases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/globals"
+	talias "github.com/hashicorp/boundary/internal/alias/target"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/aliases"
+	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+	globalScopeInfo       = &scopepb.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test target")
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	al := talias.TestAlias(t, rw, "test.alias.example",
+		talias.WithDestinationId(tar.GetPublicId()),
+		talias.WithHostId(h.GetPublicId()),
+		talias.WithName("test alias"),
+	)
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		res  *pbs.GetAliasResponse
+		err  error
+	}{
+		{
+			name: "success",
+			id:   al.GetPublicId(),
+			res: &pbs.GetAliasResponse{
+				Item: &pb.Alias{
+					Id:                al.GetPublicId(),
+					ScopeId:           scope.Global.String(),
+					Scope:             globalScopeInfo,
+					Name:              wrapperspb.String("test alias"),
+					CreatedTime:       al.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       al.GetUpdateTime().GetTimestamp(),
+					Version:           1,
+					Value:             "test.alias.example",
+					DestinationId:     wrapperspb.String(tar.GetPublicId()),
+					Type:              "target",
+					AuthorizedActions: testAuthorizedActions,
+					Attrs: &pb.Alias_TargetAliasAttributes{
+						TargetAliasAttributes: &pb.TargetAliasAttributes{
+							AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+								HostId: h.GetPublicId(),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.TargetAliasPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.GetAliasRequest{Id: tc.id}
+			got, gErr := s.GetAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "GetAlias(%q) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+
+	var wantAliases []*pb.Alias
+	for i := 0; i < 10; i++ {
+		al := talias.TestAlias(t, rw, fmt.Sprintf("alias%d.example", i))
+		wantAliases = append(wantAliases, &pb.Alias{
+			Id:                al.GetPublicId(),
+			ScopeId:           scope.Global.String(),
+			Scope:             globalScopeInfo,
+			CreatedTime:       al.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       al.GetUpdateTime().GetTimestamp(),
+			Version:           al.GetVersion(),
+			Value:             al.GetValue(),
+			Type:              "target",
+			AuthorizedActions: testAuthorizedActions,
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListAliasesRequest
+		res  *pbs.ListAliasesResponse
+		err  error
+	}{
+		{
+			name: "list many aliases",
+			req:  &pbs.ListAliasesRequest{ScopeId: scope.Global.String()},
+			res:  &pbs.ListAliasesResponse{Items: wantAliases},
+		},
+		{
+			name: "filter to one alias",
+			req:  &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Filter: fmt.Sprintf(`"/item/value"==%q`, wantAliases[1].GetValue())},
+			res:  &pbs.ListAliasesResponse{Items: wantAliases[1:2]},
+		},
+		{
+			name: "filter to no alias",
+			req:  &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Filter: `"/item/id"=="doesnt match"`},
+			res:  &pbs.ListAliasesResponse{},
+		},
+		{
+			name: "filter bad format",
+			req:  &pbs.ListAliasesRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`},
+			err:  handlers.InvalidArgumentErrorf("bad format", nil),
+		},
+		{
+			name: "non global scope",
+			req:  &pbs.ListAliasesRequest{ScopeId: "o_1234567890"},
+			err:  handlers.InvalidArgumentErrorf("bad scope", nil),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewService(ctx, repoFn, iamRepoFn)
+			require.NoError(t, err)
+
+			got, gErr := s.ListAliases(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "ListAliases(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				protocmp.SortRepeated(func(x, y *pb.Alias) bool {
+					return x.Id < y.Id
+				}),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test target")
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreateAliasRequest
+		res  *pbs.CreateAliasResponse
+		err  error
+	}{
+		{
+			name: "create with destination and host",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Type:          "target",
+				Value:         "valid.alias.example",
+				Name:          wrapperspb.String("name"),
+				Description:   wrapperspb.String("desc"),
+				DestinationId: wrapperspb.String(tar.GetPublicId()),
+				Attrs: &pb.Alias_TargetAliasAttributes{
+					TargetAliasAttributes: &pb.TargetAliasAttributes{
+						AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+							HostId: h.GetPublicId(),
+						},
+					},
+				},
+			}},
+			res: &pbs.CreateAliasResponse{
+				Uri: fmt.Sprintf("aliases/%s_", globals.TargetAliasPrefix),
+				Item: &pb.Alias{
+					ScopeId:           scope.Global.String(),
+					Scope:             globalScopeInfo,
+					Type:              "target",
+					Value:             "valid.alias.example",
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					DestinationId:     wrapperspb.String(tar.GetPublicId()),
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+					Attrs: &pb.Alias_TargetAliasAttributes{
+						TargetAliasAttributes: &pb.TargetAliasAttributes{
+							AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+								HostId: h.GetPublicId(),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create without destination",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Type:    "target",
+				Value:   "no-destination.example",
+			}},
+			res: &pbs.CreateAliasResponse{
+				Uri: fmt.Sprintf("aliases/%s_", globals.TargetAliasPrefix),
+				Item: &pb.Alias{
+					ScopeId:           scope.Global.String(),
+					Scope:             globalScopeInfo,
+					Type:              "target",
+					Value:             "no-destination.example",
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+				},
+			},
+		},
+		{
+			name: "non global scope",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: proj.GetPublicId(),
+				Type:    "target",
+				Value:   "project.alias.example",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "missing type",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Value:   "no-type.example",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "upper case value",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Type:    "target",
+				Value:   "Upper.Example",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "value looks like an id",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Type:    "target",
+				Value:   "ttcp_1234567890",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "bad destination id",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId:       scope.Global.String(),
+				Type:          "target",
+				Value:         "bad-destination.example",
+				DestinationId: wrapperspb.String(h.GetPublicId()),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "host without destination",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				ScopeId: scope.Global.String(),
+				Type:    "target",
+				Value:   "host-only.example",
+				Attrs: &pb.Alias_TargetAliasAttributes{
+					TargetAliasAttributes: &pb.TargetAliasAttributes{
+						AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+							HostId: h.GetPublicId(),
+						},
+					},
+				},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "id set",
+			req: &pbs.CreateAliasRequest{Item: &pb.Alias{
+				Id:      fmt.Sprintf("%s_1234567890", globals.TargetAliasPrefix),
+				ScopeId: scope.Global.String(),
+				Type:    "target",
+				Value:   "id-set.example",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.CreateAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "CreateAlias(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			require.NotNil(t, got)
+			assert.Contains(t, got.GetUri(), tc.res.GetUri())
+			assert.True(t, handlers.ValidId(handlers.Id(got.GetItem().GetId()), globals.TargetAliasPrefix))
+			assert.NotNil(t, got.GetItem().GetCreatedTime())
+			assert.NotNil(t, got.GetItem().GetUpdatedTime())
+			assert.Equal(t, got.GetItem().GetCreatedTime().AsTime(), got.GetItem().GetUpdatedTime().AsTime())
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id, tc.res.Item.Id = "", ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+	tar := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "test target")
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		paths []string
+		item  *pb.Alias
+		check func(t *testing.T, got *pb.Alias)
+		err   error
+	}{
+		{
+			name:  "update value",
+			paths: []string{"value"},
+			item:  &pb.Alias{Value: "updated.alias.example"},
+			check: func(t *testing.T, got *pb.Alias) {
+				assert.Equal(t, "updated.alias.example", got.GetValue())
+			},
+		},
+		{
+			name:  "set destination and host",
+			paths: []string{"destination_id", "attributes.authorize_session_arguments.host_id"},
+			item: &pb.Alias{
+				DestinationId: wrapperspb.String(tar.GetPublicId()),
+				Attrs: &pb.Alias_TargetAliasAttributes{
+					TargetAliasAttributes: &pb.TargetAliasAttributes{
+						AuthorizeSessionArguments: &pb.AuthorizeSessionArguments{
+							HostId: h.GetPublicId(),
+						},
+					},
+				},
+			},
+			check: func(t *testing.T, got *pb.Alias) {
+				assert.Equal(t, tar.GetPublicId(), got.GetDestinationId().GetValue())
+				assert.Equal(t, h.GetPublicId(), got.GetTargetAliasAttributes().GetAuthorizeSessionArguments().GetHostId())
+			},
+		},
+		{
+			name:  "unset value",
+			paths: []string{"value"},
+			item:  &pb.Alias{},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "change type",
+			paths: []string{"name"},
+			item:  &pb.Alias{Name: wrapperspb.String("new name"), Type: "other"},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "no valid paths",
+			paths: []string{"scope_id"},
+			item:  &pb.Alias{ScopeId: proj.GetPublicId()},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			al := talias.TestAlias(t, rw, fmt.Sprintf("update%d.example", i))
+			tc.item.Version = al.GetVersion()
+			req := &pbs.UpdateAliasRequest{
+				Id:         al.GetPublicId(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.paths},
+				Item:       tc.item,
+			}
+			got, gErr := s.UpdateAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "UpdateAlias(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Equal(t, al.GetVersion()+1, got.GetItem().GetVersion())
+			tc.check(t, got.GetItem())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+
+	al := talias.TestAlias(t, rw, "delete.alias.example")
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{
+			name: "success",
+			id:   al.GetPublicId(),
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.TargetAliasPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.DeleteAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.DeleteAliasRequest{Id: tc.id})
+			assert.Nil(t, got)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "DeleteAlias(%q) got error %v, wanted %v", tc.id, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			g, err := s.GetAlias(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.GetAliasRequest{Id: tc.id})
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, handlers.NotFoundError()))
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"google.golang.org/protobuf/proto"
+)
+
+// WriteRevokeTokensAuditEvent writes an audit event for a request to endpoint
+// which revoked auth tokens and canceled sessions. The event includes resp,
+// which lists the ids of the revoked tokens and canceled sessions, and the id
+// of the requesting user, so every revocation can be attributed.
+func WriteRevokeTokensAuditEvent(ctx context.Context, op errors.Op, requesterId, endpoint string, resp proto.Message) {
+	if err := event.WriteAudit(ctx, event.Op(op),
+		event.WithAuth(&event.Auth{UserInfo: &event.UserInfo{UserId: requesterId}}),
+		event.WithRequest(&event.Request{
+			Operation: "revoke-tokens",
+			Endpoint:  endpoint,
+			Details:   resp,
+		})); err != nil {
+		// error was NOT event'd above...
+		_ = errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("error writing revoke tokens audit event"))
+	}
+}
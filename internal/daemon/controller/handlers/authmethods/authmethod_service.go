@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
@@ -23,11 +24,14 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/managed_groups"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -84,18 +88,20 @@ var (
 type Service struct {
 	pbs.UnsafeAuthMethodServiceServer
 
-	kms        *kms.Kms
-	pwRepoFn   common.PasswordAuthRepoFactory
-	oidcRepoFn common.OidcAuthRepoFactory
-	iamRepoFn  common.IamRepoFactory
-	atRepoFn   common.AuthTokenRepoFactory
-	ldapRepoFn common.LdapAuthRepoFactory
+	kms           *kms.Kms
+	pwRepoFn      common.PasswordAuthRepoFactory
+	oidcRepoFn    common.OidcAuthRepoFactory
+	iamRepoFn     common.IamRepoFactory
+	atRepoFn      common.AuthTokenRepoFactory
+	ldapRepoFn    common.LdapAuthRepoFactory
+	sessionRepoFn session.RepositoryFactory
+	maxPageSize   uint
 }
 
 var _ pbs.AuthMethodServiceServer = (*Service)(nil)
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
-func NewService(ctx context.Context, kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, opt ...handlers.Option) (Service, error) {
+func NewService(ctx context.Context, kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, oidcRepoFn common.OidcAuthRepoFactory, iamRepoFn common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, ldapRepoFn common.LdapAuthRepoFactory, sessionRepoFn session.RepositoryFactory, opt ...handlers.Option) (Service, error) {
 	const op = "authmethods.NewService"
 	if kms == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
@@ -115,7 +121,19 @@ func NewService(ctx context.Context, kms *kms.Kms, pwRepoFn common.PasswordAuthR
 	if atRepoFn == nil {
 		return Service{}, fmt.Errorf("nil auth token repository provided")
 	}
-	s := Service{kms: kms, pwRepoFn: pwRepoFn, oidcRepoFn: oidcRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn, ldapRepoFn: ldapRepoFn}
+	if sessionRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing session repository")
+	}
+	s := Service{
+		kms:           kms,
+		pwRepoFn:      pwRepoFn,
+		oidcRepoFn:    oidcRepoFn,
+		iamRepoFn:     iamRepoFn,
+		atRepoFn:      atRepoFn,
+		ldapRepoFn:    ldapRepoFn,
+		sessionRepoFn: sessionRepoFn,
+		maxPageSize:   handlers.GetOpts(opt...).WithMaxPageSize,
+	}
 
 	return s, nil
 }
@@ -149,63 +167,73 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 		return &pbs.ListAuthMethodsResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds, authResults)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListAuthMethodsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthMethod, 0, len(ul))
 	res := perms.Resource{
 		Type: resource.AuthMethod,
 	}
-	for _, am := range ul {
-		res.Id = am.GetPublicId()
-		res.ScopeId = am.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, am.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(am.GetPublicId()).Subtype], requestauth.WithResource(&res)).Strings()
+	protos := make(map[string]*pb.AuthMethod)
+	filterItemFn := func(ctx context.Context, item auth.AuthMethod) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions[globals.ResourceInfoFromPrefix(item.GetPublicId()).Subtype], requestauth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
 		outputOpts := make([]handlers.Option, 0, 3)
 		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
 		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[am.GetScopeId()]))
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
 		}
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
-			collectionActions, err := requestauth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, am.GetPublicId())
+			collectionActions, err := requestauth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap, authResults.Scope.Id, item.GetPublicId())
 			if err != nil {
-				return nil, err
+				return false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
-		item, err := toAuthMethodProto(ctx, am, outputOpts...)
+		pbItem, err := toAuthMethodProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]auth.AuthMethod, error) {
+		return s.listFromRepo(ctx, scopeIds, authResults, limit, prevPageLastItem)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.AuthMethod, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListAuthMethodsResponse{Items: finalItems}, nil
+	finalItems := make([]*pb.AuthMethod, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAuthMethodsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetAuthMethod implements the interface pbs.AuthMethodServiceServer.
@@ -402,6 +430,25 @@ func (s Service) ChangeState(ctx context.Context, req *pbs.ChangeStateRequest) (
 	return &pbs.ChangeStateResponse{Item: item}, nil
 }
 
+// RevokeAuthMethodTokens implements the interface pbs.AuthMethodServiceServer.
+func (s Service) RevokeAuthMethodTokens(ctx context.Context, req *pbs.RevokeAuthMethodTokensRequest) (*pbs.RevokeAuthMethodTokensResponse, error) {
+	const op = "authmethods.(Service).RevokeAuthMethodTokens"
+	if err := validateRevokeAuthMethodTokensRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tokenIds, sessionIds, err := s.revokeTokensInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &pbs.RevokeAuthMethodTokensResponse{AuthTokenIds: tokenIds, SessionIds: sessionIds}
+	handlers.WriteRevokeTokensAuditEvent(ctx, op, authResults.UserId, fmt.Sprintf("auth-methods/%s", req.GetId()), resp)
+	return resp, nil
+}
+
 // DeleteAuthMethod implements the interface pbs.AuthMethodServiceServer.
 func (s Service) DeleteAuthMethod(ctx context.Context, req *pbs.DeleteAuthMethodRequest) (*pbs.DeleteAuthMethodResponse, error) {
 	if err := validateDeleteRequest(ctx, req); err != nil {
@@ -499,7 +546,9 @@ func (s Service) getFromRepo(ctx context.Context, id string) (auth.AuthMethod, e
 	return am, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResults requestauth.VerifyResults) ([]auth.AuthMethod, error) {
+// listFromRepo returns at most limit auth methods of any type which are
+// ordered after prevPageLastItem.
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResults requestauth.VerifyResults, limit int, prevPageLastItem pagination.Item) ([]auth.AuthMethod, error) {
 	const op = "authmethods.(Service).listFromRepo"
 	reqCtx, ok := requests.RequestContextFromCtx(ctx)
 	if !ok {
@@ -512,7 +561,12 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	ol, err := oidcRepo.ListAuthMethods(ctx, scopeIds, oidc.WithUnauthenticatedUser(reqCtx.UserId == globals.AnonymousUserId), oidc.WithLimit(-1))
+	ol, err := oidcRepo.ListAuthMethods(ctx, scopeIds,
+		oidc.WithUnauthenticatedUser(reqCtx.UserId == globals.AnonymousUserId),
+		oidc.WithLimit(limit),
+		oidc.WithOrderByCreateTime(true),
+		oidc.WithStartPageAfterItem(prevPageLastItem),
+	)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -524,7 +578,11 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	pl, err := repo.ListAuthMethods(ctx, scopeIds)
+	pl, err := repo.ListAuthMethods(ctx, scopeIds,
+		password.WithLimit(limit),
+		password.WithOrderByCreateTime(true),
+		password.WithStartPageAfterItem(prevPageLastItem),
+	)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -536,13 +594,24 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string, authResult
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	ll, err := ldapRepo.ListAuthMethods(ctx, scopeIds, ldap.WithUnauthenticatedUser(ctx, reqCtx.UserId == globals.AnonymousUserId))
+	ll, err := ldapRepo.ListAuthMethods(ctx, scopeIds,
+		ldap.WithUnauthenticatedUser(ctx, reqCtx.UserId == globals.AnonymousUserId),
+		ldap.WithLimit(ctx, limit),
+		ldap.WithOrderByCreateTime(ctx, true),
+		ldap.WithStartPageAfterItem(ctx, prevPageLastItem),
+	)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
 	for _, item := range ll {
 		outUl = append(outUl, item)
 	}
+	sort.Slice(outUl, func(i, j int) bool {
+		return pagination.Less(outUl[i], outUl[j])
+	})
+	if limit > 0 && len(outUl) > limit {
+		outUl = outUl[:limit]
+	}
 
 	return outUl, nil
 }
@@ -707,6 +776,32 @@ func (s Service) changeStateInRepo(ctx context.Context, req *pbs.ChangeStateRequ
 	return nil, errors.New(ctx, errors.InvalidParameter, op, "Given auth method type does not support changing state")
 }
 
+// revokeTokensInRepo cancels the sessions created with auth tokens issued by
+// the auth method and deletes the auth tokens in a single transaction, so no
+// session can be authorized with one of the tokens after its sessions were
+// canceled.
+func (s Service) revokeTokensInRepo(ctx context.Context, authMethodId string) ([]string, []string, error) {
+	const op = "authmethods.(Service).revokeTokensInRepo"
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	sessionRepo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	var tokenIds []string
+	sessionIds, err := sessionRepo.CancelAuthMethodSessions(ctx, authMethodId, session.WithTx(func(r db.Reader, w db.Writer) error {
+		var err error
+		tokenIds, err = atRepo.DeleteAuthMethodAuthTokens(ctx, authMethodId, authtoken.WithReaderWriter(r, w))
+		return err
+	}))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke auth method auth tokens"))
+	}
+	return tokenIds, sessionIds, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) requestauth.VerifyResults {
 	const op = "authmethods.(Service).authResult"
 	res := requestauth.VerifyResults{}
@@ -809,6 +904,12 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 	if outputFields.Has(globals.IsPrimaryField) {
 		out.IsPrimary = in.GetIsPrimaryAuthMethod()
 	}
+	if outputFields.Has(globals.AuthTokenTimeToLiveSecondsField) && in.GetAuthTokenTimeToLiveSeconds() != 0 {
+		out.AuthTokenTimeToLiveSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToLiveSeconds())
+	}
+	if outputFields.Has(globals.AuthTokenTimeToStaleSecondsField) && in.GetAuthTokenTimeToStaleSeconds() != 0 {
+		out.AuthTokenTimeToStaleSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToStaleSeconds())
+	}
 	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
 		out.Description = wrapperspb.String(in.GetDescription())
 	}
@@ -843,8 +944,13 @@ func toAuthMethodProto(ctx context.Context, in auth.AuthMethod, opt ...handlers.
 		}
 		out.Attrs = &pb.AuthMethod_PasswordAuthMethodAttributes{
 			PasswordAuthMethodAttributes: &pb.PasswordAuthMethodAttributes{
-				MinLoginNameLength: i.GetMinLoginNameLength(),
-				MinPasswordLength:  i.GetMinPasswordLength(),
+				MinLoginNameLength:          i.GetMinLoginNameLength(),
+				MinPasswordLength:           i.GetMinPasswordLength(),
+				MinPasswordCharacterClasses: i.GetMinPasswordCharacterClasses(),
+				PasswordHistoryDepth:        i.GetPasswordHistoryDepth(),
+				MaxPasswordAgeDays:          i.GetMaxPasswordAgeDays(),
+				LockoutThreshold:            i.GetLockoutThreshold(),
+				LockoutDurationSeconds:      i.GetLockoutDurationSeconds(),
 			},
 		}
 	case *oidc.AuthMethod:
@@ -992,6 +1098,7 @@ func validateCreateRequest(ctx context.Context, req *pbs.CreateAuthMethodRequest
 		switch req.GetItem().GetType() {
 		case password.Subtype.String():
 			// Password attributes are not required when creating a password auth method.
+			validatePasswordAttributes(req.GetItem().GetPasswordAuthMethodAttributes(), badFields)
 		case oidc.Subtype.String():
 			attrs := req.GetItem().GetOidcAuthMethodsAttributes()
 			if attrs == nil {
@@ -1109,6 +1216,7 @@ func validateUpdateRequest(ctx context.Context, req *pbs.UpdateAuthMethodRequest
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != password.Subtype.String() {
 				badFields[typeField] = "Cannot modify the resource type."
 			}
+			validatePasswordAttributes(req.GetItem().GetPasswordAuthMethodAttributes(), badFields)
 		case oidc.Subtype:
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != oidc.Subtype.String() {
 				badFields[typeField] = "Cannot modify the resource type."
@@ -1247,6 +1355,14 @@ func validateDeleteRequest(ctx context.Context, req *pbs.DeleteAuthMethodRequest
 	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.PasswordAuthMethodPrefix, globals.OidcAuthMethodPrefix, globals.LdapAuthMethodPrefix)
 }
 
+func validateRevokeAuthMethodTokensRequest(ctx context.Context, req *pbs.RevokeAuthMethodTokensRequest) error {
+	const op = "authmethod.validateRevokeAuthMethodTokensRequest"
+	if req == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "Missing request")
+	}
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.PasswordAuthMethodPrefix, globals.OidcAuthMethodPrefix, globals.LdapAuthMethodPrefix)
+}
+
 func validateListRequest(ctx context.Context, req *pbs.ListAuthMethodsRequest) error {
 	const op = "authmethod.validateListRequest"
 	if req == nil {
@@ -1412,12 +1528,23 @@ func transformAuthenticateRequestAttributes(ctx context.Context, msg proto.Messa
 	}
 	switch globals.ResourceInfoFromPrefix(authRequest.GetAuthMethodId()).Subtype {
 	case password.Subtype:
-		newAttrs := &pbs.PasswordLoginAttributes{}
-		if err := handlers.StructToProto(attrs, newAttrs); err != nil {
-			return err
-		}
-		authRequest.Attrs = &pbs.AuthenticateRequest_PasswordLoginAttributes{
-			PasswordLoginAttributes: newAttrs,
+		switch authRequest.GetCommand() {
+		case totpCommand:
+			newAttrs := &pbs.PasswordTotpAttributes{}
+			if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+				return err
+			}
+			authRequest.Attrs = &pbs.AuthenticateRequest_PasswordTotpAttributes{
+				PasswordTotpAttributes: newAttrs,
+			}
+		default:
+			newAttrs := &pbs.PasswordLoginAttributes{}
+			if err := handlers.StructToProto(attrs, newAttrs); err != nil {
+				return err
+			}
+			authRequest.Attrs = &pbs.AuthenticateRequest_PasswordLoginAttributes{
+				PasswordLoginAttributes: newAttrs,
+			}
 		}
 	case oidc.Subtype:
 		switch authRequest.GetCommand() {
@@ -1485,6 +1612,11 @@ func transformAuthenticateResponseAttributes(ctx context.Context, msg proto.Mess
 		if err != nil {
 			return err
 		}
+	case *pbs.AuthenticateResponse_PasswordTotpChallengeResponse:
+		newAttrs, err = handlers.ProtoToStruct(ctx, attrs.PasswordTotpChallengeResponse)
+		if err != nil {
+			return err
+		}
 	case *pbs.AuthenticateResponse_OidcAuthMethodAuthenticateStartResponse:
 		newAttrs, err = handlers.ProtoToStruct(ctx, attrs.OidcAuthMethodAuthenticateStartResponse)
 		if err != nil {
@@ -42,6 +42,7 @@ func init() {
 		action.Update,
 		action.Delete,
 		action.Authenticate,
+		action.RevokeTokens,
 	)
 }
 
@@ -274,6 +275,8 @@ func toStorageLdapAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMet
 			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method: %v.", err)
 		}
 	}
+	u.AuthTokenTimeToLiveSeconds = in.GetAuthTokenTimeToLiveSeconds().GetValue()
+	u.AuthTokenTimeToStaleSeconds = in.GetAuthTokenTimeToStaleSeconds().GetValue()
 	return u, nil
 }
 
@@ -64,6 +64,7 @@ func init() {
 		action.Delete,
 		action.ChangeState,
 		action.Authenticate,
+		action.RevokeTokens,
 	)
 }
 
@@ -495,5 +496,7 @@ func toStorageOidcAuthMethod(ctx context.Context, scopeId string, in *pb.AuthMet
 	if err != nil {
 		return nil, false, false, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method: %v.", err)
 	}
+	u.AuthTokenTimeToLiveSeconds = in.GetAuthTokenTimeToLiveSeconds().GetValue()
+	u.AuthTokenTimeToStaleSeconds = in.GetAuthTokenTimeToStaleSeconds().GetValue()
 	return u, attrs.GetDryRun(), attrs.GetDisableDiscoveredConfigValidation(), nil
 }
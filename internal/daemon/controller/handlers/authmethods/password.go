@@ -23,9 +23,11 @@ import (
 
 const (
 	// password field names
-	loginNameField = "login_name"
-	passwordField  = "password"
-	loginCommand   = "login"
+	loginNameField                   = "login_name"
+	passwordField                    = "password"
+	loginCommand                     = "login"
+	totpCommand                      = "totp"
+	minPasswordCharacterClassesField = "attributes.min_password_character_classes"
 )
 
 var pwMaskManager handlers.MaskManager
@@ -42,6 +44,7 @@ func init() {
 		action.Update,
 		action.Delete,
 		action.Authenticate,
+		action.RevokeTokens,
 	)
 }
 
@@ -91,43 +94,115 @@ func (s Service) updatePwInRepo(ctx context.Context, scopeId, id string, mask []
 	return out, nil
 }
 
+// authenticatePassword authenticates with the login command or completes a
+// pending authentication with the totp command. When the account has a TOTP
+// second factor, the login command returns the id of a challenge instead of
+// an auth token.
 func (s Service) authenticatePassword(ctx context.Context, req *pbs.AuthenticateRequest, authResults *auth.VerifyResults) (*pbs.AuthenticateResponse, error) {
+	if req.GetCommand() == totpCommand {
+		reqAttrs := req.GetPasswordTotpAttributes()
+		tok, err := s.authenticateWithTotp(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.GetChallengeId(), reqAttrs.GetCode())
+		if err != nil {
+			return nil, err
+		}
+		return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
+	}
+
 	reqAttrs := req.GetPasswordLoginAttributes()
-	tok, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.LoginName, reqAttrs.Password)
+	tok, challenge, err := s.authenticateWithPwRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), reqAttrs.LoginName, reqAttrs.Password)
 	if err != nil {
 		return nil, err
 	}
+	if challenge != nil {
+		tokenType := req.GetType()
+		if tokenType == "" {
+			// Fall back to deprecated field if type is not set
+			tokenType = req.GetTokenType()
+		}
+		return &pbs.AuthenticateResponse{
+			Command: req.GetCommand(),
+			Attrs: &pbs.AuthenticateResponse_PasswordTotpChallengeResponse{
+				PasswordTotpChallengeResponse: &pbs.PasswordTotpChallengeResponse{
+					ChallengeId: challenge.GetPrivateId(),
+				},
+			},
+			Type: tokenType,
+		}, nil
+	}
 	return s.convertToAuthenticateResponse(ctx, req, authResults, tok)
 }
 
-func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
-	const op = "authmethods.(Service).authenticateWithPwRepo"
-	iamRepo, err := s.iamRepoFn()
+// authenticateWithPwRepo authenticates loginName with pw. It returns an auth
+// token, or a TOTP challenge if the account has a TOTP second factor.
+func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, *password.TotpChallenge, error) {
+	pwRepo, err := s.pwRepoFn()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	atRepo, err := s.atRepoFn()
+
+	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	switch {
+	case errors.Match(errors.T(errors.AccountLocked), err):
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate: the account is locked.")
+	case errors.Match(errors.T(errors.PasswordExpired), err):
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate: the password has expired.")
+	case err != nil:
+		return nil, nil, err
+	}
+	if acct == nil {
+		return nil, nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+
+	challenge, err := pwRepo.CreateTotpChallenge(ctx, acct.GetPublicId())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return nil, challenge, nil
+	}
+
+	tok, err := s.createPwAuthToken(ctx, acct.GetPublicId())
+	if err != nil {
+		return nil, nil, err
 	}
+	return tok, nil, nil
+}
+
+// authenticateWithTotp completes the TOTP challenge challengeId with code and
+// returns an auth token.
+func (s Service) authenticateWithTotp(ctx context.Context, scopeId, authMethodId, challengeId, code string) (*pba.AuthToken, error) {
 	pwRepo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
 	}
-
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	acct, err := pwRepo.AuthenticateTotp(ctx, scopeId, authMethodId, challengeId, code)
 	if err != nil {
 		return nil, err
 	}
 	if acct == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
 	}
+	return s.createPwAuthToken(ctx, acct.GetPublicId())
+}
+
+// createPwAuthToken creates an auth token for the user of the authenticated
+// password account accountId.
+func (s Service) createPwAuthToken(ctx context.Context, accountId string) (*pba.AuthToken, error) {
+	const op = "authmethods.(Service).createPwAuthToken"
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, err
+	}
 
-	u, err := iamRepo.LookupUserWithLogin(ctx, acct.GetPublicId())
+	u, err := iamRepo.LookupUserWithLogin(ctx, accountId)
 	if err != nil {
 		return nil, err
 	}
-	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
+	tok, err := atRepo.CreateAuthToken(ctx, u, accountId)
 	if err != nil {
 		return nil, err
 	}
@@ -146,24 +221,39 @@ func (s Service) authenticateWithPwRepo(ctx context.Context, scopeId, authMethod
 func validateAuthenticatePasswordRequest(req *pbs.AuthenticateRequest) error {
 	badFields := make(map[string]string)
 
-	attrs := req.GetPasswordLoginAttributes()
-	switch {
-	case attrs == nil:
-		badFields["attributes"] = "This is a required field."
-	default:
+	if req.GetCommand() == "" {
+		// TODO: Eventually, require a command. For now, fall back to "login" for backwards compat.
+		req.Command = loginCommand
+	}
+	switch req.GetCommand() {
+	case loginCommand:
+		attrs := req.GetPasswordLoginAttributes()
+		if attrs == nil {
+			badFields["attributes"] = "This is a required field."
+			break
+		}
 		if attrs.LoginName == "" {
 			badFields["attributes.login_name"] = "This is a required field."
 		}
 		if attrs.Password == "" {
 			badFields["attributes.password"] = "This is a required field."
 		}
-		if req.GetCommand() == "" {
-			// TODO: Eventually, require a command. For now, fall back to "login" for backwards compat.
-			req.Command = loginCommand
+	case totpCommand:
+		attrs := req.GetPasswordTotpAttributes()
+		if attrs == nil {
+			badFields["attributes"] = "This is a required field."
+			break
 		}
-		if req.Command != loginCommand {
-			badFields[commandField] = "Invalid command for this auth method type."
+		if attrs.GetChallengeId() == "" {
+			badFields["attributes.challenge_id"] = "This is a required field."
 		}
+		if attrs.GetCode() == "" {
+			badFields["attributes.code"] = "This is a required field."
+		}
+	default:
+		badFields[commandField] = "Invalid command for this auth method type."
+	}
+	if req.GetAttrs() != nil {
 		tokenType := req.GetType()
 		if tokenType == "" {
 			// Fall back to deprecated field if type is not set
@@ -205,5 +295,20 @@ func toStoragePwAuthMethod(ctx context.Context, scopeId string, item *pb.AuthMet
 	if pwAttrs.GetMinPasswordLength() != 0 {
 		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
 	}
+	u.MinPasswordCharacterClasses = pwAttrs.GetMinPasswordCharacterClasses()
+	u.PasswordHistoryDepth = pwAttrs.GetPasswordHistoryDepth()
+	u.MaxPasswordAgeDays = pwAttrs.GetMaxPasswordAgeDays()
+	u.LockoutThreshold = pwAttrs.GetLockoutThreshold()
+	u.LockoutDurationSeconds = pwAttrs.GetLockoutDurationSeconds()
+	u.AuthTokenTimeToLiveSeconds = item.GetAuthTokenTimeToLiveSeconds().GetValue()
+	u.AuthTokenTimeToStaleSeconds = item.GetAuthTokenTimeToStaleSeconds().GetValue()
 	return u, nil
 }
+
+// validatePasswordAttributes validates the password policy attributes of a
+// password auth method. A zero value disables the corresponding policy.
+func validatePasswordAttributes(attrs *pb.PasswordAuthMethodAttributes, badFields map[string]string) {
+	if attrs.GetMinPasswordCharacterClasses() > 4 {
+		badFields[minPasswordCharacterClassesField] = "Must not be greater than 4."
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/authmethods"
 	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
@@ -62,10 +64,13 @@ func TestUpdate_Password(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
 	iamRepo := iam.TestRepo(t, conn, wrapper)
 
 	o, _ := iam.TestScopes(t, iamRepo)
-	tested, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn)
+	tested, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new auth_method service.")
 
 	defaultScopeInfo := &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: o.GetType(), ParentScopeId: scope.Global.String()}
@@ -431,6 +436,69 @@ func TestUpdate_Password(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Update password policy",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{
+						"attributes.min_password_character_classes",
+						"attributes.password_history_depth",
+						"attributes.max_password_age_days",
+						"attributes.lockout_threshold",
+						"attributes.lockout_duration_seconds",
+					},
+				},
+				Item: &pb.AuthMethod{
+					Attrs: &pb.AuthMethod_PasswordAuthMethodAttributes{
+						PasswordAuthMethodAttributes: &pb.PasswordAuthMethodAttributes{
+							MinPasswordCharacterClasses: 3,
+							PasswordHistoryDepth:        5,
+							MaxPasswordAgeDays:          90,
+							LockoutThreshold:            5,
+							LockoutDurationSeconds:      900,
+						},
+					},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attrs: &pb.AuthMethod_PasswordAuthMethodAttributes{
+						PasswordAuthMethodAttributes: &pb.PasswordAuthMethodAttributes{
+							MinPasswordLength:           8,
+							MinLoginNameLength:          3,
+							MinPasswordCharacterClasses: 3,
+							PasswordHistoryDepth:        5,
+							MaxPasswordAgeDays:          90,
+							LockoutThreshold:            5,
+							LockoutDurationSeconds:      900,
+						},
+					},
+					Scope:                       defaultScopeInfo,
+					AuthorizedActions:           pwAuthorizedActions,
+					AuthorizedCollectionActions: authorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Invalid character classes",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.min_password_character_classes"},
+				},
+				Item: &pb.AuthMethod{
+					Attrs: &pb.AuthMethod_PasswordAuthMethodAttributes{
+						PasswordAuthMethodAttributes: &pb.PasswordAuthMethodAttributes{
+							MinPasswordCharacterClasses: 5,
+						},
+					},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -510,6 +578,9 @@ func TestAuthenticate_Password(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 
 	iam.TestSetPrimaryAuthMethod(t, iam.TestRepo(t, conn, wrapper), o, am.PublicId)
@@ -652,7 +723,7 @@ func TestAuthenticate_Password(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn)
+			s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn, sessionRepoFn)
 			require.NoError(err)
 
 			resp, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.request)
@@ -694,6 +765,178 @@ func TestAuthenticate_Password(t *testing.T) {
 	}
 }
 
+func TestAuthenticate_PasswordLockout(t *testing.T) {
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kms)
+	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	iam.TestSetPrimaryAuthMethod(t, iam.TestRepo(t, conn, wrapper), o, am.PublicId)
+
+	pwRepo, err := pwRepoFn()
+	require.NoError(err)
+	am.LockoutThreshold = 2
+	_, _, err = pwRepo.UpdateAuthMethod(ctx, am, am.Version, []string{"LockoutThreshold"})
+	require.NoError(err)
+
+	acct, err := password.NewAccount(ctx, am.GetPublicId(), password.WithLoginName(testLoginName))
+	require.NoError(err)
+	acct, err = pwRepo.CreateAccount(ctx, o.GetPublicId(), acct, password.WithPassword(testPassword))
+	require.NoError(err)
+	require.NotNil(acct)
+
+	s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn, sessionRepoFn)
+	require.NoError(err)
+	authenticate := func(pw string) error {
+		_, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			TokenType:    "token",
+			Attrs: &pbs.AuthenticateRequest_PasswordLoginAttributes{
+				PasswordLoginAttributes: &pbs.PasswordLoginAttributes{
+					LoginName: testLoginName,
+					Password:  pw,
+				},
+			},
+		})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		err := authenticate("wrong")
+		require.Error(err)
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)))
+		assert.Contains(err.Error(), "Unable to authenticate.")
+	}
+	err = authenticate(testPassword)
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)))
+	assert.Contains(err.Error(), "the account is locked")
+
+	_, err = pwRepo.UnlockAccount(ctx, acct.GetPublicId())
+	require.NoError(err)
+	assert.NoError(authenticate(testPassword))
+}
+
+func TestAuthenticate_PasswordTotp(t *testing.T) {
+	ctx := context.TODO()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	oidcRepoFn := func() (*oidc.Repository, error) {
+		return oidc.NewRepository(ctx, rw, rw, kms)
+	}
+	ldapRepoFn := func() (*ldap.Repository, error) {
+		return ldap.NewRepository(ctx, rw, rw, kms)
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(ctx, rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kms)
+	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	iam.TestSetPrimaryAuthMethod(t, iam.TestRepo(t, conn, wrapper), o, am.PublicId)
+
+	pwRepo, err := pwRepoFn()
+	require.NoError(err)
+	acct, err := password.NewAccount(ctx, am.GetPublicId(), password.WithLoginName(testLoginName))
+	require.NoError(err)
+	acct, err = pwRepo.CreateAccount(ctx, o.GetPublicId(), acct, password.WithPassword(testPassword))
+	require.NoError(err)
+	require.NotNil(acct)
+	totp, err := pwRepo.EnrollTotp(ctx, o.GetPublicId(), acct.GetPublicId())
+	require.NoError(err)
+	_, err = pwRepo.VerifyTotp(ctx, o.GetPublicId(), acct.GetPublicId(), password.TestTotpCode(t, totp.GetSecret(), time.Now().Add(-30*time.Second)))
+	require.NoError(err)
+
+	s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn, sessionRepoFn)
+	require.NoError(err)
+	authCtx := auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId())
+
+	resp, err := s.Authenticate(authCtx, &pbs.AuthenticateRequest{
+		AuthMethodId: am.GetPublicId(),
+		Type:         "token",
+		Attrs: &pbs.AuthenticateRequest_PasswordLoginAttributes{
+			PasswordLoginAttributes: &pbs.PasswordLoginAttributes{
+				LoginName: testLoginName,
+				Password:  testPassword,
+			},
+		},
+	})
+	require.NoError(err)
+	challenge := resp.GetPasswordTotpChallengeResponse()
+	require.NotNil(challenge, "expected a totp challenge, got %v", resp.GetAttrs())
+	assert.NotEmpty(challenge.GetChallengeId())
+	assert.Nil(resp.GetAuthTokenResponse())
+
+	totpRequest := func(code string) *pbs.AuthenticateRequest {
+		return &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Type:         "token",
+			Command:      "totp",
+			Attrs: &pbs.AuthenticateRequest_PasswordTotpAttributes{
+				PasswordTotpAttributes: &pbs.PasswordTotpAttributes{
+					ChallengeId: challenge.GetChallengeId(),
+					Code:        code,
+				},
+			},
+		}
+	}
+
+	_, err = s.Authenticate(authCtx, totpRequest("000000"))
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)))
+
+	resp, err = s.Authenticate(authCtx, totpRequest(password.TestTotpCode(t, totp.GetSecret(), time.Now())))
+	require.NoError(err)
+	tok := resp.GetAuthTokenResponse()
+	require.NotNil(tok)
+	assert.NotEmpty(tok.GetToken())
+	assert.Equal(acct.GetPublicId(), tok.GetAccountId())
+
+	_, err = s.Authenticate(authCtx, &pbs.AuthenticateRequest{
+		AuthMethodId: am.GetPublicId(),
+		Command:      "totp",
+		Attrs: &pbs.AuthenticateRequest_PasswordTotpAttributes{
+			PasswordTotpAttributes: &pbs.PasswordTotpAttributes{},
+		},
+	})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+}
+
 func TestAuthenticate_AuthAccountConnectedToIamUser_Password(t *testing.T) {
 	ctx := context.TODO()
 	assert, require := assert.New(t), require.New(t)
@@ -718,6 +961,9 @@ func TestAuthenticate_AuthAccountConnectedToIamUser_Password(t *testing.T) {
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
 
 	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
 	acct, err := password.NewAccount(ctx, am.GetPublicId(), password.WithLoginName(testLoginName))
@@ -735,7 +981,7 @@ func TestAuthenticate_AuthAccountConnectedToIamUser_Password(t *testing.T) {
 	iamUser, err := iamRepo.LookupUserWithLogin(context.Background(), acct.GetPublicId())
 	require.NoError(err)
 
-	s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn)
+	s, err := authmethods.NewService(ctx, kms, pwRepoFn, oidcRepoFn, iamRepoFn, atRepoFn, ldapRepoFn, sessionRepoFn)
 	require.NoError(err)
 	resp, err := s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.AuthenticateRequest{
 		AuthMethodId: am.GetPublicId(),
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -51,14 +52,15 @@ func init() {
 type Service struct {
 	pbs.UnsafeAuthTokenServiceServer
 
-	repoFn    common.AuthTokenRepoFactory
-	iamRepoFn common.IamRepoFactory
+	repoFn      common.AuthTokenRepoFactory
+	iamRepoFn   common.IamRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.AuthTokenServiceServer = (*Service)(nil)
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(ctx context.Context, repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "authtoken.NewService"
 	if repo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing auth token repository")
@@ -66,7 +68,7 @@ func NewService(ctx context.Context, repo common.AuthTokenRepoFactory, iamRepoFn
 	if iamRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo, iamRepoFn: iamRepoFn}, nil
+	return Service{repoFn: repo, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListAuthTokens implements the interface pbs.AuthTokenServiceServer.
@@ -98,55 +100,64 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 		return &pbs.ListAuthTokensResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListAuthTokensResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.AuthToken, 0, len(ul))
 	res := perms.Resource{
 		Type: resource.AuthToken,
 	}
-	for _, at := range ul {
-		res.Id = at.GetPublicId()
-		res.ScopeId = at.GetScopeId()
-		authorizedActions := authResults.FetchActionSetForId(ctx, at.GetPublicId(), IdActions, auth.WithResource(&res))
+	protos := make(map[string]*pb.AuthToken)
+	filterItemFn := func(ctx context.Context, item *authtoken.AuthToken) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res))
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
-		if authorizedActions.OnlySelf() && at.GetIamUserId() != authResults.UserId {
-			continue
+		if authorizedActions.OnlySelf() && item.GetIamUserId() != authResults.UserId {
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
 		outputOpts := make([]handlers.Option, 0, 3)
 		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
 		if outputFields.Has(globals.ScopeField) {
-			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[at.GetScopeId()]))
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
 		}
 		if outputFields.Has(globals.AuthorizedActionsField) {
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions.Strings()))
 		}
 
-		item, err := toProto(ctx, at, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*authtoken.AuthToken, error) {
+		return s.listFromRepo(ctx, scopeIds, authtoken.WithLimit(limit), authtoken.WithStartPageAfterItem(prevPageLastItem))
 	}
 
-	return &pbs.ListAuthTokensResponse{Items: finalItems}, nil
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.AuthToken, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.AuthToken, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListAuthTokensResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -266,13 +277,13 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*authtoken.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, opt ...authtoken.Option) ([]*authtoken.AuthToken, error) {
 	repo, err := s.repoFn()
 	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, scopeIds, authtoken.WithLimit(-1))
+	ul, err := repo.ListAuthTokens(ctx, scopeIds, opt...)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/boundary/globals"
@@ -18,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -112,14 +114,15 @@ func init() {
 type Service struct {
 	pbs.UnsafeCredentialLibraryServiceServer
 
-	iamRepoFn common.IamRepoFactory
-	repoFn    common.VaultCredentialRepoFactory
+	iamRepoFn   common.IamRepoFactory
+	repoFn      common.VaultCredentialRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.CredentialLibraryServiceServer = (*Service)(nil)
 
 // NewService returns a credential library service which handles credential library related requests to boundary.
-func NewService(ctx context.Context, repo common.VaultCredentialRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repo common.VaultCredentialRepoFactory, iamRepo common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "credentiallibraries.NewService"
 	if iamRepo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
@@ -127,7 +130,7 @@ func NewService(ctx context.Context, repo common.VaultCredentialRepoFactory, iam
 	if repo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing vault credential repository")
 	}
-	return Service{iamRepoFn: iamRepo, repoFn: repo}, nil
+	return Service{iamRepoFn: iamRepo, repoFn: repo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListCredentialLibraries implements the interface pbs.CredentialLibraryServiceServer
@@ -140,29 +143,21 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 		return nil, authResults.Error
 	}
 
-	csl, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
-	if err != nil {
-		return nil, err
-	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialLibrariesResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialLibrary, 0, len(csl))
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.CredentialLibrary,
 		Pin:     req.GetCredentialStoreId(),
 	}
-	for _, item := range csl {
+	protos := make(map[string]*pb.CredentialLibrary)
+	filterItemFn := func(ctx context.Context, item credential.Library) (bool, error) {
 		res.Id = item.GetPublicId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -175,20 +170,38 @@ func (s Service) ListCredentialLibraries(ctx context.Context, req *pbs.ListCrede
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]credential.Library, error) {
+		return s.listFromRepo(ctx, req.GetCredentialStoreId(), limit, prevPageLastItem)
 	}
-	return &pbs.ListCredentialLibrariesResponse{Items: finalItems}, nil
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.CredentialLibrary, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.CredentialLibrary, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListCredentialLibrariesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetCredentialLibrary implements the interface pbs.CredentialLibraryServiceServer.
@@ -346,17 +359,19 @@ func (s Service) DeleteCredentialLibrary(ctx context.Context, req *pbs.DeleteCre
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Library, error) {
+// listFromRepo returns at most limit credential libraries of any type which
+// are ordered after prevPageLastItem.
+func (s Service) listFromRepo(ctx context.Context, storeId string, limit int, prevPageLastItem pagination.Item) ([]credential.Library, error) {
 	const op = "credentiallibraries.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	genCsl, err := repo.ListCredentialLibraries(ctx, storeId)
+	genCsl, err := repo.ListCredentialLibraries(ctx, storeId, vault.WithLimit(limit), vault.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	certCsl, err := repo.ListSSHCertificateCredentialLibraries(ctx, storeId)
+	certCsl, err := repo.ListSSHCertificateCredentialLibraries(ctx, storeId, vault.WithLimit(limit), vault.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -368,6 +383,12 @@ func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential
 	for _, s := range certCsl {
 		csl = append(csl, s)
 	}
+	sort.Slice(csl, func(i, j int) bool {
+		return pagination.Less(csl[i], csl[j])
+	})
+	if limit > 0 && len(csl) > limit {
+		csl = csl[:limit]
+	}
 	return csl, nil
 }
 
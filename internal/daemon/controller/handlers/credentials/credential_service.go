@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -95,14 +96,15 @@ func init() {
 type Service struct {
 	pbs.UnsafeCredentialServiceServer
 
-	iamRepoFn common.IamRepoFactory
-	repoFn    common.StaticCredentialRepoFactory
+	iamRepoFn   common.IamRepoFactory
+	repoFn      common.StaticCredentialRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.CredentialServiceServer = (*Service)(nil)
 
 // NewService returns a credential service which handles credential related requests to boundary.
-func NewService(ctx context.Context, repo common.StaticCredentialRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repo common.StaticCredentialRepoFactory, iamRepo common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "credentials.NewService"
 	if iamRepo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
@@ -110,7 +112,7 @@ func NewService(ctx context.Context, repo common.StaticCredentialRepoFactory, ia
 	if repo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static credential repository")
 	}
-	return Service{iamRepoFn: iamRepo, repoFn: repo}, nil
+	return Service{iamRepoFn: iamRepo, repoFn: repo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListCredentials implements the interface pbs.CredentialServiceServer
@@ -123,29 +125,21 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 		return nil, authResults.Error
 	}
 
-	creds, err := s.listFromRepo(ctx, req.GetCredentialStoreId())
-	if err != nil {
-		return nil, err
-	}
-	if len(creds) == 0 {
-		return &pbs.ListCredentialsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Credential, 0, len(creds))
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.Credential,
 		Pin:     req.GetCredentialStoreId(),
 	}
-	for _, item := range creds {
+	protos := make(map[string]*pb.Credential)
+	filterItemFn := func(ctx context.Context, item credential.Static) (bool, error) {
 		res.Id = item.GetPublicId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -158,20 +152,38 @@ func (s Service) ListCredentials(ctx context.Context, req *pbs.ListCredentialsRe
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(item, outputOpts...)
+		pbItem, err := toProto(item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]credential.Static, error) {
+		return s.listFromRepo(ctx, req.GetCredentialStoreId(), static.WithLimit(limit), static.WithStartPageAfterItem(prevPageLastItem))
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Credential, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Credential, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListCredentialsResponse{Items: finalItems}, nil
+	return &pbs.ListCredentialsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetCredential implements the interface pbs.CredentialServiceServer.
@@ -313,13 +325,13 @@ func (s Service) DeleteCredential(ctx context.Context, req *pbs.DeleteCredential
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, storeId string) ([]credential.Static, error) {
+func (s Service) listFromRepo(ctx context.Context, storeId string, opt ...static.Option) ([]credential.Static, error) {
 	const op = "credentials.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	up, err := repo.ListCredentials(ctx, storeId, static.WithLimit(-1))
+	up, err := repo.ListCredentials(ctx, storeId, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -22,6 +23,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentials"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -98,6 +100,7 @@ type Service struct {
 	iamRepoFn    common.IamRepoFactory
 	vaultRepoFn  common.VaultCredentialRepoFactory
 	staticRepoFn common.StaticCredentialRepoFactory
+	maxPageSize  uint
 }
 
 var _ pbs.CredentialStoreServiceServer = (*Service)(nil)
@@ -108,6 +111,7 @@ func NewService(
 	vaultRepo common.VaultCredentialRepoFactory,
 	staticRepo common.StaticCredentialRepoFactory,
 	iamRepo common.IamRepoFactory,
+	opt ...handlers.Option,
 ) (Service, error) {
 	const op = "credentialstores.NewService"
 	if iamRepo == nil {
@@ -119,7 +123,7 @@ func NewService(
 	if staticRepo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static credential repository")
 	}
-	return Service{iamRepoFn: iamRepo, vaultRepoFn: vaultRepo, staticRepoFn: staticRepo}, nil
+	return Service{iamRepoFn: iamRepo, vaultRepoFn: vaultRepo, staticRepoFn: staticRepo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListCredentialStores implements the interface pbs.CredentialStoreServiceServer
@@ -151,28 +155,20 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		return &pbs.ListCredentialStoresResponse{}, nil
 	}
 
-	csl, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(csl) == 0 {
-		return &pbs.ListCredentialStoresResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.CredentialStore, 0, len(csl))
 	res := perms.Resource{
 		Type: resource.CredentialStore,
 	}
-	for _, item := range csl {
+	protos := make(map[string]*pb.CredentialStore)
+	filterItemFn := func(ctx context.Context, item credential.Store) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -187,25 +183,43 @@ func (s Service) ListCredentialStores(ctx context.Context, req *pbs.ListCredenti
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := calculateAuthorizedCollectionActions(ctx, authResults, item.GetPublicId())
 			if err != nil {
-				return nil, err
+				return false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(filterable) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]credential.Store, error) {
+		return s.listFromRepo(ctx, scopeIds, limit, prevPageLastItem)
 	}
-	return &pbs.ListCredentialStoresResponse{Items: finalItems}, nil
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.CredentialStore, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.CredentialStore, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListCredentialStoresResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetCredentialStore implements the interface pbs.CredentialStoreServiceServer.
@@ -362,14 +376,16 @@ func (s Service) DeleteCredentialStore(ctx context.Context, req *pbs.DeleteCrede
 	return nil, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credential.Store, error) {
+// listFromRepo returns at most limit credential stores from the vault and
+// static repositories which are ordered after prevPageLastItem.
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, limit int, prevPageLastItem pagination.Item) ([]credential.Store, error) {
 	const op = "credentialstores.(Service).listFromRepo"
 
 	vaultRepo, err := s.vaultRepoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	vaultCsl, err := vaultRepo.ListCredentialStores(ctx, scopeIds, vault.WithLimit(-1))
+	vaultCsl, err := vaultRepo.ListCredentialStores(ctx, scopeIds, vault.WithLimit(limit), vault.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -378,7 +394,7 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credent
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	staticCsl, err := staticRepo.ListCredentialStores(ctx, scopeIds, static.WithLimit(-1))
+	staticCsl, err := staticRepo.ListCredentialStores(ctx, scopeIds, static.WithLimit(limit), static.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -390,6 +406,12 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]credent
 	for _, s := range staticCsl {
 		csl = append(csl, s)
 	}
+	sort.Slice(csl, func(i, j int) bool {
+		return pagination.Less(csl[i], csl[j])
+	})
+	if limit > 0 && len(csl) > limit {
+		csl = csl[:limit]
+	}
 
 	return csl, nil
 }
@@ -16,6 +16,7 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -68,18 +69,19 @@ func init() {
 type Service struct {
 	pbs.UnsafeGroupServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn      common.IamRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.GroupServiceServer = (*Service)(nil)
 
 // NewService returns a group service which handles group related requests to boundary.
-func NewService(ctx context.Context, repo common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repo common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "groups.NewService"
 	if repo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	return Service{repoFn: repo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListGroups implements the interface pbs.GroupServiceServer.
@@ -111,28 +113,20 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		return &pbs.ListGroupsResponse{}, nil
 	}
 
-	gl, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(gl) == 0 {
-		return &pbs.ListGroupsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Group, 0, len(gl))
 	res := perms.Resource{
 		Type: resource.Group,
 	}
-	for _, item := range gl {
+	protos := make(map[string]*pb.Group)
+	filterItemFn := func(ctx context.Context, item *iam.Group) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -145,16 +139,34 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*iam.Group, error) {
+		return s.listFromRepo(ctx, scopeIds, iam.WithLimit(limit), iam.WithStartPageAfterItem(prevPageLastItem))
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Group, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Group, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListGroupsResponse{Items: finalItems}, nil
+	return &pbs.ListGroupsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -493,13 +505,13 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Group, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, opt ...iam.Option) ([]*iam.Group, error) {
 	const op = "groups.(Service).listFromRepo"
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
-	gl, err := repo.ListGroups(ctx, scopeIds, iam.WithLimit(-1))
+	gl, err := repo.ListGroups(ctx, scopeIds, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -364,6 +364,56 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Pagination(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	o, _ := iam.TestScopes(t, iamRepo)
+	var wantIds []string
+	for i := 0; i < 10; i++ {
+		g := iam.TestGroup(t, conn, o.GetPublicId())
+		wantIds = append(wantIds, g.GetPublicId())
+	}
+
+	s, err := groups.NewService(ctx, repoFn, handlers.WithMaxPageSize(4))
+	require.NoError(t, err)
+	reqCtx := auth.DisabledAuthTestContext(repoFn, o.GetPublicId())
+
+	t.Run("pages", func(t *testing.T) {
+		var gotIds []string
+		req := &pbs.ListGroupsRequest{ScopeId: o.GetPublicId(), PageSize: 3}
+		for i := 0; ; i++ {
+			require.Less(t, i, len(wantIds), "too many pages")
+			got, err := s.ListGroups(reqCtx, req)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(got.GetItems()), 3)
+			for _, item := range got.GetItems() {
+				gotIds = append(gotIds, item.GetId())
+			}
+			if got.GetNextPageToken() == "" {
+				break
+			}
+			req.PageToken = got.GetNextPageToken()
+		}
+		assert.Equal(t, wantIds, gotIds)
+	})
+	t.Run("max page size", func(t *testing.T) {
+		got, err := s.ListGroups(reqCtx, &pbs.ListGroupsRequest{ScopeId: o.GetPublicId(), PageSize: 100})
+		require.NoError(t, err)
+		assert.Len(t, got.GetItems(), 4)
+		assert.NotEmpty(t, got.GetNextPageToken())
+	})
+	t.Run("invalid page token", func(t *testing.T) {
+		_, err := s.ListGroups(reqCtx, &pbs.ListGroupsRequest{ScopeId: o.GetPublicId(), PageToken: "invalid"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.InvalidArgumentErrorf("Invalid page token.", nil)))
+	})
+}
+
 func TestDelete(t *testing.T) {
 	og, pg, repoFn := createDefaultGroupsAndRepo(t)
 
@@ -6,6 +6,7 @@ package host_catalogs
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
@@ -21,6 +22,7 @@ import (
 	pluginstore "github.com/hashicorp/boundary/internal/host/plugin/store"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/plugin"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -101,13 +103,14 @@ type Service struct {
 	pluginHostRepoFn common.PluginHostRepoFactory
 	pluginRepoFn     common.PluginRepoFactory
 	iamRepoFn        common.IamRepoFactory
+	maxPageSize      uint
 }
 
 var _ pbs.HostCatalogServiceServer = (*Service)(nil)
 
 // NewService returns a host catalog Service which handles host catalog related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginHostRepoFn common.PluginHostRepoFactory, hostPluginRepoFn common.PluginRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginHostRepoFn common.PluginHostRepoFactory, hostPluginRepoFn common.PluginRepoFactory, iamRepoFn common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "host_catalogs.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static repository")
@@ -121,7 +124,7 @@ func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginHost
 	if iamRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{staticRepoFn: repoFn, pluginHostRepoFn: pluginHostRepoFn, pluginRepoFn: hostPluginRepoFn, iamRepoFn: iamRepoFn}, nil
+	return Service{staticRepoFn: repoFn, pluginHostRepoFn: pluginHostRepoFn, pluginRepoFn: hostPluginRepoFn, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogsRequest) (*pbs.ListHostCatalogsResponse, error) {
@@ -152,28 +155,21 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		return &pbs.ListHostCatalogsResponse{}, nil
 	}
 
-	items, pluginInfoMap, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(items) == 0 {
-		return &pbs.ListHostCatalogsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.HostCatalog, 0, len(items))
 	res := perms.Resource{
 		Type: resource.HostCatalog,
 	}
-	for _, item := range items {
+	protos := make(map[string]*pb.HostCatalog)
+	pluginInfoMap := make(map[string]*plugins.PluginInfo)
+	filterItemFn := func(ctx context.Context, item host.Catalog) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -196,7 +192,7 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 			if subtype != "" {
 				collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, collectionTypeMap[subtype], authResults.Scope.Id, item.GetPublicId())
 				if err != nil {
-					return nil, err
+					return false, err
 				}
 				outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 			}
@@ -208,22 +204,47 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 			}
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
+		if err != nil {
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]host.Catalog, error) {
+		items, plgs, err := s.listFromRepo(ctx, scopeIds, limit, prevPageLastItem)
 		if err != nil {
 			return nil, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		for id, plg := range plgs {
+			pluginInfoMap[id] = plg
 		}
+		return items, nil
 	}
-	return &pbs.ListHostCatalogsResponse{Items: finalItems}, nil
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.HostCatalog, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.HostCatalog, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListHostCatalogsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHostCatalog implements the interface pbs.HostCatalogServiceServer.
@@ -450,12 +471,15 @@ func (s Service) getFromRepo(ctx context.Context, id string) (host.Catalog, *plu
 	return cat, plg, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, projectIds []string) ([]host.Catalog, map[string]*plugins.PluginInfo, error) {
+// listFromRepo returns at most limit static and plugin host catalogs which are
+// ordered after prevPageLastItem, along with the plugins of the returned plugin
+// host catalogs.
+func (s Service) listFromRepo(ctx context.Context, projectIds []string, limit int, prevPageLastItem pagination.Item) ([]host.Catalog, map[string]*plugins.PluginInfo, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, nil, err
 	}
-	ul, err := repo.ListCatalogs(ctx, projectIds, static.WithLimit(-1))
+	ul, err := repo.ListCatalogs(ctx, projectIds, static.WithLimit(limit), static.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -467,13 +491,19 @@ func (s Service) listFromRepo(ctx context.Context, projectIds []string) ([]host.
 	if err != nil {
 		return nil, nil, err
 	}
-	pl, plgs, err := pluginRepo.ListCatalogs(ctx, projectIds, host.WithLimit(-1))
+	pl, plgs, err := pluginRepo.ListCatalogs(ctx, projectIds, host.WithLimit(limit), host.WithStartPageAfterItem(prevPageLastItem))
 	if err != nil {
 		return nil, nil, err
 	}
 	for _, c := range pl {
 		res = append(res, c)
 	}
+	sort.Slice(res, func(i, j int) bool {
+		return pagination.Less(res[i], res[j])
+	})
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
 	pluginsMap := make(map[string]*plugins.PluginInfo, len(plgs))
 	for _, plg := range plgs {
 		pluginsMap[plg.GetPublicId()] = toPluginInfo(plg)
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/libs/endpoint"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/plugin"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -94,13 +95,14 @@ type Service struct {
 
 	staticRepoFn common.StaticRepoFactory
 	pluginRepoFn common.PluginHostRepoFactory
+	maxPageSize  uint
 }
 
 var _ pbs.HostSetServiceServer = (*Service)(nil)
 
 // NewService returns a host set Service which handles host set related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(ctx context.Context, staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
+func NewService(ctx context.Context, staticRepoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "host_sets.NewService"
 	if staticRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static repository")
@@ -108,7 +110,7 @@ func NewService(ctx context.Context, staticRepoFn common.StaticRepoFactory, plug
 	if pluginRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing hostplugin repository")
 	}
-	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn}, nil
+	return Service{staticRepoFn: staticRepoFn, pluginRepoFn: pluginRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest) (*pbs.ListHostSetsResponse, error) {
@@ -123,31 +125,24 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), opt...)
-	if err != nil {
-		return nil, err
-	}
-	if len(hl) == 0 {
-		return &pbs.ListHostSetsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.HostSet, 0, len(hl))
 
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.HostSet,
 		Pin:     req.GetHostCatalogId(),
 	}
-	for _, item := range hl {
+	protos := make(map[string]*pb.HostSet)
+	var plg *plugins.PluginInfo
+	filterItemFn := func(ctx context.Context, item host.Set) (bool, error) {
 		res.Id = item.GetPublicId()
 		idActions := idActionsTypeMap[globals.ResourceInfoFromPrefix(res.Id).Subtype]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), idActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -163,22 +158,47 @@ func (s Service) ListHostSetsWithOptions(ctx context.Context, req *pbs.ListHostS
 			outputOpts = append(outputOpts, handlers.WithPlugin(plg))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
+		if err != nil {
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]host.Set, error) {
+		hl, hsPlg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), limit, prevPageLastItem, opt...)
 		if err != nil {
 			return nil, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if hsPlg != nil {
+			plg = hsPlg
 		}
+		return hl, nil
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.HostSet, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.HostSet, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListHostSetsResponse{Items: finalItems}, nil
+	return &pbs.ListHostSetsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHostSet implements the interface pbs.HostSetServiceServer.
@@ -635,7 +655,7 @@ func (s Service) deleteFromRepo(ctx context.Context, projectId, id string) (bool
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string, opt ...host.Option) ([]host.Set, *plugins.PluginInfo, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, limit int, prevPageLastItem pagination.Item, opt ...host.Option) ([]host.Set, *plugins.PluginInfo, error) {
 	const op = "host_sets.(Service).listFromRepo"
 	var plg *plugins.PluginInfo
 	var sets []host.Set
@@ -645,7 +665,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string, opt ...host
 		if err != nil {
 			return nil, nil, err
 		}
-		sl, err := repo.ListSets(ctx, catalogId, static.WithLimit(-1))
+		sl, err := repo.ListSets(ctx, catalogId, static.WithLimit(limit), static.WithStartPageAfterItem(prevPageLastItem))
 		if err != nil {
 			return nil, nil, errors.Wrap(ctx, err, op)
 		}
@@ -657,7 +677,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string, opt ...host
 		if err != nil {
 			return nil, nil, err
 		}
-		opt = append(opt, host.WithLimit(-1))
+		opt = append(opt, host.WithLimit(limit), host.WithStartPageAfterItem(prevPageLastItem))
 		sl, hsplg, err := repo.ListSets(ctx, catalogId, opt...)
 		if err != nil {
 			return nil, nil, errors.Wrap(ctx, err, op)
@@ -19,6 +19,7 @@ import (
 	hostplugin "github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	plugin "github.com/hashicorp/boundary/internal/plugin"
 	"github.com/hashicorp/boundary/internal/requests"
@@ -79,13 +80,14 @@ type Service struct {
 
 	staticRepoFn common.StaticRepoFactory
 	pluginRepoFn common.PluginHostRepoFactory
+	maxPageSize  uint
 }
 
 var _ pbs.HostServiceServer = (*Service)(nil)
 
 // NewService returns a host Service which handles host related requests to boundary and uses the provided
 // repositories for storage and retrieval.
-func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginRepoFn common.PluginHostRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "hosts.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static repository")
@@ -93,7 +95,7 @@ func NewService(ctx context.Context, repoFn common.StaticRepoFactory, pluginRepo
 	if pluginRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing plugin host repository")
 	}
-	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn}, nil
+	return Service{staticRepoFn: repoFn, pluginRepoFn: pluginRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs.ListHostsResponse, error) {
@@ -104,31 +106,24 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, plg, err := s.listFromRepo(ctx, req.GetHostCatalogId())
-	if err != nil {
-		return nil, err
-	}
-	if len(hl) == 0 {
-		return &pbs.ListHostsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Host, 0, len(hl))
 
 	res := perms.Resource{
 		ScopeId: authResults.Scope.Id,
 		Type:    resource.Host,
 		Pin:     req.GetHostCatalogId(),
 	}
-	for _, item := range hl {
+	protos := make(map[string]*pb.Host)
+	var plg *plugins.PluginInfo
+	filterItemFn := func(ctx context.Context, item host.Host) (bool, error) {
 		res.Id = item.GetPublicId()
 		idActions := idActionsTypeMap[globals.ResourceInfoFromPrefix(res.Id).Subtype]
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), idActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -144,22 +139,47 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 		outputOpts = append(outputOpts, handlers.WithHostSetIds(item.GetSetIds()))
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
 		// This comes last so that we can use item fields in the filter after
 		// the allowed fields are populated above
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
+		if err != nil {
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]host.Host, error) {
+		hl, hlPlg, err := s.listFromRepo(ctx, req.GetHostCatalogId(), limit, prevPageLastItem)
 		if err != nil {
 			return nil, err
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		if hlPlg != nil {
+			plg = hlPlg
 		}
+		return hl, nil
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Host, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Host, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListHostsResponse{Items: finalItems}, nil
+	return &pbs.ListHostsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -417,7 +437,7 @@ func (s Service) deleteFromRepo(ctx context.Context, projectId, id string) (bool
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Host, *plugins.PluginInfo, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, limit int, prevPageLastItem pagination.Item) ([]host.Host, *plugins.PluginInfo, error) {
 	var hosts []host.Host
 	var plg *plugins.PluginInfo
 	switch globals.ResourceInfoFromPrefix(catalogId).Subtype {
@@ -426,7 +446,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, err := repo.ListHosts(ctx, catalogId, static.WithLimit(-1))
+		hl, err := repo.ListHosts(ctx, catalogId, static.WithLimit(limit), static.WithStartPageAfterItem(prevPageLastItem))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -438,7 +458,7 @@ func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]host.Hos
 		if err != nil {
 			return nil, nil, err
 		}
-		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId)
+		hl, hlPlg, err := repo.ListHostsByCatalogId(ctx, catalogId, hostplugin.WithLimit(limit), hostplugin.WithStartPageAfterItem(prevPageLastItem))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -87,4 +87,13 @@ func Test_GetOpts(t *testing.T) {
 		opts = GetOpts(WithHostSetIds(out))
 		require.Equal(out, opts.WithHostSetIds)
 	})
+	t.Run("WithMaxPageSize", func(t *testing.T) {
+		assert := assert.New(t)
+
+		opts := GetOpts()
+		assert.Zero(opts.WithMaxPageSize)
+
+		opts = GetOpts(WithMaxPageSize(25))
+		assert.Equal(uint(25), opts.WithMaxPageSize)
+	})
 }
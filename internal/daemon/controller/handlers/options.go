@@ -36,6 +36,7 @@ type options struct {
 	WithManagedGroupIds             []string
 	WithMemberIds                   []string
 	WithHostSetIds                  []string
+	WithMaxPageSize                 uint
 }
 
 func getDefaultOptions() options {
@@ -122,3 +123,11 @@ func WithHostSetIds(ids []string) Option {
 		o.WithHostSetIds = ids
 	}
 }
+
+// WithMaxPageSize provides an option for the services to set the maximum
+// number of items returned in a single page of a list request
+func WithMaxPageSize(size uint) Option {
+	return func(o *options) {
+		o.WithMaxPageSize = size
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// PaginatedListRequest is implemented by every List request that supports
+// pagination.
+type PaginatedListRequest interface {
+	GetPageToken() string
+	GetPageSize() uint32
+}
+
+// PageSize returns the number of items to return in a page. The requested
+// size is used unless it is zero or larger than maxPageSize. A maxPageSize of
+// zero means pagination.DefaultMaxPageSize.
+func PageSize(requested uint32, maxPageSize uint) int {
+	if maxPageSize == 0 {
+		maxPageSize = pagination.DefaultMaxPageSize
+	}
+	if requested == 0 || uint(requested) > maxPageSize {
+		return int(maxPageSize)
+	}
+	return int(requested)
+}
+
+// PaginatedList returns a page of items for the request. If the request has
+// no page token, the first page is returned; otherwise the page token is
+// validated against the resource type and grants hash and the following page
+// is returned.
+func PaginatedList[T pagination.Item](
+	ctx context.Context,
+	req PaginatedListRequest,
+	maxPageSize uint,
+	resourceType resource.Type,
+	grantsHash []byte,
+	filterItemFn pagination.ListFilterFunc[T],
+	listItemsFn pagination.ListItemsFunc[T],
+) (*pagination.ListResponse[T], error) {
+	const op = "handlers.PaginatedList"
+	pageSize := PageSize(req.GetPageSize(), maxPageSize)
+	if req.GetPageToken() == "" {
+		resp, err := pagination.List(ctx, resourceType, grantsHash, pageSize, filterItemFn, listItemsFn)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return resp, nil
+	}
+	tok, err := parsePageToken(ctx, req.GetPageToken(), resourceType, grantsHash)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := pagination.ListPage(ctx, tok, pageSize, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return resp, nil
+}
+
+// RefreshableListRequest is implemented by every List request that supports
+// refresh tokens in addition to pagination.
+type RefreshableListRequest interface {
+	PaginatedListRequest
+	GetRefreshToken() string
+}
+
+// PaginatedRefreshableList returns a page of items for the request like
+// PaginatedList. If the request has a refresh token, only items updated since
+// the token was issued are listed, and the first page of the response also
+// contains the ids of items deleted since.
+func PaginatedRefreshableList[T pagination.Item](
+	ctx context.Context,
+	req RefreshableListRequest,
+	maxPageSize uint,
+	resourceType resource.Type,
+	grantsHash []byte,
+	filterItemFn pagination.ListFilterFunc[T],
+	listRefreshItemsFn pagination.ListRefreshItemsFunc[T],
+	listDeletedIdsFn pagination.ListDeletedIdsFunc,
+) (*pagination.ListResponse[T], error) {
+	const op = "handlers.PaginatedRefreshableList"
+	pageSize := PageSize(req.GetPageSize(), maxPageSize)
+	switch {
+	case req.GetPageToken() != "" && req.GetRefreshToken() != "":
+		return nil, InvalidArgumentErrorf("Invalid request.", map[string]string{"refresh_token": "Cannot be set together with a page token."})
+	case req.GetRefreshToken() != "":
+		rt, err := pagination.ParseRefreshToken(ctx, req.GetRefreshToken())
+		if err != nil {
+			return nil, InvalidArgumentErrorf("Invalid refresh token.", map[string]string{"refresh_token": "The refresh token could not be decoded."})
+		}
+		if err := rt.Validate(ctx, resourceType, grantsHash); err != nil {
+			return nil, InvalidArgumentErrorf("Invalid refresh token.", map[string]string{"refresh_token": tokenErrorMsg(err, "The refresh token is invalid.")})
+		}
+		resp, err := pagination.ListRefresh(ctx, rt, pageSize, filterItemFn, listRefreshItemsFn, listDeletedIdsFn)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return resp, nil
+	case req.GetPageToken() != "":
+		tok, err := parsePageToken(ctx, req.GetPageToken(), resourceType, grantsHash)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := pagination.ListPage(ctx, tok, pageSize, filterItemFn, pagination.RefreshItemsFunc(tok, listRefreshItemsFn))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return resp, nil
+	default:
+		resp, err := pagination.List(ctx, resourceType, grantsHash, pageSize, filterItemFn, pagination.RefreshItemsFunc(nil, listRefreshItemsFn))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		return resp, nil
+	}
+}
+
+// parsePageToken decodes the page token and validates it against the
+// resource type and grants hash, returning an invalid argument error if it
+// cannot be used.
+func parsePageToken(ctx context.Context, pageToken string, resourceType resource.Type, grantsHash []byte) (*pagination.Token, error) {
+	tok, err := pagination.ParseToken(ctx, pageToken)
+	if err != nil {
+		return nil, InvalidArgumentErrorf("Invalid page token.", map[string]string{"page_token": "The page token could not be decoded."})
+	}
+	if err := tok.Validate(ctx, resourceType, grantsHash); err != nil {
+		return nil, InvalidArgumentErrorf("Invalid page token.", map[string]string{"page_token": tokenErrorMsg(err, "The page token is invalid.")})
+	}
+	return tok, nil
+}
+
+// tokenErrorMsg returns the message of a token validation error, or the
+// provided default if the error is not a domain error.
+func tokenErrorMsg(err error, defaultMsg string) string {
+	var domainErr *errors.Err
+	if errors.As(err, &domainErr) {
+		return domainErr.Msg
+	}
+	return defaultMsg
+}
+
+// NextPageToken returns the encoded token for the page following the provided
+// list response, or an empty string when the listing is complete.
+func NextPageToken[T pagination.Item](ctx context.Context, resp *pagination.ListResponse[T]) (string, error) {
+	const op = "handlers.NextPageToken"
+	if resp.CompleteListing || resp.PageToken == nil {
+		return "", nil
+	}
+	tok, err := resp.PageToken.Marshal(ctx)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return tok, nil
+}
+
+// RefreshToken returns the encoded refresh token of the provided list
+// response, or an empty string when the listing is not yet complete.
+func RefreshToken[T pagination.Item](ctx context.Context, resp *pagination.ListResponse[T]) (string, error) {
+	const op = "handlers.RefreshToken"
+	if !resp.CompleteListing || resp.RefreshToken == nil {
+		return "", nil
+	}
+	tok, err := resp.RefreshToken.Marshal(ctx)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return tok, nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageSize(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, pagination.DefaultMaxPageSize, PageSize(0, 0))
+	assert.Equal(t, 10, PageSize(10, 0))
+	assert.Equal(t, 20, PageSize(0, 20))
+	assert.Equal(t, 20, PageSize(30, 20))
+	assert.Equal(t, 5, PageSize(5, 20))
+}
+
+type testListRequest struct {
+	pageToken string
+	pageSize  uint32
+}
+
+func (r testListRequest) GetPageToken() string { return r.pageToken }
+func (r testListRequest) GetPageSize() uint32  { return r.pageSize }
+
+type testItem struct {
+	id string
+	ct *timestamp.Timestamp
+}
+
+func (i *testItem) GetPublicId() string                 { return i.id }
+func (i *testItem) GetCreateTime() *timestamp.Timestamp { return i.ct }
+func (i *testItem) GetUpdateTime() *timestamp.Timestamp { return i.ct }
+
+func TestPaginatedList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := pagination.GrantsHash([]string{"id=*;type=*;actions=*"})
+	items := []*testItem{
+		{id: "ttst_1", ct: timestamp.Now()},
+		{id: "ttst_2", ct: timestamp.Now()},
+		{id: "ttst_3", ct: timestamp.Now()},
+	}
+	filterFn := func(context.Context, *testItem) (bool, error) { return true, nil }
+	listFn := func(_ context.Context, last pagination.Item, limit int) ([]*testItem, error) {
+		var out []*testItem
+		for _, i := range items {
+			if last != nil && !pagination.Less(last, i) {
+				continue
+			}
+			out = append(out, i)
+			if len(out) == limit {
+				break
+			}
+		}
+		return out, nil
+	}
+
+	resp, err := PaginatedList(ctx, testListRequest{pageSize: 2}, 0, resource.Target, grantsHash, filterFn, listFn)
+	require.NoError(t, err)
+	assert.Equal(t, items[:2], resp.Items)
+	next, err := NextPageToken(ctx, resp)
+	require.NoError(t, err)
+	require.NotEmpty(t, next)
+
+	resp, err = PaginatedList(ctx, testListRequest{pageToken: next, pageSize: 2}, 0, resource.Target, grantsHash, filterFn, listFn)
+	require.NoError(t, err)
+	assert.Equal(t, items[2:], resp.Items)
+	next, err = NextPageToken(ctx, resp)
+	require.NoError(t, err)
+	assert.Empty(t, next)
+
+	t.Run("invalid-token", func(t *testing.T) {
+		_, err := PaginatedList(ctx, testListRequest{pageToken: "0OIl"}, 0, resource.Target, grantsHash, filterFn, listFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid page token.")
+	})
+	t.Run("wrong-resource-type", func(t *testing.T) {
+		resp, err := PaginatedList(ctx, testListRequest{pageSize: 1}, 0, resource.Target, grantsHash, filterFn, listFn)
+		require.NoError(t, err)
+		tok, err := NextPageToken(ctx, resp)
+		require.NoError(t, err)
+		_, err = PaginatedList(ctx, testListRequest{pageToken: tok}, 0, resource.Host, grantsHash, filterFn, listFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid page token.")
+	})
+	t.Run("changed-grants", func(t *testing.T) {
+		resp, err := PaginatedList(ctx, testListRequest{pageSize: 1}, 0, resource.Target, grantsHash, filterFn, listFn)
+		require.NoError(t, err)
+		tok, err := NextPageToken(ctx, resp)
+		require.NoError(t, err)
+		_, err = PaginatedList(ctx, testListRequest{pageToken: tok}, 0, resource.Target, pagination.GrantsHash(nil), filterFn, listFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid page token.")
+	})
+}
+
+type testRefreshableListRequest struct {
+	testListRequest
+	refreshToken string
+}
+
+func (r testRefreshableListRequest) GetRefreshToken() string { return r.refreshToken }
+
+func TestPaginatedRefreshableList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := pagination.GrantsHash([]string{"id=*;type=*;actions=*"})
+	items := []*testItem{
+		{id: "ttst_1", ct: timestamp.Now()},
+		{id: "ttst_2", ct: timestamp.Now()},
+	}
+	filterFn := func(context.Context, *testItem) (bool, error) { return true, nil }
+	listFn := func(_ context.Context, updatedAfter time.Time, last pagination.Item, limit int) ([]*testItem, error) {
+		var out []*testItem
+		for _, i := range items {
+			if last != nil && !pagination.Less(last, i) {
+				continue
+			}
+			if !updatedAfter.IsZero() && !i.GetUpdateTime().AsTime().After(updatedAfter) {
+				continue
+			}
+			out = append(out, i)
+			if len(out) == limit {
+				break
+			}
+		}
+		return out, nil
+	}
+	deletedFn := func(context.Context, time.Time) ([]string, error) { return []string{"ttst_0"}, nil }
+
+	resp, err := PaginatedRefreshableList(ctx, testRefreshableListRequest{}, 0, resource.Target, grantsHash, filterFn, listFn, deletedFn)
+	require.NoError(t, err)
+	assert.Equal(t, items, resp.Items)
+	assert.Empty(t, resp.RemovedIds)
+	next, err := NextPageToken(ctx, resp)
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	refresh, err := RefreshToken(ctx, resp)
+	require.NoError(t, err)
+	require.NotEmpty(t, refresh)
+
+	resp, err = PaginatedRefreshableList(ctx, testRefreshableListRequest{refreshToken: refresh}, 0, resource.Target, grantsHash, filterFn, listFn, deletedFn)
+	require.NoError(t, err)
+	// The items were created within the refresh lookback window so they
+	// are returned again.
+	assert.Equal(t, items, resp.Items)
+	assert.Equal(t, []string{"ttst_0"}, resp.RemovedIds)
+
+	t.Run("both-tokens", func(t *testing.T) {
+		req := testRefreshableListRequest{testListRequest: testListRequest{pageToken: "a"}, refreshToken: refresh}
+		_, err := PaginatedRefreshableList(ctx, req, 0, resource.Target, grantsHash, filterFn, listFn, deletedFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot be set together with a page token.")
+	})
+	t.Run("invalid-refresh-token", func(t *testing.T) {
+		_, err := PaginatedRefreshableList(ctx, testRefreshableListRequest{refreshToken: "0OIl"}, 0, resource.Target, grantsHash, filterFn, listFn, deletedFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid refresh token.")
+	})
+	t.Run("changed-grants", func(t *testing.T) {
+		_, err := PaginatedRefreshableList(ctx, testRefreshableListRequest{refreshToken: refresh}, 0, resource.Target, pagination.GrantsHash(nil), filterFn, listFn, deletedFn)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid refresh token.")
+	})
+}
@@ -0,0 +1,609 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package policies
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	spolicy "github.com/hashicorp/boundary/internal/policy/storage"
+	"github.com/hashicorp/boundary/internal/policy/storage/store"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/types/subtypes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/policies"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	retainForDaysField   = "attributes.retain_for_days"
+	deleteAfterDaysField = "attributes.delete_after_days"
+)
+
+var (
+	maskManager handlers.MaskManager
+
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.NewActionSet(
+		action.NoOp,
+		action.Read,
+		action.Update,
+		action.Delete,
+	)
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.NewActionSet(
+		action.Create,
+		action.List,
+	)
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&store.Policy{}},
+		handlers.MaskSource{&pb.Policy{}, &pb.StoragePolicyAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	action.RegisterResource(resource.Policy, IdActions, CollectionActions)
+}
+
+// Service handles request as described by the pbs.PolicyServiceServer interface.
+type Service struct {
+	pbs.UnsafePolicyServiceServer
+
+	repoFn      common.StoragePolicyRepoFactory
+	iamRepoFn   common.IamRepoFactory
+	maxPageSize uint
+}
+
+var _ pbs.PolicyServiceServer = (*Service)(nil)
+
+// NewService returns a policy service which handles policy related requests to boundary.
+func NewService(
+	ctx context.Context,
+	repoFn common.StoragePolicyRepoFactory,
+	iamRepoFn common.IamRepoFactory,
+	opt ...handlers.Option,
+) (Service, error) {
+	const op = "policies.NewService"
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing storage policy repository")
+	}
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
+}
+
+// ListPolicies implements the interface pbs.PolicyServiceServer
+func (s Service) ListPolicies(ctx context.Context, req *pbs.ListPoliciesRequest) (*pbs.ListPoliciesResponse, error) {
+	if err := validateListRequest(ctx, req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.Policy, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListPoliciesResponse{}, nil
+	}
+
+	filter, err := handlers.NewFilter(ctx, req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	res := perms.Resource{
+		Type: resource.Policy,
+	}
+	protos := make(map[string]*pb.Policy)
+	filterItemFn := func(ctx context.Context, item *spolicy.Policy) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.GetScopeId()
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.GetScopeId()]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return false, err
+		}
+
+		filterable, err := subtypes.Filterable(ctx, pbItem)
+		if err != nil {
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*spolicy.Policy, error) {
+		return s.listFromRepo(ctx, scopeIds, limit, prevPageLastItem)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Policy, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Policy, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListPoliciesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// GetPolicy implements the interface pbs.PolicyServiceServer.
+func (s Service) GetPolicy(ctx context.Context, req *pbs.GetPolicyRequest) (*pbs.GetPolicyResponse, error) {
+	const op = "policies.(Service).GetPolicy"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetPolicyResponse{Item: item}, nil
+}
+
+// CreatePolicy implements the interface pbs.PolicyServiceServer.
+func (s Service) CreatePolicy(ctx context.Context, req *pbs.CreatePolicyRequest) (*pbs.CreatePolicyResponse, error) {
+	const op = "policies.(Service).CreatePolicy"
+
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.CreatePolicyResponse{
+		Item: item,
+		Uri:  fmt.Sprintf("policies/%s", item.GetId()),
+	}, nil
+}
+
+// UpdatePolicy implements the interface pbs.PolicyServiceServer.
+func (s Service) UpdatePolicy(ctx context.Context, req *pbs.UpdatePolicyRequest) (*pbs.UpdatePolicyResponse, error) {
+	const op = "policies.(Service).UpdatePolicy"
+
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	p, err := s.updateInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, p.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, p, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.UpdatePolicyResponse{Item: item}, nil
+}
+
+// DeletePolicy implements the interface pbs.PolicyServiceServer.
+func (s Service) DeletePolicy(ctx context.Context, req *pbs.DeletePolicyRequest) (*pbs.DeletePolicyResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, limit int, prevPageLastItem pagination.Item) ([]*spolicy.Policy, error) {
+	const op = "policies.(Service).listFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	pl, err := repo.ListPolicies(ctx, scopeIds, spolicy.WithLimit(limit), spolicy.WithStartPageAfterItem(prevPageLastItem))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return pl, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*spolicy.Policy, error) {
+	const op = "policies.(Service).getFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	p, err := repo.LookupPolicy(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if p == nil {
+		return nil, handlers.NotFoundErrorf("Policy %q doesn't exist.", id)
+	}
+	return p, nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Policy) (*spolicy.Policy, error) {
+	const op = "policies.(Service).createInRepo"
+	p, err := toStoragePolicy(ctx, scopeId, item)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, err := repo.CreatePolicy(ctx, p)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create policy"))
+	}
+	return out, nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Policy) (*spolicy.Policy, error) {
+	const op = "policies.(Service).updateInRepo"
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+
+	attrs := item.GetStoragePolicyAttributes()
+	p := &spolicy.Policy{
+		Policy: &store.Policy{
+			PublicId:        id,
+			ScopeId:         scopeId,
+			Name:            item.GetName().GetValue(),
+			Description:     item.GetDescription().GetValue(),
+			RetainForDays:   attrs.GetRetainForDays(),
+			DeleteAfterDays: attrs.GetDeleteAfterDays(),
+			LegalHold:       attrs.GetLegalHold(),
+		},
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	out, rowsUpdated, err := repo.UpdatePolicy(ctx, p, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to update policy"))
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Policy %q doesn't exist or incorrect version provided.", id)
+	}
+	return out, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	const op = "policies.(Service).deleteFromRepo"
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, errors.Wrap(ctx, err, op)
+	}
+	rows, err := repo.DeletePolicy(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete policy"))
+	}
+	return rows > 0, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Policy), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		p, err := repo.LookupPolicy(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if p == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = p.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *spolicy.Policy, opt ...handlers.Option) (*pb.Policy, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building policy proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.Policy{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeIdField) {
+		out.ScopeId = in.GetScopeId()
+	}
+	if outputFields.Has(globals.TypeField) {
+		out.Type = spolicy.Subtype.String()
+	}
+	if outputFields.Has(globals.DescriptionField) && in.GetDescription() != "" {
+		out.Description = wrapperspb.String(in.GetDescription())
+	}
+	if outputFields.Has(globals.NameField) && in.GetName() != "" {
+		out.Name = wrapperspb.String(in.GetName())
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.VersionField) {
+		out.Version = in.GetVersion()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+	if outputFields.Has(globals.AttributesField) {
+		out.Attrs = &pb.Policy_StoragePolicyAttributes{
+			StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+				RetainForDays:   in.GetRetainForDays(),
+				DeleteAfterDays: in.GetDeleteAfterDays(),
+				LegalHold:       in.GetLegalHold(),
+			},
+		}
+	}
+	return &out, nil
+}
+
+func toStoragePolicy(ctx context.Context, scopeId string, in *pb.Policy) (*spolicy.Policy, error) {
+	const op = "policies.toStoragePolicy"
+	var opts []spolicy.Option
+	if in.GetName() != nil {
+		opts = append(opts, spolicy.WithName(in.GetName().GetValue()))
+	}
+	if in.GetDescription() != nil {
+		opts = append(opts, spolicy.WithDescription(in.GetDescription().GetValue()))
+	}
+	if attrs := in.GetStoragePolicyAttributes(); attrs != nil {
+		opts = append(opts,
+			spolicy.WithRetainForDays(attrs.GetRetainForDays()),
+			spolicy.WithDeleteAfterDays(attrs.GetDeleteAfterDays()),
+			spolicy.WithLegalHold(attrs.GetLegalHold()),
+		)
+	}
+	p, err := spolicy.NewPolicy(ctx, scopeId, opts...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to build policy for creation"))
+	}
+	return p, nil
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//   - The path passed in is correctly formatted
+//   - All required parameters are set
+//   - There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetPolicyRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.StoragePolicyPrefix)
+}
+
+func validateCreateRequest(req *pbs.CreatePolicyRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(handlers.Id(item.GetScopeId()), scope.Org.Prefix()) &&
+			scope.Global.String() != item.GetScopeId() {
+			badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+		}
+		if item.GetType() != spolicy.Subtype.String() {
+			badFields[globals.TypeField] = "This is a required field and must be a known policy type."
+		}
+		validateDurations(item.GetStoragePolicyAttributes(), badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdatePolicyRequest) error {
+	return handlers.ValidateUpdateRequest(req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if item.GetType() != "" && item.GetType() != spolicy.Subtype.String() {
+			badFields[globals.TypeField] = "Cannot modify resource type."
+		}
+		attrs := item.GetStoragePolicyAttributes()
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), retainForDaysField) && attrs.GetRetainForDays() < -1 {
+			badFields[retainForDaysField] = "Must be -1 or greater."
+		}
+		if handlers.MaskContains(req.GetUpdateMask().GetPaths(), deleteAfterDaysField) && attrs.GetDeleteAfterDays() < 0 {
+			badFields[deleteAfterDaysField] = "Must not be negative."
+		}
+		return badFields
+	}, globals.StoragePolicyPrefix)
+}
+
+// validateDurations checks the durations of a storage policy which is being
+// created. The durations of an updated storage policy are checked by the
+// repository, as they depend on the stored values of the fields which are
+// not part of the update.
+func validateDurations(attrs *pb.StoragePolicyAttributes, badFields map[string]string) {
+	retainForDays, deleteAfterDays := attrs.GetRetainForDays(), attrs.GetDeleteAfterDays()
+	switch {
+	case retainForDays < -1:
+		badFields[retainForDaysField] = "Must be -1 or greater."
+	case deleteAfterDays < 0:
+		badFields[deleteAfterDaysField] = "Must not be negative."
+	case deleteAfterDays > 0 && retainForDays == -1:
+		badFields[deleteAfterDaysField] = "Session recordings which are retained forever cannot be deleted."
+	case deleteAfterDays > 0 && deleteAfterDays < retainForDays:
+		badFields[deleteAfterDaysField] = "Must not be less than retain for days."
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeletePolicyRequest) error {
+	return handlers.ValidateDeleteRequest(handlers.NoopValidatorFn, req, globals.StoragePolicyPrefix)
+}
+
+func validateListRequest(ctx context.Context, req *pbs.ListPoliciesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), scope.Org.Prefix()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+	}
+	if _, err := handlers.NewFilter(ctx, req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
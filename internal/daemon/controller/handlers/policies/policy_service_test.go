@@ -0,0 +1,588 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	spolicy "github.com/hashicorp/boundary/internal/policy/storage"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/policies"
+	scopepb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	testAuthorizedActions = []string{"no-op", "read", "update", "delete"}
+	globalScopeInfo       = &scopepb.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, rw, rw, kms)
+	}
+
+	p := spolicy.TestPolicy(t, rw, scope.Global.String(),
+		spolicy.WithName("test policy"),
+		spolicy.WithRetainForDays(7),
+		spolicy.WithDeleteAfterDays(30),
+	)
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		res  *pbs.GetPolicyResponse
+		err  error
+	}{
+		{
+			name: "success",
+			id:   p.GetPublicId(),
+			res: &pbs.GetPolicyResponse{
+				Item: &pb.Policy{
+					Id:                p.GetPublicId(),
+					ScopeId:           scope.Global.String(),
+					Scope:             globalScopeInfo,
+					Name:              wrapperspb.String("test policy"),
+					CreatedTime:       p.GetCreateTime().GetTimestamp(),
+					UpdatedTime:       p.GetUpdateTime().GetTimestamp(),
+					Version:           1,
+					Type:              "storage",
+					AuthorizedActions: testAuthorizedActions,
+					Attrs: &pb.Policy_StoragePolicyAttributes{
+						StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+							RetainForDays:   7,
+							DeleteAfterDays: 30,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.StoragePolicyPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &pbs.GetPolicyRequest{Id: tc.id}
+			got, gErr := s.GetPolicy(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "GetPolicy(%q) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, rw, rw, kms)
+	}
+
+	gp := spolicy.TestPolicy(t, rw, scope.Global.String(), spolicy.WithLegalHold(true))
+	wantGlobal := []*pb.Policy{{
+		Id:                gp.GetPublicId(),
+		ScopeId:           scope.Global.String(),
+		Scope:             globalScopeInfo,
+		CreatedTime:       gp.GetCreateTime().GetTimestamp(),
+		UpdatedTime:       gp.GetUpdateTime().GetTimestamp(),
+		Version:           gp.GetVersion(),
+		Type:              "storage",
+		AuthorizedActions: testAuthorizedActions,
+		Attrs: &pb.Policy_StoragePolicyAttributes{
+			StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+				LegalHold: true,
+			},
+		},
+	}}
+
+	var wantOrgs []*pb.Policy
+	var firstOrgId string
+	for i := 0; i < 5; i++ {
+		org, _ := iam.TestScopes(t, iamRepo)
+		if i == 0 {
+			firstOrgId = org.GetPublicId()
+		}
+		p := spolicy.TestPolicy(t, rw, org.GetPublicId(), spolicy.WithDeleteAfterDays(int32(i+1)))
+		wantOrgs = append(wantOrgs, &pb.Policy{
+			Id:                p.GetPublicId(),
+			ScopeId:           org.GetPublicId(),
+			Scope:             &scopepb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+			CreatedTime:       p.GetCreateTime().GetTimestamp(),
+			UpdatedTime:       p.GetUpdateTime().GetTimestamp(),
+			Version:           p.GetVersion(),
+			Type:              "storage",
+			AuthorizedActions: testAuthorizedActions,
+			Attrs: &pb.Policy_StoragePolicyAttributes{
+				StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+					DeleteAfterDays: int32(i + 1),
+				},
+			},
+		})
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ListPoliciesRequest
+		res  *pbs.ListPoliciesResponse
+		err  error
+	}{
+		{
+			name: "list global policy",
+			req:  &pbs.ListPoliciesRequest{ScopeId: scope.Global.String()},
+			res:  &pbs.ListPoliciesResponse{Items: wantGlobal},
+		},
+		{
+			name: "list org policy",
+			req:  &pbs.ListPoliciesRequest{ScopeId: firstOrgId},
+			res:  &pbs.ListPoliciesResponse{Items: wantOrgs[0:1]},
+		},
+		{
+			name: "list recursively",
+			req:  &pbs.ListPoliciesRequest{ScopeId: scope.Global.String(), Recursive: true},
+			res:  &pbs.ListPoliciesResponse{Items: append(append([]*pb.Policy{}, wantGlobal...), wantOrgs...)},
+		},
+		{
+			name: "filter to one policy",
+			req:  &pbs.ListPoliciesRequest{ScopeId: scope.Global.String(), Recursive: true, Filter: fmt.Sprintf(`"/item/id"==%q`, wantOrgs[1].GetId())},
+			res:  &pbs.ListPoliciesResponse{Items: wantOrgs[1:2]},
+		},
+		{
+			name: "filter to no policy",
+			req:  &pbs.ListPoliciesRequest{ScopeId: scope.Global.String(), Filter: `"/item/id"=="doesnt match"`},
+			res:  &pbs.ListPoliciesResponse{},
+		},
+		{
+			name: "filter bad format",
+			req:  &pbs.ListPoliciesRequest{ScopeId: scope.Global.String(), Filter: `"//id/"=="bad"`},
+			err:  handlers.InvalidArgumentErrorf("bad format", nil),
+		},
+		{
+			name: "project scope",
+			req:  &pbs.ListPoliciesRequest{ScopeId: "p_1234567890"},
+			err:  handlers.InvalidArgumentErrorf("bad scope", nil),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewService(ctx, repoFn, iamRepoFn)
+			require.NoError(t, err)
+
+			got, gErr := s.ListPolicies(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "ListPolicies(%q) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				protocmp.SortRepeated(func(x, y *pb.Policy) bool {
+					return x.Id < y.Id
+				}),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+				protocmp.IgnoreFields(&scopepb.ScopeInfo{}, "name", "description"),
+			))
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, rw, rw, kms)
+	}
+
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		req  *pbs.CreatePolicyRequest
+		res  *pbs.CreatePolicyResponse
+		err  error
+	}{
+		{
+			name: "create with attributes",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId:     scope.Global.String(),
+				Type:        "storage",
+				Name:        wrapperspb.String("name"),
+				Description: wrapperspb.String("desc"),
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						RetainForDays:   30,
+						DeleteAfterDays: 90,
+					},
+				},
+			}},
+			res: &pbs.CreatePolicyResponse{
+				Uri: fmt.Sprintf("policies/%s_", globals.StoragePolicyPrefix),
+				Item: &pb.Policy{
+					ScopeId:           scope.Global.String(),
+					Scope:             globalScopeInfo,
+					Type:              "storage",
+					Name:              wrapperspb.String("name"),
+					Description:       wrapperspb.String("desc"),
+					Version:           1,
+					AuthorizedActions: testAuthorizedActions,
+					Attrs: &pb.Policy_StoragePolicyAttributes{
+						StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+							RetainForDays:   30,
+							DeleteAfterDays: 90,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "scope already has a policy",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: scope.Global.String(),
+				Type:    "storage",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "project scope",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: proj.GetPublicId(),
+				Type:    "storage",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "missing type",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: scope.Global.String(),
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "negative delete after days",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: scope.Global.String(),
+				Type:    "storage",
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						DeleteAfterDays: -1,
+					},
+				},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "delete before retained",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: scope.Global.String(),
+				Type:    "storage",
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						RetainForDays:   30,
+						DeleteAfterDays: 7,
+					},
+				},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "delete when retained forever",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				ScopeId: scope.Global.String(),
+				Type:    "storage",
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						RetainForDays:   -1,
+						DeleteAfterDays: 7,
+					},
+				},
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "id set",
+			req: &pbs.CreatePolicyRequest{Item: &pb.Policy{
+				Id:      fmt.Sprintf("%s_1234567890", globals.StoragePolicyPrefix),
+				ScopeId: scope.Global.String(),
+				Type:    "storage",
+			}},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.CreatePolicy(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetItem().GetScopeId()), tc.req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "CreatePolicy(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			require.NotNil(t, got)
+			assert.Contains(t, got.GetUri(), tc.res.GetUri())
+			assert.True(t, handlers.ValidId(handlers.Id(got.GetItem().GetId()), globals.StoragePolicyPrefix))
+			assert.NotNil(t, got.GetItem().GetCreatedTime())
+			assert.NotNil(t, got.GetItem().GetUpdatedTime())
+			assert.Equal(t, got.GetItem().GetCreatedTime().AsTime(), got.GetItem().GetUpdatedTime().AsTime())
+
+			// Clear all values which are hard to compare against.
+			got.Uri, tc.res.Uri = "", ""
+			got.Item.Id, tc.res.Item.Id = "", ""
+			got.Item.CreatedTime, got.Item.UpdatedTime = nil, nil
+			assert.Empty(t, cmp.Diff(
+				got,
+				tc.res,
+				protocmp.Transform(),
+				cmpopts.SortSlices(func(a, b string) bool {
+					return a < b
+				}),
+			))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, rw, rw, kms)
+	}
+
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		paths []string
+		item  *pb.Policy
+		check func(t *testing.T, got *pb.Policy)
+		err   error
+	}{
+		{
+			name:  "update name",
+			paths: []string{"name"},
+			item:  &pb.Policy{Name: wrapperspb.String("updated")},
+			check: func(t *testing.T, got *pb.Policy) {
+				assert.Equal(t, "updated", got.GetName().GetValue())
+				assert.Equal(t, int32(30), got.GetStoragePolicyAttributes().GetDeleteAfterDays())
+			},
+		},
+		{
+			name:  "set legal hold",
+			paths: []string{"attributes.legal_hold"},
+			item: &pb.Policy{
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						LegalHold: true,
+					},
+				},
+			},
+			check: func(t *testing.T, got *pb.Policy) {
+				assert.True(t, got.GetStoragePolicyAttributes().GetLegalHold())
+				assert.Equal(t, int32(7), got.GetStoragePolicyAttributes().GetRetainForDays())
+				assert.Equal(t, int32(30), got.GetStoragePolicyAttributes().GetDeleteAfterDays())
+			},
+		},
+		{
+			name:  "unset delete after days",
+			paths: []string{"attributes.delete_after_days"},
+			item:  &pb.Policy{},
+			check: func(t *testing.T, got *pb.Policy) {
+				assert.Equal(t, int32(0), got.GetStoragePolicyAttributes().GetDeleteAfterDays())
+			},
+		},
+		{
+			name:  "delete before retained",
+			paths: []string{"attributes.delete_after_days"},
+			item: &pb.Policy{
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						DeleteAfterDays: 1,
+					},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "negative retain for days",
+			paths: []string{"attributes.retain_for_days"},
+			item: &pb.Policy{
+				Attrs: &pb.Policy_StoragePolicyAttributes{
+					StoragePolicyAttributes: &pb.StoragePolicyAttributes{
+						RetainForDays: -2,
+					},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "change type",
+			paths: []string{"name"},
+			item:  &pb.Policy{Name: wrapperspb.String("new name"), Type: "other"},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:  "no valid paths",
+			paths: []string{"scope_id"},
+			item:  &pb.Policy{ScopeId: scope.Global.String()},
+			err:   handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			org, _ := iam.TestScopes(t, iamRepo)
+			p := spolicy.TestPolicy(t, rw, org.GetPublicId(), spolicy.WithRetainForDays(7), spolicy.WithDeleteAfterDays(30))
+			tc.item.Version = p.GetVersion()
+			req := &pbs.UpdatePolicyRequest{
+				Id:         p.GetPublicId(),
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: tc.paths},
+				Item:       tc.item,
+			}
+			got, gErr := s.UpdatePolicy(auth.DisabledAuthTestContext(iamRepoFn, org.GetPublicId()), req)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "UpdatePolicy(%+v) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			assert.Equal(t, p.GetVersion()+1, got.GetItem().GetVersion())
+			tc.check(t, got.GetItem())
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*spolicy.Repository, error) {
+		return spolicy.NewRepository(ctx, rw, rw, kms)
+	}
+
+	p := spolicy.TestPolicy(t, rw, scope.Global.String())
+	s, err := NewService(ctx, repoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{
+			name: "success",
+			id:   p.GetPublicId(),
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.StoragePolicyPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gErr := s.DeletePolicy(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.DeletePolicyRequest{Id: tc.id})
+			assert.Nil(t, got)
+			if tc.err != nil {
+				require.Error(t, gErr)
+				assert.True(t, errors.Is(gErr, tc.err), "DeletePolicy(%q) got error %v, wanted %v", tc.id, gErr, tc.err)
+				return
+			}
+			require.NoError(t, gErr)
+			g, err := s.GetPolicy(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), &pbs.GetPolicyRequest{Id: tc.id})
+			assert.Nil(t, g)
+			assert.True(t, errors.Is(err, handlers.NotFoundError()))
+		})
+	}
+}
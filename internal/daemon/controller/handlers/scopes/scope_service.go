@@ -14,11 +14,14 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/accessrequests"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/aliases"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/credentialstores"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/host_catalogs"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/policies"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/session_recordings"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
@@ -31,6 +34,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -70,10 +74,12 @@ var (
 	// TODO: get this from action registry
 	scopeCollectionTypeMapMap = map[string]map[resource.Type]action.ActionSet{
 		scope.Global.String(): {
+			resource.Alias:            aliases.CollectionActions,
 			resource.AuthMethod:       authmethods.CollectionActions,
 			resource.StorageBucket:    storage_buckets.CollectionActions,
 			resource.AuthToken:        authtokens.CollectionActions,
 			resource.Group:            groups.CollectionActions,
+			resource.Policy:           policies.CollectionActions,
 			resource.Role:             roles.CollectionActions,
 			resource.Scope:            CollectionActions,
 			resource.User:             users.CollectionActions,
@@ -86,6 +92,7 @@ var (
 			resource.StorageBucket:    storage_buckets.CollectionActions,
 			resource.AuthToken:        authtokens.CollectionActions,
 			resource.Group:            groups.CollectionActions,
+			resource.Policy:           policies.CollectionActions,
 			resource.Role:             roles.CollectionActions,
 			resource.Scope:            CollectionActions,
 			resource.User:             users.CollectionActions,
@@ -93,6 +100,7 @@ var (
 		},
 
 		scope.Project.String(): {
+			resource.AccessRequest:   accessrequests.CollectionActions,
 			resource.CredentialStore: credentialstores.CollectionActions,
 			resource.Group:           groups.CollectionActions,
 			resource.HostCatalog:     host_catalogs.CollectionActions,
@@ -127,14 +135,15 @@ func init() {
 type Service struct {
 	pbs.UnsafeScopeServiceServer
 
-	repoFn  common.IamRepoFactory
-	kmsRepo *kms.Kms
+	repoFn      common.IamRepoFactory
+	kmsRepo     *kms.Kms
+	maxPageSize uint
 }
 
 var _ pbs.ScopeServiceServer = (*Service)(nil)
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(ctx context.Context, repo common.IamRepoFactory, kmsRepo *kms.Kms) (Service, error) {
+func NewService(ctx context.Context, repo common.IamRepoFactory, kmsRepo *kms.Kms, opt ...handlers.Option) (Service, error) {
 	const op = "scopes.(Service).NewService"
 	if util.IsNil(repo) {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
@@ -142,7 +151,7 @@ func NewService(ctx context.Context, repo common.IamRepoFactory, kmsRepo *kms.Km
 	if kmsRepo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
 	}
-	return Service{repoFn: repo, kmsRepo: kmsRepo}, nil
+	return Service{repoFn: repo, kmsRepo: kmsRepo, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListScopes implements the interface pbs.ScopeServiceServer.
@@ -177,29 +186,21 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		return &pbs.ListScopesResponse{}, nil
 	}
 
-	pl, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(pl) == 0 {
-		return &pbs.ListScopesResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Scope, 0, len(pl))
 	res := perms.Resource{
 		Type: resource.Scope,
 	}
-	for _, item := range pl {
+	protos := make(map[string]*pb.Scope)
+	filterItemFn := func(ctx context.Context, item *iam.Scope) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetParentId()
 
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -214,22 +215,40 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		if outputFields.Has(globals.AuthorizedCollectionActionsField) {
 			collectionActions, err := auth.CalculateAuthorizedCollectionActions(ctx, authResults, scopeCollectionTypeMapMap[item.Type], item.GetPublicId(), "")
 			if err != nil {
-				return nil, err
+				return false, err
 			}
 			outputOpts = append(outputOpts, handlers.WithAuthorizedCollectionActions(collectionActions))
 		}
 
-		item, err := ToProto(ctx, item, outputOpts...)
+		pbItem, err := ToProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*iam.Scope, error) {
+		return s.listFromRepo(ctx, scopeIds, iam.WithLimit(limit), iam.WithStartPageAfterItem(prevPageLastItem))
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Scope, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Scope, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
 	}
 	SortScopes(finalItems)
-	return &pbs.ListScopesResponse{Items: finalItems}, nil
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListScopesResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -549,6 +568,12 @@ func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResult
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetAuthTokenTimeToLiveSeconds() != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToLiveSeconds(item.GetAuthTokenTimeToLiveSeconds().GetValue()))
+	}
+	if item.GetAuthTokenTimeToStaleSeconds() != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToStaleSeconds(item.GetAuthTokenTimeToStaleSeconds().GetValue()))
+	}
 	opts = append(opts, iam.WithSkipAdminRoleCreation(req.GetSkipAdminRoleCreation()))
 	opts = append(opts, iam.WithSkipDefaultRoleCreation(req.GetSkipDefaultRoleCreation()))
 
@@ -597,6 +622,15 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 		scopePrimaryAuthMethodId = primaryAuthMethodId.GetValue()
 		opts = append(opts, iam.WithPrimaryAuthMethodId(scopePrimaryAuthMethodId))
 	}
+	var scopeTokenTtl, scopeTokenStale uint32
+	if ttl := item.GetAuthTokenTimeToLiveSeconds(); ttl != nil {
+		scopeTokenTtl = ttl.GetValue()
+		opts = append(opts, iam.WithAuthTokenTimeToLiveSeconds(scopeTokenTtl))
+	}
+	if stale := item.GetAuthTokenTimeToStaleSeconds(); stale != nil {
+		scopeTokenStale = stale.GetValue()
+		opts = append(opts, iam.WithAuthTokenTimeToStaleSeconds(scopeTokenStale))
+	}
 	version := item.GetVersion()
 
 	var iamScope *iam.Scope
@@ -611,6 +645,8 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 		iamScope.Description = scopeDesc
 		iamScope.Name = scopeName
 		iamScope.PrimaryAuthMethodId = scopePrimaryAuthMethodId
+		iamScope.AuthTokenTimeToLiveSeconds = scopeTokenTtl
+		iamScope.AuthTokenTimeToStaleSeconds = scopeTokenStale
 	case parentScope.GetType() == scope.Global.String():
 		iamScope, err = iam.NewOrg(ctx, opts...)
 	case parentScope.GetType() == scope.Org.String():
@@ -674,12 +710,12 @@ func sortKeys(keys []*pb.Key) {
 	}
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, opt ...iam.Option) ([]*iam.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	scps, err := repo.ListScopes(ctx, scopeIds, iam.WithLimit(-1))
+	scps, err := repo.ListScopes(ctx, scopeIds, opt...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to list scopes: %v", err)
 	}
@@ -769,6 +805,12 @@ func ToProto(ctx context.Context, in *iam.Scope, opt ...handlers.Option) (*pb.Sc
 	if outputFields.Has(globals.PrimaryAuthMethodIdField) && in.GetPrimaryAuthMethodId() != "" {
 		out.PrimaryAuthMethodId = &wrapperspb.StringValue{Value: in.GetPrimaryAuthMethodId()}
 	}
+	if outputFields.Has(globals.AuthTokenTimeToLiveSecondsField) && in.GetAuthTokenTimeToLiveSeconds() != 0 {
+		out.AuthTokenTimeToLiveSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToLiveSeconds())
+	}
+	if outputFields.Has(globals.AuthTokenTimeToStaleSecondsField) && in.GetAuthTokenTimeToStaleSeconds() != 0 {
+		out.AuthTokenTimeToStaleSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToStaleSeconds())
+	}
 
 	return &out, nil
 }
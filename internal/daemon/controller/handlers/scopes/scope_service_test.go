@@ -73,6 +73,12 @@ func createDefaultScopesRepoAndKms(t *testing.T) (*iam.Scope, *iam.Scope, func()
 }
 
 var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
+	"aliases": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"auth-methods": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -90,6 +96,12 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"policies": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -109,6 +121,7 @@ var globalAuthorizedCollectionActions = map[string]*structpb.ListValue{
 	"session-recordings": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("search"),
 		},
 	},
 	"storage-buckets": {
@@ -152,6 +165,12 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"policies": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"roles": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -171,6 +190,7 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 	"session-recordings": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("list"),
+			structpb.NewStringValue("search"),
 		},
 	},
 	"storage-buckets": {
@@ -188,6 +208,12 @@ var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
 }
 
 var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
+	"access-requests": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"credential-stores": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
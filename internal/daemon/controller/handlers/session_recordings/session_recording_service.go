@@ -5,18 +5,74 @@ package session_recordings
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync/atomic"
 
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/bsr/convert"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/bsr/search"
+	"github.com/hashicorp/boundary/internal/credential"
+	credstatic "github.com/hashicorp/boundary/internal/credential/static"
+	"github.com/hashicorp/boundary/internal/credential/vault"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	intglobals "github.com/hashicorp/boundary/internal/globals"
+	"github.com/hashicorp/boundary/internal/host/plugin"
+	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	pluginstorage "github.com/hashicorp/boundary/internal/storage/plugin"
+	"github.com/hashicorp/boundary/internal/target/ssh"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/session_recordings"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// asciicastMimeType is the mime type of the asciicast a shell channel
+	// recording can be converted into.
+	asciicastMimeType = "application/x-asciicast"
+	// transcriptMimeType is the mime type of the timestamped text transcript
+	// a shell or exec channel recording can be converted into.
+	transcriptMimeType = "text/plain"
+	// jsonlMimeType is the mime type of the JSON lines of requests a session
+	// channel recording can be converted into.
+	jsonlMimeType = "application/jsonl"
+)
+
+// downloadChunkSize is the maximum size of the data sent in each message of
+// a download.
+const downloadChunkSize = 64 * 1024
+
 var (
 	// IdActions contains the set of actions that can be performed on
 	// individual resources
@@ -30,6 +86,7 @@ var (
 	// this collection
 	CollectionActions = action.NewActionSet(
 		action.List,
+		action.Search,
 	)
 )
 
@@ -38,33 +95,887 @@ func init() {
 	action.RegisterResource(resource.SessionRecording, IdActions, CollectionActions)
 }
 
-// NewServiceFn returns a storage bucket service which is not implemented in OSS
+// NewServiceFn returns a session recording service which handles session
+// recording related requests to boundary.
 var NewServiceFn = func(ctx context.Context,
 	iamRepoFn common.IamRepoFactory,
+	recordingRepoFn common.RecordingRepoFactory,
 	workerStatusGracePeriod *atomic.Int64,
 	kms *kms.Kms,
 	controllerExt intglobals.ControllerExtension,
+	storageRepoFn common.PluginStorageBucketRepoFactory,
+	storagePlugins map[string]plgpb.StoragePluginServiceClient,
+	opt ...handlers.Option,
 ) (pbs.SessionRecordingServiceServer, error) {
-	return Service{}, nil
+	return NewService(ctx, iamRepoFn, recordingRepoFn, storageRepoFn, kms, storagePlugins, opt...)
 }
 
+// Service handles request as described by the pbs.SessionRecordingServiceServer interface.
 type Service struct {
 	pbs.UnimplementedSessionRecordingServiceServer
+
+	iamRepoFn      common.IamRepoFactory
+	repoFn         common.RecordingRepoFactory
+	storageRepoFn  common.PluginStorageBucketRepoFactory
+	kms            *kms.Kms
+	storagePlugins map[string]plgpb.StoragePluginServiceClient
+	maxPageSize    uint
 }
 
 var _ pbs.SessionRecordingServiceServer = (*Service)(nil)
 
-// GetSessionRecording implements the interface pbs.SessionRecordingServiceServer.
-func (s Service) GetSessionRecording(context.Context, *pbs.GetSessionRecordingRequest) (*pbs.GetSessionRecordingResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "session recordings are an Enterprise-only feature")
+// NewService returns a session recording service which handles session
+// recording related requests to boundary. storagePlugins are the storage
+// plugin clients used to download session recordings, keyed on the public
+// id of the plugin.
+func NewService(
+	ctx context.Context,
+	iamRepoFn common.IamRepoFactory,
+	repoFn common.RecordingRepoFactory,
+	storageRepoFn common.PluginStorageBucketRepoFactory,
+	kms *kms.Kms,
+	storagePlugins map[string]plgpb.StoragePluginServiceClient,
+	opt ...handlers.Option,
+) (Service, error) {
+	const op = "session_recordings.NewService"
+	if iamRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
+	}
+	if repoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing recording repository")
+	}
+	if storageRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing storage bucket repository")
+	}
+	if kms == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	}
+	return Service{
+		iamRepoFn:      iamRepoFn,
+		repoFn:         repoFn,
+		storageRepoFn:  storageRepoFn,
+		kms:            kms,
+		storagePlugins: storagePlugins,
+		maxPageSize:    handlers.GetOpts(opt...).WithMaxPageSize,
+	}, nil
 }
 
 // ListSessionRecordings implements the interface pbs.SessionRecordingServiceServer.
-func (s Service) ListSessionRecordings(context.Context, *pbs.ListSessionRecordingsRequest) (*pbs.ListSessionRecordingsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "session recordings are an Enterprise-only feature")
+func (s Service) ListSessionRecordings(ctx context.Context, req *pbs.ListSessionRecordingsRequest) (*pbs.ListSessionRecordingsResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.SessionRecording, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListSessionRecordingsResponse{}, nil
+	}
+
+	res := perms.Resource{
+		Type: resource.SessionRecording,
+	}
+	protos := make(map[string]*pb.SessionRecording)
+	filterItemFn := func(ctx context.Context, item *recording.SessionRecording) (bool, error) {
+		res.Id = item.GetPublicId()
+		res.ScopeId = item.StorageBucketScopeId
+		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
+		if len(authorizedActions) == 0 {
+			return false, nil
+		}
+
+		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
+		outputOpts := make([]handlers.Option, 0, 3)
+		outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+		if outputFields.Has(globals.ScopeField) {
+			outputOpts = append(outputOpts, handlers.WithScope(scopeInfoMap[item.StorageBucketScopeId]))
+		}
+		if outputFields.Has(globals.AuthorizedActionsField) {
+			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
+		}
+
+		pbItem, err := toProto(ctx, item, outputOpts...)
+		if err != nil {
+			return false, err
+		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*recording.SessionRecording, error) {
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
+		}
+		opts := []recording.Option{recording.WithLimit(limit)}
+		if prevPageLastItem != nil {
+			opts = append(opts, recording.WithStartPageAfterItem(prevPageLastItem))
+		}
+		return repo.ListSessionRecordings(ctx, scopeIds, opts...)
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.SessionRecording, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.SessionRecording, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListSessionRecordingsResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
+}
+
+// SearchSessionRecordings implements the interface pbs.SessionRecordingServiceServer.
+// The search index of each indexed session recording in the requested scopes
+// which the caller can download is retrieved from the storage bucket of the
+// session recording and searched. At most the maximum page size of matches
+// are returned.
+func (s Service) SearchSessionRecordings(ctx context.Context, req *pbs.SearchSessionRecordingsRequest) (*pbs.SearchSessionRecordingsResponse, error) {
+	if err := validateSearchRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.Search)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes. Or, if they've not
+		// authenticated, still process in case u_anon has permissions.
+		if (authResults.Error == handlers.ForbiddenError() || authResults.Error == handlers.UnauthenticatedError()) &&
+			req.GetRecursive() &&
+			authResults.AuthenticationFinished {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, _, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.SessionRecording, req.GetRecursive())
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.SearchSessionRecordingsResponse{}, nil
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	recordings, err := repo.ListSessionRecordings(ctx, scopeIds, recording.WithSearchIndexed(true), recording.WithLimit(-1))
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "boundary-search-")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	limit := handlers.PageSize(0, s.maxPageSize)
+	res := perms.Resource{
+		Type: resource.SessionRecording,
+	}
+	buckets := make(map[string]storage.FS)
+	var items []*pb.SearchMatch
+	for _, sr := range recordings {
+		res.Id = sr.GetPublicId()
+		res.ScopeId = sr.StorageBucketScopeId
+		if !authResults.FetchActionSetForId(ctx, sr.GetPublicId(), IdActions, auth.WithResource(&res)).HasAction(action.Download) {
+			continue
+		}
+		fs, ok := buckets[sr.GetStorageBucketId()]
+		if !ok {
+			_, fs, err = s.remoteFS(ctx, filepath.Join(dir, sr.GetStorageBucketId()), sr.GetStorageBucketId())
+			if err != nil {
+				return nil, err
+			}
+			buckets[sr.GetStorageBucketId()] = fs
+		}
+		idx, err := search.Read(ctx, fs, sr.GetPublicId())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error reading search index of session recording %q: %v", sr.GetPublicId(), err)
+		}
+		for _, l := range idx.Search(req.GetQuery()) {
+			items = append(items, &pb.SearchMatch{
+				SessionRecordingId:    sr.GetPublicId(),
+				ConnectionRecordingId: l.ConnectionRecordingId,
+				ChannelRecordingId:    l.ChannelRecordingId,
+				Time:                  timestamppb.New(l.Time),
+				Offset:                durationpb.New(l.Offset),
+				Line:                  l.Text,
+			})
+			if len(items) >= limit {
+				return &pbs.SearchSessionRecordingsResponse{Items: items}, nil
+			}
+		}
+	}
+	return &pbs.SearchSessionRecordingsResponse{Items: items}, nil
+}
+
+// GetSessionRecording implements the interface pbs.SessionRecordingServiceServer.
+func (s Service) GetSessionRecording(ctx context.Context, req *pbs.GetSessionRecordingRequest) (*pbs.GetSessionRecordingResponse, error) {
+	const op = "session_recordings.(Service).GetSessionRecording"
+
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	sr, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	outputFields, ok := requests.OutputFields(ctx)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "no request context found")
+	}
+
+	outputOpts := make([]handlers.Option, 0, 3)
+	outputOpts = append(outputOpts, handlers.WithOutputFields(outputFields))
+	if outputFields.Has(globals.ScopeField) {
+		outputOpts = append(outputOpts, handlers.WithScope(authResults.Scope))
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authResults.FetchActionSetForId(ctx, sr.GetPublicId(), IdActions).Strings()))
+	}
+
+	item, err := toProto(ctx, sr, outputOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbs.GetSessionRecordingResponse{Item: item}, nil
 }
 
 // Download implements the interface pbs.SessionRecordingServiceServer.
-func (s Service) Download(*pbs.DownloadRequest, pbs.SessionRecordingService_DownloadServer) error {
-	return status.Errorf(codes.Unimplemented, "session recordings are an Enterprise-only feature")
+// Channel recordings are retrieved from the storage bucket of the session
+// recording and converted into the requested mime type while being streamed.
+func (s Service) Download(req *pbs.DownloadRequest, stream pbs.SessionRecordingService_DownloadServer) error {
+	ctx := stream.Context()
+	if err := validateDownloadRequest(req); err != nil {
+		return err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Download)
+	if authResults.Error != nil {
+		return authResults.Error
+	}
+	sr, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return err
+	}
+	mimeType := req.GetMimeType()
+	if mimeType == "" {
+		mimeType = asciicastMimeType
+	}
+
+	var connectionId string
+	var ch *recording.ChannelRecording
+	for _, cr := range sr.ConnectionRecordings {
+		for _, c := range cr.ChannelRecordings {
+			if c.GetPublicId() == req.GetId() {
+				connectionId, ch = cr.GetPublicId(), c
+			}
+		}
+	}
+	if ch == nil {
+		return handlers.NotFoundErrorf("Channel recording %q doesn't exist.", req.GetId())
+	}
+	if !slices.Contains(channelMimeTypes(ch), mimeType) {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{
+			globals.MimeTypeField: fmt.Sprintf("Channel recording %q can't be downloaded as %q.", ch.GetPublicId(), mimeType),
+		})
+	}
+
+	bsrWrapper := s.kms.GetExternalWrappers(ctx).Bsr()
+	if bsrWrapper == nil {
+		return status.Error(codes.FailedPrecondition, "No BSR KMS is configured, unable to download session recording.")
+	}
+	dir, err := os.MkdirTemp("", "boundary-download-")
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	rs, fs, err := s.remoteFS(ctx, dir, sr.GetStorageBucketId())
+	if err != nil {
+		return err
+	}
+	session, err := bsr.OpenSession(ctx, sr.GetPublicId(), fs, bsrkms.NewKeyUnwrapCallbackFunc(ctx, bsrWrapper))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error opening session recording: %v", err)
+	}
+	defer session.Close(ctx)
+
+	tmp, err := rs.CreateTemp(ctx, ch.GetPublicId()+"-*")
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error creating temporary file: %v", err)
+	}
+	defer tmp.Close()
+
+	convertFn := convert.ToAsciicast
+	switch mimeType {
+	case transcriptMimeType:
+		convertFn = convert.ToTranscript
+	case jsonlMimeType:
+		convertFn = convert.ToJsonl
+	}
+	r, err := convertFn(ctx, session, tmp, connectionId, convert.WithChannelId(ch.GetPublicId()))
+	if err != nil {
+		return status.Errorf(codes.Internal, "Error converting channel recording: %v", err)
+	}
+	defer r.Close()
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&httpbody.HttpBody{ContentType: mimeType, Data: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		switch {
+		case err == nil:
+		case stderrors.Is(err, io.EOF):
+			return nil
+		default:
+			return status.Errorf(codes.Internal, "Error reading channel recording: %v", err)
+		}
+	}
+}
+
+// remoteFS returns a read only storage.FS of the storage bucket with the
+// provided id, along with the recording storage which holds the files
+// retrieved from the storage bucket in dir.
+func (s Service) remoteFS(ctx context.Context, dir, storageBucketId string) (*local.RecordingStorage, storage.FS, error) {
+	storageRepo, err := s.storageRepoFn()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Error getting storage bucket repo: %v", err)
+	}
+	sb, plg, err := storageRepo.LookupStorageBucket(ctx, storageBucketId)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Error looking up storage bucket: %v", err)
+	}
+	if sb == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "Storage bucket %q not found.", storageBucketId)
+	}
+	client, ok := s.storagePlugins[plg.GetPublicId()]
+	if !ok || client == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "Storage plugin %q is not available, unable to read session recordings.", plg.GetName())
+	}
+	bucket, err := pluginstorage.ToPluginStorageBucket(ctx, sb, plg)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Error converting storage bucket: %v", err)
+	}
+	rs, err := local.New(ctx, dir, map[string]plgpb.StoragePluginServiceClient{plg.GetName(): client}, false)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Error creating recording storage: %v", err)
+	}
+	fs, err := rs.NewRemoteFS(ctx, bucket)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Error opening storage bucket: %v", err)
+	}
+	return rs, fs, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*recording.SessionRecording, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	sr, err := repo.LookupSessionRecording(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sr == nil {
+		return nil, handlers.NotFoundErrorf("Session recording %q doesn't exist.", id)
+	}
+	return sr, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.SessionRecording), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Search:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		sr, err := repo.LookupSessionRecording(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if sr == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = sr.StorageBucketScopeId
+		id = sr.GetPublicId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(ctx context.Context, in *recording.SessionRecording, opt ...handlers.Option) (*pb.SessionRecording, error) {
+	opts := handlers.GetOpts(opt...)
+	if opts.WithOutputFields == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "output fields not found when building session recording proto")
+	}
+	outputFields := *opts.WithOutputFields
+
+	out := pb.SessionRecording{}
+	if outputFields.Has(globals.IdField) {
+		out.Id = in.GetPublicId()
+	}
+	if outputFields.Has(globals.ScopeField) {
+		out.Scope = opts.WithScope
+	}
+	if outputFields.Has(globals.SessionIdField) {
+		out.SessionId = in.GetSessionId()
+	}
+	if outputFields.Has(globals.StorageBucketIdField) {
+		out.StorageBucketId = in.GetStorageBucketId()
+	}
+	if outputFields.Has(globals.BytesUpField) {
+		out.BytesUp = in.GetBytesUp()
+	}
+	if outputFields.Has(globals.BytesDownField) {
+		out.BytesDown = in.GetBytesDown()
+	}
+	if outputFields.Has(globals.CreatedTimeField) {
+		out.CreatedTime = in.GetCreateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.UpdatedTimeField) {
+		out.UpdatedTime = in.GetUpdateTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.StartTimeField) {
+		out.StartTime = in.GetStartTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.EndTimeField) {
+		out.EndTime = in.GetEndTime().GetTimestamp()
+	}
+	if outputFields.Has(globals.DurationField) {
+		out.Duration = duration(in.GetStartTime().GetTimestamp(), in.GetEndTime().GetTimestamp())
+	}
+	if outputFields.Has(globals.TypeField) {
+		out.Type = ssh.Subtype.String()
+	}
+	if outputFields.Has(globals.StateField) {
+		out.State = in.GetState()
+	}
+	if outputFields.Has(globals.ErrorDetailsField) {
+		out.ErrorDetails = in.GetErrorDetails()
+	}
+	if outputFields.Has(globals.EndpointField) {
+		out.Endpoint = in.GetEndpoint()
+	}
+	if outputFields.Has(globals.AuthorizedActionsField) {
+		out.AuthorizedActions = opts.WithAuthorizedActions
+	}
+
+	var mimeTypes []string
+	for _, cr := range in.ConnectionRecordings {
+		pcr := connectionRecordingToProto(cr)
+		mimeTypes = appendMimeTypes(mimeTypes, pcr.GetMimeTypes()...)
+		if outputFields.Has(globals.ConnectionRecordingsField) {
+			out.ConnectionRecordings = append(out.ConnectionRecordings, pcr)
+		}
+	}
+	if outputFields.Has(globals.MimeTypesField) {
+		out.MimeTypes = mimeTypes
+	}
+
+	if outputFields.Has(globals.CreateTimeValues) && in.SessionMeta != nil {
+		var err error
+		if out.CreateTimeValues, err = valuesAtTimeToProto(ctx, in.SessionMeta); err != nil {
+			return nil, err
+		}
+	}
+	return &out, nil
+}
+
+func connectionRecordingToProto(in *recording.ConnectionRecording) *pb.ConnectionRecording {
+	out := &pb.ConnectionRecording{
+		Id:          in.GetPublicId(),
+		BytesUp:     in.GetBytesUp(),
+		BytesDown:   in.GetBytesDown(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		StartTime:   in.GetStartTime().GetTimestamp(),
+		EndTime:     in.GetEndTime().GetTimestamp(),
+		Duration:    duration(in.GetStartTime().GetTimestamp(), in.GetEndTime().GetTimestamp()),
+	}
+	for _, ch := range in.ChannelRecordings {
+		pch := &pb.ChannelRecording{
+			Id:          ch.GetPublicId(),
+			BytesUp:     ch.GetBytesUp(),
+			BytesDown:   ch.GetBytesDown(),
+			CreatedTime: ch.GetCreateTime().GetTimestamp(),
+			UpdatedTime: ch.GetUpdateTime().GetTimestamp(),
+			StartTime:   ch.GetStartTime().GetTimestamp(),
+			EndTime:     ch.GetEndTime().GetTimestamp(),
+			Duration:    duration(ch.GetStartTime().GetTimestamp(), ch.GetEndTime().GetTimestamp()),
+		}
+		pch.MimeTypes = channelMimeTypes(ch)
+		out.MimeTypes = appendMimeTypes(out.MimeTypes, pch.GetMimeTypes()...)
+		out.ChannelRecordings = append(out.ChannelRecordings, pch)
+	}
+	return out
+}
+
+// channelMimeTypes returns the mime types ch can be downloaded as. Only
+// shell sessions can be converted into an asciicast and only the data of
+// shell and exec sessions into a transcript. The requests of any session
+// channel can be converted into JSON lines.
+func channelMimeTypes(ch *recording.ChannelRecording) []string {
+	if ch.GetChannelType() != recording.ChannelTypeSession {
+		return nil
+	}
+	switch ch.Program {
+	case recording.ProgramShell:
+		return []string{asciicastMimeType, transcriptMimeType, jsonlMimeType}
+	case recording.ProgramExec:
+		return []string{transcriptMimeType, jsonlMimeType}
+	default:
+		return []string{jsonlMimeType}
+	}
+}
+
+// appendMimeTypes appends the mime types in add which are not in mimeTypes
+// to mimeTypes.
+func appendMimeTypes(mimeTypes []string, add ...string) []string {
+	for _, a := range add {
+		found := false
+		for _, m := range mimeTypes {
+			if m == a {
+				found = true
+				break
+			}
+		}
+		if !found {
+			mimeTypes = append(mimeTypes, a)
+		}
+	}
+	return mimeTypes
+}
+
+// duration returns the duration between start and end or nil if either is
+// not set.
+func duration(start, end *timestamppb.Timestamp) *durationpb.Duration {
+	if start == nil || end == nil {
+		return nil
+	}
+	return durationpb.New(end.AsTime().Sub(start.AsTime()))
+}
+
+func scopeInfoToProto(in bsr.Scope) *scopes.ScopeInfo {
+	return &scopes.ScopeInfo{
+		Id:            in.PublicId,
+		Type:          in.Type,
+		Name:          in.Name,
+		Description:   in.Description,
+		ParentScopeId: in.ParentId,
+	}
+}
+
+func valuesAtTimeToProto(ctx context.Context, in *bsr.SessionMeta) (*pb.ValuesAtTime, error) {
+	const op = "session_recordings.valuesAtTimeToProto"
+	out := &pb.ValuesAtTime{}
+	if u := in.User; u != nil {
+		out.User = &pb.User{
+			Id:          u.PublicId,
+			Name:        u.Name,
+			Description: u.Description,
+			Scope:       scopeInfoToProto(u.Scope),
+		}
+	}
+	if t := in.Target; t != nil {
+		out.Target = &pb.Target{
+			Id:                     t.PublicId,
+			Name:                   t.Name,
+			Description:            t.Description,
+			Scope:                  scopeInfoToProto(t.Scope),
+			SessionMaxSeconds:      t.SessionMaxSeconds,
+			SessionConnectionLimit: t.SessionConnectionLimit,
+			WorkerFilter:           t.WorkerFilter,
+			EgressWorkerFilter:     t.EgressWorkerFilter,
+			IngressWorkerFilter:    t.IngressWorkerFilter,
+			Type:                   ssh.Subtype.String(),
+			Attrs: &pb.Target_SshTargetAttributes{
+				SshTargetAttributes: &pb.SshTargetAttributes{
+					DefaultPort:       t.DefaultPort,
+					DefaultClientPort: t.DefaultClientPort,
+				},
+			},
+		}
+	}
+
+	switch {
+	case in.StaticHost != nil:
+		h := in.StaticHost
+		out.Host = &pb.Host{
+			Id: h.PublicId,
+			HostCatalog: &pb.HostCatalog{
+				Id:          h.Catalog.PublicId,
+				Scope:       &scopes.ScopeInfo{Id: h.Catalog.ProjectId, Type: scope.Project.String()},
+				Name:        h.Catalog.Name,
+				Description: h.Catalog.Description,
+				Type:        static.Subtype.String(),
+			},
+			Name:        h.Name,
+			Description: h.Description,
+			Type:        static.Subtype.String(),
+			Attrs: &pb.Host_StaticHostAttributes{
+				StaticHostAttributes: &pb.StaticHostAttributes{
+					Address: h.Address,
+				},
+			},
+		}
+	case in.DynamicHost != nil:
+		h := in.DynamicHost
+		hc := &pb.HostCatalog{
+			Id:          h.Catalog.PublicId,
+			Scope:       &scopes.ScopeInfo{Id: h.Catalog.ProjectId, Type: scope.Project.String()},
+			PluginId:    h.Catalog.PluginId,
+			Name:        h.Catalog.Name,
+			Description: h.Catalog.Description,
+			Type:        plugin.Subtype.String(),
+		}
+		if h.Catalog.Attributes != "" {
+			attrs := &structpb.Struct{}
+			if err := protojson.Unmarshal([]byte(h.Catalog.Attributes), attrs); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decode host catalog attributes"))
+			}
+			hc.Attrs = &pb.HostCatalog_Attributes{Attributes: attrs}
+		}
+		out.Host = &pb.Host{
+			Id:           h.PublicId,
+			HostCatalog:  hc,
+			Name:         h.Name,
+			Description:  h.Description,
+			Type:         plugin.Subtype.String(),
+			ExternalId:   h.ExternalId,
+			ExternalName: h.ExternalName,
+		}
+	}
+
+	for _, c := range in.StaticJSONCredentials {
+		out.Credentials = append(out.Credentials, &pb.Credential{
+			Id:              c.PublicId,
+			CredentialStore: staticCredentialStoreToProto(c.CredentialStore),
+			Name:            c.Name,
+			Description:     c.Description,
+			Purposes:        c.Purposes,
+			Type:            credential.JsonSubtype.String(),
+			Attrs: &pb.Credential_JsonAttributes{
+				JsonAttributes: &pb.JsonCredentialAttributes{
+					ObjectHmac: base64.RawURLEncoding.EncodeToString(c.ObjectHmac),
+				},
+			},
+		})
+	}
+	for _, c := range in.StaticUsernamePasswordCredentials {
+		out.Credentials = append(out.Credentials, &pb.Credential{
+			Id:              c.PublicId,
+			CredentialStore: staticCredentialStoreToProto(c.CredentialStore),
+			Name:            c.Name,
+			Description:     c.Description,
+			Purposes:        c.Purposes,
+			Type:            credential.UsernamePasswordSubtype.String(),
+			Attrs: &pb.Credential_UsernamePasswordAttributes{
+				UsernamePasswordAttributes: &pb.UsernamePasswordCredentialAttributes{
+					Username:     c.Username,
+					PasswordHmac: base64.RawURLEncoding.EncodeToString(c.PasswordHmac),
+				},
+			},
+		})
+	}
+	for _, c := range in.StaticSshPrivateKeyCredentials {
+		attrs := &pb.SshPrivateKeyCredentialAttributes{
+			Username:       c.Username,
+			PrivateKeyHmac: base64.RawURLEncoding.EncodeToString(c.PrivateKeyHmac),
+		}
+		if len(c.PrivateKeyPassphraseHmac) > 0 {
+			attrs.PrivateKeyPassphraseHmac = base64.RawURLEncoding.EncodeToString(c.PrivateKeyPassphraseHmac)
+		}
+		out.Credentials = append(out.Credentials, &pb.Credential{
+			Id:              c.PublicId,
+			CredentialStore: staticCredentialStoreToProto(c.CredentialStore),
+			Name:            c.Name,
+			Description:     c.Description,
+			Purposes:        c.Purposes,
+			Type:            credential.SshPrivateKeySubtype.String(),
+			Attrs:           &pb.Credential_SshPrivateKeyAttributes{SshPrivateKeyAttributes: attrs},
+		})
+	}
+
+	for _, l := range in.VaultGenericLibraries {
+		out.CredentialLibraries = append(out.CredentialLibraries, &pb.CredentialLibrary{
+			Id:              l.PublicId,
+			CredentialStore: vaultCredentialStoreToProto(l.CredentialStore),
+			Name:            l.Name,
+			Description:     l.Description,
+			Purposes:        l.Purposes,
+			Type:            vault.GenericLibrarySubtype.String(),
+			Attrs: &pb.CredentialLibrary_VaultGenericCredentialLibraryAttributes{
+				VaultGenericCredentialLibraryAttributes: &pb.VaultCredentialLibraryAttributes{
+					Path:            l.VaultPath,
+					HttpMethod:      l.HttpMethod,
+					HttpRequestBody: string(l.HttpRequestBody),
+				},
+			},
+		})
+	}
+	for _, l := range in.VaultSshCertificateLibraries {
+		attrs := &pb.VaultSSHCertificateCredentialLibraryAttributes{
+			Path:     l.VaultPath,
+			Username: l.Username,
+			KeyType:  l.KeyType,
+			KeyBits:  uint32(l.KeyBits),
+			Ttl:      l.Ttl,
+		}
+		if len(l.CriticalOptions) > 0 {
+			if err := json.Unmarshal(l.CriticalOptions, &attrs.CriticalOptions); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decode critical options"))
+			}
+		}
+		if len(l.Extensions) > 0 {
+			if err := json.Unmarshal(l.Extensions, &attrs.Extensions); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decode extensions"))
+			}
+		}
+		out.CredentialLibraries = append(out.CredentialLibraries, &pb.CredentialLibrary{
+			Id:              l.PublicId,
+			CredentialStore: vaultCredentialStoreToProto(l.CredentialStore),
+			Name:            l.Name,
+			Description:     l.Description,
+			Purposes:        l.Purposes,
+			Type:            vault.SSHCertificateLibrarySubtype.String(),
+			Attrs: &pb.CredentialLibrary_VaultSshCertificateCredentialLibraryAttributes{
+				VaultSshCertificateCredentialLibraryAttributes: attrs,
+			},
+		})
+	}
+	return out, nil
+}
+
+func staticCredentialStoreToProto(in bsr.StaticCredentialStore) *pb.CredentialStore {
+	return &pb.CredentialStore{
+		Id:          in.PublicId,
+		ScopeId:     in.ProjectId,
+		Name:        in.Name,
+		Description: in.Description,
+		Type:        credstatic.Subtype.String(),
+	}
+}
+
+func vaultCredentialStoreToProto(in bsr.VaultCredentialStore) *pb.CredentialStore {
+	return &pb.CredentialStore{
+		Id:          in.PublicId,
+		ScopeId:     in.ProjectId,
+		Name:        in.Name,
+		Description: in.Description,
+		Type:        vault.Subtype.String(),
+		Attrs: &pb.CredentialStore_VaultCredentialStoreAttributes{
+			VaultCredentialStoreAttributes: &pb.VaultCredentialStoreAttributes{
+				Address:       in.VaultAddress,
+				Namespace:     in.Namespace,
+				TlsServerName: in.TlsServerName,
+				TlsSkipVerify: in.TlsSkipVerify,
+				WorkerFilter:  in.WorkerFilter,
+			},
+		},
+	}
+}
+
+func validateGetRequest(req *pbs.GetSessionRecordingRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.SessionRecordingPrefix, globals.SessionPrefix)
+}
+
+func validateDownloadRequest(req *pbs.DownloadRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.ChannelRecordingPrefix) {
+		badFields[globals.IdField] = "Only channel recordings can be downloaded."
+	}
+	switch req.GetMimeType() {
+	case "", asciicastMimeType, transcriptMimeType, jsonlMimeType:
+	default:
+		badFields[globals.MimeTypeField] = fmt.Sprintf("Must be one of %q, %q or %q.", asciicastMimeType, transcriptMimeType, jsonlMimeType)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateSearchRequest(req *pbs.SearchSessionRecordingsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), globals.OrgPrefix) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+	}
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		badFields[globals.QueryField] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListSessionRecordingsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetScopeId()), globals.OrgPrefix) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields[globals.ScopeIdField] = "This field must be 'global' or a valid org scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
 }
@@ -0,0 +1,427 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session_recordings
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/globals"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/recording"
+	"github.com/hashicorp/boundary/internal/session"
+	pluginstorage "github.com/hashicorp/boundary/internal/storage/plugin"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw, kms)
+	}
+	storageRepoFn := func() (*pluginstorage.Repository, error) {
+		return pluginstorage.NewRepository(ctx, rw, rw, kms)
+	}
+	repo, err := repoFn()
+	require.NoError(t, err)
+
+	sess := recording.TestRecordedSession(t, conn, wrapper, iamRepo)
+	c := session.TestConnection(t, conn, sess.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+	cr, _, err := repo.StartConnectionRecording(ctx, sess.PublicId, c.PublicId)
+	require.NoError(t, err)
+	require.NotNil(t, cr)
+
+	s, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kms, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name string
+		id   string
+		err  error
+	}{
+		{
+			name: "success",
+			id:   cr.GetRecordingSessionId(),
+		},
+		{
+			name: "success by session id",
+			id:   sess.PublicId,
+		},
+		{
+			name: "not found",
+			id:   fmt.Sprintf("%s_1234567890", globals.SessionRecordingPrefix),
+			err:  handlers.NotFoundError(),
+		},
+		{
+			name: "bad prefix",
+			id:   fmt.Sprintf("%s_1234567890", globals.StaticHostPrefix),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := &pbs.GetSessionRecordingRequest{Id: tc.id}
+			got, gErr := s.GetSessionRecording(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetSessionRecording(%q) got error %v, wanted %v", req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.Equal(cr.GetRecordingSessionId(), item.GetId())
+			assert.Equal(sess.PublicId, item.GetSessionId())
+			assert.Equal("ssh", item.GetType())
+			assert.Equal(string(recording.StateStarted), item.GetState())
+			assert.Equal(scope.Org.String(), item.GetScope().GetType())
+			require.Len(item.GetConnectionRecordings(), 1)
+			assert.Equal(cr.GetPublicId(), item.GetConnectionRecordings()[0].GetId())
+
+			values := item.GetCreateTimeValues()
+			require.NotNil(values)
+			assert.Equal(sess.UserId, values.GetUser().GetId())
+			assert.Equal(sess.TargetId, values.GetTarget().GetId())
+			assert.Equal(uint32(22), values.GetTarget().GetSshTargetAttributes().GetDefaultPort())
+			assert.Equal(sess.HostId, values.GetHost().GetId())
+			assert.Equal("static", values.GetHost().GetType())
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw, kms)
+	}
+	storageRepoFn := func() (*pluginstorage.Repository, error) {
+		return pluginstorage.NewRepository(ctx, rw, rw, kms)
+	}
+	repo, err := repoFn()
+	require.NoError(t, err)
+
+	var wantIds []string
+	for i := 0; i < 3; i++ {
+		sess := recording.TestRecordedSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, sess.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, _, err := repo.StartConnectionRecording(ctx, sess.PublicId, c.PublicId)
+		require.NoError(t, err)
+		wantIds = append(wantIds, cr.GetRecordingSessionId())
+	}
+
+	s, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kms, nil)
+	require.NoError(t, err)
+
+	t.Run("recursive", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req := &pbs.ListSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true}
+		got, err := s.ListSessionRecordings(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+		require.NoError(err)
+		var gotIds []string
+		for _, item := range got.GetItems() {
+			gotIds = append(gotIds, item.GetId())
+			assert.Equal("ssh", item.GetType())
+			assert.NotNil(item.GetCreateTimeValues().GetTarget())
+		}
+		assert.ElementsMatch(wantIds, gotIds)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		req := &pbs.ListSessionRecordingsRequest{ScopeId: scope.Global.String()}
+		got, err := s.ListSessionRecordings(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+		require.NoError(t, err)
+		assert.Empty(t, got.GetItems())
+	})
+
+	t.Run("project scope", func(t *testing.T) {
+		req := &pbs.ListSessionRecordingsRequest{ScopeId: "p_1234567890"}
+		_, err := s.ListSessionRecordings(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
+
+type testDownloadStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	body []byte
+}
+
+func (s *testDownloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testDownloadStream) Send(b *httpbody.HttpBody) error {
+	s.body = append(s.body, b.GetData()...)
+	return nil
+}
+
+func TestDownload(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	bsrWrapper := bsrkms.TestWrapper(t)
+	require.NoError(t, kmsCache.AddExternalWrappers(ctx, kms.WithBsrWrapper(bsrWrapper)))
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	storageRepoFn := func() (*pluginstorage.Repository, error) {
+		return pluginstorage.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	sr, plugins := recording.TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+	channelId := sr.ConnectionRecordings[0].ChannelRecordings[0].GetPublicId()
+
+	s, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kmsCache, plugins)
+	require.NoError(t, err)
+	noPlugins, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kmsCache, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		s        Service
+		id       string
+		mimeType string
+		want     []string
+		wantCode codes.Code
+	}{
+		{
+			name:     "transcript",
+			s:        s,
+			id:       channelId,
+			mimeType: transcriptMimeType,
+			want:     []string{"outbound hello\n"},
+		},
+		{
+			name:     "jsonl",
+			s:        s,
+			id:       channelId,
+			mimeType: jsonlMimeType,
+			want:     []string{`"direction":"inbound"`, `"request_type":"exec"`, `"command":"ls"`},
+		},
+		{
+			name:     "asciicast of exec channel",
+			s:        s,
+			id:       channelId,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "unsupported mime type",
+			s:        s,
+			id:       channelId,
+			mimeType: "application/pdf",
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "session recording id",
+			s:        s,
+			id:       sr.GetPublicId(),
+			mimeType: jsonlMimeType,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "not found",
+			s:        s,
+			id:       fmt.Sprintf("%s_1234567890", globals.ChannelRecordingPrefix),
+			mimeType: jsonlMimeType,
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "storage plugin not available",
+			s:        noPlugins,
+			id:       channelId,
+			mimeType: jsonlMimeType,
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			stream := &testDownloadStream{ctx: auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String())}
+			err := tc.s.Download(&pbs.DownloadRequest{Id: tc.id, MimeType: tc.mimeType}, stream)
+			if tc.wantCode != codes.OK {
+				require.Error(err)
+				assert.Equal(tc.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			for _, w := range tc.want {
+				assert.True(strings.Contains(string(stream.body), w), "%q does not contain %q", stream.body, w)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	bsrWrapper := bsrkms.TestWrapper(t)
+	require.NoError(t, kmsCache.AddExternalWrappers(ctx, kms.WithBsrWrapper(bsrWrapper)))
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*recording.Repository, error) {
+		return recording.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	storageRepoFn := func() (*pluginstorage.Repository, error) {
+		return pluginstorage.NewRepository(ctx, rw, rw, kmsCache)
+	}
+
+	plugins := map[string]plgpb.StoragePluginServiceClient{}
+	var indexed []*recording.SessionRecording
+	for i := 0; i < 2; i++ {
+		sr, plgm := recording.TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+		for id, client := range plgm {
+			plugins[id] = client
+		}
+		indexed = append(indexed, sr)
+	}
+	recording.TestIndexSessionRecordings(t, conn, kmsCache, plugins)
+	// Session recordings are only searched once they are indexed.
+	unindexed, plgm := recording.TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+	for id, client := range plgm {
+		plugins[id] = client
+	}
+
+	s, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kmsCache, plugins)
+	require.NoError(t, err)
+	limited, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kmsCache, plugins, handlers.WithMaxPageSize(1))
+	require.NoError(t, err)
+	noPlugins, err := NewService(ctx, iamRepoFn, repoFn, storageRepoFn, kmsCache, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		s        Service
+		req      *pbs.SearchSessionRecordingsRequest
+		want     []*recording.SessionRecording
+		wantCode codes.Code
+	}{
+		{
+			name: "recursive",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: "hello"},
+			want: indexed,
+		},
+		{
+			name: "ignores case",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: "HELLO"},
+			want: indexed,
+		},
+		{
+			name: "org scope",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: indexed[1].StorageBucketScopeId, Query: "hello"},
+			want: indexed[1:],
+		},
+		{
+			name: "org scope of unindexed recording",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: unindexed.StorageBucketScopeId, Query: "hello"},
+		},
+		{
+			name: "global",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Query: "hello"},
+		},
+		{
+			name: "no match",
+			s:    s,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: "goodbye"},
+		},
+		{
+			name: "limited",
+			s:    limited,
+			req:  &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: "hello"},
+			want: indexed[:1],
+		},
+		{
+			name:     "missing query",
+			s:        s,
+			req:      &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: " "},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "project scope",
+			s:        s,
+			req:      &pbs.SearchSessionRecordingsRequest{ScopeId: "p_1234567890", Query: "hello"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "storage plugin not available",
+			s:        noPlugins,
+			req:      &pbs.SearchSessionRecordingsRequest{ScopeId: scope.Global.String(), Recursive: true, Query: "hello"},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := tc.s.SearchSessionRecordings(auth.DisabledAuthTestContext(iamRepoFn, scope.Global.String()), tc.req)
+			if tc.wantCode != codes.OK {
+				require.Error(err)
+				assert.Equal(tc.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			require.Len(got.GetItems(), len(tc.want))
+			for i, sr := range tc.want {
+				item := got.GetItems()[i]
+				cr := sr.ConnectionRecordings[0]
+				assert.Equal(sr.GetPublicId(), item.GetSessionRecordingId())
+				assert.Equal(cr.GetPublicId(), item.GetConnectionRecordingId())
+				assert.Equal(cr.ChannelRecordings[0].GetPublicId(), item.GetChannelRecordingId())
+				assert.Equal("hello", item.GetLine())
+				assert.NotNil(item.GetTime())
+				assert.NotNil(item.GetOffset())
+			}
+		})
+	}
+}
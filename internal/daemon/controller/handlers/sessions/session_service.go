@@ -4,16 +4,24 @@
 package sessions
 
 import (
+	"bytes"
 	"context"
 	stderrors "errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/session"
@@ -24,6 +32,7 @@ import (
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/sessions"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -35,6 +44,7 @@ var (
 		action.ReadSelf,
 		action.Cancel,
 		action.CancelSelf,
+		action.Watch,
 	)
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -44,6 +54,14 @@ var (
 	)
 )
 
+// The long poll of WatchSession waits up to watchPollTimeout for output of the
+// session, checking for it every watchPollInterval. They can be overridden in
+// tests.
+var (
+	watchPollTimeout  = 10 * time.Second
+	watchPollInterval = 500 * time.Millisecond
+)
+
 func init() {
 	// TODO: refactor to remove IdActions and CollectionActions package variables
 	action.RegisterResource(resource.Session, IdActions, CollectionActions)
@@ -53,14 +71,15 @@ func init() {
 type Service struct {
 	pbs.UnsafeSessionServiceServer
 
-	repoFn    session.RepositoryFactory
-	iamRepoFn common.IamRepoFactory
+	repoFn      session.RepositoryFactory
+	iamRepoFn   common.IamRepoFactory
+	maxPageSize uint
 }
 
 var _ pbs.SessionServiceServer = (*Service)(nil)
 
 // NewService returns a session service which handles session related requests to boundary.
-func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn common.IamRepoFactory, opt ...handlers.Option) (Service, error) {
 	const op = "sessions.NewService"
 	if repoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing session repository")
@@ -68,7 +87,7 @@ func NewService(ctx context.Context, repoFn session.RepositoryFactory, iamRepoFn
 	if iamRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // GetSessions implements the interface pbs.SessionServiceServer.
@@ -159,7 +178,7 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		}
 	}
 
-	listPerms := authResults.ACL().ListPermissions(scopeIds, resource.Session, IdActions, authResults.UserId)
+	listPerms := authResults.ACL().ListPermissions(scopeIds, resource.Session, IdActions, authResults.UserId, authResults.ACLOptions()...)
 
 	repo, err := s.repoFn(session.WithPermissions(&perms.UserPermissions{
 		UserId:      authResults.UserId,
@@ -169,28 +188,27 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 		return nil, errors.Wrap(ctx, err, op)
 	}
 
-	sesList, err := repo.ListSessions(ctx, session.WithTerminated(req.GetIncludeTerminated()), session.WithLimit(-1))
-	if err != nil {
-		return nil, err
-	}
-	if len(sesList) == 0 {
-		return &pbs.ListSessionsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Session, 0, len(sesList))
 	res := perms.Resource{
 		Type: resource.Session,
 	}
-	for _, item := range sesList {
+	protos := make(map[string]*pb.Session)
+	var terminatedIds []string
+	filterItemFn := func(ctx context.Context, item *session.Session) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetProjectId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
+		}
+		if item.TerminationReason != "" && !req.GetIncludeTerminated() {
+			// Terminated sessions are only listed when refreshing, so that
+			// they can be reported as removed.
+			terminatedIds = append(terminatedIds, item.GetPublicId())
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -203,17 +221,51 @@ func (s Service) ListSessions(ctx context.Context, req *pbs.ListSessionsRequest)
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, updatedAfter time.Time, prevPageLastItem pagination.Item, limit int) ([]*session.Session, error) {
+		return repo.ListSessions(ctx,
+			session.WithTerminated(req.GetIncludeTerminated() || !updatedAfter.IsZero()),
+			session.WithLimit(limit),
+			session.WithStartPageAfterItem(prevPageLastItem),
+			session.WithUpdatedAfter(updatedAfter),
+		)
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		return repo.ListDeletedIds(ctx, since)
 	}
 
-	return &pbs.ListSessionsResponse{Items: finalItems}, nil
+	listResp, err := handlers.PaginatedRefreshableList(ctx, req, s.maxPageSize, resource.Session, authResults.GrantsHash, filterItemFn, listItemsFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Session, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := handlers.RefreshToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListSessionsResponse{
+		Items:         finalItems,
+		NextPageToken: nextPageToken,
+		RefreshToken:  refreshToken,
+		RemovedIds:    append(listResp.RemovedIds, terminatedIds...),
+	}, nil
 }
 
 // CancelSession implements the interface pbs.SessionServiceServer.
@@ -300,6 +352,95 @@ func (s Service) CancelSession(ctx context.Context, req *pbs.CancelSessionReques
 	return &pbs.CancelSessionResponse{Item: item}, nil
 }
 
+// WatchSession implements the interface pbs.SessionServiceServer.
+func (s Service) WatchSession(ctx context.Context, req *pbs.WatchSessionRequest) (*pbs.WatchSessionResponse, error) {
+	const op = "sessions.(Service).WatchSession"
+
+	if err := validateWatchRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Watch, false)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ses, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(ses.Endpoint, "ssh://") {
+		return nil, handlers.InvalidArgumentErrorf("Only SSH sessions can be watched.", map[string]string{"id": "The session is not an SSH session."})
+	}
+
+	var w *session.Watch
+	if req.GetWatchId() == "" {
+		w, err = repo.CreateWatch(ctx, ses.PublicId, authResults.UserId)
+		if err != nil {
+			if errors.Match(errors.T(errors.InvalidSessionState), err) {
+				return nil, handlers.InvalidArgumentErrorf("Only pending or active sessions can be watched.", map[string]string{"id": "The session is not pending or active."})
+			}
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create watch"))
+		}
+		writeWatchAuditEvent(ctx, op, authResults.UserId, w)
+	} else {
+		w, err = repo.LookupWatch(ctx, req.GetWatchId())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up watch"))
+		}
+		// Watches can only be used by the user which created them
+		if w == nil || w.SessionId != ses.PublicId || w.UserId != authResults.UserId {
+			return nil, handlers.NotFoundErrorf("Watch %q doesn't exist.", req.GetWatchId())
+		}
+	}
+
+	// Wait for output until the session is no longer active or the poll
+	// times out.
+	timeout := time.NewTimer(watchPollTimeout)
+	defer timeout.Stop()
+	var output []*session.WatchOutput
+poll:
+	for {
+		output, err = repo.ListWatchOutput(ctx, w.PublicId, req.GetPosition())
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to list output"))
+		}
+		if len(output) > 0 || !watchable(ses) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			break poll
+		case <-time.After(watchPollInterval):
+		}
+		if ses, err = s.getFromRepo(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &pbs.WatchSessionResponse{
+		WatchId:  w.PublicId,
+		Position: req.GetPosition(),
+	}
+	if len(ses.States) > 0 {
+		resp.Status = ses.States[0].Status.String()
+	}
+	for _, o := range output {
+		out, err := decodeWatchOutput(ctx, o.Data)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		resp.Output = append(resp.Output, out...)
+		resp.Position = o.Position
+	}
+	return resp, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*session.Session, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -340,7 +481,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, ignor
 			res.Error = handlers.NotFoundError()
 			return res
 		}
-	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf:
+	case action.Read, action.ReadSelf, action.Cancel, action.CancelSelf, action.Watch:
 		repo, err := s.repoFn()
 		if err != nil {
 			res.Error = err
@@ -365,6 +506,64 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type, ignor
 	return auth.Verify(ctx, opts...)
 }
 
+// watchable returns whether output of the session can still be watched.
+func watchable(ses *session.Session) bool {
+	if len(ses.States) == 0 {
+		return false
+	}
+	switch ses.States[0].Status {
+	case session.StatusPending, session.StatusActive:
+		return true
+	}
+	return false
+}
+
+// decodeWatchOutput decodes the BSR data chunks forwarded by the worker
+// proxying the session.
+func decodeWatchOutput(ctx context.Context, data []byte) ([]*pbs.SessionOutput, error) {
+	const op = "sessions.decodeWatchOutput"
+	dec, err := bsr.NewChunkDecoder(ctx, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	var ret []*pbs.SessionOutput
+	for {
+		c, err := dec.Decode(ctx)
+		if err == io.EOF {
+			return ret, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decode output"))
+		}
+		dc, ok := c.(*bsrssh.DataChunk)
+		if !ok || dc.Direction != bsr.Outbound {
+			continue
+		}
+		ret = append(ret, &pbs.SessionOutput{
+			Time: timestamppb.New(dc.Timestamp.AsTime()),
+			Data: dc.Data,
+		})
+	}
+}
+
+// writeWatchAuditEvent writes an audit event for the creation of a watch, so
+// that every watch of a session can be attributed to the user watching it.
+func writeWatchAuditEvent(ctx context.Context, op errors.Op, userId string, w *session.Watch) {
+	if err := event.WriteAudit(ctx, event.Op(op),
+		event.WithAuth(&event.Auth{UserInfo: &event.UserInfo{UserId: userId}}),
+		event.WithRequest(&event.Request{
+			Operation: "watch",
+			Endpoint:  fmt.Sprintf("sessions/%s", w.SessionId),
+			Details: &pbs.WatchSessionRequest{
+				Id:      w.SessionId,
+				WatchId: w.PublicId,
+			},
+		})); err != nil {
+		// error was NOT event'd above...
+		_ = errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("error writing session watch audit event"))
+	}
+}
+
 func toProto(ctx context.Context, in *session.Session, opt ...handlers.Option) (*pb.Session, error) {
 	opts := handlers.GetOpts(opt...)
 	if opts.WithOutputFields == nil {
@@ -506,3 +705,20 @@ func validateCancelRequest(req *pbs.CancelSessionRequest) error {
 	}
 	return nil
 }
+
+func validateWatchRequest(req *pbs.WatchSessionRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(handlers.Id(req.GetId()), globals.SessionPrefix) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetWatchId() != "" && !handlers.ValidId(handlers.Id(req.GetWatchId()), session.WatchPrefix) {
+		badFields["watch_id"] = "Improperly formatted identifier."
+	}
+	if req.GetWatchId() == "" && req.GetPosition() != 0 {
+		badFields["position"] = "This field can only be set along with a watch id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
@@ -4,9 +4,11 @@
 package sessions_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +16,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/sessions"
@@ -556,6 +560,7 @@ func TestList(t *testing.T) {
 				got,
 				tc.res,
 				protocmp.Transform(),
+				protocmp.IgnoreFields(&pbs.ListSessionsResponse{}, "refresh_token"),
 				cmpopts.SortSlices(func(a, b string) bool {
 					return a < b
 				}),
@@ -809,3 +814,198 @@ func TestCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestWatch(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+
+	iamRepo := iam.TestRepo(t, conn, wrap)
+
+	rw := db.New(conn)
+
+	ctx := context.Background()
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	sessRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kms, opt...)
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(ctx, rw, rw, kms)
+	}
+	sessRepo, err := sessRepoFn()
+	require.NoError(t, err)
+	connRepo, err := session.NewConnectionRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	o, p := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	adminAt := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	otherAdminAt := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	adminRole := iam.TestRole(t, conn, p.GetPublicId())
+	iam.TestRoleGrant(t, conn, adminRole.GetPublicId(), "id=*;type=*;actions=*")
+	iam.TestUserRole(t, conn, adminRole.GetPublicId(), adminAt.GetIamUserId())
+	iam.TestUserRole(t, conn, adminRole.GetPublicId(), otherAdminAt.GetIamUserId())
+
+	hc := static.TestCatalogs(t, conn, p.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := tcp.TestTarget(context.Background(), t, conn, p.GetPublicId(), "test", target.WithHostSources([]string{hs.GetPublicId()}))
+	worker := server.TestKmsWorker(t, conn, wrap)
+
+	newSession := func(t *testing.T, endpoint string) *session.Session {
+		sess := session.TestSession(t, conn, wrap, session.ComposedOf{
+			UserId:      at.GetIamUserId(),
+			HostId:      h.GetPublicId(),
+			TargetId:    tar.GetPublicId(),
+			HostSetId:   hs.GetPublicId(),
+			AuthTokenId: at.GetPublicId(),
+			ProjectId:   p.GetPublicId(),
+			Endpoint:    endpoint,
+		})
+		sess, _, err := sessRepo.ActivateSession(ctx, sess.GetPublicId(), sess.Version, session.TestTofu(t))
+		require.NoError(t, err)
+		_, _, _, err = session.AuthorizeConnection(ctx, sessRepo, connRepo, sess.GetPublicId(), worker.GetPublicId())
+		require.NoError(t, err)
+		return sess
+	}
+	requestContext := func(at *authtoken.AuthToken) context.Context {
+		requestInfo := authpb.RequestInfo{
+			TokenFormat: uint32(auth.AuthTokenTypeBearer),
+			PublicId:    at.GetPublicId(),
+			Token:       at.GetToken(),
+		}
+		requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+		return auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+	}
+	// encodeOutput encodes data the way workers forward the output of
+	// watched sessions
+	encodeOutput := func(t *testing.T, data string) []byte {
+		var buf bytes.Buffer
+		enc, err := bsr.NewChunkEncoder(ctx, &buf, bsr.NoCompression, bsr.NoEncryption)
+		require.NoError(t, err)
+		c, err := bsrssh.NewDataChunk(ctx, bsr.Outbound, bsr.NewTimestamp(time.Now()), []byte(data))
+		require.NoError(t, err)
+		_, err = enc.Encode(ctx, c)
+		require.NoError(t, err)
+		return buf.Bytes()
+	}
+
+	s, err := sessions.NewService(ctx, sessRepoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new session service.")
+
+	t.Run("invalid-requests", func(t *testing.T) {
+		sess := newSession(t, "ssh://127.0.0.1:22")
+		cases := []struct {
+			name string
+			at   *authtoken.AuthToken
+			req  *pbs.WatchSessionRequest
+			err  error
+		}{
+			{
+				name: "Wrong id prefix",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: "j_1234567890"},
+				err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+			},
+			{
+				name: "Wrong watch id prefix",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: sess.GetPublicId(), WatchId: "j_1234567890"},
+				err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+			},
+			{
+				name: "Position without watch id",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: sess.GetPublicId(), Position: 10},
+				err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+			},
+			{
+				name: "Non existing session",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: globals.SessionPrefix + "_DoesntExis"},
+				err:  handlers.ApiErrorWithCode(codes.NotFound),
+			},
+			{
+				name: "Non existing watch",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: sess.GetPublicId(), WatchId: session.WatchPrefix + "_DoesntExis"},
+				err:  handlers.ApiErrorWithCode(codes.NotFound),
+			},
+			{
+				name: "Not an ssh session",
+				at:   adminAt,
+				req:  &pbs.WatchSessionRequest{Id: newSession(t, "tcp://127.0.0.1:22").GetPublicId()},
+				err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+			},
+			{
+				// Users can't watch their own sessions without being granted
+				// the watch action
+				name: "Not authorized",
+				at:   at,
+				req:  &pbs.WatchSessionRequest{Id: sess.GetPublicId()},
+				err:  handlers.ForbiddenError(),
+			},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				got, err := s.WatchSession(requestContext(tc.at), tc.req)
+				require.Error(err)
+				assert.Nil(got)
+				assert.True(errors.Is(err, tc.err), "WatchSession(%+v) got error %v, wanted %v", tc.req, err, tc.err)
+			})
+		}
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		sess := newSession(t, "ssh://127.0.0.1:22")
+
+		// Forward output until the first request returns, since output of
+		// the session is only kept once it is watched.
+		done := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-done:
+					return
+				case <-time.After(100 * time.Millisecond):
+				}
+				assert.NoError(sessRepo.AddWatchOutput(ctx, sess.GetPublicId(), worker.GetPublicId(), encodeOutput(t, "hello")))
+			}
+		}()
+		got, err := s.WatchSession(requestContext(adminAt), &pbs.WatchSessionRequest{Id: sess.GetPublicId()})
+		close(done)
+		require.NoError(err)
+		assert.True(strings.HasPrefix(got.GetWatchId(), session.WatchPrefix+"_"))
+		assert.Equal(session.StatusActive.String(), got.GetStatus())
+		assert.NotZero(got.GetPosition())
+		require.NotEmpty(got.GetOutput())
+		assert.Equal([]byte("hello"), got.GetOutput()[0].GetData())
+		assert.NotNil(got.GetOutput()[0].GetTime())
+
+		// Watches can't be used by other users
+		_, err = s.WatchSession(requestContext(otherAdminAt), &pbs.WatchSessionRequest{Id: sess.GetPublicId(), WatchId: got.GetWatchId()})
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+
+		// Once the session is canceled the remaining output is returned
+		// without waiting for more.
+		_, err = sessRepo.CancelSession(ctx, sess.GetPublicId(), sess.Version)
+		require.NoError(err)
+		next, err := s.WatchSession(requestContext(adminAt), &pbs.WatchSessionRequest{Id: sess.GetPublicId(), WatchId: got.GetWatchId(), Position: got.GetPosition()})
+		require.NoError(err)
+		assert.Equal(got.GetWatchId(), next.GetWatchId())
+		assert.Equal(session.StatusCanceling.String(), next.GetStatus())
+		assert.GreaterOrEqual(next.GetPosition(), got.GetPosition())
+
+		// New watches of canceled sessions can't be created
+		_, err = s.WatchSession(requestContext(adminAt), &pbs.WatchSessionRequest{Id: sess.GetPublicId()})
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+}
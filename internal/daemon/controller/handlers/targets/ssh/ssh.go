@@ -0,0 +1,214 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	sshStore "github.com/hashicorp/boundary/internal/target/ssh/store"
+	"github.com/hashicorp/boundary/internal/target/store"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
+)
+
+const (
+	defaultPortField               = "attributes.default_port"
+	defaultClientPortField         = "attributes.default_client_port"
+	storageBucketIdField           = "attributes.storage_bucket_id"
+	enableSessionRecordingField    = "attributes.enable_session_recording"
+	recordingCompressionField      = "attributes.recording_compression"
+	recordingCompressionLevelField = "attributes.recording_compression_level"
+	hostKeysField                  = "attributes.host_keys"
+)
+
+type attribute struct {
+	*pb.SshTargetAttributes
+}
+
+func (a *attribute) Options() []target.Option {
+	var opts []target.Option
+	if a.GetDefaultPort().GetValue() != 0 {
+		opts = append(opts, target.WithDefaultPort(a.GetDefaultPort().GetValue()))
+	} else {
+		opts = append(opts, target.WithDefaultPort(ssh.DefaultPort))
+	}
+	if a.GetDefaultClientPort().GetValue() != 0 {
+		opts = append(opts, target.WithDefaultClientPort(a.GetDefaultClientPort().GetValue()))
+	}
+	if a.GetStorageBucketId().GetValue() != "" {
+		opts = append(opts, target.WithStorageBucketId(a.GetStorageBucketId().GetValue()))
+	}
+	if a.GetEnableSessionRecording().GetValue() {
+		opts = append(opts, target.WithEnableSessionRecording(true))
+	}
+	if a.GetRecordingCompression().GetValue() != "" {
+		opts = append(opts, target.WithRecordingCompression(a.GetRecordingCompression().GetValue()))
+	}
+	if a.GetRecordingCompressionLevel().GetValue() != 0 {
+		opts = append(opts, target.WithRecordingCompressionLevel(a.GetRecordingCompressionLevel().GetValue()))
+	}
+	if a.GetHostKeys().GetValue() != "" {
+		opts = append(opts, target.WithHostKeys(a.GetHostKeys().GetValue()))
+	}
+	return opts
+}
+
+func (a *attribute) Vet() map[string]string {
+	badFields := map[string]string{}
+	if a.GetDefaultPort() != nil {
+		if a.GetDefaultPort().GetValue() == 0 {
+			badFields[defaultPortField] = "This field cannot be set to zero."
+		}
+		if a.GetDefaultPort().GetValue() > math.MaxUint16 {
+			badFields[defaultPortField] = "Value is greater than maximum port number."
+		}
+	}
+	if a.GetDefaultClientPort() != nil {
+		if a.GetDefaultClientPort().GetValue() == 0 {
+			badFields[defaultClientPortField] = "This field cannot be set to zero."
+		}
+		if a.GetDefaultClientPort().GetValue() > math.MaxUint16 {
+			badFields[defaultClientPortField] = "Value is greater than maximum port number."
+		}
+	}
+	if id := a.GetStorageBucketId(); id != nil && !handlers.ValidId(handlers.Id(id.GetValue()), globals.PluginStorageBucketPrefix) {
+		badFields[storageBucketIdField] = "Incorrectly formatted identifier."
+	}
+	if a.GetEnableSessionRecording().GetValue() && a.GetStorageBucketId().GetValue() == "" {
+		badFields[enableSessionRecordingField] = "Session recording requires a storage bucket."
+	}
+	compression := bsr.NoCompression
+	if c := a.GetRecordingCompression(); c != nil {
+		var err error
+		if compression, err = bsr.ParseCompression(c.GetValue()); err != nil {
+			badFields[recordingCompressionField] = `Must be one of "none", "gzip" or "zstd".`
+		}
+	}
+	if l := a.GetRecordingCompressionLevel(); l != nil && !bsr.ValidCompressionLevel(compression, int(l.GetValue())) {
+		badFields[recordingCompressionLevelField] = fmt.Sprintf("Invalid level for %s compression.", compression)
+	}
+	if k := a.GetHostKeys(); k != nil {
+		if _, err := ssh.ParseHostKeys(context.Background(), k.GetValue()); err != nil {
+			badFields[hostKeysField] = "Must contain public keys in the authorized_keys format, one per line."
+		}
+	}
+	return badFields
+}
+
+func (a *attribute) VetForUpdate(p []string) map[string]string {
+	badFields := map[string]string{}
+	if handlers.MaskContains(p, defaultPortField) {
+		if a.GetDefaultPort() == nil {
+			badFields[defaultPortField] = "This field is required."
+		} else {
+			if a.GetDefaultPort().GetValue() == 0 {
+				badFields[defaultPortField] = "This cannot be set to zero."
+			}
+			if a.GetDefaultPort().GetValue() > math.MaxUint16 {
+				badFields[defaultPortField] = "Value is greater than maximum port number."
+			}
+		}
+	}
+	if handlers.MaskContains(p, defaultClientPortField) && a.GetDefaultClientPort() != nil {
+		if a.GetDefaultClientPort().GetValue() == 0 {
+			badFields[defaultClientPortField] = "This cannot be set to zero."
+		}
+		if a.GetDefaultClientPort().GetValue() > math.MaxUint16 {
+			badFields[defaultClientPortField] = "Value is greater than maximum port number."
+		}
+	}
+	if handlers.MaskContains(p, storageBucketIdField) {
+		if id := a.GetStorageBucketId(); id != nil && !handlers.ValidId(handlers.Id(id.GetValue()), globals.PluginStorageBucketPrefix) {
+			badFields[storageBucketIdField] = "Incorrectly formatted identifier."
+		}
+	}
+	if handlers.MaskContains(p, recordingCompressionField) {
+		if c := a.GetRecordingCompression(); c != nil {
+			if _, err := bsr.ParseCompression(c.GetValue()); err != nil {
+				badFields[recordingCompressionField] = `Must be one of "none", "gzip" or "zstd".`
+			}
+		}
+	}
+	if handlers.MaskContains(p, recordingCompressionLevelField) {
+		if l := a.GetRecordingCompressionLevel(); l != nil && l.GetValue() > bsr.MaxZstdCompressionLevel {
+			badFields[recordingCompressionLevelField] = "Value is greater than the maximum compression level."
+		}
+	}
+	if handlers.MaskContains(p, hostKeysField) {
+		if k := a.GetHostKeys(); k != nil {
+			if _, err := ssh.ParseHostKeys(context.Background(), k.GetValue()); err != nil {
+				badFields[hostKeysField] = "Must contain public keys in the authorized_keys format, one per line."
+			}
+		}
+	}
+	return badFields
+}
+
+func newAttribute(m any) targets.Attributes {
+	a := &attribute{
+		&pb.SshTargetAttributes{},
+	}
+	if sshAttr, ok := m.(*pb.Target_SshTargetAttributes); ok {
+		a.SshTargetAttributes = sshAttr.SshTargetAttributes
+	}
+	return a
+}
+
+func setAttributes(t target.Target, out *pb.Target) error {
+	if t == nil {
+		return nil
+	}
+
+	attrs := &pb.Target_SshTargetAttributes{
+		SshTargetAttributes: &pb.SshTargetAttributes{},
+	}
+	if t.GetDefaultPort() > 0 {
+		attrs.SshTargetAttributes.DefaultPort = &wrappers.UInt32Value{Value: t.GetDefaultPort()}
+	}
+	if t.GetDefaultClientPort() > 0 {
+		attrs.SshTargetAttributes.DefaultClientPort = &wrappers.UInt32Value{Value: t.GetDefaultClientPort()}
+	}
+	if t.GetStorageBucketId() != "" {
+		attrs.SshTargetAttributes.StorageBucketId = &wrappers.StringValue{Value: t.GetStorageBucketId()}
+	}
+	attrs.SshTargetAttributes.EnableSessionRecording = &wrappers.BoolValue{Value: t.GetEnableSessionRecording()}
+	if t.GetRecordingCompression() != "" {
+		attrs.SshTargetAttributes.RecordingCompression = &wrappers.StringValue{Value: t.GetRecordingCompression()}
+	}
+	if t.GetRecordingCompressionLevel() > 0 {
+		attrs.SshTargetAttributes.RecordingCompressionLevel = &wrappers.UInt32Value{Value: t.GetRecordingCompressionLevel()}
+	}
+	if t.GetHostKeys() != "" {
+		attrs.SshTargetAttributes.HostKeys = &wrappers.StringValue{Value: t.GetHostKeys()}
+	}
+
+	out.Attrs = attrs
+	return nil
+}
+
+func noopSessionValidation(context.Context, *session.Session) error { return nil }
+
+func init() {
+	var maskManager handlers.MaskManager
+	var err error
+
+	if maskManager, err = handlers.NewMaskManager(
+		context.Background(),
+		handlers.MaskDestination{&sshStore.Target{}, &store.TargetAddress{}},
+		handlers.MaskSource{&pb.Target{}, &pb.SshTargetAttributes{}},
+	); err != nil {
+		panic(err)
+	}
+
+	targets.Register(ssh.Subtype, maskManager, newAttribute, setAttributes, noopSessionValidation)
+}
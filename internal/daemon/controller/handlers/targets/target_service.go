@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/boundary/internal/host/plugin"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -123,10 +124,12 @@ type Service struct {
 	staticHostRepoFn        common.StaticRepoFactory
 	vaultCredRepoFn         common.VaultCredentialRepoFactory
 	staticCredRepoFn        common.StaticCredentialRepoFactory
+	aliasRepoFn             common.TargetAliasRepoFactory
 	downstreams             common.Downstreamers
 	kmsCache                *kms.Kms
 	workerStatusGracePeriod *atomic.Int64
 	controllerExt           intglobals.ControllerExtension
+	maxPageSize             uint
 }
 
 var _ pbs.TargetServiceServer = (*Service)(nil)
@@ -143,9 +146,11 @@ func NewService(
 	staticHostRepoFn common.StaticRepoFactory,
 	vaultCredRepoFn common.VaultCredentialRepoFactory,
 	staticCredRepoFn common.StaticCredentialRepoFactory,
+	aliasRepoFn common.TargetAliasRepoFactory,
 	downstreams common.Downstreamers,
 	workerStatusGracePeriod *atomic.Int64,
 	controllerExt intglobals.ControllerExtension,
+	opt ...handlers.Option,
 ) (Service, error) {
 	const op = "targets.NewService"
 	if kmsCache == nil {
@@ -175,6 +180,9 @@ func NewService(
 	if staticCredRepoFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing static credential repository")
 	}
+	if aliasRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing target alias repository")
+	}
 	return Service{
 		repoFn:                  repoFn,
 		iamRepoFn:               iamRepoFn,
@@ -184,10 +192,12 @@ func NewService(
 		staticHostRepoFn:        staticHostRepoFn,
 		vaultCredRepoFn:         vaultCredRepoFn,
 		staticCredRepoFn:        staticCredRepoFn,
+		aliasRepoFn:             aliasRepoFn,
 		downstreams:             downstreams,
 		kmsCache:                kmsCache,
 		workerStatusGracePeriod: workerStatusGracePeriod,
 		controllerExt:           controllerExt,
+		maxPageSize:             handlers.GetOpts(opt...).WithMaxPageSize,
 	}, nil
 }
 
@@ -224,26 +234,18 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 	}
 
 	// Get all user permissions for the requested scope(s).
-	userPerms := authResults.ACL().ListPermissions(authzScopes, resource.Target, IdActions, authResults.UserId)
+	userPerms := authResults.ACL().ListPermissions(authzScopes, resource.Target, IdActions, authResults.UserId, authResults.ACLOptions()...)
 	if len(userPerms) == 0 {
 		return &pbs.ListTargetsResponse{}, nil
 	}
 
-	tl, err := s.listFromRepo(ctx, userPerms)
-	if err != nil {
-		return nil, err
-	}
-	if len(tl) == 0 {
-		return &pbs.ListTargetsResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
 
-	finalItems := make([]*pb.Target, 0, len(tl))
-	for _, item := range tl {
+	protos := make(map[string]*pb.Target)
+	filterItemFn := func(ctx context.Context, item target.Target) (bool, error) {
 		pr := perms.Resource{Id: item.GetPublicId(), ScopeId: item.GetProjectId(), Type: resource.Target}
 		outputFields := authResults.FetchOutputFields(pr, action.List).SelfOrDefaults(authResults.UserId)
 
@@ -258,21 +260,58 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, outputOpts...)
+		pbItem, err := toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		filterable, err := subtypes.Filterable(ctx, item)
+		filterable, err := subtypes.Filterable(ctx, pbItem)
 		if err != nil {
-			return nil, err
+			return false, err
+		}
+		if !filter.Match(filterable) {
+			return false, nil
 		}
-		if filter.Match(filterable) {
-			finalItems = append(finalItems, item)
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, updatedAfter time.Time, prevPageLastItem pagination.Item, limit int) ([]target.Target, error) {
+		return s.listFromRepo(ctx, userPerms,
+			target.WithLimit(limit),
+			target.WithStartPageAfterItem(prevPageLastItem),
+			target.WithUpdatedAfter(updatedAfter),
+		)
+	}
+	listDeletedIdsFn := func(ctx context.Context, since time.Time) ([]string, error) {
+		repo, err := s.repoFn()
+		if err != nil {
+			return nil, err
 		}
+		return repo.ListDeletedIds(ctx, since)
 	}
 
-	return &pbs.ListTargetsResponse{Items: finalItems}, nil
+	listResp, err := handlers.PaginatedRefreshableList(ctx, req, s.maxPageSize, resource.Target, authResults.GrantsHash, filterItemFn, listItemsFn, listDeletedIdsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Target, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := handlers.RefreshToken(ctx, listResp)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListTargetsResponse{
+		Items:         finalItems,
+		NextPageToken: nextPageToken,
+		RefreshToken:  refreshToken,
+		RemovedIds:    listResp.RemovedIds,
+	}, nil
 }
 
 // GetTarget implements the interface pbs.TargetServiceServer.
@@ -698,12 +737,55 @@ func AuthorizeSessionWithWorkerFilter(
 	return selectedWorkers, nil, nil
 }
 
+// resolveAlias replaces an alias value provided in place of a target id with
+// the id of the alias's destination. If the request does not specify a host id
+// the alias's host id, if any, is used. An alias which doesn't resolve is only
+// reported as not found to a requester authorized to connect to any target.
+func (s Service) resolveAlias(ctx context.Context, req *pbs.AuthorizeSessionRequest) error {
+	const op = "targets.(Service).resolveAlias"
+	// Alias values cannot contain an underscore, so anything that does is left
+	// for validation to handle as an id.
+	if req.GetName() != "" || req.GetId() == "" || strings.Contains(req.GetId(), "_") {
+		return nil
+	}
+	repo, err := s.aliasRepoFn()
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	a, err := repo.LookupAliasByValue(ctx, req.GetId())
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if a == nil || a.GetDestinationId() == "" {
+		// Return the error of a request for a target the requester isn't
+		// authorized to connect to, so the response doesn't reveal whether
+		// the alias exists.
+		res := auth.Verify(ctx,
+			auth.WithType(resource.Target),
+			auth.WithAction(action.AuthorizeSession),
+			auth.WithScopeId(scope.Global.String()),
+		)
+		if res.Error != nil {
+			return res.Error
+		}
+		return handlers.NotFoundError()
+	}
+	req.Id = a.GetDestinationId()
+	if req.GetHostId() == "" {
+		req.HostId = a.GetHostId()
+	}
+	return nil
+}
+
 func DefaultPostSessionAuthorizationCallback(context.Context, intglobals.ControllerExtension, *kms.Kms, *target.Repository, target.Target, *session.Session, *server.Worker) error {
 	return nil
 }
 
 func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSessionRequest) (_ *pbs.AuthorizeSessionResponse, retErr error) {
 	const op = "targets.(Service).AuthorizeSession"
+	if err := s.resolveAlias(ctx, req); err != nil {
+		return nil, err
+	}
 	if err := validateAuthorizeSessionRequest(req); err != nil {
 		return nil, err
 	}
@@ -938,6 +1020,7 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		Endpoint:            endpointUrl.String(),
 		ExpirationTime:      &timestamp.Timestamp{Timestamp: expTime},
 		ConnectionLimit:     t.GetSessionConnectionLimit(),
+		IdleTimeoutSeconds:  t.GetSessionIdleTimeoutSeconds(),
 		WorkerFilter:        t.GetWorkerFilter(),
 		EgressWorkerFilter:  t.GetEgressWorkerFilter(),
 		IngressWorkerFilter: t.GetIngressWorkerFilter(),
@@ -1171,6 +1254,9 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (target.Targ
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
+	}
 	if item.GetEgressWorkerFilter() != nil {
 		opts = append(opts, target.WithEgressWorkerFilter(item.GetEgressWorkerFilter().GetValue()))
 	}
@@ -1224,6 +1310,9 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if item.GetSessionConnectionLimit() != nil {
 		opts = append(opts, target.WithSessionConnectionLimit(item.GetSessionConnectionLimit().GetValue()))
 	}
+	if item.GetSessionIdleTimeoutSeconds() != nil {
+		opts = append(opts, target.WithSessionIdleTimeoutSeconds(item.GetSessionIdleTimeoutSeconds().GetValue()))
+	}
 	// worker_filter is deprecated, but we allow users who have migrated with a worker_filter value to update it.
 	if workerFilter := item.GetWorkerFilter(); workerFilter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
@@ -1298,12 +1387,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, perms []perms.Permission) ([]target.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, perms []perms.Permission, opt ...target.Option) ([]target.Target, error) {
 	repo, err := s.repoFn(target.WithPermissions(perms))
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithLimit(-1))
+	ul, err := repo.ListTargets(ctx, opt...)
 	if err != nil {
 		return nil, err
 	}
@@ -1575,6 +1664,9 @@ func toProto(ctx context.Context, in target.Target, opt ...handlers.Option) (*pb
 	if outputFields.Has(globals.SessionConnectionLimitField) {
 		out.SessionConnectionLimit = wrapperspb.Int32(in.GetSessionConnectionLimit())
 	}
+	if outputFields.Has(globals.SessionIdleTimeoutSecondsField) && in.GetSessionIdleTimeoutSeconds() != 0 {
+		out.SessionIdleTimeoutSeconds = wrapperspb.UInt32(in.GetSessionIdleTimeoutSeconds())
+	}
 	if outputFields.Has(globals.WorkerFilterField) && in.GetWorkerFilter() != "" {
 		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
@@ -19,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/boundary/globals"
+	talias "github.com/hashicorp/boundary/internal/alias/target"
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
@@ -114,7 +115,10 @@ func testService(t *testing.T, ctx context.Context, conn *db.DB, kms *kms.Kms, w
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(context.Background(), rw, rw, kms)
 	}
-	return targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, nil, statusGracePeriod, nil)
+	aliasRepoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
+	return targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, aliasRepoFn, nil, statusGracePeriod, nil)
 }
 
 func TestGet(t *testing.T) {
@@ -428,6 +432,70 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestList_Refresh(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	rw := db.New(conn)
+
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kms)
+	}
+	serversRepoFn := func() (*server.Repository, error) {
+		return server.NewRepository(ctx, rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	r := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
+	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
+
+	tar1 := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "tar1")
+	tar2 := tcp.TestTarget(ctx, t, conn, proj.GetPublicId(), "tar2")
+
+	s, err := testService(t, ctx, conn, kms, wrapper)
+	require.NoError(t, err, "Couldn't create a new target service.")
+
+	requestInfo := authpb.RequestInfo{
+		TokenFormat: uint32(auth.AuthTokenTypeBearer),
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	requestContext := context.WithValue(context.Background(), requests.ContextRequestInformationKey, &requests.RequestContext{})
+	ctx = auth.NewVerifierContext(requestContext, iamRepoFn, tokenRepoFn, serversRepoFn, kms, &requestInfo)
+
+	got, err := s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId()})
+	require.NoError(t, err)
+	require.Len(t, got.GetItems(), 2)
+	assert.Empty(t, got.GetRemovedIds())
+	refreshToken := got.GetRefreshToken()
+	require.NotEmpty(t, refreshToken)
+
+	_, err = s.DeleteTarget(ctx, &pbs.DeleteTargetRequest{Id: tar1.GetPublicId()})
+	require.NoError(t, err)
+
+	got, err = s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), RefreshToken: refreshToken})
+	require.NoError(t, err)
+	assert.Equal(t, []string{tar1.GetPublicId()}, got.GetRemovedIds())
+	for _, item := range got.GetItems() {
+		assert.Equal(t, tar2.GetPublicId(), item.GetId())
+	}
+	assert.NotEmpty(t, got.GetRefreshToken())
+
+	_, err = s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), RefreshToken: refreshToken, PageToken: refreshToken})
+	assert.Error(t, err)
+	_, err = s.ListTargets(ctx, &pbs.ListTargetsRequest{ScopeId: proj.GetPublicId(), RefreshToken: "invalid"})
+	assert.Error(t, err)
+}
+
 func TestDelete(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -639,6 +707,39 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create a target with a session idle timeout",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("idle"),
+				Type:    tcp.Subtype.String(),
+				Attrs: &pb.Target_TcpTargetAttributes{
+					TcpTargetAttributes: &pb.TcpTargetAttributes{
+						DefaultPort: wrapperspb.UInt32(2),
+					},
+				},
+				SessionIdleTimeoutSeconds: wrapperspb.UInt32(300),
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", globals.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: org.GetPublicId()},
+					Name:    wrapperspb.String("idle"),
+					Type:    tcp.Subtype.String(),
+					Attrs: &pb.Target_TcpTargetAttributes{
+						TcpTargetAttributes: &pb.TcpTargetAttributes{
+							DefaultPort: wrapperspb.UInt32(2),
+						},
+					},
+					SessionMaxSeconds:         wrapperspb.UInt32(28800),
+					SessionConnectionLimit:    wrapperspb.Int32(-1),
+					SessionIdleTimeoutSeconds: wrapperspb.UInt32(300),
+					AuthorizedActions:         testAuthorizedActions,
+					Address:                   &wrapperspb.StringValue{},
+				},
+			},
+		},
 		{
 			name: "Create a target with no port",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -2478,6 +2579,9 @@ func TestAuthorizeSession(t *testing.T) {
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
@@ -2630,7 +2734,7 @@ func TestAuthorizeSession(t *testing.T) {
 
 	statusGracePeriod := new(atomic.Int64)
 	statusGracePeriod.Store(int64(server.DefaultLiveness))
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, nil, statusGracePeriod, nil)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, aliasRepoFn, nil, statusGracePeriod, nil)
 	require.NoError(t, err)
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -2676,6 +2780,14 @@ func TestAuthorizeSession(t *testing.T) {
 			})
 			require.NoError(t, err, "session must authorize with explicit host ID")
 
+			al := talias.TestAlias(t, rw, fmt.Sprintf("%s.alias.example", strings.ReplaceAll(tc.name, " ", "-")),
+				talias.WithDestinationId(tar.GetPublicId()))
+			asRes3, err := s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{
+				Id: al.GetValue(),
+			})
+			require.NoError(t, err, "session must authorize with an alias")
+			assert.Equal(t, tar.GetPublicId(), asRes3.GetItem().GetTargetId())
+
 			wantedHostId := tc.wantedHostId
 			if tc.wantedHostId == "?" {
 				wantedHostId = asRes2.GetItem().GetHostId()
@@ -2792,6 +2904,9 @@ func TestAuthorizeSessionTypedCredentials(t *testing.T) {
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
@@ -2818,7 +2933,7 @@ func TestAuthorizeSessionTypedCredentials(t *testing.T) {
 
 	statusGracePeriod := new(atomic.Int64)
 	statusGracePeriod.Store(int64(server.DefaultLiveness))
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, nil, statusGracePeriod, nil)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, aliasRepoFn, nil, statusGracePeriod, nil)
 	require.NoError(t, err)
 
 	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
@@ -3394,6 +3509,9 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	staticCredRepoFn := func() (*credstatic.Repository, error) {
 		return credstatic.NewRepository(ctx, rw, rw, kms)
 	}
+	aliasRepoFn := func() (*talias.Repository, error) {
+		return talias.NewRepository(ctx, rw, rw, kms)
+	}
 	atRepoFn := func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(ctx, rw, rw, kms)
 	}
@@ -3401,7 +3519,7 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 
 	statusGracePeriod := new(atomic.Int64)
 	statusGracePeriod.Store(int64(server.DefaultLiveness))
-	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, nil, statusGracePeriod, nil)
+	s, err := targets.NewService(ctx, kms, repoFn, iamRepoFn, serversRepoFn, sessionRepoFn, pluginHostRepoFn, staticHostRepoFn, vaultCredRepoFn, staticCredRepoFn, aliasRepoFn, nil, statusGracePeriod, nil)
 	require.NoError(t, err)
 
 	// Authorized user gets full permissions
@@ -3420,6 +3538,10 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 	_ = iam.TestUserRole(t, conn, r.GetPublicId(), at.GetIamUserId())
 	_ = iam.TestRoleGrant(t, conn, r.GetPublicId(), "id=*;type=*;actions=*")
 
+	_, otherProj := iam.TestScopes(t, iamRepo)
+	otherTar := tcp.TestTarget(ctx, t, conn, otherProj.GetPublicId(), "other", target.WithDefaultPort(22))
+	otherAlias := talias.TestAlias(t, rw, "other.alias.example", talias.WithDestinationId(otherTar.GetPublicId()))
+
 	v := vault.NewTestVaultServer(t, vault.WithDockerNetwork(true))
 	v.MountDatabase(t)
 	sec, tok := v.CreateToken(t, vault.WithPolicies([]string{"default", "database"}))
@@ -3566,6 +3688,7 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 		name            string
 		setup           []func(target.Target) uint32
 		useTargetId     bool
+		aliasValue      string
 		wantErr         bool
 		wantErrContains string
 	}{
@@ -3589,6 +3712,23 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "Resource not found",
 		},
+		{
+			// the requester only has grants in proj, so a missing alias
+			// returns the same error as an alias of a target in another
+			// project.
+			name:            "no alias",
+			setup:           []func(tcpTarget target.Target) uint32{workerExists, hostExists, libraryExists},
+			aliasValue:      "bogus.alias.example",
+			wantErr:         true,
+			wantErrContains: "Forbidden",
+		},
+		{
+			name:            "alias of unauthorized target",
+			setup:           []func(tcpTarget target.Target) uint32{workerExists, hostExists, libraryExists},
+			aliasValue:      otherAlias.GetValue(),
+			wantErr:         true,
+			wantErrContains: "Forbidden",
+		},
 		{
 			name:        "no host port",
 			setup:       []func(tcpTarget target.Target) uint32{workerExists, hostWithoutPort, libraryExists},
@@ -3626,7 +3766,10 @@ func TestAuthorizeSession_Errors(t *testing.T) {
 			}
 
 			id := tar.GetPublicId()
-			if !tc.useTargetId {
+			switch {
+			case tc.aliasValue != "":
+				id = tc.aliasValue
+			case !tc.useTargetId:
 				id = "ttcp_bogusid"
 			}
 
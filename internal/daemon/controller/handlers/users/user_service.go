@@ -6,18 +6,24 @@ package users
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -40,6 +46,8 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.RevokeTokens,
+		action.ExplainPermissions,
 	)
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -67,18 +75,32 @@ func init() {
 type Service struct {
 	pbs.UnsafeUserServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn        common.IamRepoFactory
+	atRepoFn      common.AuthTokenRepoFactory
+	sessionRepoFn session.RepositoryFactory
+	maxPageSize   uint
 }
 
 var _ pbs.UserServiceServer = (*Service)(nil)
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(ctx context.Context, repo common.IamRepoFactory) (Service, error) {
+func NewService(ctx context.Context, repo common.IamRepoFactory, atRepoFn common.AuthTokenRepoFactory, sessionRepoFn session.RepositoryFactory, opt ...handlers.Option) (Service, error) {
 	const op = "users.NewService"
 	if repo == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing iam repository")
 	}
-	return Service{repoFn: repo}, nil
+	if atRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing auth token repository")
+	}
+	if sessionRepoFn == nil {
+		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing session repository")
+	}
+	return Service{
+		repoFn:        repo,
+		atRepoFn:      atRepoFn,
+		sessionRepoFn: sessionRepoFn,
+		maxPageSize:   handlers.GetOpts(opt...).WithMaxPageSize,
+	}, nil
 }
 
 // ListUsers implements the interface pbs.UserServiceServer.
@@ -110,28 +132,20 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 		return &pbs.ListUsersResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListUsersResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.User, 0, len(ul))
 	res := perms.Resource{
 		Type: resource.User,
 	}
-	for _, item := range ul {
+	protos := make(map[string]*pb.User)
+	filterItemFn := func(ctx context.Context, item *iam.User) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(*authResults.UserData.User.Id)
@@ -144,16 +158,34 @@ func (s Service) ListUsers(ctx context.Context, req *pbs.ListUsersRequest) (*pbs
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := toProto(ctx, item, nil, outputOpts...)
+		pbItem, err := toProto(ctx, item, nil, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*iam.User, error) {
+		return s.listFromRepo(ctx, scopeIds, iam.WithLimit(limit), iam.WithStartPageAfterItem(prevPageLastItem))
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.User, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.User, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListUsersResponse{Items: finalItems}, nil
+	return &pbs.ListUsersResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetUsers implements the interface pbs.UserServiceServer.
@@ -399,6 +431,37 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: item}, nil
 }
 
+// RevokeUserTokens implements the interface pbs.UserServiceServer.
+func (s Service) RevokeUserTokens(ctx context.Context, req *pbs.RevokeUserTokensRequest) (*pbs.RevokeUserTokensResponse, error) {
+	const op = "users.(Service).RevokeUserTokens"
+	if err := validateRevokeUserTokensRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RevokeTokens)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	tokenIds, sessionIds, err := s.revokeTokensInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &pbs.RevokeUserTokensResponse{AuthTokenIds: tokenIds, SessionIds: sessionIds}
+	handlers.WriteRevokeTokensAuditEvent(ctx, op, authResults.UserId, fmt.Sprintf("users/%s", req.GetId()), resp)
+	return resp, nil
+}
+
+// ExplainUserPermissions implements the interface pbs.UserServiceServer.
+func (s Service) ExplainUserPermissions(ctx context.Context, req *pbs.ExplainUserPermissionsRequest) (*pbs.ExplainUserPermissionsResponse, error) {
+	if err := validateExplainUserPermissionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ExplainPermissions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return s.explainPermissionsInRepo(ctx, req)
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*iam.User, []string, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -493,12 +556,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*iam.User, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, opt ...iam.Option) ([]*iam.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListUsers(ctx, scopeIds, iam.WithLimit(-1))
+	ul, err := repo.ListUsers(ctx, scopeIds, opt...)
 	if err != nil {
 		return nil, err
 	}
@@ -565,6 +628,104 @@ func (s Service) removeInRepo(ctx context.Context, userId string, accountIds []s
 	return out, accts, nil
 }
 
+// revokeTokensInRepo cancels the sessions of the user and deletes the user's
+// auth tokens in a single transaction, so no session can be authorized with
+// one of the tokens after its sessions were canceled.
+func (s Service) revokeTokensInRepo(ctx context.Context, userId string) ([]string, []string, error) {
+	const op = "users.(Service).revokeTokensInRepo"
+	atRepo, err := s.atRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	sessionRepo, err := s.sessionRepoFn()
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	var tokenIds []string
+	sessionIds, err := sessionRepo.CancelUserSessions(ctx, userId, session.WithTx(func(r db.Reader, w db.Writer) error {
+		var err error
+		tokenIds, err = atRepo.DeleteUserAuthTokens(ctx, userId, authtoken.WithReaderWriter(r, w))
+		return err
+	}))
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to revoke user auth tokens"))
+	}
+	return tokenIds, sessionIds, nil
+}
+
+// explainPermissionsInRepo evaluates the grants of the user against the
+// requested resource, tracing the evaluation of the requested action if one
+// was provided.
+func (s Service) explainPermissionsInRepo(ctx context.Context, req *pbs.ExplainUserPermissionsRequest) (*pbs.ExplainUserPermissionsResponse, error) {
+	const op = "users.(Service).explainPermissionsInRepo"
+	if _, _, err := s.getFromRepo(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	res, err := repo.LookupResource(ctx, req.GetResourceId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if res == nil {
+		return nil, handlers.NotFoundErrorf("Resource %q doesn't exist.", req.GetResourceId())
+	}
+	grantTuples, err := repo.GrantsForUser(ctx, req.GetId())
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	grants := make([]perms.Grant, 0, len(grantTuples))
+	for _, tuple := range grantTuples {
+		// As when authorizing requests, validation is skipped so that grants
+		// which are no longer valid are still explained
+		g, err := perms.Parse(ctx, tuple.ScopeId, tuple.Grant,
+			perms.WithUserId(req.GetId()),
+			perms.WithRoleId(tuple.RoleId),
+			perms.WithSkipFinalValidation(true))
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", tuple.Grant)))
+		}
+		grants = append(grants, g)
+	}
+	acl := perms.NewACL(grants...)
+	aclOpts := []perms.Option{perms.WithClientIp(req.GetClientIp())}
+
+	ret := &pbs.ExplainUserPermissionsResponse{
+		ResourceId:   res.Id,
+		ResourceType: res.Type.String(),
+		ScopeId:      res.ScopeId,
+		Action:       req.GetAction(),
+	}
+	if req.GetAction() != "" {
+		results := acl.Allowed(*res, action.Map[req.GetAction()], req.GetId(), append(aclOpts, perms.WithTrace(true))...)
+		ret.Authorized = results.Authorized
+		for _, t := range results.Trace {
+			ret.Grants = append(ret.Grants, &pbs.GrantExplanation{
+				RoleId:     t.RoleId,
+				ScopeId:    t.ScopeId,
+				Grant:      t.Grant,
+				Matched:    t.Matched,
+				Authorized: t.Authorized,
+				Reason:     t.Reason,
+			})
+		}
+	}
+
+	idActions, err := action.IdActionSetForResource(res.Type)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	for act := range idActions {
+		if acl.Allowed(*res, act, req.GetId(), aclOpts...).Authorized {
+			ret.AuthorizedActions = append(ret.AuthorizedActions, act.String())
+		}
+	}
+	sort.Strings(ret.AuthorizedActions)
+	return ret, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 	repo, err := s.repoFn()
@@ -792,3 +953,32 @@ func validateRemoveUserAccountsRequest(req *pbs.RemoveUserAccountsRequest) error
 	}
 	return nil
 }
+
+func validateRevokeUserTokensRequest(req *pbs.RevokeUserTokensRequest) error {
+	return handlers.ValidateGetRequest(handlers.NoopValidatorFn, req, globals.UserPrefix)
+}
+
+func validateExplainUserPermissionsRequest(req *pbs.ExplainUserPermissionsRequest) error {
+	return handlers.ValidateGetRequest(func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetResourceId() == "" {
+			badFields["resource_id"] = "This field is required."
+		} else if globals.ResourceInfoFromPrefix(req.GetResourceId()).Type == resource.Unknown {
+			badFields["resource_id"] = "Unknown resource type."
+		}
+		if req.GetAction() != "" {
+			if act, ok := action.Map[req.GetAction()]; !ok || act == action.All {
+				badFields["action"] = "Unknown action."
+			}
+		}
+		if ip := req.GetClientIp(); ip != "" {
+			if host, _, err := net.SplitHostPort(ip); err == nil {
+				ip = host
+			}
+			if net.ParseIP(ip) == nil {
+				badFields["client_ip"] = "Must be a valid IP address."
+			}
+		}
+		return badFields
+	}, req, globals.UserPrefix)
+}
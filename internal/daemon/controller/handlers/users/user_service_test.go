@@ -17,13 +17,16 @@ import (
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/daemon/controller/auth"
+	"github.com/hashicorp/boundary/internal/daemon/controller/common"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers/users"
 	"github.com/hashicorp/boundary/internal/db"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/users"
@@ -38,9 +41,22 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"}
+var testAuthorizedActions = []string{"no-op", "read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "revoke-tokens", "explain-permissions"}
 
-func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error)) {
+func testRepoFns(t *testing.T, conn *db.DB, kmsCache *kms.Kms) (common.AuthTokenRepoFactory, session.RepositoryFactory) {
+	t.Helper()
+	ctx := context.Background()
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(ctx, rw, rw, kmsCache)
+	}
+	sessionRepoFn := func(opt ...session.Option) (*session.Repository, error) {
+		return session.NewRepository(ctx, rw, rw, kmsCache, opt...)
+	}
+	return atRepoFn, sessionRepoFn
+}
+
+func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string, func() (*iam.Repository, error), common.AuthTokenRepoFactory, session.RepositoryFactory) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
@@ -48,16 +64,17 @@ func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string
 	repoFn := func() (*iam.Repository, error) {
 		return repo, nil
 	}
+	kmsCache := kms.TestKms(t, conn, wrap)
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
 	o, _ := iam.TestScopes(t, repo)
 	u := iam.TestUser(t, repo, o.GetPublicId(), iam.WithDescription("default"), iam.WithName("default"))
 
 	switch withAccts {
 	case false:
-		return u, nil, repoFn
+		return u, nil, repoFn, atRepoFn, sessionRepoFn
 	default:
 		require := require.New(t)
 		ctx := context.Background()
-		kmsCache := kms.TestKms(t, conn, wrap)
 		databaseWrap, err := kmsCache.GetWrapper(ctx, o.PublicId, kms.KeyPurposeDatabase)
 		require.NoError(err)
 		primaryAm := oidc.TestAuthMethod(t, conn, databaseWrap, o.PublicId, oidc.ActivePublicState, "alice-rp", "fido",
@@ -80,12 +97,12 @@ func createDefaultUserAndRepo(t *testing.T, withAccts bool) (*iam.User, []string
 		// reload the user with their accounts
 		u, accts, err := repo.LookupUser(ctx, u.PublicId)
 		require.NoError(err)
-		return u, accts, repoFn
+		return u, accts, repoFn, atRepoFn, sessionRepoFn
 	}
 }
 
 func TestGet(t *testing.T) {
-	u, uAccts, repoFn := createDefaultUserAndRepo(t, true)
+	u, uAccts, repoFn, atRepoFn, sessionRepoFn := createDefaultUserAndRepo(t, true)
 
 	toMerge := &pbs.GetUserRequest{
 		Id: u.GetPublicId(),
@@ -145,7 +162,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetUserRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := users.NewService(context.Background(), repoFn)
+			s, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 			require.NoError(err, "Couldn't create new user service.")
 
 			got, gErr := s.GetUser(auth.DisabledAuthTestContext(repoFn, u.GetScopeId()), req)
@@ -179,6 +196,7 @@ func TestList(t *testing.T) {
 	oWithUsers, _ := iam.TestScopes(t, repo)
 
 	kmsCache := kms.TestKms(t, conn, wrap)
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
 	databaseWrap, err := kmsCache.GetWrapper(context.Background(), oWithUsers.PublicId, kms.KeyPurposeDatabase)
 	require.NoError(t, err)
 	primaryAm := oidc.TestAuthMethod(t, conn, databaseWrap, oWithUsers.PublicId, oidc.ActivePublicState, "alice-rp", "fido",
@@ -191,7 +209,7 @@ func TestList(t *testing.T) {
 	secondaryAm := password.TestAuthMethods(t, conn, oWithUsers.PublicId, 1)
 	require.Len(t, secondaryAm, 1)
 
-	s, err := users.NewService(context.Background(), repoFn)
+	s, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err)
 
 	var wantUsers []*pb.User
@@ -345,9 +363,9 @@ func (s sortableUsers) Less(i, j int) bool { return s.users[i].GetId() < s.users
 func (s sortableUsers) Swap(i, j int)      { s.users[i], s.users[j] = s.users[j], s.users[i] }
 
 func TestDelete(t *testing.T) {
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
+	u, _, repoFn, atRepoFn, sessionRepoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(context.Background(), repoFn)
+	s, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -392,9 +410,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
+	u, _, repoFn, atRepoFn, sessionRepoFn := createDefaultUserAndRepo(t, false)
 
-	s, err := users.NewService(context.Background(), repoFn)
+	s, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteUserRequest{
 		Id: u.GetPublicId(),
@@ -408,7 +426,7 @@ func TestDelete_twice(t *testing.T) {
 }
 
 func TestCreate(t *testing.T) {
-	defaultUser, _, repoFn := createDefaultUserAndRepo(t, false)
+	defaultUser, _, repoFn, atRepoFn, sessionRepoFn := createDefaultUserAndRepo(t, false)
 	defaultCreated := defaultUser.GetCreateTime().GetTimestamp().AsTime()
 
 	cases := []struct {
@@ -486,7 +504,7 @@ func TestCreate(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := users.NewService(context.Background(), repoFn)
+			s, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 			require.NoError(err, "Error when getting new user service.")
 
 			got, gErr := s.CreateUser(auth.DisabledAuthTestContext(repoFn, tc.req.GetItem().GetScopeId()), tc.req)
@@ -521,8 +539,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	u, _, repoFn := createDefaultUserAndRepo(t, false)
-	tested, err := users.NewService(context.Background(), repoFn)
+	u, _, repoFn, atRepoFn, sessionRepoFn := createDefaultUserAndRepo(t, false)
+	tested, err := users.NewService(context.Background(), repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	created := u.GetCreateTime().GetTimestamp().AsTime()
@@ -806,7 +824,8 @@ func TestAddAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(ctx, repoFn)
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
+	s, err := users.NewService(ctx, repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -966,7 +985,8 @@ func TestSetAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(ctx, repoFn)
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
+	s, err := users.NewService(ctx, repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1128,7 +1148,8 @@ func TestRemoveAccount(t *testing.T) {
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
-	s, err := users.NewService(ctx, repoFn)
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
+	s, err := users.NewService(ctx, repoFn, atRepoFn, sessionRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	o, _ := iam.TestScopes(t, iamRepo)
@@ -1300,3 +1321,158 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestRevokeUserTokens(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
+	s, err := users.NewService(ctx, repoFn, atRepoFn, sessionRepoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	t.Run("revoke", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		sess := session.TestDefaultSession(t, conn, wrap, iamRepo)
+		usr, _, err := iamRepo.LookupUser(ctx, sess.UserId)
+		require.NoError(err)
+
+		got, err := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, usr.GetScopeId()), &pbs.RevokeUserTokensRequest{Id: usr.GetPublicId()})
+		require.NoError(err)
+		assert.Equal([]string{sess.AuthTokenId}, got.GetAuthTokenIds())
+		assert.Equal([]string{sess.PublicId}, got.GetSessionIds())
+
+		atRepo, err := atRepoFn()
+		require.NoError(err)
+		at, err := atRepo.LookupAuthToken(ctx, sess.AuthTokenId)
+		require.NoError(err)
+		assert.Nil(at)
+
+		// revoking again is a no-op
+		got, err = s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, usr.GetScopeId()), &pbs.RevokeUserTokensRequest{Id: usr.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(got.GetAuthTokenIds())
+		assert.Empty(got.GetSessionIds())
+	})
+	t.Run("bad-user-id", func(t *testing.T) {
+		_, err := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, "global"), &pbs.RevokeUserTokensRequest{Id: "bad id"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	})
+	t.Run("user-not-found", func(t *testing.T) {
+		_, err := s.RevokeUserTokens(auth.DisabledAuthTestContext(repoFn, "global"), &pbs.RevokeUserTokensRequest{Id: globals.UserPrefix + "_DoesntExis"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()), "got error %v", err)
+	})
+}
+
+func TestExplainUserPermissions(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn, sessionRepoFn := testRepoFns(t, conn, kmsCache)
+	s, err := users.NewService(ctx, repoFn, atRepoFn, sessionRepoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	usr := iam.TestUser(t, iamRepo, org.GetPublicId())
+	other := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	orgRole := iam.TestRole(t, conn, org.GetPublicId())
+	iam.TestRoleGrant(t, conn, orgRole.GetPublicId(), "ids=*;type=user;actions=read")
+	iam.TestRoleGrant(t, conn, orgRole.GetPublicId(), "ids={{user.id}};type=user;actions=update")
+	iam.TestUserRole(t, conn, orgRole.GetPublicId(), usr.GetPublicId())
+	globalRole := iam.TestRole(t, conn, scope.Global.String())
+	iam.TestRoleGrant(t, conn, globalRole.GetPublicId(), "ids=*;type=user;actions=delete")
+	iam.TestUserRole(t, conn, globalRole.GetPublicId(), usr.GetPublicId())
+
+	explain := func(req *pbs.ExplainUserPermissionsRequest) (*pbs.ExplainUserPermissionsResponse, error) {
+		return s.ExplainUserPermissions(auth.DisabledAuthTestContext(repoFn, org.GetPublicId()), req)
+	}
+	grantFor := func(t *testing.T, got *pbs.ExplainUserPermissionsResponse, roleId, grant string) *pbs.GrantExplanation {
+		t.Helper()
+		for _, g := range got.GetGrants() {
+			if g.GetRoleId() == roleId && g.GetGrant() == grant {
+				return g
+			}
+		}
+		require.Failf(t, "grant not found", "no explanation for grant %q of role %q", grant, roleId)
+		return nil
+	}
+
+	t.Run("authorized", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := explain(&pbs.ExplainUserPermissionsRequest{Id: usr.GetPublicId(), ResourceId: other.GetPublicId(), Action: "read"})
+		require.NoError(err)
+		assert.Equal(other.GetPublicId(), got.GetResourceId())
+		assert.Equal("user", got.GetResourceType())
+		assert.Equal(org.GetPublicId(), got.GetScopeId())
+		assert.Equal("read", got.GetAction())
+		assert.True(got.GetAuthorized())
+
+		read := grantFor(t, got, orgRole.GetPublicId(), "ids=*;type=user;actions=read")
+		assert.True(read.GetMatched())
+		assert.True(read.GetAuthorized())
+		assert.Equal(org.GetPublicId(), read.GetScopeId())
+
+		del := grantFor(t, got, globalRole.GetPublicId(), "ids=*;type=user;actions=delete")
+		assert.False(del.GetMatched())
+		assert.Contains(del.GetReason(), "not the resource's scope")
+
+		assert.Contains(got.GetAuthorizedActions(), "read")
+		assert.NotContains(got.GetAuthorizedActions(), "update")
+		assert.NotContains(got.GetAuthorizedActions(), "delete")
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := explain(&pbs.ExplainUserPermissionsRequest{Id: usr.GetPublicId(), ResourceId: other.GetPublicId(), Action: "update"})
+		require.NoError(err)
+		assert.False(got.GetAuthorized())
+
+		update := grantFor(t, got, orgRole.GetPublicId(), "ids="+usr.GetPublicId()+";type=user;actions=update")
+		assert.False(update.GetMatched())
+		assert.False(update.GetAuthorized())
+		assert.NotEmpty(update.GetReason())
+	})
+	t.Run("actions only", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := explain(&pbs.ExplainUserPermissionsRequest{Id: usr.GetPublicId(), ResourceId: usr.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(got.GetGrants())
+		assert.Subset(got.GetAuthorizedActions(), []string{"read", "update"})
+		assert.True(sort.StringsAreSorted(got.GetAuthorizedActions()))
+	})
+	t.Run("resource-not-found", func(t *testing.T) {
+		_, err := explain(&pbs.ExplainUserPermissionsRequest{Id: usr.GetPublicId(), ResourceId: globals.UserPrefix + "_DoesntExis", Action: "read"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()), "got error %v", err)
+	})
+	t.Run("user-not-found", func(t *testing.T) {
+		_, err := explain(&pbs.ExplainUserPermissionsRequest{Id: globals.UserPrefix + "_DoesntExis", ResourceId: other.GetPublicId(), Action: "read"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, handlers.NotFoundError()), "got error %v", err)
+	})
+	for name, req := range map[string]*pbs.ExplainUserPermissionsRequest{
+		"bad-user-id":      {Id: "bad id", ResourceId: other.GetPublicId()},
+		"missing-resource": {Id: usr.GetPublicId()},
+		"unknown-resource": {Id: usr.GetPublicId(), ResourceId: "bad_1234567890"},
+		"unknown-action":   {Id: usr.GetPublicId(), ResourceId: other.GetPublicId(), Action: "fly"},
+		"bad-client-ip":    {Id: usr.GetPublicId(), ResourceId: other.GetPublicId(), ClientIp: "not an ip"},
+		"wildcard-action":  {Id: usr.GetPublicId(), ResourceId: other.GetPublicId(), Action: "*"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := explain(req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/daemon/controller/handlers"
 	"github.com/hashicorp/boundary/internal/errors"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/requests"
 	"github.com/hashicorp/boundary/internal/server"
@@ -97,6 +98,7 @@ type Service struct {
 	workerAuthFn common.WorkerAuthRepoStorageFactory
 	iamRepoFn    common.IamRepoFactory
 	downstreams  common.Downstreamers
+	maxPageSize  uint
 }
 
 var _ pbs.WorkerServiceServer = (*Service)(nil)
@@ -104,6 +106,7 @@ var _ pbs.WorkerServiceServer = (*Service)(nil)
 // NewService returns a worker service which handles worker related requests to boundary.
 func NewService(ctx context.Context, repo common.ServersRepoFactory, iamRepoFn common.IamRepoFactory,
 	workerAuthFn common.WorkerAuthRepoStorageFactory, ds common.Downstreamers,
+	opt ...handlers.Option,
 ) (Service, error) {
 	const op = "workers.NewService"
 	if repo == nil {
@@ -115,7 +118,7 @@ func NewService(ctx context.Context, repo common.ServersRepoFactory, iamRepoFn c
 	if workerAuthFn == nil {
 		return Service{}, errors.New(ctx, errors.InvalidParameter, op, "missing worker auth repository")
 	}
-	return Service{repoFn: repo, iamRepoFn: iamRepoFn, workerAuthFn: workerAuthFn, downstreams: ds}, nil
+	return Service{repoFn: repo, iamRepoFn: iamRepoFn, workerAuthFn: workerAuthFn, downstreams: ds, maxPageSize: handlers.GetOpts(opt...).WithMaxPageSize}, nil
 }
 
 // ListWorkers implements the interface pbs.WorkerServiceServer.
@@ -147,28 +150,20 @@ func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (
 		return &pbs.ListWorkersResponse{}, nil
 	}
 
-	ul, err := s.listFromRepo(ctx, scopeIds)
-	if err != nil {
-		return nil, err
-	}
-	if len(ul) == 0 {
-		return &pbs.ListWorkersResponse{}, nil
-	}
-
 	filter, err := handlers.NewFilter(ctx, req.GetFilter())
 	if err != nil {
 		return nil, err
 	}
-	finalItems := make([]*pb.Worker, 0, len(ul))
 	res := perms.Resource{
 		Type: resource.Worker,
 	}
-	for _, item := range ul {
+	protos := make(map[string]*pb.Worker)
+	filterItemFn := func(ctx context.Context, item *server.Worker) (bool, error) {
 		res.Id = item.GetPublicId()
 		res.ScopeId = item.GetScopeId()
 		authorizedActions := authResults.FetchActionSetForId(ctx, item.GetPublicId(), IdActions, auth.WithResource(&res)).Strings()
 		if len(authorizedActions) == 0 {
-			continue
+			return false, nil
 		}
 
 		outputFields := authResults.FetchOutputFields(res, action.List).SelfOrDefaults(authResults.UserId)
@@ -181,16 +176,34 @@ func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (
 			outputOpts = append(outputOpts, handlers.WithAuthorizedActions(authorizedActions))
 		}
 
-		item, err := s.toProto(ctx, item, outputOpts...)
+		pbItem, err := s.toProto(ctx, item, outputOpts...)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 
-		if filter.Match(item) {
-			finalItems = append(finalItems, item)
+		if !filter.Match(pbItem) {
+			return false, nil
 		}
+		protos[item.GetPublicId()] = pbItem
+		return true, nil
+	}
+	listItemsFn := func(ctx context.Context, prevPageLastItem pagination.Item, limit int) ([]*server.Worker, error) {
+		return s.listFromRepo(ctx, scopeIds, server.WithLimit(limit), server.WithStartPageAfterItem(prevPageLastItem))
+	}
+
+	listResp, err := handlers.PaginatedList(ctx, req, s.maxPageSize, resource.Worker, authResults.GrantsHash, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Worker, 0, len(listResp.Items))
+	for _, item := range listResp.Items {
+		finalItems = append(finalItems, protos[item.GetPublicId()])
+	}
+	nextPageToken, err := handlers.NextPageToken(ctx, listResp)
+	if err != nil {
+		return nil, err
 	}
-	return &pbs.ListWorkersResponse{Items: finalItems}, nil
+	return &pbs.ListWorkersResponse{Items: finalItems, NextPageToken: nextPageToken}, nil
 }
 
 // GetWorker implements the interface pbs.WorkerServiceServer.
@@ -575,12 +588,13 @@ func (s Service) ReinitializeCertificateAuthority(ctx context.Context, req *pbs.
 	return &pbs.ReinitializeCertificateAuthorityResponse{Item: ca}, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*server.Worker, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string, opt ...server.Option) ([]*server.Worker, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	wl, err := repo.ListWorkers(ctx, scopeIds, server.WithLiveness(-1), server.WithLimit(-1))
+	opt = append([]server.Option{server.WithLiveness(-1)}, opt...)
+	wl, err := repo.ListWorkers(ctx, scopeIds, opt...)
 	if err != nil {
 		return nil, err
 	}
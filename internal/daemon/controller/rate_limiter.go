@@ -5,8 +5,12 @@ package controller
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/ratelimit"
@@ -31,12 +35,26 @@ type (
 	resources       map[string]resourceActions
 )
 
+// override is a representation of a ratelimit.Override that is used when
+// emitting a sys event to report the rate limit configuration.
+type override struct {
+	Users  []string  `json:"users,omitempty"`
+	Roles  []string  `json:"roles,omitempty"`
+	Limits resources `json:"limits"`
+}
+
 type rateLimiterConfig struct {
 	maxSize  int
 	disabled bool
 	configs  ratelimit.Configs
 
-	limits []rate.Limit
+	// shared enforces the limits across the controllers of a cluster, by
+	// syncing the usage of quotas with the database every syncInterval.
+	shared       bool
+	syncInterval time.Duration
+
+	limits    []rate.Limit
+	overrides []*ratelimit.Override
 }
 
 func newRateLimiterConfig(ctx context.Context, configs ratelimit.Configs, maxSize int, disabled bool) (*rateLimiterConfig, error) {
@@ -48,18 +66,23 @@ func newRateLimiterConfig(ctx context.Context, configs ratelimit.Configs, maxSiz
 	}
 
 	var limits []rate.Limit
+	var overrides []*ratelimit.Override
 	var err error
 	if !disabled {
 		if limits, err = configs.Limits(ctx); err != nil {
 			return nil, err
 		}
+		if overrides, err = configs.Overrides(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	return &rateLimiterConfig{
-		maxSize:  maxSize,
-		disabled: disabled,
-		configs:  configs,
-		limits:   limits,
+		maxSize:   maxSize,
+		disabled:  disabled,
+		configs:   configs,
+		limits:    limits,
+		overrides: overrides,
 	}, nil
 }
 
@@ -78,9 +101,42 @@ func (c *rateLimiterConfig) writeSysEvent(ctx context.Context) {
 		return
 	}
 
+	args := []any{
+		"limits",
+		newResources(c.limits),
+		"max_size",
+		c.maxSize,
+	}
+	if c.shared {
+		args = append(args,
+			"shared", true,
+			"sync_interval", c.syncInterval.String(),
+		)
+	}
+	if len(c.overrides) > 0 {
+		overrides := make([]override, 0, len(c.overrides))
+		for _, o := range c.overrides {
+			overrides = append(overrides, override{
+				Users:  o.Users,
+				Roles:  o.Roles,
+				Limits: newResources(o.Limits),
+			})
+		}
+		args = append(args, "overrides", overrides)
+	}
+	event.WriteSysEvent(
+		ctx,
+		op,
+		"controller api rate limiter",
+		args...,
+	)
+}
+
+// newResources creates the nested structure of the sys event for limits.
+func newResources(limits []rate.Limit) resources {
 	e := make(resources)
 
-	for _, l := range c.limits {
+	for _, l := range limits {
 		var r resourceActions
 		var a actionLimits
 		var ok bool
@@ -115,15 +171,7 @@ func (c *rateLimiterConfig) writeSysEvent(ctx context.Context) {
 		}
 		r[l.GetAction()] = a
 	}
-	event.WriteSysEvent(
-		ctx,
-		op,
-		"controller api rate limiter",
-		"limits",
-		e,
-		"max_size",
-		c.maxSize,
-	)
+	return e
 }
 
 func (c *Controller) initializeRateLimiter(conf *config.Config) error {
@@ -147,15 +195,12 @@ func (c *Controller) initializeRateLimiter(conf *config.Config) error {
 	if err != nil {
 		return err
 	}
+	rlConfig.shared = conf.Controller.ApiRateLimitShared
+	rlConfig.syncInterval = conf.Controller.ApiRateLimitSyncIntervalDuration
 
-	switch {
-	case rlConfig.disabled:
-		c.rateLimiter = rate.NopLimiter
-	default:
-		c.rateLimiter, err = ratelimit.NewLimiter(rlConfig.limits, rlConfig.maxSize)
-		if err != nil {
-			return err
-		}
+	c.rateLimiter, err = c.newRateLimiter(rlConfig)
+	if err != nil {
+		return err
 	}
 
 	c.conf.rateLimiterConfig = rlConfig
@@ -164,6 +209,109 @@ func (c *Controller) initializeRateLimiter(conf *config.Config) error {
 	return nil
 }
 
+// newRateLimiter creates the ratelimit.Limiter for rlConfig. When there are
+// overrides for users or roles, a ratelimit.OverrideLimiter is created which
+// resolves the principals of auth tokens with rateLimitPrincipals. When the
+// limits are shared, the usage of quotas is synced with the database.
+func (c *Controller) newRateLimiter(rlConfig *rateLimiterConfig) (ratelimit.Limiter, error) {
+	if rlConfig.disabled {
+		return rate.NopLimiter, nil
+	}
+
+	var quotas *ratelimit.SharedQuotas
+	if rlConfig.shared {
+		var err error
+		if quotas, err = c.newSharedQuotas(rlConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	var l ratelimit.Limiter
+	var err error
+	switch {
+	case len(rlConfig.overrides) > 0:
+		var opt []ratelimit.Option
+		if quotas != nil {
+			opt = append(opt, ratelimit.WithSharedQuotas(quotas))
+		}
+		l, err = ratelimit.NewOverrideLimiter(rlConfig.limits, rlConfig.overrides, rlConfig.maxSize, c.rateLimitPrincipals, opt...)
+	case quotas != nil:
+		l, err = ratelimit.NewSharedLimiter(rlConfig.limits, rlConfig.maxSize, quotas)
+	default:
+		l, err = ratelimit.NewLimiter(rlConfig.limits, rlConfig.maxSize)
+	}
+	if err != nil {
+		if quotas != nil {
+			quotas.Stop()
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// newSharedQuotas creates the ratelimit.SharedQuotas which syncs the usage of
+// quotas with the database.
+func (c *Controller) newSharedQuotas(rlConfig *rateLimiterConfig) (*ratelimit.SharedQuotas, error) {
+	const op = "controller.(Controller).newSharedQuotas"
+	if c.conf.Server == nil || c.conf.Database == nil {
+		return nil, errors.New(c.baseContext, errors.InvalidConfiguration, op, "shared rate limits require a database")
+	}
+	dbase := db.New(c.conf.Database)
+	repo, err := ratelimit.NewRepository(c.baseContext, dbase, dbase)
+	if err != nil {
+		return nil, errors.Wrap(c.baseContext, err, op)
+	}
+	return ratelimit.NewSharedQuotas(c.baseContext, repo, rlConfig.syncInterval, rlConfig.maxSize)
+}
+
+// rateLimitPrincipals returns the user of the auth token and the roles the
+// user gets grants from, directly or through groups and managed groups. An
+// empty user id is returned when the auth token doesn't exist, isn't valid or
+// issued, or encryptedToken isn't the encrypted token of the auth token.
+func (c *Controller) rateLimitPrincipals(ctx context.Context, authTokenId, encryptedToken string) (string, []string, error) {
+	const op = "controller.(Controller).rateLimitPrincipals"
+	atRepo, err := c.AuthTokenRepoFn()
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	at, err := atRepo.LookupAuthToken(ctx, authTokenId)
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	if at == nil {
+		return "", nil, nil
+	}
+	token, err := authtoken.DecryptToken(ctx, c.kms, at.GetScopeId(), authTokenId, encryptedToken)
+	if err != nil {
+		// The auth token id is not secret, so a token which can't be
+		// decrypted gets the default rate limits.
+		return "", nil, nil
+	}
+	at, err = atRepo.ValidateToken(ctx, authTokenId, token)
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	if at == nil || at.GetStatus() != string(authtoken.IssuedStatus) {
+		return "", nil, nil
+	}
+
+	iamRepo, err := c.IamRepoFn()
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	grants, err := iamRepo.GrantsForUser(ctx, at.GetIamUserId())
+	if err != nil {
+		return "", nil, errors.Wrap(ctx, err, op)
+	}
+	roleIds := make([]string, 0, len(grants))
+	for _, g := range grants {
+		if !slices.Contains(roleIds, g.RoleId) {
+			roleIds = append(roleIds, g.RoleId)
+		}
+	}
+	return at.GetIamUserId(), roleIds, nil
+}
+
 func (c *Controller) getRateLimiter() ratelimit.Limiter {
 	c.rateLimiterMu.RLock()
 	defer c.rateLimiterMu.RUnlock()
@@ -195,24 +343,21 @@ func (c *Controller) ReloadRateLimiter(newConfig *config.Config) error {
 	if err != nil {
 		return err
 	}
+	rlConfig.shared = newConfig.Controller.ApiRateLimitShared
+	rlConfig.syncInterval = newConfig.Controller.ApiRateLimitSyncIntervalDuration
 
 	// Config has not changed, no need to reload.
 	if c.conf.rateLimiterConfig.maxSize == rlConfig.maxSize &&
 		c.conf.rateLimiterConfig.disabled == rlConfig.disabled &&
+		c.conf.rateLimiterConfig.shared == rlConfig.shared &&
+		c.conf.rateLimiterConfig.syncInterval == rlConfig.syncInterval &&
 		c.conf.rateLimiterConfig.configs.Equal(rlConfig.configs) {
 		return nil
 	}
 
-	var limiter ratelimit.Limiter
-	switch {
-	case rlConfig.disabled:
-		limiter = rate.NopLimiter
-	default:
-		limiter, err = ratelimit.NewLimiter(rlConfig.limits, rlConfig.maxSize)
-		if err != nil {
-			return errors.Wrap(c.baseContext, err, op)
-		}
-
+	limiter, err := c.newRateLimiter(rlConfig)
+	if err != nil {
+		return errors.Wrap(c.baseContext, err, op)
 	}
 	c.rateLimiterMu.Lock()
 	old := c.rateLimiter
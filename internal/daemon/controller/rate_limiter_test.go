@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/ratelimit"
 	"github.com/hashicorp/eventlogger/formatter_filters/cloudevents"
@@ -669,3 +672,164 @@ func Test_rateLimiterConfig_writeSysEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestControllerReloadRateLimiterOverrides(t *testing.T) {
+	// Disabling eventing so reduce noise.
+	event.TestWithoutEventing(t)
+
+	overridesConf := &config.Config{
+		Controller: &config.Controller{
+			ApiRateLimiterMaxQuotas: ratelimit.DefaultLimiterMaxQuotas(),
+			ApiRateLimits: ratelimit.Configs{
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Users:     []string{"u_1234567890"},
+				},
+			},
+		},
+	}
+	defaultConf := &config.Config{
+		Controller: &config.Controller{
+			ApiRateLimiterMaxQuotas: ratelimit.DefaultLimiterMaxQuotas(),
+		},
+	}
+
+	c := &Controller{
+		baseContext: context.Background(),
+		conf: &Config{
+			RawConfig: defaultConf,
+		},
+	}
+	require.NoError(t, c.initializeRateLimiter(defaultConf))
+	_, ok := c.getRateLimiter().(*rate.Limiter)
+	require.True(t, ok, "expected rate.Limiter")
+
+	require.NoError(t, c.ReloadRateLimiter(overridesConf))
+	prevLimiter := c.getRateLimiter()
+	_, ok = prevLimiter.(*ratelimit.OverrideLimiter)
+	require.True(t, ok, "expected ratelimit.OverrideLimiter")
+	require.Len(t, c.conf.rateLimiterConfig.overrides, 1)
+	assert.Equal(t, []string{"u_1234567890"}, c.conf.rateLimiterConfig.overrides[0].Users)
+
+	// reloading the same overrides keeps the limiter
+	require.NoError(t, c.ReloadRateLimiter(overridesConf))
+	assert.Same(t, prevLimiter, c.getRateLimiter())
+
+	require.NoError(t, c.ReloadRateLimiter(defaultConf))
+	_, ok = c.getRateLimiter().(*rate.Limiter)
+	assert.True(t, ok, "expected rate.Limiter")
+	assert.Empty(t, c.conf.rateLimiterConfig.overrides)
+
+	invalidConf := &config.Config{
+		Controller: &config.Controller{
+			ApiRateLimiterMaxQuotas: ratelimit.DefaultLimiterMaxQuotas(),
+			ApiRateLimits: ratelimit.Configs{
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Roles:     []string{"admins"},
+				},
+			},
+		},
+	}
+	assert.EqualError(t, c.ReloadRateLimiter(invalidConf), "ratelimit.(Configs).Overrides: invalid role id admins: configuration issue: error #5000")
+}
+
+func TestControllerReloadRateLimiterShared(t *testing.T) {
+	// Disabling eventing so reduce noise.
+	event.TestWithoutEventing(t)
+
+	conn, _ := db.TestSetup(t, "postgres")
+	sharedConf := &config.Config{
+		Controller: &config.Controller{
+			ApiRateLimiterMaxQuotas:          ratelimit.DefaultLimiterMaxQuotas(),
+			ApiRateLimitShared:               true,
+			ApiRateLimitSyncIntervalDuration: 500 * time.Millisecond,
+		},
+	}
+	defaultConf := &config.Config{
+		Controller: &config.Controller{
+			ApiRateLimiterMaxQuotas: ratelimit.DefaultLimiterMaxQuotas(),
+		},
+	}
+
+	c := &Controller{
+		baseContext: context.Background(),
+		conf: &Config{
+			Server:    &base.Server{Database: conn},
+			RawConfig: defaultConf,
+		},
+	}
+	require.NoError(t, c.initializeRateLimiter(defaultConf))
+	_, ok := c.getRateLimiter().(*rate.Limiter)
+	require.True(t, ok, "expected rate.Limiter")
+
+	require.NoError(t, c.ReloadRateLimiter(sharedConf))
+	prevLimiter := c.getRateLimiter()
+	_, ok = prevLimiter.(*ratelimit.SharedLimiter)
+	require.True(t, ok, "expected ratelimit.SharedLimiter")
+
+	// reloading the same config keeps the limiter
+	require.NoError(t, c.ReloadRateLimiter(sharedConf))
+	assert.Same(t, prevLimiter, c.getRateLimiter())
+
+	// a different sync interval replaces the limiter
+	sharedConf.Controller.ApiRateLimitSyncIntervalDuration = time.Second
+	require.NoError(t, c.ReloadRateLimiter(sharedConf))
+	assert.NotSame(t, prevLimiter, c.getRateLimiter())
+
+	require.NoError(t, c.ReloadRateLimiter(defaultConf))
+	_, ok = c.getRateLimiter().(*rate.Limiter)
+	assert.True(t, ok, "expected rate.Limiter")
+
+	// shared rate limits need a database
+	c.conf.Server = nil
+	require.Error(t, c.ReloadRateLimiter(sharedConf))
+}
+
+func TestController_rateLimitPrincipals(t *testing.T) {
+	tc := NewTestController(t, nil)
+	defer tc.Shutdown()
+	ctx := tc.Context()
+	c := tc.Controller()
+
+	encryptedToken := func(token string) string {
+		parts := strings.Split(token, "_")
+		require.Len(t, parts, 3)
+		return parts[2]
+	}
+	tok := tc.Token()
+	other := tc.UnprivilegedToken()
+
+	t.Run("valid", func(t *testing.T) {
+		userId, roleIds, err := c.rateLimitPrincipals(ctx, tok.Id, encryptedToken(tok.Token))
+		require.NoError(t, err)
+		assert.Equal(t, tok.UserId, userId)
+		assert.NotEmpty(t, roleIds)
+	})
+	t.Run("forged-token", func(t *testing.T) {
+		userId, roleIds, err := c.rateLimitPrincipals(ctx, tok.Id, "s1forged")
+		require.NoError(t, err)
+		assert.Empty(t, userId)
+		assert.Empty(t, roleIds)
+	})
+	t.Run("token-of-other-auth-token", func(t *testing.T) {
+		userId, roleIds, err := c.rateLimitPrincipals(ctx, tok.Id, encryptedToken(other.Token))
+		require.NoError(t, err)
+		assert.Empty(t, userId)
+		assert.Empty(t, roleIds)
+	})
+	t.Run("unknown-auth-token", func(t *testing.T) {
+		userId, roleIds, err := c.rateLimitPrincipals(ctx, "at_1234567890", encryptedToken(tok.Token))
+		require.NoError(t, err)
+		assert.Empty(t, userId)
+		assert.Empty(t, roleIds)
+	})
+}
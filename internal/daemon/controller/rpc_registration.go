@@ -27,6 +27,9 @@ func init() {
 		registerControllerMultihopService,
 		registerControllerUpstreamMessageService,
 	)
+	controllerRegisterUpstreamMessageHandlerFunctions = append(controllerRegisterUpstreamMessageHandlerFunctions,
+		registerControllerSessionOutputHandler,
+	)
 }
 
 func registerControllerServerCoordinationService(ctx context.Context, c *Controller, server *grpc.Server) error {
@@ -46,6 +49,8 @@ func registerControllerServerCoordinationService(ctx context.Context, c *Control
 		c.WorkerAuthRepoStorageFn,
 		c.SessionRepoFn,
 		c.ConnectionRepoFn,
+		c.RecordingRepoFn,
+		c.PluginStorageBucketRepoFn,
 		c.downstreamWorkers,
 		c.workerStatusUpdateTimes,
 		c.kms,
@@ -73,6 +78,8 @@ func registerControllerSessionService(ctx context.Context, c *Controller, server
 		c.WorkerAuthRepoStorageFn,
 		c.SessionRepoFn,
 		c.ConnectionRepoFn,
+		c.RecordingRepoFn,
+		c.PluginStorageBucketRepoFn,
 		c.downstreamWorkers,
 		c.workerStatusUpdateTimes,
 		c.kms,
@@ -145,3 +152,23 @@ func registerControllerUpstreamMessageService(ctx context.Context, c *Controller
 	}
 	return nil
 }
+
+func registerControllerSessionOutputHandler(ctx context.Context, c *Controller) error {
+	const op = "controller.registerControllerSessionOutputHandler"
+
+	switch {
+	case nodeenrollment.IsNil(ctx):
+		return fmt.Errorf("%s: context is nil", op)
+	case c == nil:
+		return fmt.Errorf("%s: controller is nil", op)
+	}
+
+	h, err := handlers.NewSessionOutputHandler(ctx, c.ServersRepoFn, c.SessionRepoFn)
+	if err != nil {
+		return fmt.Errorf("%s: error creating session output handler: %w", op, err)
+	}
+	if err := handlers.RegisterUpstreamMessageHandler(ctx, pbs.MsgType_MSG_TYPE_SEND_SESSION_OUTPUT, h); err != nil {
+		return fmt.Errorf("%s: error registering session output handler: %w", op, err)
+	}
+	return nil
+}
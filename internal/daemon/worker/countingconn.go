@@ -4,19 +4,23 @@
 package worker
 
 import (
+	"context"
 	"net"
 	"sync"
+	"time"
 )
 
 // countingConn is a `net.Conn` implementation that records the bytes that go
-// across Read() and Write(). All other `net.Conn` function calls are a
-// pass-through to the underlying `net.Conn`, meaning it's also safe to call
-// those functions directly on the underlying object, if you have access to it.
+// across Read() and Write(), as well as when bytes last went across. All other
+// `net.Conn` function calls are a pass-through to the underlying `net.Conn`,
+// meaning it's also safe to call those functions directly on the underlying
+// object, if you have access to it.
 type countingConn struct {
 	net.Conn
 
 	bytesRead    int64
 	bytesWritten int64
+	lastActivity time.Time
 	// Use mutex for counters as net.Conn methods may be called concurrently
 	// https://github.com/golang/go/issues/27203#issuecomment-415854958
 	mu sync.Mutex
@@ -36,12 +40,23 @@ func (c *countingConn) BytesWritten() int64 {
 	return c.bytesWritten
 }
 
+// LastActivity reports the last time bytes were read or written, or the zero
+// time if none have been
+func (c *countingConn) LastActivity() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastActivity
+}
+
 // Read wraps the embedded conn's Read() and counts the number of bytes read
 // (the number of bytes the client sent to us).
 func (c *countingConn) Read(in []byte) (int, error) {
 	n, err := c.Conn.Read(in)
 	c.mu.Lock()
 	c.bytesRead += int64(n)
+	if n > 0 {
+		c.lastActivity = time.Now()
+	}
 	c.mu.Unlock()
 	return n, err
 }
@@ -52,6 +67,36 @@ func (c *countingConn) Write(in []byte) (int, error) {
 	n, err := c.Conn.Write(in)
 	c.mu.Lock()
 	c.bytesWritten += int64(n)
+	if n > 0 {
+		c.lastActivity = time.Now()
+	}
 	c.mu.Unlock()
 	return n, err
 }
+
+// waitForIdle blocks until no bytes have been read or written for the given
+// timeout, in which case it returns true, or until ctx is done, in which case
+// it returns false. The connection is considered active when waitForIdle is
+// called.
+func (c *countingConn) waitForIdle(ctx context.Context, timeout time.Duration) bool {
+	c.mu.Lock()
+	if c.lastActivity.IsZero() {
+		c.lastActivity = time.Now()
+	}
+	c.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			idle := time.Since(c.LastActivity())
+			if idle >= timeout {
+				return true
+			}
+			timer.Reset(timeout - idle)
+		}
+	}
+}
@@ -4,10 +4,12 @@
 package worker
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -154,6 +156,40 @@ func TestCountingConnConcurrentCalls(t *testing.T) {
 	require.EqualValues(t, concurrentWrites*len(bytesToWrite), conn.BytesWritten())
 }
 
+func TestCountingConnWaitForIdle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("idle", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 1}}
+		start := time.Now()
+		require.True(t, conn.waitForIdle(context.Background(), 50*time.Millisecond))
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("activity extends timeout", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 1}}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			for i := 0; i < 5; i++ {
+				time.Sleep(20 * time.Millisecond)
+				_, _ = conn.Write([]byte("a"))
+			}
+		}()
+		start := time.Now()
+		require.True(t, conn.waitForIdle(ctx, 50*time.Millisecond))
+		require.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+		require.EqualValues(t, 5, conn.BytesWritten())
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		conn := &countingConn{Conn: &testNetConn{bytesToRead: 1}}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.False(t, conn.waitForIdle(ctx, time.Hour))
+	})
+}
+
 type testNetConn struct {
 	net.Conn // So we don't have to implement the entire interface.
 
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/daemon/common"
@@ -22,6 +23,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	coresession "github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/util"
 	"github.com/hashicorp/boundary/sdk/pbs/proxy"
 	"github.com/hashicorp/boundary/sdk/wspb"
@@ -231,6 +233,19 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			return
 		}
 
+		// If the session has an idle timeout, close the connection once it
+		// has carried no traffic for that long.
+		var idleClosed atomic.Bool
+		if idleTimeout := sess.GetIdleTimeout(); idleTimeout > 0 {
+			go func() {
+				if cc.waitForIdle(connCtx, idleTimeout) {
+					idleClosed.Store(true)
+					event.WriteSysEvent(ctx, op, "closing idle connection", "session_id", sessionId, "connection_id", acResp.GetConnectionId(), "idle_timeout", idleTimeout.String())
+					connCancel()
+				}
+			}()
+		}
+
 		defer func() {
 			ccd := map[string]*session.ConnectionCloseData{
 				acResp.GetConnectionId(): {
@@ -239,6 +254,9 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 					BytesDown: cc.BytesWritten(),
 				},
 			}
+			if idleClosed.Load() {
+				ccd[acResp.GetConnectionId()].ClosedReason = coresession.ConnectionIdleTimeout
+			}
 			if sessionManager.RequestCloseConnections(ctx, ccd) {
 				event.WriteSysEvent(ctx, op, "connection closed", "session_id", sessionId, "connection_id", acResp.GetConnectionId())
 			}
@@ -294,7 +312,7 @@ func (w *Worker) handleProxy(listenerCfg *listenerutil.ListenerConfig, sessionMa
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "error getting decryption function")
 			event.WriteError(ctx, op, err)
 		}
-		runProxy, err := handleProxyFn(ctx, ctx, decryptFn, cc, pDialer, acResp.GetConnectionId(), protocolCtx, w.recorderManager)
+		runProxy, err := handleProxyFn(ctx, ctx, decryptFn, cc, pDialer, acResp.GetConnectionId(), protocolCtx, w.recorderManager, proxyHandlers.WithOutputWatcher(sess))
 		if err != nil {
 			conn.Close(proxyHandlers.WebsocketStatusProtocolSetupError, "unable to setup proxying")
 			event.WriteError(ctx, op, err)
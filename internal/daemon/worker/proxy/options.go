@@ -26,6 +26,7 @@ type Options struct {
 	WithInjectedApplicationCredentials []*serverpb.Credential
 	WithPostConnectionHook             func(net.Conn)
 	WithDnsServerAddress               string
+	WithOutputWatcher                  OutputWatcher
 }
 
 func getDefaultOptions() Options {
@@ -61,3 +62,11 @@ func WithDnsServerAddress(with string) Option {
 		o.WithDnsServerAddress = with
 	}
 }
+
+// WithOutputWatcher provides a watcher which receives the output of the
+// session sent to the client by a proxy handler.
+func WithOutputWatcher(w OutputWatcher) Option {
+	return func(o *Options) {
+		o.WithOutputWatcher = w
+	}
+}
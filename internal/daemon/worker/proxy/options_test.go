@@ -4,6 +4,7 @@
 package proxy
 
 import (
+	"context"
 	"net"
 	"reflect"
 	"runtime"
@@ -45,4 +46,17 @@ func Test_GetOpts(t *testing.T) {
 			runtime.FuncForPC(reflect.ValueOf(testOpts.WithPostConnectionHook).Pointer()).Name(),
 		)
 	})
+	t.Run("WithOutputWatcher", func(t *testing.T) {
+		assert := assert.New(t)
+		w := &testOutputWatcher{}
+		opts := GetOpts(WithOutputWatcher(w))
+		testOpts := getDefaultOptions()
+		assert.NotEqual(opts, testOpts)
+		testOpts.WithOutputWatcher = w
+		assert.Equal(opts, testOpts)
+	})
 }
+
+type testOutputWatcher struct{}
+
+func (*testOutputWatcher) WatchOutput(context.Context, []byte) {}
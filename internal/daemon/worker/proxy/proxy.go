@@ -9,12 +9,14 @@ import (
 	"net"
 	"sync"
 
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
 	TcpHandlerName = "tcp"
+	SshHandlerName = "ssh"
 
 	// handlers is the map of registered handlers
 	handlers sync.Map
@@ -28,12 +30,20 @@ var (
 	// GetHandler returns the handler registered for the provided worker and
 	// protocolContext. If a protocol cannot be determined or the protocol is
 	// not registered nil, ErrUnknownProtocol is returned.
-	GetHandler = tcpOnly
+	GetHandler = handlerForProtocolContext
 )
 
 // RecordingManager allows a handler for a protocol that supports recording.
 type RecordingManager any
 
+// OutputWatcher receives the output of a session sent to the client while
+// the session is being watched.
+type OutputWatcher interface {
+	// WatchOutput is called with output sent to the client. It must not
+	// retain data after it returns and must not block.
+	WatchOutput(ctx context.Context, data []byte)
+}
+
 // DecryptFn decrypts the provided bytes into a proto.Message
 type DecryptFn func(ctx context.Context, from []byte, to proto.Message) error
 
@@ -45,8 +55,8 @@ type ProxyConnFn func()
 // when a new client connection is created.  If there is an error ProxyConnFn must
 // be nil. If there is no error ProxyConnFn must be set.  When Handler has
 // returned, it is expected that the initial connection to the endpoint has been
-// established.
-type Handler func(controlCtx context.Context, dataCtx context.Context, df DecryptFn, c net.Conn, pd *ProxyDialer, connId string, pb *anypb.Any, rm RecordingManager) (ProxyConnFn, error)
+// established. Handlers may support the WithOutputWatcher option.
+type Handler func(controlCtx context.Context, dataCtx context.Context, df DecryptFn, c net.Conn, pd *ProxyDialer, connId string, pb *anypb.Any, rm RecordingManager, opt ...Option) (ProxyConnFn, error)
 
 func RegisterHandler(protocol string, handler Handler) error {
 	_, loaded := handlers.LoadOrStore(protocol, handler)
@@ -56,9 +66,14 @@ func RegisterHandler(protocol string, handler Handler) error {
 	return nil
 }
 
-// tcpOnly returns only the TCP protocol.
-func tcpOnly(string, proto.Message) (Handler, error) {
-	handler, ok := handlers.Load(TcpHandlerName)
+// handlerForProtocolContext returns the SSH handler when the protocolContext
+// is an SshProtocolContext and the TCP handler otherwise.
+func handlerForProtocolContext(_ string, protocolContext proto.Message) (Handler, error) {
+	protocol := TcpHandlerName
+	if a, ok := protocolContext.(*anypb.Any); ok && a != nil && a.MessageIs(&pbs.SshProtocolContext{}) {
+		protocol = SshHandlerName
+	}
+	handler, ok := handlers.Load(protocol)
 	if !ok {
 		return nil, ErrUnknownProtocol
 	}
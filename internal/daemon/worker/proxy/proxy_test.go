@@ -9,6 +9,7 @@ import (
 	"sync"
 	"testing"
 
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -17,7 +18,7 @@ import (
 func TestRegisterHandler(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
-	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager) (ProxyConnFn, error) {
+	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager, ...Option) (ProxyConnFn, error) {
 		return nil, nil
 	}
 	oldHandler := handlers
@@ -40,7 +41,7 @@ func TestRegisterHandler(t *testing.T) {
 
 func TestAlwaysTcpGetHandler(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager) (ProxyConnFn, error) {
+	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager, ...Option) (ProxyConnFn, error) {
 		return nil, nil
 	}
 	oldHandler := handlers
@@ -48,12 +49,41 @@ func TestAlwaysTcpGetHandler(t *testing.T) {
 		handlers = oldHandler
 	})
 	handlers = sync.Map{}
-	_, err := tcpOnly("wid", nil)
+	_, err := handlerForProtocolContext("wid", nil)
 	assert.ErrorIs(err, ErrUnknownProtocol)
 
 	require.NoError(RegisterHandler("tcp", fn))
 
-	handler, err := tcpOnly("wid", nil)
+	handler, err := handlerForProtocolContext("wid", nil)
+	require.NoError(err)
+	require.NotNil(handler)
+
+	tcpContext, err := anypb.New(&pbs.UsernamePassword{})
+	require.NoError(err)
+	handler, err = handlerForProtocolContext("wid", tcpContext)
+	require.NoError(err)
+	require.NotNil(handler)
+}
+
+func TestSshGetHandler(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	fn := func(context.Context, context.Context, DecryptFn, net.Conn, *ProxyDialer, string, *anypb.Any, RecordingManager, ...Option) (ProxyConnFn, error) {
+		return nil, nil
+	}
+	oldHandler := handlers
+	t.Cleanup(func() {
+		handlers = oldHandler
+	})
+	handlers = sync.Map{}
+	sshContext, err := anypb.New(&pbs.SshProtocolContext{})
+	require.NoError(err)
+
+	require.NoError(RegisterHandler(TcpHandlerName, fn))
+	_, err = handlerForProtocolContext("wid", sshContext)
+	assert.ErrorIs(err, ErrUnknownProtocol)
+
+	require.NoError(RegisterHandler(SshHandlerName, fn))
+	handler, err := handlerForProtocolContext("wid", sshContext)
 	require.NoError(err)
 	require.NotNil(handler)
 }
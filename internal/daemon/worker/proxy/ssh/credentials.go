@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	targetssh "github.com/hashicorp/boundary/internal/target/ssh"
+	"golang.org/x/crypto/ssh"
+)
+
+// clientConfig returns the ssh.ClientConfig used to authenticate to the
+// endpoint with the provided credentials. All credentials must be for the same
+// username. The endpoint must present one of hostKeys, which are public keys
+// in the OpenSSH authorized_keys format.
+func clientConfig(ctx context.Context, creds []*pbs.Credential, hostKeys string) (*ssh.ClientConfig, error) {
+	const op = "ssh.clientConfig"
+	if len(creds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing credentials")
+	}
+	verifyHostKey, err := hostKeyCallback(ctx, hostKeys)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	var username string
+	var auths []ssh.AuthMethod
+	for _, c := range creds {
+		var credUsername string
+		var auth ssh.AuthMethod
+		switch cred := c.GetCredential().(type) {
+		case *pbs.Credential_UsernamePassword:
+			credUsername = cred.UsernamePassword.GetUsername()
+			auth = ssh.Password(cred.UsernamePassword.GetPassword())
+
+		case *pbs.Credential_SshPrivateKey:
+			credUsername = cred.SshPrivateKey.GetUsername()
+			var signer ssh.Signer
+			var err error
+			if passphrase := cred.SshPrivateKey.GetPrivateKeyPassphrase(); passphrase != "" {
+				signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(cred.SshPrivateKey.GetPrivateKey()), []byte(passphrase))
+			} else {
+				signer, err = ssh.ParsePrivateKey([]byte(cred.SshPrivateKey.GetPrivateKey()))
+			}
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse private key"))
+			}
+			auth = ssh.PublicKeys(signer)
+
+		case *pbs.Credential_SshCertificate:
+			credUsername = cred.SshCertificate.GetUsername()
+			signer, err := ssh.ParsePrivateKey([]byte(cred.SshCertificate.GetPrivateKey()))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse private key"))
+			}
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cred.SshCertificate.GetCertificate()))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to parse certificate"))
+			}
+			cert, ok := pub.(*ssh.Certificate)
+			if !ok {
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("expected an ssh certificate, got %s", pub.Type()))
+			}
+			certSigner, err := ssh.NewCertSigner(cert, signer)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to create certificate signer"))
+			}
+			auth = ssh.PublicKeys(certSigner)
+
+		default:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unsupported credential type %T", cred))
+		}
+
+		switch {
+		case credUsername == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing username")
+		case username == "":
+			username = credUsername
+		case username != credUsername:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "all credentials must have the same username")
+		}
+		auths = append(auths, auth)
+	}
+
+	return &ssh.ClientConfig{
+		User:            username,
+		Auth:            auths,
+		HostKeyCallback: verifyHostKey,
+		Timeout:         handshakeTimeout,
+	}, nil
+}
+
+// hostKeyCallback returns an ssh.HostKeyCallback which only accepts the host
+// keys in hostKeys. A host certificate is accepted if the key it certifies is
+// one of hostKeys. An error is returned if hostKeys contains no public key, so
+// that no connection is established to an endpoint which can't be verified.
+func hostKeyCallback(ctx context.Context, hostKeys string) (ssh.HostKeyCallback, error) {
+	const op = "ssh.hostKeyCallback"
+	keys, err := targetssh.ParseHostKeys(ctx, hostKeys)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+		if cert, ok := key.(*ssh.Certificate); ok {
+			key = cert.Key
+		}
+		for _, k := range keys {
+			if bytes.Equal(k.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		return errors.New(ctx, errors.Verify, op, fmt.Sprintf("host key %s %s of the endpoint is not a host key of the target", key.Type(), ssh.FingerprintSHA256(key)))
+	}, nil
+}
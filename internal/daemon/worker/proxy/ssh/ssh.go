@@ -0,0 +1,378 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package ssh provides a worker proxy handler for ssh targets. The handler
+// terminates the ssh connection from the client, authenticates to the
+// endpoint using the injected application credentials of the session and
+// proxies channels and requests between the two connections. The credentials
+// are never sent to the client. When the session is recorded, the data and
+// requests of every channel are recorded by the recording manager of the
+// worker. When the session is watched, the output the endpoint sends on
+// session channels is passed to the output watcher of the session.
+package ssh
+
+import (
+	"context"
+	"crypto/ed25519"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	"github.com/hashicorp/boundary/internal/daemon/worker/recorder"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// handshakeTimeout is the time the ssh handshake with the endpoint, including
+// the authentication with the injected credentials, can take before the
+// connection is closed.
+var handshakeTimeout = 30 * time.Second
+
+// sessionChannelType is the type of ssh channels which run a program. Only
+// the output of these channels is watched.
+const sessionChannelType = "session"
+
+// recordingManager creates the recorders of recorded connections.
+type recordingManager interface {
+	NewConnectionRecorder(ctx context.Context, sshCtx *pbs.SshProtocolContext, secrets *pbs.SshProtocolSecrets, connId string) (*recorder.ConnectionRecorder, error)
+}
+
+func init() {
+	err := proxy.RegisterHandler(proxy.SshHandlerName, handleProxy)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// handleProxy establishes an ssh connection to the endpoint using the
+// credentials in the provided SshProtocolContext. The connection is only
+// established if the endpoint presents one of the host keys of the protocol
+// context and completes the handshake within handshakeTimeout.
+//
+// handleProxy returns a ProxyConnFn which terminates the ssh connection from
+// the client and proxies channels and requests between the client and the
+// endpoint. It blocks until either connection is closed.
+//
+// If the session is recorded, rm must be able to create a recorder for the
+// connection or no connection is established. Supports the WithOutputWatcher
+// option.
+func handleProxy(controlCtx context.Context, dataCtx context.Context, df proxy.DecryptFn, conn net.Conn, out *proxy.ProxyDialer, connId string, pc *anypb.Any, rm proxy.RecordingManager, opt ...proxy.Option) (proxy.ProxyConnFn, error) {
+	const op = "ssh.HandleProxy"
+	switch {
+	case conn == nil:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "conn is nil")
+	case out == nil:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "proxy dialer is nil")
+	case len(connId) == 0:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "connection id is empty")
+	case pc == nil:
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "protocol context is nil")
+	}
+
+	watcher := proxy.GetOpts(opt...).WithOutputWatcher
+
+	sshCtx := &pbs.SshProtocolContext{}
+	if err := pc.UnmarshalTo(sshCtx); err != nil {
+		return nil, errors.Wrap(controlCtx, err, op, errors.WithMsg("unable to unmarshal ssh protocol context"))
+	}
+	secrets, err := protocolSecrets(controlCtx, df, sshCtx)
+	if err != nil {
+		return nil, errors.Wrap(controlCtx, err, op)
+	}
+	if len(secrets.GetHostKey()) != ed25519.PrivateKeySize {
+		return nil, errors.New(controlCtx, errors.InvalidParameter, op, "invalid host key")
+	}
+	hostKey, err := ssh.NewSignerFromKey(ed25519.PrivateKey(secrets.GetHostKey()))
+	if err != nil {
+		return nil, errors.Wrap(controlCtx, err, op, errors.WithMsg("unable to create host key signer"))
+	}
+	clientConfig, err := clientConfig(controlCtx, secrets.GetCredentials(), sshCtx.GetHostKeys())
+	if err != nil {
+		return nil, errors.Wrap(controlCtx, err, op)
+	}
+
+	rec, err := connectionRecorder(controlCtx, sshCtx, secrets, connId, rm)
+	if err != nil {
+		return nil, errors.Wrap(controlCtx, err, op)
+	}
+	closeRecorder := func() {
+		if rec == nil {
+			return
+		}
+		if err := rec.Close(dataCtx); err != nil {
+			event.WriteError(dataCtx, op, err, event.WithInfoMsg("unable to close connection recording", "connection_id", connId))
+		}
+	}
+
+	remoteConn, err := out.Dial(controlCtx)
+	if err != nil {
+		closeRecorder()
+		return nil, err
+	}
+	// NewClientConn does not apply the timeout of the client config, so an
+	// endpoint which never completes the handshake would block it forever.
+	if err := remoteConn.SetDeadline(time.Now().Add(clientConfig.Timeout)); err != nil {
+		_ = remoteConn.Close()
+		closeRecorder()
+		return nil, errors.Wrap(controlCtx, err, op, errors.WithMsg("unable to set handshake deadline"))
+	}
+	endpointConn, endpointChans, endpointReqs, err := ssh.NewClientConn(remoteConn, remoteConn.RemoteAddr().String(), clientConfig)
+	if err != nil {
+		_ = remoteConn.Close()
+		closeRecorder()
+		return nil, errors.Wrap(controlCtx, err, op, errors.WithMsg("unable to establish ssh connection to endpoint"))
+	}
+	if err := remoteConn.SetDeadline(time.Time{}); err != nil {
+		_ = endpointConn.Close()
+		closeRecorder()
+		return nil, errors.Wrap(controlCtx, err, op, errors.WithMsg("unable to clear handshake deadline"))
+	}
+
+	serverConfig := &ssh.ServerConfig{
+		// The client has already been authenticated and authorized by
+		// Boundary when the session was created.
+		NoClientAuth: true,
+	}
+	serverConfig.AddHostKey(hostKey)
+
+	return func() {
+		defer closeRecorder()
+		clientConn, clientChans, clientReqs, err := ssh.NewServerConn(conn, serverConfig)
+		if err != nil {
+			event.WriteError(dataCtx, op, err, event.WithInfoMsg("ssh handshake with client failed", "connection_id", connId))
+			_ = endpointConn.Close()
+			_ = conn.Close()
+			return
+		}
+
+		wg := new(sync.WaitGroup)
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			forwardGlobalRequests(endpointConn, clientReqs)
+		}()
+		go func() {
+			defer wg.Done()
+			forwardGlobalRequests(clientConn, endpointReqs)
+		}()
+		go func() {
+			defer wg.Done()
+			proxyNewChannels(dataCtx, endpointConn, clientChans, rec, watcher, bsr.Inbound)
+		}()
+		go func() {
+			defer wg.Done()
+			proxyNewChannels(dataCtx, clientConn, endpointChans, rec, watcher, bsr.Outbound)
+		}()
+		go func() {
+			_ = clientConn.Wait()
+			_ = endpointConn.Close()
+		}()
+		_ = endpointConn.Wait()
+		_ = clientConn.Close()
+		wg.Wait()
+	}, nil
+}
+
+// protocolSecrets returns the SshProtocolSecrets carried by the protocol
+// context, decrypting them with df when they are encrypted.
+func protocolSecrets(ctx context.Context, df proxy.DecryptFn, sshCtx *pbs.SshProtocolContext) (*pbs.SshProtocolSecrets, error) {
+	const op = "ssh.protocolSecrets"
+	if len(sshCtx.GetEncryptedSecrets()) == 0 {
+		if sshCtx.GetSecrets() == nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing secrets")
+		}
+		return sshCtx.GetSecrets(), nil
+	}
+	if df == nil {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "secrets are encrypted but no decrypt function is available")
+	}
+	secrets := &pbs.SshProtocolSecrets{}
+	if err := df(ctx, sshCtx.GetEncryptedSecrets(), secrets); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decrypt secrets"))
+	}
+	return secrets, nil
+}
+
+// connectionRecorder returns the recorder of the connection if its session
+// is recorded, or nil otherwise.
+func connectionRecorder(ctx context.Context, sshCtx *pbs.SshProtocolContext, secrets *pbs.SshProtocolSecrets, connId string, rm proxy.RecordingManager) (*recorder.ConnectionRecorder, error) {
+	const op = "ssh.connectionRecorder"
+	if sshCtx.GetSessionRecordingId() == "" {
+		return nil, nil
+	}
+	m, ok := rm.(recordingManager)
+	if !ok {
+		return nil, errors.New(ctx, errors.Internal, op, "session is recorded but the worker is unable to record sessions")
+	}
+	rec, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, connId)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to record connection"))
+	}
+	return rec, nil
+}
+
+// forwardGlobalRequests sends the requests received on reqs to dst and
+// replies with the response from dst.
+func forwardGlobalRequests(dst ssh.Conn, reqs <-chan *ssh.Request) {
+	for req := range reqs {
+		ok, payload, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if err != nil {
+			ok, payload = false, nil
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, payload)
+		}
+	}
+}
+
+// proxyNewChannels opens a channel on dst for every channel requested on chans
+// and proxies between the two channels once both are open. If rec is not nil,
+// every channel is recorded. If watcher is not nil, the output of session
+// channels is passed to it. dir is the direction of the data sent by the side
+// which requested the channels. proxyNewChannels returns once chans is closed
+// and all of its channels have been closed.
+func proxyNewChannels(ctx context.Context, dst ssh.Conn, chans <-chan ssh.NewChannel, rec *recorder.ConnectionRecorder, watcher proxy.OutputWatcher, dir bsr.Direction) {
+	const op = "ssh.proxyNewChannels"
+	chWg := new(sync.WaitGroup)
+	defer chWg.Wait()
+	for nc := range chans {
+		chWg.Add(1)
+		go func(nc ssh.NewChannel) {
+			defer chWg.Done()
+			dstCh, dstReqs, err := dst.OpenChannel(nc.ChannelType(), nc.ExtraData())
+			if err != nil {
+				var openErr *ssh.OpenChannelError
+				if errors.As(err, &openErr) {
+					_ = nc.Reject(openErr.Reason, openErr.Message)
+				} else {
+					_ = nc.Reject(ssh.ConnectionFailed, err.Error())
+				}
+				return
+			}
+			srcCh, srcReqs, err := nc.Accept()
+			if err != nil {
+				_ = dstCh.Close()
+				return
+			}
+			var chRec *recorder.ChannelRecorder
+			if rec != nil {
+				chRec, err = rec.NewChannelRecorder(ctx, nc.ChannelType())
+				if err != nil {
+					event.WriteError(ctx, op, err, event.WithInfoMsg("unable to record channel", "connection_recording_id", rec.Id()))
+					_ = srcCh.Close()
+					_ = dstCh.Close()
+					return
+				}
+				defer func() {
+					if err := chRec.Close(ctx); err != nil {
+						event.WriteError(ctx, op, err, event.WithInfoMsg("unable to close channel recording", "channel_recording_id", chRec.Id()))
+					}
+				}()
+			}
+			chWatcher := watcher
+			if nc.ChannelType() != sessionChannelType {
+				chWatcher = nil
+			}
+			wg := new(sync.WaitGroup)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				forwardChannel(ctx, dstCh, srcCh, srcReqs, chRec, chWatcher, dir)
+			}()
+			go func() {
+				defer wg.Done()
+				forwardChannel(ctx, srcCh, dstCh, dstReqs, chRec, chWatcher, reverse(dir))
+			}()
+			wg.Wait()
+		}(nc)
+	}
+}
+
+// forwardChannel copies the data, extended data and requests received on src
+// to dst. dst is closed once src has been closed and all of its data has been
+// forwarded. If rec is not nil, the data and requests are recorded in the
+// direction dir before they are forwarded. If recording fails, the data or
+// request is not forwarded. If watcher is not nil and dir is outbound, the
+// data and extended data are passed to the watcher.
+func forwardChannel(ctx context.Context, dst, src ssh.Channel, srcReqs <-chan *ssh.Request, rec *recorder.ChannelRecorder, watcher proxy.OutputWatcher, dir bsr.Direction) {
+	var data, extData io.Reader = src, src.Stderr()
+	if rec != nil {
+		data = io.TeeReader(data, &recordingWriter{ctx: ctx, rec: rec, dir: dir})
+		extData = io.TeeReader(extData, &recordingWriter{ctx: ctx, rec: rec, dir: dir})
+	}
+	if watcher != nil && dir == bsr.Outbound {
+		data = io.TeeReader(data, &watchingWriter{ctx: ctx, watcher: watcher})
+		extData = io.TeeReader(extData, &watchingWriter{ctx: ctx, watcher: watcher})
+	}
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(dst, data)
+		_ = dst.CloseWrite()
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(dst.Stderr(), extData)
+	}()
+	for req := range srcReqs {
+		if rec != nil {
+			if err := rec.RecordRequest(ctx, dir, req); err != nil {
+				if req.WantReply {
+					_ = req.Reply(false, nil)
+				}
+				continue
+			}
+		}
+		ok, err := dst.SendRequest(req.Type, req.WantReply, req.Payload)
+		if err != nil {
+			ok = false
+		}
+		if req.WantReply {
+			_ = req.Reply(ok, nil)
+		}
+	}
+	wg.Wait()
+	_ = dst.Close()
+}
+
+// recordingWriter records the data written to it on a channel recorder.
+type recordingWriter struct {
+	ctx context.Context
+	rec *recorder.ChannelRecorder
+	dir bsr.Direction
+}
+
+// Write records b.
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if err := w.rec.RecordData(w.ctx, w.dir, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// watchingWriter passes the data written to it to an output watcher.
+type watchingWriter struct {
+	ctx     context.Context
+	watcher proxy.OutputWatcher
+}
+
+// Write passes b to the watcher.
+func (w *watchingWriter) Write(b []byte) (int, error) {
+	w.watcher.WatchOutput(w.ctx, b)
+	return len(b), nil
+}
+
+// reverse returns the opposite of dir.
+func reverse(dir bsr.Direction) bsr.Direction {
+	if dir == bsr.Inbound {
+		return bsr.Outbound
+	}
+	return bsr.Inbound
+}
@@ -0,0 +1,571 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/proxy"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/testdata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// testEndpointHostKeys returns the host key of the ssh server started by
+// testEndpoint in the authorized_keys format.
+func testEndpointHostKeys(t *testing.T) string {
+	t.Helper()
+	hostKey, err := ssh.ParsePrivateKey(testdata.PEMBytes["ed25519"])
+	require.NoError(t, err)
+	return string(ssh.MarshalAuthorizedKey(hostKey.PublicKey()))
+}
+
+// testEndpoint starts an ssh server which accepts the provided password for
+// user and echos the payload of exec requests back to the client. The server
+// presents the host key returned by testEndpointHostKeys.
+func testEndpoint(t *testing.T, user, password string) net.Listener {
+	t.Helper()
+	hostKey, err := ssh.ParsePrivateKey(testdata.PEMBytes["ed25519"])
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == user && string(pass) == password {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, chans, reqs, err := ssh.NewServerConn(c, config)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for nc := range chans {
+					ch, chReqs, err := nc.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						for req := range chReqs {
+							if req.Type != "exec" {
+								_ = req.Reply(false, nil)
+								continue
+							}
+							_ = req.Reply(true, nil)
+							var cmd struct{ Command string }
+							_ = ssh.Unmarshal(req.Payload, &cmd)
+							_, _ = ch.Write([]byte(cmd.Command))
+							_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+							_ = ch.Close()
+						}
+					}()
+				}
+			}()
+		}
+	}()
+	return l
+}
+
+func testProtocolContext(t *testing.T, hostKey ed25519.PrivateKey, creds ...*pbs.Credential) *anypb.Any {
+	t.Helper()
+	pc, err := anypb.New(&pbs.SshProtocolContext{
+		HostKeys: testEndpointHostKeys(t),
+		Secrets: &pbs.SshProtocolSecrets{
+			Credentials: creds,
+			HostKey:     hostKey,
+		},
+	})
+	require.NoError(t, err)
+	return pc
+}
+
+func TestHandleProxy(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	l := testEndpoint(t, "user", "pass")
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(err)
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	pc := testProtocolContext(t, hostKey, &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+		},
+	})
+
+	// A net.Pipe can't be used since both sides of an ssh handshake start
+	// by writing their version.
+	cl, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	t.Cleanup(func() {
+		cl.Close()
+	})
+	clientSide, err := net.Dial("tcp", cl.Addr().String())
+	require.NoError(err)
+	workerSide, err := cl.Accept()
+	require.NoError(err)
+	watcher := &testOutputWatcher{}
+	fn, err := handleProxy(ctx, ctx, nil, workerSide, dialer, "someconnectionid", pc, nil, proxy.WithOutputWatcher(watcher))
+	require.NoError(err)
+	require.NotNil(fn)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(err)
+	// The client doesn't provide any credentials; they are injected by the
+	// worker.
+	c, chans, reqs, err := ssh.NewClientConn(clientSide, "localhost", &ssh.ClientConfig{
+		User:            "someone-else",
+		HostKeyCallback: ssh.FixedHostKey(hostSigner.PublicKey()),
+	})
+	require.NoError(err)
+	client := ssh.NewClient(c, chans, reqs)
+
+	sess, err := client.NewSession()
+	require.NoError(err)
+	out, err := sess.Output("hello")
+	require.NoError(err)
+	assert.Equal("hello", string(out))
+
+	require.NoError(client.Close())
+	<-done
+	// The output of the session channel is passed to the watcher
+	assert.Equal("hello", watcher.String())
+}
+
+// testOutputWatcher collects the output passed to it.
+type testOutputWatcher struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *testOutputWatcher) WatchOutput(_ context.Context, data []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(data)
+}
+
+func (w *testOutputWatcher) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestHandleProxy_Errors(t *testing.T) {
+	ctx := context.Background()
+	l := testEndpoint(t, "user", "pass")
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(t, err)
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	c, _ := net.Pipe()
+
+	validCred := &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+		},
+	}
+	encrypted, err := anypb.New(&pbs.SshProtocolContext{EncryptedSecrets: []byte("encrypted")})
+	require.NoError(t, err)
+	notSsh, err := anypb.New(&pbs.UsernamePassword{})
+	require.NoError(t, err)
+	recorded, err := anypb.New(&pbs.SshProtocolContext{
+		SessionId:             "s_1234567890",
+		SessionRecordingId:    "sr_1234567890",
+		ConnectionRecordingId: "cr_1234567890",
+		HostKeys:              testEndpointHostKeys(t),
+		Secrets: &pbs.SshProtocolSecrets{
+			Credentials: []*pbs.Credential{validCred},
+			HostKey:     hostKey,
+		},
+	})
+	require.NoError(t, err)
+	noHostKeys, err := anypb.New(&pbs.SshProtocolContext{
+		Secrets: &pbs.SshProtocolSecrets{
+			Credentials: []*pbs.Credential{validCred},
+			HostKey:     hostKey,
+		},
+	})
+	require.NoError(t, err)
+	otherHostKey, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	unknownHostKey, err := anypb.New(&pbs.SshProtocolContext{
+		HostKeys: string(ssh.MarshalAuthorizedKey(otherHostKey.PublicKey())),
+		Secrets: &pbs.SshProtocolSecrets{
+			Credentials: []*pbs.Credential{validCred},
+			HostKey:     hostKey,
+		},
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		conn        net.Conn
+		dialer      *proxy.ProxyDialer
+		connId      string
+		protocolCtx *anypb.Any
+	}{
+		{
+			name:        "nil connection",
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, hostKey, validCred),
+		},
+		{
+			name:        "nil dialer",
+			conn:        c,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, hostKey, validCred),
+		},
+		{
+			name:        "empty connection id",
+			conn:        c,
+			dialer:      dialer,
+			protocolCtx: testProtocolContext(t, hostKey, validCred),
+		},
+		{
+			name:   "nil protocol context",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+		},
+		{
+			name:        "not an ssh protocol context",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: notSsh,
+		},
+		{
+			name:        "encrypted secrets without decrypt function",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: encrypted,
+		},
+		{
+			name:        "missing host key",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, nil, validCred),
+		},
+		{
+			name:        "recorded without recording manager",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: recorded,
+		},
+		{
+			name:        "no credentials",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: testProtocolContext(t, hostKey),
+		},
+		{
+			name:        "no endpoint host keys",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: noHostKeys,
+		},
+		{
+			name:        "endpoint presents unknown host key",
+			conn:        c,
+			dialer:      dialer,
+			connId:      "someconnectionid",
+			protocolCtx: unknownHostKey,
+		},
+		{
+			name:   "wrong password",
+			conn:   c,
+			dialer: dialer,
+			connId: "someconnectionid",
+			protocolCtx: testProtocolContext(t, hostKey, &pbs.Credential{
+				Credential: &pbs.Credential_UsernamePassword{
+					UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "wrong"},
+				},
+			}),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := handleProxy(ctx, ctx, nil, tc.conn, tc.dialer, tc.connId, tc.protocolCtx, nil)
+			assert.Error(t, err)
+			assert.Nil(t, fn)
+		})
+	}
+}
+
+func TestProtocolSecrets_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	want := &pbs.SshProtocolSecrets{HostKey: []byte("hostkey")}
+	// The test decrypt function treats the marshaled message as ciphertext.
+	ct, err := proto.Marshal(want)
+	require.NoError(err)
+	df := func(_ context.Context, from []byte, to proto.Message) error {
+		return proto.Unmarshal(from, to)
+	}
+	got, err := protocolSecrets(ctx, df, &pbs.SshProtocolContext{EncryptedSecrets: ct})
+	require.NoError(err)
+	assert.True(proto.Equal(want, got))
+}
+
+func TestClientConfig(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name     string
+		creds    []*pbs.Credential
+		wantUser string
+		wantErr  bool
+	}{
+		{
+			name: "username password",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_UsernamePassword{
+					UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+				},
+			}},
+			wantUser: "user",
+		},
+		{
+			name: "private key",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_SshPrivateKey{
+					SshPrivateKey: &pbs.SshPrivateKey{Username: "user", PrivateKey: string(testdata.PEMBytes["ed25519"])},
+				},
+			}},
+			wantUser: "user",
+		},
+		{
+			name: "private key with passphrase",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_SshPrivateKey{
+					SshPrivateKey: &pbs.SshPrivateKey{
+						Username:             "user",
+						PrivateKey:           string(testdata.PEMEncryptedKeys[0].PEMBytes),
+						PrivateKeyPassphrase: testdata.PEMEncryptedKeys[0].EncryptionKey,
+					},
+				},
+			}},
+			wantUser: "user",
+		},
+		{
+			name: "password and private key",
+			creds: []*pbs.Credential{
+				{
+					Credential: &pbs.Credential_UsernamePassword{
+						UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+					},
+				},
+				{
+					Credential: &pbs.Credential_SshPrivateKey{
+						SshPrivateKey: &pbs.SshPrivateKey{Username: "user", PrivateKey: string(testdata.PEMBytes["ed25519"])},
+					},
+				},
+			},
+			wantUser: "user",
+		},
+		{
+			name:    "no credentials",
+			wantErr: true,
+		},
+		{
+			name: "missing username",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_UsernamePassword{
+					UsernamePassword: &pbs.UsernamePassword{Password: "pass"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "different usernames",
+			creds: []*pbs.Credential{
+				{
+					Credential: &pbs.Credential_UsernamePassword{
+						UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+					},
+				},
+				{
+					Credential: &pbs.Credential_UsernamePassword{
+						UsernamePassword: &pbs.UsernamePassword{Username: "other", Password: "pass"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad private key",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_SshPrivateKey{
+					SshPrivateKey: &pbs.SshPrivateKey{Username: "user", PrivateKey: "not a key"},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "certificate that is not a certificate",
+			creds: []*pbs.Credential{{
+				Credential: &pbs.Credential_SshCertificate{
+					SshCertificate: &pbs.SshCertificate{
+						Username:    "user",
+						PrivateKey:  string(testdata.PEMBytes["ed25519"]),
+						Certificate: "not a certificate",
+					},
+				},
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown credential type",
+			creds:   []*pbs.Credential{{}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := clientConfig(ctx, tc.creds, testEndpointHostKeys(t))
+			if tc.wantErr {
+				assert.Error(err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.wantUser, got.User)
+			assert.Len(got.Auth, len(tc.creds))
+		})
+	}
+}
+
+func TestHandleProxy_HandshakeTimeout(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+
+	oldTimeout := handshakeTimeout
+	handshakeTimeout = 100 * time.Millisecond
+	t.Cleanup(func() {
+		handshakeTimeout = oldTimeout
+	})
+
+	// The endpoint accepts connections but never sends anything.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	t.Cleanup(func() {
+		l.Close()
+	})
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() {
+				c.Close()
+			})
+		}
+	}()
+	dialer, err := proxy.NewProxyDialer(ctx, func(...proxy.Option) (net.Conn, error) {
+		return net.Dial("tcp", l.Addr().String())
+	})
+	require.NoError(err)
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	pc := testProtocolContext(t, hostKey, &pbs.Credential{
+		Credential: &pbs.Credential_UsernamePassword{
+			UsernamePassword: &pbs.UsernamePassword{Username: "user", Password: "pass"},
+		},
+	})
+	c, _ := net.Pipe()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn, err := handleProxy(ctx, ctx, nil, c, dialer, "someconnectionid", pc, nil)
+		assert.Error(err)
+		assert.Nil(fn)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("handshake with a silent endpoint did not time out")
+	}
+}
+
+func TestHostKeyCallback(t *testing.T) {
+	ctx := context.Background()
+	hostKeys := testEndpointHostKeys(t)
+	endpointKey, err := ssh.ParsePrivateKey(testdata.PEMBytes["ed25519"])
+	require.NoError(t, err)
+	otherKey, err := ssh.ParsePrivateKey(testdata.PEMBytes["ecdsa"])
+	require.NoError(t, err)
+	cert := &ssh.Certificate{
+		Key:         endpointKey.PublicKey(),
+		CertType:    ssh.HostCert,
+		ValidBefore: ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, otherKey))
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+
+	t.Run("host key of the target", func(t *testing.T) {
+		cb, err := hostKeyCallback(ctx, hostKeys)
+		require.NoError(t, err)
+		assert.NoError(t, cb("127.0.0.1:22", addr, endpointKey.PublicKey()))
+	})
+	t.Run("certificate of a host key of the target", func(t *testing.T) {
+		cb, err := hostKeyCallback(ctx, hostKeys)
+		require.NoError(t, err)
+		assert.NoError(t, cb("127.0.0.1:22", addr, cert))
+	})
+	t.Run("unknown host key", func(t *testing.T) {
+		cb, err := hostKeyCallback(ctx, hostKeys)
+		require.NoError(t, err)
+		assert.Error(t, cb("127.0.0.1:22", addr, otherKey.PublicKey()))
+	})
+	t.Run("no host keys", func(t *testing.T) {
+		cb, err := hostKeyCallback(ctx, "")
+		assert.Error(t, err)
+		assert.Nil(t, cb)
+	})
+}
@@ -0,0 +1,362 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sessionChannelType is the type of ssh channels which run a program.
+const sessionChannelType = "session"
+
+// chunkWriter encodes chunks into a file of a channel recording.
+type chunkWriter struct {
+	mu  sync.Mutex
+	enc *bsr.ChunkEncoder
+}
+
+// newChunkWriter writes the magic and a header chunk to w and returns a
+// chunkWriter encoding chunks into w with the compression of the session
+// recording.
+func newChunkWriter(ctx context.Context, w io.Writer, dir bsr.Direction, sr *sessionRecorder) (*chunkWriter, error) {
+	if _, err := w.Write(bsr.Magic.Bytes()); err != nil {
+		return nil, err
+	}
+	enc, err := bsr.NewChunkEncoder(ctx, w, sr.compression, bsr.NoEncryption, bsr.WithCompressionLevel(sr.compressionLevel))
+	if err != nil {
+		return nil, err
+	}
+	h, err := bsr.NewHeader(ctx, protocol, dir, bsr.NewTimestamp(time.Now()), sr.compression, bsr.NoEncryption, sr.sessionId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := enc.Encode(ctx, h); err != nil {
+		return nil, err
+	}
+	return &chunkWriter{enc: enc}, nil
+}
+
+func (w *chunkWriter) encode(ctx context.Context, c bsr.Chunk) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := w.enc.Encode(ctx, c)
+	return err
+}
+
+// close writes an end chunk and closes the file.
+func (w *chunkWriter) close(ctx context.Context, dir bsr.Direction) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	end, err := bsr.NewEnd(ctx, protocol, dir, bsr.NewTimestamp(time.Now()))
+	if err != nil {
+		_ = w.enc.Close()
+		return err
+	}
+	if _, err := w.enc.Encode(ctx, end); err != nil {
+		_ = w.enc.Close()
+		return err
+	}
+	return w.enc.Close()
+}
+
+// ChannelRecorder records the data and requests of an ssh channel into a
+// bsr.Channel.
+type ChannelRecorder struct {
+	cr          *ConnectionRecorder
+	id          string
+	channelType string
+	ch          *bsr.Channel
+	startTime   time.Time
+	messages    map[bsr.Direction]*chunkWriter
+	requests    map[bsr.Direction]*chunkWriter
+
+	bytesUp   atomic.Uint64
+	bytesDown atomic.Uint64
+
+	mu                    sync.Mutex
+	program               bsrssh.SessionProgram
+	subsystemName         string
+	execProgram           bsrssh.ExecApplicationProgram
+	fileTransferDirection bsrssh.FileTransferDirection
+	closed                bool
+}
+
+func newChannelRecorder(ctx context.Context, cr *ConnectionRecorder, c *bsr.Channel, startTime time.Time) (*ChannelRecorder, error) {
+	ch := &ChannelRecorder{
+		cr:                    cr,
+		id:                    c.Meta.Id,
+		channelType:           c.Meta.Type,
+		ch:                    c,
+		startTime:             startTime,
+		messages:              make(map[bsr.Direction]*chunkWriter, 2),
+		requests:              make(map[bsr.Direction]*chunkWriter, 2),
+		program:               bsrssh.NotApplicable,
+		execProgram:           bsrssh.ExecApplicationProgramNotApplicable,
+		fileTransferDirection: bsrssh.FileTransferNotApplicable,
+	}
+	if ch.channelType == sessionChannelType {
+		ch.program = bsrssh.None
+	}
+	for _, dir := range []bsr.Direction{bsr.Inbound, bsr.Outbound} {
+		mw, err := c.NewMessagesWriter(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		if ch.messages[dir], err = newChunkWriter(ctx, mw, dir, cr.sr); err != nil {
+			return nil, err
+		}
+		rw, err := c.NewRequestsWriter(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		if ch.requests[dir], err = newChunkWriter(ctx, rw, dir, cr.sr); err != nil {
+			return nil, err
+		}
+	}
+	return ch, nil
+}
+
+// Id returns the id of the channel recording.
+func (ch *ChannelRecorder) Id() string {
+	return ch.id
+}
+
+// RecordData records data sent on the channel. Inbound data is sent by the
+// client and outbound data by the endpoint.
+func (ch *ChannelRecorder) RecordData(ctx context.Context, dir bsr.Direction, data []byte) error {
+	const op = "recorder.(ChannelRecorder).RecordData"
+	w, ok := ch.messages[dir]
+	if !ok {
+		return fmt.Errorf("%s: invalid direction", op)
+	}
+	ts := bsr.NewTimestamp(time.Now())
+	for len(data) > 0 {
+		n := len(data)
+		if n > bsrssh.MaxPacketSize {
+			n = bsrssh.MaxPacketSize
+		}
+		c, err := bsrssh.NewDataChunk(ctx, dir, ts, data[:n])
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := w.encode(ctx, c); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		switch dir {
+		case bsr.Inbound:
+			ch.bytesUp.Add(uint64(n))
+		default:
+			ch.bytesDown.Add(uint64(n))
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// RecordRequest records a request sent on the channel. Inbound requests are
+// sent by the client and outbound requests by the endpoint.
+func (ch *ChannelRecorder) RecordRequest(ctx context.Context, dir bsr.Direction, req *ssh.Request) error {
+	const op = "recorder.(ChannelRecorder).RecordRequest"
+	w, ok := ch.requests[dir]
+	switch {
+	case !ok:
+		return fmt.Errorf("%s: invalid direction", op)
+	case req == nil:
+		return fmt.Errorf("%s: missing request", op)
+	}
+	c, err := requestChunk(ctx, dir, bsr.NewTimestamp(time.Now()), req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := w.encode(ctx, c); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if dir == bsr.Inbound && ch.channelType == sessionChannelType {
+		ch.setProgram(c)
+	}
+	return nil
+}
+
+// setProgram sets the program of a session channel from the request which
+// started it. Only one program can be started per channel.
+func (ch *ChannelRecorder) setProgram(c bsr.Chunk) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if ch.program != bsrssh.None {
+		return
+	}
+	switch r := c.(type) {
+	case *bsrssh.ShellRequest:
+		ch.program = bsrssh.Shell
+	case *bsrssh.SubsystemRequest:
+		ch.program = bsrssh.Subsystem
+		ch.subsystemName = r.GetSubsystemName()
+	case *bsrssh.ExecRequest:
+		ch.program = bsrssh.Exec
+		ch.execProgram, ch.fileTransferDirection = execProgram(r.GetCommand())
+	}
+}
+
+// execProgram identifies the program run by an exec request and the
+// direction of the file transfer for file transfer programs.
+func execProgram(cmd string) (bsrssh.ExecApplicationProgram, bsrssh.FileTransferDirection) {
+	args := strings.Fields(cmd)
+	if len(args) == 0 {
+		return bsrssh.Unknown, bsrssh.FileTransferNotApplicable
+	}
+	switch path.Base(args[0]) {
+	case "scp":
+		for _, a := range args[1:] {
+			switch a {
+			case "-t":
+				return bsrssh.Scp, bsrssh.FileTransferUpload
+			case "-f":
+				return bsrssh.Scp, bsrssh.FileTransferDownload
+			}
+		}
+		return bsrssh.Scp, bsrssh.FileTransferNotApplicable
+	case "rsync":
+		for _, a := range args[1:] {
+			if a == "--sender" {
+				return bsrssh.Rsync, bsrssh.FileTransferDownload
+			}
+		}
+		return bsrssh.Rsync, bsrssh.FileTransferUpload
+	}
+	return bsrssh.Unknown, bsrssh.FileTransferNotApplicable
+}
+
+// Close closes the files of the channel, writes the summary of the channel
+// and closes the bsr.Channel. Closing an already closed channel recorder
+// does nothing.
+func (ch *ChannelRecorder) Close(ctx context.Context) error {
+	const op = "recorder.(ChannelRecorder).Close"
+	summary, err := ch.close(ctx)
+	if summary == nil {
+		return nil
+	}
+	ch.cr.channelClosed(ch, summary, err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// close closes the channel recorder and returns its summary without
+// reporting it to the connection recorder. It returns a nil summary if the
+// channel recorder is already closed.
+func (ch *ChannelRecorder) close(ctx context.Context) (*pbs.ChannelRecordingSummary, error) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if ch.closed {
+		return nil, nil
+	}
+	ch.closed = true
+
+	var errs []error
+	for _, dir := range []bsr.Direction{bsr.Inbound, bsr.Outbound} {
+		if err := ch.messages[dir].close(ctx, dir); err != nil {
+			errs = append(errs, fmt.Errorf("unable to close %s messages: %w", dir, err))
+		}
+		if err := ch.requests[dir].close(ctx, dir); err != nil {
+			errs = append(errs, fmt.Errorf("unable to close %s requests: %w", dir, err))
+		}
+	}
+
+	endTime := time.Now()
+	summary := &pbs.ChannelRecordingSummary{
+		ChannelRecordingId: ch.id,
+		StartTime:          timestamppb.New(ch.startTime),
+		EndTime:            timestamppb.New(endTime),
+		BytesUp:            ch.bytesUp.Load(),
+		BytesDown:          ch.bytesDown.Load(),
+		ChannelType:        ch.channelType,
+	}
+	if ch.channelType == sessionChannelType {
+		summary.Program = string(ch.program)
+		summary.SubsystemName = ch.subsystemName
+		if ch.program == bsrssh.Exec {
+			summary.ExecProgram = string(ch.execProgram)
+		}
+	}
+	if err := ch.ch.EncodeSummary(ctx, &bsrssh.ChannelSummary{
+		ChannelSummary: &bsr.BaseChannelSummary{
+			Id:                    ch.id,
+			ConnectionRecordingId: ch.cr.id,
+			StartTime:             ch.startTime,
+			EndTime:               endTime,
+			BytesUp:               summary.GetBytesUp(),
+			BytesDown:             summary.GetBytesDown(),
+			ChannelType:           ch.channelType,
+		},
+		SessionProgram:        ch.program,
+		SubsystemName:         ch.subsystemName,
+		ExecProgram:           ch.execProgram,
+		FileTransferDirection: ch.fileTransferDirection,
+	}); err != nil {
+		errs = append(errs, fmt.Errorf("unable to write channel summary: %w", err))
+	}
+	if err := ch.ch.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("unable to close channel recording: %w", err))
+	}
+	return summary, errors.Join(errs...)
+}
+
+// requestChunk creates the chunk recording req.
+func requestChunk(ctx context.Context, dir bsr.Direction, ts *bsr.Timestamp, req *ssh.Request) (bsr.Chunk, error) {
+	switch req.Type {
+	case bsrssh.BreakRequestType:
+		return bsrssh.NewBreakRequest(ctx, dir, ts, req)
+	case bsrssh.CancelTCPIPForwardRequestType:
+		return bsrssh.NewCancelTCPIPForwardRequest(ctx, dir, ts, req)
+	case bsrssh.DirectTCPIPRequestType:
+		return bsrssh.NewDirectTCPIPRequest(ctx, dir, ts, req)
+	case bsrssh.EnvRequestType:
+		return bsrssh.NewEnvRequest(ctx, dir, ts, req)
+	case bsrssh.ExecRequestType:
+		return bsrssh.NewExecRequest(ctx, dir, ts, req)
+	case bsrssh.ExitSignalRequestType:
+		return bsrssh.NewExitSignalRequest(ctx, dir, ts, req)
+	case bsrssh.ExitStatusRequestType:
+		return bsrssh.NewExitStatusRequest(ctx, dir, ts, req)
+	case bsrssh.ForwardedTCPIPRequestType:
+		return bsrssh.NewForwardedTCPIPRequest(ctx, dir, ts, req)
+	case bsrssh.PtyRequestType:
+		return bsrssh.NewPtyRequest(ctx, dir, ts, req)
+	case bsrssh.SessionRequestType:
+		return bsrssh.NewSessionRequest(ctx, dir, ts, req)
+	case bsrssh.ShellRequestType:
+		return bsrssh.NewShellRequest(ctx, dir, ts, req)
+	case bsrssh.SignalRequestType:
+		return bsrssh.NewSignalRequest(ctx, dir, ts, req)
+	case bsrssh.SubsystemRequestType:
+		return bsrssh.NewSubsystemRequest(ctx, dir, ts, req)
+	case bsrssh.TCPIPForwardRequestType:
+		return bsrssh.NewTCPIPForwardRequest(ctx, dir, ts, req)
+	case bsrssh.WindowChangeRequestType:
+		return bsrssh.NewWindowChangeRequest(ctx, dir, ts, req)
+	case bsrssh.X11ForwardingRequestType:
+		return bsrssh.NewX11ForwardingRequest(ctx, dir, ts, req)
+	case bsrssh.X11RequestType:
+		return bsrssh.NewX11Request(ctx, dir, ts, req)
+	case bsrssh.XonXoffRequestType:
+		return bsrssh.NewXonXoffRequest(ctx, dir, ts, req)
+	default:
+		return bsrssh.NewUnknownRequest(ctx, dir, ts, req)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConnectionRecorder records a connection of a session into a
+// bsr.Connection.
+type ConnectionRecorder struct {
+	sr        *sessionRecorder
+	id        string
+	connId    string
+	conn      *bsr.Connection
+	startTime time.Time
+
+	mu           sync.Mutex
+	channels     map[string]*ChannelRecorder
+	channelCount uint64
+	summaries    []*pbs.ChannelRecordingSummary
+	errs         []error
+	closed       bool
+}
+
+// Id returns the id of the connection recording.
+func (cr *ConnectionRecorder) Id() string {
+	return cr.id
+}
+
+// NewChannelRecorder creates a ChannelRecorder for a channel of type
+// channelType in the connection.
+func (cr *ConnectionRecorder) NewChannelRecorder(ctx context.Context, channelType string) (*ChannelRecorder, error) {
+	const op = "recorder.(ConnectionRecorder).NewChannelRecorder"
+	if channelType == "" {
+		return nil, fmt.Errorf("%s: missing channel type", op)
+	}
+	id, err := bsr.NewChannelId()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if cr.closed {
+		return nil, fmt.Errorf("%s: connection recording %q is closed", op, cr.id)
+	}
+	startTime := time.Now()
+	c, err := cr.conn.NewChannel(ctx, &bsr.ChannelRecordingMeta{
+		Id:   id,
+		Type: channelType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	ch, err := newChannelRecorder(ctx, cr, c, startTime)
+	if err != nil {
+		_ = c.Close(ctx)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	cr.channels[id] = ch
+	cr.channelCount++
+	return ch, nil
+}
+
+// channelClosed records the summary of a closed channel.
+func (cr *ConnectionRecorder) channelClosed(ch *ChannelRecorder, summary *pbs.ChannelRecordingSummary, err error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if cr.closed {
+		return
+	}
+	delete(cr.channels, ch.id)
+	cr.summaries = append(cr.summaries, summary)
+	if err != nil {
+		cr.errs = append(cr.errs, err)
+	}
+}
+
+// Close closes the channels still being recorded, writes the summary of the
+// connection and closes the bsr.Connection. Closing an already closed
+// connection recorder does nothing.
+func (cr *ConnectionRecorder) Close(ctx context.Context) error {
+	const op = "recorder.(ConnectionRecorder).Close"
+	summary, err := cr.close(ctx)
+	if summary == nil {
+		return nil
+	}
+	cr.sr.connectionClosed(ctx, cr, summary, err)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// close closes the connection recorder and returns its summary without
+// reporting it to the session recorder. It returns a nil summary if the
+// connection recorder is already closed.
+func (cr *ConnectionRecorder) close(ctx context.Context) (*pbs.ConnectionRecordingSummary, error) {
+	cr.mu.Lock()
+	if cr.closed {
+		cr.mu.Unlock()
+		return nil, nil
+	}
+	cr.closed = true
+	open := make([]*ChannelRecorder, 0, len(cr.channels))
+	for _, ch := range cr.channels {
+		open = append(open, ch)
+	}
+	cr.channels = nil
+	errs := cr.errs
+	summaries := cr.summaries
+	cr.mu.Unlock()
+
+	for _, ch := range open {
+		summary, err := ch.close(ctx)
+		if summary != nil {
+			summaries = append(summaries, summary)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	endTime := time.Now()
+	summary := &pbs.ConnectionRecordingSummary{
+		ConnectionRecordingId: cr.id,
+		StartTime:             timestamppb.New(cr.startTime),
+		EndTime:               timestamppb.New(endTime),
+		ChannelRecordings:     summaries,
+	}
+	for _, s := range summaries {
+		summary.BytesUp += s.GetBytesUp()
+		summary.BytesDown += s.GetBytesDown()
+	}
+
+	bsrSummary := &bsr.BaseConnectionSummary{
+		Id:           cr.id,
+		ChannelCount: cr.channelCount,
+		StartTime:    cr.startTime,
+		EndTime:      endTime,
+		BytesUp:      summary.GetBytesUp(),
+		BytesDown:    summary.GetBytesDown(),
+	}
+	if err := errors.Join(errs...); err != nil {
+		bsrSummary.SetErrors(err)
+	}
+	if err := cr.conn.EncodeSummary(ctx, bsrSummary); err != nil {
+		errs = append(errs, fmt.Errorf("unable to write connection summary: %w", err))
+	}
+	if err := cr.conn.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("unable to close connection recording: %w", err))
+	}
+	return summary, errors.Join(errs...)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+/*
+Package recorder records the sessions proxied by a worker into BSR files.
+
+A Manager keeps a session recorder for every recorded session with an active
+connection on the worker. The session recorder is created by the first
+connection of the session, which carries the session meta, the storage bucket
+and the keys of the recording in its protocol context. The bsr.Session
+container is created in a syncing FS of the storage bucket, so every file of
+the recording is synced to the bucket when it is closed.
+
+Every proxied connection gets a ConnectionRecorder and every channel of the
+connection a ChannelRecorder which encodes the data and requests of the
+channel into chunks:
+
+	cr, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, connId)
+	if err != nil {
+		return err
+	}
+	defer cr.Close(ctx)
+	ch, err := cr.NewChannelRecorder(ctx, "session")
+	if err != nil {
+		return err
+	}
+	defer ch.Close(ctx)
+	err = ch.RecordData(ctx, bsr.Inbound, data)
+
+Once the session is no longer active and all of its connections are closed,
+the summary of the session is written, the bsr.Session is closed and the
+summaries of its connections and channels are sent to the controller, which
+marks the recording as available.
+*/
+package recorder
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/storage"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// closeRecordingTimeout is the time allowed to report a closed session
+// recording to the controller.
+const closeRecordingTimeout = 30 * time.Second
+
+// SessionClientFn returns the client used to report closed session
+// recordings to the controller.
+type SessionClientFn func() pbs.SessionServiceClient
+
+// Manager manages the recorders of the sessions proxied by a worker.
+type Manager struct {
+	storage  storage.RecordingStorage
+	clientFn SessionClientFn
+
+	mu       sync.Mutex
+	sessions map[string]*sessionRecorder
+	wg       sync.WaitGroup
+}
+
+// NewManager creates a Manager which creates the recordings in rs and
+// reports closed recordings using the client returned by clientFn.
+func NewManager(ctx context.Context, rs storage.RecordingStorage, clientFn SessionClientFn) (*Manager, error) {
+	const op = "recorder.NewManager"
+	switch {
+	case rs == nil:
+		return nil, fmt.Errorf("%s: missing recording storage", op)
+	case clientFn == nil:
+		return nil, fmt.Errorf("%s: missing session client function", op)
+	}
+	return &Manager{
+		storage:  rs,
+		clientFn: clientFn,
+		sessions: make(map[string]*sessionRecorder),
+	}, nil
+}
+
+// NewConnectionRecorder creates a ConnectionRecorder for the connection
+// recording of the provided ssh protocol context. If the worker is not yet
+// recording the session of the connection, the session recorder is created
+// using the session meta of the context and the storage bucket and keys of
+// the secrets.
+func (m *Manager) NewConnectionRecorder(ctx context.Context, sshCtx *pbs.SshProtocolContext, secrets *pbs.SshProtocolSecrets, connId string) (*ConnectionRecorder, error) {
+	const op = "recorder.(Manager).NewConnectionRecorder"
+	switch {
+	case sshCtx == nil:
+		return nil, fmt.Errorf("%s: missing ssh protocol context", op)
+	case sshCtx.GetSessionId() == "":
+		return nil, fmt.Errorf("%s: missing session id", op)
+	case sshCtx.GetSessionRecordingId() == "":
+		return nil, fmt.Errorf("%s: missing session recording id", op)
+	case sshCtx.GetConnectionRecordingId() == "":
+		return nil, fmt.Errorf("%s: missing connection recording id", op)
+	case connId == "":
+		return nil, fmt.Errorf("%s: missing connection id", op)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sr, ok := m.sessions[sshCtx.GetSessionId()]
+	if !ok {
+		var err error
+		if sr, err = m.newSessionRecorder(ctx, sshCtx, secrets); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		m.sessions[sr.sessionId] = sr
+		m.wg.Add(1)
+	}
+	if sr.id != sshCtx.GetSessionRecordingId() {
+		return nil, fmt.Errorf("%s: session %q is being recorded by %q, not %q", op, sr.sessionId, sr.id, sshCtx.GetSessionRecordingId())
+	}
+	cr, err := sr.newConnectionRecorder(ctx, sshCtx.GetConnectionRecordingId(), connId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return cr, nil
+}
+
+// newSessionRecorder creates the bsr.Session of a recorded session. It must
+// be called with the lock of m held.
+func (m *Manager) newSessionRecorder(ctx context.Context, sshCtx *pbs.SshProtocolContext, secrets *pbs.SshProtocolSecrets) (*sessionRecorder, error) {
+	// Only the connection which started the session recording carries the
+	// information required to create it. Any other connection arriving
+	// before the recorder exists, for instance after a restart of the
+	// worker, cannot be recorded and must be rejected.
+	switch {
+	case len(sshCtx.GetSessionMeta()) == 0:
+		return nil, fmt.Errorf("session %q is not being recorded by this worker", sshCtx.GetSessionId())
+	case secrets.GetStorageBucket() == nil:
+		return nil, fmt.Errorf("missing storage bucket")
+	case secrets.GetRecordingKeys() == nil:
+		return nil, fmt.Errorf("missing recording keys")
+	}
+
+	compression := bsr.NoCompression
+	if sshCtx.GetRecordingCompression() != "" {
+		var err error
+		if compression, err = bsr.ParseCompression(sshCtx.GetRecordingCompression()); err != nil {
+			return nil, err
+		}
+	}
+	compressionLevel := int(sshCtx.GetRecordingCompressionLevel())
+	if !bsr.ValidCompressionLevel(compression, compressionLevel) {
+		return nil, fmt.Errorf("invalid compression level %d for %s", compressionLevel, compression)
+	}
+
+	sessionMeta := &bsr.SessionMeta{}
+	if err := json.Unmarshal(sshCtx.GetSessionMeta(), sessionMeta); err != nil {
+		return nil, fmt.Errorf("unable to decode session meta: %w", err)
+	}
+	keys, err := recordingKeys(secrets.GetRecordingKeys())
+	if err != nil {
+		return nil, err
+	}
+	fs, err := m.storage.NewSyncingFS(ctx, secrets.GetStorageBucket())
+	if err != nil {
+		return nil, err
+	}
+	startTime := time.Now()
+	s, err := bsr.NewSession(ctx, &bsr.SessionRecordingMeta{
+		Id:       sshCtx.GetSessionRecordingId(),
+		Protocol: protocol,
+	}, sessionMeta, fs, keys, bsr.WithSupportsMultiplex(true))
+	if err != nil {
+		return nil, err
+	}
+	return &sessionRecorder{
+		m:                m,
+		id:               sshCtx.GetSessionRecordingId(),
+		sessionId:        sshCtx.GetSessionId(),
+		session:          s,
+		startTime:        startTime,
+		compression:      compression,
+		compressionLevel: compressionLevel,
+		connections:      make(map[string]*ConnectionRecorder),
+	}, nil
+}
+
+// removeSession removes a closed session recorder.
+func (m *Manager) removeSession(sr *sessionRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sessions[sr.sessionId] == sr {
+		delete(m.sessions, sr.sessionId)
+	}
+	m.wg.Done()
+}
+
+// ReauthorizeAllExcept closes the recorders of closedSessions once their
+// connections are closed. The recorders of all other sessions are kept.
+func (m *Manager) ReauthorizeAllExcept(ctx context.Context, closedSessions []string) error {
+	var closing []*sessionRecorder
+	m.mu.Lock()
+	for _, id := range closedSessions {
+		if sr, ok := m.sessions[id]; ok {
+			closing = append(closing, sr)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, sr := range closing {
+		sr.sessionClosed(ctx)
+	}
+	return nil
+}
+
+// SessionsManaged returns the ids of the sessions being recorded.
+func (m *Manager) SessionsManaged(_ context.Context) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.sessions))
+	for id := range m.sessions {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Shutdown closes the recorders of all sessions once their connections are
+// closed. If ctx is done before all connections are closed, the remaining
+// recorders are closed regardless.
+func (m *Manager) Shutdown(ctx context.Context) {
+	m.mu.Lock()
+	recorders := make([]*sessionRecorder, 0, len(m.sessions))
+	for _, sr := range m.sessions {
+		recorders = append(recorders, sr)
+	}
+	m.mu.Unlock()
+	for _, sr := range recorders {
+		sr.sessionClosed(ctx)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		for _, sr := range recorders {
+			sr.close(context.Background(), fmt.Errorf("worker shut down before the session ended"))
+		}
+	}
+}
+
+// recordingKeys converts the keys of a recording sent by the controller
+// into bsr keys.
+func recordingKeys(k *pbs.SessionRecordingKeys) (*bsrkms.Keys, error) {
+	keys := &bsrkms.Keys{
+		WrappedBsrKey:       &wrapping.KeyInfo{},
+		WrappedPrivKey:      &wrapping.KeyInfo{},
+		BsrKey:              &wrapping.KeyInfo{},
+		PrivKey:             &wrapping.KeyInfo{},
+		PubKey:              &wrapping.KeyInfo{},
+		PubKeySelfSignature: &wrapping.SigInfo{},
+		PubKeyBsrSignature:  &wrapping.SigInfo{},
+	}
+	for _, f := range []struct {
+		name string
+		src  []byte
+		dst  proto.Message
+	}{
+		{"wrapped bsr key", k.GetWrappedBsrKey(), keys.WrappedBsrKey},
+		{"wrapped private key", k.GetWrappedPrivKey(), keys.WrappedPrivKey},
+		{"bsr key", k.GetBsrKey(), keys.BsrKey},
+		{"private key", k.GetPrivKey(), keys.PrivKey},
+		{"public key", k.GetPubKey(), keys.PubKey},
+		{"public key self signature", k.GetPubKeySelfSignature(), keys.PubKeySelfSignature},
+		{"public key bsr signature", k.GetPubKeyBsrSignature(), keys.PubKeyBsrSignature},
+	} {
+		if len(f.src) == 0 {
+			return nil, fmt.Errorf("missing %s", f.name)
+		}
+		if err := proto.Unmarshal(f.src, f.dst); err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %w", f.name, err)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type testSessionClient struct {
+	pbs.SessionServiceClient
+
+	mu   sync.Mutex
+	reqs []*pbs.CloseSessionRecordingRequest
+}
+
+func (c *testSessionClient) CloseSessionRecording(_ context.Context, req *pbs.CloseSessionRecordingRequest, _ ...grpc.CallOption) (*pbs.CloseSessionRecordingResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reqs = append(c.reqs, req)
+	return &pbs.CloseSessionRecordingResponse{}, nil
+}
+
+func (c *testSessionClient) requests() []*pbs.CloseSessionRecordingRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reqs
+}
+
+func testBucket() *storagebuckets.StorageBucket {
+	return &storagebuckets.StorageBucket{
+		Id:         "sb_1234567890",
+		BucketName: "default",
+		Plugin:     &plugins.PluginInfo{Name: "loopback"},
+		Attributes: &structpb.Struct{},
+	}
+}
+
+func testRecording(t *testing.T, sessionId, recordingId string) (*pbs.SshProtocolContext, *pbs.SshProtocolSecrets, *bsrkms.Keys) {
+	t.Helper()
+	ctx := context.Background()
+	meta, err := json.Marshal(bsr.TestSessionMeta(sessionId))
+	require.NoError(t, err)
+	keys, err := bsrkms.CreateKeys(ctx, bsrkms.TestWrapper(t), recordingId)
+	require.NoError(t, err)
+	marshal := func(m proto.Message) []byte {
+		b, err := proto.Marshal(m)
+		require.NoError(t, err)
+		return b
+	}
+	return &pbs.SshProtocolContext{
+		SessionId:          sessionId,
+		SessionRecordingId: recordingId,
+		SessionMeta:        meta,
+	}, &pbs.SshProtocolSecrets{
+		StorageBucket: testBucket(),
+		RecordingKeys: &pbs.SessionRecordingKeys{
+			WrappedBsrKey:       marshal(keys.WrappedBsrKey),
+			WrappedPrivKey:      marshal(keys.WrappedPrivKey),
+			BsrKey:              marshal(keys.BsrKey),
+			PrivKey:             marshal(keys.PrivKey),
+			PubKey:              marshal(keys.PubKey),
+			PubKeySelfSignature: marshal(keys.PubKeySelfSignature),
+			PubKeyBsrSignature:  marshal(keys.PubKeyBsrSignature),
+		},
+	}, keys
+}
+
+func TestNewManager(t *testing.T) {
+	ctx := context.Background()
+	rs, err := local.New(ctx, t.TempDir(), nil, true)
+	require.NoError(t, err)
+	clientFn := func() pbs.SessionServiceClient { return &testSessionClient{} }
+
+	_, err = NewManager(ctx, nil, clientFn)
+	assert.Error(t, err)
+	_, err = NewManager(ctx, rs, nil)
+	assert.Error(t, err)
+	m, err := NewManager(ctx, rs, clientFn)
+	require.NoError(t, err)
+	assert.NotNil(t, m)
+}
+
+func TestManager_NewConnectionRecorder(t *testing.T) {
+	ctx := context.Background()
+	rs, err := local.New(ctx, t.TempDir(), nil, true)
+	require.NoError(t, err)
+	client := &testSessionClient{}
+	m, err := NewManager(ctx, rs, func() pbs.SessionServiceClient { return client })
+	require.NoError(t, err)
+
+	sshCtx, secrets, _ := testRecording(t, "s_1234567890", "sr_1234567890")
+
+	t.Run("missing-connection-recording-id", func(t *testing.T) {
+		_, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, "sc_1234567890")
+		assert.Error(t, err)
+	})
+	t.Run("unknown-session", func(t *testing.T) {
+		c := proto.Clone(sshCtx).(*pbs.SshProtocolContext)
+		c.ConnectionRecordingId = "cr_1234567890"
+		c.SessionMeta = nil
+		_, err := m.NewConnectionRecorder(ctx, c, &pbs.SshProtocolSecrets{}, "sc_1234567890")
+		assert.ErrorContains(t, err, "is not being recorded by this worker")
+	})
+	t.Run("missing-keys", func(t *testing.T) {
+		c := proto.Clone(sshCtx).(*pbs.SshProtocolContext)
+		c.ConnectionRecordingId = "cr_1234567890"
+		_, err := m.NewConnectionRecorder(ctx, c, &pbs.SshProtocolSecrets{StorageBucket: testBucket()}, "sc_1234567890")
+		assert.ErrorContains(t, err, "missing recording keys")
+	})
+	t.Run("unknown-plugin", func(t *testing.T) {
+		c := proto.Clone(sshCtx).(*pbs.SshProtocolContext)
+		c.ConnectionRecordingId = "cr_1234567890"
+		s := proto.Clone(secrets).(*pbs.SshProtocolSecrets)
+		s.StorageBucket.Plugin.Name = "unknown"
+		_, err := m.NewConnectionRecorder(ctx, c, s, "sc_1234567890")
+		assert.ErrorIs(t, err, local.ErrUnknownPlugin)
+	})
+	t.Run("invalid-compression", func(t *testing.T) {
+		c := proto.Clone(sshCtx).(*pbs.SshProtocolContext)
+		c.ConnectionRecordingId = "cr_1234567890"
+		c.RecordingCompression = "lz4"
+		_, err := m.NewConnectionRecorder(ctx, c, secrets, "sc_1234567890")
+		assert.ErrorIs(t, err, bsr.ErrInvalidParameter)
+	})
+	t.Run("invalid-compression-level", func(t *testing.T) {
+		c := proto.Clone(sshCtx).(*pbs.SshProtocolContext)
+		c.ConnectionRecordingId = "cr_1234567890"
+		c.RecordingCompression = "gzip"
+		c.RecordingCompressionLevel = 19
+		_, err := m.NewConnectionRecorder(ctx, c, secrets, "sc_1234567890")
+		assert.ErrorContains(t, err, "invalid compression level 19 for gzip")
+	})
+
+	ids, err := m.SessionsManaged(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	assert.Empty(t, client.requests())
+}
+
+func TestManager_Record(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	rs, err := local.New(ctx, t.TempDir(), nil, true)
+	require.NoError(err)
+	client := &testSessionClient{}
+	m, err := NewManager(ctx, rs, func() pbs.SessionServiceClient { return client })
+	require.NoError(err)
+
+	const (
+		sessionId   = "s_1234567890"
+		recordingId = "sr_1234567890"
+	)
+	sshCtx, secrets, keys := testRecording(t, sessionId, recordingId)
+
+	sshCtx.ConnectionRecordingId = "cr_1234567890"
+	cr1, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, "sc_1234567890")
+	require.NoError(err)
+
+	// Further connections of the session only carry the ids of the
+	// recording.
+	sshCtx2 := &pbs.SshProtocolContext{
+		SessionId:             sessionId,
+		SessionRecordingId:    recordingId,
+		ConnectionRecordingId: "cr_0987654321",
+	}
+	cr2, err := m.NewConnectionRecorder(ctx, sshCtx2, &pbs.SshProtocolSecrets{}, "sc_0987654321")
+	require.NoError(err)
+	_, err = m.NewConnectionRecorder(ctx, sshCtx2, &pbs.SshProtocolSecrets{}, "sc_0987654321")
+	assert.Error(err)
+	sshCtx2.SessionRecordingId = "sr_0987654321"
+	_, err = m.NewConnectionRecorder(ctx, sshCtx2, &pbs.SshProtocolSecrets{}, "sc_0987654321")
+	assert.Error(err)
+
+	ids, err := m.SessionsManaged(ctx)
+	require.NoError(err)
+	assert.Equal([]string{sessionId}, ids)
+
+	ch, err := cr1.NewChannelRecorder(ctx, "session")
+	require.NoError(err)
+	require.NoError(ch.RecordRequest(ctx, bsr.Inbound, &ssh.Request{
+		Type:      bsrssh.ExecRequestType,
+		WantReply: true,
+		Payload:   ssh.Marshal(struct{ Command string }{"scp -t /tmp"}),
+	}))
+	require.NoError(ch.RecordData(ctx, bsr.Inbound, []byte("uploaded file")))
+	require.NoError(ch.RecordData(ctx, bsr.Outbound, []byte("ok")))
+	require.NoError(ch.Close(ctx))
+	require.NoError(ch.Close(ctx))
+	assert.Error(ch.RecordData(ctx, bsr.Inbound, []byte("closed")))
+
+	// A channel left open is closed with its connection.
+	_, err = cr1.NewChannelRecorder(ctx, "direct-tcpip")
+	require.NoError(err)
+	require.NoError(cr1.Close(ctx))
+	_, err = cr1.NewChannelRecorder(ctx, "session")
+	assert.Error(err)
+
+	// The session recorder is closed once the session has ended and all
+	// of its connections are closed.
+	require.NoError(m.ReauthorizeAllExcept(ctx, []string{"s_unknown", sessionId}))
+	assert.Empty(client.requests())
+	require.NoError(cr2.Close(ctx))
+
+	ids, err = m.SessionsManaged(ctx)
+	require.NoError(err)
+	assert.Empty(ids)
+
+	reqs := client.requests()
+	require.Len(reqs, 1)
+	req := reqs[0]
+	assert.Equal(recordingId, req.GetSessionRecordingId())
+	assert.Empty(req.GetErrorDetails())
+	require.Len(req.GetConnectionRecordings(), 2)
+	var conn *pbs.ConnectionRecordingSummary
+	for _, c := range req.GetConnectionRecordings() {
+		if c.GetConnectionRecordingId() == "cr_1234567890" {
+			conn = c
+		}
+	}
+	require.NotNil(conn)
+	assert.Equal(uint64(len("uploaded file")), conn.GetBytesUp())
+	assert.Equal(uint64(len("ok")), conn.GetBytesDown())
+	require.Len(conn.GetChannelRecordings(), 2)
+	chSummary := conn.GetChannelRecordings()[0]
+	assert.Equal(ch.Id(), chSummary.GetChannelRecordingId())
+	assert.Equal("session", chSummary.GetChannelType())
+	assert.Equal(string(bsrssh.Exec), chSummary.GetProgram())
+	assert.Equal(string(bsrssh.Scp), chSummary.GetExecProgram())
+	assert.Equal("direct-tcpip", conn.GetChannelRecordings()[1].GetChannelType())
+	assert.Empty(conn.GetChannelRecordings()[1].GetProgram())
+
+	// The recording has been synced to the storage bucket.
+	fs, err := rs.NewRemoteFS(ctx, testBucket())
+	require.NoError(err)
+	s, err := bsr.OpenSession(ctx, recordingId, fs, func(bsrkms.WrappedKeys) (bsrkms.UnwrappedKeys, error) {
+		return bsrkms.UnwrappedKeys{BsrKey: keys.BsrKey, PrivKey: keys.PrivKey}, nil
+	})
+	require.NoError(err)
+	assert.Equal(uint64(2), s.Summary.GetConnectionCount())
+	c, err := s.OpenConnection(ctx, "cr_1234567890")
+	require.NoError(err)
+	assert.Equal(uint64(2), c.Summary.GetChannelCount())
+	opened, err := c.OpenChannel(ctx, ch.Id())
+	require.NoError(err)
+	chs, ok := opened.Summary.(*bsrssh.ChannelSummary)
+	require.True(ok)
+	assert.Equal(bsrssh.Exec, chs.SessionProgram)
+	assert.Equal(bsrssh.FileTransferUpload, chs.FileTransferDirection)
+}
+
+func TestManager_RecordCompressed(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	rs, err := local.New(ctx, t.TempDir(), nil, true)
+	require.NoError(err)
+	client := &testSessionClient{}
+	m, err := NewManager(ctx, rs, func() pbs.SessionServiceClient { return client })
+	require.NoError(err)
+
+	const (
+		sessionId   = "s_1234567890"
+		recordingId = "sr_1234567890"
+	)
+	sshCtx, secrets, keys := testRecording(t, sessionId, recordingId)
+	sshCtx.ConnectionRecordingId = "cr_1234567890"
+	sshCtx.RecordingCompression = "zstd"
+	sshCtx.RecordingCompressionLevel = 3
+
+	cr, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, "sc_1234567890")
+	require.NoError(err)
+	ch, err := cr.NewChannelRecorder(ctx, "session")
+	require.NoError(err)
+	require.NoError(ch.RecordData(ctx, bsr.Inbound, []byte("compressed input")))
+	require.NoError(ch.Close(ctx))
+	require.NoError(m.ReauthorizeAllExcept(ctx, []string{sessionId}))
+	require.NoError(cr.Close(ctx))
+	require.Len(client.requests(), 1)
+
+	fs, err := rs.NewRemoteFS(ctx, testBucket())
+	require.NoError(err)
+	s, err := bsr.OpenSession(ctx, recordingId, fs, func(bsrkms.WrappedKeys) (bsrkms.UnwrappedKeys, error) {
+		return bsrkms.UnwrappedKeys{BsrKey: keys.BsrKey, PrivKey: keys.PrivKey}, nil
+	})
+	require.NoError(err)
+	c, err := s.OpenConnection(ctx, "cr_1234567890")
+	require.NoError(err)
+	opened, err := c.OpenChannel(ctx, ch.Id())
+	require.NoError(err)
+	scanner, err := opened.OpenMessageScanner(ctx, bsr.Inbound)
+	require.NoError(err)
+	defer scanner.Close()
+	first, err := scanner.Scan(ctx)
+	require.NoError(err)
+	header, ok := first.(*bsr.HeaderChunk)
+	require.True(ok)
+	assert.Equal(bsr.ZstdCompression, header.Compression)
+	var chunks int
+	require.NoError(bsr.ChunkWalk(ctx, scanner, func(context.Context, bsr.Chunk) error {
+		chunks++
+		return nil
+	}))
+	assert.NotZero(chunks)
+}
+
+func TestManager_Shutdown(t *testing.T) {
+	ctx := context.Background()
+	rs, err := local.New(ctx, t.TempDir(), nil, true)
+	require.NoError(t, err)
+	client := &testSessionClient{}
+	m, err := NewManager(ctx, rs, func() pbs.SessionServiceClient { return client })
+	require.NoError(t, err)
+
+	sshCtx, secrets, _ := testRecording(t, "s_1234567890", "sr_1234567890")
+	sshCtx.ConnectionRecordingId = "cr_1234567890"
+	cr, err := m.NewConnectionRecorder(ctx, sshCtx, secrets, "sc_1234567890")
+	require.NoError(t, err)
+	_, err = cr.NewChannelRecorder(ctx, "session")
+	require.NoError(t, err)
+
+	// The connection is never closed, so the recorder is closed once the
+	// context is done.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	m.Shutdown(cancelCtx)
+
+	reqs := client.requests()
+	require.Len(t, reqs, 1)
+	assert.Contains(t, reqs[0].GetErrorDetails(), "worker shut down")
+	require.Len(t, reqs[0].GetConnectionRecordings(), 1)
+	assert.Len(t, reqs[0].GetConnectionRecordings()[0].GetChannelRecordings(), 1)
+	assert.NoError(t, cr.Close(ctx))
+}
@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/event"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protocol is the protocol of the recorded sessions.
+const protocol = bsrssh.Protocol
+
+// sessionRecorder records a session into a bsr.Session.
+type sessionRecorder struct {
+	m         *Manager
+	id        string
+	sessionId string
+	session   *bsr.Session
+	startTime time.Time
+
+	// compression and compressionLevel are used to compress the chunks of
+	// the channel recordings of the session.
+	compression      bsr.Compression
+	compressionLevel int
+
+	mu              sync.Mutex
+	connections     map[string]*ConnectionRecorder
+	connectionCount uint64
+	summaries       []*pbs.ConnectionRecordingSummary
+	errs            []error
+	ended           bool
+	closed          bool
+}
+
+// newConnectionRecorder creates a ConnectionRecorder in the session.
+func (sr *sessionRecorder) newConnectionRecorder(ctx context.Context, id, connId string) (*ConnectionRecorder, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	switch {
+	case sr.closed:
+		return nil, fmt.Errorf("session recording %q is closed", sr.id)
+	case sr.connections[id] != nil:
+		return nil, fmt.Errorf("connection recording %q already exists", id)
+	}
+	startTime := time.Now()
+	c, err := sr.session.NewConnection(ctx, &bsr.ConnectionRecordingMeta{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	cr := &ConnectionRecorder{
+		sr:        sr,
+		id:        id,
+		connId:    connId,
+		conn:      c,
+		startTime: startTime,
+		channels:  make(map[string]*ChannelRecorder),
+	}
+	sr.connections[id] = cr
+	sr.connectionCount++
+	return cr, nil
+}
+
+// connectionClosed records the summary of a closed connection. The session
+// recorder is closed if the session has ended and this was its last open
+// connection.
+func (sr *sessionRecorder) connectionClosed(ctx context.Context, cr *ConnectionRecorder, summary *pbs.ConnectionRecordingSummary, err error) {
+	sr.mu.Lock()
+	if sr.closed {
+		sr.mu.Unlock()
+		return
+	}
+	delete(sr.connections, cr.id)
+	sr.summaries = append(sr.summaries, summary)
+	if err != nil {
+		sr.errs = append(sr.errs, err)
+	}
+	closeSession := sr.ended && len(sr.connections) == 0
+	sr.mu.Unlock()
+
+	if closeSession {
+		sr.close(ctx, nil)
+	}
+}
+
+// sessionClosed marks the session as ended. The session recorder is closed
+// once all of its connections are closed.
+func (sr *sessionRecorder) sessionClosed(ctx context.Context) {
+	sr.mu.Lock()
+	sr.ended = true
+	closeSession := len(sr.connections) == 0
+	sr.mu.Unlock()
+
+	if closeSession {
+		sr.close(ctx, nil)
+	}
+}
+
+// close closes the connections still being recorded, writes the summary of
+// the session, closes the bsr.Session and reports the closed recording to
+// the controller. Closing an already closed session recorder does nothing.
+func (sr *sessionRecorder) close(ctx context.Context, closeErr error) {
+	const op = "recorder.(sessionRecorder).close"
+	sr.mu.Lock()
+	if sr.closed {
+		sr.mu.Unlock()
+		return
+	}
+	sr.closed = true
+	open := make([]*ConnectionRecorder, 0, len(sr.connections))
+	for _, cr := range sr.connections {
+		open = append(open, cr)
+	}
+	sr.connections = nil
+	errs := sr.errs
+	if closeErr != nil {
+		errs = append(errs, closeErr)
+	}
+	summaries := sr.summaries
+	sr.mu.Unlock()
+	defer sr.m.removeSession(sr)
+
+	for _, cr := range open {
+		summary, err := cr.close(ctx)
+		summaries = append(summaries, summary)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	endTime := time.Now()
+	summary := &bsr.BaseSessionSummary{
+		Id:              sr.id,
+		ConnectionCount: sr.connectionCount,
+		StartTime:       sr.startTime,
+		EndTime:         endTime,
+	}
+	if err := errors.Join(errs...); err != nil {
+		summary.SetErrors(err)
+	}
+	if err := sr.session.EncodeSummary(ctx, summary); err != nil {
+		errs = append(errs, fmt.Errorf("unable to write session summary: %w", err))
+	}
+	if err := sr.session.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("unable to close session recording: %w", err))
+	}
+
+	req := &pbs.CloseSessionRecordingRequest{
+		SessionRecordingId:   sr.id,
+		StartTime:            timestamppb.New(sr.startTime),
+		EndTime:              timestamppb.New(endTime),
+		ConnectionRecordings: summaries,
+	}
+	if err := errors.Join(errs...); err != nil {
+		req.ErrorDetails = err.Error()
+		event.WriteError(ctx, op, err, event.WithInfoMsg("session recording closed with errors", "session_recording_id", sr.id))
+	}
+	// The recording is reported even if ctx is done, for instance when
+	// the worker is shutting down.
+	rpcCtx, cancel := context.WithTimeout(context.Background(), closeRecordingTimeout)
+	defer cancel()
+	if _, err := sr.m.clientFn().CloseSessionRecording(rpcCtx, req); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to report closed session recording", "session_recording_id", sr.id))
+	}
+}
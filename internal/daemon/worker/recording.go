@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package worker
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/daemon/worker/recorder"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+func init() {
+	recordingStorageFactory = newLocalRecordingStorage
+	recorderManagerFactory = newRecorderManager
+}
+
+func newLocalRecordingStorage(ctx context.Context, path string, plgClients map[string]plgpb.StoragePluginServiceClient, enableLoopback bool) (storage.RecordingStorage, error) {
+	return local.New(ctx, path, plgClients, enableLoopback)
+}
+
+// newRecorderManager creates the recorder manager of w. Workers without
+// recording storage do not record sessions and have no recorder manager.
+func newRecorderManager(w *Worker) (recorderManager, error) {
+	if w.RecordingStorage == nil {
+		return nil, nil
+	}
+	m, err := recorder.NewManager(w.baseContext, w.RecordingStorage, func() pbs.SessionServiceClient {
+		return pbs.NewSessionServiceClient(w.GrpcClientConn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
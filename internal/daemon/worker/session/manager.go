@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 )
 
@@ -55,6 +56,12 @@ type Manager interface {
 	//
 	// closeInfo is a map of connection ids mapped to connection metadata.
 	RequestCloseConnections(context.Context, map[string]*ConnectionCloseData) bool
+
+	// SetWatchedSessions starts sending the output of the local sessions
+	// with the provided ids to the controller using send, and stops sending
+	// the output of all other local sessions. It should be called during the
+	// worker status loop with the sessions the controller reports as watched.
+	SetWatchedSessions(ctx context.Context, send UpstreamMessageSender, sessIds []string)
 }
 
 type manager struct {
@@ -130,7 +137,9 @@ func (m *manager) LoadLocalSession(ctx context.Context, id string, workerId stri
 
 func (m *manager) DeleteLocalSession(sessIds []string) {
 	for _, s := range sessIds {
-		m.sessionMap.Delete(s)
+		if s, ok := m.sessionMap.LoadAndDelete(s); ok {
+			s.(*sess).stopWatch()
+		}
 	}
 }
 
@@ -138,6 +147,25 @@ func (m *manager) RequestCloseConnections(ctx context.Context, closeInfo map[str
 	return closeConnections(ctx, m.controllerSessionConn, m, closeInfo)
 }
 
+func (m *manager) SetWatchedSessions(ctx context.Context, send UpstreamMessageSender, sessIds []string) {
+	const op = "session.(*manager).SetWatchedSessions"
+	watched := make(map[string]struct{}, len(sessIds))
+	for _, id := range sessIds {
+		watched[id] = struct{}{}
+	}
+	m.sessionMap.Range(func(_, value any) bool {
+		s := value.(*sess)
+		if _, ok := watched[s.GetId()]; !ok {
+			s.stopWatch()
+			return true
+		}
+		if err := s.startWatch(ctx, send); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error watching session", "session_id", s.GetId()))
+		}
+		return true
+	})
+}
+
 func isNil(i any) bool {
 	if i == nil {
 		return true
@@ -4,22 +4,27 @@
 package session
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/targets"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -71,6 +76,86 @@ func TestManager_DeleteLocalSession(t *testing.T) {
 	assert.Nil(t, manager.Get("foo"))
 }
 
+func TestManager_SetWatchedSessions(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	oldInterval := watchFlushInterval
+	watchFlushInterval = 10 * time.Millisecond
+	t.Cleanup(func() {
+		watchFlushInterval = oldInterval
+	})
+
+	mockSessionClient := pbs.NewMockSessionServiceClient()
+	mockSessionClient.LookupSessionFn = func(_ context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
+		return &pbs.LookupSessionResponse{
+			Authorization: &targets.SessionAuthorizationData{
+				SessionId:   req.GetSessionId(),
+				Certificate: createTestCert(t),
+			},
+			Version:    1,
+			Expiration: timestamppb.New(time.Now().Add(time.Hour)),
+			Status:     pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE,
+		}, nil
+	}
+	sent := make(chan proto.Message, 10)
+	send := func(_ context.Context, m proto.Message) (proto.Message, error) {
+		sent <- m
+		return &pbs.SendSessionOutputResponse{}, nil
+	}
+	manager, err := NewManager(mockSessionClient)
+	require.NoError(err)
+	foo, err := manager.LoadLocalSession(ctx, "foo", "worker id")
+	require.NoError(err)
+	bar, err := manager.LoadLocalSession(ctx, "bar", "worker id")
+	require.NoError(err)
+
+	manager.SetWatchedSessions(ctx, send, []string{"foo", "unknown"})
+	assert.True(foo.IsWatched())
+	assert.False(bar.IsWatched())
+	// Watching an already watched session is ok.
+	manager.SetWatchedSessions(ctx, send, []string{"foo"})
+	assert.True(foo.IsWatched())
+
+	bar.WatchOutput(ctx, []byte("not watched"))
+	foo.WatchOutput(ctx, []byte("hello "))
+	foo.WatchOutput(ctx, []byte("world"))
+
+	var m proto.Message
+	select {
+	case m = <-sent:
+	case <-time.After(5 * time.Second):
+		require.FailNow("output was not sent")
+	}
+	req, ok := m.(*pbs.SendSessionOutputRequest)
+	require.True(ok)
+	assert.Equal("foo", req.GetSessionId())
+
+	// The output is sent as a sequence of outbound BSR data chunks
+	dec, err := bsr.NewChunkDecoder(ctx, bytes.NewReader(req.GetOutput()))
+	require.NoError(err)
+	var output []byte
+	for {
+		c, err := dec.Decode(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		dc, ok := c.(*bsrssh.DataChunk)
+		require.True(ok)
+		assert.Equal(bsr.Outbound, dc.GetDirection())
+		output = append(output, dc.Data...)
+	}
+	assert.Equal("hello world", string(output))
+
+	manager.SetWatchedSessions(ctx, send, nil)
+	assert.False(foo.IsWatched())
+
+	manager.SetWatchedSessions(ctx, send, []string{"foo"})
+	assert.True(foo.IsWatched())
+	manager.DeleteLocalSession([]string{"foo"})
+	assert.False(foo.IsWatched())
+}
+
 func TestManager_RequestCloseConnections(t *testing.T) {
 	ctx := context.Background()
 	mockSessionClient := pbs.NewMockSessionServiceClient()
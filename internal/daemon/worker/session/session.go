@@ -48,6 +48,8 @@ type ConnectionCloseData struct {
 	SessionId string
 	BytesUp   int64
 	BytesDown int64
+	// ClosedReason is the reason the connection was closed, if known
+	ClosedReason session.ClosedReason
 }
 
 // Session is the local representation of a session.  After initial loading
@@ -74,6 +76,9 @@ type Session interface {
 	GetHostKeys() ([]crypto.Signer, error)
 	GetCredentials() []*pbs.Credential
 	GetExpiration() time.Time
+	// GetIdleTimeout returns how long a connection of the session can carry no
+	// traffic before it is closed. A zero duration means no idle timeout.
+	GetIdleTimeout() time.Duration
 	GetCertificate() *x509.Certificate
 	GetPrivateKey() []byte
 	GetId() string
@@ -121,6 +126,15 @@ type Session interface {
 	// authorized.  The local connection's status is updated with the result of the
 	// call.
 	RequestConnectConnection(ctx context.Context, info *pbs.ConnectConnectionRequest) error
+
+	// WatchOutput passes output of the session sent to the client to the
+	// controller while the session is being watched. It does nothing if the
+	// session is not being watched. It is safe for concurrent use, does not
+	// retain data and does not block on the controller.
+	WatchOutput(ctx context.Context, data []byte)
+
+	// IsWatched returns whether the output of the session is being watched.
+	IsWatched() bool
 }
 
 type sess struct {
@@ -132,6 +146,8 @@ type sess struct {
 	cert        *x509.Certificate
 	sessionId   string
 	tofuToken   string
+	// watch is set while the output of the session is being watched
+	watch *watch
 }
 
 func newSess(client pbs.SessionServiceClient, resp *pbs.LookupSessionResponse) (*sess, error) {
@@ -279,6 +295,12 @@ func (s *sess) GetExpiration() time.Time {
 	return s.resp.GetExpiration().AsTime()
 }
 
+func (s *sess) GetIdleTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return time.Duration(s.resp.GetIdleTimeoutSeconds()) * time.Second
+}
+
 func (s *sess) GetCertificate() *x509.Certificate {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -548,9 +570,13 @@ func closeConnections(ctx context.Context, sessClient pbs.SessionServiceClient,
 func makeCloseConnectionRequest(closeInfo map[string]*ConnectionCloseData) *pbs.CloseConnectionRequest {
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeInfo))
 	for connId, data := range closeInfo {
+		reason := data.ClosedReason
+		if reason == "" {
+			reason = session.UnknownReason
+		}
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
 			ConnectionId: connId,
-			Reason:       session.UnknownReason.String(),
+			Reason:       reason.String(),
 			BytesUp:      data.BytesUp,
 			BytesDown:    data.BytesDown,
 		})
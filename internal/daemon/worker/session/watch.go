@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/event"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"google.golang.org/protobuf/proto"
+)
+
+// watchFlushInterval is how often the output buffered for a watched session
+// is sent to the controller. It can be overridden in tests.
+var watchFlushInterval = 250 * time.Millisecond
+
+const (
+	// maxWatchBufferSize limits the output buffered for a watched session.
+	// Output is dropped while the buffer is full, which only happens if the
+	// controller can't keep up with the output of the session.
+	maxWatchBufferSize = 1024 * 1024
+
+	// sendOutputTimeout is the timeout of sending output to the controller.
+	sendOutputTimeout = 10 * time.Second
+)
+
+// watch buffers the output of a watched session as BSR data chunks and
+// periodically sends it to the controller.
+type watch struct {
+	lock   sync.Mutex
+	buf    *bytes.Buffer
+	enc    *bsr.ChunkEncoder
+	cancel context.CancelFunc
+}
+
+func newWatch(ctx context.Context) (*watch, error) {
+	buf := new(bytes.Buffer)
+	enc, err := bsr.NewChunkEncoder(ctx, buf, bsr.NoCompression, bsr.NoEncryption)
+	if err != nil {
+		return nil, fmt.Errorf("error creating chunk encoder: %w", err)
+	}
+	return &watch{
+		buf: buf,
+		enc: enc,
+	}, nil
+}
+
+// write encodes data as outbound data chunks and buffers them. data is
+// dropped if the buffer is full.
+func (w *watch) write(ctx context.Context, data []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.buf.Len() >= maxWatchBufferSize {
+		return nil
+	}
+	ts := bsr.NewTimestamp(time.Now())
+	for len(data) > 0 {
+		n := min(len(data), bsrssh.MaxPacketSize)
+		c, err := bsrssh.NewDataChunk(ctx, bsr.Outbound, ts, data[:n])
+		if err != nil {
+			return err
+		}
+		if _, err := w.enc.Encode(ctx, c); err != nil {
+			return fmt.Errorf("error encoding data chunk: %w", err)
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// take returns the buffered output and empties the buffer.
+func (w *watch) take() []byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	output := bytes.Clone(w.buf.Bytes())
+	w.buf.Reset()
+	return output
+}
+
+// UpstreamMessageSender sends an upstream message to the controller and
+// returns its response.
+type UpstreamMessageSender func(context.Context, proto.Message) (proto.Message, error)
+
+// run sends the buffered output to the controller every interval until ctx
+// is done. The output is sent as an encrypted upstream message, which tells
+// the controller which worker sent it.
+func (w *watch) run(ctx context.Context, send UpstreamMessageSender, sessionId string, interval time.Duration) {
+	const op = "session.(watch).run"
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		output := w.take()
+		if len(output) == 0 {
+			continue
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, sendOutputTimeout)
+		_, err := send(sendCtx, &pbs.SendSessionOutputRequest{
+			SessionId: sessionId,
+			Output:    output,
+		})
+		cancel()
+		if err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("error sending session output", "session_id", sessionId))
+		}
+	}
+}
+
+// WatchOutput passes output sent to the client to the watch of the session.
+// It does nothing if the session is not being watched.
+func (s *sess) WatchOutput(ctx context.Context, data []byte) {
+	const op = "session.(sess).WatchOutput"
+	s.lock.RLock()
+	w := s.watch
+	s.lock.RUnlock()
+	if w == nil {
+		return
+	}
+	if err := w.write(ctx, data); err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("error watching session output", "session_id", s.GetId()))
+	}
+}
+
+// IsWatched returns whether the output of the session is being watched.
+func (s *sess) IsWatched() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.watch != nil
+}
+
+// startWatch starts sending the output of the session to the controller
+// until stopWatch is called or ctx is done. It does nothing if the session is
+// already being watched.
+func (s *sess) startWatch(ctx context.Context, send UpstreamMessageSender) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.watch != nil {
+		return nil
+	}
+	w, err := newWatch(ctx)
+	if err != nil {
+		return err
+	}
+	var watchCtx context.Context
+	watchCtx, w.cancel = context.WithCancel(ctx)
+	s.watch = w
+	go w.run(watchCtx, send, s.GetId(), watchFlushInterval)
+	return nil
+}
+
+// stopWatch stops sending the output of the session to the controller.
+func (s *sess) stopWatch() {
+	s.lock.Lock()
+	w := s.watch
+	s.watch = nil
+	s.lock.Unlock()
+	if w != nil {
+		w.cancel()
+	}
+}
@@ -352,6 +352,9 @@ func (w *Worker) sendWorkerStatus(cancelCtx context.Context, sessionManager sess
 		}
 	}
 
+	// Forward the output of sessions which are being watched to the controller
+	sessionManager.SetWatchedSessions(cancelCtx, w.SendUpstreamMessage, result.GetWatchedSessionIds())
+
 	// Standard cleanup: Run through current jobs. Cancel connections
 	// for any canceling session or any session that is expired.
 	w.cleanupConnections(cancelCtx, false, sessionManager)
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/boundary/internal/event"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/servers"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/plugin/filesystem"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/storage"
 	boundary_plugin_assets "github.com/hashicorp/boundary/plugins/boundary"
@@ -252,6 +253,12 @@ func New(ctx context.Context, conf *Config) (*Worker, error) {
 				}
 				conf.ShutdownFuncs = append(conf.ShutdownFuncs, cleanup)
 				plgClients[pluginType] = client
+			case enabledPlugin == base.EnabledPluginFilesystem && w.conf.RawConfig.Worker.FilesystemStoragePath != "":
+				client, err := filesystem.NewStorageClient(w.conf.RawConfig.Worker.FilesystemStoragePath)
+				if err != nil {
+					return nil, fmt.Errorf("error creating filesystem storage plugin: %w", err)
+				}
+				plgClients[filesystem.PluginName] = client
 			case enabledPlugin == base.EnabledPluginLoopback:
 				enableStorageLoopback = true
 			}
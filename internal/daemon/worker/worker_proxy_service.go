@@ -69,3 +69,7 @@ func (ws *workerProxyServiceServer) ConnectConnection(ctx context.Context, req *
 func (ws *workerProxyServiceServer) CloseConnection(ctx context.Context, req *pbs.CloseConnectionRequest) (*pbs.CloseConnectionResponse, error) {
 	return pbs.NewSessionServiceClient(ws.cc).CloseConnection(ctx, req)
 }
+
+func (ws *workerProxyServiceServer) CloseSessionRecording(ctx context.Context, req *pbs.CloseSessionRecordingRequest) (*pbs.CloseSessionRecordingResponse, error) {
+	return pbs.NewSessionServiceClient(ws.cc).CloseSessionRecording(ctx, req)
+}
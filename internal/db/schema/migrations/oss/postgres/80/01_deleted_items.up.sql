@@ -0,0 +1,50 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+-- insert_deleted_id records the public id of a deleted row in the table named
+-- by the first trigger argument. These tables are used to report removed
+-- items to clients refreshing a list of resources.
+create function insert_deleted_id() returns trigger
+as $$
+begin
+  execute format('insert into %I (public_id, delete_time)
+                  values ($1, now())
+                  on conflict (public_id) do update
+                  set delete_time = excluded.delete_time',
+                  tg_argv[0])
+    using old.public_id;
+  return old;
+end;
+$$ language plpgsql;
+comment on function insert_deleted_id() is
+  'function used in after delete triggers to record the public id of a deleted row';
+
+create table target_deleted (
+  public_id wt_public_id primary key,
+  delete_time wt_timestamp
+);
+comment on table target_deleted is
+  'target_deleted holds the public ids of deleted targets';
+create index target_deleted_delete_time_idx on target_deleted (delete_time);
+
+create trigger insert_deleted_id after delete on target
+  for each row execute procedure insert_deleted_id('target_deleted');
+
+create table session_deleted (
+  public_id wt_public_id primary key,
+  delete_time wt_timestamp
+);
+comment on table session_deleted is
+  'session_deleted holds the public ids of deleted sessions';
+create index session_deleted_delete_time_idx on session_deleted (delete_time);
+
+create trigger insert_deleted_id after delete on session
+  for each row execute procedure insert_deleted_id('session_deleted');
+
+-- Session state changes do not update the session itself, so refreshing a
+-- list of sessions also looks for recently started session states.
+create index session_state_start_time_idx on session_state (start_time);
+
+commit;
@@ -0,0 +1,154 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- wt_alias defines a type for alias values. An alias value is a lower case
+  -- dns-like name made up of dot separated labels.
+  create domain wt_alias as text
+    constraint wt_alias_too_short
+      check (length(trim(value)) > 0)
+    constraint wt_alias_too_long
+      check (length(value) < 254)
+    constraint wt_alias_must_be_lowercase
+      check (value = lower(value))
+    constraint wt_alias_must_be_dns_like
+      check (value ~ '^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$');
+  comment on domain wt_alias is
+    'standard column for alias values';
+
+  create table alias (
+    public_id wt_public_id primary key,
+    value wt_alias not null
+      constraint alias_value_uq
+        unique
+  );
+  comment on table alias is
+    'alias is a base table for the alias type. '
+    'Each row maps 1-to-1 to a row in one of the alias subtype tables. '
+    'Alias values are unique across all alias subtypes.';
+
+  create trigger immutable_columns before update on alias
+    for each row execute procedure immutable_columns('public_id');
+
+  -- insert_alias_subtype() is a before insert trigger function for subtypes
+  -- of alias
+  create function insert_alias_subtype() returns trigger
+  as $$
+  begin
+    insert into alias
+      (public_id, value)
+    values
+      (new.public_id, new.value);
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- update_alias_subtype() is a before update trigger function for subtypes
+  -- of alias
+  create function update_alias_subtype() returns trigger
+  as $$
+  begin
+    if new.value is distinct from old.value then
+      update alias
+         set value = new.value
+       where public_id = new.public_id;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- delete_alias_subtype() is an after delete trigger function for subtypes
+  -- of alias
+  create function delete_alias_subtype() returns trigger
+  as $$
+  begin
+    delete from alias
+    where public_id = old.public_id;
+    return null; -- result is ignored since this is an after trigger
+  end;
+  $$ language plpgsql;
+
+  create table alias_target (
+    public_id wt_public_id primary key
+      constraint alias_fkey
+        references alias (public_id)
+        on delete cascade
+        on update cascade,
+    scope_id wt_scope_id not null
+      constraint iam_scope_global_fkey
+        references iam_scope_global (scope_id)
+        on delete cascade
+        on update cascade,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    value wt_alias not null
+      constraint alias_target_value_uq
+        unique,
+    destination_id wt_public_id
+      constraint target_fkey
+        references target (public_id)
+        on delete set null
+        on update cascade,
+    host_id wt_public_id
+      constraint host_fkey
+        references host (public_id)
+        on delete set null
+        on update cascade,
+    constraint destination_id_set_when_host_id_is_set
+      check (destination_id is not null or host_id is null),
+    constraint alias_target_scope_id_name_uq
+      unique (scope_id, name)
+  );
+  comment on table alias_target is
+    'alias_target is a table where each row is a resource that represents an alias for a target. '
+    'It is an alias subtype and an aggregate root.';
+  create index alias_target_destination_id_idx on alias_target (destination_id);
+
+  create trigger update_version_column after update on alias_target
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on alias_target
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on alias_target
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on alias_target
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create trigger insert_alias_subtype before insert on alias_target
+    for each row execute procedure insert_alias_subtype();
+
+  create trigger update_alias_subtype before update on alias_target
+    for each row execute procedure update_alias_subtype();
+
+  create trigger delete_alias_subtype after delete on alias_target
+    for each row execute procedure delete_alias_subtype();
+
+  -- allow operations on target aliases to be oplogged
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('alias_target', 1);
+
+  -- clear_alias_target_host_id() is a before update trigger function that
+  -- clears the host id of a target alias when its destination is removed,
+  -- either explicitly or because the target was deleted.
+  create function clear_alias_target_host_id() returns trigger
+  as $$
+  begin
+    if new.destination_id is null then
+      new.host_id = null;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger clear_alias_target_host_id before update of destination_id on alias_target
+    for each row execute procedure clear_alias_target_host_id();
+
+commit;
@@ -0,0 +1,111 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table policy_storage_policy (
+    public_id wt_public_id primary key,
+    scope_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade
+      constraint policy_storage_policy_scope_id_uq
+        unique,
+    name wt_name,
+    description wt_description,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    -- -1 retains recordings forever, 0 does not require recordings to be
+    -- retained for any duration.
+    retain_for_days integer not null default 0
+      constraint retain_for_days_must_be_infinite_or_not_negative
+        check (retain_for_days >= -1),
+    -- 0 never deletes recordings.
+    delete_after_days integer not null default 0
+      constraint delete_after_days_must_not_be_negative
+        check (delete_after_days >= 0),
+    legal_hold boolean not null default false,
+    constraint delete_after_days_must_not_be_less_than_retain_for_days
+      check (
+        delete_after_days = 0 or
+        (retain_for_days >= 0 and delete_after_days >= retain_for_days)
+      )
+  );
+  comment on table policy_storage_policy is
+    'policy_storage_policy is a table where each row is a resource that represents a storage policy. '
+    'A storage policy is attached to the global scope or an org scope, which can have at most one storage policy. '
+    'It applies to the session recordings stored in the storage buckets of the scope.';
+
+  create trigger update_version_column after update on policy_storage_policy
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on policy_storage_policy
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on policy_storage_policy
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on policy_storage_policy
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  -- policy_storage_policy_scope_id_valid() is a before insert trigger
+  -- function which ensures storage policies are only attached to the global
+  -- scope or org scopes.
+  create function policy_storage_policy_scope_id_valid() returns trigger
+  as $$
+  begin
+    perform from iam_scope where public_id = new.scope_id and type in ('global', 'org');
+    if not found then
+      raise exception 'invalid scope type for storage policy creation';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger policy_storage_policy_scope_id_valid before insert on policy_storage_policy
+    for each row execute procedure policy_storage_policy_scope_id_valid();
+
+  -- allow operations on storage policies to be oplogged
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('policy_storage_policy', 1);
+
+  -- recording_session_storage_policy resolves the storage policy which
+  -- applies to each session recording. Storage buckets, and the recordings
+  -- stored in them, inherit the storage policy of the scope of the storage
+  -- bucket or, if that scope has no storage policy, the storage policy of the
+  -- global scope. The policy is resolved whenever the view is read so changes
+  -- to a policy, like setting a legal hold, apply to existing recordings.
+  create view recording_session_storage_policy as
+  select
+    rs.public_id,
+    rs.storage_bucket_id,
+    rs.state,
+    rs.create_time,
+    rs.end_time,
+    sb.scope_id as storage_bucket_scope_id,
+    sb.plugin_id as storage_bucket_plugin_id,
+    sp.public_id as storage_policy_id,
+    coalesce(sp.retain_for_days, 0) as retain_for_days,
+    coalesce(sp.delete_after_days, 0) as delete_after_days,
+    coalesce(sp.legal_hold, false) as legal_hold
+  from recording_session rs
+    join storage_plugin_storage_bucket sb
+      on sb.public_id = rs.storage_bucket_id
+    left join lateral (
+      select p.public_id,
+             p.retain_for_days,
+             p.delete_after_days,
+             p.legal_hold
+        from policy_storage_policy p
+       where p.scope_id in (sb.scope_id, 'global')
+    order by p.scope_id = sb.scope_id desc
+       limit 1
+    ) sp on true;
+  comment on view recording_session_storage_policy is
+    'recording_session_storage_policy contains each session recording and the settings of the storage policy it inherits.';
+
+commit;
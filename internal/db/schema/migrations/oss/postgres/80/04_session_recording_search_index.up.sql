@@ -0,0 +1,55 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table recording_session_search_index_state_enm (
+    name text primary key
+      constraint only_predefined_search_index_states_allowed
+        check(name in ('indexed', 'failed'))
+  );
+  comment on table recording_session_search_index_state_enm is
+    'recording_session_search_index_state_enm holds valid values for the state of a recording_session_search_index row.';
+
+  insert into recording_session_search_index_state_enm (name)
+  values
+    ('indexed'),
+    ('failed');
+
+  create trigger immutable_columns before update on recording_session_search_index_state_enm
+    for each row execute procedure immutable_columns('name');
+
+  create table recording_session_search_index (
+    recording_session_id wt_public_id primary key
+      constraint recording_session_fkey
+        references recording_session (public_id)
+        on delete cascade
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    state text not null
+      constraint recording_session_search_index_state_enm_fkey
+        references recording_session_search_index_state_enm (name)
+        on delete restrict
+        on update cascade,
+    error_details text
+      constraint error_details_must_be_set_for_failed_state
+        check (
+          (state = 'failed' and error_details is not null) or
+          (state = 'indexed' and error_details is null)
+        )
+  );
+  comment on table recording_session_search_index is
+    'recording_session_search_index is a table where each row records whether the search index of a session recording was built. '
+    'The index itself is stored alongside the session recording in its storage bucket.';
+
+  create trigger update_time_column before update on recording_session_search_index
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on recording_session_search_index
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on recording_session_search_index
+    for each row execute procedure immutable_columns('recording_session_id', 'create_time');
+
+commit;
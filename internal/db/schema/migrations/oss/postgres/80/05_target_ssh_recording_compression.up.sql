@@ -0,0 +1,70 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Values can be null, in which case recordings are not compressed.
+  alter table target_ssh
+    add column recording_compression text null
+      constraint recording_compression_valid
+        check (recording_compression in ('none', 'gzip', 'zstd')),
+    add column recording_compression_level integer null,
+    add constraint recording_compression_level_valid
+      check (
+        recording_compression_level is null
+        or (recording_compression = 'gzip' and recording_compression_level between 1 and 9)
+        or (recording_compression = 'zstd' and recording_compression_level between 1 and 22)
+      );
+  comment on column target_ssh.recording_compression is
+    'recording_compression is the compression used for the chunks of session recordings of the target.';
+  comment on column target_ssh.recording_compression_level is
+    'recording_compression_level is the level of the recording_compression, or null for the default level.';
+
+  -- Replaces target_all_subtypes defined in 71/07_targets.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    null::text as recording_compression,
+    null::integer as recording_compression_level
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    recording_compression,
+    recording_compression_level
+  from
+    target_ssh;
+
+commit;
@@ -0,0 +1,123 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- A null value disables the corresponding policy.
+  alter table auth_password_method
+    add column min_password_character_classes integer null
+      constraint min_password_character_classes_must_be_between_1_and_4
+        check (min_password_character_classes between 1 and 4),
+    add column password_history_depth integer null
+      constraint password_history_depth_must_be_positive
+        check (password_history_depth > 0),
+    add column max_password_age_days integer null
+      constraint max_password_age_days_must_be_positive
+        check (max_password_age_days > 0),
+    add column lockout_threshold integer null
+      constraint lockout_threshold_must_be_positive
+        check (lockout_threshold > 0),
+    add column lockout_duration_seconds integer null
+      constraint lockout_duration_seconds_must_be_positive
+        check (lockout_duration_seconds > 0);
+
+  comment on column auth_password_method.min_password_character_classes is
+    'The number of character classes (lowercase, uppercase, digits and symbols) a password must contain.';
+  comment on column auth_password_method.password_history_depth is
+    'The number of most recent passwords of an account, including the current one, which cannot be reused.';
+  comment on column auth_password_method.max_password_age_days is
+    'The number of days after which the password of an account expires.';
+  comment on column auth_password_method.lockout_threshold is
+    'The number of consecutive failed authentication attempts after which an account is locked.';
+  comment on column auth_password_method.lockout_duration_seconds is
+    'The number of seconds an account stays locked.';
+
+  -- Replaces view from 2/20_pass.up.sql
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.min_password_character_classes,
+    am.password_history_depth,
+    am.max_password_age_days,
+    am.lockout_threshold,
+    am.lockout_duration_seconds
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+
+  -- Replaces view from 0/14_auth_password_views.up.sql
+  create or replace view auth_password_current_conf as
+      select pm.min_login_name_length, pm.min_password_length, c.*,
+             pm.min_password_character_classes, pm.password_history_depth
+        from auth_password_method pm
+  inner join auth_password_conf_union c
+          on pm.password_conf_id = c.password_conf_id;
+
+  create table auth_password_account_lockout (
+    password_account_id wt_public_id primary key
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    failed_attempts integer not null default 0
+      constraint failed_attempts_must_not_be_negative
+        check (failed_attempts >= 0),
+    last_failed_attempt_time wt_timestamp,
+    locked_until timestamp with time zone null
+  );
+  comment on table auth_password_account_lockout is
+    'auth_password_account_lockout holds the number of consecutive failed authentication attempts of a password account '
+    'and the time until which the account is locked.';
+
+  -- auth_password_argon2_cred_history contains the previous credentials of
+  -- an account which are checked when the password of the account is changed
+  -- to prevent the reuse of recent passwords.
+  create table auth_password_argon2_cred_history (
+    private_id wt_private_id primary key,
+    password_account_id wt_public_id not null
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    password_conf_id wt_private_id not null
+      constraint auth_password_argon2_conf_fkey
+        references auth_password_argon2_conf (private_id)
+        on delete cascade
+        on update cascade,
+    create_time wt_timestamp,
+    retire_time wt_timestamp,
+    salt bytea not null
+      constraint salt_must_not_be_empty
+        check(length(salt) > 0),
+    derived_key bytea not null
+      constraint derived_key_must_not_be_empty
+        check(length(derived_key) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade
+  );
+  comment on table auth_password_argon2_cred_history is
+    'auth_password_argon2_cred_history holds the retired argon2 credentials of a password account.';
+
+  create index auth_password_argon2_cred_history_account_retire_time_ix
+    on auth_password_argon2_cred_history (password_account_id, retire_time desc);
+
+  create trigger immutable_columns before update on auth_password_argon2_cred_history
+    for each row execute procedure immutable_columns('private_id', 'password_account_id', 'password_conf_id', 'create_time', 'retire_time', 'derived_key');
+
+commit;
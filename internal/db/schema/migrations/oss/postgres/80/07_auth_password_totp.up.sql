@@ -0,0 +1,72 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- auth_password_totp holds the time-based one-time password (TOTP) second
+  -- factor of a password account. The second factor is only required to
+  -- authenticate once the enrollment is verified.
+  create table auth_password_totp (
+    password_account_id wt_public_id primary key
+      constraint auth_password_account_fkey
+        references auth_password_account (public_id)
+        on delete cascade
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    secret bytea not null
+      constraint secret_must_not_be_empty
+        check(length(secret) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    verify_time timestamp with time zone null,
+    last_time_step bigint null
+      constraint last_time_step_must_be_positive
+        check(last_time_step > 0)
+  );
+  comment on table auth_password_totp is
+    'auth_password_totp holds the encrypted shared secret of the TOTP second factor of a password account.';
+
+  create trigger update_time_column before update on auth_password_totp
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on auth_password_totp
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_totp
+    for each row execute procedure immutable_columns('password_account_id', 'create_time');
+
+  -- auth_password_totp_challenge holds the pending authentications of password
+  -- accounts which passed the password step and must be completed with a TOTP
+  -- code.
+  create table auth_password_totp_challenge (
+    private_id wt_private_id primary key,
+    create_time wt_timestamp,
+    password_account_id wt_public_id not null
+      constraint auth_password_totp_fkey
+        references auth_password_totp (password_account_id)
+        on delete cascade
+        on update cascade,
+    expiration_time timestamp with time zone not null
+      constraint create_time_must_be_before_expiration_time
+        check(create_time < expiration_time),
+    failed_attempts integer not null default 0
+      constraint failed_attempts_must_not_be_negative
+        check (failed_attempts >= 0)
+  );
+  comment on table auth_password_totp_challenge is
+    'auth_password_totp_challenge holds the pending TOTP authentications of password accounts.';
+
+  create index auth_password_totp_challenge_account_ix
+    on auth_password_totp_challenge (password_account_id);
+
+  create trigger default_create_time_column before insert on auth_password_totp_challenge
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on auth_password_totp_challenge
+    for each row execute procedure immutable_columns('private_id', 'create_time', 'password_account_id', 'expiration_time');
+
+commit;
@@ -0,0 +1,286 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Auth token lifetimes can be set on scopes and overridden on auth methods.
+  -- A null value uses the next level up: auth method, then scope, then the
+  -- controller's configuration.
+
+  alter table iam_scope
+    add column auth_token_time_to_live_seconds integer null
+      constraint auth_token_time_to_live_seconds_must_be_positive
+        check (auth_token_time_to_live_seconds > 0),
+    add column auth_token_time_to_stale_seconds integer null
+      constraint auth_token_time_to_stale_seconds_must_be_positive
+        check (auth_token_time_to_stale_seconds > 0);
+  comment on column iam_scope.auth_token_time_to_live_seconds is
+    'The maximum number of seconds an auth token is valid for.';
+  comment on column iam_scope.auth_token_time_to_stale_seconds is
+    'The number of seconds after which an unused auth token is invalidated.';
+
+  alter table auth_method
+    add column auth_token_time_to_live_seconds integer null
+      constraint auth_token_time_to_live_seconds_must_be_positive
+        check (auth_token_time_to_live_seconds > 0),
+    add column auth_token_time_to_stale_seconds integer null
+      constraint auth_token_time_to_stale_seconds_must_be_positive
+        check (auth_token_time_to_stale_seconds > 0);
+  comment on column auth_method.auth_token_time_to_live_seconds is
+    'The maximum number of seconds an auth token is valid for.';
+  comment on column auth_method.auth_token_time_to_stale_seconds is
+    'The number of seconds after which an unused auth token is invalidated.';
+
+  alter table auth_password_method
+    add column auth_token_time_to_live_seconds integer null
+      constraint auth_token_time_to_live_seconds_must_be_positive
+        check (auth_token_time_to_live_seconds > 0),
+    add column auth_token_time_to_stale_seconds integer null
+      constraint auth_token_time_to_stale_seconds_must_be_positive
+        check (auth_token_time_to_stale_seconds > 0);
+  comment on column auth_password_method.auth_token_time_to_live_seconds is
+    'The maximum number of seconds an auth token is valid for.';
+  comment on column auth_password_method.auth_token_time_to_stale_seconds is
+    'The number of seconds after which an unused auth token is invalidated.';
+
+  alter table auth_oidc_method
+    add column auth_token_time_to_live_seconds integer null
+      constraint auth_token_time_to_live_seconds_must_be_positive
+        check (auth_token_time_to_live_seconds > 0),
+    add column auth_token_time_to_stale_seconds integer null
+      constraint auth_token_time_to_stale_seconds_must_be_positive
+        check (auth_token_time_to_stale_seconds > 0);
+  comment on column auth_oidc_method.auth_token_time_to_live_seconds is
+    'The maximum number of seconds an auth token is valid for.';
+  comment on column auth_oidc_method.auth_token_time_to_stale_seconds is
+    'The number of seconds after which an unused auth token is invalidated.';
+
+  alter table auth_ldap_method
+    add column auth_token_time_to_live_seconds integer null
+      constraint auth_token_time_to_live_seconds_must_be_positive
+        check (auth_token_time_to_live_seconds > 0),
+    add column auth_token_time_to_stale_seconds integer null
+      constraint auth_token_time_to_stale_seconds_must_be_positive
+        check (auth_token_time_to_stale_seconds > 0);
+  comment on column auth_ldap_method.auth_token_time_to_live_seconds is
+    'The maximum number of seconds an auth token is valid for.';
+  comment on column auth_ldap_method.auth_token_time_to_stale_seconds is
+    'The number of seconds after which an unused auth token is invalidated.';
+
+  -- Replaces function from 2/10_auth.up.sql
+  -- The auth token lifetimes are copied to the base type so the auth token
+  -- repository can resolve them without knowing the auth method's sub type.
+  create or replace function insert_auth_method_subtype() returns trigger
+  as $$
+  begin
+    insert into auth_method
+      (public_id, scope_id, name, auth_token_time_to_live_seconds, auth_token_time_to_stale_seconds)
+    values
+      (new.public_id, new.scope_id, new.name, new.auth_token_time_to_live_seconds, new.auth_token_time_to_stale_seconds);
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function insert_auth_method_subtype() is
+    'insert_auth_method_subtype() inserts sub type name and auth token lifetimes into the base type auth method table';
+
+  -- Replaces function from 2/10_auth.up.sql
+  create or replace function update_auth_method_subtype() returns trigger
+  as $$
+  begin
+    update auth_method set name = new.name where public_id = new.public_id and new.name != name;
+    update auth_method
+       set auth_token_time_to_live_seconds  = new.auth_token_time_to_live_seconds,
+           auth_token_time_to_stale_seconds = new.auth_token_time_to_stale_seconds
+     where public_id = new.public_id
+       and (auth_token_time_to_live_seconds  is distinct from new.auth_token_time_to_live_seconds
+        or  auth_token_time_to_stale_seconds is distinct from new.auth_token_time_to_stale_seconds);
+    return new;
+  end;
+  $$ language plpgsql;
+  comment on function update_auth_method_subtype() is
+    'update_auth_method_subtype() will update base auth method type name and auth token lifetime columns with new values from sub type';
+
+  -- Replaces view from 80/06_auth_password_policy.up.sql
+  create or replace view auth_password_method_with_is_primary as
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.password_conf_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.min_login_name_length,
+    am.min_password_length,
+    am.min_password_character_classes,
+    am.password_history_depth,
+    am.max_password_age_days,
+    am.lockout_threshold,
+    am.lockout_duration_seconds,
+    am.auth_token_time_to_live_seconds,
+    am.auth_token_time_to_stale_seconds
+  from
+    auth_password_method am
+    left outer join iam_scope s on am.public_id = s.primary_auth_method_id;
+
+  -- Replaces view from 79/01_auth_oidc_prompt.up.sql
+  drop view oidc_auth_method_with_value_obj;
+  -- oidc_auth_method_with_value_obj is useful for reading an oidc auth method
+  -- with its associated value objects (algs, auds, certs, claims scopes,
+  -- account claim maps and prompts) as columns with | delimited values.  The 
+  -- use of the postgres string_agg(...) to aggregate the value objects into a 
+  -- column works because we are only pulling in one column from the associated 
+  -- tables and that value is part of the primary key and unique.  This view 
+  -- will make things like recursive listing of oidc auth methods fairly 
+  -- straightforward to implement for the oidc repo. The view also includes an 
+  -- is_primary_auth_method bool 
+  create view oidc_auth_method_with_value_obj as 
+  select
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.state,
+    am.api_url,
+    am.disable_discovered_config_validation,
+    am.issuer,
+    am.client_id,
+    am.client_secret,
+    am.client_secret_hmac,
+    am.key_id,
+    am.max_age,
+    am.auth_token_time_to_live_seconds,
+    am.auth_token_time_to_stale_seconds,
+    -- the string_agg(..) column will be null if there are no associated value objects
+    string_agg(distinct alg.signing_alg_name, '|') as algs,
+    string_agg(distinct aud.aud_claim, '|') as auds,
+    string_agg(distinct cert.certificate, '|') as certs,
+    string_agg(distinct cs.scope, '|') as claims_scopes,
+    string_agg(distinct p.prompt, '|') as prompts,
+    string_agg(distinct concat_ws('=', acm.from_claim, acm.to_claim), '|') as account_claim_maps
+  from 	
+    auth_oidc_method am 
+    left outer join iam_scope                   s     on am.public_id = s.primary_auth_method_id 
+    left outer join auth_oidc_signing_alg       alg   on am.public_id = alg.oidc_method_id
+    left outer join auth_oidc_aud_claim         aud   on am.public_id = aud.oidc_method_id
+    left outer join auth_oidc_certificate       cert  on am.public_id = cert.oidc_method_id
+    left outer join auth_oidc_scope             cs    on am.public_id = cs.oidc_method_id
+    left outer join auth_oidc_account_claim_map acm   on am.public_id = acm.oidc_method_id
+    left outer join auth_oidc_prompt            p     on am.public_id = p.oidc_method_id
+  group by am.public_id, is_primary_auth_method; -- there can be only one public_id + is_primary_auth_method, so group by isn't a problem.
+  comment on view oidc_auth_method_with_value_obj is
+  'oidc auth method with its associated value objects (algs, auds, certs, scopes, prompts) as columns with | delimited values';
+
+  -- Replaces view from 76/01_ldap.up.sql
+  drop view ldap_auth_method_with_value_obj;
+  -- ldap_auth_method_with_value_obj is useful for reading an ldap auth method 
+  -- with its associated value objects (urls, certs, search config, etc). The use
+  -- of the postgres string_agg(...) to aggregate the url and cert value objects
+  -- into a column works because we are only pulling in one column from the
+  -- associated tables and that value is part of the primary key and unique.  This
+  -- view will make things like recursive listing of ldap auth methods fairly
+  -- straightforward to implement for the ldap repo.  The view also includes an
+  -- is_primary_auth_method bool
+  create view ldap_auth_method_with_value_obj as 
+  select 
+    case when s.primary_auth_method_id is not null then
+      true
+    else false end
+    as is_primary_auth_method,
+    am.public_id,
+    am.scope_id,
+    am.name,
+    am.description,
+    am.create_time,
+    am.update_time,
+    am.version,
+    am.state,
+    am.start_tls,
+    am.insecure_tls,
+    am.discover_dn,
+    am.anon_group_search,
+    am.upn_domain,
+    am.enable_groups,
+    am.use_token_groups,
+    am.maximum_page_size,
+    am.auth_token_time_to_live_seconds,
+    am.auth_token_time_to_stale_seconds,
+    -- the string_agg(..) column will be null if there are no associated value objects
+    string_agg(distinct url.url, '|') as urls,
+    string_agg(distinct cert.certificate, '|') as certs,
+    string_agg(distinct concat_ws('=', aam.from_attribute, aam.to_attribute), '|') as account_attribute_map,
+    
+    -- the rest of the fields are zero to one relationships that are stored in
+    -- related tables. Since we're outer joining with these tables, we need to
+    -- either add them to the group by, use an aggregating func, or handle
+    -- multiple rows returning for each auth method. I've chosen to just use
+    -- string_agg(...) 
+    string_agg(distinct uc.user_dn, '|') as user_dn, 
+    string_agg(distinct uc.user_attr, '|') as user_attr, 
+    string_agg(distinct uc.user_filter, '|') as user_filter, 
+    string_agg(distinct gc.group_dn, '|') as group_dn, 
+    string_agg(distinct gc.group_attr, '|') as group_attr, 
+    string_agg(distinct gc.group_filter, '|') as group_filter, 
+    string_agg(distinct cc.certificate_key, '|') as client_certificate_key, 
+    string_agg(distinct cc.certificate_key_hmac, '|') as client_certificate_key_hmac, 
+    string_agg(distinct cc.key_id, '|') as client_certificate_key_id, 
+    string_agg(distinct cc.certificate, '|') as client_certificate_cert,
+    string_agg(distinct bc.dn, '|') as bind_dn, 
+    string_agg(distinct bc.password, '|') as bind_password, 
+    string_agg(distinct bc.password_hmac, '|') as bind_password_hmac,
+    string_agg(distinct bc.key_id, '|') as bind_password_key_id,
+    string_agg(distinct df.dereference_aliases, '|') as dereference_aliases
+  from 	
+    auth_ldap_method am 
+    left outer join iam_scope                       s     on am.public_id = s.primary_auth_method_id 
+    left outer join auth_ldap_url                   url   on am.public_id = url.ldap_method_id
+    left outer join auth_ldap_certificate           cert  on am.public_id = cert.ldap_method_id
+    left outer join auth_ldap_account_attribute_map aam   on am.public_id = aam.ldap_method_id
+    left outer join auth_ldap_user_entry_search     uc    on am.public_id = uc.ldap_method_id
+    left outer join auth_ldap_group_entry_search    gc    on am.public_id = gc.ldap_method_id
+    left outer join auth_ldap_client_certificate    cc    on am.public_id = cc.ldap_method_id
+    left outer join auth_ldap_bind_credential       bc    on am.public_id = bc.ldap_method_id
+    left outer join auth_ldap_deref_aliases         df    on am.public_id = df.ldap_method_id
+  group by am.public_id, is_primary_auth_method; -- there can be only one public_id + is_primary_auth_method, so group by isn't a problem.
+  comment on view ldap_auth_method_with_value_obj is
+    'ldap auth method with its associated value objects (urls, certs, search config, etc)';
+
+  -- Adds the lifetimes of the token's auth method, or of its scope, to the
+  -- view used to read auth tokens, so validating a token doesn't need another
+  -- query. A zero value is returned if neither sets a lifetime.
+  -- Replaces view from 2/05_authtoken.up.sql
+  create or replace view auth_token_account as
+        select at.public_id,
+               at.token,
+               at.auth_account_id,
+               at.create_time,
+               at.update_time,
+               at.approximate_last_access_time,
+               at.expiration_time,
+               aa.scope_id,
+               aa.iam_user_id,
+               aa.auth_method_id,
+               at.status,
+               coalesce(am.auth_token_time_to_live_seconds,  s.auth_token_time_to_live_seconds,  0) as time_to_live_seconds,
+               coalesce(am.auth_token_time_to_stale_seconds, s.auth_token_time_to_stale_seconds, 0) as time_to_stale_seconds
+          from auth_token as at
+    inner join auth_account as aa
+            on at.auth_account_id = aa.public_id
+    inner join auth_method as am
+            on aa.auth_method_id = am.public_id
+    inner join iam_scope as s
+            on am.scope_id = s.public_id;
+
+commit;
@@ -0,0 +1,39 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- The usage of API rate limit quotas is shared by the controllers of a
+  -- cluster when the controllers are configured with api_rate_limit_shared.
+  -- Each controller periodically adds the requests it allowed to the usage of
+  -- the quotas, and reads back the usage of the whole cluster. The rows are
+  -- only needed until the window of a quota ends, and are deleted by the
+  -- controllers after that.
+  create table controller_api_rate_limit_quota (
+    quota_key text not null
+      constraint quota_key_must_not_be_empty
+        check (length(trim(quota_key)) > 0),
+    window_start timestamp with time zone not null,
+    expiration_time timestamp with time zone not null
+      constraint expiration_time_must_be_after_window_start
+        check (expiration_time > window_start),
+    used bigint not null default 0
+      constraint used_must_not_be_negative
+        check (used >= 0),
+    primary key (quota_key, window_start)
+  );
+  comment on table controller_api_rate_limit_quota is
+    'controller_api_rate_limit_quota is a table where each row represents the usage of an API rate limit quota by all controllers during a window.';
+  comment on column controller_api_rate_limit_quota.quota_key is
+    'A hash of the resource, action, limit type and client identifier of the quota.';
+  comment on column controller_api_rate_limit_quota.window_start is
+    'The start of the window of the quota, aligned to the period of its limit.';
+  comment on column controller_api_rate_limit_quota.expiration_time is
+    'The end of the window of the quota, after which the row can be deleted.';
+  comment on column controller_api_rate_limit_quota.used is
+    'The number of requests allowed by all controllers during the window.';
+
+  create index controller_api_rate_limit_quota_expiration_time_ix
+    on controller_api_rate_limit_quota (expiration_time);
+
+commit;
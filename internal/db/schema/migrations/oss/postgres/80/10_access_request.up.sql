@@ -0,0 +1,152 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table access_request_status_enm (
+    name text primary key
+      constraint only_predefined_access_request_statuses_allowed
+      check (
+        name in ('pending', 'approved', 'denied', 'canceled', 'expired')
+      )
+  );
+  comment on table access_request_status_enm is
+    'access_request_status_enm is an enumeration table for the status of access requests.';
+
+  insert into access_request_status_enm (name)
+  values
+    ('pending'),
+    ('approved'),
+    ('denied'),
+    ('canceled'),
+    ('expired');
+
+  create table access_request (
+    public_id wt_public_id primary key,
+    project_id wt_scope_id not null,
+    target_id wt_public_id not null,
+    user_id wt_user_id
+      constraint iam_user_fkey
+        references iam_user (public_id)
+        on delete cascade
+        on update cascade,
+    justification text not null
+      constraint justification_must_not_be_empty
+        check (length(trim(justification)) > 0),
+    duration_seconds integer not null
+      constraint duration_seconds_must_be_positive
+        check (duration_seconds > 0),
+    status text not null default 'pending'
+      constraint access_request_status_enm_fkey
+        references access_request_status_enm (name)
+        on delete restrict
+        on update cascade,
+    approver_id wt_public_id
+      constraint iam_user_approver_fkey
+        references iam_user (public_id)
+        on delete set null
+        on update cascade,
+    decision_comment text,
+    decision_time timestamp with time zone,
+    expiration_time timestamp with time zone,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    constraint target_fkey
+      foreign key (project_id, target_id)
+        references target (project_id, public_id)
+        on delete cascade
+        on update cascade,
+    constraint approver_must_not_be_requester
+      check (approver_id is null or approver_id <> user_id),
+    constraint decision_time_must_be_set_when_decided
+      check (status in ('pending', 'canceled') or decision_time is not null),
+    constraint expiration_time_must_be_after_decision_time
+      check (expiration_time is null or expiration_time > decision_time)
+  );
+  comment on table access_request is
+    'access_request is a table where each row is a resource that represents a request by a user for time-boxed access to a target. '
+    'Once the request is approved, the user is granted access to the target until the expiration time.';
+  comment on column access_request.duration_seconds is
+    'duration_seconds is the requested duration of the access. It starts when the request is approved.';
+  comment on column access_request.approver_id is
+    'approver_id is the id of the user who approved or denied the request.';
+
+  create index access_request_project_id_status_ix
+    on access_request (project_id, status);
+
+  create trigger update_version_column after update on access_request
+    for each row execute procedure update_version_column();
+
+  create trigger update_time_column before update on access_request
+    for each row execute procedure update_time_column();
+
+  create trigger default_create_time_column before insert on access_request
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on access_request
+    for each row execute procedure immutable_columns('public_id', 'project_id', 'target_id', 'user_id', 'justification', 'duration_seconds', 'create_time');
+
+  -- access_request_status_transition_valid() is a before update trigger
+  -- function which ensures the status of an access request only changes from
+  -- pending to approved, denied or canceled, and from approved to canceled or
+  -- expired.
+  create function access_request_status_transition_valid() returns trigger
+  as $$
+  begin
+    if new.status = old.status then
+      return new;
+    end if;
+    if (old.status = 'pending' and new.status in ('approved', 'denied', 'canceled')) or
+       (old.status = 'approved' and new.status in ('canceled', 'expired')) then
+      return new;
+    end if;
+    raise exception 'invalid access request status transition from % to %', old.status, new.status;
+  end;
+  $$ language plpgsql;
+
+  create trigger access_request_status_transition_valid before update of status on access_request
+    for each row execute procedure access_request_status_transition_valid();
+
+  -- allow operations on access requests to be oplogged
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('access_request', 1);
+
+  create table access_request_grant (
+    access_request_id wt_public_id primary key
+      constraint access_request_fkey
+        references access_request (public_id)
+        on delete cascade
+        on update cascade,
+    user_id wt_user_id
+      constraint iam_user_fkey
+        references iam_user (public_id)
+        on delete cascade
+        on update cascade,
+    project_id wt_scope_id not null
+      constraint iam_scope_fkey
+        references iam_scope (public_id)
+        on delete cascade
+        on update cascade,
+    canonical_grant text not null
+      constraint canonical_grant_must_not_be_empty
+        check (length(trim(canonical_grant)) > 0),
+    expiration_time timestamp with time zone not null,
+    create_time wt_timestamp
+  );
+  comment on table access_request_grant is
+    'access_request_grant is a table where each row is a temporary grant materialized for the user of an approved access request. '
+    'The grant is only effective until its expiration time, and it is deleted when the access request expires or is canceled.';
+
+  create index access_request_grant_user_id_expiration_time_ix
+    on access_request_grant (user_id, expiration_time);
+
+  create trigger default_create_time_column before insert on access_request_grant
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on access_request_grant
+    for each row execute procedure immutable_columns('access_request_id', 'user_id', 'project_id', 'canonical_grant', 'expiration_time', 'create_time');
+
+commit;
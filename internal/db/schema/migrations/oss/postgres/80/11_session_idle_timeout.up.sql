@@ -0,0 +1,125 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- A value of 0 disables the idle timeout.
+  alter table target_tcp
+    add column session_idle_timeout_seconds integer not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+        check (session_idle_timeout_seconds >= 0);
+  comment on column target_tcp.session_idle_timeout_seconds is
+    'session_idle_timeout_seconds is the number of seconds a connection of a session to the target can carry no traffic before it is closed, or 0 for no idle timeout.';
+
+  alter table target_ssh
+    add column session_idle_timeout_seconds integer not null default 0
+      constraint session_idle_timeout_seconds_must_not_be_negative
+        check (session_idle_timeout_seconds >= 0);
+  comment on column target_ssh.session_idle_timeout_seconds is
+    'session_idle_timeout_seconds is the number of seconds a connection of a session to the target can carry no traffic before it is closed, or 0 for no idle timeout.';
+
+  -- Replaces target_all_subtypes defined in 80/05_target_ssh_recording_compression.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    null::text as recording_compression,
+    null::integer as recording_compression_level,
+    session_idle_timeout_seconds
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    recording_compression,
+    recording_compression_level,
+    session_idle_timeout_seconds
+  from
+    target_ssh;
+
+  -- The idle timeout of the target is copied to the session when the session
+  -- is authorized, like the connection limit.
+  alter table session
+    add column idle_timeout_seconds integer not null default 0
+      constraint idle_timeout_seconds_must_not_be_negative
+        check (idle_timeout_seconds >= 0);
+  comment on column session.idle_timeout_seconds is
+    'idle_timeout_seconds is the number of seconds a connection of the session can carry no traffic before the worker closes it, or 0 for no idle timeout.';
+
+  -- Replaces trigger from 59/01_target_ingress_egress_worker_filters.up.sql
+  drop trigger immutable_columns on session;
+  create trigger immutable_columns before update on session
+    for each row execute procedure immutable_columns('public_id', 'certificate', 'expiration_time', 'connection_limit',
+      'create_time', 'endpoint', 'worker_filter', 'egress_worker_filter', 'ingress_worker_filter', 'idle_timeout_seconds');
+
+  -- Updates the constraint from 0/51_connection.up.sql
+  alter table session_connection_closed_reason_enm
+    drop constraint only_predefined_session_connection_closed_reasons_allowed,
+    add constraint only_predefined_session_connection_closed_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'canceled',
+          'network error',
+          'system error',
+          'idle timeout'
+        )
+      );
+  insert into session_connection_closed_reason_enm (name)
+  values
+    ('idle timeout');
+
+  -- Updates the constraint from 0/50_session.up.sql
+  alter table session_termination_reason_enm
+    drop constraint only_predefined_session_termination_reasons_allowed,
+    add constraint only_predefined_session_termination_reasons_allowed
+      check (
+        name in (
+          'unknown',
+          'timed out',
+          'closed by end-user',
+          'terminated',
+          'network error',
+          'system error',
+          'connection limit',
+          'canceled',
+          'idle timeout'
+        )
+      );
+  insert into session_termination_reason_enm (name)
+  values
+    ('idle timeout');
+
+commit;
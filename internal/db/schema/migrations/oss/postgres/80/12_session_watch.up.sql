@@ -0,0 +1,77 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  create table session_watch (
+    public_id wt_public_id primary key,
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    user_id wt_user_id
+      constraint iam_user_fkey
+        references iam_user (public_id)
+        on delete cascade
+        on update cascade,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+  comment on table session_watch is
+    'session_watch is a table where each row represents a user watching the live output of a session. '
+    'The update time of a watch is set each time the user polls the output of the session, and the workers '
+    'only forward the output of sessions with a watch which was polled recently.';
+  comment on column session_watch.update_time is
+    'The last time the output of the session was polled by the watch. It is set explicitly by the controllers.';
+
+  create index session_watch_session_id_update_time_ix
+    on session_watch (session_id, update_time);
+
+  create trigger default_create_time_column before insert on session_watch
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_watch
+    for each row execute procedure immutable_columns('public_id', 'session_id', 'user_id', 'create_time');
+
+  -- session_watch_output holds the output of watched sessions forwarded by
+  -- the workers, so that it can be read by the controller serving the watch.
+  -- The output is only needed until it has been polled by the watchers, and
+  -- rows are deleted by a scheduled job shortly after they are created.
+  create table session_watch_output (
+    position bigint generated always as identity primary key,
+    session_id wt_public_id not null
+      constraint session_fkey
+        references session (public_id)
+        on delete cascade
+        on update cascade,
+    data bytea not null
+      constraint data_must_not_be_empty
+        check (length(data) > 0),
+    key_id kms_private_id not null
+      constraint kms_data_key_version_fkey
+        references kms_data_key_version (private_id)
+        on delete restrict
+        on update cascade,
+    create_time wt_timestamp
+  );
+  comment on table session_watch_output is
+    'session_watch_output is a table where each row is a sequence of BSR data chunks of the output of a watched session.';
+  comment on column session_watch_output.position is
+    'The position of the output in the stream of output of all watched sessions, used by watchers to poll for new output.';
+  comment on column session_watch_output.data is
+    'The output encrypted with the sessions key of the project of the session.';
+
+  create index session_watch_output_session_id_position_ix
+    on session_watch_output (session_id, position);
+
+  create index session_watch_output_create_time_ix
+    on session_watch_output (create_time);
+
+  create trigger default_create_time_column before insert on session_watch_output
+    for each row execute procedure default_create_time();
+
+  create trigger immutable_columns before update on session_watch_output
+    for each row execute procedure immutable_columns('position', 'session_id', 'create_time');
+
+commit;
@@ -0,0 +1,66 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+
+  -- Workers only connect to the hosts of an ssh target which present one of
+  -- these keys. Connections to targets without host keys are refused.
+  alter table target_ssh
+    add column host_keys text null
+      constraint host_keys_must_not_be_empty
+        check (length(trim(host_keys)) > 0);
+  comment on column target_ssh.host_keys is
+    'host_keys are the public keys of the hosts of the target in the OpenSSH authorized_keys format, one per line.';
+
+  -- Replaces target_all_subtypes defined in 80/11_session_idle_timeout.up.sql
+  create or replace view target_all_subtypes as
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    null as storage_bucket_id,
+    false as enable_session_recording,
+    'tcp' as type,
+    null::text as recording_compression,
+    null::integer as recording_compression_level,
+    session_idle_timeout_seconds,
+    null::text as host_keys
+  from target_tcp
+  union
+  select
+    public_id,
+    project_id,
+    name,
+    description,
+    default_port,
+    session_max_seconds,
+    session_connection_limit,
+    version,
+    create_time,
+    update_time,
+    worker_filter,
+    egress_worker_filter,
+    ingress_worker_filter,
+    default_client_port,
+    storage_bucket_id,
+    enable_session_recording,
+    'ssh' as type,
+    recording_compression,
+    recording_compression_level,
+    session_idle_timeout_seconds,
+    host_keys
+  from
+    target_ssh;
+
+commit;
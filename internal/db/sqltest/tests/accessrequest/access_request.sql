@@ -0,0 +1,82 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(13);
+
+  insert into access_request
+    (project_id,     public_id,      target_id,      user_id,        justification, duration_seconds)
+  values
+    ('p____bcolors', 'areq______b1', 't_________cb', 'u______cindy', 'incident 1', 3600),
+    ('p____bcolors', 'areq______b2', 't_________cb', 'u______cindy', 'incident 2', 3600);
+
+  select is(status, 'pending') from access_request where public_id = 'areq______b1';
+
+  -- the target must be in the project of the access request
+  prepare insert_wrong_project as
+    insert into access_request (project_id, public_id, target_id, user_id, justification, duration_seconds)
+    values ('p____rcolors', 'areq______r1', 't_________cb', 'u______cindy', 'incident', 3600);
+  select throws_ok('insert_wrong_project', '23503');
+
+  prepare insert_empty_justification as
+    insert into access_request (project_id, public_id, target_id, user_id, justification, duration_seconds)
+    values ('p____bcolors', 'areq______b3', 't_________cb', 'u______cindy', ' ', 3600);
+  select throws_ok('insert_empty_justification', '23514');
+
+  prepare insert_zero_duration as
+    insert into access_request (project_id, public_id, target_id, user_id, justification, duration_seconds)
+    values ('p____bcolors', 'areq______b4', 't_________cb', 'u______cindy', 'incident', 0);
+  select throws_ok('insert_zero_duration', '23514');
+
+  -- requesters cannot decide on their own access requests
+  prepare update_self_approve as
+    update access_request
+       set status = 'approved', approver_id = 'u______cindy', decision_time = now(), expiration_time = now() + interval '1 hour'
+     where public_id = 'areq______b1';
+  select throws_ok('update_self_approve', '23514');
+
+  -- the requested access cannot be changed
+  prepare update_duration as
+    update access_request set duration_seconds = 60 where public_id = 'areq______b1';
+  select throws_ok('update_duration', '23601');
+
+  update access_request
+     set status = 'approved', approver_id = 'u______clare', decision_time = now(), expiration_time = now() + interval '1 hour'
+   where public_id = 'areq______b1';
+  select is(version, 2) from access_request where public_id = 'areq______b1';
+
+  -- approved access requests cannot go back to pending or be denied
+  prepare update_pending as
+    update access_request set status = 'pending' where public_id = 'areq______b1';
+  select throws_ok('update_pending', 'P0001');
+  prepare update_denied as
+    update access_request set status = 'denied' where public_id = 'areq______b1';
+  select throws_ok('update_denied', 'P0001');
+
+  -- denied access requests are final
+  update access_request
+     set status = 'denied', approver_id = 'u______clare', decision_time = now()
+   where public_id = 'areq______b2';
+  prepare update_canceled as
+    update access_request set status = 'canceled' where public_id = 'areq______b2';
+  select throws_ok('update_canceled', 'P0001');
+
+  -- the grant of an approved access request cannot be extended
+  insert into access_request_grant
+    (access_request_id, user_id,        project_id,     canonical_grant,                                   expiration_time)
+  values
+    ('areq______b1',    'u______cindy', 'p____bcolors', 'ids=t_________cb;actions=read,authorize-session', now() + interval '1 hour');
+  prepare update_grant_expiration as
+    update access_request_grant set expiration_time = now() + interval '1 day' where access_request_id = 'areq______b1';
+  select throws_ok('update_grant_expiration', '23601');
+
+  -- deleting the access request deletes its grant
+  delete from access_request where public_id = 'areq______b1';
+  select is(count(*), 0::bigint) from access_request_grant where access_request_id = 'areq______b1';
+
+  -- deleting the target deletes its access requests
+  delete from target where public_id = 't_________cb';
+  select is(count(*), 0::bigint) from access_request where target_id = 't_________cb';
+
+  select * from finish();
+rollback;
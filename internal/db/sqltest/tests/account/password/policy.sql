@@ -0,0 +1,47 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(9);
+
+  -- the policy columns are null by default
+  select is(lockout_threshold, null::integer) from auth_password_method_with_is_primary where public_id = 'apm___colors';
+
+  prepare set_policy as
+    update auth_password_method
+       set min_password_character_classes = 3,
+           password_history_depth         = 5,
+           max_password_age_days          = 90,
+           lockout_threshold              = 5,
+           lockout_duration_seconds       = 900
+     where public_id = 'apm___colors';
+  select lives_ok('set_policy');
+
+  select is(lockout_threshold,              5)   from auth_password_method_with_is_primary where public_id = 'apm___colors';
+  select is(min_password_character_classes, 3)   from auth_password_current_conf           where password_method_id = 'apm___colors';
+
+  prepare invalid_character_classes as
+    update auth_password_method set min_password_character_classes = 5 where public_id = 'apm___colors';
+  select throws_ok('invalid_character_classes', '23514');
+
+  prepare invalid_lockout_threshold as
+    update auth_password_method set lockout_threshold = 0 where public_id = 'apm___colors';
+  select throws_ok('invalid_lockout_threshold', '23514');
+
+  prepare insert_lockout as
+    insert into auth_password_account_lockout
+      (password_account_id, failed_attempts, locked_until)
+    values
+      ('apa____clare', 0, now() + interval '15 minutes');
+  select lives_ok('insert_lockout');
+
+  prepare invalid_failed_attempts as
+    update auth_password_account_lockout set failed_attempts = -1 where password_account_id = 'apa____clare';
+  select throws_ok('invalid_failed_attempts', '23514');
+
+  -- the lockout state is deleted with the account
+  delete from auth_password_account where public_id = 'apa____clare';
+  select is(count(*), 0::bigint) from auth_password_account_lockout where password_account_id = 'apa____clare';
+
+  select * from finish();
+rollback;
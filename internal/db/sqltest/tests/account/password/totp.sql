@@ -0,0 +1,52 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(8);
+
+  prepare insert_totp as
+    insert into auth_password_totp
+      (password_account_id, secret, key_id)
+    values
+      ('apa____clare', 'secret'::bytea, 'kdkv__colors');
+  select lives_ok('insert_totp');
+
+  prepare invalid_last_time_step as
+    update auth_password_totp set last_time_step = 0 where password_account_id = 'apa____clare';
+  select throws_ok('invalid_last_time_step', '23514');
+
+  prepare immutable_create_time as
+    update auth_password_totp set create_time = now() - interval '1 day' where password_account_id = 'apa____clare';
+  select throws_ok('immutable_create_time', '23601');
+
+  prepare insert_challenge as
+    insert into auth_password_totp_challenge
+      (private_id, password_account_id, expiration_time)
+    values
+      ('totpch__clare', 'apa____clare', now() + interval '5 minutes');
+  select lives_ok('insert_challenge');
+
+  prepare insert_expired_challenge as
+    insert into auth_password_totp_challenge
+      (private_id, password_account_id, expiration_time)
+    values
+      ('totpch__cindy', 'apa____clare', now() - interval '5 minutes');
+  select throws_ok('insert_expired_challenge', '23514');
+
+  prepare immutable_expiration_time as
+    update auth_password_totp_challenge set expiration_time = now() + interval '1 day' where private_id = 'totpch__clare';
+  select throws_ok('immutable_expiration_time', '23601');
+
+  prepare challenge_without_totp as
+    insert into auth_password_totp_challenge
+      (private_id, password_account_id, expiration_time)
+    values
+      ('totpch__cindy', 'apa____cindy', now() + interval '5 minutes');
+  select throws_ok('challenge_without_totp', '23503');
+
+  -- deleting the totp deletes its challenges
+  delete from auth_password_totp where password_account_id = 'apa____clare';
+  select is(count(*), 0::bigint) from auth_password_totp_challenge where password_account_id = 'apa____clare';
+
+  select * from finish();
+rollback;
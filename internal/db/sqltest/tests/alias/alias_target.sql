@@ -0,0 +1,67 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(13);
+
+  insert into alias_target
+    (scope_id, public_id,      value,                destination_id, host_id)
+  values
+    ('global', 'alt_______b1', 'blue.color.target',  't_________cb', 'h___st____b1'),
+    ('global', 'alt_______r1', 'red.color.target',   't_________cr', null);
+
+  select is(count(*), 2::bigint) from alias where public_id in ('alt_______b1', 'alt_______r1');
+
+  -- alias values must be dns-like and unique across all aliases
+  prepare insert_upper as
+    insert into alias_target (scope_id, public_id, value)
+    values ('global', 'alt_______u1', 'Blue.Color.Target');
+  select throws_ok('insert_upper', '23514');
+
+  prepare insert_invalid as
+    insert into alias_target (scope_id, public_id, value)
+    values ('global', 'alt_______i1', 'blue_color');
+  select throws_ok('insert_invalid', '23514');
+
+  prepare insert_duplicate as
+    insert into alias_target (scope_id, public_id, value)
+    values ('global', 'alt_______d1', 'blue.color.target');
+  select throws_ok('insert_duplicate', '23505');
+
+  -- aliases can only be created in the global scope
+  prepare insert_org as
+    insert into alias_target (scope_id, public_id, value)
+    values ('o_____colors', 'alt_______o1', 'org.color.target');
+  select throws_ok('insert_org', '23503');
+
+  -- a host id requires a destination id
+  prepare insert_host_only as
+    insert into alias_target (scope_id, public_id, value, host_id)
+    values ('global', 'alt_______h1', 'host.color.target', 'h___st____b1');
+  select throws_ok('insert_host_only', '23514');
+
+  -- updating the value of the subtype updates the base table
+  update alias_target set value = 'red.colors.target' where public_id = 'alt_______r1';
+  select is(value, 'red.colors.target'::wt_alias) from alias where public_id = 'alt_______r1';
+
+  -- removing the destination clears the host id
+  update alias_target set destination_id = null where public_id = 'alt_______b1';
+  select is(host_id, null) from alias_target where public_id = 'alt_______b1';
+
+  update alias_target
+     set destination_id = 't_________cb', host_id = 'h___st____b1'
+   where public_id = 'alt_______b1';
+  select is(host_id, 'h___st____b1') from alias_target where public_id = 'alt_______b1';
+
+  -- deleting the target clears the destination and the host id
+  delete from target where public_id = 't_________cb';
+  select is(destination_id, null) from alias_target where public_id = 'alt_______b1';
+  select is(host_id, null) from alias_target where public_id = 'alt_______b1';
+
+  -- deleting the subtype deletes the base table row
+  delete from alias_target where public_id = 'alt_______b1';
+  select is(count(*), 0::bigint) from alias where public_id = 'alt_______b1';
+  select is(count(*), 1::bigint) from alias where public_id = 'alt_______r1';
+
+  select * from finish();
+rollback;
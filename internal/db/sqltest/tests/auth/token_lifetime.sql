@@ -0,0 +1,45 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(13);
+  select wtt_load('widgets', 'iam', 'kms', 'auth');
+
+  -- the lifetimes are null by default
+  select is(auth_token_time_to_live_seconds, null::integer) from iam_scope   where public_id = 'o_____widget';
+  select is(auth_token_time_to_live_seconds, null::integer) from auth_method where public_id = 'apm___widget';
+
+  prepare set_scope_lifetimes as
+    update iam_scope
+       set auth_token_time_to_live_seconds  = 3600,
+           auth_token_time_to_stale_seconds = 600
+     where public_id = 'o_____widget';
+  select lives_ok('set_scope_lifetimes');
+
+  prepare invalid_scope_lifetime as
+    update iam_scope set auth_token_time_to_live_seconds = 0 where public_id = 'o_____widget';
+  select throws_ok('invalid_scope_lifetime', '23514');
+
+  -- the lifetimes of the sub types are copied to the base type
+  update auth_password_method set auth_token_time_to_live_seconds = 60 where public_id = 'apm___widget';
+  select is(auth_token_time_to_live_seconds, 60) from auth_method where public_id = 'apm___widget';
+  update auth_oidc_method set auth_token_time_to_stale_seconds = 30 where public_id = 'aom___widget';
+  select is(auth_token_time_to_stale_seconds, 30) from auth_method where public_id = 'aom___widget';
+  update auth_ldap_method set auth_token_time_to_live_seconds = 120 where public_id = 'alm___widget';
+  select is(auth_token_time_to_live_seconds, 120) from auth_method where public_id = 'alm___widget';
+
+  update auth_password_method set auth_token_time_to_live_seconds = null where public_id = 'apm___widget';
+  select is(auth_token_time_to_live_seconds, null::integer) from auth_method where public_id = 'apm___widget';
+
+  -- the lifetimes are included in the views used to read the auth methods
+  select is(auth_token_time_to_stale_seconds, 30) from oidc_auth_method_with_value_obj where public_id = 'aom___widget';
+
+  -- the auth token view resolves the lifetimes of the token's auth method,
+  -- falling back to its scope
+  select is(time_to_live_seconds,  3600) from auth_token_account where public_id = 'tok___walter';
+  select is(time_to_stale_seconds, 600)  from auth_token_account where public_id = 'tok___walter';
+  select is(time_to_stale_seconds, 30)   from auth_token_account where public_id = 'oidc__walter';
+  select is(time_to_live_seconds,  120)  from auth_token_account where public_id = 'ldap__walter';
+
+  select * from finish();
+rollback;
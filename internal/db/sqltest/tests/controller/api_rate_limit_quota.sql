@@ -0,0 +1,50 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+-- api_rate_limit_quota tests:
+--  validates the controller_api_rate_limit_quota table
+
+begin;
+  select plan(8);
+
+  select has_table('controller_api_rate_limit_quota');
+  select has_index('controller_api_rate_limit_quota', 'controller_api_rate_limit_quota_expiration_time_ix', 'expiration_time');
+
+  prepare empty_key as
+    insert into controller_api_rate_limit_quota (quota_key, window_start, expiration_time, used)
+    values ('', now(), now() + interval '1 minute', 1);
+  select throws_like(
+    'empty_key',
+    '%"quota_key_must_not_be_empty"',
+    'We should error for an empty quota key'
+  );
+
+  prepare invalid_expiration_time as
+    insert into controller_api_rate_limit_quota (quota_key, window_start, expiration_time, used)
+    values ('key', now(), now(), 1);
+  select throws_like(
+    'invalid_expiration_time',
+    '%"expiration_time_must_be_after_window_start"',
+    'We should error for an expiration time which is not after the window start'
+  );
+
+  prepare negative_used as
+    insert into controller_api_rate_limit_quota (quota_key, window_start, expiration_time, used)
+    values ('key', now(), now() + interval '1 minute', -1);
+  select throws_like(
+    'negative_used',
+    '%"used_must_not_be_negative"',
+    'We should error for a negative usage'
+  );
+
+  prepare add_usage as
+    insert into controller_api_rate_limit_quota (quota_key, window_start, expiration_time, used)
+    values ('key', '2024-01-01 00:00:00+00', '2024-01-01 00:01:00+00', 2)
+    on conflict (quota_key, window_start) do update
+       set used = controller_api_rate_limit_quota.used + excluded.used;
+  select lives_ok('add_usage');
+  select lives_ok('add_usage');
+  select is(used, 4::bigint) from controller_api_rate_limit_quota where quota_key = 'key';
+
+  select * from finish();
+rollback;
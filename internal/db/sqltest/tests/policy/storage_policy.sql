@@ -0,0 +1,65 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(12);
+
+  -- recordings have no storage policy until one is attached to a scope
+  select is(count(*), 2::bigint)
+    from recording_session_storage_policy
+   where public_id in ('sr1____clare', 'sr1_____cora')
+     and storage_policy_id is null
+     and delete_after_days = 0
+     and legal_hold = false;
+
+  insert into policy_storage_policy
+    (scope_id, public_id,      retain_for_days, delete_after_days)
+  values
+    ('global', 'pst____global', 7,              30);
+
+  -- recordings in org storage buckets inherit the global storage policy
+  select is(storage_policy_id, 'pst____global') from recording_session_storage_policy where public_id = 'sr1____clare';
+  select is(storage_policy_id, 'pst____global') from recording_session_storage_policy where public_id = 'sr1_____cora';
+
+  insert into policy_storage_policy
+    (scope_id,       public_id,      retain_for_days, delete_after_days, legal_hold)
+  values
+    ('o_____colors', 'pst____colors', -1,             0,                 true);
+
+  -- the storage policy of the storage bucket's scope takes precedence
+  select is(storage_policy_id, 'pst____global') from recording_session_storage_policy where public_id = 'sr1____clare';
+  select is(storage_policy_id, 'pst____colors') from recording_session_storage_policy where public_id = 'sr1_____cora';
+  select is(legal_hold, true) from recording_session_storage_policy where public_id = 'sr1_____cora';
+
+  -- a scope can only have one storage policy
+  prepare insert_duplicate as
+    insert into policy_storage_policy (scope_id, public_id)
+    values ('global', 'pst_______d1');
+  select throws_ok('insert_duplicate', '23505');
+
+  -- storage policies cannot be attached to projects
+  prepare insert_project as
+    insert into policy_storage_policy (scope_id, public_id)
+    values ('p____bcolors', 'pst_______p1');
+  select throws_ok('insert_project', 'P0001');
+
+  -- recordings cannot be deleted before they have been retained
+  prepare insert_delete_before_retain as
+    insert into policy_storage_policy (scope_id, public_id, retain_for_days, delete_after_days)
+    values ('o_____colors', 'pst_______r1', 30, 7);
+  select throws_ok('insert_delete_before_retain', '23514');
+
+  prepare update_delete_forever as
+    update policy_storage_policy set delete_after_days = 30 where public_id = 'pst____colors';
+  select throws_ok('update_delete_forever', '23514');
+
+  prepare update_negative as
+    update policy_storage_policy set retain_for_days = -2 where public_id = 'pst____global';
+  select throws_ok('update_negative', '23514');
+
+  -- deleting a storage policy falls back to the global storage policy
+  delete from policy_storage_policy where public_id = 'pst____colors';
+  select is(storage_policy_id, 'pst____global') from recording_session_storage_policy where public_id = 'sr1_____cora';
+
+  select * from finish();
+rollback;
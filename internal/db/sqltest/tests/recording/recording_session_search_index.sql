@@ -0,0 +1,49 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(7);
+
+  -- failed indexes must have error details
+  prepare insert_failed_without_details as
+    insert into recording_session_search_index (recording_session_id, state)
+    values ('sr1____clare', 'failed');
+  select throws_ok('insert_failed_without_details', '23514');
+
+  -- indexed recordings must not have error details
+  prepare insert_indexed_with_details as
+    insert into recording_session_search_index (recording_session_id, state, error_details)
+    values ('sr1____clare', 'indexed', 'error');
+  select throws_ok('insert_indexed_with_details', '23514');
+
+  -- only predefined states are allowed
+  prepare insert_invalid_state as
+    insert into recording_session_search_index (recording_session_id, state)
+    values ('sr1____clare', 'pending');
+  select throws_ok('insert_invalid_state', '23503');
+
+  insert into recording_session_search_index
+    (recording_session_id, state)
+  values
+    ('sr1____clare', 'indexed');
+  select is(count(*), 1::bigint) from recording_session_search_index where recording_session_id = 'sr1____clare' and state = 'indexed';
+
+  insert into recording_session_search_index
+    (recording_session_id, state,    error_details)
+  values
+    ('sr1_____cora',       'failed', 'unable to open session recording');
+  select is(error_details, 'unable to open session recording') from recording_session_search_index where recording_session_id = 'sr1_____cora';
+
+  -- the recording session id is immutable
+  prepare update_recording_session_id as
+    update recording_session_search_index
+       set recording_session_id = 'sr1_____cora'
+     where recording_session_id = 'sr1____clare';
+  select throws_ok('update_recording_session_id');
+
+  -- the index row is deleted with the session recording
+  delete from recording_session where public_id = 'sr1____clare';
+  select is(count(*), 0::bigint) from recording_session_search_index where recording_session_id = 'sr1____clare';
+
+  select * from finish();
+rollback;
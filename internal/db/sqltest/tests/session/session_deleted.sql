@@ -0,0 +1,14 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(3);
+
+  select is(count(*), 0::bigint) from session_deleted where public_id = 's1_____cindy';
+
+  delete from session where public_id = 's1_____cindy';
+  select is(count(*), 1::bigint) from session_deleted where public_id = 's1_____cindy';
+  select is(delete_time, now()) from session_deleted where public_id = 's1_____cindy';
+
+  select * from finish();
+rollback;
@@ -0,0 +1,28 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(8);
+
+  select is(session_idle_timeout_seconds, 0) from target_all_subtypes where public_id = 't_________cb';
+
+  prepare set_idle_timeout as
+    update target_tcp set session_idle_timeout_seconds = 300 where public_id = 't_________cb';
+  select lives_ok('set_idle_timeout');
+  select is(session_idle_timeout_seconds, 300) from target_all_subtypes where public_id = 't_________cb';
+
+  prepare negative_idle_timeout as
+    update target_tcp set session_idle_timeout_seconds = -1 where public_id = 't_________cb';
+  select throws_ok('negative_idle_timeout', '23514', null, 'negative idle timeout');
+
+  select is(idle_timeout_seconds, 0) from session where public_id = 's1_____cindy';
+
+  prepare immutable_idle_timeout as
+    update session set idle_timeout_seconds = 60 where public_id = 's1_____cindy';
+  select throws_ok('immutable_idle_timeout', '23601');
+
+  select is(count(*), 1::bigint) from session_connection_closed_reason_enm where name = 'idle timeout';
+  select is(count(*), 1::bigint) from session_termination_reason_enm where name = 'idle timeout';
+
+  select * from finish();
+rollback;
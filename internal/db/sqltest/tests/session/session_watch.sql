@@ -0,0 +1,57 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+-- session_watch tests:
+--  validates the session_watch and session_watch_output tables
+
+begin;
+  select plan(11);
+
+  select has_table('session_watch');
+  select has_table('session_watch_output');
+
+  prepare insert_watch as
+    insert into session_watch (public_id, session_id, user_id)
+    values ('sw_____clare', 's2_____carly', 'u______clare');
+  select lives_ok('insert_watch');
+
+  prepare immutable_watch_session as
+    update session_watch set session_id = 's1_____carly' where public_id = 'sw_____clare';
+  select throws_ok('immutable_watch_session', '23601');
+
+  prepare insert_output as
+    insert into session_watch_output (session_id, data, key_id)
+    values ('s2_____carly', 'output'::bytea, 'kdkv__colors');
+  select lives_ok('insert_output');
+
+  prepare empty_output as
+    insert into session_watch_output (session_id, data, key_id)
+    values ('s2_____carly', ''::bytea, 'kdkv__colors');
+  select throws_like(
+    'empty_output',
+    '%"data_must_not_be_empty"',
+    'We should error for empty output'
+  );
+
+  -- the output can be rewrapped
+  prepare rewrap_output as
+    update session_watch_output set data = 'rewrapped'::bytea where session_id = 's2_____carly';
+  select lives_ok('rewrap_output');
+
+  prepare immutable_output as
+    update session_watch_output set session_id = 's1_____carly' where session_id = 's2_____carly';
+  select throws_ok('immutable_output', '23601');
+
+  -- deleting the session deletes its watches and output
+  delete from session where public_id = 's2_____carly';
+  select is(count(*), 0::bigint) from session_watch where session_id = 's2_____carly';
+  select is(count(*), 0::bigint) from session_watch_output where session_id = 's2_____carly';
+
+  -- deleting the user deletes their watches
+  insert into session_watch (public_id, session_id, user_id)
+  values ('sw_____cora', 's1_____carly', 'u_______cora');
+  delete from iam_user where public_id = 'u_______cora';
+  select is(count(*), 0::bigint) from session_watch where public_id = 'sw_____cora';
+
+  select * from finish();
+rollback;
@@ -0,0 +1,14 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(3);
+
+  select is(count(*), 0::bigint) from target_deleted where public_id = 't_________cb';
+
+  delete from target where public_id = 't_________cb';
+  select is(count(*), 1::bigint) from target_deleted where public_id = 't_________cb';
+  select is(delete_time, now()) from target_deleted where public_id = 't_________cb';
+
+  select * from finish();
+rollback;
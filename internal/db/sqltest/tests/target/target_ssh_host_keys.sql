@@ -0,0 +1,24 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(4);
+
+  select wtt_load('widgets', 'iam', 'kms', 'auth', 'hosts', 'targets');
+
+  insert into target_ssh
+    (project_id, public_id, name, host_keys)
+  values
+    ('p____bwidget', 'tssh______wb', 'Big Widget SSH Target', 'ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII4/60BKOPLxmKVsPQngvO7sS2NP34EemdH4CKFvTPzU'),
+    ('p____swidget', 'tssh______ws', 'Small Widget SSH Target', null);
+
+  select is(host_keys, 'ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII4/60BKOPLxmKVsPQngvO7sS2NP34EemdH4CKFvTPzU') from target_all_subtypes where public_id = 'tssh______wb';
+  select is(host_keys, null::text) from target_all_subtypes where public_id = 'tssh______ws';
+  select is(host_keys, null::text) from target_all_subtypes where public_id = 't_________wb';
+
+  prepare empty_host_keys as
+    update target_ssh set host_keys = ' ' where public_id = 'tssh______ws';
+  select throws_ok('empty_host_keys', '23514', null, 'empty host keys');
+
+  select * from finish();
+rollback;
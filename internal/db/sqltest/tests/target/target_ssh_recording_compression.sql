@@ -0,0 +1,37 @@
+-- Copyright (c) HashiCorp, Inc.
+-- SPDX-License-Identifier: BUSL-1.1
+
+begin;
+  select plan(8);
+
+  select wtt_load('widgets', 'iam', 'kms', 'auth', 'hosts', 'targets');
+
+  insert into target_ssh
+    (project_id, public_id, name, recording_compression, recording_compression_level)
+  values
+    ('p____bwidget', 'tssh______wb', 'Big Widget SSH Target', 'zstd', 3),
+    ('p____swidget', 'tssh______ws', 'Small Widget SSH Target', null, null);
+
+  select is(recording_compression,       'zstd') from target_all_subtypes where public_id = 'tssh______wb';
+  select is(recording_compression_level, 3)      from target_all_subtypes where public_id = 'tssh______wb';
+  select is(recording_compression,       null::text) from target_all_subtypes where public_id = 'tssh______ws';
+  select is(recording_compression,       null::text) from target_all_subtypes where public_id = 't_________wb';
+
+  prepare invalid_compression as
+    update target_ssh set recording_compression = 'lz4' where public_id = 'tssh______ws';
+  select throws_ok('invalid_compression', '23514', null, 'unknown compression');
+
+  prepare invalid_gzip_level as
+    update target_ssh set recording_compression = 'gzip', recording_compression_level = 10 where public_id = 'tssh______ws';
+  select throws_ok('invalid_gzip_level', '23514', null, 'gzip level out of range');
+
+  prepare level_without_compression as
+    update target_ssh set recording_compression = 'none', recording_compression_level = 1 where public_id = 'tssh______ws';
+  select throws_ok('level_without_compression', '23514', null, 'level without compression');
+
+  prepare valid_gzip_level as
+    update target_ssh set recording_compression = 'gzip', recording_compression_level = 9 where public_id = 'tssh______ws';
+  select lives_ok('valid_gzip_level', 'gzip level in range');
+
+  select * from finish();
+rollback;
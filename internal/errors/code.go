@@ -85,6 +85,26 @@ const (
 	// new passwords are equal.
 	PasswordsEqual Code = 203
 
+	// AccountLocked results from attempting to authenticate with an account
+	// which is locked after too many failed authentication attempts.
+	AccountLocked Code = 204
+
+	// PasswordTooSimple results from attempting to set a password which does
+	// not contain enough character classes.
+	PasswordTooSimple Code = 205
+
+	// PasswordReused results from attempting to set a password which equals
+	// one of the recent passwords of an account.
+	PasswordReused Code = 206
+
+	// PasswordExpired results from attempting to authenticate with a
+	// password which is older than the maximum password age.
+	PasswordExpired Code = 207
+
+	// InvalidTotpCode results from attempting to verify or complete an
+	// authentication with an invalid or reused TOTP code.
+	InvalidTotpCode Code = 208
+
 	Encrypt Code = 300 // Encrypt represents an error occurred during the underlying encryption process
 	Decrypt Code = 301 // Decrypt represents an error occurred during the underlying decryption process
 	Encode  Code = 302 // Encode represents an error occurred during the underlying encoding/marshaling process
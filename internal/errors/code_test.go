@@ -165,6 +165,31 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    PasswordsEqual,
 			want: PasswordsEqual,
 		},
+		{
+			name: "AccountLocked",
+			c:    AccountLocked,
+			want: AccountLocked,
+		},
+		{
+			name: "PasswordTooSimple",
+			c:    PasswordTooSimple,
+			want: PasswordTooSimple,
+		},
+		{
+			name: "PasswordReused",
+			c:    PasswordReused,
+			want: PasswordReused,
+		},
+		{
+			name: "PasswordExpired",
+			c:    PasswordExpired,
+			want: PasswordExpired,
+		},
+		{
+			name: "InvalidTotpCode",
+			c:    InvalidTotpCode,
+			want: InvalidTotpCode,
+		},
 		{
 			name: "Encrypt",
 			c:    Encrypt,
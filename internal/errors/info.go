@@ -119,6 +119,26 @@ var errorCodeInfo = map[Code]Info{
 		Message: "old and new password are equal",
 		Kind:    Password,
 	},
+	AccountLocked: {
+		Message: "account locked",
+		Kind:    Password,
+	},
+	PasswordTooSimple: {
+		Message: "too simple",
+		Kind:    Password,
+	},
+	PasswordReused: {
+		Message: "password reused",
+		Kind:    Password,
+	},
+	PasswordExpired: {
+		Message: "password expired",
+		Kind:    Password,
+	},
+	InvalidTotpCode: {
+		Message: "invalid totp code",
+		Kind:    Password,
+	},
 	Encrypt: {
 		Message: "error occurred during encrypt",
 		Kind:    Encryption,
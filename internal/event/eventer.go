@@ -85,6 +85,8 @@ type Eventer struct {
 	observationPipelines []pipeline
 	errPipelines         []pipeline
 	auditWrapperNodes    []any
+	fileSinks            map[string]*fileSink // fileSinks are the file sinks by path and file name
+	httpSinks            []*httpSink          // httpSinks are closed when the eventer is closed
 
 	// Gating is used to delay output of events until after we have a chance to
 	// render startup info, similar to what was done for hclog before eventing
@@ -219,7 +221,7 @@ func NewAuditEncryptFilter(opt ...Option) (*encrypt.Filter, error) {
 // NewEventer creates a new Eventer using the config.  Supports options:
 // WithNow, WithSerializationLock, WithBroker, WithAuditWrapper,
 // WithNoDefaultSink
-func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName string, c EventerConfig, opt ...Option) (*Eventer, error) {
+func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName string, c EventerConfig, opt ...Option) (_ *Eventer, retErr error) {
 	const op = "event.NewEventer"
 	if log == nil {
 		return nil, fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
@@ -260,7 +262,15 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 		broker:            b,
 		auditWrapperNodes: []any{},
 		serverName:        serverName,
+		fileSinks:         map[string]*fileSink{},
 	}
+	// the senders of the http sinks created before an error don't outlive
+	// the eventer.
+	defer func() {
+		if retErr != nil {
+			_ = e.Close(context.Background())
+		}
+	}()
 
 	if !opts.withNow.IsZero() {
 		e.broker.StopTimeAt(opts.withNow)
@@ -280,6 +290,8 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 	// we need to keep track of all the Sink filenames to ensure they aren't
 	// reused.
 	allSinkFilenames := map[string]bool{}
+	// the same goes for the spool paths of http sinks.
+	allSpoolPaths := map[string]bool{}
 
 	for _, s := range c.Sinks {
 		fmtId, fmtNode, err := newFmtFilterNode(serverName, *s, opt...)
@@ -311,14 +323,23 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				return nil, fmt.Errorf("%s: duplicate file sink: %s %s: %w", op, fsc.Path, fsc.FileName, ErrInvalidParameter)
 			}
 			allSinkFilenames[fsc.Path+fsc.FileName] = true
-			sinkNode = &eventlogger.FileSink{
-				Format:      string(s.Format),
-				Path:        fsc.Path,
-				FileName:    fsc.FileName,
-				MaxBytes:    fsc.RotateBytes,
-				MaxDuration: fsc.RotateDuration,
-				MaxFiles:    fsc.RotateMaxFiles,
+			fileSinkNode, err := newFileSink(log, s.Format, fsc)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkNode = fileSinkNode
+			if fsc.HashChain != nil {
+				hashChainNode, err := newHashChainFileSink(log, fileSinkNode, fsc.HashChain)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", op, err)
+				}
+				sinkNode = hashChainNode
+				// the checkpoint written when flushing may rotate the file,
+				// so the hash chain is flushed before the file sink
+				e.flushableNodes = append(e.flushableNodes, hashChainNode)
 			}
+			e.flushableNodes = append(e.flushableNodes, fileSinkNode)
+			e.fileSinks[fsc.Path+fsc.FileName] = fileSinkNode
 			id, err := NewId(fmt.Sprintf("file_%s_%s_", fsc.Path, fsc.FileName))
 			if err != nil {
 				return nil, fmt.Errorf("%s: %w", op, err)
@@ -335,6 +356,36 @@ func NewEventer(log hclog.Logger, serializationLock *sync.Mutex, serverName stri
 				return nil, fmt.Errorf("%s: %w", op, err)
 			}
 			sinkId = eventlogger.NodeID(id)
+		case HttpSink:
+			hsc := s.HttpConfig
+			if hsc.SpoolPath != "" {
+				if _, found := allSpoolPaths[hsc.SpoolPath]; found {
+					return nil, fmt.Errorf("%s: duplicate http sink spool path: %s: %w", op, hsc.SpoolPath, ErrInvalidParameter)
+				}
+				allSpoolPaths[hsc.SpoolPath] = true
+			}
+			httpSinkNode, err := newHttpSink(log, s.Format, hsc)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			e.flushableNodes = append(e.flushableNodes, httpSinkNode)
+			e.httpSinks = append(e.httpSinks, httpSinkNode)
+			sinkNode = httpSinkNode
+			id, err := NewId("http")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
+		case SyslogSink:
+			sinkNode, err = newSyslogSink(s.Format, s.SyslogConfig)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			id, err := NewId("syslog")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			sinkId = eventlogger.NodeID(id)
 		default:
 			return nil, fmt.Errorf("%s: unknown sink type %s", op, s.Type)
 		}
@@ -756,6 +807,35 @@ func (e *Eventer) Reopen() error {
 	return nil
 }
 
+// ReloadFileSinks changes the rotation settings of the eventer's file sinks
+// to the ones of the file sinks in sinks with the same path and file name.
+// File sinks which aren't part of the eventer are ignored, since adding
+// sinks requires a restart.
+func (e *Eventer) ReloadFileSinks(sinks []*SinkConfig) error {
+	const op = "event.(Eventer).ReloadFileSinks"
+	for _, sc := range sinks {
+		if sc == nil || sc.Type != FileSink || sc.FileConfig == nil {
+			continue
+		}
+		if err := sc.FileConfig.validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	for _, sc := range sinks {
+		if sc == nil || sc.Type != FileSink || sc.FileConfig == nil {
+			continue
+		}
+		fsc := sc.FileConfig
+		s, ok := e.fileSinks[fsc.Path+fsc.FileName]
+		if !ok {
+			e.logger.Warn("ignoring new file sink until restart", "operation", op, "path", fsc.Path, "file_name", fsc.FileName)
+			continue
+		}
+		s.reload(fsc)
+	}
+	return nil
+}
+
 // FlushNodes will flush any of the eventer's flushable nodes.  This
 // needs to be called whenever Boundary is stopping (aka shutting down).
 func (e *Eventer) FlushNodes(ctx context.Context) error {
@@ -768,6 +848,24 @@ func (e *Eventer) FlushNodes(ctx context.Context) error {
 	return nil
 }
 
+// Close closes the eventer's http sinks, which sends their pending events and
+// stops their senders. It needs to be called once the eventer is no longer
+// used, after Boundary has stopped. Events sent to the http sinks after Close
+// are rejected, while the other sinks keep working.
+func (e *Eventer) Close(ctx context.Context) error {
+	const op = "event.(Eventer).Close"
+	var closeErr error
+	for _, s := range e.httpSinks {
+		if err := s.Close(ctx); err != nil {
+			closeErr = errors.Join(closeErr, err)
+		}
+	}
+	if closeErr != nil {
+		return fmt.Errorf("%s: %w", op, closeErr)
+	}
+	return nil
+}
+
 // ReleaseGate releases queued events. If any event isn't successfully written,
 // it remains in the queue and we could try a flush later.
 func (e *Eventer) ReleaseGate() error {
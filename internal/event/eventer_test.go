@@ -13,6 +13,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/eventlogger"
 	"github.com/hashicorp/eventlogger/filters/encrypt"
@@ -153,6 +154,7 @@ func Test_InitSysEventer(t *testing.T) {
 			require.NotNil(got)
 			tt.want.broker = got.broker
 			tt.want.flushableNodes = got.flushableNodes
+			tt.want.fileSinks = got.fileSinks
 			tt.want.auditPipelines = got.auditPipelines
 			tt.want.errPipelines = got.errPipelines
 			tt.want.observationPipelines = got.observationPipelines
@@ -687,6 +689,7 @@ func Test_NewEventer(t *testing.T) {
 			require.NotNil(got)
 			tt.want.broker = got.broker
 			tt.want.flushableNodes = got.flushableNodes
+			tt.want.fileSinks = got.fileSinks
 			tt.want.auditPipelines = got.auditPipelines
 			tt.want.errPipelines = got.errPipelines
 			tt.want.observationPipelines = got.observationPipelines
@@ -798,6 +801,83 @@ func TestEventer_FlushNodes(t *testing.T) {
 	})
 }
 
+func TestEventer_ReloadFileSinks(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	testLock := &sync.Mutex{}
+	testLogger := testLogger(t, testLock)
+	dir := t.TempDir()
+
+	sinkConfig := func(rotateBytes int) *SinkConfig {
+		return &SinkConfig{
+			Name:       "file-sink",
+			EventTypes: []Type{EveryType},
+			Format:     JSONSinkFormat,
+			Type:       FileSink,
+			FileConfig: &FileSinkTypeConfig{
+				Path:        dir,
+				FileName:    "events.ndjson",
+				RotateBytes: rotateBytes,
+			},
+		}
+	}
+	e, err := NewEventer(testLogger, testLock, "TestEventer_ReloadFileSinks", EventerConfig{
+		Sinks: []*SinkConfig{sinkConfig(0)},
+	})
+	require.NoError(err)
+	s := e.fileSinks[dir+"events.ndjson"]
+	require.NotNil(s)
+
+	require.NoError(e.ReloadFileSinks([]*SinkConfig{sinkConfig(100), DefaultSink()}))
+	assert.Equal(100, s.rotateBytes)
+
+	// new file sinks are ignored
+	newSink := sinkConfig(10)
+	newSink.FileConfig.FileName = "new.ndjson"
+	require.NoError(e.ReloadFileSinks([]*SinkConfig{newSink}))
+	assert.Len(e.fileSinks, 1)
+
+	err = e.ReloadFileSinks([]*SinkConfig{sinkConfig(-1)})
+	require.Error(err)
+	assert.ErrorIs(err, ErrInvalidParameter)
+	assert.Equal(100, s.rotateBytes)
+}
+
+func TestEventer_Close(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	testLock := &sync.Mutex{}
+	testLogger := testLogger(t, testLock)
+	ctx := context.Background()
+	ep := newTestHttpEndpoint(t)
+
+	e, err := NewEventer(testLogger, testLock, "TestEventer_Close", EventerConfig{
+		AuditEnabled: true,
+		Sinks: []*SinkConfig{
+			{
+				Name:       "http-sink",
+				EventTypes: []Type{AuditType},
+				Format:     JSONSinkFormat,
+				Type:       HttpSink,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:           ep.URL,
+					BatchInterval: time.Hour,
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	require.Len(e.httpSinks, 1)
+	s := e.httpSinks[0]
+
+	_, err = s.Process(ctx, testHttpEvent(1))
+	require.NoError(err)
+	require.NoError(e.Close(ctx))
+	assert.True(isClosed(s.stopped))
+	batches, _ := ep.received()
+	assert.Equal([][]map[string]any{{{"id": "1"}}}, batches)
+}
+
 type testFlushNode struct {
 	flushed    bool
 	raiseError bool
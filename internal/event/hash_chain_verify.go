@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// HashChainReport is the result of verifying a hash chained audit event file.
+type HashChainReport struct {
+	// Lines is the number of lines of the file.
+	Lines int `json:"lines"`
+	// Events is the number of chained event lines.
+	Events int `json:"events"`
+	// Checkpoints is the number of checkpoint lines.
+	Checkpoints int `json:"checkpoints"`
+	// SignaturesVerified is true when the signatures of the checkpoints were
+	// verified with a public key.
+	SignaturesVerified bool `json:"signatures_verified"`
+	// FirstSeq is the sequence number of the first chained line. It's only
+	// expected to be greater than 1 when the file was rotated.
+	FirstSeq uint64 `json:"first_seq"`
+	// LastSeq is the sequence number of the last chained line.
+	LastSeq uint64 `json:"last_seq"`
+	// UnsealedEvents is the number of events after the last valid
+	// checkpoint. Changes to unsealed events which rewrite the rest of the
+	// hash chain can't be detected.
+	UnsealedEvents int `json:"unsealed_events"`
+	// Problems are the gaps, reordered lines, modified lines and invalid
+	// checkpoints found in the file.
+	Problems []*HashChainProblem `json:"problems,omitempty"`
+}
+
+// HashChainProblem is a problem found on a line of a hash chained file.
+type HashChainProblem struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Valid returns true when no problems were found.
+func (r *HashChainReport) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *HashChainReport) addProblem(line int, format string, args ...any) {
+	r.Problems = append(r.Problems, &HashChainProblem{
+		Line:    line,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// VerifyHashChain verifies the hash chain of an audit event file written by
+// a file sink with a hash chain. It reports missing, reordered and modified
+// lines, and checkpoints whose signature can't be verified with publicKey.
+// Checkpoint signatures aren't verified when publicKey is nil. A chain which
+// doesn't start at sequence 1 is reported too, unless WithRotated is used.
+// An error is only returned when r can't be read.
+func VerifyHashChain(r io.Reader, publicKey ed25519.PublicKey, opt ...Option) (*HashChainReport, error) {
+	const op = "event.VerifyHashChain"
+	opts := getOpts(opt...)
+	if publicKey != nil && len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%s: invalid public key: %w", op, ErrInvalidParameter)
+	}
+	report := &HashChainReport{
+		SignaturesVerified: publicKey != nil,
+	}
+
+	var (
+		chained  bool   // whether a chained line precedes the current line
+		lastSeq  uint64 // sequence number of the previous chained line
+		lastHash string // hash of the previous chained line
+		lastLine int    // line number of the previous chained line
+	)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) == 0 && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		report.Lines++
+		n := report.Lines
+		line = bytes.TrimRight(line, "\r\n")
+
+		link, ok := parseHashChainLink(line)
+		if !ok {
+			report.addProblem(n, "line is not part of the hash chain")
+			// the chain is verified again from the next chained line, so a
+			// single modified line isn't reported as a gap too
+			chained = false
+			continue
+		}
+		seq := *link.Seq
+		switch {
+		case !chained && report.FirstSeq == 0:
+			report.FirstSeq = seq
+			switch {
+			case seq == 1 && *link.PrevHash != "":
+				report.addProblem(n, "first line of the hash chain has a previous hash")
+			case seq > 1 && !opts.withRotated:
+				report.addProblem(n, "hash chain starts at sequence %d: lines were removed from the start of the file, or the file was rotated", seq)
+			}
+		case !chained:
+			// the previous line was reported as not part of the hash chain
+		case seq == 1:
+			report.addProblem(n, "hash chain restarts after sequence %d: lines were removed or the chain was reset", lastSeq)
+		case seq <= lastSeq:
+			report.addProblem(n, "sequence %d follows sequence %d: lines were reordered or duplicated", seq, lastSeq)
+		case seq > lastSeq+1:
+			report.addProblem(n, "sequence jumps from %d to %d: %d lines are missing", lastSeq, seq, seq-lastSeq-1)
+		case *link.PrevHash != lastHash:
+			report.addProblem(n, "previous hash doesn't match line %d: line %d was modified", lastLine, lastLine)
+		}
+
+		if link.Checkpoint != nil {
+			report.Checkpoints++
+			if publicKey != nil && !verifyHashChainCheckpoint(publicKey, seq, *link.PrevHash, link.Checkpoint) {
+				report.addProblem(n, "checkpoint signature is invalid")
+			} else {
+				report.UnsealedEvents = 0
+			}
+		} else {
+			report.Events++
+			report.UnsealedEvents++
+		}
+
+		chained = true
+		lastSeq = seq
+		lastHash = hashChainHash(line)
+		lastLine = n
+		report.LastSeq = seq
+	}
+	return report, nil
+}
+
+// verifyHashChainCheckpoint returns true when the signature of the checkpoint
+// at seq was made by the private key of publicKey.
+func verifyHashChainCheckpoint(publicKey ed25519.PublicKey, seq uint64, prevHash string, cp *hashChainCheckpoint) bool {
+	sig, err := base64.StdEncoding.DecodeString(cp.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(publicKey, hashChainCheckpointMessage(seq, prevHash, cp.CreatedAt), sig)
+}
@@ -49,6 +49,7 @@ type options struct {
 	withGating           bool
 	withNoGateLocking    bool
 	withTelemetry        bool
+	withRotated          bool
 
 	// These options are related to the hclog adapter
 	withHclogLevel hclog.Level
@@ -236,3 +237,11 @@ func WithTelemetry() Option {
 		o.withTelemetry = true
 	}
 }
+
+// WithRotated specifies that a hash chained file was rotated, so its hash
+// chain may continue the chain of the previous file.
+func WithRotated() Option {
+	return func(o *options) {
+		o.withRotated = true
+	}
+}
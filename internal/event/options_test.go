@@ -203,6 +203,13 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTelemetry = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithRotated", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithRotated())
+		testOpts := getDefaultOptions()
+		testOpts.withRotated = true
+		assert.Equal(opts, testOpts)
+	})
 }
 
 // testWrapper initializes an AEAD wrapping.Wrapper for testing.  Note: this
@@ -6,6 +6,8 @@ package event
 import (
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"slices"
 	"time"
 )
@@ -19,10 +21,12 @@ type SinkConfig struct {
 	AllowFilters   []string              `hcl:"allow_filters"`    // AllowFilters define a set predicates for including an event in the sink. If any filter matches, the event will be included. The filter should be in a format supported by hashicorp/go-bexpr.
 	DenyFilters    []string              `hcl:"deny_filters"`     // DenyFilters define a set predicates for excluding an event in the sink. If any filter matches, the event will be excluded. The filter should be in a format supported by hashicorp/go-bexpr.
 	Format         SinkFormat            `hcl:"format"`           // Format defines the format for the sink (JSONSinkFormat or TextSinkFormat).
-	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink, FileSink, or WriterSink).
+	Type           SinkType              `hcl:"type"`             // Type defines the type of sink (StderrSink, FileSink, WriterSink, HttpSink or SyslogSink).
 	StderrConfig   *StderrSinkTypeConfig `hcl:"stderr"`           // StderrConfig defines parameters for a stderr output.
 	FileConfig     *FileSinkTypeConfig   `hcl:"file"`             // FileConfig defines parameters for a file output.
 	WriterConfig   *WriterSinkTypeConfig `hcl:"-"`                // WriterConfig defines parameters for an io.Writer output. This is not available via HCL.
+	HttpConfig     *HttpSinkTypeConfig   `hcl:"http"`             // HttpConfig defines parameters for an http output.
+	SyslogConfig   *SyslogSinkTypeConfig `hcl:"syslog"`           // SyslogConfig defines parameters for a syslog output.
 	AuditConfig    *AuditConfig          `hcl:"audit_config"`     // AuditConfig defines optional parameters for audit events (if EventTypes contains audit)
 }
 
@@ -45,6 +49,12 @@ func (sc *SinkConfig) Validate() error {
 	if sc.WriterConfig != nil {
 		foundSinkTypeConfigs++
 	}
+	if sc.HttpConfig != nil {
+		foundSinkTypeConfigs++
+	}
+	if sc.SyslogConfig != nil {
+		foundSinkTypeConfigs++
+	}
 	if foundSinkTypeConfigs > 1 {
 		return fmt.Errorf("%s: too many sink type config blocks: %w", op, ErrInvalidParameter)
 	}
@@ -65,6 +75,18 @@ func (sc *SinkConfig) Validate() error {
 		if sc.FileConfig.FileName == "" {
 			return fmt.Errorf("%s: missing file name: %w", op, ErrInvalidParameter)
 		}
+		if err := sc.FileConfig.validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if sc.FileConfig.HashChain != nil {
+			// hash chain fields are added to each event's JSON object
+			if sc.Format != JSONSinkFormat && sc.Format != JSONHclogSinkFormat {
+				return fmt.Errorf("%s: hash chain requires %s or %s format: %w", op, JSONSinkFormat, JSONHclogSinkFormat, ErrInvalidParameter)
+			}
+			if err := sc.FileConfig.HashChain.validate(); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
 	case WriterSink:
 		if sc.WriterConfig == nil {
 			return fmt.Errorf(`%s: missing writer config: %w`, op, ErrInvalidParameter)
@@ -72,6 +94,24 @@ func (sc *SinkConfig) Validate() error {
 		if sc.WriterConfig.Writer == nil {
 			return fmt.Errorf("%s: missing writer: %w", op, ErrInvalidParameter)
 		}
+	case HttpSink:
+		if sc.HttpConfig == nil {
+			return fmt.Errorf(`%s: missing "http" block: %w`, op, ErrInvalidParameter)
+		}
+		// batches are sent as a JSON array of events
+		if sc.Format != JSONSinkFormat {
+			return fmt.Errorf("%s: http sink requires %s format: %w", op, JSONSinkFormat, ErrInvalidParameter)
+		}
+		if err := sc.HttpConfig.validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case SyslogSink:
+		if sc.SyslogConfig == nil {
+			return fmt.Errorf(`%s: missing "syslog" block: %w`, op, ErrInvalidParameter)
+		}
+		if err := sc.SyslogConfig.validate(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 	}
 	if sc.Name == "" {
 		return fmt.Errorf("%s: missing sink name: %w", op, ErrInvalidParameter)
@@ -108,12 +148,142 @@ type StderrSinkTypeConfig struct{}
 
 // FileSinkTypeConfig contains configuration structures for file sink types
 type FileSinkTypeConfig struct {
-	Path              string        `hcl:"path"             mapstructure:"path"`             // Path defines the file path for the sink
-	FileName          string        `hcl:"file_name"        mapstructure:"file_name"`        // FileName defines the file name for the sink
-	RotateBytes       int           `hcl:"rotate_bytes"     mapstructure:"rotate_bytes"`     // RotateBytes defines the number of bytes that should trigger rotation of a FileSink
-	RotateDuration    time.Duration `mapstructure:"rotate_duration"`                         // RotateDuration defines how often a FileSink should be rotated
-	RotateDurationHCL string        `hcl:"rotate_duration" json:"-"`                         // RotateDurationHCL defines hcl string version of RotateDuration
-	RotateMaxFiles    int           `hcl:"rotate_max_files" mapstructure:"rotate_max_files"` // RotateMaxFiles defines how may historical rotated files should be kept for a FileSink
+	Path              string           `hcl:"path"             mapstructure:"path"`             // Path defines the file path for the sink
+	FileName          string           `hcl:"file_name"        mapstructure:"file_name"`        // FileName defines the file name for the sink
+	RotateBytes       int              `hcl:"rotate_bytes"     mapstructure:"rotate_bytes"`     // RotateBytes defines the number of bytes that should trigger rotation of a FileSink
+	RotateDuration    time.Duration    `mapstructure:"rotate_duration"`                         // RotateDuration defines how often a FileSink should be rotated
+	RotateDurationHCL string           `hcl:"rotate_duration" json:"-"`                         // RotateDurationHCL defines hcl string version of RotateDuration
+	RotateMaxFiles    int              `hcl:"rotate_max_files" mapstructure:"rotate_max_files"` // RotateMaxFiles defines how may historical rotated files should be kept for a FileSink
+	RotateCompress    bool             `hcl:"rotate_compress"  mapstructure:"rotate_compress"`  // RotateCompress defines whether rotated files of a FileSink are compressed with gzip
+	HashChain         *HashChainConfig `hcl:"hash_chain"       mapstructure:"hash_chain"`       // HashChain defines an optional hash chain of the events written to the file
+}
+
+func (c *FileSinkTypeConfig) validate() error {
+	const op = "event.(FileSinkTypeConfig).validate"
+	if c.RotateBytes < 0 {
+		return fmt.Errorf("%s: rotate bytes must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.RotateDuration < 0 {
+		return fmt.Errorf("%s: rotate duration must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.RotateMaxFiles < 0 {
+		return fmt.Errorf("%s: rotate max files must not be negative: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// HashChainConfig contains configuration structures for hash chaining the
+// events of a file sink
+type HashChainConfig struct {
+	SigningKeyFile        string        `hcl:"signing_key_file"  mapstructure:"signing_key_file"`  // SigningKeyFile defines a PEM file with the ed25519 private key used to sign checkpoints
+	CheckpointEvents      int           `hcl:"checkpoint_events" mapstructure:"checkpoint_events"` // CheckpointEvents defines the number of events between checkpoints
+	CheckpointInterval    time.Duration `mapstructure:"checkpoint_interval"`                       // CheckpointInterval defines the maximum time between an event and the next checkpoint
+	CheckpointIntervalHCL string        `hcl:"checkpoint_interval" json:"-"`                       // CheckpointIntervalHCL defines hcl string version of CheckpointInterval
+}
+
+func (c *HashChainConfig) validate() error {
+	const op = "event.(HashChainConfig).validate"
+	if c.SigningKeyFile == "" {
+		return fmt.Errorf("%s: missing signing key file: %w", op, ErrInvalidParameter)
+	}
+	if c.CheckpointEvents < 0 {
+		return fmt.Errorf("%s: checkpoint events must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.CheckpointInterval < 0 {
+		return fmt.Errorf("%s: checkpoint interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// HttpSinkTypeConfig contains configuration structures for http sink types
+type HttpSinkTypeConfig struct {
+	Url               string            `hcl:"url"                mapstructure:"url"`                // Url defines the endpoint batches of events are POSTed to
+	Headers           map[string]string `hcl:"headers"            mapstructure:"headers"`            // Headers defines additional headers sent with each batch
+	TlsCaFile         string            `hcl:"tls_ca_file"        mapstructure:"tls_ca_file"`        // TlsCaFile defines a PEM file with the CA certificates used to verify the endpoint
+	TlsCertFile       string            `hcl:"tls_cert_file"      mapstructure:"tls_cert_file"`      // TlsCertFile defines a PEM file with a client certificate
+	TlsKeyFile        string            `hcl:"tls_key_file"       mapstructure:"tls_key_file"`       // TlsKeyFile defines a PEM file with the key of the client certificate
+	TlsServerName     string            `hcl:"tls_server_name"    mapstructure:"tls_server_name"`    // TlsServerName defines the server name used to verify the endpoint's certificate
+	TlsSkipVerify     bool              `hcl:"tls_skip_verify"    mapstructure:"tls_skip_verify"`    // TlsSkipVerify disables the verification of the endpoint's certificate
+	BatchSize         int               `hcl:"batch_size"         mapstructure:"batch_size"`         // BatchSize defines the maximum number of events in a batch
+	BatchInterval     time.Duration     `mapstructure:"batch_interval"`                              // BatchInterval defines the maximum time an event is buffered before its batch is sent
+	BatchIntervalHCL  string            `hcl:"batch_interval" json:"-"`                              // BatchIntervalHCL defines hcl string version of BatchInterval
+	DeliveryGuarantee DeliveryGuarantee `hcl:"delivery_guarantee" mapstructure:"delivery_guarantee"` // DeliveryGuarantee defines whether a batch which can't be delivered or spooled is kept and retried (Enforced) or is dropped (BestEffort)
+	SpoolPath         string            `hcl:"spool_path"         mapstructure:"spool_path"`         // SpoolPath defines a directory batches are written to while the endpoint can't be reached
+}
+
+func (c *HttpSinkTypeConfig) validate() error {
+	const op = "event.(HttpSinkTypeConfig).validate"
+	if c.Url == "" {
+		return fmt.Errorf("%s: missing url: %w", op, ErrInvalidParameter)
+	}
+	u, err := url.Parse(c.Url)
+	if err != nil {
+		return fmt.Errorf("%s: invalid url: %w", op, ErrInvalidParameter)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%s: url scheme must be http or https: %w", op, ErrInvalidParameter)
+	}
+	if c.BatchSize < 0 {
+		return fmt.Errorf("%s: batch size must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if c.BatchInterval < 0 {
+		return fmt.Errorf("%s: batch interval must not be negative: %w", op, ErrInvalidParameter)
+	}
+	if (c.TlsCertFile == "") != (c.TlsKeyFile == "") {
+		return fmt.Errorf("%s: tls cert file and tls key file must be set together: %w", op, ErrInvalidParameter)
+	}
+	if err := c.DeliveryGuarantee.validate(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// without a spool, an undeliverable batch can only be dropped
+	if c.DeliveryGuarantee == Enforced && c.SpoolPath == "" {
+		return fmt.Errorf("%s: enforced delivery guarantee requires a spool path: %w", op, ErrInvalidParameter)
+	}
+	return nil
+}
+
+// SyslogSinkTypeConfig contains configuration structures for syslog sink types
+type SyslogSinkTypeConfig struct {
+	Network       string `hcl:"network"         mapstructure:"network"`         // Network defines the transport to the syslog server (udp, tcp or tls). Defaults to udp.
+	Address       string `hcl:"address"         mapstructure:"address"`         // Address defines the host:port of the syslog server
+	Facility      string `hcl:"facility"        mapstructure:"facility"`        // Facility defines the facility of the messages (kern, user, ..., local0 to local7). Defaults to local0.
+	Tag           string `hcl:"tag"             mapstructure:"tag"`             // Tag defines the APP-NAME of the messages. Defaults to boundary.
+	TlsCaFile     string `hcl:"tls_ca_file"     mapstructure:"tls_ca_file"`     // TlsCaFile defines a PEM file with the CA certificates used to verify the syslog server
+	TlsCertFile   string `hcl:"tls_cert_file"   mapstructure:"tls_cert_file"`   // TlsCertFile defines a PEM file with a client certificate
+	TlsKeyFile    string `hcl:"tls_key_file"    mapstructure:"tls_key_file"`    // TlsKeyFile defines a PEM file with the key of the client certificate
+	TlsServerName string `hcl:"tls_server_name" mapstructure:"tls_server_name"` // TlsServerName defines the server name used to verify the syslog server's certificate
+	TlsSkipVerify bool   `hcl:"tls_skip_verify" mapstructure:"tls_skip_verify"` // TlsSkipVerify disables the verification of the syslog server's certificate
+}
+
+func (c *SyslogSinkTypeConfig) validate() error {
+	const op = "event.(SyslogSinkTypeConfig).validate"
+	switch c.Network {
+	case "", "udp", "tcp", "tls":
+	default:
+		return fmt.Errorf("%s: '%s' is not a valid network: %w", op, c.Network, ErrInvalidParameter)
+	}
+	if c.Address == "" {
+		return fmt.Errorf("%s: missing address: %w", op, ErrInvalidParameter)
+	}
+	if _, _, err := net.SplitHostPort(c.Address); err != nil {
+		return fmt.Errorf("%s: invalid address: %w", op, ErrInvalidParameter)
+	}
+	if _, ok := syslogFacilities[c.Facility]; c.Facility != "" && !ok {
+		return fmt.Errorf("%s: '%s' is not a valid facility: %w", op, c.Facility, ErrInvalidParameter)
+	}
+	// RFC 5424 limits the APP-NAME to 48 printable US-ASCII characters
+	if len(c.Tag) > 48 {
+		return fmt.Errorf("%s: tag is longer than 48 characters: %w", op, ErrInvalidParameter)
+	}
+	for _, r := range c.Tag {
+		if r < 33 || r > 126 {
+			return fmt.Errorf("%s: tag must only contain printable ascii characters: %w", op, ErrInvalidParameter)
+		}
+	}
+	if (c.TlsCertFile == "") != (c.TlsKeyFile == "") {
+		return fmt.Errorf("%s: tls cert file and tls key file must be set together: %w", op, ErrInvalidParameter)
+	}
+	return nil
 }
 
 // WriterSinkTypeConfig contains configuration structures for writer sink types
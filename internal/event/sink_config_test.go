@@ -207,6 +207,287 @@ func TestSinkConfig_Validate(t *testing.T) {
 			wantErrIs:       ErrInvalidParameter,
 			wantErrContains: `too many sink type config blocks`,
 		},
+		{
+			name: "file-sink-with-negative-rotate-bytes",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:    "audit.log",
+					RotateBytes: -1,
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "rotate bytes must not be negative",
+		},
+		{
+			name: "file-sink-with-negative-rotate-max-files",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:       "audit.log",
+					RotateMaxFiles: -1,
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "rotate max files must not be negative",
+		},
+		{
+			name: "hash-chain-with-text-format",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     TextSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:  "audit.log",
+					HashChain: &HashChainConfig{SigningKeyFile: "key.pem"},
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "hash chain requires cloudevents-json or hclog-json format",
+		},
+		{
+			name: "hash-chain-with-no-signing-key-file",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:  "audit.log",
+					HashChain: &HashChainConfig{},
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing signing key file",
+		},
+		{
+			name: "hash-chain-with-negative-checkpoint-events",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:  "audit.log",
+					HashChain: &HashChainConfig{SigningKeyFile: "key.pem", CheckpointEvents: -1},
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "checkpoint events must not be negative",
+		},
+		{
+			name: "valid-hash-chain",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       FileSink,
+				Format:     JSONHclogSinkFormat,
+				FileConfig: &FileSinkTypeConfig{
+					FileName:  "audit.log",
+					HashChain: &HashChainConfig{SigningKeyFile: "key.pem"},
+				},
+			},
+		},
+		{
+			name: "http-sink-with-no-http-block",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "http" block`,
+		},
+		{
+			name: "http-sink-with-text-format",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     TextSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: "https://siem.example.com",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "http sink requires cloudevents-json format",
+		},
+		{
+			name: "http-sink-with-no-url",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing url",
+		},
+		{
+			name: "http-sink-with-invalid-url-scheme",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url: "ftp://siem.example.com",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "url scheme must be http or https",
+		},
+		{
+			name: "http-sink-with-cert-and-no-key",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:         "https://siem.example.com",
+					TlsCertFile: "client.pem",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "tls cert file and tls key file must be set together",
+		},
+		{
+			name: "http-sink-with-invalid-delivery-guarantee",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:               "https://siem.example.com",
+					DeliveryGuarantee: "invalid",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "not a valid delivery guarantee",
+		},
+		{
+			name: "http-sink-enforced-with-no-spool-path",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:               "https://siem.example.com",
+					DeliveryGuarantee: Enforced,
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "enforced delivery guarantee requires a spool path",
+		},
+		{
+			name: "valid-http-sink",
+			sc: SinkConfig{
+				Name:       "valid",
+				EventTypes: []Type{AuditType},
+				Type:       HttpSink,
+				Format:     JSONSinkFormat,
+				HttpConfig: &HttpSinkTypeConfig{
+					Url:               "https://siem.example.com",
+					DeliveryGuarantee: Enforced,
+					SpoolPath:         "/var/spool/boundary",
+				},
+			},
+		},
+		{
+			name: "syslog-sink-with-no-syslog-block",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: `missing "syslog" block`,
+		},
+		{
+			name: "syslog-sink-with-invalid-network",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network: "unix",
+					Address: "127.0.0.1:514",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "not a valid network",
+		},
+		{
+			name: "syslog-sink-with-no-address",
+			sc: SinkConfig{
+				Name:         "sink-name",
+				EventTypes:   []Type{AuditType},
+				Type:         SyslogSink,
+				Format:       JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "missing address",
+		},
+		{
+			name: "syslog-sink-with-invalid-facility",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Address:  "127.0.0.1:514",
+					Facility: "local8",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "not a valid facility",
+		},
+		{
+			name: "syslog-sink-with-invalid-tag",
+			sc: SinkConfig{
+				Name:       "sink-name",
+				EventTypes: []Type{AuditType},
+				Type:       SyslogSink,
+				Format:     JSONSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Address: "127.0.0.1:514",
+					Tag:     "my tag",
+				},
+			},
+			wantErrIs:       ErrInvalidParameter,
+			wantErrContains: "tag must only contain printable ascii characters",
+		},
+		{
+			name: "valid-syslog-sink",
+			sc: SinkConfig{
+				Name:       "valid",
+				EventTypes: []Type{AuditType, ErrorType},
+				Type:       SyslogSink,
+				Format:     TextHclogSinkFormat,
+				SyslogConfig: &SyslogSinkTypeConfig{
+					Network:  "tls",
+					Address:  "syslog.example.com:6514",
+					Facility: "auth",
+					Tag:      "boundary-controller",
+				},
+			},
+		},
 		{
 			name: "invalid observation, telemetry type",
 			sc: SinkConfig{
@@ -0,0 +1,377 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// fileSinkMode is the mode of the files written by a file sink.
+	fileSinkMode = 0o600
+
+	// fileSinkDirMode is the mode of the directory created for a file sink.
+	fileSinkDirMode = 0o700
+
+	// defaultFileSinkExt is the extension of rotated files when the file
+	// name doesn't have one.
+	defaultFileSinkExt = ".log"
+
+	// compressedFileExt is the extension added to rotated files which were
+	// compressed.
+	compressedFileExt = ".gz"
+)
+
+// fileSinkSpecialPaths are the paths a file sink writes to without opening,
+// rotating or compressing a file.
+var fileSinkSpecialPaths = map[string]func() io.Writer{
+	"/dev/stdout": func() io.Writer { return os.Stdout },
+	"/dev/stderr": func() io.Writer { return os.Stderr },
+	"/dev/null":   func() io.Writer { return io.Discard },
+}
+
+// fileSink is an eventlogger sink node which writes events to a file. Once
+// the file reaches the rotate bytes or is older than the rotate duration, it
+// is renamed to name-<unix nano>.ext, optionally compressed with gzip, and a
+// new file is started. Rotated files beyond the rotate max files are
+// removed. The rotation settings can be changed with reload.
+type fileSink struct {
+	logger   hclog.Logger
+	format   string
+	path     string
+	fileName string
+
+	l              sync.Mutex
+	rotateBytes    int
+	rotateDuration time.Duration
+	rotateMaxFiles int
+	rotateCompress bool
+	f              *os.File
+	bytesWritten   int64
+	createdAt      time.Time
+
+	// rotatedLock serializes compressing and pruning rotated files, which
+	// happens in the background when rotated files are compressed.
+	rotatedLock sync.Mutex
+	rotatedWg   sync.WaitGroup
+}
+
+var (
+	_ eventlogger.Node = (*fileSink)(nil)
+	_ flushable        = (*fileSink)(nil)
+)
+
+func newFileSink(log hclog.Logger, format SinkFormat, c *FileSinkTypeConfig) (*fileSink, error) {
+	const op = "event.newFileSink"
+	switch {
+	case log == nil:
+		return nil, fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
+	case c == nil:
+		return nil, fmt.Errorf("%s: missing file sink config: %w", op, ErrInvalidParameter)
+	case c.FileName == "":
+		return nil, fmt.Errorf("%s: missing file name: %w", op, ErrInvalidParameter)
+	}
+	s := &fileSink{
+		logger:   log,
+		format:   string(format),
+		path:     c.Path,
+		fileName: c.FileName,
+	}
+	s.reload(c)
+	return s, nil
+}
+
+// reload changes the rotation settings of the sink to the ones of c. They
+// apply from the next event.
+func (s *fileSink) reload(c *FileSinkTypeConfig) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.rotateBytes = c.RotateBytes
+	s.rotateDuration = c.RotateDuration
+	s.rotateMaxFiles = c.RotateMaxFiles
+	s.rotateCompress = c.RotateCompress
+}
+
+// Reopen closes the file, so it's reopened when the next event is processed.
+// This allows the file to be rotated by an external tool before a SIGHUP.
+func (s *fileSink) Reopen() error {
+	const op = "event.(fileSink).Reopen"
+	s.l.Lock()
+	defer s.l.Unlock()
+	if err := s.closeFile(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// FlushAll waits for rotated files which are being compressed.
+func (s *fileSink) FlushAll(_ context.Context) error {
+	s.rotatedWg.Wait()
+	return nil
+}
+
+// Type defines the fileSink as a NodeTypeSink
+func (s *fileSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Name returns the path of the file the sink writes to.
+func (s *fileSink) Name() string {
+	return filepath.Join(s.path, s.fileName)
+}
+
+// Process writes the event to the file, rotating it first if needed. A write
+// which fails is retried once after reopening the file.
+func (s *fileSink) Process(_ context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(fileSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(s.format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled as %s: %w", op, s.format, ErrInvalidParameter)
+	}
+
+	if w, ok := fileSinkSpecialPaths[s.path]; ok {
+		if _, err := w().Write(val); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return nil, nil
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if err := s.rotate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.write(val); err != nil {
+		// the file may have been removed or replaced, so retry once with a
+		// newly opened file.
+		if err := s.closeFile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := s.write(val); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// write writes val to the file, opening it first if needed. The caller must
+// hold the lock.
+func (s *fileSink) write(val []byte) error {
+	const op = "event.(fileSink).write"
+	if err := s.open(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := s.f.Write(val)
+	s.bytesWritten += int64(n)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// open opens the file for appending, creating it and its directory if
+// needed. The caller must hold the lock.
+func (s *fileSink) open() error {
+	const op = "event.(fileSink).open"
+	if s.f != nil {
+		return nil
+	}
+	if s.path != "" {
+		if err := os.MkdirAll(s.path, fileSinkDirMode); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	f, err := os.OpenFile(s.Name(), os.O_APPEND|os.O_WRONLY|os.O_CREATE, fileSinkMode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.f = f
+	// a file which existed before counts towards the rotate bytes
+	s.bytesWritten = fi.Size()
+	s.createdAt = time.Now()
+	return nil
+}
+
+// closeFile closes the file. The caller must hold the lock.
+func (s *fileSink) closeFile() error {
+	const op = "event.(fileSink).closeFile"
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// rotate renames the file once it reached the rotate bytes or is older than
+// the rotate duration. The rotated file is compressed when rotate compress
+// is set, and the rotated files beyond the rotate max files are removed. The
+// caller must hold the lock.
+func (s *fileSink) rotate() error {
+	const op = "event.(fileSink).rotate"
+	if err := s.open(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if s.bytesWritten == 0 {
+		return nil
+	}
+	bySize := s.rotateBytes > 0 && s.bytesWritten >= int64(s.rotateBytes)
+	byDuration := s.rotateDuration > 0 && time.Since(s.createdAt) > s.rotateDuration
+	if !bySize && !byDuration {
+		return nil
+	}
+
+	if err := s.closeFile(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rotated := filepath.Join(s.path, fmt.Sprintf(s.rotatedFileNamePattern(), strconv.FormatInt(time.Now().UnixNano(), 10)))
+	if err := os.Rename(s.Name(), rotated); err != nil {
+		return fmt.Errorf("%s: unable to rotate file: %w", op, err)
+	}
+
+	maxFiles := s.rotateMaxFiles
+	if !s.rotateCompress {
+		s.rotatedLock.Lock()
+		defer s.rotatedLock.Unlock()
+		if err := s.pruneRotated(maxFiles); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return s.open()
+	}
+	// compressing a large file takes a while, so it's done in the background
+	// to not delay events.
+	s.rotatedWg.Add(1)
+	go func() {
+		defer s.rotatedWg.Done()
+		s.rotatedLock.Lock()
+		defer s.rotatedLock.Unlock()
+		if err := compressFile(rotated); err != nil {
+			s.logger.Error("unable to compress rotated event file", "operation", op, "file", rotated, "error", err)
+		}
+		if err := s.pruneRotated(maxFiles); err != nil {
+			s.logger.Error("unable to remove rotated event files", "operation", op, "file", s.Name(), "error", err)
+		}
+	}()
+	return s.open()
+}
+
+// rotatedFileNamePattern returns the pattern of the names of rotated files,
+// which has a verb for the time of the rotation between the file name and
+// its extension.
+func (s *fileSink) rotatedFileNamePattern() string {
+	ext := filepath.Ext(s.fileName)
+	if ext == "" {
+		ext = defaultFileSinkExt
+	}
+	return strings.TrimSuffix(s.fileName, ext) + "-%s" + ext
+}
+
+// rotatedFiles returns the names of the rotated files, compressed or not,
+// from the oldest to the newest.
+func (s *fileSink) rotatedFiles() ([]string, error) {
+	const op = "event.(fileSink).rotatedFiles"
+	pattern := filepath.Join(s.path, fmt.Sprintf(s.rotatedFileNamePattern(), "*"))
+	uncompressed, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	compressed, err := filepath.Glob(pattern + compressedFileExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	// the names only differ in the time of the rotation, so they sort in the
+	// order the files were rotated.
+	names := append(uncompressed, compressed...)
+	slices.Sort(names)
+	return names, nil
+}
+
+// pruneRotated removes the oldest rotated files until at most maxFiles are
+// left. Nothing is removed when maxFiles is 0. The caller must hold the
+// rotated lock.
+func (s *fileSink) pruneRotated(maxFiles int) error {
+	const op = "event.(fileSink).pruneRotated"
+	if maxFiles <= 0 {
+		return nil
+	}
+	names, err := s.rotatedFiles()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for i := 0; i < len(names)-maxFiles; i++ {
+		if err := os.Remove(names[i]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// compressFile compresses a file with gzip, and replaces it with the
+// compressed file.
+func compressFile(name string) (retErr error) {
+	const op = "event.compressFile"
+	src, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer src.Close()
+
+	compressed := name + compressedFileExt
+	tmp := compressed + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileSinkMode)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if retErr != nil {
+			_ = dst.Close()
+			_ = os.Remove(tmp)
+		}
+	}()
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(name)
+	if _, err := io.Copy(zw, src); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Rename(tmp, compressed); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
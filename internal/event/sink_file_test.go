@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFileEvent(id int) *eventlogger.Event {
+	e := &eventlogger.Event{}
+	// every event is 11 bytes
+	e.FormattedAs(string(JSONSinkFormat), []byte(fmt.Sprintf("{\"id\":\"%d\"}\n", id)))
+	return e
+}
+
+func testReadFile(t *testing.T, name string) string {
+	t.Helper()
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	var r io.Reader = f
+	if filepath.Ext(name) == compressedFileExt {
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		r = zr
+	}
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func Test_fileSink(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: &sync.Mutex{},
+		Name:  "test",
+	})
+
+	t.Run("rotate-bytes", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:        dir,
+			FileName:    "events.ndjson",
+			RotateBytes: 20,
+		})
+		require.NoError(err)
+		for i := 0; i < 5; i++ {
+			_, err := s.Process(ctx, testFileEvent(i))
+			require.NoError(err)
+		}
+		assert.Equal("{\"id\":\"4\"}\n", testReadFile(t, filepath.Join(dir, "events.ndjson")))
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 2)
+		assert.Regexp(`events-\d+\.ndjson$`, rotated[0])
+		assert.Equal("{\"id\":\"0\"}\n{\"id\":\"1\"}\n", testReadFile(t, rotated[0]))
+		assert.Equal("{\"id\":\"2\"}\n{\"id\":\"3\"}\n", testReadFile(t, rotated[1]))
+	})
+	t.Run("rotate-duration", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:           dir,
+			FileName:       "events.ndjson",
+			RotateDuration: 10 * time.Millisecond,
+		})
+		require.NoError(err)
+		_, err = s.Process(ctx, testFileEvent(1))
+		require.NoError(err)
+		time.Sleep(20 * time.Millisecond)
+		_, err = s.Process(ctx, testFileEvent(2))
+		require.NoError(err)
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 1)
+		assert.Equal("{\"id\":\"1\"}\n", testReadFile(t, rotated[0]))
+		assert.Equal("{\"id\":\"2\"}\n", testReadFile(t, filepath.Join(dir, "events.ndjson")))
+	})
+	t.Run("rotate-max-files", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:           dir,
+			FileName:       "events",
+			RotateBytes:    1,
+			RotateMaxFiles: 2,
+		})
+		require.NoError(err)
+		for i := 0; i < 5; i++ {
+			_, err := s.Process(ctx, testFileEvent(i))
+			require.NoError(err)
+		}
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 2)
+		assert.Regexp(`events-\d+\.log$`, rotated[0])
+		assert.Equal("{\"id\":\"2\"}\n", testReadFile(t, rotated[0]))
+		assert.Equal("{\"id\":\"3\"}\n", testReadFile(t, rotated[1]))
+	})
+	t.Run("rotate-compress", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:           dir,
+			FileName:       "events.ndjson",
+			RotateBytes:    1,
+			RotateMaxFiles: 2,
+			RotateCompress: true,
+		})
+		require.NoError(err)
+		for i := 0; i < 4; i++ {
+			_, err := s.Process(ctx, testFileEvent(i))
+			require.NoError(err)
+		}
+		require.NoError(s.FlushAll(ctx))
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 2)
+		assert.Regexp(`events-\d+\.ndjson\.gz$`, rotated[0])
+		assert.Equal("{\"id\":\"1\"}\n", testReadFile(t, rotated[0]))
+		assert.Equal("{\"id\":\"2\"}\n", testReadFile(t, rotated[1]))
+		matches, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+		require.NoError(err)
+		assert.Empty(matches)
+	})
+	t.Run("reload", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		c := &FileSinkTypeConfig{
+			Path:     dir,
+			FileName: "events.ndjson",
+		}
+		s, err := newFileSink(testLogger, JSONSinkFormat, c)
+		require.NoError(err)
+		_, err = s.Process(ctx, testFileEvent(1))
+		require.NoError(err)
+
+		s.reload(&FileSinkTypeConfig{
+			Path:        dir,
+			FileName:    "events.ndjson",
+			RotateBytes: 1,
+		})
+		_, err = s.Process(ctx, testFileEvent(2))
+		require.NoError(err)
+		rotated, err := s.rotatedFiles()
+		require.NoError(err)
+		require.Len(rotated, 1)
+		assert.Equal("{\"id\":\"1\"}\n", testReadFile(t, rotated[0]))
+	})
+	t.Run("reopen", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:     dir,
+			FileName: "events.ndjson",
+		})
+		require.NoError(err)
+		_, err = s.Process(ctx, testFileEvent(1))
+		require.NoError(err)
+
+		// rotated by an external tool before a SIGHUP
+		name := filepath.Join(dir, "events.ndjson")
+		require.NoError(os.Rename(name, name+".1"))
+		require.NoError(s.Reopen())
+		_, err = s.Process(ctx, testFileEvent(2))
+		require.NoError(err)
+		assert.Equal("{\"id\":\"1\"}\n", testReadFile(t, name+".1"))
+		assert.Equal("{\"id\":\"2\"}\n", testReadFile(t, name))
+	})
+	t.Run("existing-file", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		name := filepath.Join(dir, "events.ndjson")
+		require.NoError(os.WriteFile(name, []byte("{\"id\":\"0\"}\n"), 0o600))
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:        dir,
+			FileName:    "events.ndjson",
+			RotateBytes: 20,
+		})
+		require.NoError(err)
+		_, err = s.Process(ctx, testFileEvent(1))
+		require.NoError(err)
+		_, err = s.Process(ctx, testFileEvent(2))
+		require.NoError(err)
+		assert.Equal("{\"id\":\"2\"}\n", testReadFile(t, name))
+	})
+	t.Run("dev-null", func(t *testing.T) {
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:        "/dev/null",
+			FileName:    "events.ndjson",
+			RotateBytes: 1,
+		})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, testFileEvent(1))
+		require.NoError(t, err)
+	})
+	t.Run("missing-format", func(t *testing.T) {
+		s, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+			Path:     t.TempDir(),
+			FileName: "events.ndjson",
+		})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, &eventlogger.Event{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,409 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultCheckpointEvents is the number of events between checkpoints
+	// when a hash chain doesn't define it.
+	defaultCheckpointEvents = 100
+
+	// defaultCheckpointInterval is the maximum time an event stays unsealed
+	// when a hash chain doesn't define it.
+	defaultCheckpointInterval = time.Minute
+
+	// hashChainHashPrefix prefixes the hex encoded hash of a chained line.
+	hashChainHashPrefix = "sha256:"
+
+	// hashChainLastLineChunk is the size of the chunks read from the end of a
+	// file to find its last line.
+	hashChainLastLineChunk = 64 * 1024
+)
+
+// hashChainLink contains the fields added to each line of a hash chained
+// file. Checkpoint is only set for checkpoint lines.
+type hashChainLink struct {
+	Seq        *uint64              `json:"chain_seq"`
+	PrevHash   *string              `json:"chain_prev_hash"`
+	Checkpoint *hashChainCheckpoint `json:"chain_checkpoint,omitempty"`
+}
+
+// hashChainCheckpoint is a signature of the hash chain up to the checkpoint.
+type hashChainCheckpoint struct {
+	CreatedAt string `json:"created_at"`
+	Signature string `json:"signature"`
+}
+
+// hashChainFileSink is an eventlogger sink node which writes events to a
+// file sink as a hash chain. Each line gets a sequence number and the hash of
+// the previous line, and signed checkpoints are written periodically, so
+// removed, reordered or modified lines can be detected by VerifyHashChain.
+type hashChainFileSink struct {
+	logger             hclog.Logger
+	fileSink           *fileSink
+	format             string
+	signingKey         ed25519.PrivateKey
+	checkpointEvents   int
+	checkpointInterval time.Duration
+
+	l        sync.Mutex
+	seq      uint64
+	prevHash string
+	unsealed int
+	timer    *time.Timer
+}
+
+var (
+	_ eventlogger.Node = (*hashChainFileSink)(nil)
+	_ flushable        = (*hashChainFileSink)(nil)
+)
+
+// newHashChainFileSink returns a hash chain sink writing to fs. The chain is
+// continued from the last line of the newest file written by fs.
+func newHashChainFileSink(log hclog.Logger, fs *fileSink, c *HashChainConfig) (*hashChainFileSink, error) {
+	const op = "event.newHashChainFileSink"
+	switch {
+	case log == nil:
+		return nil, fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
+	case fs == nil:
+		return nil, fmt.Errorf("%s: missing file sink: %w", op, ErrInvalidParameter)
+	case c == nil:
+		return nil, fmt.Errorf("%s: missing hash chain config: %w", op, ErrInvalidParameter)
+	}
+	signingKey, err := loadHashChainSigningKey(c.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s := &hashChainFileSink{
+		logger:             log,
+		fileSink:           fs,
+		format:             fs.format,
+		signingKey:         signingKey,
+		checkpointEvents:   c.CheckpointEvents,
+		checkpointInterval: c.CheckpointInterval,
+	}
+	if s.checkpointEvents <= 0 {
+		s.checkpointEvents = defaultCheckpointEvents
+	}
+	if s.checkpointInterval <= 0 {
+		s.checkpointInterval = defaultCheckpointInterval
+	}
+	if err := s.resume(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return s, nil
+}
+
+// Reopen reopens the underlying file sink.
+func (s *hashChainFileSink) Reopen() error {
+	return s.fileSink.Reopen()
+}
+
+// Type defines the hashChainFileSink as a NodeTypeSink
+func (s *hashChainFileSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Process writes the event as the next line of the hash chain. A checkpoint
+// is written once the checkpoint events were written since the last one, or
+// once the checkpoint interval has passed.
+func (s *hashChainFileSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(hashChainFileSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(s.format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled as %s: %w", op, s.format, ErrInvalidParameter)
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if err := s.append(ctx, e.Type, e.CreatedAt, val); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	s.unsealed++
+	switch {
+	case s.unsealed >= s.checkpointEvents:
+		if err := s.checkpoint(ctx); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	case s.timer == nil:
+		s.timer = time.AfterFunc(s.checkpointInterval, s.checkpointOnTimer)
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// FlushAll writes a checkpoint when events were written since the last one.
+func (s *hashChainFileSink) FlushAll(ctx context.Context) error {
+	const op = "event.(hashChainFileSink).FlushAll"
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.unsealed == 0 {
+		return nil
+	}
+	if err := s.checkpoint(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *hashChainFileSink) checkpointOnTimer() {
+	const op = "event.(hashChainFileSink).checkpointOnTimer"
+	if err := s.FlushAll(context.Background()); err != nil {
+		s.logger.Error("unable to write hash chain checkpoint", "operation", op, "file", s.fileSink.Name(), "error", err)
+	}
+}
+
+// append writes val as the next line of the hash chain. The chain only
+// advances when the line was written. The caller must hold the lock.
+func (s *hashChainFileSink) append(ctx context.Context, t eventlogger.EventType, createdAt time.Time, val []byte) error {
+	const op = "event.(hashChainFileSink).append"
+	line, err := hashChainLine(s.seq+1, s.prevHash, val)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	e := &eventlogger.Event{
+		Type:      t,
+		CreatedAt: createdAt,
+		Formatted: map[string][]byte{s.format: append(line, '\n')},
+	}
+	if _, err := s.fileSink.Process(ctx, e); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.seq++
+	s.prevHash = hashChainHash(line)
+	return nil
+}
+
+// checkpoint writes a signed checkpoint as the next line of the hash chain.
+// The caller must hold the lock.
+func (s *hashChainFileSink) checkpoint(ctx context.Context) error {
+	const op = "event.(hashChainFileSink).checkpoint"
+	now := time.Now().UTC()
+	cp := &hashChainCheckpoint{
+		CreatedAt: now.Format(time.RFC3339Nano),
+	}
+	sig := ed25519.Sign(s.signingKey, hashChainCheckpointMessage(s.seq+1, s.prevHash, cp.CreatedAt))
+	cp.Signature = base64.StdEncoding.EncodeToString(sig)
+	val, err := json.Marshal(struct {
+		Checkpoint *hashChainCheckpoint `json:"chain_checkpoint"`
+	}{cp})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.append(ctx, "", now, val); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	s.unsealed = 0
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	return nil
+}
+
+// resume continues the hash chain from the last line written by the file
+// sink, which is the last line of the file, or of the newest rotated file
+// when the file is empty. A new chain is started when there's no such line
+// or it isn't chained.
+func (s *hashChainFileSink) resume() error {
+	const op = "event.(hashChainFileSink).resume"
+	if _, ok := fileSinkSpecialPaths[s.fileSink.path]; ok {
+		return nil
+	}
+	rotated, err := s.fileSink.rotatedFiles()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	slices.Reverse(rotated)
+	for _, name := range append([]string{s.fileSink.Name()}, rotated...) {
+		line, err := lastLine(name)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			continue
+		case err != nil:
+			return fmt.Errorf("%s: %w", op, err)
+		case len(line) == 0:
+			continue
+		}
+		link, ok := parseHashChainLink(line)
+		if !ok {
+			s.logger.Warn("last line of event file is not hash chained, starting a new hash chain", "operation", op, "file", name)
+			return nil
+		}
+		s.seq = *link.Seq
+		s.prevHash = hashChainHash(line)
+		return nil
+	}
+	return nil
+}
+
+// hashChainLine returns val, a JSON object, with the sequence number and
+// previous hash of the hash chain added as its first fields.
+func hashChainLine(seq uint64, prevHash string, val []byte) ([]byte, error) {
+	const op = "event.hashChainLine"
+	val = bytes.TrimSpace(val)
+	if len(val) < 2 || val[0] != '{' {
+		return nil, fmt.Errorf("%s: event is not a JSON object: %w", op, ErrInvalidParameter)
+	}
+	prev, err := json.Marshal(prevHash)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"chain_seq":%d,"chain_prev_hash":%s`, seq, prev)
+	rest := bytes.TrimSpace(val[1:])
+	if !bytes.Equal(rest, []byte("}")) {
+		buf.WriteByte(',')
+	}
+	buf.Write(rest)
+	return buf.Bytes(), nil
+}
+
+// hashChainHash returns the hash of a line of a hash chain, which is
+// included in the next line.
+func hashChainHash(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hashChainHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// hashChainCheckpointMessage returns the message signed by a checkpoint.
+func hashChainCheckpointMessage(seq uint64, prevHash, createdAt string) []byte {
+	return []byte(fmt.Sprintf("boundary-hash-chain-checkpoint\n%d\n%s\n%s", seq, prevHash, createdAt))
+}
+
+// parseHashChainLink returns the hash chain fields of a line. It returns
+// false when the line isn't part of a hash chain.
+func parseHashChainLink(line []byte) (*hashChainLink, bool) {
+	var link hashChainLink
+	if err := json.Unmarshal(line, &link); err != nil {
+		return nil, false
+	}
+	if link.Seq == nil || link.PrevHash == nil {
+		return nil, false
+	}
+	return &link, true
+}
+
+// lastLine returns the last non-empty line of a file. An uncompressed file
+// is read from its end, so it isn't read completely.
+func lastLine(name string) ([]byte, error) {
+	const op = "event.lastLine"
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer f.Close()
+	if strings.HasSuffix(name, compressedFileExt) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		var last []byte
+		br := bufio.NewReader(zr)
+		for {
+			line, err := br.ReadBytes('\n')
+			if l := bytes.TrimRight(line, "\r\n"); len(l) > 0 {
+				last = l
+			}
+			if errors.Is(err, io.EOF) {
+				return last, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var tail []byte
+	for end := fi.Size(); end > 0; {
+		start := max(end-hashChainLastLineChunk, 0)
+		chunk := make([]byte, end-start)
+		if _, err := f.ReadAt(chunk, start); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tail = append(chunk, tail...)
+		end = start
+		trimmed := bytes.TrimRight(tail, "\r\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+	}
+	return bytes.TrimRight(tail, "\r\n"), nil
+}
+
+// loadHashChainSigningKey reads the ed25519 private key used to sign
+// checkpoints from a PEM encoded PKCS #8 file.
+func loadHashChainSigningKey(name string) (ed25519.PrivateKey, error) {
+	const op = "event.loadHashChainSigningKey"
+	if name == "" {
+		return nil, fmt.Errorf("%s: missing signing key file: %w", op, ErrInvalidParameter)
+	}
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to read signing key file: %w", op, err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%s: signing key file is not PEM encoded: %w", op, ErrInvalidParameter)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to parse signing key: %w", op, err)
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: signing key is not an ed25519 key: %w", op, ErrInvalidParameter)
+	}
+	return signingKey, nil
+}
+
+// ParseHashChainPublicKey parses the PEM encoded ed25519 public key used to
+// verify the checkpoints of a hash chain.
+func ParseHashChainPublicKey(b []byte) (ed25519.PublicKey, error) {
+	const op = "event.ParseHashChainPublicKey"
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("%s: public key is not PEM encoded: %w", op, ErrInvalidParameter)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to parse public key: %w", op, err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: public key is not an ed25519 key: %w", op, ErrInvalidParameter)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHashChainKey writes a new ed25519 signing key to a PEM file and returns
+// the file's path and the key's public key.
+func testHashChainKey(t *testing.T) (string, ed25519.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	name := filepath.Join(t.TempDir(), "signing-key.pem")
+	require.NoError(t, os.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return name, pub
+}
+
+func testHashChainEvent(id int) *eventlogger.Event {
+	e := &eventlogger.Event{
+		Type:      eventlogger.EventType(AuditType),
+		CreatedAt: time.Now(),
+	}
+	e.FormattedAs(string(JSONSinkFormat), []byte(fmt.Sprintf("{\"id\":\"%d\"}\n", id)))
+	return e
+}
+
+func testHashChainFileSink(t *testing.T, dir, keyFile string, checkpointEvents int) *hashChainFileSink {
+	t.Helper()
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: &sync.Mutex{},
+		Name:  "test",
+	})
+	fs, err := newFileSink(testLogger, JSONSinkFormat, &FileSinkTypeConfig{
+		Path:     dir,
+		FileName: "audit.log",
+	})
+	require.NoError(t, err)
+	s, err := newHashChainFileSink(testLogger, fs, &HashChainConfig{
+		SigningKeyFile:     keyFile,
+		CheckpointEvents:   checkpointEvents,
+		CheckpointInterval: time.Hour,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func testHashChainLines(t *testing.T, name string) []string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+}
+
+func Test_hashChainFileSink(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	keyFile, pub := testHashChainKey(t)
+
+	t.Run("chain-and-checkpoints", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s := testHashChainFileSink(t, dir, keyFile, 3)
+		for i := 1; i <= 5; i++ {
+			_, err := s.Process(ctx, testHashChainEvent(i))
+			require.NoError(err)
+		}
+		require.NoError(s.FlushAll(ctx))
+		// flushing without unsealed events doesn't write a checkpoint
+		require.NoError(s.FlushAll(ctx))
+
+		lines := testHashChainLines(t, filepath.Join(dir, "audit.log"))
+		require.Len(lines, 7)
+		var prevHash string
+		for i, line := range lines {
+			link, ok := parseHashChainLink([]byte(line))
+			require.True(ok)
+			assert.Equal(uint64(i+1), *link.Seq)
+			assert.Equal(prevHash, *link.PrevHash)
+			prevHash = hashChainHash([]byte(line))
+		}
+		// checkpoints follow every third event and the last event
+		for _, i := range []int{3, 6} {
+			assert.Contains(lines[i], `"chain_checkpoint"`)
+		}
+		var got map[string]any
+		require.NoError(json.Unmarshal([]byte(lines[0]), &got))
+		assert.Equal("1", got["id"])
+
+		f, err := os.Open(filepath.Join(dir, "audit.log"))
+		require.NoError(err)
+		defer f.Close()
+		report, err := VerifyHashChain(f, pub)
+		require.NoError(err)
+		assert.True(report.Valid(), "unexpected problems: %v", report.Problems)
+		assert.Equal(5, report.Events)
+		assert.Equal(2, report.Checkpoints)
+		assert.Equal(0, report.UnsealedEvents)
+	})
+	t.Run("resume", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		s := testHashChainFileSink(t, dir, keyFile, 10)
+		_, err := s.Process(ctx, testHashChainEvent(1))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+
+		s = testHashChainFileSink(t, dir, keyFile, 10)
+		assert.Equal(uint64(2), s.seq)
+		_, err = s.Process(ctx, testHashChainEvent(2))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+
+		b, err := os.ReadFile(filepath.Join(dir, "audit.log"))
+		require.NoError(err)
+		report, err := VerifyHashChain(bytes.NewReader(b), pub)
+		require.NoError(err)
+		assert.True(report.Valid(), "unexpected problems: %v", report.Problems)
+		assert.Equal(uint64(4), report.LastSeq)
+	})
+	t.Run("checkpoint-interval", func(t *testing.T) {
+		require := require.New(t)
+		dir := t.TempDir()
+		s := testHashChainFileSink(t, dir, keyFile, 10)
+		s.checkpointInterval = 10 * time.Millisecond
+		_, err := s.Process(ctx, testHashChainEvent(1))
+		require.NoError(err)
+		require.Eventually(func() bool {
+			s.l.Lock()
+			defer s.l.Unlock()
+			return s.unsealed == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		lines := testHashChainLines(t, filepath.Join(dir, "audit.log"))
+		require.Len(lines, 2)
+		require.Contains(lines[1], `"chain_checkpoint"`)
+	})
+	t.Run("not-a-json-object", func(t *testing.T) {
+		s := testHashChainFileSink(t, t.TempDir(), keyFile, 10)
+		e := &eventlogger.Event{}
+		e.FormattedAs(string(JSONSinkFormat), []byte("[1,2]\n"))
+		_, err := s.Process(ctx, e)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+		assert.Equal(t, uint64(0), s.seq)
+	})
+	t.Run("invalid-signing-key", func(t *testing.T) {
+		keyFile := filepath.Join(t.TempDir(), "key.pem")
+		require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+		fs, err := newFileSink(hclog.NewNullLogger(), JSONSinkFormat, &FileSinkTypeConfig{FileName: "audit.log"})
+		require.NoError(t, err)
+		_, err = newHashChainFileSink(hclog.NewNullLogger(), fs, &HashChainConfig{SigningKeyFile: keyFile})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+func TestVerifyHashChain(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	keyFile, pub := testHashChainKey(t)
+	_, otherPub := testHashChainKey(t)
+
+	// lines are 6 events with a checkpoint after every second event
+	dir := t.TempDir()
+	s := testHashChainFileSink(t, dir, keyFile, 2)
+	for i := 1; i <= 6; i++ {
+		_, err := s.Process(ctx, testHashChainEvent(i))
+		require.NoError(t, err)
+	}
+	lines := testHashChainLines(t, filepath.Join(dir, "audit.log"))
+	require.Len(t, lines, 9)
+
+	tests := []struct {
+		name         string
+		lines        func() []string
+		publicKey    ed25519.PublicKey
+		wantProblems []string
+		wantUnsealed int
+	}{
+		{
+			name:      "valid",
+			lines:     func() []string { return lines },
+			publicKey: pub,
+		},
+		{
+			name:  "without-public-key",
+			lines: func() []string { return lines },
+		},
+		{
+			name: "removed-line",
+			lines: func() []string {
+				return append(append([]string{}, lines[:3]...), lines[4:]...)
+			},
+			publicKey:    pub,
+			wantProblems: []string{"sequence jumps from 3 to 5: 1 lines are missing"},
+		},
+		{
+			name: "reordered-lines",
+			lines: func() []string {
+				l := append([]string{}, lines...)
+				l[3], l[4] = l[4], l[3]
+				return l
+			},
+			publicKey:    pub,
+			wantProblems: []string{"sequence jumps from 3 to 5", "sequence 4 follows sequence 5: lines were reordered or duplicated", "sequence jumps from 4 to 6"},
+		},
+		{
+			name: "modified-line",
+			lines: func() []string {
+				l := append([]string{}, lines...)
+				l[3] = strings.Replace(l[3], `"id":"3"`, `"id":"x"`, 1)
+				return l
+			},
+			publicKey:    pub,
+			wantProblems: []string{"previous hash doesn't match line 4: line 4 was modified"},
+		},
+		{
+			name: "truncated-line",
+			lines: func() []string {
+				l := append([]string{}, lines...)
+				l[8] = l[8][:10]
+				return l
+			},
+			publicKey:    pub,
+			wantProblems: []string{"line is not part of the hash chain"},
+			wantUnsealed: 2,
+		},
+		{
+			name: "restarted-chain",
+			lines: func() []string {
+				return append(append([]string{}, lines[:3]...), lines...)
+			},
+			publicKey:    pub,
+			wantProblems: []string{"hash chain restarts after sequence 3"},
+		},
+		{
+			name:         "wrong-public-key",
+			lines:        func() []string { return lines },
+			publicKey:    otherPub,
+			wantProblems: []string{"checkpoint signature is invalid", "checkpoint signature is invalid", "checkpoint signature is invalid"},
+			wantUnsealed: 6,
+		},
+		{
+			name:         "unsealed-events",
+			lines:        func() []string { return lines[:8] },
+			publicKey:    pub,
+			wantUnsealed: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			in := strings.Join(tt.lines(), "\n") + "\n"
+			report, err := VerifyHashChain(strings.NewReader(in), tt.publicKey)
+			require.NoError(err)
+			require.Len(report.Problems, len(tt.wantProblems), "unexpected problems: %v", report.Problems)
+			for i, want := range tt.wantProblems {
+				assert.Contains(report.Problems[i].Message, want)
+			}
+			assert.Equal(tt.wantUnsealed, report.UnsealedEvents)
+			assert.Equal(tt.publicKey != nil, report.SignaturesVerified)
+		})
+	}
+	t.Run("rotated-file", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		report, err := VerifyHashChain(strings.NewReader(strings.Join(lines[3:], "\n")), pub, WithRotated())
+		require.NoError(err)
+		assert.True(report.Valid(), "unexpected problems: %v", report.Problems)
+		assert.Equal(uint64(4), report.FirstSeq)
+		assert.Equal(uint64(9), report.LastSeq)
+	})
+	t.Run("removed-first-lines", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		report, err := VerifyHashChain(strings.NewReader(strings.Join(lines[3:], "\n")), pub)
+		require.NoError(err)
+		require.Len(report.Problems, 1)
+		assert.Equal(1, report.Problems[0].Line)
+		assert.Contains(report.Problems[0].Message, "hash chain starts at sequence 4")
+		assert.Equal(uint64(4), report.FirstSeq)
+	})
+	t.Run("invalid-public-key", func(t *testing.T) {
+		_, err := VerifyHashChain(strings.NewReader(""), ed25519.PublicKey("short"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
@@ -0,0 +1,604 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultHttpBatchSize is the number of events sent in a batch when the
+	// http sink doesn't define a batch size.
+	defaultHttpBatchSize = 100
+
+	// defaultHttpBatchInterval is the time an event is buffered before its
+	// batch is sent when the http sink doesn't define a batch interval.
+	defaultHttpBatchInterval = 5 * time.Second
+
+	// httpSinkTimeout is the timeout of a single http request of an http
+	// sink.
+	httpSinkTimeout = 30 * time.Second
+
+	// httpSinkQueueSize is the number of batches an http sink holds in memory
+	// while they wait to be sent.
+	httpSinkQueueSize = 10
+
+	// maxSpooledBatches is the maximum number of batches in the spool
+	// directory of an http sink.
+	maxSpooledBatches = 10000
+
+	// httpBatchContentType is the content type of a batch of events as
+	// defined by the batched content mode of the CloudEvents http binding.
+	httpBatchContentType = "application/cloudevents-batch+json"
+
+	// spoolFileExt is the extension of the files of spooled batches.
+	spoolFileExt = ".json"
+)
+
+var (
+	// errSpoolFull is returned when a batch is spooled while the spool
+	// directory already holds maxSpooledBatches batches.
+	errSpoolFull = errors.New("spool is full")
+
+	// errHttpSinkClosed is returned when an event is processed by, or a batch
+	// is queued for, a closed http sink.
+	errHttpSinkClosed = errors.New("http sink is closed")
+)
+
+// httpSink is an eventlogger sink node which POSTs batches of events to an
+// http endpoint. Processing an event only adds it to a batch, full batches are
+// queued and sent in order by a background sender. Batches which can't be
+// delivered after retrying, or which don't fit in the queue, are written to the
+// spool directory, if one is configured, and resent by the sender before the
+// next batch. Close stops the sender once it has handled the pending batches.
+type httpSink struct {
+	logger            hclog.Logger
+	client            *http.Client
+	url               string
+	headers           map[string]string
+	format            string
+	batchSize         int
+	batchInterval     time.Duration
+	deliveryGuarantee DeliveryGuarantee
+	spoolPath         string
+
+	l     sync.Mutex
+	batch [][]byte
+	timer *time.Timer
+	// lastBatchTime is the time of the last batch, which makes sure batches
+	// sort in the order they were created.
+	lastBatchTime int64
+
+	// queue holds the batches waiting for the sender.
+	queue chan *httpBatch
+
+	// closed is set by Close, after which no events are added to batches.
+	// It's protected by s.l.
+	closed bool
+	// final is the last batch, which is cut by Close and delivered by the
+	// sender once it has emptied the queue.
+	final *httpBatch
+	// enqueuing counts the batches being added to the queue, which the sender
+	// waits for before it empties the queue and stops.
+	enqueuing sync.WaitGroup
+	// stop is closed by Close to stop the sender, and stopped is closed by the
+	// sender once it has stopped.
+	stop    chan struct{}
+	stopped chan struct{}
+	// ctx is the context of the sender's requests, which Close cancels when
+	// its ctx is done before the sender has stopped.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// httpBatch is a batch of formatted events.
+type httpBatch struct {
+	// name sorts in the order the batches were created and is the file name
+	// of the batch when it is spooled.
+	name   string
+	events [][]byte
+	// done is closed once the sender handled the batch, when it's not nil.
+	done chan struct{}
+}
+
+var (
+	_ eventlogger.Node   = (*httpSink)(nil)
+	_ eventlogger.Closer = (*httpSink)(nil)
+	_ flushable          = (*httpSink)(nil)
+)
+
+func newHttpSink(log hclog.Logger, format SinkFormat, c *HttpSinkTypeConfig) (*httpSink, error) {
+	const op = "event.newHttpSink"
+	if log == nil {
+		return nil, fmt.Errorf("%s: missing logger: %w", op, ErrInvalidParameter)
+	}
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing http sink config: %w", op, ErrInvalidParameter)
+	}
+	tlsConfig, err := clientTlsConfig(c.TlsCaFile, c.TlsCertFile, c.TlsKeyFile, c.TlsServerName, c.TlsSkipVerify)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	s := &httpSink{
+		logger:            log,
+		client:            &http.Client{Transport: transport, Timeout: httpSinkTimeout},
+		url:               c.Url,
+		headers:           c.Headers,
+		format:            string(format),
+		batchSize:         c.BatchSize,
+		batchInterval:     c.BatchInterval,
+		deliveryGuarantee: c.DeliveryGuarantee,
+		spoolPath:         c.SpoolPath,
+		queue:             make(chan *httpBatch, httpSinkQueueSize),
+		stop:              make(chan struct{}),
+		stopped:           make(chan struct{}),
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultHttpBatchSize
+	}
+	if s.batchInterval <= 0 {
+		s.batchInterval = defaultHttpBatchInterval
+	}
+	if s.deliveryGuarantee == DefaultDeliveryGuarantee {
+		s.deliveryGuarantee = BestEffort
+	}
+	if s.spoolPath != "" {
+		if err := os.MkdirAll(s.spoolPath, 0o700); err != nil {
+			return nil, fmt.Errorf("%s: unable to create spool directory: %w", op, err)
+		}
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.sender()
+	return s, nil
+}
+
+// Reopen does nothing for an http sink.
+func (s *httpSink) Reopen() error { return nil }
+
+// Type defines the httpSink as a NodeTypeSink
+func (s *httpSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Process adds the event to the current batch. The batch is queued for the
+// sender once it reaches the batch size, or once the batch interval has
+// passed since its first event was added. Process never sends a batch itself,
+// it only waits for the sender when the delivery guarantee is enforced and
+// both the queue and the spool are full.
+func (s *httpSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(httpSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(s.format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled as %s: %w", op, s.format, ErrInvalidParameter)
+	}
+	val = bytes.TrimSpace(val)
+
+	var b *httpBatch
+	s.l.Lock()
+	if s.closed {
+		s.l.Unlock()
+		return nil, fmt.Errorf("%s: %w", op, errHttpSinkClosed)
+	}
+	s.batch = append(s.batch, slices.Clone(val))
+	switch {
+	case len(s.batch) >= s.batchSize:
+		b = s.cutBatch()
+		s.enqueuing.Add(1)
+	case s.timer == nil:
+		s.timer = time.AfterFunc(s.batchInterval, s.queueOnTimer)
+	}
+	s.l.Unlock()
+
+	if b != nil {
+		defer s.enqueuing.Done()
+		if err := s.enqueue(ctx, b); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	// Sinks are leafs, so do not return the event, since nothing more can
+	// happen to it downstream.
+	return nil, nil
+}
+
+// FlushAll queues the current batch and waits until the sender has handled it
+// and every batch queued before it. A closed sink has nothing to flush.
+func (s *httpSink) FlushAll(ctx context.Context) error {
+	const op = "event.(httpSink).FlushAll"
+	s.l.Lock()
+	if s.closed {
+		s.l.Unlock()
+		return nil
+	}
+	b := s.cutBatch()
+	s.enqueuing.Add(1)
+	s.l.Unlock()
+	defer s.enqueuing.Done()
+	b.done = make(chan struct{})
+	select {
+	case s.queue <- b:
+	case <-s.stop:
+		// the sender waits for the batch before it empties the queue and
+		// stops, so it's queued or spooled like any other batch.
+		if err := s.enqueue(ctx, b); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", op, ctx.Err())
+	}
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: %w", op, ctx.Err())
+	}
+}
+
+func (s *httpSink) queueOnTimer() {
+	const op = "event.(httpSink).queueOnTimer"
+	s.l.Lock()
+	if s.closed {
+		s.l.Unlock()
+		return
+	}
+	b := s.cutBatch()
+	if len(b.events) == 0 {
+		s.l.Unlock()
+		return
+	}
+	s.enqueuing.Add(1)
+	s.l.Unlock()
+	defer s.enqueuing.Done()
+	if err := s.enqueue(context.Background(), b); err != nil {
+		s.logger.Error("unable to queue batch of events", "operation", op, "url", s.url, "error", err)
+	}
+}
+
+// cutBatch returns the current batch and starts a new one. The caller must
+// hold s.l.
+func (s *httpSink) cutBatch() *httpBatch {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	t := time.Now().UnixNano()
+	if t <= s.lastBatchTime {
+		t = s.lastBatchTime + 1
+	}
+	s.lastBatchTime = t
+	b := &httpBatch{
+		name:   fmt.Sprintf("%020d%s", t, spoolFileExt),
+		events: s.batch,
+	}
+	s.batch = nil
+	return b
+}
+
+// enqueue adds the batch to the queue of the sender. When the queue is full,
+// the batch is spooled instead. A batch which can be neither queued nor
+// spooled is dropped, unless the delivery guarantee is enforced. Then enqueue
+// waits until the queue has room for the batch, the ctx is done or the sink is
+// closed.
+func (s *httpSink) enqueue(ctx context.Context, b *httpBatch) error {
+	const op = "event.(httpSink).enqueue"
+	select {
+	case s.queue <- b:
+		return nil
+	default:
+	}
+	err := fmt.Errorf("%s: queue is full", op)
+	if s.spoolPath != "" {
+		spoolErr := s.spool(b.name, encodeBatch(b.events))
+		if spoolErr == nil {
+			return nil
+		}
+		err = errors.Join(err, spoolErr)
+	}
+	if s.deliveryGuarantee != Enforced {
+		s.logger.Error("dropping batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", err)
+		return nil
+	}
+	select {
+	case s.queue <- b:
+		return nil
+	case <-s.stop:
+		return fmt.Errorf("%s: unable to queue or spool batch of %d events: %w", op, len(b.events), errors.Join(err, errHttpSinkClosed))
+	case <-ctx.Done():
+		return fmt.Errorf("%s: unable to queue or spool batch of %d events: %w", op, len(b.events), errors.Join(err, ctx.Err()))
+	}
+}
+
+// sender sends the queued batches in order. The spooled batches are resent
+// when the sender starts, whenever the queue has been emptied, and every batch
+// interval while there are spooled batches which couldn't be resent. Once the
+// sink is closed, the sender delivers the batches left in the queue and the
+// final batch, and stops.
+func (s *httpSink) sender() {
+	const op = "event.(httpSink).sender"
+	defer close(s.stopped)
+	ctx := s.ctx
+	var retry <-chan time.Time
+	var done chan struct{}
+	for {
+		// while batches are spooled, queued batches are spooled too, and the
+		// spool is only resent once the queue is empty. Batches spooled by
+		// enqueue are newer than the batches queued before them, so this
+		// keeps the batches in order.
+		if len(s.queue) == 0 {
+			if err := s.sendSpooled(ctx); err != nil {
+				s.logger.Error("unable to send spooled batch of events", "operation", op, "url", s.url, "error", err)
+				if retry == nil {
+					retry = time.After(s.batchInterval)
+				}
+			} else {
+				retry = nil
+			}
+		}
+		if done != nil {
+			close(done)
+			done = nil
+		}
+		select {
+		case b := <-s.queue:
+			s.deliver(ctx, b)
+			done = b.done
+		case <-retry:
+			retry = nil
+		case <-s.stop:
+			s.drain(ctx)
+			return
+		}
+	}
+}
+
+// drain delivers the batches left in the queue and the final batch once the
+// sink is closed. Batches which can't be sent are spooled or dropped like any
+// other batch, and the ones which are still being queued are waited for, so
+// no batch is left in the queue.
+func (s *httpSink) drain(ctx context.Context) {
+	s.enqueuing.Wait()
+	for {
+		select {
+		case b := <-s.queue:
+			s.deliver(ctx, b)
+			if b.done != nil {
+				close(b.done)
+			}
+			continue
+		default:
+		}
+		break
+	}
+	s.deliver(ctx, s.final)
+}
+
+// Close stops the sink. The current batch and the queued batches are sent,
+// or spooled or dropped when they can't be sent, before Close returns. When
+// the ctx is done first, the sender's requests are canceled, so the remaining
+// batches are spooled or dropped without waiting for the endpoint. Events
+// processed after Close are rejected.
+func (s *httpSink) Close(ctx context.Context) error {
+	const op = "event.(httpSink).Close"
+	s.l.Lock()
+	if s.closed {
+		s.l.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.final = s.cutBatch()
+	s.l.Unlock()
+	close(s.stop)
+
+	var err error
+	select {
+	case <-s.stopped:
+	case <-ctx.Done():
+		err = fmt.Errorf("%s: %w", op, ctx.Err())
+		s.cancel()
+		<-s.stopped
+	}
+	s.cancel()
+	s.client.CloseIdleConnections()
+	return err
+}
+
+// deliver sends the batch, or spools it when there are spooled batches, so
+// the batches are delivered in order. A batch which can't be sent is spooled
+// when the sink has a spool directory. Otherwise it is dropped. With an
+// enforced delivery guarantee, a batch which can be neither sent nor spooled
+// is retried every batch interval instead of being dropped, which eventually
+// makes Process wait for the sender once the queue and the spool are full. It's
+// only dropped when the ctx is done, once Close gave up waiting for it.
+func (s *httpSink) deliver(ctx context.Context, b *httpBatch) {
+	const op = "event.(httpSink).deliver"
+	if len(b.events) == 0 {
+		return
+	}
+	body := encodeBatch(b.events)
+	for {
+		var sendErr error
+		if !s.hasSpooled() {
+			if sendErr = s.send(ctx, body); sendErr == nil {
+				return
+			}
+		}
+		if s.spoolPath == "" {
+			s.logger.Error("dropping batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", sendErr)
+			return
+		}
+		spoolErr := s.spool(b.name, body)
+		switch {
+		case spoolErr == nil:
+			if sendErr != nil {
+				s.logger.Error("spooled batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", sendErr)
+			}
+			return
+		case s.deliveryGuarantee != Enforced:
+			s.logger.Error("dropping batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", errors.Join(sendErr, spoolErr))
+			return
+		}
+		s.logger.Error("unable to send or spool batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", errors.Join(sendErr, spoolErr))
+		select {
+		case <-ctx.Done():
+			s.logger.Error("dropping batch of events", "operation", op, "url", s.url, "events", len(b.events), "error", ctx.Err())
+			return
+		case <-time.After(s.batchInterval):
+		}
+	}
+}
+
+// send POSTs the body to the sink's url, retrying with backoff.
+func (s *httpSink) send(ctx context.Context, body []byte) error {
+	const op = "event.(httpSink).send"
+	var sendErrors error
+	for attempts := uint(1); ; attempts++ {
+		err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		sendErrors = errors.Join(sendErrors, err)
+		if attempts > stdRetryCount {
+			return fmt.Errorf("%s: reached max of %d retries: %w", op, stdRetryCount, errors.Join(sendErrors, ErrMaxRetries))
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, errors.Join(sendErrors, ctx.Err()))
+		case <-time.After(expBackoff{}.duration(attempts)):
+		}
+	}
+}
+
+func (s *httpSink) post(ctx context.Context, body []byte) error {
+	const op = "event.(httpSink).post"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", httpBatchContentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: unexpected status %s", op, resp.Status)
+	}
+	return nil
+}
+
+// spool writes the body of a batch to the spool directory as the file name.
+// errSpoolFull is returned when the spool directory already holds
+// maxSpooledBatches batches.
+func (s *httpSink) spool(name string, body []byte) error {
+	const op = "event.(httpSink).spool"
+	spooled, err := s.spooledBatches()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(spooled) >= maxSpooledBatches {
+		return fmt.Errorf("%s: %w", op, errSpoolFull)
+	}
+	name = filepath.Join(s.spoolPath, name)
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o600); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// sendSpooled sends the spooled batches in the order they were created and
+// removes them once they are delivered. It stops at the first batch which
+// can't be delivered.
+func (s *httpSink) sendSpooled(ctx context.Context) error {
+	const op = "event.(httpSink).sendSpooled"
+	if s.spoolPath == "" {
+		return nil
+	}
+	spooled, err := s.spooledBatches()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, name := range spooled {
+		body, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := s.send(ctx, body); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := os.Remove(name); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return nil
+}
+
+// hasSpooled returns whether there are spooled batches.
+func (s *httpSink) hasSpooled() bool {
+	if s.spoolPath == "" {
+		return false
+	}
+	spooled, err := s.spooledBatches()
+	return err == nil && len(spooled) > 0
+}
+
+// spooledBatches returns the file names of the spooled batches in the order
+// the batches were created.
+func (s *httpSink) spooledBatches() ([]string, error) {
+	const op = "event.(httpSink).spooledBatches"
+	entries, err := os.ReadDir(s.spoolPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), spoolFileExt) {
+			continue
+		}
+		names = append(names, filepath.Join(s.spoolPath, e.Name()))
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// encodeBatch returns the JSON array of the formatted events of a batch.
+func encodeBatch(batch [][]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, v := range batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
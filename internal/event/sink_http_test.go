@@ -0,0 +1,387 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHttpEndpoint records the batches POSTed to it and fails requests while
+// failing is set.
+type testHttpEndpoint struct {
+	*httptest.Server
+	failing atomic.Bool
+
+	l       sync.Mutex
+	batches [][]map[string]any
+	headers []http.Header
+}
+
+func newTestHttpEndpoint(t *testing.T) *testHttpEndpoint {
+	t.Helper()
+	ep := &testHttpEndpoint{}
+	ep.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ep.failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var batch []map[string]any
+		require.NoError(t, json.Unmarshal(body, &batch))
+		ep.l.Lock()
+		defer ep.l.Unlock()
+		ep.batches = append(ep.batches, batch)
+		ep.headers = append(ep.headers, r.Header.Clone())
+	}))
+	t.Cleanup(ep.Close)
+	return ep
+}
+
+func (ep *testHttpEndpoint) received() ([][]map[string]any, []http.Header) {
+	ep.l.Lock()
+	defer ep.l.Unlock()
+	return ep.batches, ep.headers
+}
+
+func testHttpEvent(id int) *eventlogger.Event {
+	e := &eventlogger.Event{}
+	e.FormattedAs(string(JSONSinkFormat), []byte(fmt.Sprintf("{\"id\":\"%d\"}\n", id)))
+	return e
+}
+
+func testSpooledBatches(t *testing.T, s *httpSink) []string {
+	t.Helper()
+	spooled, err := s.spooledBatches()
+	require.NoError(t, err)
+	return spooled
+}
+
+func Test_httpSink(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	testLogger := hclog.New(&hclog.LoggerOptions{
+		Mutex: &sync.Mutex{},
+		Name:  "test",
+	})
+
+	t.Run("batch-size", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			Headers:       map[string]string{"Authorization": "Bearer token"},
+			BatchSize:     2,
+			BatchInterval: time.Hour,
+		})
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		batches, _ := ep.received()
+		assert.Empty(batches)
+
+		_, err = s.Process(ctx, testHttpEvent(2))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		batches, headers := ep.received()
+		require.Len(batches, 1)
+		assert.Equal([]map[string]any{{"id": "1"}, {"id": "2"}}, batches[0])
+		assert.Equal("Bearer token", headers[0].Get("Authorization"))
+		assert.Equal(httpBatchContentType, headers[0].Get("Content-Type"))
+	})
+	t.Run("batch-interval", func(t *testing.T) {
+		require := require.New(t)
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			BatchSize:     10,
+			BatchInterval: 10 * time.Millisecond,
+		})
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		require.Eventually(func() bool {
+			batches, _ := ep.received()
+			return len(batches) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("flush-all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			BatchSize:     10,
+			BatchInterval: time.Hour,
+		})
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		batches, _ := ep.received()
+		require.Len(batches, 1)
+		assert.Equal([]map[string]any{{"id": "1"}}, batches[0])
+	})
+	t.Run("spool", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ep := newTestHttpEndpoint(t)
+		ep.failing.Store(true)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:               ep.URL,
+			BatchSize:         1,
+			BatchInterval:     time.Hour,
+			DeliveryGuarantee: Enforced,
+			SpoolPath:         t.TempDir(),
+		})
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		_, err = s.Process(ctx, testHttpEvent(2))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		assert.Len(testSpooledBatches(t, s), 2)
+
+		ep.failing.Store(false)
+		_, err = s.Process(ctx, testHttpEvent(3))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		assert.Empty(testSpooledBatches(t, s))
+		batches, _ := ep.received()
+		assert.Equal([][]map[string]any{{{"id": "1"}}, {{"id": "2"}}, {{"id": "3"}}}, batches)
+	})
+	t.Run("spooled-before-restart", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ep := newTestHttpEndpoint(t)
+		ep.failing.Store(true)
+		spoolPath := t.TempDir()
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			BatchSize:     1,
+			BatchInterval: time.Hour,
+			SpoolPath:     spoolPath,
+		})
+		require.NoError(err)
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		require.NoError(s.FlushAll(ctx))
+		assert.Len(testSpooledBatches(t, s), 1)
+
+		ep.failing.Store(false)
+		s, err = newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			BatchSize:     1,
+			BatchInterval: 10 * time.Millisecond,
+			SpoolPath:     spoolPath,
+		})
+		require.NoError(err)
+		require.Eventually(func() bool {
+			batches, _ := ep.received()
+			return len(batches) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Empty(testSpooledBatches(t, s))
+	})
+	t.Run("spool-failure", func(t *testing.T) {
+		tests := []struct {
+			name              string
+			deliveryGuarantee DeliveryGuarantee
+			wantErrIs         []error
+		}{
+			{
+				name:              "best-effort",
+				deliveryGuarantee: BestEffort,
+			},
+			{
+				name:              "enforced",
+				deliveryGuarantee: Enforced,
+				wantErrIs:         []error{os.ErrNotExist, context.DeadlineExceeded},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				ep := newTestHttpEndpoint(t)
+				ep.failing.Store(true)
+				spoolPath := t.TempDir()
+				s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+					Url:               ep.URL,
+					BatchSize:         1,
+					BatchInterval:     time.Hour,
+					DeliveryGuarantee: tt.deliveryGuarantee,
+					SpoolPath:         spoolPath,
+				})
+				require.NoError(err)
+				require.NoError(os.RemoveAll(spoolPath))
+
+				// the first batch keeps the sender busy, so the next ones
+				// fill the queue and then have to be spooled.
+				ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+				defer cancel()
+				for i := 0; i < httpSinkQueueSize+2 && err == nil; i++ {
+					_, err = s.Process(ctx, testHttpEvent(i))
+				}
+				if tt.wantErrIs != nil {
+					require.Error(err)
+					for _, want := range tt.wantErrIs {
+						assert.ErrorIs(err, want)
+					}
+					return
+				}
+				require.NoError(err)
+			})
+		}
+	})
+	t.Run("blocking-endpoint", func(t *testing.T) {
+		tests := []struct {
+			name              string
+			deliveryGuarantee DeliveryGuarantee
+			spool             bool
+		}{
+			{
+				name:              "best-effort",
+				deliveryGuarantee: BestEffort,
+			},
+			{
+				name:              "enforced",
+				deliveryGuarantee: Enforced,
+				spool:             true,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+				release := make(chan struct{})
+				ep := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					<-release
+				}))
+				t.Cleanup(ep.Close)
+				t.Cleanup(func() { close(release) })
+				c := &HttpSinkTypeConfig{
+					Url:               ep.URL,
+					BatchSize:         1,
+					BatchInterval:     time.Hour,
+					DeliveryGuarantee: tt.deliveryGuarantee,
+				}
+				if tt.spool {
+					c.SpoolPath = t.TempDir()
+				}
+				s, err := newHttpSink(testLogger, JSONSinkFormat, c)
+				require.NoError(err)
+
+				start := time.Now()
+				for i := 0; i < 2*httpSinkQueueSize; i++ {
+					_, err := s.Process(ctx, testHttpEvent(i))
+					require.NoError(err)
+				}
+				assert.Less(time.Since(start), time.Second)
+				if tt.spool {
+					assert.NotEmpty(testSpooledBatches(t, s))
+				}
+			})
+		}
+	})
+	t.Run("close", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:           ep.URL,
+			BatchSize:     2,
+			BatchInterval: time.Hour,
+		})
+		require.NoError(err)
+
+		for i := 1; i <= 3; i++ {
+			_, err = s.Process(ctx, testHttpEvent(i))
+			require.NoError(err)
+		}
+		require.NoError(s.Close(ctx))
+		assert.True(isClosed(s.stopped))
+		batches, _ := ep.received()
+		assert.Equal([][]map[string]any{{{"id": "1"}, {"id": "2"}}, {{"id": "3"}}}, batches)
+
+		_, err = s.Process(ctx, testHttpEvent(4))
+		assert.ErrorIs(err, errHttpSinkClosed)
+		assert.NoError(s.FlushAll(ctx))
+		assert.NoError(s.Close(ctx))
+	})
+	t.Run("close-timeout", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		release := make(chan struct{})
+		ep := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(ep.Close)
+		t.Cleanup(func() { close(release) })
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url:               ep.URL,
+			BatchSize:         10,
+			BatchInterval:     time.Hour,
+			DeliveryGuarantee: Enforced,
+			SpoolPath:         t.TempDir(),
+		})
+		require.NoError(err)
+
+		_, err = s.Process(ctx, testHttpEvent(1))
+		require.NoError(err)
+		closeCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err = s.Close(closeCtx)
+		assert.ErrorIs(err, context.DeadlineExceeded)
+		assert.True(isClosed(s.stopped))
+		// the batch which couldn't be sent before the timeout is spooled,
+		// so it's resent when the sink is created again.
+		assert.Len(testSpooledBatches(t, s), 1)
+	})
+	t.Run("removed-node", func(t *testing.T) {
+		require := require.New(t)
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url: ep.URL,
+		})
+		require.NoError(err)
+		b, err := eventlogger.NewBroker()
+		require.NoError(err)
+		require.NoError(b.RegisterNode("http", s))
+
+		require.NoError(b.RemoveNode(ctx, "http"))
+		assert.True(t, isClosed(s.stopped))
+	})
+	t.Run("missing-format", func(t *testing.T) {
+		ep := newTestHttpEndpoint(t)
+		s, err := newHttpSink(testLogger, JSONSinkFormat, &HttpSinkTypeConfig{
+			Url: ep.URL,
+		})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, &eventlogger.Event{})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidParameter)
+	})
+}
+
+// isClosed returns whether the channel is closed.
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
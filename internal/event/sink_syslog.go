@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+)
+
+const (
+	// defaultSyslogNetwork is the network of a syslog sink which doesn't
+	// define one.
+	defaultSyslogNetwork = "udp"
+
+	// defaultSyslogFacility is the facility of a syslog sink which doesn't
+	// define one.
+	defaultSyslogFacility = "local0"
+
+	// defaultSyslogTag is the tag (APP-NAME) of a syslog sink which doesn't
+	// define one.
+	defaultSyslogTag = "boundary"
+
+	// syslogTimeout is the timeout for connecting to and writing to a syslog
+	// server.
+	syslogTimeout = 10 * time.Second
+
+	// syslogTimeFormat is the RFC 5424 timestamp format, which allows at most
+	// microsecond precision.
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	// syslogNilValue is the RFC 5424 NILVALUE.
+	syslogNilValue = "-"
+)
+
+// syslogFacilities maps the facility names of a syslog sink config to their
+// RFC 5424 facility codes.
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// RFC 5424 severities
+const (
+	syslogSeverityError  = 3
+	syslogSeverityNotice = 5
+	syslogSeverityInfo   = 6
+)
+
+// syslogSeverity returns the severity of syslog messages for events of type
+// t.
+func syslogSeverity(t eventlogger.EventType) int {
+	switch Type(t) {
+	case ErrorType:
+		return syslogSeverityError
+	case AuditType:
+		return syslogSeverityNotice
+	default:
+		return syslogSeverityInfo
+	}
+}
+
+// syslogSink is an eventlogger sink node which sends events as RFC 5424
+// syslog messages. Messages are sent as a single datagram over udp and with
+// RFC 6587 octet counting framing over tcp and tls.
+type syslogSink struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	facility  int
+	tag       string
+	hostname  string
+	procId    string
+	format    string
+
+	l    sync.Mutex
+	conn net.Conn
+}
+
+var _ eventlogger.Node = (*syslogSink)(nil)
+
+func newSyslogSink(format SinkFormat, c *SyslogSinkTypeConfig) (*syslogSink, error) {
+	const op = "event.newSyslogSink"
+	if c == nil {
+		return nil, fmt.Errorf("%s: missing syslog sink config: %w", op, ErrInvalidParameter)
+	}
+	s := &syslogSink{
+		network:  c.Network,
+		address:  c.Address,
+		facility: syslogFacilities[defaultSyslogFacility],
+		tag:      c.Tag,
+		procId:   strconv.Itoa(os.Getpid()),
+		format:   string(format),
+	}
+	if s.network == "" {
+		s.network = defaultSyslogNetwork
+	}
+	if c.Facility != "" {
+		facility, ok := syslogFacilities[c.Facility]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown facility %q: %w", op, c.Facility, ErrInvalidParameter)
+		}
+		s.facility = facility
+	}
+	if s.tag == "" {
+		s.tag = defaultSyslogTag
+	}
+	if s.network == "tls" {
+		tlsConfig, err := clientTlsConfig(c.TlsCaFile, c.TlsCertFile, c.TlsKeyFile, c.TlsServerName, c.TlsSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		s.tlsConfig = tlsConfig
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = syslogNilValue
+	}
+	s.hostname = hostname
+	return s, nil
+}
+
+// Reopen closes the connection to the syslog server, so it's reestablished
+// when the next event is processed.
+func (s *syslogSink) Reopen() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.closeConn()
+}
+
+// Type defines the syslogSink as a NodeTypeSink
+func (s *syslogSink) Type() eventlogger.NodeType {
+	return eventlogger.NodeTypeSink
+}
+
+// Process sends the event to the syslog server. A message which can't be
+// written is retried once over a new connection.
+func (s *syslogSink) Process(ctx context.Context, e *eventlogger.Event) (*eventlogger.Event, error) {
+	const op = "event.(syslogSink).Process"
+	if e == nil {
+		return nil, fmt.Errorf("%s: missing event: %w", op, ErrInvalidParameter)
+	}
+	val, ok := e.Format(s.format)
+	if !ok {
+		return nil, fmt.Errorf("%s: event was not marshaled as %s: %w", op, s.format, ErrInvalidParameter)
+	}
+	msg := s.message(e, bytes.TrimSpace(val))
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	var err error
+	for attempts := 0; attempts < 2; attempts++ {
+		if err = s.write(ctx, msg); err == nil {
+			// Sinks are leafs, so do not return the event, since nothing more
+			// can happen to it downstream.
+			return nil, nil
+		}
+		_ = s.closeConn()
+	}
+	return nil, fmt.Errorf("%s: %w", op, err)
+}
+
+// message returns the RFC 5424 syslog message of the event with the
+// formatted event as MSG.
+func (s *syslogSink) message(e *eventlogger.Event, val []byte) []byte {
+	createdAt := e.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	msgId := string(e.Type)
+	if msgId == "" {
+		msgId = syslogNilValue
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %s %s %s ",
+		s.facility*8+syslogSeverity(e.Type),
+		createdAt.UTC().Format(syslogTimeFormat),
+		s.hostname,
+		s.tag,
+		s.procId,
+		msgId,
+		syslogNilValue, // STRUCTURED-DATA
+	)
+	buf.Write(val)
+	return buf.Bytes()
+}
+
+// write sends msg to the syslog server, connecting to it first if needed.
+// The caller must hold the lock.
+func (s *syslogSink) write(ctx context.Context, msg []byte) error {
+	const op = "event.(syslogSink).write"
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		s.conn = conn
+	}
+	if s.network != "udp" {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	const op = "event.(syslogSink).dial"
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	var conn net.Conn
+	var err error
+	switch s.network {
+	case "tls":
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.address)
+	default:
+		conn, err = dialer.DialContext(ctx, s.network, s.address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to connect to %s over %s: %w", op, s.address, s.network, err)
+	}
+	return conn, nil
+}
+
+// closeConn closes the connection to the syslog server. The caller must hold
+// the lock.
+func (s *syslogSink) closeConn() error {
+	const op = "event.(syslogSink).closeConn"
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package event
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/eventlogger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSyslogListener starts a syslog server on network and returns its
+// address and a channel of the messages it receives.
+func testSyslogListener(t *testing.T, network string, tlsConfig *tls.Config) (string, <-chan string) {
+	t.Helper()
+	msgs := make(chan string, 10)
+	if network == "udp" {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { pc.Close() })
+		go func() {
+			buf := make([]byte, 64*1024)
+			for {
+				n, _, err := pc.ReadFrom(buf)
+				if err != nil {
+					return
+				}
+				msgs <- string(buf[:n])
+			}
+		}()
+		return pc.LocalAddr().String(), msgs
+	}
+
+	var l net.Listener
+	var err error
+	switch network {
+	case "tls":
+		l, err = tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	default:
+		l, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					// messages are framed with octet counting
+					l, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(l))
+					if err != nil {
+						return
+					}
+					buf := make([]byte, n)
+					if _, err := io.ReadFull(r, buf); err != nil {
+						return
+					}
+					msgs <- string(buf)
+				}
+			}()
+		}
+	}()
+	return l.Addr().String(), msgs
+}
+
+// testSyslogCert returns a TLS config with a self-signed certificate for
+// 127.0.0.1 and the path of a file with the certificate in PEM format.
+func testSyslogCert(t *testing.T) (*tls.Config, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}, caFile
+}
+
+func testSyslogEvent(t Type, formatted string) *eventlogger.Event {
+	e := &eventlogger.Event{
+		Type:      eventlogger.EventType(t),
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+	}
+	e.FormattedAs(string(JSONSinkFormat), []byte(formatted+"\n"))
+	e.FormattedAs(string(TextHclogSinkFormat), []byte(formatted+"\n"))
+	return e
+}
+
+func Test_syslogSink(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	serverTlsConfig, caFile := testSyslogCert(t)
+
+	tests := []struct {
+		name      string
+		format    SinkFormat
+		config    SyslogSinkTypeConfig
+		eventType Type
+		wantPri   int
+		wantTag   string
+	}{
+		{
+			name:      "udp-audit",
+			format:    JSONSinkFormat,
+			config:    SyslogSinkTypeConfig{Network: "udp"},
+			eventType: AuditType,
+			wantPri:   16*8 + 5,
+			wantTag:   "boundary",
+		},
+		{
+			name:      "tcp-error",
+			format:    JSONSinkFormat,
+			config:    SyslogSinkTypeConfig{Network: "tcp", Facility: "auth", Tag: "controller"},
+			eventType: ErrorType,
+			wantPri:   4*8 + 3,
+			wantTag:   "controller",
+		},
+		{
+			name:      "tls-observation",
+			format:    JSONSinkFormat,
+			config:    SyslogSinkTypeConfig{Network: "tls", Facility: "local7", TlsCaFile: caFile},
+			eventType: ObservationType,
+			wantPri:   23*8 + 6,
+			wantTag:   "boundary",
+		},
+		{
+			name:      "hclog-system",
+			format:    TextHclogSinkFormat,
+			config:    SyslogSinkTypeConfig{Network: "tcp"},
+			eventType: SystemType,
+			wantPri:   16*8 + 6,
+			wantTag:   "boundary",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			network := tt.config.Network
+			addr, msgs := testSyslogListener(t, network, serverTlsConfig)
+			tt.config.Address = addr
+			require.NoError(tt.config.validate())
+
+			s, err := newSyslogSink(tt.format, &tt.config)
+			require.NoError(err)
+			t.Cleanup(func() { s.Reopen() })
+
+			for i := 0; i < 2; i++ {
+				_, err = s.Process(ctx, testSyslogEvent(tt.eventType, fmt.Sprintf(`{"id":"%d"}`, i)))
+				require.NoError(err)
+			}
+			for i := 0; i < 2; i++ {
+				select {
+				case msg := <-msgs:
+					want := fmt.Sprintf(`<%d>1 2024-01-02T03:04:05.000006Z %s %s %d %s - {"id":"%d"}`,
+						tt.wantPri, hostname, tt.wantTag, os.Getpid(), tt.eventType, i)
+					assert.Equal(want, msg)
+				case <-time.After(5 * time.Second):
+					require.FailNow("timed out waiting for syslog message")
+				}
+			}
+		})
+	}
+	t.Run("reconnect", func(t *testing.T) {
+		require := require.New(t)
+		addr, msgs := testSyslogListener(t, "tcp", nil)
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "tcp", Address: addr})
+		require.NoError(err)
+		t.Cleanup(func() { s.Reopen() })
+
+		_, err = s.Process(ctx, testSyslogEvent(AuditType, `{"id":"1"}`))
+		require.NoError(err)
+		<-msgs
+		require.NoError(s.Reopen())
+		_, err = s.Process(ctx, testSyslogEvent(AuditType, `{"id":"2"}`))
+		require.NoError(err)
+		select {
+		case msg := <-msgs:
+			require.Contains(msg, `{"id":"2"}`)
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for syslog message")
+		}
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		require.NoError(t, l.Close())
+
+		s, err := newSyslogSink(JSONSinkFormat, &SyslogSinkTypeConfig{Network: "tcp", Address: addr})
+		require.NoError(t, err)
+		_, err = s.Process(ctx, testSyslogEvent(AuditType, `{"id":"1"}`))
+		require.Error(t, err)
+	})
+}
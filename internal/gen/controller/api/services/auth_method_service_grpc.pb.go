@@ -22,13 +22,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AuthMethodService_GetAuthMethod_FullMethodName    = "/controller.api.services.v1.AuthMethodService/GetAuthMethod"
-	AuthMethodService_ListAuthMethods_FullMethodName  = "/controller.api.services.v1.AuthMethodService/ListAuthMethods"
-	AuthMethodService_CreateAuthMethod_FullMethodName = "/controller.api.services.v1.AuthMethodService/CreateAuthMethod"
-	AuthMethodService_UpdateAuthMethod_FullMethodName = "/controller.api.services.v1.AuthMethodService/UpdateAuthMethod"
-	AuthMethodService_DeleteAuthMethod_FullMethodName = "/controller.api.services.v1.AuthMethodService/DeleteAuthMethod"
-	AuthMethodService_ChangeState_FullMethodName      = "/controller.api.services.v1.AuthMethodService/ChangeState"
-	AuthMethodService_Authenticate_FullMethodName     = "/controller.api.services.v1.AuthMethodService/Authenticate"
+	AuthMethodService_GetAuthMethod_FullMethodName          = "/controller.api.services.v1.AuthMethodService/GetAuthMethod"
+	AuthMethodService_ListAuthMethods_FullMethodName        = "/controller.api.services.v1.AuthMethodService/ListAuthMethods"
+	AuthMethodService_CreateAuthMethod_FullMethodName       = "/controller.api.services.v1.AuthMethodService/CreateAuthMethod"
+	AuthMethodService_UpdateAuthMethod_FullMethodName       = "/controller.api.services.v1.AuthMethodService/UpdateAuthMethod"
+	AuthMethodService_DeleteAuthMethod_FullMethodName       = "/controller.api.services.v1.AuthMethodService/DeleteAuthMethod"
+	AuthMethodService_ChangeState_FullMethodName            = "/controller.api.services.v1.AuthMethodService/ChangeState"
+	AuthMethodService_Authenticate_FullMethodName           = "/controller.api.services.v1.AuthMethodService/Authenticate"
+	AuthMethodService_RevokeAuthMethodTokens_FullMethodName = "/controller.api.services.v1.AuthMethodService/RevokeAuthMethodTokens"
 )
 
 // AuthMethodServiceClient is the client API for AuthMethodService service.
@@ -65,6 +66,11 @@ type AuthMethodServiceClient interface {
 	ChangeState(ctx context.Context, in *ChangeStateRequest, opts ...grpc.CallOption) (*ChangeStateResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// RevokeAuthMethodTokens deletes every Auth Token issued by the specified
+	// Auth Method and cancels the Sessions created with them which are not
+	// already canceling or terminated. The ids of the revoked Auth Tokens and
+	// canceled Sessions are returned.
+	RevokeAuthMethodTokens(ctx context.Context, in *RevokeAuthMethodTokensRequest, opts ...grpc.CallOption) (*RevokeAuthMethodTokensResponse, error)
 }
 
 type authMethodServiceClient struct {
@@ -138,6 +144,15 @@ func (c *authMethodServiceClient) Authenticate(ctx context.Context, in *Authenti
 	return out, nil
 }
 
+func (c *authMethodServiceClient) RevokeAuthMethodTokens(ctx context.Context, in *RevokeAuthMethodTokensRequest, opts ...grpc.CallOption) (*RevokeAuthMethodTokensResponse, error) {
+	out := new(RevokeAuthMethodTokensResponse)
+	err := c.cc.Invoke(ctx, AuthMethodService_RevokeAuthMethodTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthMethodServiceServer is the server API for AuthMethodService service.
 // All implementations must embed UnimplementedAuthMethodServiceServer
 // for forward compatibility
@@ -172,6 +187,11 @@ type AuthMethodServiceServer interface {
 	ChangeState(context.Context, *ChangeStateRequest) (*ChangeStateResponse, error)
 	// Authenticate validates credentials provided and returns an Auth Token.
 	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// RevokeAuthMethodTokens deletes every Auth Token issued by the specified
+	// Auth Method and cancels the Sessions created with them which are not
+	// already canceling or terminated. The ids of the revoked Auth Tokens and
+	// canceled Sessions are returned.
+	RevokeAuthMethodTokens(context.Context, *RevokeAuthMethodTokensRequest) (*RevokeAuthMethodTokensResponse, error)
 	mustEmbedUnimplementedAuthMethodServiceServer()
 }
 
@@ -200,6 +220,9 @@ func (UnimplementedAuthMethodServiceServer) ChangeState(context.Context, *Change
 func (UnimplementedAuthMethodServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
 }
+func (UnimplementedAuthMethodServiceServer) RevokeAuthMethodTokens(context.Context, *RevokeAuthMethodTokensRequest) (*RevokeAuthMethodTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAuthMethodTokens not implemented")
+}
 func (UnimplementedAuthMethodServiceServer) mustEmbedUnimplementedAuthMethodServiceServer() {}
 
 // UnsafeAuthMethodServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -339,6 +362,24 @@ func _AuthMethodService_Authenticate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthMethodService_RevokeAuthMethodTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAuthMethodTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).RevokeAuthMethodTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthMethodService_RevokeAuthMethodTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).RevokeAuthMethodTokens(ctx, req.(*RevokeAuthMethodTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthMethodService_ServiceDesc is the grpc.ServiceDesc for AuthMethodService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -374,6 +415,10 @@ var AuthMethodService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authenticate",
 			Handler:    _AuthMethodService_Authenticate_Handler,
 		},
+		{
+			MethodName: "RevokeAuthMethodTokens",
+			Handler:    _AuthMethodService_RevokeAuthMethodTokens_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/auth_method_service.proto",
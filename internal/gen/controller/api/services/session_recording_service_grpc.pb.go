@@ -23,9 +23,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SessionRecordingService_GetSessionRecording_FullMethodName   = "/controller.api.services.v1.SessionRecordingService/GetSessionRecording"
-	SessionRecordingService_ListSessionRecordings_FullMethodName = "/controller.api.services.v1.SessionRecordingService/ListSessionRecordings"
-	SessionRecordingService_Download_FullMethodName              = "/controller.api.services.v1.SessionRecordingService/Download"
+	SessionRecordingService_GetSessionRecording_FullMethodName     = "/controller.api.services.v1.SessionRecordingService/GetSessionRecording"
+	SessionRecordingService_ListSessionRecordings_FullMethodName   = "/controller.api.services.v1.SessionRecordingService/ListSessionRecordings"
+	SessionRecordingService_Download_FullMethodName                = "/controller.api.services.v1.SessionRecordingService/Download"
+	SessionRecordingService_SearchSessionRecordings_FullMethodName = "/controller.api.services.v1.SessionRecordingService/SearchSessionRecordings"
 )
 
 // SessionRecordingServiceClient is the client API for SessionRecordingService service.
@@ -44,8 +45,14 @@ type SessionRecordingServiceClient interface {
 	// Supports both Session ID and Session recording ID for looking up a Session recording.
 	// Supports both Connection ID and Connection recording ID to look up a Connection recording.
 	// A Channel recording ID is required to look up a Channel recording.
-	// The only supported mime type is "application/x-asciicast".
+	// Supported mime types are "application/x-asciicast" for shell channels, "text/plain"
+	// for a timestamped transcript of the data of shell and exec channels and
+	// "application/jsonl" for the requests of session channels.
 	Download(ctx context.Context, in *DownloadRequest, opts ...grpc.CallOption) (SessionRecordingService_DownloadClient, error)
+	// SearchSessionRecordings returns the lines of output of the shell and exec Channels
+	// of Session recordings which contain the query, ignoring case. Only Session recordings
+	// which were indexed after they became available and which the caller can download are searched.
+	SearchSessionRecordings(ctx context.Context, in *SearchSessionRecordingsRequest, opts ...grpc.CallOption) (*SearchSessionRecordingsResponse, error)
 }
 
 type sessionRecordingServiceClient struct {
@@ -106,6 +113,15 @@ func (x *sessionRecordingServiceDownloadClient) Recv() (*httpbody.HttpBody, erro
 	return m, nil
 }
 
+func (c *sessionRecordingServiceClient) SearchSessionRecordings(ctx context.Context, in *SearchSessionRecordingsRequest, opts ...grpc.CallOption) (*SearchSessionRecordingsResponse, error) {
+	out := new(SearchSessionRecordingsResponse)
+	err := c.cc.Invoke(ctx, SessionRecordingService_SearchSessionRecordings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionRecordingServiceServer is the server API for SessionRecordingService service.
 // All implementations must embed UnimplementedSessionRecordingServiceServer
 // for forward compatibility
@@ -122,8 +138,14 @@ type SessionRecordingServiceServer interface {
 	// Supports both Session ID and Session recording ID for looking up a Session recording.
 	// Supports both Connection ID and Connection recording ID to look up a Connection recording.
 	// A Channel recording ID is required to look up a Channel recording.
-	// The only supported mime type is "application/x-asciicast".
+	// Supported mime types are "application/x-asciicast" for shell channels, "text/plain"
+	// for a timestamped transcript of the data of shell and exec channels and
+	// "application/jsonl" for the requests of session channels.
 	Download(*DownloadRequest, SessionRecordingService_DownloadServer) error
+	// SearchSessionRecordings returns the lines of output of the shell and exec Channels
+	// of Session recordings which contain the query, ignoring case. Only Session recordings
+	// which were indexed after they became available and which the caller can download are searched.
+	SearchSessionRecordings(context.Context, *SearchSessionRecordingsRequest) (*SearchSessionRecordingsResponse, error)
 	mustEmbedUnimplementedSessionRecordingServiceServer()
 }
 
@@ -140,6 +162,9 @@ func (UnimplementedSessionRecordingServiceServer) ListSessionRecordings(context.
 func (UnimplementedSessionRecordingServiceServer) Download(*DownloadRequest, SessionRecordingService_DownloadServer) error {
 	return status.Errorf(codes.Unimplemented, "method Download not implemented")
 }
+func (UnimplementedSessionRecordingServiceServer) SearchSessionRecordings(context.Context, *SearchSessionRecordingsRequest) (*SearchSessionRecordingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSessionRecordings not implemented")
+}
 func (UnimplementedSessionRecordingServiceServer) mustEmbedUnimplementedSessionRecordingServiceServer() {
 }
 
@@ -211,6 +236,24 @@ func (x *sessionRecordingServiceDownloadServer) Send(m *httpbody.HttpBody) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _SessionRecordingService_SearchSessionRecordings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSessionRecordingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionRecordingServiceServer).SearchSessionRecordings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionRecordingService_SearchSessionRecordings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionRecordingServiceServer).SearchSessionRecordings(ctx, req.(*SearchSessionRecordingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionRecordingService_ServiceDesc is the grpc.ServiceDesc for SessionRecordingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -226,6 +269,10 @@ var SessionRecordingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessionRecordings",
 			Handler:    _SessionRecordingService_ListSessionRecordings_Handler,
 		},
+		{
+			MethodName: "SearchSessionRecordings",
+			Handler:    _SessionRecordingService_SearchSessionRecordings_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -25,6 +25,7 @@ const (
 	SessionService_GetSession_FullMethodName    = "/controller.api.services.v1.SessionService/GetSession"
 	SessionService_ListSessions_FullMethodName  = "/controller.api.services.v1.SessionService/ListSessions"
 	SessionService_CancelSession_FullMethodName = "/controller.api.services.v1.SessionService/CancelSession"
+	SessionService_WatchSession_FullMethodName  = "/controller.api.services.v1.SessionService/WatchSession"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -45,6 +46,13 @@ type SessionServiceClient interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(ctx context.Context, in *CancelSessionRequest, opts ...grpc.CallOption) (*CancelSessionResponse, error)
+	// WatchSession returns the output sent to the client of an active SSH
+	// Session. The first request creates a watch of the Session and returns
+	// its id, which must be provided with the position returned by the
+	// previous call to receive the output which followed. Watching a Session
+	// is read-only; no input can be sent to the Session. The request waits
+	// for a while for output to become available.
+	WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (*WatchSessionResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -82,6 +90,15 @@ func (c *sessionServiceClient) CancelSession(ctx context.Context, in *CancelSess
 	return out, nil
 }
 
+func (c *sessionServiceClient) WatchSession(ctx context.Context, in *WatchSessionRequest, opts ...grpc.CallOption) (*WatchSessionResponse, error) {
+	out := new(WatchSessionResponse)
+	err := c.cc.Invoke(ctx, SessionService_WatchSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -100,6 +117,13 @@ type SessionServiceServer interface {
 	// is returned if the request attempts to cancel a Session that does
 	// not exist.
 	CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error)
+	// WatchSession returns the output sent to the client of an active SSH
+	// Session. The first request creates a watch of the Session and returns
+	// its id, which must be provided with the position returned by the
+	// previous call to receive the output which followed. Watching a Session
+	// is read-only; no input can be sent to the Session. The request waits
+	// for a while for output to become available.
+	WatchSession(context.Context, *WatchSessionRequest) (*WatchSessionResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -116,6 +140,9 @@ func (UnimplementedSessionServiceServer) ListSessions(context.Context, *ListSess
 func (UnimplementedSessionServiceServer) CancelSession(context.Context, *CancelSessionRequest) (*CancelSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelSession not implemented")
 }
+func (UnimplementedSessionServiceServer) WatchSession(context.Context, *WatchSessionRequest) (*WatchSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WatchSession not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -183,6 +210,24 @@ func _SessionService_CancelSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_WatchSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WatchSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).WatchSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_WatchSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).WatchSession(ctx, req.(*WatchSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -202,6 +247,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelSession",
 			Handler:    _SessionService_CancelSession_Handler,
 		},
+		{
+			MethodName: "WatchSession",
+			Handler:    _SessionService_WatchSession_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/session_service.proto",
@@ -22,14 +22,16 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	UserService_GetUser_FullMethodName            = "/controller.api.services.v1.UserService/GetUser"
-	UserService_ListUsers_FullMethodName          = "/controller.api.services.v1.UserService/ListUsers"
-	UserService_CreateUser_FullMethodName         = "/controller.api.services.v1.UserService/CreateUser"
-	UserService_UpdateUser_FullMethodName         = "/controller.api.services.v1.UserService/UpdateUser"
-	UserService_DeleteUser_FullMethodName         = "/controller.api.services.v1.UserService/DeleteUser"
-	UserService_AddUserAccounts_FullMethodName    = "/controller.api.services.v1.UserService/AddUserAccounts"
-	UserService_SetUserAccounts_FullMethodName    = "/controller.api.services.v1.UserService/SetUserAccounts"
-	UserService_RemoveUserAccounts_FullMethodName = "/controller.api.services.v1.UserService/RemoveUserAccounts"
+	UserService_GetUser_FullMethodName                = "/controller.api.services.v1.UserService/GetUser"
+	UserService_ListUsers_FullMethodName              = "/controller.api.services.v1.UserService/ListUsers"
+	UserService_CreateUser_FullMethodName             = "/controller.api.services.v1.UserService/CreateUser"
+	UserService_UpdateUser_FullMethodName             = "/controller.api.services.v1.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName             = "/controller.api.services.v1.UserService/DeleteUser"
+	UserService_AddUserAccounts_FullMethodName        = "/controller.api.services.v1.UserService/AddUserAccounts"
+	UserService_SetUserAccounts_FullMethodName        = "/controller.api.services.v1.UserService/SetUserAccounts"
+	UserService_RemoveUserAccounts_FullMethodName     = "/controller.api.services.v1.UserService/RemoveUserAccounts"
+	UserService_RevokeUserTokens_FullMethodName       = "/controller.api.services.v1.UserService/RevokeUserTokens"
+	UserService_ExplainUserPermissions_FullMethodName = "/controller.api.services.v1.UserService/ExplainUserPermissions"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -84,6 +86,16 @@ type UserServiceClient interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(ctx context.Context, in *RemoveUserAccountsRequest, opts ...grpc.CallOption) (*RemoveUserAccountsResponse, error)
+	// RevokeUserTokens deletes every Auth Token of the specified User and
+	// cancels the User's Sessions which are not already canceling or
+	// terminated. The ids of the revoked Auth Tokens and canceled Sessions are
+	// returned.
+	RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error)
+	// ExplainUserPermissions evaluates the grants of the specified User against
+	// the provided resource. If an action is provided, each grant considered
+	// while authorizing the action is returned along with whether and why it
+	// matched. Every action the User can perform on the resource is returned.
+	ExplainUserPermissions(ctx context.Context, in *ExplainUserPermissionsRequest, opts ...grpc.CallOption) (*ExplainUserPermissionsResponse, error)
 }
 
 type userServiceClient struct {
@@ -166,6 +178,24 @@ func (c *userServiceClient) RemoveUserAccounts(ctx context.Context, in *RemoveUs
 	return out, nil
 }
 
+func (c *userServiceClient) RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error) {
+	out := new(RevokeUserTokensResponse)
+	err := c.cc.Invoke(ctx, UserService_RevokeUserTokens_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ExplainUserPermissions(ctx context.Context, in *ExplainUserPermissionsRequest, opts ...grpc.CallOption) (*ExplainUserPermissionsResponse, error) {
+	out := new(ExplainUserPermissionsResponse)
+	err := c.cc.Invoke(ctx, UserService_ExplainUserPermissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -218,6 +248,16 @@ type UserServiceServer interface {
 	// will be removed from. If the provided Account ids is not associated with the
 	// provided User, an error is returned.
 	RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error)
+	// RevokeUserTokens deletes every Auth Token of the specified User and
+	// cancels the User's Sessions which are not already canceling or
+	// terminated. The ids of the revoked Auth Tokens and canceled Sessions are
+	// returned.
+	RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error)
+	// ExplainUserPermissions evaluates the grants of the specified User against
+	// the provided resource. If an action is provided, each grant considered
+	// while authorizing the action is returned along with whether and why it
+	// matched. Every action the User can perform on the resource is returned.
+	ExplainUserPermissions(context.Context, *ExplainUserPermissionsRequest) (*ExplainUserPermissionsResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -249,6 +289,12 @@ func (UnimplementedUserServiceServer) SetUserAccounts(context.Context, *SetUserA
 func (UnimplementedUserServiceServer) RemoveUserAccounts(context.Context, *RemoveUserAccountsRequest) (*RemoveUserAccountsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserAccounts not implemented")
 }
+func (UnimplementedUserServiceServer) RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
+func (UnimplementedUserServiceServer) ExplainUserPermissions(context.Context, *ExplainUserPermissionsRequest) (*ExplainUserPermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainUserPermissions not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -406,6 +452,42 @@ func _UserService_RemoveUserAccounts_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeUserTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeUserTokens(ctx, req.(*RevokeUserTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ExplainUserPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainUserPermissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ExplainUserPermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ExplainUserPermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ExplainUserPermissions(ctx, req.(*ExplainUserPermissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -445,6 +527,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveUserAccounts",
 			Handler:    _UserService_RemoveUserAccounts_Handler,
 		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _UserService_RevokeUserTokens_Handler,
+		},
+		{
+			MethodName: "ExplainUserPermissions",
+			Handler:    _UserService_ExplainUserPermissions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/user_service.proto",
@@ -22,12 +22,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SessionService_LookupSession_FullMethodName       = "/controller.servers.services.v1.SessionService/LookupSession"
-	SessionService_ActivateSession_FullMethodName     = "/controller.servers.services.v1.SessionService/ActivateSession"
-	SessionService_CancelSession_FullMethodName       = "/controller.servers.services.v1.SessionService/CancelSession"
-	SessionService_AuthorizeConnection_FullMethodName = "/controller.servers.services.v1.SessionService/AuthorizeConnection"
-	SessionService_ConnectConnection_FullMethodName   = "/controller.servers.services.v1.SessionService/ConnectConnection"
-	SessionService_CloseConnection_FullMethodName     = "/controller.servers.services.v1.SessionService/CloseConnection"
+	SessionService_LookupSession_FullMethodName         = "/controller.servers.services.v1.SessionService/LookupSession"
+	SessionService_ActivateSession_FullMethodName       = "/controller.servers.services.v1.SessionService/ActivateSession"
+	SessionService_CancelSession_FullMethodName         = "/controller.servers.services.v1.SessionService/CancelSession"
+	SessionService_AuthorizeConnection_FullMethodName   = "/controller.servers.services.v1.SessionService/AuthorizeConnection"
+	SessionService_ConnectConnection_FullMethodName     = "/controller.servers.services.v1.SessionService/ConnectConnection"
+	SessionService_CloseConnection_FullMethodName       = "/controller.servers.services.v1.SessionService/CloseConnection"
+	SessionService_CloseSessionRecording_FullMethodName = "/controller.servers.services.v1.SessionService/CloseSessionRecording"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -47,6 +48,9 @@ type SessionServiceClient interface {
 	ConnectConnection(ctx context.Context, in *ConnectConnectionRequest, opts ...grpc.CallOption) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(ctx context.Context, in *CloseConnectionRequest, opts ...grpc.CallOption) (*CloseConnectionResponse, error)
+	// CloseSessionRecording records the summary of a session recording once the
+	// worker has finished writing the recording to its storage bucket.
+	CloseSessionRecording(ctx context.Context, in *CloseSessionRecordingRequest, opts ...grpc.CallOption) (*CloseSessionRecordingResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -111,6 +115,15 @@ func (c *sessionServiceClient) CloseConnection(ctx context.Context, in *CloseCon
 	return out, nil
 }
 
+func (c *sessionServiceClient) CloseSessionRecording(ctx context.Context, in *CloseSessionRecordingRequest, opts ...grpc.CallOption) (*CloseSessionRecordingResponse, error) {
+	out := new(CloseSessionRecordingResponse)
+	err := c.cc.Invoke(ctx, SessionService_CloseSessionRecording_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility
@@ -128,6 +141,9 @@ type SessionServiceServer interface {
 	ConnectConnection(context.Context, *ConnectConnectionRequest) (*ConnectConnectionResponse, error)
 	// CloseConnections updates a connection to set it to closed
 	CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error)
+	// CloseSessionRecording records the summary of a session recording once the
+	// worker has finished writing the recording to its storage bucket.
+	CloseSessionRecording(context.Context, *CloseSessionRecordingRequest) (*CloseSessionRecordingResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -153,6 +169,9 @@ func (UnimplementedSessionServiceServer) ConnectConnection(context.Context, *Con
 func (UnimplementedSessionServiceServer) CloseConnection(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseConnection not implemented")
 }
+func (UnimplementedSessionServiceServer) CloseSessionRecording(context.Context, *CloseSessionRecordingRequest) (*CloseSessionRecordingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseSessionRecording not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 
 // UnsafeSessionServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -274,6 +293,24 @@ func _SessionService_CloseConnection_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_CloseSessionRecording_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseSessionRecordingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).CloseSessionRecording(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_CloseSessionRecording_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).CloseSessionRecording(ctx, req.(*CloseSessionRecordingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -305,6 +342,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseConnection",
 			Handler:    _SessionService_CloseConnection_Handler,
 		},
+		{
+			MethodName: "CloseSessionRecording",
+			Handler:    _SessionService_CloseSessionRecording_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/servers/services/v1/session_service.proto",
@@ -16,6 +16,8 @@ type mockSessionServiceClient struct {
 	AuthorizeConnectionFn func(context.Context, *AuthorizeConnectionRequest) (*AuthorizeConnectionResponse, error)
 	ConnectConnectionFn   func(context.Context, *ConnectConnectionRequest) (*ConnectConnectionResponse, error)
 	CloseConnectionFn     func(context.Context, *CloseConnectionRequest) (*CloseConnectionResponse, error)
+
+	CloseSessionRecordingFn func(context.Context, *CloseSessionRecordingRequest) (*CloseSessionRecordingResponse, error)
 }
 
 // NewMockSessionServiceClient returns a mock SessionServiceClient which allows
@@ -66,3 +68,10 @@ func (c *mockSessionServiceClient) CloseConnection(ctx context.Context, req *Clo
 	}
 	panic("not implemented")
 }
+
+func (c *mockSessionServiceClient) CloseSessionRecording(ctx context.Context, req *CloseSessionRecordingRequest, _ ...grpc.CallOption) (*CloseSessionRecordingResponse, error) {
+	if c.CloseSessionRecordingFn != nil {
+		return c.CloseSessionRecordingFn(ctx, req)
+	}
+	panic("not implemented")
+}
@@ -3,6 +3,8 @@
 
 package host
 
+import "github.com/hashicorp/boundary/internal/pagination"
+
 // GetOpts - iterate the inbound Options and return a struct
 func GetOpts(opt ...Option) (options, error) {
 	opts := getDefaultOptions()
@@ -19,9 +21,10 @@ type Option func(*options) error
 
 // options = how options are represented
 type options struct {
-	WithLimit             int
-	WithOrderByCreateTime bool
-	Ascending             bool
+	WithLimit              int
+	WithOrderByCreateTime  bool
+	Ascending              bool
+	WithStartPageAfterItem pagination.Item
 }
 
 func getDefaultOptions() options {
@@ -47,3 +50,12 @@ func WithOrderByCreateTime(ascending bool) Option {
 		return nil
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) error {
+		o.WithStartPageAfterItem = item
+		return nil
+	}
+}
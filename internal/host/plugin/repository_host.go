@@ -35,8 +35,9 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 	return ha.toHost(), plg, nil
 }
 
-// ListHostsByCatalogId returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// ListHostsByCatalogId returns a slice of Hosts for the catalogId ordered by
+// create time and public id. WithLimit and WithStartPageAfterItem are the only
+// options supported.
 func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string, opt ...Option) ([]*Host, *plugin.Plugin, error) {
 	const op = "plugin.(Repository).ListHostsByCatalogId"
 	if catalogId == "" {
@@ -49,7 +50,12 @@ func (r *Repository) ListHostsByCatalogId(ctx context.Context, catalogId string,
 		limit = opts.withLimit
 	}
 	var hostAggs []*hostAgg
-	err := r.reader.SearchWhere(ctx, &hostAggs, "catalog_id = ?", []any{catalogId}, db.WithLimit(limit))
+	where, args := "catalog_id = ?", []any{catalogId}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &hostAggs, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
 
 	switch {
 	case err != nil:
@@ -592,7 +592,9 @@ func (r *Repository) LookupCatalog(ctx context.Context, id string, _ ...Option)
 	return c, plg, nil
 }
 
-// ListCatalogs returns a slice of HostCatalogs for the project IDs. WithLimit is the only option supported.
+// ListCatalogs returns a slice of HostCatalogs for the project IDs ordered by
+// create time and public id. WithLimit and WithStartPageAfterItem are the only
+// options supported.
 func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt ...host.Option) ([]*HostCatalog, []*plg.Plugin, error) {
 	const op = "plugin.(Repository).ListCatalogs"
 	if len(projectIds) == 0 {
@@ -607,8 +609,13 @@ func (r *Repository) ListCatalogs(ctx context.Context, projectIds []string, opt
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.WithLimit
 	}
+	where, args := "project_id in (?)", []any{projectIds}
+	if opts.WithStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.WithStartPageAfterItem.GetCreateTime(), opts.WithStartPageAfterItem.GetPublicId())
+	}
 	var hostCatalogs []*HostCatalog
-	if err := r.reader.SearchWhere(ctx, &hostCatalogs, "project_id in (?)", []any{projectIds}, db.WithLimit(limit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc")); err != nil {
 		return nil, nil, errors.Wrap(ctx, err, op)
 	}
 	plgIds := make([]string, 0, len(hostCatalogs))
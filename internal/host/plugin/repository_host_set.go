@@ -596,8 +596,9 @@ func (r *Repository) LookupSet(ctx context.Context, publicId string, _ ...host.O
 	return sets[0], plg, nil
 }
 
-// ListSets returns a slice of HostSets for the catalogId. WithLimit is the
-// only option supported.
+// ListSets returns a slice of HostSets for the catalogId ordered by create
+// time and public id. WithLimit, WithOrderByCreateTime and
+// WithStartPageAfterItem are the only options supported.
 func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...host.Option) ([]*HostSet, *plugin.Plugin, error) {
 	const op = "plugin.(Repository).ListSets"
 	if catalogId == "" {
@@ -714,16 +715,19 @@ func (r *Repository) getSets(ctx context.Context, publicId string, catalogId str
 		where, args = "public_id = ?", append(args, publicId)
 	default:
 		where, args = "catalog_id = ?", append(args, catalogId)
+		if opts.WithStartPageAfterItem != nil {
+			where += " and (create_time, public_id) > (?, ?)"
+			args = append(args, opts.WithStartPageAfterItem.GetCreateTime(), opts.WithStartPageAfterItem.GetPublicId())
+		}
 	}
 
 	dbArgs := []db.Option{db.WithLimit(limit)}
 
-	if opts.WithOrderByCreateTime {
-		if opts.Ascending {
-			dbArgs = append(dbArgs, db.WithOrder("create_time asc"))
-		} else {
-			dbArgs = append(dbArgs, db.WithOrder("create_time"))
-		}
+	switch {
+	case opts.WithOrderByCreateTime && !opts.Ascending:
+		dbArgs = append(dbArgs, db.WithOrder("create_time, public_id"))
+	case opts.WithOrderByCreateTime, catalogId != "":
+		dbArgs = append(dbArgs, db.WithOrder("create_time asc, public_id asc"))
 	}
 
 	var aggHostSets []*hostSetAgg
@@ -3,7 +3,11 @@
 
 package iam
 
-import "io"
+import (
+	"io"
+
+	"github.com/hashicorp/boundary/internal/pagination"
+)
 
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
@@ -32,6 +36,9 @@ type options struct {
 	withRandomReader            io.Reader
 	withAccountIds              []string
 	withPrimaryAuthMethodId     string
+	withAuthTokenTimeToLive     uint32
+	withAuthTokenTimeToStale    uint32
+	withStartPageAfterItem      pagination.Item
 }
 
 func getDefaultOptions() options {
@@ -144,3 +151,27 @@ func WithPrimaryAuthMethodId(id string) Option {
 		o.withPrimaryAuthMethodId = id
 	}
 }
+
+// WithAuthTokenTimeToLiveSeconds provides an option to specify the maximum
+// lifetime of auth tokens issued within the scope.
+func WithAuthTokenTimeToLiveSeconds(seconds uint32) Option {
+	return func(o *options) {
+		o.withAuthTokenTimeToLive = seconds
+	}
+}
+
+// WithAuthTokenTimeToStaleSeconds provides an option to specify the time
+// after which unused auth tokens issued within the scope are invalidated.
+func WithAuthTokenTimeToStaleSeconds(seconds uint32) Option {
+	return func(o *options) {
+		o.withAuthTokenTimeToStale = seconds
+	}
+}
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = item
+	}
+}
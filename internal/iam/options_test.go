@@ -82,4 +82,18 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPrimaryAuthMethodId = "test"
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAuthTokenTimeToLiveSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAuthTokenTimeToLiveSeconds(3600))
+		testOpts := getDefaultOptions()
+		testOpts.withAuthTokenTimeToLive = 3600
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAuthTokenTimeToStaleSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAuthTokenTimeToStaleSeconds(600))
+		testOpts := getDefaultOptions()
+		testOpts.withAuthTokenTimeToStale = 600
+		assert.Equal(opts, testOpts)
+	})
 }
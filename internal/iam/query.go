@@ -173,7 +173,105 @@ const (
 	   inner join iam_role_grant
 		  on roles.role_id = iam_role_grant.role_id
 		  -- only retrieves roles with a grant! there can be roles that don't have grants (it's a valid state in boundary)
+	   union
+	  -- temporary grants of approved access requests, which are effective
+	  -- until they expire, even before they are deleted
+	  select access_request_grant.access_request_id,
+			 access_request_grant.project_id,
+			 access_request_grant.canonical_grant
+		from access_request_grant
+	   where access_request_grant.user_id in (select id from users)
+		 and access_request_grant.expiration_time > now()
 	)
 	select role_id as role_id, role_scope as scope_id, role_grant as grant from final;
 		`
+
+	// resourceQuery finds the scope of a resource, and the id of its parent
+	// resource for resources which are not top level, given its public id.
+	resourceQuery = `
+	with resource (public_id, scope_id, pin) as (
+	  select public_id, coalesce(parent_id, public_id), null
+		from iam_scope
+	   union all
+	  select public_id, scope_id, null
+		from iam_user
+	   union all
+	  select public_id, scope_id, null
+		from iam_group
+	   union all
+	  select public_id, scope_id, null
+		from iam_role
+	   union all
+	  select public_id, scope_id, null
+		from auth_method
+	   union all
+	  select public_id, scope_id, auth_method_id
+		from auth_account
+	   union all
+	  select auth_managed_group.public_id, auth_method.scope_id, auth_managed_group.auth_method_id
+		from auth_managed_group
+		join auth_method
+		  on auth_method.public_id = auth_managed_group.auth_method_id
+	   union all
+	  select auth_token.public_id, auth_account.scope_id, null
+		from auth_token
+		join auth_account
+		  on auth_account.public_id = auth_token.auth_account_id
+	   union all
+	  select public_id, project_id, null
+		from host_catalog
+	   union all
+	  select host_set.public_id, host_catalog.project_id, host_set.catalog_id
+		from host_set
+		join host_catalog
+		  on host_catalog.public_id = host_set.catalog_id
+	   union all
+	  select host.public_id, host_catalog.project_id, host.catalog_id
+		from host
+		join host_catalog
+		  on host_catalog.public_id = host.catalog_id
+	   union all
+	  select public_id, project_id, null
+		from credential_store
+	   union all
+	  select credential_library.public_id, credential_store.project_id, credential_library.store_id
+		from credential_library
+		join credential_store
+		  on credential_store.public_id = credential_library.store_id
+	   union all
+	  select credential_static.public_id, credential_store.project_id, credential_static.store_id
+		from credential_static
+		join credential_store
+		  on credential_store.public_id = credential_static.store_id
+	   union all
+	  select public_id, project_id, null
+		from target
+	   union all
+	  select public_id, project_id, null
+		from session
+	   union all
+	  select public_id, scope_id, null
+		from server_worker
+	   union all
+	  select public_id, scope_id, null
+		from storage_plugin_storage_bucket
+	   union all
+	  select recording_session.public_id, storage_plugin_storage_bucket.scope_id, null
+		from recording_session
+		join storage_plugin_storage_bucket
+		  on storage_plugin_storage_bucket.public_id = recording_session.storage_bucket_id
+	   union all
+	  select public_id, scope_id, null
+		from alias_target
+	   union all
+	  select public_id, scope_id, null
+		from policy_storage_policy
+	   union all
+	  select public_id, project_id, null
+		from access_request
+	)
+	select scope_id, pin
+	  from resource
+	 where public_id = ?;
+	`
 )
@@ -65,6 +65,23 @@ func (r *Repository) list(ctx context.Context, resources any, where string, args
 	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
 }
 
+// listResources will return a page of resources ordered by create time and
+// public id. It honors the WithLimit option or the repo defaultLimit, and
+// the WithStartPageAfterItem option.
+func (r *Repository) listResources(ctx context.Context, resources any, where string, args []any, opt ...Option) error {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	if opts.withStartPageAfterItem != nil {
+		where = fmt.Sprintf("(%s) and (create_time, public_id) > (?, ?)", where)
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
+}
+
 // create will create a new iam resource in the db repository with an oplog entry
 func (r *Repository) create(ctx context.Context, resource Resource, _ ...Option) (Resource, error) {
 	const op = "iam.(Repository).create"
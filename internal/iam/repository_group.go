@@ -173,14 +173,15 @@ func (r *Repository) DeleteGroup(ctx context.Context, withPublicId string, _ ...
 	return rowsDeleted, nil
 }
 
-// ListGroups lists groups in the given scopes and supports WithLimit option.
+// ListGroups lists groups in the given scopes and supports the WithLimit and
+// WithStartPageAfterItem options. Groups are ordered by create time and public id.
 func (r *Repository) ListGroups(ctx context.Context, withScopeIds []string, opt ...Option) ([]*Group, error) {
 	const op = "iam.(Repository).ListGroups"
 	if len(withScopeIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing scope id")
 	}
 	var grps []*Group
-	err := r.list(ctx, &grps, "scope_id in (?)", []any{withScopeIds}, opt...)
+	err := r.listResources(ctx, &grps, "scope_id in (?)", []any{withScopeIds}, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
@@ -5,6 +5,7 @@ package iam
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/hashicorp/boundary/globals"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/resource"
 )
 
 // AddRoleGrant will add role grants associated with the role ID in the
@@ -389,6 +391,9 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants of the roles of userId, and the temporary
+// grants of the approved access requests of userId which have not expired.
+// The RoleId of a temporary grant is the id of its access request.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Option) ([]perms.GrantTuple, error) {
 	const op = "iam.(Repository).GrantsForUser"
 	if userId == "" {
@@ -423,3 +428,45 @@ func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Opti
 	}
 	return grants, nil
 }
+
+// LookupResource returns the perms.Resource for the resource with the given
+// public id, which includes the scope of the resource and, for resources that
+// are not top level, the id of their parent. If the resource is not found, it
+// returns nil, nil.
+func (r *Repository) LookupResource(ctx context.Context, publicId string, _ ...Option) (*perms.Resource, error) {
+	const op = "iam.(Repository).LookupResource"
+	if publicId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	}
+	typ := globals.ResourceInfoFromPrefix(publicId).Type
+	if typ == resource.Unknown {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown resource type for id %q", publicId))
+	}
+
+	rows, err := r.reader.Query(ctx, resourceQuery, []any{publicId})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var found *perms.Resource
+	for rows.Next() {
+		if found != nil {
+			return nil, errors.New(ctx, errors.NotSpecificIntegrity, op, fmt.Sprintf("multiple resources found for id %q", publicId))
+		}
+		var scopeId string
+		var pin sql.NullString
+		if err := rows.Scan(&scopeId, &pin); err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		found = &perms.Resource{
+			ScopeId: scopeId,
+			Id:      publicId,
+			Type:    typ,
+			Pin:     pin.String,
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return found, nil
+}
@@ -13,9 +13,15 @@ import (
 	"github.com/hashicorp/boundary/internal/auth/ldap"
 	"github.com/hashicorp/boundary/internal/auth/ldap/store"
 	"github.com/hashicorp/boundary/internal/auth/oidc"
+	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/target/tcp"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -389,3 +395,105 @@ func TestGrantsForUser(t *testing.T) {
 			", roles from ldap managed groups", rolesFromLdapManagedGroups)
 	}
 }
+
+func TestRepository_LookupResource(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := iam.TestRepo(t, conn, wrapper)
+
+	org, prj := iam.TestScopes(t, repo)
+	user := iam.TestUser(t, repo, org.GetPublicId())
+	group := iam.TestGroup(t, conn, prj.GetPublicId())
+	role := iam.TestRole(t, conn, scope.Global.String())
+	authMethod := password.TestAuthMethod(t, conn, org.GetPublicId())
+	account := password.TestAccount(t, conn, authMethod.GetPublicId(), "name")
+	catalog := static.TestCatalogs(t, conn, prj.GetPublicId(), 1)[0]
+	set := static.TestSets(t, conn, catalog.GetPublicId(), 1)[0]
+	tar := tcp.TestTarget(ctx, t, conn, prj.GetPublicId(), "test target")
+
+	tests := []struct {
+		name        string
+		id          string
+		want        *perms.Resource
+		wantErrCode errors.Code
+	}{
+		{
+			name: "global",
+			id:   scope.Global.String(),
+			want: &perms.Resource{ScopeId: scope.Global.String(), Id: scope.Global.String(), Type: resource.Scope},
+		},
+		{
+			name: "project",
+			id:   prj.GetPublicId(),
+			want: &perms.Resource{ScopeId: org.GetPublicId(), Id: prj.GetPublicId(), Type: resource.Scope},
+		},
+		{
+			name: "user",
+			id:   user.GetPublicId(),
+			want: &perms.Resource{ScopeId: org.GetPublicId(), Id: user.GetPublicId(), Type: resource.User},
+		},
+		{
+			name: "group",
+			id:   group.GetPublicId(),
+			want: &perms.Resource{ScopeId: prj.GetPublicId(), Id: group.GetPublicId(), Type: resource.Group},
+		},
+		{
+			name: "role",
+			id:   role.GetPublicId(),
+			want: &perms.Resource{ScopeId: scope.Global.String(), Id: role.GetPublicId(), Type: resource.Role},
+		},
+		{
+			name: "auth method",
+			id:   authMethod.GetPublicId(),
+			want: &perms.Resource{ScopeId: org.GetPublicId(), Id: authMethod.GetPublicId(), Type: resource.AuthMethod},
+		},
+		{
+			name: "account",
+			id:   account.GetPublicId(),
+			want: &perms.Resource{ScopeId: org.GetPublicId(), Id: account.GetPublicId(), Type: resource.Account, Pin: authMethod.GetPublicId()},
+		},
+		{
+			name: "host catalog",
+			id:   catalog.GetPublicId(),
+			want: &perms.Resource{ScopeId: prj.GetPublicId(), Id: catalog.GetPublicId(), Type: resource.HostCatalog},
+		},
+		{
+			name: "host set",
+			id:   set.GetPublicId(),
+			want: &perms.Resource{ScopeId: prj.GetPublicId(), Id: set.GetPublicId(), Type: resource.HostSet, Pin: catalog.GetPublicId()},
+		},
+		{
+			name: "target",
+			id:   tar.GetPublicId(),
+			want: &perms.Resource{ScopeId: prj.GetPublicId(), Id: tar.GetPublicId(), Type: resource.Target},
+		},
+		{
+			name: "not found",
+			id:   "ttcp_1234567890",
+		},
+		{
+			name:        "missing id",
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "unknown type",
+			id:          "bad_1234567890",
+			wantErrCode: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.LookupResource(ctx, tt.id)
+			if tt.wantErrCode != 0 {
+				require.Error(err)
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -316,7 +316,7 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 						}
 						grants = append(grants, roleGrant)
 
-						roleGrant, err = NewRoleGrant(ctx, defaultRolePublicId, "id={{.Account.Id}};actions=read,change-password")
+						roleGrant, err = NewRoleGrant(ctx, defaultRolePublicId, "id={{.Account.Id}};actions=read,change-password,enroll-totp,verify-totp")
 						if err != nil {
 							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create in memory role grant"))
 						}
@@ -403,6 +403,9 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 			"name":                scope.Name,
 			"description":         scope.Description,
 			"PrimaryAuthMethodId": scope.PrimaryAuthMethodId, // gorm: it's important that the field start with a capital letter.
+
+			"AuthTokenTimeToLiveSeconds":  scope.AuthTokenTimeToLiveSeconds,
+			"AuthTokenTimeToStaleSeconds": scope.AuthTokenTimeToStaleSeconds,
 		},
 		fieldMaskPaths,
 		nil,
@@ -460,14 +463,15 @@ func (r *Repository) DeleteScope(ctx context.Context, withPublicId string, _ ...
 	return rowsDeleted, nil
 }
 
-// ListScopes with the parent IDs, supports the WithLimit option.
+// ListScopes with the parent IDs, supports the WithLimit and
+// WithStartPageAfterItem options. Scopes are ordered by create time and public id.
 func (r *Repository) ListScopes(ctx context.Context, withParentIds []string, opt ...Option) ([]*Scope, error) {
 	const op = "iam.(Repository).ListScopes"
 	if len(withParentIds) == 0 {
 		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing parent id")
 	}
 	var items []*Scope
-	err := r.list(ctx, &items, "parent_id in (?)", []any{withParentIds}, opt...)
+	err := r.listResources(ctx, &items, "parent_id in (?)", []any{withParentIds}, opt...)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
 	}
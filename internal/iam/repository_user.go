@@ -209,7 +209,8 @@ func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, _ ...O
 	return rowsDeleted, nil
 }
 
-// ListUsers lists users in the given scopes and supports the WithLimit option.
+// ListUsers lists users in the given scopes and supports the WithLimit and
+// WithStartPageAfterItem options. Users are ordered by create time and public id.
 func (r *Repository) ListUsers(ctx context.Context, withScopeIds []string, opt ...Option) ([]*User, error) {
 	const op = "iam.(Repository).ListUsers"
 	if len(withScopeIds) == 0 {
@@ -888,6 +889,11 @@ func (r *Repository) getUsers(ctx context.Context, userId string, scopeIds []str
 		where, args = append(where, "public_id = ?"), append(args, userId)
 	default:
 		where, args = append(where, "scope_id in(?)"), append(args, scopeIds)
+		if opts.withStartPageAfterItem != nil {
+			where = append(where, "(create_time, public_id) > (?, ?)")
+			args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+		}
+		dbArgs = append(dbArgs, db.WithOrder("create_time asc, public_id asc"))
 	}
 	var usersAcctInfo []*userAccountInfo
 	err := r.reader.SearchWhere(ctx, &usersAcctInfo, strings.Join(where, " and "), args, dbArgs...)
@@ -59,7 +59,9 @@ func NewProject(ctx context.Context, orgPublicId string, opt ...Option) (*Scope,
 // friendly name. WithDescription specifies the scope's description. WithScope
 // specifies the Scope's parent and must be filled in. The type of the parent is
 // used to determine the type of the child. WithPrimaryAuthMethodId specifies
-// the primary auth method for the scope
+// the primary auth method for the scope. WithAuthTokenTimeToLiveSeconds and
+// WithAuthTokenTimeToStaleSeconds specify the lifetime of auth tokens issued
+// within the scope.
 func newScope(ctx context.Context, parent *Scope, opt ...Option) (*Scope, error) {
 	const op = "iam.newScope"
 	if parent == nil || parent.PublicId == "" {
@@ -84,6 +86,9 @@ func newScope(ctx context.Context, parent *Scope, opt ...Option) (*Scope, error)
 			Description:         opts.withDescription,
 			ParentId:            parent.PublicId,
 			PrimaryAuthMethodId: opts.withPrimaryAuthMethodId,
+
+			AuthTokenTimeToLiveSeconds:  opts.withAuthTokenTimeToLive,
+			AuthTokenTimeToStaleSeconds: opts.withAuthTokenTimeToStale,
 		},
 	}
 
@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package pagination implements cursor based pagination of list operations.
+// Items are listed in ascending order of create time and public id, and the
+// last item of each page is encoded in an opaque page token which is handed
+// back to the client to request the next page. Once a list operation has
+// completed, an opaque refresh token is handed back which the client can use
+// to list only the items which changed since.
+package pagination
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+// DefaultMaxPageSize is the default maximum number of items returned in a
+// single page of a list operation.
+const DefaultMaxPageSize = 1000
+
+// Item defines a subset of a boundary.Resource that can be paginated.
+type Item interface {
+	GetPublicId() string
+	GetCreateTime() *timestamp.Timestamp
+	GetUpdateTime() *timestamp.Timestamp
+}
+
+// Less reports whether item a sorts before item b in the order used by
+// paginated list operations: ascending create time, then ascending public id.
+func Less(a, b Item) bool {
+	at, bt := a.GetCreateTime().AsTime(), b.GetCreateTime().AsTime()
+	if !at.Equal(bt) {
+		return at.Before(bt)
+	}
+	return a.GetPublicId() < b.GetPublicId()
+}
+
+// ListFilterFunc is a callback used to filter out items after they have been
+// read from the database. It returns true if the item should be included in
+// the page.
+type ListFilterFunc[T Item] func(ctx context.Context, item T) (bool, error)
+
+// ListItemsFunc is a callback used to retrieve items from the database. If
+// prevPageLastItem is not nil, only items which sort after it should be
+// returned. Items must be returned in ascending order of create time and
+// public id, and no more than limit items should be returned.
+type ListItemsFunc[T Item] func(ctx context.Context, prevPageLastItem Item, limit int) ([]T, error)
+
+// ListRefreshItemsFunc is a callback used to retrieve items from the database
+// when the list operation may be a refresh. It behaves like ListItemsFunc,
+// except that if updatedAfter is not zero only items updated after that time
+// should be returned.
+type ListRefreshItemsFunc[T Item] func(ctx context.Context, updatedAfter time.Time, prevPageLastItem Item, limit int) ([]T, error)
+
+// ListDeletedIdsFunc is a callback used to retrieve the ids of items deleted
+// after the provided time.
+type ListDeletedIdsFunc func(ctx context.Context, since time.Time) ([]string, error)
+
+// ListResponse represents the response from the paginated list operation.
+type ListResponse[T Item] struct {
+	// Items contains the page of items.
+	Items []T
+	// CompleteListing is set when there are no more items to list.
+	CompleteListing bool
+	// PageToken is used to request the next page of items. It is nil when
+	// CompleteListing is set.
+	PageToken *Token
+	// RefreshToken is used to list the items which changed since the list
+	// operation started. It is only set when CompleteListing is set.
+	RefreshToken *RefreshToken
+	// RemovedIds contains the ids of items deleted since the previous list
+	// operation. It is only set on the first page of a refresh.
+	RemovedIds []string
+}
+
+// List returns the first page of items of the provided resource type. The
+// grants hash is stored in the returned page token so that subsequent pages
+// can only be requested with the same grants.
+func List[T Item](
+	ctx context.Context,
+	resourceType resource.Type,
+	grantsHash []byte,
+	pageSize int,
+	filterItemFn ListFilterFunc[T],
+	listItemsFn ListItemsFunc[T],
+) (*ListResponse[T], error) {
+	const op = "pagination.List"
+	switch {
+	case len(grantsHash) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing grants hash")
+	case pageSize < 1:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "page size must be at least 1")
+	case filterItemFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing filter item callback")
+	case listItemsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list items callback")
+	}
+	tok, err := NewToken(ctx, resourceType, grantsHash)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp, err := list(ctx, tok, nil, pageSize, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return resp, nil
+}
+
+// ListPage returns the page of items following the last item recorded in the
+// provided page token. The token must already have been validated by the
+// caller.
+func ListPage[T Item](
+	ctx context.Context,
+	tok *Token,
+	pageSize int,
+	filterItemFn ListFilterFunc[T],
+	listItemsFn ListItemsFunc[T],
+) (*ListResponse[T], error) {
+	const op = "pagination.ListPage"
+	switch {
+	case tok == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing page token")
+	case pageSize < 1:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "page size must be at least 1")
+	case filterItemFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing filter item callback")
+	case listItemsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list items callback")
+	}
+	lastItem, err := tok.LastItem(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp, err := list(ctx, tok, lastItem, pageSize, filterItemFn, listItemsFn)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return resp, nil
+}
+
+// ListRefresh returns the first page of items of the resource type of the
+// provided refresh token which were updated since the list operation that
+// produced the token started, along with the ids of items deleted since. The
+// token must already have been validated by the caller.
+func ListRefresh[T Item](
+	ctx context.Context,
+	rt *RefreshToken,
+	pageSize int,
+	filterItemFn ListFilterFunc[T],
+	listRefreshItemsFn ListRefreshItemsFunc[T],
+	listDeletedIdsFn ListDeletedIdsFunc,
+) (*ListResponse[T], error) {
+	const op = "pagination.ListRefresh"
+	switch {
+	case rt == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing refresh token")
+	case pageSize < 1:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "page size must be at least 1")
+	case filterItemFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing filter item callback")
+	case listRefreshItemsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list refresh items callback")
+	case listDeletedIdsFn == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing list deleted ids callback")
+	}
+	tok, err := NewToken(ctx, rt.ResourceType, rt.GrantsHash)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	tok.UpdatedAfter = rt.UpdatedAfter()
+	removedIds, err := listDeletedIdsFn(ctx, tok.UpdatedAfter)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp, err := list(ctx, tok, nil, pageSize, filterItemFn, RefreshItemsFunc(tok, listRefreshItemsFn))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	resp.RemovedIds = removedIds
+	return resp, nil
+}
+
+// RefreshItemsFunc adapts a ListRefreshItemsFunc to a ListItemsFunc which
+// lists the items updated after the update time recorded in the provided
+// token. If the token is nil or not part of a refresh, all items are listed.
+func RefreshItemsFunc[T Item](tok *Token, listRefreshItemsFn ListRefreshItemsFunc[T]) ListItemsFunc[T] {
+	var updatedAfter time.Time
+	if tok != nil {
+		updatedAfter = tok.UpdatedAfter
+	}
+	return func(ctx context.Context, prevPageLastItem Item, limit int) ([]T, error) {
+		return listRefreshItemsFn(ctx, updatedAfter, prevPageLastItem, limit)
+	}
+}
+
+// list reads items from the database until it has collected a full page of
+// items that pass the filter, or until the database has no more items.
+func list[T Item](
+	ctx context.Context,
+	tok *Token,
+	lastItem Item,
+	pageSize int,
+	filterItemFn ListFilterFunc[T],
+	listItemsFn ListItemsFunc[T],
+) (*ListResponse[T], error) {
+	const op = "pagination.list"
+	// Request one more item than the page size so we know whether
+	// there are more items after this page.
+	limit := pageSize + 1
+	items := make([]T, 0, limit)
+dbLoop:
+	for {
+		page, err := listItemsFn(ctx, lastItem, limit)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		for _, item := range page {
+			ok, err := filterItemFn(ctx, item)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op)
+			}
+			if ok {
+				items = append(items, item)
+				if len(items) == limit {
+					break dbLoop
+				}
+			}
+		}
+		if len(page) < limit {
+			break
+		}
+		lastItem = page[len(page)-1]
+	}
+
+	resp := &ListResponse[T]{
+		Items:           items,
+		CompleteListing: true,
+	}
+	if len(items) > pageSize {
+		resp.Items = items[:pageSize]
+		resp.CompleteListing = false
+		resp.PageToken = tok.next(resp.Items[pageSize-1])
+		return resp, nil
+	}
+	resp.RefreshToken = &RefreshToken{
+		CreateTime:   tok.CreateTime,
+		ResourceType: tok.ResourceType,
+		GrantsHash:   tok.GrantsHash,
+	}
+	return resp, nil
+}
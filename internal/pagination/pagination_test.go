@@ -0,0 +1,261 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	publicId   string
+	createTime *timestamp.Timestamp
+}
+
+func (i *testItem) GetPublicId() string                 { return i.publicId }
+func (i *testItem) GetCreateTime() *timestamp.Timestamp { return i.createTime }
+func (i *testItem) GetUpdateTime() *timestamp.Timestamp { return i.createTime }
+
+func testItems(t *testing.T, n int) []*testItem {
+	t.Helper()
+	now := time.Now().Add(-time.Hour)
+	items := make([]*testItem, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, &testItem{
+			publicId:   fmt.Sprintf("ttst_%010d", i),
+			createTime: timestamp.New(now.Add(time.Duration(i) * time.Second)),
+		})
+	}
+	return items
+}
+
+// testListFn emulates a database query returning items ordered by create
+// time and public id, starting after the previous page's last item.
+func testListFn(items []*testItem) ListItemsFunc[*testItem] {
+	return func(_ context.Context, prevPageLastItem Item, limit int) ([]*testItem, error) {
+		start := 0
+		if prevPageLastItem != nil {
+			start = sort.Search(len(items), func(i int) bool {
+				ct, lct := items[i].createTime.AsTime(), prevPageLastItem.GetCreateTime().AsTime()
+				if ct.Equal(lct) {
+					return strings.Compare(items[i].publicId, prevPageLastItem.GetPublicId()) > 0
+				}
+				return ct.After(lct)
+			})
+		}
+		end := start + limit
+		if end > len(items) {
+			end = len(items)
+		}
+		return items[start:end], nil
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := []byte("some hash")
+	allowAll := func(context.Context, *testItem) (bool, error) { return true, nil }
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		listFn := testListFn(nil)
+		_, err := List(ctx, resource.Target, nil, 10, allowAll, listFn)
+		assert.ErrorContains(t, err, "missing grants hash")
+		_, err = List(ctx, resource.Target, grantsHash, 0, allowAll, listFn)
+		assert.ErrorContains(t, err, "page size must be at least 1")
+		_, err = List(ctx, resource.Target, grantsHash, 10, nil, listFn)
+		assert.ErrorContains(t, err, "missing filter item callback")
+		_, err = List[*testItem](ctx, resource.Target, grantsHash, 10, allowAll, nil)
+		assert.ErrorContains(t, err, "missing list items callback")
+		_, err = List(ctx, resource.Unknown, grantsHash, 10, allowAll, listFn)
+		assert.ErrorContains(t, err, "missing resource type")
+	})
+
+	t.Run("complete-listing", func(t *testing.T) {
+		t.Parallel()
+		items := testItems(t, 5)
+		resp, err := List(ctx, resource.Target, grantsHash, 5, allowAll, testListFn(items))
+		require.NoError(t, err)
+		assert.Equal(t, items, resp.Items)
+		assert.True(t, resp.CompleteListing)
+		assert.Nil(t, resp.PageToken)
+		require.NotNil(t, resp.RefreshToken)
+		assert.Equal(t, resource.Target, resp.RefreshToken.ResourceType)
+		assert.Equal(t, grantsHash, resp.RefreshToken.GrantsHash)
+	})
+
+	t.Run("all-pages", func(t *testing.T) {
+		t.Parallel()
+		items := testItems(t, 10)
+		listFn := testListFn(items)
+		resp, err := List(ctx, resource.Target, grantsHash, 3, allowAll, listFn)
+		require.NoError(t, err)
+		got := resp.Items
+		pages := 1
+		for !resp.CompleteListing {
+			require.NotNil(t, resp.PageToken)
+			assert.Equal(t, resource.Target, resp.PageToken.ResourceType)
+			assert.Equal(t, grantsHash, resp.PageToken.GrantsHash)
+			resp, err = ListPage(ctx, resp.PageToken, 3, allowAll, listFn)
+			require.NoError(t, err)
+			got = append(got, resp.Items...)
+			pages++
+		}
+		assert.Equal(t, items, got)
+		assert.Equal(t, 4, pages)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		t.Parallel()
+		items := testItems(t, 20)
+		listFn := testListFn(items)
+		var calls int
+		countingListFn := func(ctx context.Context, last Item, limit int) ([]*testItem, error) {
+			calls++
+			return listFn(ctx, last, limit)
+		}
+		// Only keep every fourth item so that filling a page needs multiple
+		// queries.
+		filterFn := func(_ context.Context, item *testItem) (bool, error) {
+			var n int
+			_, err := fmt.Sscanf(item.publicId, "ttst_%d", &n)
+			return n%4 == 0, err
+		}
+		resp, err := List(ctx, resource.Target, grantsHash, 2, filterFn, countingListFn)
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, items[0], resp.Items[0])
+		assert.Equal(t, items[4], resp.Items[1])
+		assert.False(t, resp.CompleteListing)
+		assert.Greater(t, calls, 1)
+
+		resp, err = ListPage(ctx, resp.PageToken, 2, filterFn, countingListFn)
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, items[8], resp.Items[0])
+		assert.Equal(t, items[12], resp.Items[1])
+		assert.False(t, resp.CompleteListing)
+
+		resp, err = ListPage(ctx, resp.PageToken, 2, filterFn, countingListFn)
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, items[16], resp.Items[0])
+		assert.True(t, resp.CompleteListing)
+	})
+
+	t.Run("filter-error", func(t *testing.T) {
+		t.Parallel()
+		filterFn := func(context.Context, *testItem) (bool, error) { return false, fmt.Errorf("filter failed") }
+		_, err := List(ctx, resource.Target, grantsHash, 2, filterFn, testListFn(testItems(t, 3)))
+		assert.ErrorContains(t, err, "filter failed")
+	})
+
+	t.Run("list-error", func(t *testing.T) {
+		t.Parallel()
+		listFn := func(context.Context, Item, int) ([]*testItem, error) { return nil, fmt.Errorf("list failed") }
+		_, err := List(ctx, resource.Target, grantsHash, 2, allowAll, listFn)
+		assert.ErrorContains(t, err, "list failed")
+	})
+}
+
+func TestListRefresh(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := []byte("some hash")
+	allowAll := func(context.Context, *testItem) (bool, error) { return true, nil }
+	items := testItems(t, 10)
+	// testItems uses the create time as the update time, so items 8 and 9
+	// are the only ones updated after items[7].
+	rt := &RefreshToken{
+		CreateTime:   items[7].createTime.AsTime().Add(refreshLookback),
+		ResourceType: resource.Target,
+		GrantsHash:   grantsHash,
+	}
+	listFn := testListFn(items)
+	listRefreshFn := func(ctx context.Context, updatedAfter time.Time, last Item, limit int) ([]*testItem, error) {
+		page, err := listFn(ctx, last, len(items))
+		if err != nil {
+			return nil, err
+		}
+		var updated []*testItem
+		for _, item := range page {
+			if updatedAfter.IsZero() || item.GetUpdateTime().AsTime().After(updatedAfter) {
+				updated = append(updated, item)
+			}
+		}
+		if len(updated) > limit {
+			updated = updated[:limit]
+		}
+		return updated, nil
+	}
+	listDeletedFn := func(_ context.Context, since time.Time) ([]string, error) {
+		assert.True(t, since.Equal(rt.UpdatedAfter()))
+		return []string{"ttst_deleted"}, nil
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+		_, err := ListRefresh(ctx, nil, 10, allowAll, listRefreshFn, listDeletedFn)
+		assert.ErrorContains(t, err, "missing refresh token")
+		_, err = ListRefresh(ctx, rt, 0, allowAll, listRefreshFn, listDeletedFn)
+		assert.ErrorContains(t, err, "page size must be at least 1")
+		_, err = ListRefresh(ctx, rt, 10, nil, listRefreshFn, listDeletedFn)
+		assert.ErrorContains(t, err, "missing filter item callback")
+		_, err = ListRefresh[*testItem](ctx, rt, 10, allowAll, nil, listDeletedFn)
+		assert.ErrorContains(t, err, "missing list refresh items callback")
+		_, err = ListRefresh(ctx, rt, 10, allowAll, listRefreshFn, nil)
+		assert.ErrorContains(t, err, "missing list deleted ids callback")
+	})
+
+	t.Run("complete-listing", func(t *testing.T) {
+		t.Parallel()
+		resp, err := ListRefresh(ctx, rt, 10, allowAll, listRefreshFn, listDeletedFn)
+		require.NoError(t, err)
+		assert.Equal(t, items[8:], resp.Items)
+		assert.Equal(t, []string{"ttst_deleted"}, resp.RemovedIds)
+		assert.True(t, resp.CompleteListing)
+		require.NotNil(t, resp.RefreshToken)
+		assert.True(t, resp.RefreshToken.CreateTime.After(rt.CreateTime))
+	})
+
+	t.Run("all-pages", func(t *testing.T) {
+		t.Parallel()
+		resp, err := ListRefresh(ctx, rt, 1, allowAll, listRefreshFn, listDeletedFn)
+		require.NoError(t, err)
+		assert.Equal(t, items[8:9], resp.Items)
+		assert.Equal(t, []string{"ttst_deleted"}, resp.RemovedIds)
+		require.False(t, resp.CompleteListing)
+		assert.True(t, resp.PageToken.UpdatedAfter.Equal(rt.UpdatedAfter()))
+
+		resp, err = ListPage(ctx, resp.PageToken, 1, allowAll, RefreshItemsFunc(resp.PageToken, listRefreshFn))
+		require.NoError(t, err)
+		assert.Equal(t, items[9:], resp.Items)
+		assert.Empty(t, resp.RemovedIds)
+		assert.True(t, resp.CompleteListing)
+		assert.NotNil(t, resp.RefreshToken)
+	})
+}
+
+func TestLess(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	a := &testItem{publicId: "ttst_a", createTime: timestamp.New(now)}
+	b := &testItem{publicId: "ttst_b", createTime: timestamp.New(now)}
+	c := &testItem{publicId: "ttst_0", createTime: timestamp.New(now.Add(time.Second))}
+	assert.True(t, Less(a, b))
+	assert.False(t, Less(b, a))
+	assert.True(t, Less(b, c))
+	assert.False(t, Less(c, a))
+	assert.False(t, Less(a, a))
+}
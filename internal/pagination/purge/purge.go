@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package purge provides a job which removes the records of deleted items
+// which are no longer needed to answer list refresh requests.
+package purge
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/util"
+)
+
+// RegisterJob registers the purge job with the provided scheduler.
+func RegisterJob(ctx context.Context, s *scheduler.Scheduler, w db.Writer) error {
+	const op = "purge.RegisterJob"
+	if s == nil {
+		return errors.New(ctx, errors.Internal, "nil scheduler", op, errors.WithoutEvent())
+	}
+	if util.IsNil(w) {
+		return errors.New(ctx, errors.Internal, "nil DB writer", op, errors.WithoutEvent())
+	}
+
+	if err := s.RegisterJob(ctx, newPurgeJob(w)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package purge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+// deletedTables are the tables recording the ids of deleted items. Rows are
+// kept for as long as a refresh token could still ask for them.
+var deletedTables = []string{
+	"target_deleted",
+	"session_deleted",
+}
+
+const purgeQuery = `
+delete from %s
+ where delete_time < wt_sub_seconds_from_now(@threshold_seconds);
+`
+
+type purgeJob struct {
+	w db.Writer
+
+	// the number of rows deleted in the most recent run
+	deletedInRun int
+}
+
+func newPurgeJob(w db.Writer) *purgeJob {
+	return &purgeJob{
+		w: w,
+	}
+}
+
+// Status reports the job’s current status.
+func (p *purgeJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: p.deletedInRun,
+		Total:     p.deletedInRun,
+	}
+}
+
+// Run performs the required work depending on the implementation.
+// The context is used to notify the job that it should exit early.
+func (p *purgeJob) Run(ctx context.Context) error {
+	const op = "purge.(purgeJob).Run"
+	p.deletedInRun = 0
+
+	args := []any{sql.Named("threshold_seconds", int(pagination.TokenLifetime.Seconds()))}
+	for _, table := range deletedTables {
+		n, err := p.w.Exec(ctx, fmt.Sprintf(purgeQuery, table), args)
+		if err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed to purge %s", table)))
+		}
+		p.deletedInRun += n
+	}
+
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+func (p *purgeJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return time.Hour, nil
+}
+
+// Name is the unique name of the job.
+func (p *purgeJob) Name() string {
+	return "deleted_items_purge"
+}
+
+// Description is the human readable description of the job.
+func (p *purgeJob) Description() string {
+	return "Purges records of deleted items which are older than the lifetime of refresh tokens"
+}
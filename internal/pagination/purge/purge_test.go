@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package purge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/pagination"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeJob(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	insert := "insert into target_deleted (public_id, delete_time) values (?, ?)"
+	_, err := rw.Exec(ctx, insert, []any{"ttcp_old1234567", time.Now().Add(-pagination.TokenLifetime - time.Hour)})
+	require.NoError(t, err)
+	_, err = rw.Exec(ctx, insert, []any{"ttcp_new1234567", time.Now()})
+	require.NoError(t, err)
+
+	job := newPurgeJob(rw)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.Status().Completed)
+
+	rows, err := rw.Query(ctx, "select public_id from target_deleted", nil)
+	require.NoError(t, err)
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"ttcp_new1234567"}, ids)
+}
+
+func TestRegisterJob(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	s := scheduler.TestScheduler(t, conn, wrapper)
+
+	t.Run("succeeds", func(t *testing.T) {
+		err := RegisterJob(context.Background(), s, rw)
+		require.NoError(t, err)
+	})
+	t.Run("fails-on-nil-scheduler", func(t *testing.T) {
+		err := RegisterJob(context.Background(), nil, rw)
+		require.Error(t, err)
+	})
+	t.Run("fails-on-nil-db-writer", func(t *testing.T) {
+		err := RegisterJob(context.Background(), s, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// refreshLookback is subtracted from the start time of the previous list
+// operation when listing changed items. Transactions which were in flight
+// when the previous list operation started may have committed changes with
+// an earlier timestamp that the list operation could not see.
+const refreshLookback = 30 * time.Second
+
+// RefreshToken is the internal representation of a refresh token. It is
+// returned once a list operation has completed and can be used to list only
+// the items which changed since.
+type RefreshToken struct {
+	// CreateTime is the time the first page of the list operation which
+	// produced this token was requested.
+	CreateTime time.Time
+	// ResourceType is the type of the resources being listed.
+	ResourceType resource.Type
+	// GrantsHash is a hash of the grants of the requester when the list
+	// operation was started.
+	GrantsHash []byte
+}
+
+// UpdatedAfter returns the time after which items must have been updated or
+// deleted to be included when refreshing.
+func (t *RefreshToken) UpdatedAfter() time.Time {
+	return t.CreateTime.Add(-refreshLookback)
+}
+
+// Validate checks that the refresh token belongs to a list operation over the
+// expected resource type, was issued for the same grants, and has not expired.
+func (t *RefreshToken) Validate(ctx context.Context, expectedResourceType resource.Type, expectedGrantsHash []byte) error {
+	const op = "pagination.(RefreshToken).Validate"
+	switch {
+	case t == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing refresh token")
+	case t.ResourceType != expectedResourceType:
+		return errors.New(ctx, errors.InvalidParameter, op, "refresh token resource type does not match expected resource type")
+	case !bytes.Equal(t.GrantsHash, expectedGrantsHash):
+		return errors.New(ctx, errors.InvalidParameter, op, "grants have changed since the list operation was started")
+	case t.CreateTime.After(time.Now()):
+		return errors.New(ctx, errors.InvalidParameter, op, "refresh token was created in the future")
+	case time.Since(t.CreateTime) > TokenLifetime:
+		return errors.New(ctx, errors.InvalidParameter, op, "refresh token has expired")
+	}
+	return nil
+}
+
+// Marshal encodes the refresh token into the opaque string returned to
+// clients.
+func (t *RefreshToken) Marshal(ctx context.Context) (string, error) {
+	const op = "pagination.(RefreshToken).Marshal"
+	b, err := proto.Marshal(&tokens.RefreshToken{
+		CreateTime:   timestamppb.New(t.CreateTime),
+		ResourceType: t.ResourceType.String(),
+		GrantsHash:   t.GrantsHash,
+	})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	return base58.FastBase58Encoding(b), nil
+}
+
+// ParseRefreshToken decodes an opaque refresh token string. The returned
+// token must still be validated with Validate before use.
+func ParseRefreshToken(ctx context.Context, s string) (*RefreshToken, error) {
+	const op = "pagination.ParseRefreshToken"
+	if s == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing refresh token")
+	}
+	b, err := base58.FastBase58Decoding(s)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("refresh token is not base58 encoded"))
+	}
+	var rt tokens.RefreshToken
+	if err := proto.Unmarshal(b, &rt); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to decode refresh token"))
+	}
+	resourceType, ok := resource.Map[rt.GetResourceType()]
+	if !ok {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unknown refresh token resource type")
+	}
+	return &RefreshToken{
+		CreateTime:   rt.GetCreateTime().AsTime(),
+		ResourceType: resourceType,
+		GrantsHash:   rt.GetGrantsHash(),
+	}, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pagination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken_MarshalParse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tok := &RefreshToken{
+		CreateTime:   time.Now(),
+		ResourceType: resource.Session,
+		GrantsHash:   []byte("some hash"),
+	}
+
+	s, err := tok.Marshal(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, s)
+
+	got, err := ParseRefreshToken(ctx, s)
+	require.NoError(t, err)
+	assert.True(t, tok.CreateTime.Equal(got.CreateTime))
+	assert.Equal(t, tok.ResourceType, got.ResourceType)
+	assert.Equal(t, tok.GrantsHash, got.GrantsHash)
+	assert.True(t, got.UpdatedAfter().Before(got.CreateTime))
+
+	_, err = ParseRefreshToken(ctx, "")
+	assert.ErrorContains(t, err, "missing refresh token")
+	_, err = ParseRefreshToken(ctx, "not-base58-0OIl")
+	assert.ErrorContains(t, err, "refresh token is not base58 encoded")
+}
+
+func TestRefreshToken_Validate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := []byte("some hash")
+	newTok := func() *RefreshToken {
+		return &RefreshToken{
+			CreateTime:   time.Now(),
+			ResourceType: resource.Session,
+			GrantsHash:   grantsHash,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		tok     func() *RefreshToken
+		wantErr string
+	}{
+		{
+			name: "valid",
+			tok:  newTok,
+		},
+		{
+			name:    "nil",
+			tok:     func() *RefreshToken { return nil },
+			wantErr: "missing refresh token",
+		},
+		{
+			name: "wrong-resource-type",
+			tok: func() *RefreshToken {
+				tok := newTok()
+				tok.ResourceType = resource.Target
+				return tok
+			},
+			wantErr: "refresh token resource type does not match expected resource type",
+		},
+		{
+			name: "changed-grants",
+			tok: func() *RefreshToken {
+				tok := newTok()
+				tok.GrantsHash = []byte("other hash")
+				return tok
+			},
+			wantErr: "grants have changed since the list operation was started",
+		},
+		{
+			name: "future",
+			tok: func() *RefreshToken {
+				tok := newTok()
+				tok.CreateTime = time.Now().Add(time.Hour)
+				return tok
+			},
+			wantErr: "refresh token was created in the future",
+		},
+		{
+			name: "expired",
+			tok: func() *RefreshToken {
+				tok := newTok()
+				tok.CreateTime = time.Now().Add(-TokenLifetime - time.Hour)
+				return tok
+			},
+			wantErr: "refresh token has expired",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.tok().Validate(ctx, resource.Session, grantsHash)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/mr-tron/base58"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TokenLifetime is the amount of time after the start of a list operation
+// for which its page and refresh tokens are accepted.
+const TokenLifetime = 30 * 24 * time.Hour
+
+// Token is the internal representation of a page token.
+type Token struct {
+	// CreateTime is the time the first page of the list operation was
+	// requested.
+	CreateTime time.Time
+	// ResourceType is the type of the resources being listed.
+	ResourceType resource.Type
+	// GrantsHash is a hash of the grants of the requester when the first
+	// page was requested.
+	GrantsHash []byte
+	// LastItemId is the public id of the last item of the previous page.
+	LastItemId string
+	// LastItemCreateTime is the create time of the last item of the
+	// previous page.
+	LastItemCreateTime time.Time
+	// UpdatedAfter is set when the list operation is a refresh, in which
+	// case only items updated after this time are listed.
+	UpdatedAfter time.Time
+}
+
+// NewToken creates a new token for a list operation over the given resource
+// type. The returned token does not yet reference a last item.
+func NewToken(ctx context.Context, resourceType resource.Type, grantsHash []byte) (*Token, error) {
+	const op = "pagination.NewToken"
+	switch {
+	case resourceType == resource.Unknown:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing resource type")
+	case len(grantsHash) == 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing grants hash")
+	}
+	return &Token{
+		CreateTime:   time.Now(),
+		ResourceType: resourceType,
+		GrantsHash:   grantsHash,
+	}, nil
+}
+
+// next returns a copy of the token referencing the provided item as the
+// last item of the previous page.
+func (t *Token) next(lastItem Item) *Token {
+	return &Token{
+		CreateTime:         t.CreateTime,
+		ResourceType:       t.ResourceType,
+		GrantsHash:         t.GrantsHash,
+		LastItemId:         lastItem.GetPublicId(),
+		LastItemCreateTime: lastItem.GetCreateTime().AsTime(),
+		UpdatedAfter:       t.UpdatedAfter,
+	}
+}
+
+// LastItem returns a partial item containing the public id and create time
+// of the last item of the previous page.
+func (t *Token) LastItem(ctx context.Context) (Item, error) {
+	const op = "pagination.(Token).LastItem"
+	if t.LastItemId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "token has no last item")
+	}
+	return &lastItem{
+		publicId:   t.LastItemId,
+		createTime: timestamp.New(t.LastItemCreateTime),
+	}, nil
+}
+
+// Validate checks that the token belongs to a list operation over the
+// expected resource type, was issued for the same grants, and has not
+// expired.
+func (t *Token) Validate(ctx context.Context, expectedResourceType resource.Type, expectedGrantsHash []byte) error {
+	const op = "pagination.(Token).Validate"
+	switch {
+	case t == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing token")
+	case t.ResourceType != expectedResourceType:
+		return errors.New(ctx, errors.InvalidParameter, op, "token resource type does not match expected resource type")
+	case !bytes.Equal(t.GrantsHash, expectedGrantsHash):
+		return errors.New(ctx, errors.InvalidParameter, op, "grants have changed since the list operation was started")
+	case t.LastItemId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing last item id")
+	case t.LastItemCreateTime.IsZero():
+		return errors.New(ctx, errors.InvalidParameter, op, "missing last item create time")
+	case t.CreateTime.After(time.Now()):
+		return errors.New(ctx, errors.InvalidParameter, op, "token was created in the future")
+	case time.Since(t.CreateTime) > TokenLifetime:
+		return errors.New(ctx, errors.InvalidParameter, op, "token has expired")
+	}
+	return nil
+}
+
+// Marshal encodes the token into the opaque string returned to clients.
+func (t *Token) Marshal(ctx context.Context) (string, error) {
+	const op = "pagination.(Token).Marshal"
+	pt := &tokens.PageToken{
+		CreateTime:         timestamppb.New(t.CreateTime),
+		ResourceType:       t.ResourceType.String(),
+		GrantsHash:         t.GrantsHash,
+		LastItemId:         t.LastItemId,
+		LastItemCreateTime: timestamppb.New(t.LastItemCreateTime),
+	}
+	if !t.UpdatedAfter.IsZero() {
+		pt.UpdatedAfter = timestamppb.New(t.UpdatedAfter)
+	}
+	b, err := proto.Marshal(pt)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
+	}
+	return base58.FastBase58Encoding(b), nil
+}
+
+// ParseToken decodes an opaque page token string. The returned token must
+// still be validated with Validate before use.
+func ParseToken(ctx context.Context, s string) (*Token, error) {
+	const op = "pagination.ParseToken"
+	if s == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing token")
+	}
+	b, err := base58.FastBase58Decoding(s)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("token is not base58 encoded"))
+	}
+	var pt tokens.PageToken
+	if err := proto.Unmarshal(b, &pt); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to decode token"))
+	}
+	rt, ok := resource.Map[pt.GetResourceType()]
+	if !ok {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "unknown token resource type")
+	}
+	tok := &Token{
+		CreateTime:         pt.GetCreateTime().AsTime(),
+		ResourceType:       rt,
+		GrantsHash:         pt.GetGrantsHash(),
+		LastItemId:         pt.GetLastItemId(),
+		LastItemCreateTime: pt.GetLastItemCreateTime().AsTime(),
+	}
+	if pt.GetUpdatedAfter() != nil {
+		tok.UpdatedAfter = pt.GetUpdatedAfter().AsTime()
+	}
+	return tok, nil
+}
+
+// GrantsHash returns a hash of the provided grants which is independent of
+// the order of the grants.
+func GrantsHash(grants []string) []byte {
+	sorted := make([]string, len(grants))
+	copy(sorted, grants)
+	sort.Strings(sorted)
+	h := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return h[:]
+}
+
+type lastItem struct {
+	publicId   string
+	createTime *timestamp.Timestamp
+}
+
+func (i *lastItem) GetPublicId() string                 { return i.publicId }
+func (i *lastItem) GetCreateTime() *timestamp.Timestamp { return i.createTime }
+func (i *lastItem) GetUpdateTime() *timestamp.Timestamp { return i.createTime }
@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package pagination
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken_MarshalParse(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tok, err := NewToken(ctx, resource.Host, []byte("some hash"))
+	require.NoError(t, err)
+	tok = tok.next(testItems(t, 1)[0])
+	tok.UpdatedAfter = time.Now().Add(-time.Hour)
+
+	s, err := tok.Marshal(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, s)
+
+	got, err := ParseToken(ctx, s)
+	require.NoError(t, err)
+	assert.True(t, tok.CreateTime.Equal(got.CreateTime))
+	assert.True(t, tok.LastItemCreateTime.Equal(got.LastItemCreateTime))
+	assert.Equal(t, tok.ResourceType, got.ResourceType)
+	assert.Equal(t, tok.GrantsHash, got.GrantsHash)
+	assert.Equal(t, tok.LastItemId, got.LastItemId)
+	assert.True(t, tok.UpdatedAfter.Equal(got.UpdatedAfter))
+
+	_, err = ParseToken(ctx, "")
+	assert.ErrorContains(t, err, "missing token")
+	_, err = ParseToken(ctx, "not-base58-0OIl")
+	assert.ErrorContains(t, err, "token is not base58 encoded")
+}
+
+func TestToken_Validate(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	grantsHash := []byte("some hash")
+	newTok := func() *Token {
+		tok, err := NewToken(ctx, resource.Host, grantsHash)
+		require.NoError(t, err)
+		return tok.next(testItems(t, 1)[0])
+	}
+
+	tests := []struct {
+		name    string
+		tok     func() *Token
+		wantErr string
+	}{
+		{
+			name: "valid",
+			tok:  newTok,
+		},
+		{
+			name:    "nil",
+			tok:     func() *Token { return nil },
+			wantErr: "missing token",
+		},
+		{
+			name: "wrong-resource-type",
+			tok: func() *Token {
+				tok := newTok()
+				tok.ResourceType = resource.Target
+				return tok
+			},
+			wantErr: "token resource type does not match expected resource type",
+		},
+		{
+			name: "changed-grants",
+			tok: func() *Token {
+				tok := newTok()
+				tok.GrantsHash = []byte("other hash")
+				return tok
+			},
+			wantErr: "grants have changed since the list operation was started",
+		},
+		{
+			name: "missing-last-item",
+			tok: func() *Token {
+				tok := newTok()
+				tok.LastItemId = ""
+				return tok
+			},
+			wantErr: "missing last item id",
+		},
+		{
+			name: "future",
+			tok: func() *Token {
+				tok := newTok()
+				tok.CreateTime = time.Now().Add(time.Hour)
+				return tok
+			},
+			wantErr: "token was created in the future",
+		},
+		{
+			name: "expired",
+			tok: func() *Token {
+				tok := newTok()
+				tok.CreateTime = time.Now().Add(-TokenLifetime - time.Hour)
+				return tok
+			},
+			wantErr: "token has expired",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.tok().Validate(ctx, resource.Host, grantsHash)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGrantsHash(t *testing.T) {
+	t.Parallel()
+	a := GrantsHash([]string{"id=*;type=*;actions=read", "id=*;type=target;actions=list"})
+	b := GrantsHash([]string{"id=*;type=target;actions=list", "id=*;type=*;actions=read"})
+	c := GrantsHash([]string{"id=*;type=target;actions=list"})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
@@ -4,7 +4,10 @@
 package perms
 
 import (
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -18,6 +21,9 @@ type AclGrant struct {
 	// The scope ID, which will be a project ID or an org ID
 	scope Scope
 
+	// The ID of the role the grant belongs to, if known
+	roleId string
+
 	// The ID to use
 	id string
 
@@ -29,6 +35,9 @@ type AclGrant struct {
 
 	// The set of output fields granted
 	OutputFields *OutputFields
+
+	// The conditions restricting when the grant is in effect, if any
+	conditions *conditions
 }
 
 // Actions returns the actions as a slice from the internal map, along with the
@@ -37,6 +46,21 @@ func (a AclGrant) Actions() ([]action.Type, []string) {
 	return a.actions.Actions()
 }
 
+// canonicalString returns the canonical representation of the grant for its
+// single ID
+func (a AclGrant) canonicalString() string {
+	g := Grant{
+		typ:          a.typ,
+		actions:      a.actions,
+		OutputFields: a.OutputFields,
+		conditions:   a.conditions,
+	}
+	if a.id != "" {
+		g.ids = []string{a.id}
+	}
+	return g.CanonicalString()
+}
+
 // ACL provides an entry point into the permissions engine for determining if an
 // action is allowed on a resource based on a principal's (user or group) grants.
 type ACL struct {
@@ -51,10 +75,36 @@ type ACLResults struct {
 	Authorized             bool
 	OutputFields           *OutputFields
 
+	// Trace describes how each grant was evaluated. It is only populated when
+	// Allowed is called with WithTrace.
+	Trace []GrantTrace
+
 	// This is included but unexported for testing/debugging
 	scopeMap map[string][]AclGrant
 }
 
+// GrantTrace describes how a grant was evaluated when determining whether an
+// action is allowed on a resource.
+type GrantTrace struct {
+	// RoleId is the ID of the role the grant belongs to, if known
+	RoleId string
+
+	// ScopeId is the scope the grant applies to
+	ScopeId string
+
+	// Grant is the canonical representation of the grant
+	Grant string
+
+	// Matched is true if the grant applies to the resource
+	Matched bool
+
+	// Authorized is true if the grant authorizes the action on the resource
+	Authorized bool
+
+	// Reason describes why the grant did or did not authorize the action
+	Reason string
+}
+
 // Permission provides information about the specific
 // resources that a user has been granted access to for a given scope, resource, and action.
 type Permission struct {
@@ -115,11 +165,42 @@ func NewACL(grants ...Grant) ACL {
 func aclGrantFromGrant(grant Grant, id string) AclGrant {
 	return AclGrant{
 		scope:        grant.scope,
+		roleId:       grant.roleId,
 		id:           id,
 		typ:          grant.typ,
 		actions:      grant.actions,
 		OutputFields: grant.OutputFields,
+		conditions:   grant.conditions,
+	}
+}
+
+// unmetCondition returns a description of the condition of the grant which is
+// not satisfied by the request described by the options, or an empty string if
+// the grant is in effect.
+func (a AclGrant) unmetCondition(opts options) string {
+	if a.conditions == nil {
+		return ""
+	}
+	now := opts.withRequestTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return a.conditions.unmet(now, opts.withClientIp)
+}
+
+// trace records how a grant was evaluated if tracing was requested
+func (r *ACLResults) trace(opts options, grant AclGrant, matched, authorized bool, reason string) {
+	if !opts.withTrace {
+		return
 	}
+	r.Trace = append(r.Trace, GrantTrace{
+		RoleId:     grant.roleId,
+		ScopeId:    grant.scope.Id,
+		Grant:      grant.canonicalString(),
+		Matched:    matched,
+		Authorized: authorized,
+		Reason:     reason,
+	})
 }
 
 // Allowed determines if the grants for an ACL allow an action for a resource.
@@ -130,13 +211,41 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
 
+	// Grants in other scopes never apply, but when tracing they are listed
+	// too so that it's clear they were considered
+	if opts.withTrace {
+		scopeIds := make([]string, 0, len(a.scopeMap))
+		for scopeId := range a.scopeMap {
+			if scopeId != r.ScopeId {
+				scopeIds = append(scopeIds, scopeId)
+			}
+		}
+		sort.Strings(scopeIds)
+		for _, scopeId := range scopeIds {
+			for _, grant := range a.scopeMap[scopeId] {
+				results.trace(opts, grant, false, false, fmt.Sprintf("grant applies to scope %s, not the resource's scope %s", scopeId, r.ScopeId))
+			}
+		}
+	}
+
 	var parentAction action.Type
 	split := strings.Split(aType.String(), ":")
 	if len(split) == 2 {
 		parentAction = action.Map[split[0]]
 	}
 	// Now, go through and check the cases indicated above
+	var done bool
 	for _, grant := range grants {
+		if done {
+			results.trace(opts, grant, false, false, "not evaluated, the action and all output fields were already granted")
+			continue
+		}
+		// Grants whose conditions aren't satisfied by the request have no
+		// effect at all
+		if unmet := grant.unmetCondition(opts); unmet != "" {
+			results.trace(opts, grant, false, false, unmet)
+			continue
+		}
 		var outputFieldsOnly bool
 		switch {
 		case len(grant.actions) == 0:
@@ -159,6 +268,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 		default:
 			// No actions in the grant match what we're looking for, so continue
 			// with the next grant
+			results.trace(opts, grant, false, false, fmt.Sprintf("grant does not include the %s action", aType))
 			continue
 		}
 
@@ -176,6 +286,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 		// that form is valid. The actual checking of whether the given action
 		// is granted to the user already happened above.
 		var found bool
+		var reason string
 		switch {
 		// Case 1: We only allow specific actions on specific types for the
 		// anonymous user. ID being supplied or not doesn't matter in this case,
@@ -192,12 +303,17 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 				grant.typ == resource.Scope &&
 				(aType == action.List || aType == action.NoOp):
 				found = true
+				reason = "anonymous users can discover scopes"
 
 			// Allow discovery of and authenticating to auth methods
 			case grant.typ == r.Type &&
 				grant.typ == resource.AuthMethod &&
 				(aType == action.List || aType == action.NoOp || aType == action.Authenticate):
 				found = true
+				reason = "anonymous users can discover and authenticate to auth methods"
+
+			default:
+				reason = "anonymous users are only granted discovery of scopes and auth methods and authentication"
 			}
 
 		// Case 2:
@@ -212,6 +328,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 			!action.Create.IsActionOrParent(aType):
 
 			found = true
+			reason = "grant's id matches the resource"
 
 		// Case 3: type=<resource.type>;actions=<action> when action is list or
 		// create (cannot be a wildcard). Must be a top level collection,
@@ -237,6 +354,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 				action.Create.IsActionOrParent(aType)):
 
 			found = true
+			reason = "grant's type matches the collection"
 
 		// Case 4:
 		// id=*;type=<resource.type>;actions=<action> where type cannot be
@@ -248,6 +366,7 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 				grant.typ == resource.All):
 
 			found = true
+			reason = "grant's wildcard id matches any resource of the type"
 
 		// Case 5:
 		// id=<pin>;type=<resource.type>;actions=<action> where type can be a
@@ -260,17 +379,29 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 			!resource.TopLevelType(r.Type):
 
 			found = true
+			reason = "grant's id matches the resource's parent"
+
+		default:
+			reason = "grant's id and type do not match the resource"
 		}
 
-		if found {
-			if !outputFieldsOnly {
-				results.Authorized = true
-			}
-			fields, _ := grant.OutputFields.Fields()
-			results.OutputFields = results.OutputFields.AddFields(fields)
-			if results.OutputFields.Has("*") && results.Authorized {
+		if !found {
+			results.trace(opts, grant, false, false, reason)
+			continue
+		}
+		if outputFieldsOnly {
+			reason += ", but the grant only includes output fields"
+		} else {
+			results.Authorized = true
+		}
+		results.trace(opts, grant, true, !outputFieldsOnly, reason)
+		fields, _ := grant.OutputFields.Fields()
+		results.OutputFields = results.OutputFields.AddFields(fields)
+		if results.OutputFields.Has("*") && results.Authorized {
+			if !opts.withTrace {
 				return
 			}
+			done = true
 		}
 	}
 	return
@@ -281,12 +412,15 @@ func (a ACL) Allowed(r Resource, aType action.Type, userId string, opt ...Option
 // There must be a grant for a given resource for one of the provided "id actions"
 // or for action.All in order for a Permission to be created for the scope.
 // The set of "id actions" is resource dependant, but will generally include all
-// actions that can be taken on an individual resource.
+// actions that can be taken on an individual resource. Grants whose conditions
+// are not satisfied by the request described by the options are ignored.
 func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 	requestedType resource.Type,
 	idActions action.ActionSet,
 	userId string,
+	opt ...Option,
 ) []Permission {
+	opts := getOpts(opt...)
 	perms := make([]Permission, 0, len(requestedScopes))
 	for scopeId := range requestedScopes {
 		p := Permission{
@@ -305,6 +439,9 @@ func (a ACL) ListPermissions(requestedScopes map[string]*scopes.ScopeInfo,
 		// Get grants for a specific scope id from the source of truth.
 		grants := a.scopeMap[scopeId]
 		for _, grant := range grants {
+			if grant.unmetCondition(opts) != "" {
+				continue
+			}
 			// This grant doesn't match what we're looking for, ignore.
 			if grant.typ != requestedType && grant.typ != resource.All && globals.ResourceInfoFromPrefix(grant.id).Type != requestedType {
 				continue
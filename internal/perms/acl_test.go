@@ -928,11 +928,11 @@ func Test_AnonRestrictions(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			require, assert := require.New(t), assert.New(t)
-			for i := resource.Type(1); i <= resource.StorageBucket; i++ {
+			for i := resource.Type(1); i <= resource.AccessRequest; i++ {
 				if i == resource.Controller || i == resource.Worker {
 					continue
 				}
-				for j := action.Type(1); j <= action.Download; j++ {
+				for j := action.Type(1); j <= action.Watch; j++ {
 					id := "foobar"
 					prefixes := globals.ResourcePrefixesFromType(resource.Type(i))
 					if len(prefixes) > 0 {
@@ -980,3 +980,98 @@ func Test_AnonRestrictions(t *testing.T) {
 		})
 	}
 }
+
+func Test_ACLAllowedTrace(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	parse := func(t *testing.T, roleId, scopeId, grant string) Grant {
+		t.Helper()
+		g, err := Parse(ctx, scopeId, grant, WithRoleId(roleId))
+		require.NoError(t, err)
+		return g
+	}
+	acl := NewACL(
+		parse(t, "r_other", "o_1234567890", "ids=*;type=target;actions=*"),
+		parse(t, "r_read", "p_1234567890", "ids=*;type=target;actions=read"),
+		parse(t, "r_other_target", "p_1234567890", "ids=ttcp_other;actions=authorize-session"),
+		parse(t, "r_expired", "p_1234567890", "ids=ttcp_1234567890;actions=authorize-session;not_after=2000-01-01T00:00:00Z"),
+		parse(t, "r_fields", "p_1234567890", "ids=ttcp_1234567890;output_fields=id,name"),
+		parse(t, "r_connect", "p_1234567890", "ids=ttcp_1234567890;actions=authorize-session"),
+	)
+	res := Resource{ScopeId: "p_1234567890", Id: "ttcp_1234567890", Type: resource.Target}
+
+	t.Run("without trace", func(t *testing.T) {
+		results := acl.Allowed(res, action.AuthorizeSession, "u_1234567890")
+		assert.True(t, results.Authorized)
+		assert.Empty(t, results.Trace)
+	})
+
+	t.Run("with trace", func(t *testing.T) {
+		results := acl.Allowed(res, action.AuthorizeSession, "u_1234567890", WithTrace(true))
+		assert.True(t, results.Authorized)
+		assert.Equal(t, []GrantTrace{
+			{
+				RoleId:  "r_other",
+				ScopeId: "o_1234567890",
+				Grant:   "ids=*;type=target;actions=*",
+				Reason:  "grant applies to scope o_1234567890, not the resource's scope p_1234567890",
+			},
+			{
+				RoleId:  "r_read",
+				ScopeId: "p_1234567890",
+				Grant:   "ids=*;type=target;actions=read",
+				Reason:  "grant does not include the authorize-session action",
+			},
+			{
+				RoleId:  "r_other_target",
+				ScopeId: "p_1234567890",
+				Grant:   "ids=ttcp_other;actions=authorize-session",
+				Reason:  "grant's id and type do not match the resource",
+			},
+			{
+				RoleId:  "r_expired",
+				ScopeId: "p_1234567890",
+				Grant:   "ids=ttcp_1234567890;actions=authorize-session;not_after=2000-01-01T00:00:00Z",
+				Reason:  "grant is not in effect after 2000-01-01T00:00:00Z",
+			},
+			{
+				RoleId:  "r_fields",
+				ScopeId: "p_1234567890",
+				Grant:   "ids=ttcp_1234567890;output_fields=id,name",
+				Matched: true,
+				Reason:  "grant's id matches the resource, but the grant only includes output fields",
+			},
+			{
+				RoleId:     "r_connect",
+				ScopeId:    "p_1234567890",
+				Grant:      "ids=ttcp_1234567890;actions=authorize-session",
+				Matched:    true,
+				Authorized: true,
+				Reason:     "grant's id matches the resource",
+			},
+		}, results.Trace)
+	})
+
+	t.Run("evaluation continues after decision", func(t *testing.T) {
+		acl := NewACL(
+			parse(t, "r_admin", "p_1234567890", "ids=*;type=*;actions=*;output_fields=*"),
+			parse(t, "r_read", "p_1234567890", "ids=*;type=target;actions=read"),
+		)
+		results := acl.Allowed(res, action.Read, "u_1234567890", WithTrace(true))
+		assert.True(t, results.Authorized)
+		require.Len(t, results.Trace, 2)
+		assert.True(t, results.Trace[0].Authorized)
+		assert.Equal(t, "grant's wildcard id matches any resource of the type", results.Trace[0].Reason)
+		assert.False(t, results.Trace[1].Authorized)
+		assert.Equal(t, "not evaluated, the action and all output fields were already granted", results.Trace[1].Reason)
+	})
+
+	t.Run("anonymous user", func(t *testing.T) {
+		acl := NewACL(parse(t, "r_anon", "p_1234567890", "ids=*;type=target;actions=read"))
+		results := acl.Allowed(res, action.Read, globals.AnonymousUserId, WithTrace(true))
+		assert.False(t, results.Authorized)
+		require.Len(t, results.Trace, 1)
+		assert.Equal(t, "anonymous users are only granted discovery of scopes and auth methods and authentication", results.Trace[0].Reason)
+	})
+}
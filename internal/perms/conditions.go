@@ -0,0 +1,335 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package perms
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// The grant fields used to set conditions
+const (
+	notBeforeField   = "not_before"
+	notAfterField    = "not_after"
+	daysField        = "days"
+	hoursField       = "hours"
+	timeZoneField    = "time_zone"
+	sourceCidrsField = "source_cidrs"
+)
+
+// weekdays are the names of days accepted in the days field, in the order
+// they are written out in canonical grants.
+var weekdays = []struct {
+	name string
+	day  time.Weekday
+}{
+	{"mon", time.Monday},
+	{"tue", time.Tuesday},
+	{"wed", time.Wednesday},
+	{"thu", time.Thursday},
+	{"fri", time.Friday},
+	{"sat", time.Saturday},
+	{"sun", time.Sunday},
+}
+
+const minutesPerDay = 24 * 60
+
+// conditions restrict when a grant is in effect. A grant with conditions only
+// applies to requests which satisfy all of them.
+type conditions struct {
+	// The grant is not in effect before this time, if set
+	notBefore time.Time
+
+	// The grant is not in effect after this time, if set
+	notAfter time.Time
+
+	// The days of the week the grant is in effect on, if set
+	days map[time.Weekday]bool
+
+	// The window of time during the day the grant is in effect, in minutes
+	// after midnight. If end is before start the window spans midnight.
+	hasHours               bool
+	startMinute, endMinute int
+
+	// The time zone days and hours are evaluated in, UTC by default
+	timeZone *time.Location
+
+	// The CIDRs the client IP of the request must be in, if set
+	sourceCidrs []netip.Prefix
+}
+
+func (c *conditions) location() *time.Location {
+	if c.timeZone == nil {
+		return time.UTC
+	}
+	return c.timeZone
+}
+
+// set parses the value of a condition field from a text grant
+func (c *conditions) set(ctx context.Context, key, value string) error {
+	const op = "perms.(conditions).set"
+	switch key {
+	case notBeforeField, notAfterField:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to parse %q as an RFC 3339 timestamp", key))
+		}
+		if key == notBeforeField {
+			c.notBefore = t
+		} else {
+			c.notAfter = t
+		}
+
+	case daysField:
+		c.days = make(map[time.Weekday]bool, len(weekdays))
+		for _, d := range strings.Split(value, ",") {
+			if err := c.addDays(ctx, d); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+
+	case hoursField:
+		start, end, ok := strings.Cut(value, "-")
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("hours %q must be formatted as HH:MM-HH:MM", value))
+		}
+		var err error
+		if c.startMinute, err = parseTimeOfDay(start); err != nil || c.startMinute == minutesPerDay {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid start time %q in hours", start))
+		}
+		if c.endMinute, err = parseTimeOfDay(end); err != nil {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid end time %q in hours", end))
+		}
+		if c.startMinute == c.endMinute {
+			return errors.New(ctx, errors.InvalidParameter, op, "hours must not start and end at the same time")
+		}
+		c.hasHours = true
+
+	case timeZoneField:
+		loc, err := time.LoadLocation(value)
+		if err != nil || value == "" || value == "Local" {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown time zone %q", value))
+		}
+		c.timeZone = loc
+
+	case sourceCidrsField:
+		cidrs := strings.Split(value, ",")
+		c.sourceCidrs = make([]netip.Prefix, 0, len(cidrs))
+		for _, cidr := range cidrs {
+			p, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to parse %q as a CIDR", cidr))
+			}
+			c.sourceCidrs = append(c.sourceCidrs, p.Masked())
+		}
+
+	default:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown condition %q", key))
+	}
+	return nil
+}
+
+// addDays adds a day or a range of days such as "mon-fri". Ranges can wrap
+// around the end of the week.
+func (c *conditions) addDays(ctx context.Context, days string) error {
+	const op = "perms.(conditions).addDays"
+	first, last, isRange := strings.Cut(strings.ToLower(days), "-")
+	if !isRange {
+		last = first
+	}
+	start, end := weekdayIndex(first), weekdayIndex(last)
+	if start < 0 || end < 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unknown day %q", days))
+	}
+	for i := start; ; i = (i + 1) % len(weekdays) {
+		c.days[weekdays[i].day] = true
+		if i == end {
+			break
+		}
+	}
+	return nil
+}
+
+// validate checks the conditions against each other once all have been set
+func (c *conditions) validate(ctx context.Context) error {
+	const op = "perms.(conditions).validate"
+	switch {
+	case !c.notBefore.IsZero() && !c.notAfter.IsZero() && !c.notAfter.After(c.notBefore):
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q must be after %q", notAfterField, notBeforeField))
+	case c.timeZone != nil && len(c.days) == 0 && !c.hasHours:
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%q requires %q or %q", timeZoneField, daysField, hoursField))
+	}
+	return nil
+}
+
+// unmet returns a description of the first condition which is not satisfied
+// by a request made at the given time from the given client IP, or an empty
+// string if all conditions are satisfied.
+func (c *conditions) unmet(now time.Time, clientIp string) string {
+	if c == nil {
+		return ""
+	}
+	if !c.notBefore.IsZero() && now.Before(c.notBefore) {
+		return fmt.Sprintf("grant is not in effect before %s", c.notBefore.Format(time.RFC3339))
+	}
+	if !c.notAfter.IsZero() && now.After(c.notAfter) {
+		return fmt.Sprintf("grant is not in effect after %s", c.notAfter.Format(time.RFC3339))
+	}
+	if len(c.days) > 0 || c.hasHours {
+		local := now.In(c.location())
+		minute := local.Hour()*60 + local.Minute()
+		day := local.Weekday()
+		if c.hasHours {
+			switch {
+			case c.startMinute < c.endMinute:
+				if minute < c.startMinute || minute >= c.endMinute {
+					return fmt.Sprintf("grant is only in effect during hours %s", c.hoursString())
+				}
+			case minute >= c.endMinute && minute < c.startMinute:
+				return fmt.Sprintf("grant is only in effect during hours %s", c.hoursString())
+			case minute < c.endMinute:
+				// The window spans midnight, so the early hours belong to the
+				// window that started the day before
+				day = (day + 6) % 7
+			}
+		}
+		if len(c.days) > 0 && !c.days[day] {
+			return fmt.Sprintf("grant is only in effect on days %s", c.daysString())
+		}
+	}
+	if len(c.sourceCidrs) > 0 {
+		ip, ok := parseClientIp(clientIp)
+		if !ok {
+			return "grant requires the client IP of the request"
+		}
+		var found bool
+		for _, p := range c.sourceCidrs {
+			if p.Contains(ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Sprintf("grant is only in effect for client IPs in %s", c.sourceCidrsString())
+		}
+	}
+	return ""
+}
+
+// segments returns the canonical text representation of the conditions
+func (c *conditions) segments() []string {
+	if c == nil {
+		return nil
+	}
+	var ret []string
+	if !c.notBefore.IsZero() {
+		ret = append(ret, fmt.Sprintf("%s=%s", notBeforeField, c.notBefore.UTC().Format(time.RFC3339)))
+	}
+	if !c.notAfter.IsZero() {
+		ret = append(ret, fmt.Sprintf("%s=%s", notAfterField, c.notAfter.UTC().Format(time.RFC3339)))
+	}
+	if len(c.days) > 0 {
+		ret = append(ret, fmt.Sprintf("%s=%s", daysField, c.daysString()))
+	}
+	if c.hasHours {
+		ret = append(ret, fmt.Sprintf("%s=%s", hoursField, c.hoursString()))
+	}
+	if c.timeZone != nil {
+		ret = append(ret, fmt.Sprintf("%s=%s", timeZoneField, c.timeZone.String()))
+	}
+	if len(c.sourceCidrs) > 0 {
+		ret = append(ret, fmt.Sprintf("%s=%s", sourceCidrsField, c.sourceCidrsString()))
+	}
+	return ret
+}
+
+// marshal adds the conditions to the JSON representation of a grant
+func (c *conditions) marshal(res map[string]any) {
+	if c == nil {
+		return
+	}
+	if !c.notBefore.IsZero() {
+		res[notBeforeField] = c.notBefore.UTC().Format(time.RFC3339)
+	}
+	if !c.notAfter.IsZero() {
+		res[notAfterField] = c.notAfter.UTC().Format(time.RFC3339)
+	}
+	if len(c.days) > 0 {
+		res[daysField] = strings.Split(c.daysString(), ",")
+	}
+	if c.hasHours {
+		res[hoursField] = c.hoursString()
+	}
+	if c.timeZone != nil {
+		res[timeZoneField] = c.timeZone.String()
+	}
+	if len(c.sourceCidrs) > 0 {
+		res[sourceCidrsField] = strings.Split(c.sourceCidrsString(), ",")
+	}
+}
+
+func (c *conditions) daysString() string {
+	days := make([]string, 0, len(c.days))
+	for _, d := range weekdays {
+		if c.days[d.day] {
+			days = append(days, d.name)
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+func (c *conditions) hoursString() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", c.startMinute/60, c.startMinute%60, c.endMinute/60, c.endMinute%60)
+}
+
+func (c *conditions) sourceCidrsString() string {
+	cidrs := make([]string, 0, len(c.sourceCidrs))
+	for _, p := range c.sourceCidrs {
+		cidrs = append(cidrs, p.String())
+	}
+	return strings.Join(cidrs, ",")
+}
+
+func weekdayIndex(name string) int {
+	for i, d := range weekdays {
+		if d.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseTimeOfDay parses a time formatted as HH:MM into minutes after midnight.
+// 24:00 is accepted to allow windows to end at midnight.
+func parseTimeOfDay(s string) (int, error) {
+	if s == "24:00" {
+		return minutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseClientIp parses the client IP of a request, which may include a port
+func parseClientIp(clientIp string) (netip.Addr, bool) {
+	if clientIp == "" {
+		return netip.Addr{}, false
+	}
+	if host, _, err := net.SplitHostPort(clientIp); err == nil {
+		clientIp = host
+	}
+	ip, err := netip.ParseAddr(clientIp)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
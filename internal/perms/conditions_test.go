@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package perms
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseConditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		input     string
+		canonical string
+		json      string
+		errSubstr string
+	}{
+		{
+			name:      "time bounds",
+			input:     "ids=*;type=target;actions=read;not_before=2024-01-01T09:00:00+01:00;not_after=2024-02-01T00:00:00Z",
+			canonical: "ids=*;type=target;actions=read;not_before=2024-01-01T08:00:00Z;not_after=2024-02-01T00:00:00Z",
+			json:      `{"actions":["read"],"ids":["*"],"not_after":"2024-02-01T00:00:00Z","not_before":"2024-01-01T08:00:00Z","type":"target"}`,
+		},
+		{
+			name:      "business hours",
+			input:     "ids=*;type=target;actions=read;days=fri,MON-wed,thu;hours=09:00-17:30;time_zone=America/New_York",
+			canonical: "ids=*;type=target;actions=read;days=mon,tue,wed,thu,fri;hours=09:00-17:30;time_zone=America/New_York",
+			json:      `{"actions":["read"],"days":["mon","tue","wed","thu","fri"],"hours":"09:00-17:30","ids":["*"],"time_zone":"America/New_York","type":"target"}`,
+		},
+		{
+			name:      "day range wrapping the week",
+			input:     "ids=*;type=target;actions=read;days=sat-mon;hours=22:00-24:00",
+			canonical: "ids=*;type=target;actions=read;days=mon,sat,sun;hours=22:00-24:00",
+			json:      `{"actions":["read"],"days":["mon","sat","sun"],"hours":"22:00-24:00","ids":["*"],"type":"target"}`,
+		},
+		{
+			name:      "source cidrs",
+			input:     "ids=*;type=target;actions=read;source_cidrs=10.1.2.3/8,2001:db8::/32",
+			canonical: "ids=*;type=target;actions=read;source_cidrs=10.0.0.0/8,2001:db8::/32",
+			json:      `{"actions":["read"],"ids":["*"],"source_cidrs":["10.0.0.0/8","2001:db8::/32"],"type":"target"}`,
+		},
+		{
+			name:      "json",
+			input:     `{"ids":["*"],"type":"target","actions":["read"],"days":["mon-fri"],"source_cidrs":["192.168.0.0/16"]}`,
+			canonical: "ids=*;type=target;actions=read;days=mon,tue,wed,thu,fri;source_cidrs=192.168.0.0/16",
+			json:      `{"actions":["read"],"days":["mon","tue","wed","thu","fri"],"ids":["*"],"source_cidrs":["192.168.0.0/16"],"type":"target"}`,
+		},
+		{
+			name:      "bad timestamp",
+			input:     "ids=*;type=target;actions=read;not_after=2024-02-01",
+			errSubstr: `unable to parse "not_after" as an RFC 3339 timestamp`,
+		},
+		{
+			name:      "not after before not before",
+			input:     "ids=*;type=target;actions=read;not_before=2024-02-01T00:00:00Z;not_after=2024-01-01T00:00:00Z",
+			errSubstr: `"not_after" must be after "not_before"`,
+		},
+		{
+			name:      "bad day",
+			input:     "ids=*;type=target;actions=read;days=mon-funday",
+			errSubstr: `unknown day "mon-funday"`,
+		},
+		{
+			name:      "bad hours",
+			input:     "ids=*;type=target;actions=read;hours=09:00",
+			errSubstr: `hours "09:00" must be formatted as HH:MM-HH:MM`,
+		},
+		{
+			name:      "bad hours start",
+			input:     "ids=*;type=target;actions=read;hours=24:00-08:00",
+			errSubstr: `invalid start time "24:00" in hours`,
+		},
+		{
+			name:      "empty hours",
+			input:     "ids=*;type=target;actions=read;hours=09:00-09:00",
+			errSubstr: "hours must not start and end at the same time",
+		},
+		{
+			name:      "bad time zone",
+			input:     "ids=*;type=target;actions=read;hours=09:00-17:00;time_zone=Mars/Olympus_Mons",
+			errSubstr: `unknown time zone "Mars/Olympus_Mons"`,
+		},
+		{
+			name:      "time zone without days or hours",
+			input:     "ids=*;type=target;actions=read;time_zone=UTC",
+			errSubstr: `"time_zone" requires "days" or "hours"`,
+		},
+		{
+			name:      "bad cidr",
+			input:     "ids=*;type=target;actions=read;source_cidrs=10.0.0.1",
+			errSubstr: `unable to parse "10.0.0.1" as a CIDR`,
+		},
+		{
+			name:      "bad json days",
+			input:     `{"ids":["*"],"type":"target","actions":["read"],"days":"mon"}`,
+			errSubstr: `unable to interpret "days" as array`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse(ctx, "p_1234567890", tt.input)
+			if tt.errSubstr != "" {
+				require.Error(err)
+				assert.Contains(err.Error(), tt.errSubstr)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.canonical, grant.CanonicalString())
+			j, err := grant.MarshalJSON(ctx)
+			require.NoError(err)
+			assert.Equal(tt.json, string(j))
+
+			// The canonical and JSON forms parse back into the same grant
+			for _, s := range []string{grant.CanonicalString(), string(j)} {
+				reparsed, err := Parse(ctx, "p_1234567890", s)
+				require.NoError(err)
+				assert.Equal(tt.canonical, reparsed.CanonicalString())
+			}
+		})
+	}
+}
+
+func Test_ACLAllowedConditions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// A Wednesday
+	wednesday := time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		conditions  string
+		requestTime time.Time
+		clientIp    string
+		allowed     bool
+	}{
+		{
+			name:        "before not before",
+			conditions:  "not_before=2024-01-11T00:00:00Z",
+			requestTime: wednesday,
+		},
+		{
+			name:        "after not before",
+			conditions:  "not_before=2024-01-10T00:00:00Z",
+			requestTime: wednesday,
+			allowed:     true,
+		},
+		{
+			name:        "after not after",
+			conditions:  "not_after=2024-01-10T11:59:59Z",
+			requestTime: wednesday,
+		},
+		{
+			name:        "between",
+			conditions:  "not_before=2024-01-01T00:00:00Z;not_after=2024-02-01T00:00:00Z",
+			requestTime: wednesday,
+			allowed:     true,
+		},
+		{
+			name:        "on allowed day",
+			conditions:  "days=mon-fri",
+			requestTime: wednesday,
+			allowed:     true,
+		},
+		{
+			name:        "on other day",
+			conditions:  "days=sat,sun",
+			requestTime: wednesday,
+		},
+		{
+			name:        "within hours",
+			conditions:  "days=mon-fri;hours=09:00-17:00",
+			requestTime: wednesday,
+			allowed:     true,
+		},
+		{
+			name:        "at end of hours",
+			conditions:  "hours=09:00-12:00",
+			requestTime: wednesday,
+		},
+		{
+			name:        "hours in time zone",
+			conditions:  "hours=09:00-17:00;time_zone=America/New_York",
+			requestTime: time.Date(2024, time.January, 10, 8, 0, 0, 0, ny),
+		},
+		{
+			name:        "within hours in time zone",
+			conditions:  "hours=09:00-17:00;time_zone=America/New_York",
+			requestTime: wednesday.Add(2 * time.Hour),
+			allowed:     true,
+		},
+		{
+			name:        "after midnight of overnight window",
+			conditions:  "days=fri;hours=22:00-06:00",
+			requestTime: time.Date(2024, time.January, 13, 2, 0, 0, 0, time.UTC),
+			allowed:     true,
+		},
+		{
+			name:        "after midnight of overnight window of other day",
+			conditions:  "days=sat;hours=22:00-06:00",
+			requestTime: time.Date(2024, time.January, 13, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "outside overnight window",
+			conditions:  "hours=22:00-06:00",
+			requestTime: wednesday,
+		},
+		{
+			name:        "client ip in cidr",
+			conditions:  "source_cidrs=192.168.0.0/16,10.0.0.0/8",
+			requestTime: wednesday,
+			clientIp:    "10.1.2.3",
+			allowed:     true,
+		},
+		{
+			name:        "client ip with port in cidr",
+			conditions:  "source_cidrs=10.0.0.0/8",
+			requestTime: wednesday,
+			clientIp:    "10.1.2.3:9200",
+			allowed:     true,
+		},
+		{
+			name:        "client ip not in cidr",
+			conditions:  "source_cidrs=10.0.0.0/8",
+			requestTime: wednesday,
+			clientIp:    "172.16.0.1",
+		},
+		{
+			name:        "no client ip",
+			conditions:  "source_cidrs=10.0.0.0/8",
+			requestTime: wednesday,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			grant, err := Parse(ctx, "p_1234567890", "ids=*;type=target;actions=read;"+tt.conditions)
+			require.NoError(err)
+			acl := NewACL(grant)
+			opts := []Option{WithRequestTime(tt.requestTime), WithClientIp(tt.clientIp)}
+
+			res := Resource{ScopeId: "p_1234567890", Id: "ttcp_1234567890", Type: resource.Target}
+			assert.Equal(tt.allowed, acl.Allowed(res, action.Read, "u_1234567890", opts...).Authorized)
+
+			permissions := acl.ListPermissions(
+				map[string]*scopes.ScopeInfo{"p_1234567890": nil},
+				resource.Target,
+				action.NewActionSet(action.Read),
+				"u_1234567890",
+				opts...,
+			)
+			assert.Equal(tt.allowed, len(permissions) == 1)
+		})
+	}
+
+	t.Run("unconditional grants are unaffected", func(t *testing.T) {
+		conditional, err := Parse(ctx, "p_1234567890", "ids=*;type=target;actions=read;not_after=2000-01-01T00:00:00Z")
+		require.NoError(t, err)
+		unconditional, err := Parse(ctx, "p_1234567890", "ids=*;type=target;actions=update")
+		require.NoError(t, err)
+		acl := NewACL(conditional, unconditional)
+		res := Resource{ScopeId: "p_1234567890", Id: "ttcp_1234567890", Type: resource.Target}
+		assert.False(t, acl.Allowed(res, action.Read, globals.AnyAuthenticatedUserId).Authorized)
+		assert.True(t, acl.Allowed(res, action.Update, globals.AnyAuthenticatedUserId).Authorized)
+	})
+}
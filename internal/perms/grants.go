@@ -60,6 +60,9 @@ type Grant struct {
 	// The scope, containing the ID and type
 	scope Scope
 
+	// The ID of the role the grant belongs to, if provided
+	roleId string
+
 	// The ID of the grant, if provided. Deprecated in favor of ids.
 	id string
 
@@ -75,6 +78,9 @@ type Grant struct {
 	// The set of output fields granted
 	OutputFields *OutputFields
 
+	// The conditions restricting when the grant is in effect, if any
+	conditions *conditions
+
 	// This is used as a temporary staging area before validating permissions to
 	// allow the same validation code across grant string formats
 	actionsBeingParsed []string
@@ -85,6 +91,11 @@ func (g Grant) Id() string {
 	return g.id
 }
 
+// RoleId returns the ID of the role the grant belongs to, if known
+func (g Grant) RoleId() string {
+	return g.roleId
+}
+
 // Ids returns the IDs the grant refers to, if any
 func (g Grant) Ids() []string {
 	return g.ids
@@ -117,10 +128,12 @@ func (g Grant) hasActionOrSubaction(act action.Type) bool {
 
 func (g Grant) clone() *Grant {
 	ret := &Grant{
-		scope: g.scope,
-		id:    g.id,
-		ids:   g.ids,
-		typ:   g.typ,
+		scope:      g.scope,
+		roleId:     g.roleId,
+		id:         g.id,
+		ids:        g.ids,
+		typ:        g.typ,
+		conditions: g.conditions,
 	}
 	if g.ids != nil {
 		ret.ids = make([]string, len(g.ids))
@@ -174,6 +187,8 @@ func (g Grant) CanonicalString() string {
 		builder = append(builder, fmt.Sprintf("output_fields=%s", strings.Join(outFields, ",")))
 	}
 
+	builder = append(builder, g.conditions.segments()...)
+
 	return strings.Join(builder, ";")
 }
 
@@ -201,6 +216,7 @@ func (g Grant) MarshalJSON(ctx context.Context) ([]byte, error) {
 	if outFields, hasSetFields := g.OutputFields.Fields(); hasSetFields {
 		res["output_fields"] = outFields
 	}
+	g.conditions.marshal(res)
 	b, err := json.Marshal(res)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.Encode))
@@ -307,6 +323,44 @@ func (g *Grant) unmarshalJSON(ctx context.Context, data []byte) error {
 			g.OutputFields = g.OutputFields.AddFields(fields)
 		}
 	}
+	for _, key := range []string{notBeforeField, notAfterField, hoursField, timeZoneField} {
+		rawValue, ok := raw[key]
+		if !ok {
+			continue
+		}
+		value, ok := rawValue.(string)
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as string", key))
+		}
+		if err := g.setCondition(ctx, key, value); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	for _, key := range []string{daysField, sourceCidrsField} {
+		rawValues, ok := raw[key]
+		if !ok {
+			continue
+		}
+		interfaceValues, ok := rawValues.([]any)
+		if !ok {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %q as array", key))
+		}
+		values := make([]string, 0, len(interfaceValues))
+		for _, v := range interfaceValues {
+			value, ok := v.(string)
+			switch {
+			case !ok:
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("unable to interpret %v in %s array as string", v, key))
+			case strings.ContainsAny(value, ",;="):
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s cannot contain a comma, semicolon or equals sign", key))
+			default:
+				values = append(values, value)
+			}
+		}
+		if err := g.setCondition(ctx, key, strings.Join(values, ",")); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
 	return nil
 }
 
@@ -367,12 +421,25 @@ func (g *Grant) unmarshalText(ctx context.Context, grantString string) error {
 			default:
 				g.OutputFields = g.OutputFields.AddFields(strings.Split(kv[1], ","))
 			}
+
+		case notBeforeField, notAfterField, daysField, hoursField, timeZoneField, sourceCidrsField:
+			if err := g.setCondition(ctx, kv[0], kv[1]); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
 		}
 	}
 
 	return nil
 }
 
+// setCondition sets the condition of the grant with the given field name
+func (g *Grant) setCondition(ctx context.Context, key, value string) error {
+	if g.conditions == nil {
+		g.conditions = &conditions{}
+	}
+	return g.conditions.set(ctx, key, value)
+}
+
 // Parse parses a grant string. Note that this does not do checking
 // of the validity of IDs and such; that's left for other parts of the system.
 // We may not check at all (e.g. let it be an authz-time failure) or could check
@@ -390,8 +457,11 @@ func Parse(ctx context.Context, scopeId, grantString string, opt ...Option) (Gra
 	}
 	grantString = strings.ToValidUTF8(grantString, string(unicode.ReplacementChar))
 
+	opts := getOpts(opt...)
+
 	grant := Grant{
-		scope: Scope{Id: strings.ToValidUTF8(scopeId, string(unicode.ReplacementChar))},
+		scope:  Scope{Id: strings.ToValidUTF8(scopeId, string(unicode.ReplacementChar))},
+		roleId: opts.withRoleId,
 	}
 	switch {
 	case scopeId == scope.Global.String():
@@ -422,8 +492,11 @@ func Parse(ctx context.Context, scopeId, grantString string, opt ...Option) (Gra
 	if len(grant.ids) > 1 && slices.Contains(grant.ids, "*") {
 		return Grant{}, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("input grant string %q contains both wildcard and non-wildcard values in %q field", grantString, "ids"))
 	}
-
-	opts := getOpts(opt...)
+	if grant.conditions != nil {
+		if err := grant.conditions.validate(ctx); err != nil {
+			return Grant{}, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("input grant string %q contains invalid conditions", grantString)))
+		}
+	}
 
 	var grantIds []string
 	var deprecatedId bool
@@ -588,8 +661,10 @@ func Parse(ctx context.Context, scopeId, grantString string, opt ...Option) (Gra
 				// Create a dummy resource and pass it through Allowed and
 				// ensure that we get allowed. We need to use the templated
 				// grant, if any, so we send in a clone with an updated ID.
+				// Conditions depend on the request so they are left out.
 				grantForValidation := grant.clone()
 				grantForValidation.id = grantIds[i]
+				grantForValidation.conditions = nil
 				acl := NewACL(*grantForValidation)
 				r := Resource{
 					ScopeId: scopeId,
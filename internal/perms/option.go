@@ -3,6 +3,8 @@
 
 package perms
 
+import "time"
+
 // getOpts - iterate the inbound Options and return a struct
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -23,6 +25,10 @@ type options struct {
 	withAccountId                     string
 	withSkipFinalValidation           bool
 	withSkipAnonymousUserRestrictions bool
+	withClientIp                      string
+	withRequestTime                   time.Time
+	withRoleId                        string
+	withTrace                         bool
 }
 
 func getDefaultOptions() options {
@@ -60,3 +66,36 @@ func WithSkipAnonymousUserRestrictions(with bool) Option {
 		o.withSkipAnonymousUserRestrictions = with
 	}
 }
+
+// WithClientIp provides the client IP of the request being authorized, which is
+// checked against the source CIDRs of grants that have them
+func WithClientIp(clientIp string) Option {
+	return func(o *options) {
+		o.withClientIp = clientIp
+	}
+}
+
+// WithRequestTime provides the time of the request being authorized, which is
+// checked against the time conditions of grants that have them. Defaults to
+// the current time.
+func WithRequestTime(t time.Time) Option {
+	return func(o *options) {
+		o.withRequestTime = t
+	}
+}
+
+// WithRoleId provides the ID of the role a grant being parsed belongs to
+func WithRoleId(roleId string) Option {
+	return func(o *options) {
+		o.withRoleId = roleId
+	}
+}
+
+// WithTrace records how each grant of the ACL was evaluated in the results of
+// Allowed. Evaluation does not stop early when tracing, so this should only be
+// used when explaining a decision.
+func WithTrace(with bool) Option {
+	return func(o *options) {
+		o.withTrace = with
+	}
+}
@@ -5,6 +5,7 @@ package perms
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -43,4 +44,33 @@ func Test_GetOpts(t *testing.T) {
 		opts = getOpts(WithSkipAnonymousUserRestrictions(true))
 		assert.True(opts.withSkipAnonymousUserRestrictions)
 	})
+	t.Run("with-client-ip", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Empty(opts.withClientIp)
+		opts = getOpts(WithClientIp("10.0.0.1"))
+		assert.Equal("10.0.0.1", opts.withClientIp)
+	})
+	t.Run("with-request-time", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.True(opts.withRequestTime.IsZero())
+		now := time.Now()
+		opts = getOpts(WithRequestTime(now))
+		assert.Equal(now, opts.withRequestTime)
+	})
+	t.Run("with-role-id", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.Empty(opts.withRoleId)
+		opts = getOpts(WithRoleId("r_1234567890"))
+		assert.Equal("r_1234567890", opts.withRoleId)
+	})
+	t.Run("with-trace", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts()
+		assert.False(opts.withTrace)
+		opts = getOpts(WithTrace(true))
+		assert.True(opts.withTrace)
+	})
 }
@@ -0,0 +1,412 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package filesystem provides a built-in storage plugin which stores the
+// objects of storage buckets in a directory on the local filesystem. It
+// allows recording sessions on workers which have no access to an object
+// store, for instance in air-gapped deployments and in tests.
+//
+// The objects of a storage bucket are stored under
+// <root>/<bucket name>/<bucket prefix>/<key>, where root is the directory
+// the plugin was created with. Bucket names must be a single path element
+// and neither bucket prefixes nor keys can refer to a location outside of
+// their bucket.
+package filesystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// PluginName is the name the filesystem storage plugin is registered
+	// with.
+	PluginName = "filesystem"
+
+	// defaultChunkSize is the size of the chunks GetObject streams an object
+	// in if the request does not set a chunk size.
+	defaultChunkSize = 64 * 1024
+
+	dirMode  fs.FileMode = 0o700
+	fileMode fs.FileMode = 0o600
+)
+
+var _ plgpb.StoragePluginServiceClient = (*StorageClient)(nil)
+
+// StorageClient is a plgpb.StoragePluginServiceClient which stores objects
+// in a directory on the local filesystem. It is safe for concurrent use.
+type StorageClient struct {
+	root string
+}
+
+// NewStorageClient returns a StorageClient which stores the storage buckets
+// in the directory root. root must be an existing directory.
+func NewStorageClient(root string) (*StorageClient, error) {
+	const op = "filesystem.NewStorageClient"
+	if root == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing root directory", op)
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	if !info.IsDir() {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: root %q is not a directory", op, root)
+	}
+	return &StorageClient{root: root}, nil
+}
+
+// OnCreateStorageBucket validates the bucket and creates its directory.
+func (c *StorageClient) OnCreateStorageBucket(ctx context.Context, req *plgpb.OnCreateStorageBucketRequest, _ ...grpc.CallOption) (*plgpb.OnCreateStorageBucketResponse, error) {
+	const op = "filesystem.(StorageClient).OnCreateStorageBucket"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	dir, err := c.bucketDir(req.GetBucket())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: unable to create bucket directory: %v", op, err)
+	}
+	return &plgpb.OnCreateStorageBucketResponse{
+		Persisted: &storagebuckets.StorageBucketPersisted{
+			Data: &structpb.Struct{Fields: make(map[string]*structpb.Value)},
+		},
+	}, nil
+}
+
+// OnUpdateStorageBucket validates the updated bucket and creates its
+// directory. Objects stored under the previous bucket name or prefix are
+// not moved.
+func (c *StorageClient) OnUpdateStorageBucket(ctx context.Context, req *plgpb.OnUpdateStorageBucketRequest, _ ...grpc.CallOption) (*plgpb.OnUpdateStorageBucketResponse, error) {
+	const op = "filesystem.(StorageClient).OnUpdateStorageBucket"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	dir, err := c.bucketDir(req.GetNewBucket())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: unable to create bucket directory: %v", op, err)
+	}
+	return &plgpb.OnUpdateStorageBucketResponse{
+		Persisted: &storagebuckets.StorageBucketPersisted{
+			Data: &structpb.Struct{Fields: make(map[string]*structpb.Value)},
+		},
+	}, nil
+}
+
+// OnDeleteStorageBucket validates the bucket. The objects of the bucket are
+// left in place.
+func (c *StorageClient) OnDeleteStorageBucket(ctx context.Context, req *plgpb.OnDeleteStorageBucketRequest, _ ...grpc.CallOption) (*plgpb.OnDeleteStorageBucketResponse, error) {
+	const op = "filesystem.(StorageClient).OnDeleteStorageBucket"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	if _, err := c.bucketDir(req.GetBucket()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	return &plgpb.OnDeleteStorageBucketResponse{}, nil
+}
+
+// ValidatePermissions checks that objects can be written to the directory of
+// the bucket.
+func (c *StorageClient) ValidatePermissions(ctx context.Context, req *plgpb.ValidatePermissionsRequest, _ ...grpc.CallOption) (*plgpb.ValidatePermissionsResponse, error) {
+	const op = "filesystem.(StorageClient).ValidatePermissions"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	dir, err := c.bucketDir(req.GetBucket())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: unable to create bucket directory: %v", op, err)
+	}
+	f, err := os.CreateTemp(dir, ".validate-*")
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: unable to write to bucket directory: %v", op, err)
+	}
+	name := f.Name()
+	_ = f.Close()
+	if err := os.Remove(name); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "%s: unable to delete from bucket directory: %v", op, err)
+	}
+	return &plgpb.ValidatePermissionsResponse{}, nil
+}
+
+// HeadObject returns the size and modification time of an object.
+func (c *StorageClient) HeadObject(ctx context.Context, req *plgpb.HeadObjectRequest, _ ...grpc.CallOption) (*plgpb.HeadObjectResponse, error) {
+	const op = "filesystem.(StorageClient).HeadObject"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	p, err := c.objectPath(req.GetBucket(), req.GetKey())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	info, err := os.Stat(p)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, status.Errorf(codes.NotFound, "%s: object %s not found", op, req.GetKey())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "%s: %v", op, err)
+	case !info.Mode().IsRegular():
+		return nil, status.Errorf(codes.NotFound, "%s: object %s not found", op, req.GetKey())
+	}
+	return &plgpb.HeadObjectResponse{
+		ContentLength: info.Size(),
+		LastModified:  timestamppb.New(info.ModTime()),
+	}, nil
+}
+
+// GetObject returns a stream of the contents of an object. The object is
+// read as the stream is received from.
+func (c *StorageClient) GetObject(ctx context.Context, req *plgpb.GetObjectRequest, _ ...grpc.CallOption) (plgpb.StoragePluginService_GetObjectClient, error) {
+	const op = "filesystem.(StorageClient).GetObject"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	p, err := c.objectPath(req.GetBucket(), req.GetKey())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+	f, err := os.Open(p)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, status.Errorf(codes.NotFound, "%s: object %s not found", op, req.GetKey())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "%s: %v", op, err)
+	}
+	info, err := f.Stat()
+	switch {
+	case err != nil:
+		_ = f.Close()
+		return nil, status.Errorf(codes.Internal, "%s: %v", op, err)
+	case !info.Mode().IsRegular():
+		_ = f.Close()
+		return nil, status.Errorf(codes.NotFound, "%s: object %s not found", op, req.GetKey())
+	}
+	chunkSize := req.GetChunkSize()
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+	return newGetObjectStream(ctx, f, int(chunkSize)), nil
+}
+
+// PutObject stores the file at the path of the request as an object,
+// replacing the object if it already exists. The object is written to a
+// temporary file first, so a partially written object is never visible.
+func (c *StorageClient) PutObject(ctx context.Context, req *plgpb.PutObjectRequest, _ ...grpc.CallOption) (*plgpb.PutObjectResponse, error) {
+	const op = "filesystem.(StorageClient).PutObject"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	if req.GetPath() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing path", op)
+	}
+	p, err := c.objectPath(req.GetBucket(), req.GetKey())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+
+	src, err := os.Open(req.GetPath())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: file check failed: %v", op, err)
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	switch {
+	case err != nil:
+		return nil, status.Errorf(codes.InvalidArgument, "%s: file check failed: %v", op, err)
+	case info.IsDir():
+		return nil, status.Errorf(codes.InvalidArgument, "%s: path is a directory", op)
+	}
+
+	dir := filepath.Dir(p)
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: unable to create object directory: %v", op, err)
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(p)+"-*")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: unable to create object: %v", op, err)
+	}
+	tmpName := tmp.Name()
+	removeTmp := func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), src); err != nil {
+		removeTmp()
+		return nil, status.Errorf(codes.Internal, "%s: unable to write object: %v", op, err)
+	}
+	if err := tmp.Chmod(fileMode); err != nil {
+		removeTmp()
+		return nil, status.Errorf(codes.Internal, "%s: unable to write object: %v", op, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		removeTmp()
+		return nil, status.Errorf(codes.Internal, "%s: unable to write object: %v", op, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return nil, status.Errorf(codes.Internal, "%s: unable to write object: %v", op, err)
+	}
+	if err := os.Rename(tmpName, p); err != nil {
+		_ = os.Remove(tmpName)
+		return nil, status.Errorf(codes.Internal, "%s: unable to write object: %v", op, err)
+	}
+
+	return &plgpb.PutObjectResponse{
+		ChecksumSha_256: hash.Sum(nil),
+	}, nil
+}
+
+// DeleteObjects deletes the object with the key prefix of the request or,
+// if the request is recursive, every object with a key which starts with
+// the key prefix. Deleting objects which do not exist is not an error.
+func (c *StorageClient) DeleteObjects(ctx context.Context, req *plgpb.DeleteObjectsRequest, _ ...grpc.CallOption) (*plgpb.DeleteObjectsResponse, error) {
+	const op = "filesystem.(StorageClient).DeleteObjects"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	p, err := c.objectPath(req.GetBucket(), req.GetKeyPrefix())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: %v", op, err)
+	}
+
+	if !req.GetRecursive() {
+		info, err := os.Lstat(p)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return &plgpb.DeleteObjectsResponse{}, nil
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "%s: %v", op, err)
+		case !info.Mode().IsRegular():
+			return &plgpb.DeleteObjectsResponse{}, nil
+		}
+		if err := os.Remove(p); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: unable to delete object: %v", op, err)
+		}
+		return &plgpb.DeleteObjectsResponse{ObjectsDeleted: 1}, nil
+	}
+
+	// A key prefix ending in a slash names a directory. Any other key prefix
+	// matches the entries of its parent directory which start with its base
+	// name.
+	var deleted uint32
+	if strings.HasSuffix(req.GetKeyPrefix(), "/") {
+		n, err := removeAll(p)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: unable to delete objects: %v", op, err)
+		}
+		return &plgpb.DeleteObjectsResponse{ObjectsDeleted: n}, nil
+	}
+	dir, base := filepath.Split(p)
+	entries, err := os.ReadDir(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return &plgpb.DeleteObjectsResponse{}, nil
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "%s: %v", op, err)
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), base) {
+			continue
+		}
+		n, err := removeAll(filepath.Join(dir, e.Name()))
+		deleted += n
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: unable to delete objects: %v", op, err)
+		}
+	}
+	return &plgpb.DeleteObjectsResponse{ObjectsDeleted: deleted}, nil
+}
+
+// removeAll removes p and any children it contains. It returns the number of
+// regular files which were removed.
+func removeAll(p string) (uint32, error) {
+	var n uint32
+	err := filepath.WalkDir(p, func(_ string, d fs.DirEntry, err error) error {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return nil
+		case err != nil:
+			return err
+		case d.Type().IsRegular():
+			n++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// bucketDir returns the directory the objects of bucket are stored in.
+func (c *StorageClient) bucketDir(bucket *storagebuckets.StorageBucket) (string, error) {
+	switch {
+	case bucket == nil:
+		return "", errors.New("missing storage bucket")
+	case bucket.GetBucketName() == "":
+		return "", errors.New("missing bucket name")
+	case len(bucket.GetSecrets().GetFields()) > 0:
+		return "", errors.New("secrets are not supported")
+	}
+	name := bucket.GetBucketName()
+	if name != filepath.Base(name) || !filepath.IsLocal(name) {
+		return "", errors.New("bucket name must be a single path element")
+	}
+	dir := name
+	if prefix := bucket.GetBucketPrefix(); prefix != "" {
+		prefix = filepath.FromSlash(path.Clean(prefix))
+		if !filepath.IsLocal(prefix) {
+			return "", errors.New("bucket prefix must be a relative path within the bucket")
+		}
+		dir = filepath.Join(dir, prefix)
+	}
+	return filepath.Join(c.root, dir), nil
+}
+
+// objectPath returns the path of the object key in bucket.
+func (c *StorageClient) objectPath(bucket *storagebuckets.StorageBucket, key string) (string, error) {
+	dir, err := c.bucketDir(bucket)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", errors.New("missing object key")
+	}
+	key = filepath.FromSlash(path.Clean(key))
+	if !filepath.IsLocal(key) {
+		return "", errors.New("object key must be a relative path within the bucket")
+	}
+	return filepath.Join(dir, key), nil
+}
@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testBucket(name, prefix string) *storagebuckets.StorageBucket {
+	return &storagebuckets.StorageBucket{
+		BucketName:   name,
+		BucketPrefix: prefix,
+		Attributes:   &structpb.Struct{},
+	}
+}
+
+func TestNewStorageClient(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "file")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+
+	tests := []struct {
+		name    string
+		root    string
+		wantErr bool
+	}{
+		{name: "valid", root: root},
+		{name: "missing-root", wantErr: true},
+		{name: "not-found", root: filepath.Join(root, "missing"), wantErr: true},
+		{name: "not-a-directory", root: file, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewStorageClient(tt.root)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, c)
+		})
+	}
+}
+
+func TestStorageClient_Buckets(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	c, err := NewStorageClient(root)
+	require.NoError(t, err)
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := c.OnCreateStorageBucket(ctx, &plgpb.OnCreateStorageBucketRequest{Bucket: testBucket("bucket", "prefix/a")})
+		require.NoError(t, err)
+		assert.NotNil(t, resp.GetPersisted().GetData())
+		assert.DirExists(t, filepath.Join(root, "bucket", "prefix", "a"))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		_, err := c.OnUpdateStorageBucket(ctx, &plgpb.OnUpdateStorageBucketRequest{NewBucket: testBucket("bucket", "prefix/b")})
+		require.NoError(t, err)
+		assert.DirExists(t, filepath.Join(root, "bucket", "prefix", "b"))
+	})
+
+	t.Run("validate-permissions", func(t *testing.T) {
+		_, err := c.ValidatePermissions(ctx, &plgpb.ValidatePermissionsRequest{Bucket: testBucket("bucket", "")})
+		require.NoError(t, err)
+		entries, err := os.ReadDir(filepath.Join(root, "bucket"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := c.OnDeleteStorageBucket(ctx, &plgpb.OnDeleteStorageBucketRequest{Bucket: testBucket("bucket", "")})
+		require.NoError(t, err)
+		assert.DirExists(t, filepath.Join(root, "bucket"))
+	})
+
+	invalid := []struct {
+		name   string
+		bucket *storagebuckets.StorageBucket
+	}{
+		{name: "missing-bucket"},
+		{name: "missing-bucket-name", bucket: testBucket("", "")},
+		{name: "nested-bucket-name", bucket: testBucket("a/b", "")},
+		{name: "parent-bucket-name", bucket: testBucket("..", "")},
+		{name: "escaping-prefix", bucket: testBucket("bucket", "../other")},
+		{name: "absolute-prefix", bucket: testBucket("bucket", "/etc")},
+		{
+			name: "secrets",
+			bucket: &storagebuckets.StorageBucket{
+				BucketName: "bucket",
+				Secrets: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"key": structpb.NewStringValue("value"),
+				}},
+			},
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.OnCreateStorageBucket(ctx, &plgpb.OnCreateStorageBucketRequest{Bucket: tt.bucket})
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestStorageClient_Objects(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	c, err := NewStorageClient(root)
+	require.NoError(t, err)
+	bucket := testBucket("bucket", "prefix")
+
+	data := bytes.Repeat([]byte("0123456789"), 10)
+	src := filepath.Join(t.TempDir(), "src")
+	require.NoError(t, os.WriteFile(src, data, 0o600))
+
+	t.Run("put", func(t *testing.T) {
+		resp, err := c.PutObject(ctx, &plgpb.PutObjectRequest{Bucket: bucket, Key: "s_1234567890.session/summary.json", Path: src})
+		require.NoError(t, err)
+		sum := sha256.Sum256(data)
+		assert.Equal(t, sum[:], resp.GetChecksumSha_256())
+
+		got, err := os.ReadFile(filepath.Join(root, "bucket", "prefix", "s_1234567890.session", "summary.json"))
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	})
+
+	t.Run("head", func(t *testing.T) {
+		resp, err := c.HeadObject(ctx, &plgpb.HeadObjectRequest{Bucket: bucket, Key: "s_1234567890.session/summary.json"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), resp.GetContentLength())
+		assert.NotNil(t, resp.GetLastModified())
+	})
+
+	t.Run("get", func(t *testing.T) {
+		stream, err := c.GetObject(ctx, &plgpb.GetObjectRequest{Bucket: bucket, Key: "s_1234567890.session/summary.json", ChunkSize: 32})
+		require.NoError(t, err)
+		var got []byte
+		var chunks int
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(resp.GetFileChunk()), 32)
+			got = append(got, resp.GetFileChunk()...)
+			chunks++
+		}
+		assert.Equal(t, data, got)
+		assert.Equal(t, 4, chunks)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		_, err := c.HeadObject(ctx, &plgpb.HeadObjectRequest{Bucket: bucket, Key: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = c.GetObject(ctx, &plgpb.GetObjectRequest{Bucket: bucket, Key: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = c.GetObject(ctx, &plgpb.GetObjectRequest{Bucket: bucket, Key: "s_1234567890.session"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, key := range []string{"sr_1.bsr/summary.json", "sr_1.bsr/cr_1.connection/summary.json", "sr_10.bsr/summary.json", "sr_2.bsr/summary.json"} {
+			_, err := c.PutObject(ctx, &plgpb.PutObjectRequest{Bucket: bucket, Key: key, Path: src})
+			require.NoError(t, err)
+		}
+
+		resp, err := c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "sr_2.bsr/summary.json"})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), resp.GetObjectsDeleted())
+		assert.NoFileExists(t, filepath.Join(root, "bucket", "prefix", "sr_2.bsr", "summary.json"))
+
+		resp, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "sr_1.bsr/"})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), resp.GetObjectsDeleted())
+
+		resp, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "sr_1.bsr/", Recursive: true})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(2), resp.GetObjectsDeleted())
+		assert.NoDirExists(t, filepath.Join(root, "bucket", "prefix", "sr_1.bsr"))
+		assert.FileExists(t, filepath.Join(root, "bucket", "prefix", "sr_10.bsr", "summary.json"))
+
+		resp, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "sr_1", Recursive: true})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1), resp.GetObjectsDeleted())
+		assert.NoDirExists(t, filepath.Join(root, "bucket", "prefix", "sr_10.bsr"))
+
+		resp, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "missing/", Recursive: true})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), resp.GetObjectsDeleted())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := c.PutObject(ctx, &plgpb.PutObjectRequest{Bucket: bucket, Key: "../../escape", Path: src})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.PutObject(ctx, &plgpb.PutObjectRequest{Bucket: bucket, Key: "key"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.PutObject(ctx, &plgpb.PutObjectRequest{Bucket: bucket, Key: "key", Path: filepath.Dir(src)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.HeadObject(ctx, &plgpb.HeadObjectRequest{Bucket: bucket})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, Recursive: true})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = c.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{Bucket: bucket, KeyPrefix: "..", Recursive: true})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var _ plgpb.StoragePluginService_GetObjectClient = (*getObjectStream)(nil)
+
+// getObjectStream streams the contents of an object file in chunks. The
+// file is closed once it has been read completely, the stream is closed or
+// the context of the stream is done.
+type getObjectStream struct {
+	ctx       context.Context
+	chunkSize int
+
+	mu     sync.Mutex
+	f      *os.File
+	closed bool
+}
+
+func newGetObjectStream(ctx context.Context, f *os.File, chunkSize int) *getObjectStream {
+	return &getObjectStream{
+		ctx:       ctx,
+		chunkSize: chunkSize,
+		f:         f,
+	}
+}
+
+// Recv returns the next chunk of the object. It returns io.EOF once the
+// object has been read completely.
+func (s *getObjectStream) Recv() (*plgpb.GetObjectResponse, error) {
+	const op = "filesystem.(getObjectStream).Recv"
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, io.EOF
+	}
+	if err := s.ctx.Err(); err != nil {
+		s.close()
+		return nil, status.FromContextError(err).Err()
+	}
+	buf := make([]byte, s.chunkSize)
+	n, err := io.ReadFull(s.f, buf)
+	switch {
+	case n > 0:
+		return &plgpb.GetObjectResponse{FileChunk: buf[:n]}, nil
+	case err == nil, errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		s.close()
+		return nil, io.EOF
+	default:
+		s.close()
+		return nil, status.Errorf(codes.Internal, "%s: failed to read object data: %v", op, err)
+	}
+}
+
+// CloseSend closes the stream and its file.
+func (s *getObjectStream) CloseSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.close()
+	return nil
+}
+
+func (s *getObjectStream) close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	_ = s.f.Close()
+}
+
+func (s *getObjectStream) Header() (metadata.MD, error) {
+	return make(metadata.MD), nil
+}
+
+func (s *getObjectStream) Trailer() metadata.MD {
+	return make(metadata.MD)
+}
+
+func (s *getObjectStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *getObjectStream) SendMsg(any) error {
+	return nil
+}
+
+func (s *getObjectStream) RecvMsg(any) error {
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ type TestPluginStorageServer struct {
 	HeadObjectFn            func(context.Context, *plgpb.HeadObjectRequest) (*plgpb.HeadObjectResponse, error)
 	GetObjectFn             func(*plgpb.GetObjectRequest, plgpb.StoragePluginService_GetObjectServer) error
 	PutObjectFn             func(context.Context, *plgpb.PutObjectRequest) (*plgpb.PutObjectResponse, error)
+	DeleteObjectsFn         func(context.Context, *plgpb.DeleteObjectsRequest) (*plgpb.DeleteObjectsResponse, error)
 	plgpb.UnimplementedStoragePluginServiceServer
 }
 
@@ -84,6 +86,13 @@ func (t TestPluginStorageServer) PutObject(ctx context.Context, req *plgpb.PutOb
 	return t.PutObjectFn(ctx, req)
 }
 
+func (t TestPluginStorageServer) DeleteObjects(ctx context.Context, req *plgpb.DeleteObjectsRequest) (*plgpb.DeleteObjectsResponse, error) {
+	if t.DeleteObjectsFn == nil {
+		return t.UnimplementedStoragePluginServiceServer.DeleteObjects(ctx, req)
+	}
+	return t.DeleteObjectsFn(ctx, req)
+}
+
 type (
 	BucketName string
 	ObjectName string
@@ -406,6 +415,56 @@ func (l *LoopbackStorage) putObject(ctx context.Context, req *plgpb.PutObjectReq
 	}, nil
 }
 
+func (l *LoopbackStorage) deleteObjects(ctx context.Context, req *plgpb.DeleteObjectsRequest) (*plgpb.DeleteObjectsResponse, error) {
+	const op = "loopback.(LoopbackStorage).deleteObjects"
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: request is nil", op)
+	}
+	if req.GetBucket() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing storage bucket", op)
+	}
+	if req.GetBucket().GetAttributes() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing attributes", op)
+	}
+	if req.GetBucket().GetBucketName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing bucket name", op)
+	}
+	if req.GetKeyPrefix() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: missing object key prefix", op)
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	bucket, ok := l.buckets[BucketName(req.GetBucket().GetBucketName())]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s: bucket not found", op)
+	}
+	for _, err := range l.errs {
+		if err.match(req.GetBucket(), req.GetKeyPrefix(), DeleteObjects) {
+			return nil, status.Errorf(err.ErrCode, "%s: %s", op, err.ErrMsg)
+		}
+	}
+
+	objectPath := path.Join(req.GetBucket().GetBucketPrefix(), req.GetKeyPrefix())
+	if !req.GetRecursive() {
+		if _, ok := bucket[ObjectName(objectPath)]; !ok {
+			return &plgpb.DeleteObjectsResponse{}, nil
+		}
+		delete(bucket, ObjectName(objectPath))
+		return &plgpb.DeleteObjectsResponse{ObjectsDeleted: 1}, nil
+	}
+	if strings.HasSuffix(req.GetKeyPrefix(), "/") {
+		objectPath += "/"
+	}
+	var deleted uint32
+	for name := range bucket {
+		if strings.HasPrefix(string(name), objectPath) {
+			delete(bucket, name)
+			deleted++
+		}
+	}
+	return &plgpb.DeleteObjectsResponse{ObjectsDeleted: deleted}, nil
+}
+
 // CloneBucket returns a clone of the bucket.
 // returns nil when the bucket is not found.
 func (l *LoopbackStorage) CloneBucket(name string) Bucket {
@@ -1309,3 +1309,136 @@ func TestLoopbackStoragePlugin(t *testing.T) {
 	require.NotEmpty(objectData)
 	assert.EqualValues(objectData, getObjectData)
 }
+
+func TestLoopbackDeleteObjects(t *testing.T) {
+	mockObject := func() *storagePluginStorageInfo {
+		return MockObject([]Chunk{[]byte("THIS IS A MOCKED OBJECT")})
+	}
+	attrs := &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"endpoint": structpb.NewStringValue("0.0.0.0"),
+		},
+	}
+	bucket := func(name string) *storagebuckets.StorageBucket {
+		return &storagebuckets.StorageBucket{
+			BucketName: name,
+			Attributes: attrs,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		request         *plgpb.DeleteObjectsRequest
+		expectedErr     codes.Code
+		expectedDeleted uint32
+		expectedObjects []ObjectName
+	}{
+		{
+			name:        "missing request",
+			expectedErr: codes.InvalidArgument,
+		},
+		{
+			name:        "missing storage bucket",
+			request:     &plgpb.DeleteObjectsRequest{},
+			expectedErr: codes.InvalidArgument,
+		},
+		{
+			name: "missing attributes",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    &storagebuckets.StorageBucket{BucketName: "aws_s3_mock"},
+				KeyPrefix: "sr_1.bsr/",
+			},
+			expectedErr: codes.InvalidArgument,
+		},
+		{
+			name: "missing key prefix",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket: bucket("aws_s3_mock"),
+			},
+			expectedErr: codes.InvalidArgument,
+		},
+		{
+			name: "bucket not found",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    bucket("missing"),
+				KeyPrefix: "sr_1.bsr/",
+			},
+			expectedErr: codes.NotFound,
+		},
+		{
+			name: "mock error",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    bucket("aws_s3_err"),
+				KeyPrefix: "sr_1.bsr/",
+			},
+			expectedErr: codes.PermissionDenied,
+		},
+		{
+			name: "single object",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    bucket("aws_s3_mock"),
+				KeyPrefix: "sr_1.bsr/summary.json",
+			},
+			expectedDeleted: 1,
+			expectedObjects: []ObjectName{"sr_1.bsr/cr_1.connection/summary.json", "sr_10.bsr/summary.json"},
+		},
+		{
+			name: "single object not found",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    bucket("aws_s3_mock"),
+				KeyPrefix: "sr_1.bsr/",
+			},
+			expectedObjects: []ObjectName{"sr_1.bsr/summary.json", "sr_1.bsr/cr_1.connection/summary.json", "sr_10.bsr/summary.json"},
+		},
+		{
+			name: "recursive",
+			request: &plgpb.DeleteObjectsRequest{
+				Bucket:    bucket("aws_s3_mock"),
+				KeyPrefix: "sr_1.bsr/",
+				Recursive: true,
+			},
+			expectedDeleted: 2,
+			expectedObjects: []ObjectName{"sr_10.bsr/summary.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require, assert := tr.New(t), ta.New(t)
+			plg, err := NewLoopbackPlugin(
+				WithMockBuckets(map[BucketName]Bucket{
+					"aws_s3_mock": {
+						"sr_1.bsr/summary.json":                 mockObject(),
+						"sr_1.bsr/cr_1.connection/summary.json": mockObject(),
+						"sr_10.bsr/summary.json":                mockObject(),
+					},
+					"aws_s3_err": {},
+				}),
+				WithMockError(PluginMockError{
+					BucketName: "aws_s3_err",
+					ObjectKey:  "sr_1.bsr/",
+					ErrMsg:     "invalid credentials",
+					ErrCode:    codes.PermissionDenied,
+				}),
+			)
+			require.NoError(err)
+			client := NewWrappingPluginStorageClient(plg)
+
+			resp, err := client.DeleteObjects(context.Background(), tt.request)
+			if tt.expectedErr != codes.OK {
+				assert.Error(err)
+				assert.Nil(resp)
+				s, ok := status.FromError(err)
+				require.True(ok, "invalid error type")
+				assert.Equal(tt.expectedErr, s.Code())
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.expectedDeleted, resp.GetObjectsDeleted())
+			var objects []ObjectName
+			for name := range plg.CloneBucket("aws_s3_mock") {
+				objects = append(objects, name)
+			}
+			assert.ElementsMatch(tt.expectedObjects, objects)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package policy contains the types shared by the different policy subtypes.
+package policy
+
+// Domain defines the domain for the policy package
+const Domain = "policy"
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package storage provides a policy which controls how long session
+// recordings are retained for.
+//
+// A storage policy is attached to the global scope or an org scope, and a
+// scope can have at most one storage policy. Storage buckets, and the session
+// recordings stored in them, inherit the storage policy of the scope of the
+// storage bucket or, if that scope has no storage policy, the storage policy
+// of the global scope. A storage policy has three settings:
+//
+//   - RetainForDays is the number of days a session recording must be
+//     retained for after it ended. -1 retains session recordings forever.
+//   - DeleteAfterDays is the number of days after which a session recording
+//     is deleted after it ended. 0 never deletes session recordings. It must
+//     not be less than RetainForDays.
+//   - LegalHold prevents the deletion of session recordings regardless of
+//     DeleteAfterDays.
+//
+// Expired session recordings are deleted by a job in the recording package.
+//
+// # Repository
+//
+// A repository provides methods for creating, updating, retrieving, and
+// deleting storage policies. A new repository should be created for each
+// transaction. For example:
+//
+//	var wrapper wrapping.Wrapper
+//	... init wrapper...
+//
+//	// db implements both the reader and writer interfaces.
+//	db, _ := db.Open(db.Postgres, url)
+//
+//	var repo *storage.Repository
+//
+//	repo, _ = storage.NewRepository(ctx, db, db, kms)
+//
+//	p, _ := storage.NewPolicy(ctx, orgId, storage.WithRetainForDays(30), storage.WithDeleteAfterDays(90))
+//	p, _ = repo.CreatePolicy(ctx, p)
+package storage
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/policy/storage/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithName", func(t *testing.T) {
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDescription", func(t *testing.T) {
+		opts := getOpts(WithDescription("test desc"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test desc"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithRetainForDays", func(t *testing.T) {
+		opts := getOpts(WithRetainForDays(-1))
+		testOpts := getDefaultOptions()
+		testOpts.withRetainForDays = -1
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithDeleteAfterDays", func(t *testing.T) {
+		opts := getOpts(WithDeleteAfterDays(30))
+		testOpts := getDefaultOptions()
+		testOpts.withDeleteAfterDays = 30
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLegalHold", func(t *testing.T) {
+		opts := getOpts(WithLegalHold(true))
+		testOpts := getDefaultOptions()
+		testOpts.withLegalHold = true
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPublicId", func(t *testing.T) {
+		opts := getOpts(WithPublicId("pst_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withPublicId = "pst_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		updateTime := time.Now()
+		createTime := time.Now()
+		opts := getOpts(WithStartPageAfterItem(&Policy{
+			Policy: &store.Policy{
+				PublicId:   "pst_1234567890",
+				CreateTime: timestamp.New(createTime),
+				UpdateTime: timestamp.New(updateTime),
+			},
+		}))
+		assert.Equal(opts.withStartPageAfterItem.GetPublicId(), "pst_1234567890")
+		assert.Equal(opts.withStartPageAfterItem.GetUpdateTime(), timestamp.New(updateTime))
+		assert.Equal(opts.withStartPageAfterItem.GetCreateTime(), timestamp.New(createTime))
+	})
+}
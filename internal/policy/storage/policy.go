@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/policy/storage/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// A Policy controls how long the session recordings stored in the storage
+// buckets of its scope, and of the scopes which inherit it, are retained
+// for. It is attached to the global scope or an org scope.
+type Policy struct {
+	*store.Policy
+	tableName string `gorm:"-"`
+}
+
+// NewPolicy creates a new in memory Policy attached to scopeId. Name,
+// description, retain for days, delete after days, and legal hold are the
+// only valid options. All other options are ignored.
+func NewPolicy(ctx context.Context, scopeId string, opt ...Option) (*Policy, error) {
+	const op = "storage.NewPolicy"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+
+	opts := getOpts(opt...)
+	if err := validateDurations(ctx, opts.withRetainForDays, opts.withDeleteAfterDays); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	p := &Policy{
+		Policy: &store.Policy{
+			ScopeId:         scopeId,
+			Name:            opts.withName,
+			Description:     opts.withDescription,
+			RetainForDays:   opts.withRetainForDays,
+			DeleteAfterDays: opts.withDeleteAfterDays,
+			LegalHold:       opts.withLegalHold,
+		},
+	}
+	return p, nil
+}
+
+// validateDurations checks that retainForDays and deleteAfterDays are
+// consistent with each other.
+func validateDurations(ctx context.Context, retainForDays, deleteAfterDays int32) error {
+	const op = "storage.validateDurations"
+	switch {
+	case retainForDays < -1:
+		return errors.New(ctx, errors.InvalidParameter, op, "retain for days must be -1 or greater")
+	case deleteAfterDays < 0:
+		return errors.New(ctx, errors.InvalidParameter, op, "delete after days must not be negative")
+	case deleteAfterDays > 0 && retainForDays == -1:
+		return errors.New(ctx, errors.InvalidParameter, op, "session recordings which are retained forever cannot be deleted")
+	case deleteAfterDays > 0 && deleteAfterDays < retainForDays:
+		return errors.New(ctx, errors.InvalidParameter, op, "delete after days must not be less than retain for days")
+	}
+	return nil
+}
+
+func (p *Policy) clone() *Policy {
+	cp := proto.Clone(p.Policy)
+	return &Policy{
+		Policy: cp.(*store.Policy),
+	}
+}
+
+// TableName returns the table name for the storage policy.
+func (p *Policy) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return "policy_storage_policy"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (p *Policy) SetTableName(n string) {
+	p.tableName = n
+}
+
+func allocPolicy() *Policy {
+	return &Policy{
+		Policy: &store.Policy{},
+	}
+}
+
+func newPolicyMetadata(p *Policy, op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{p.GetPublicId()},
+		"resource-type":      []string{"storage policy"},
+		"op-type":            []string{op.String()},
+	}
+	if p.ScopeId != "" {
+		metadata["scope-id"] = []string{p.ScopeId}
+	}
+	return metadata
+}
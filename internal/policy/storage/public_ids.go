@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storage
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/policy"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+func init() {
+	globals.RegisterPrefixToResourceInfo(globals.StoragePolicyPrefix, resource.Policy, policy.Domain, Subtype)
+}
+
+// PublicId prefixes for the resources in the storage policy package.
+const (
+	Subtype = globals.Subtype("storage")
+)
+
+func newPolicyId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.StoragePolicyPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "storage.newPolicyId")
+	}
+	return id, nil
+}
@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// CreatePolicy inserts p into the repository and returns a new Policy
+// containing the policy's PublicId. p is not changed. p must contain a valid
+// ScopeId of the global scope or an org scope. p must not contain a
+// PublicId. The PublicId is generated and assigned by this method.
+// WithPublicId is the only valid option.
+//
+// A scope can have at most one storage policy.
+//
+// Both p.CreateTime and p.UpdateTime are ignored.
+func (r *Repository) CreatePolicy(ctx context.Context, p *Policy, opt ...Option) (*Policy, error) {
+	const op = "storage.(Repository).CreatePolicy"
+	switch {
+	case p == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil Policy")
+	case p.Policy == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Policy")
+	case p.ScopeId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case p.PublicId != "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "public id not empty")
+	}
+	if err := validateDurations(ctx, p.RetainForDays, p.DeleteAfterDays); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	p = p.clone()
+
+	opts := getOpts(opt...)
+
+	if opts.withPublicId != "" {
+		if !strings.HasPrefix(opts.withPublicId, globals.StoragePolicyPrefix+"_") {
+			return nil, errors.New(ctx,
+				errors.InvalidPublicId,
+				op,
+				fmt.Sprintf("passed-in public ID %q has wrong prefix, should be %q", opts.withPublicId, globals.StoragePolicyPrefix),
+			)
+		}
+		p.PublicId = opts.withPublicId
+	} else {
+		id, err := newPolicyId(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		p.PublicId = id
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_CREATE)
+
+	var newPolicy *Policy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newPolicy = p.clone()
+			if err := w.Create(ctx, newPolicy, db.WithOplog(oplogWrapper, metadata)); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("scope %s already has a storage policy", p.ScopeId)))
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in scope: %s", p.ScopeId)))
+	}
+	return newPolicy, nil
+}
+
+// UpdatePolicy updates the repository entry for p.PublicId with the values
+// in p for the fields listed in fieldMask. It returns a new Policy
+// containing the updated values and a count of the number of records
+// updated. p is not changed.
+//
+// p must contain a valid PublicId and ScopeId. Only p.Name, p.Description,
+// p.RetainForDays, p.DeleteAfterDays, and p.LegalHold can be updated. The
+// updated p.RetainForDays and p.DeleteAfterDays must be consistent with each
+// other.
+//
+// An attribute of p will be set to NULL in the database if the attribute in
+// p is the zero value and it is included in fieldMask. Retain for days,
+// delete after days, and legal hold are set to their zero values instead.
+func (r *Repository) UpdatePolicy(ctx context.Context, p *Policy, version uint32, fieldMask []string, opt ...Option) (*Policy, int, error) {
+	const op = "storage.(Repository).UpdatePolicy"
+	switch {
+	case p == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil Policy")
+	case p.Policy == nil:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "nil embedded Policy")
+	case p.PublicId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	case p.ScopeId == "":
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	case len(fieldMask) == 0:
+		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
+	}
+
+	p = p.clone()
+
+	var dbMask, nullFields []string
+	for _, f := range fieldMask {
+		switch {
+		case strings.EqualFold("name", f) && p.Name == "":
+			nullFields = append(nullFields, "name")
+		case strings.EqualFold("name", f) && p.Name != "":
+			dbMask = append(dbMask, "name")
+		case strings.EqualFold("description", f) && p.Description == "":
+			nullFields = append(nullFields, "description")
+		case strings.EqualFold("description", f) && p.Description != "":
+			dbMask = append(dbMask, "description")
+		case strings.EqualFold("retain_for_days", f):
+			dbMask = append(dbMask, "retain_for_days")
+		case strings.EqualFold("delete_after_days", f):
+			dbMask = append(dbMask, "delete_after_days")
+		case strings.EqualFold("legal_hold", f):
+			dbMask = append(dbMask, "legal_hold")
+
+		default:
+			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
+		}
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_UPDATE)
+
+	var rowsUpdated int
+	var returnedPolicy *Policy
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current := allocPolicy()
+			current.PublicId = p.PublicId
+			if err := reader.LookupByPublicId(ctx, current); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			// Validate the durations the policy will have once it is
+			// updated so the caller gets a useful error message.
+			retainForDays, deleteAfterDays := current.RetainForDays, current.DeleteAfterDays
+			for _, f := range dbMask {
+				switch f {
+				case "retain_for_days":
+					retainForDays = p.RetainForDays
+				case "delete_after_days":
+					deleteAfterDays = p.DeleteAfterDays
+				}
+			}
+			if err := validateDurations(ctx, retainForDays, deleteAfterDays); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			returnedPolicy = p.clone()
+			var err error
+			rowsUpdated, err = w.Update(
+				ctx,
+				returnedPolicy,
+				dbMask,
+				nullFields,
+				db.WithOplog(oplogWrapper, metadata),
+				db.WithVersion(&version),
+			)
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if rowsUpdated == 0 {
+				return nil
+			}
+			// Read the policy back so fields which were not in the field
+			// mask reflect what is stored.
+			returnedPolicy = allocPolicy()
+			returnedPolicy.PublicId = p.PublicId
+			if err := reader.LookupByPublicId(ctx, returnedPolicy); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("in %s", p.PublicId)))
+	}
+
+	return returnedPolicy, rowsUpdated, nil
+}
+
+// LookupPolicy returns the Policy for id. Returns nil, nil if no Policy is
+// found for id.
+func (r *Repository) LookupPolicy(ctx context.Context, id string, opt ...Option) (*Policy, error) {
+	const op = "storage.(Repository).LookupPolicy"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	p := allocPolicy()
+	p.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, p); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	return p, nil
+}
+
+// LookupPolicyByScopeId returns the Policy attached to scopeId. Returns nil,
+// nil if no Policy is attached to scopeId. The Policy a scope inherits is
+// not returned.
+func (r *Repository) LookupPolicyByScopeId(ctx context.Context, scopeId string, opt ...Option) (*Policy, error) {
+	const op = "storage.(Repository).LookupPolicyByScopeId"
+	if scopeId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	p := allocPolicy()
+	if err := r.reader.LookupWhere(ctx, p, "scope_id = ?", []any{scopeId}); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", scopeId)))
+	}
+	return p, nil
+}
+
+// ListPolicies returns a slice of Policies for the scope IDs ordered by
+// create time and public id. WithLimit and WithStartPageAfterItem are the
+// only options supported.
+func (r *Repository) ListPolicies(ctx context.Context, scopeIds []string, opt ...Option) ([]*Policy, error) {
+	const op = "storage.(Repository).ListPolicies"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var policies []*Policy
+	where, args := "scope_id in (?)", []any{scopeIds}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	err := r.reader.SearchWhere(ctx, &policies, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc"))
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return policies, nil
+}
+
+// DeletePolicy deletes id from the repository returning a count of the
+// number of records deleted. Session recordings which inherited the deleted
+// Policy inherit the Policy of the global scope, if any, instead.
+func (r *Repository) DeletePolicy(ctx context.Context, id string, opt ...Option) (int, error) {
+	const op = "storage.(Repository).DeletePolicy"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+
+	p := allocPolicy()
+	p.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, p); err != nil {
+		if errors.IsNotFoundError(err) {
+			return db.NoRowsAffected, nil
+		}
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	if p.ScopeId == "" {
+		return db.NoRowsAffected, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, p.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	metadata := newPolicyMetadata(p, oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deletePolicy := p.clone()
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deletePolicy, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(ctx, errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("delete failed for %s", p.PublicId)))
+	}
+
+	return rowsDeleted, nil
+}
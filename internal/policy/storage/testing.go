@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPolicy creates a storage policy attached to scopeId. WithName,
+// WithDescription, WithRetainForDays, WithDeleteAfterDays, and WithLegalHold
+// are supported. If any errors are encountered during the creation of the
+// policy, the test will fail.
+func TestPolicy(t testing.TB, rw *db.Db, scopeId string, opt ...Option) *Policy {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+
+	p, err := NewPolicy(ctx, scopeId, opt...)
+	require.NoError(err)
+	p.PublicId, err = newPolicyId(ctx)
+	require.NoError(err)
+	require.NoError(rw.Create(ctx, p))
+	return p
+}
@@ -7,9 +7,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
@@ -96,6 +99,10 @@ func DefaultLimiterMaxQuotas() int {
 // Config is used to configure rate limits. Each config is used to specify
 // the maximum number of requests that can be made in a time period for the
 // corresponding resources and actions.
+//
+// A config with users or roles is an override. It only applies to requests
+// made with an auth token of one of the users, or of a user which is a
+// principal of one of the roles.
 type Config struct {
 	Resources []string      `hcl:"resources"`
 	Actions   []string      `hcl:"actions"`
@@ -104,6 +111,28 @@ type Config struct {
 	PeriodHCL string        `hcl:"period"`
 	Period    time.Duration `hcl:"-"`
 	Unlimited bool          `hcl:"unlimited"`
+	Users     []string      `hcl:"users"`
+	Roles     []string      `hcl:"roles"`
+}
+
+// isOverride returns true when the config only applies to some users or
+// roles.
+func (c *Config) isOverride() bool {
+	return len(c.Users) > 0 || len(c.Roles) > 0
+}
+
+// principalsKey identifies the users and roles of an override, regardless of
+// their order.
+func (c *Config) principalsKey() string {
+	return principalsKey(c.Users, c.Roles)
+}
+
+func principalsKey(users, roles []string) string {
+	users = slices.Clone(users)
+	slices.Sort(users)
+	roles = slices.Clone(roles)
+	slices.Sort(roles)
+	return strings.Join(users, ",") + "|" + strings.Join(roles, ",")
 }
 
 // Configs is an ordered set of Config.
@@ -115,10 +144,72 @@ func (c Configs) Equal(o Configs) bool {
 }
 
 // Limits creates a slice of rate.Limit from the Configs. This will enumerate
-// every combination of resource+action, defining a Limit for each.
+// every combination of resource+action, defining a Limit for each. Overrides
+// are not included, see Overrides.
 func (c Configs) Limits(ctx context.Context) ([]rate.Limit, error) {
 	const op = "ratelimit.(Configs).Limits"
+	return c.limits(ctx, op, func(cc *Config) bool {
+		return !cc.isOverride()
+	})
+}
+
+// Override is a set of rate limits which apply instead of the default rate
+// limits to requests made by the users, or by users which are principals of
+// the roles.
+type Override struct {
+	Users  []string
+	Roles  []string
+	Limits []rate.Limit
+}
+
+// Overrides creates an Override for each set of users and roles of the
+// Configs, in the order they are first configured. The limits of an Override
+// are the ones created by Limits, with the Configs of its users and roles
+// applied on top, so a resource+action which isn't overridden keeps its
+// default limits.
+func (c Configs) Overrides(ctx context.Context) ([]*Override, error) {
+	const op = "ratelimit.(Configs).Overrides"
+
+	var overrides []*Override
+	seen := make(map[string]bool)
+	for _, cc := range c {
+		if !cc.isOverride() {
+			continue
+		}
+		for _, u := range cc.Users {
+			if !strings.HasPrefix(u, globals.UserPrefix+"_") {
+				return nil, errors.New(ctx, errors.InvalidConfiguration, op, "", errors.WithMsg("invalid user id %s", u))
+			}
+		}
+		for _, r := range cc.Roles {
+			if !strings.HasPrefix(r, globals.RolePrefix+"_") {
+				return nil, errors.New(ctx, errors.InvalidConfiguration, op, "", errors.WithMsg("invalid role id %s", r))
+			}
+		}
+		key := cc.principalsKey()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
 
+		limits, err := c.limits(ctx, op, func(o *Config) bool {
+			return !o.isOverride() || o.principalsKey() == key
+		})
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, &Override{
+			Users:  cc.Users,
+			Roles:  cc.Roles,
+			Limits: limits,
+		})
+	}
+	return overrides, nil
+}
+
+// limits creates the rate.Limits from the defaults and the Configs for which
+// include returns true.
+func (c Configs) limits(ctx context.Context, op errors.Op, include func(*Config) bool) ([]rate.Limit, error) {
 	defaults := make(map[string]rate.Limit, len(resource.Map)*len(action.Map))
 
 	allResources := make([]resource.Type, 0, len(resource.Map))
@@ -193,6 +284,9 @@ func (c Configs) Limits(ctx context.Context) ([]rate.Limit, error) {
 	}
 
 	for _, cc := range c {
+		if !include(cc) {
+			continue
+		}
 		var resourceSet []resource.Type
 		switch {
 		case len(cc.Resources) == 1 && cc.Resources[0] == resource.All.String():
@@ -779,6 +779,195 @@ func TestConfigsLimits(t *testing.T) {
 	}
 }
 
+func TestConfigsOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	defaultLimits, err := Configs{}.Limits(ctx)
+	require.NoError(t, err)
+
+	// withLimits returns the default limits with the target list limits per
+	// auth token replaced by limit.
+	withLimits := func(limit uint64, per rate.LimitPer, actions ...action.Type) []rate.Limit {
+		limits := make([]rate.Limit, 0, len(defaultLimits))
+		for _, l := range defaultLimits {
+			for _, a := range actions {
+				if l.GetResource() == resource.Target.String() && l.GetAction() == a.String() && l.GetPer() == per {
+					l = &rate.Limited{
+						Resource:    l.GetResource(),
+						Action:      l.GetAction(),
+						Per:         per,
+						MaxRequests: limit,
+						Period:      time.Minute,
+					}
+				}
+			}
+			limits = append(limits, l)
+		}
+		return limits
+	}
+
+	cases := []struct {
+		name    string
+		configs Configs
+		want    []*Override
+		wantErr error
+	}{
+		{
+			"none",
+			Configs{},
+			nil,
+			nil,
+		},
+		{
+			"user",
+			Configs{
+				{
+					Resources: []string{"target"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Users:     []string{"u_1234567890"},
+				},
+			},
+			[]*Override{
+				{
+					Users:  []string{"u_1234567890"},
+					Limits: withLimits(1000, rate.LimitPerAuthToken, action.List),
+				},
+			},
+			nil,
+		},
+		{
+			"same-principals",
+			Configs{
+				{
+					Resources: []string{"target"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Users:     []string{"u_1234567890", "u_0987654321"},
+				},
+				{
+					Resources: []string{"target"},
+					Actions:   []string{"read"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Users:     []string{"u_0987654321", "u_1234567890"},
+				},
+			},
+			[]*Override{
+				{
+					Users:  []string{"u_1234567890", "u_0987654321"},
+					Limits: withLimits(1000, rate.LimitPerAuthToken, action.List, action.Read),
+				},
+			},
+			nil,
+		},
+		{
+			"roles-and-users",
+			Configs{
+				{
+					Resources: []string{"target"},
+					Actions:   []string{"list"},
+					Per:       "total",
+					Limit:     5000,
+					Period:    time.Minute,
+					Roles:     []string{"r_1234567890"},
+				},
+				{
+					Resources: []string{"target"},
+					Actions:   []string{"list"},
+					Per:       "auth-token",
+					Limit:     1000,
+					Period:    time.Minute,
+					Users:     []string{"u_1234567890"},
+				},
+			},
+			[]*Override{
+				{
+					Roles:  []string{"r_1234567890"},
+					Limits: withLimits(5000, rate.LimitPerTotal, action.List),
+				},
+				{
+					Users:  []string{"u_1234567890"},
+					Limits: withLimits(1000, rate.LimitPerAuthToken, action.List),
+				},
+			},
+			nil,
+		},
+		{
+			"invalid-user",
+			Configs{
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"*"},
+					Per:       "total",
+					Limit:     10,
+					Period:    time.Minute,
+					Users:     []string{"r_1234567890"},
+				},
+			},
+			nil,
+			fmt.Errorf("ratelimit.(Configs).Overrides: invalid user id r_1234567890: configuration issue: error #5000"),
+		},
+		{
+			"invalid-role",
+			Configs{
+				{
+					Resources: []string{"*"},
+					Actions:   []string{"*"},
+					Per:       "total",
+					Limit:     10,
+					Period:    time.Minute,
+					Roles:     []string{"admin"},
+				},
+			},
+			nil,
+			fmt.Errorf("ratelimit.(Configs).Overrides: invalid role id admin: configuration issue: error #5000"),
+		},
+		{
+			"invalid-resource",
+			Configs{
+				{
+					Resources: []string{"foo"},
+					Actions:   []string{"*"},
+					Per:       "total",
+					Limit:     10,
+					Period:    time.Minute,
+					Users:     []string{"u_1234567890"},
+				},
+			},
+			nil,
+			fmt.Errorf("ratelimit.(Configs).Overrides: unknown resource foo: configuration issue: error #5000"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.configs.Overrides(ctx)
+			if tc.wantErr != nil {
+				require.EqualError(t, err, tc.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.want))
+			for i, want := range tc.want {
+				assert.Equal(t, want.Users, got[i].Users)
+				assert.Equal(t, want.Roles, got[i].Roles)
+				assert.ElementsMatch(t, want.Limits, got[i].Limits)
+			}
+
+			// overrides don't change the default limits
+			limits, err := tc.configs.Limits(ctx)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, defaultLimits, limits)
+		})
+	}
+}
+
 func TestDefaulLimiterMaxQuotas(t *testing.T) {
 	var want int
 
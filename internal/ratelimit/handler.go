@@ -116,9 +116,12 @@ func extractResourceAction(path, method string) (res, act string, err error) {
 type LimiterFunc func() Limiter
 
 // Handler is an http middleware handler that checks if a request is allowed
-// using the rate limiter returned by f. If the request is allowed, the next handler
-// is called. Otherwise a 429 is returned with the Retry-After response header
-// set to the number of seconds the client should wait to make it's next request.
+// using the rate limiter returned by f. When f returns an OverrideLimiter,
+// the rate limiter for the request's auth token is used, and the principals of
+// an auth token are resolved after its first request was allowed by the
+// default rate limiter. If the request is allowed, the next handler is called.
+// Otherwise a 429 is returned with the Retry-After response header set to the
+// number of seconds the client should wait to make it's next request.
 func Handler(ctx context.Context, f LimiterFunc, next http.Handler) http.Handler {
 	const op = "ratelimit.Handler"
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -135,6 +138,12 @@ func Handler(ctx context.Context, f LimiterFunc, next http.Handler) http.Handler
 			rw.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		encryptedToken, _ := req.Context().Value(globals.ContextEncryptedAuthTokenKey).(string)
+		ol, _ := l.(*OverrideLimiter)
+		var resolve bool
+		if ol != nil {
+			l, resolve = ol.LimiterFor(authtoken, encryptedToken)
+		}
 
 		res, a, err := extractResourceAction(req.URL.Path, req.Method)
 		if err != nil {
@@ -146,7 +155,7 @@ func Handler(ctx context.Context, f LimiterFunc, next http.Handler) http.Handler
 			return
 		}
 
-		allowed, quota, err := l.Allow(res, a, reqInfo.ClientIp, authtoken)
+		allowed, quota, err := allow(l, res, a, reqInfo.ClientIp, authtoken)
 		if err != nil {
 			if errFull, ok := err.(*rate.ErrLimiterFull); ok {
 				rw.Header().Add("Retry-After", fmt.Sprintf("%.0f", math.Ceil(errFull.RetryIn.Seconds())))
@@ -160,7 +169,7 @@ func Handler(ctx context.Context, f LimiterFunc, next http.Handler) http.Handler
 			return
 		}
 
-		l.SetUsageHeader(quota, rw.Header())
+		setUsageHeader(l, quota, rw.Header())
 		if err := l.SetPolicyHeader(res, a, rw.Header()); err != nil {
 			// Wrap error to emit an error event. An error here would be
 			// unexpected, since the only possible error would be
@@ -177,6 +186,36 @@ func Handler(ctx context.Context, f LimiterFunc, next http.Handler) http.Handler
 			return
 		}
 
+		if resolve {
+			ol.Resolve(req.Context(), authtoken, encryptedToken)
+		}
+
 		next.ServeHTTP(rw, req)
 	})
 }
+
+// allow checks if a request is allowed by l, and returns the quota which
+// decided the request.
+func allow(l Limiter, resource, action, ip, authToken string) (bool, Quota, error) {
+	if ql, ok := l.(quotaLimiter); ok {
+		return ql.AllowQuota(resource, action, ip, authToken)
+	}
+	allowed, quota, err := l.Allow(resource, action, ip, authToken)
+	return allowed, quotaOf(quota), err
+}
+
+// setUsageHeader sets the usage header of the response to the usage of quota.
+// The header of a rate.Quota is set by l, and the header of other quotas is set
+// in the same format.
+func setUsageHeader(l Limiter, quota Quota, header http.Header) {
+	switch q := quota.(type) {
+	case nil:
+	case *rate.Quota:
+		l.SetUsageHeader(q, header)
+	default:
+		header.Set(
+			rate.DefaultUsageHeader,
+			fmt.Sprintf("limit=%d, remaining=%d, reset=%.0f", q.MaxRequests(), q.Remaining(), math.Ceil(q.ResetsIn().Seconds())),
+		)
+	}
+}
@@ -5,8 +5,10 @@ package ratelimit_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -813,3 +815,147 @@ func TestHandlerErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerOverrideLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	l, err := ratelimit.NewOverrideLimiter(
+		testTargetListLimits(10),
+		[]*ratelimit.Override{
+			{
+				Users:  []string{"u_automation"},
+				Limits: testTargetListLimits(100),
+			},
+		},
+		10,
+		func(_ context.Context, authTokenId, encryptedToken string) (string, []string, error) {
+			if authTokenId == "at_automation" && encryptedToken == "s1automation" {
+				return "u_automation", nil, nil
+			}
+			return "u_human", nil, nil
+		},
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Shutdown()) })
+
+	defaultHeaders := http.Header{
+		"RateLimit-Policy": []string{`10;w=60;comment="total", 10;w=60;comment="ip-address", 10;w=60;comment="auth-token"`},
+	}
+	overrideHeaders := http.Header{
+		"RateLimit-Policy": []string{`100;w=60;comment="total", 100;w=60;comment="ip-address", 100;w=60;comment="auth-token"`},
+	}
+	cases := []struct {
+		name           string
+		authtoken      string
+		encryptedToken string
+		// expectHeaders are the expected headers of each request. The
+		// principals of an auth token are resolved after its first request
+		// was allowed with the default limits.
+		expectHeaders []http.Header
+	}{
+		{
+			"Override",
+			"at_automation",
+			"s1automation",
+			[]http.Header{defaultHeaders, overrideHeaders, overrideHeaders},
+		},
+		{
+			"Default",
+			"at_human",
+			"s1human",
+			[]http.Header{defaultHeaders, defaultHeaders},
+		},
+		{
+			"Forged token",
+			"at_automation",
+			"s1forged",
+			[]http.Header{defaultHeaders, defaultHeaders},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(
+				func(next http.Handler) http.Handler {
+					return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						ctx := req.Context()
+						id, err := event.NewId(event.IdPrefix)
+						require.NoError(t, err)
+						ctx, err = event.NewRequestInfoContext(ctx, &event.RequestInfo{
+							Id:       id,
+							EventId:  common.GeneratedTraceId(ctx),
+							ClientIp: "127.0.0.1",
+						})
+						require.NoError(t, err)
+						ctx = context.WithValue(ctx, globals.ContextAuthTokenPublicIdKey, tc.authtoken)
+						ctx = context.WithValue(ctx, globals.ContextEncryptedAuthTokenKey, tc.encryptedToken)
+
+						req = req.Clone(ctx)
+
+						next.ServeHTTP(rw, req)
+					})
+				}(ratelimit.Handler(ctx, func() ratelimit.Limiter { return l }, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}))),
+			)
+			defer server.Close()
+
+			for _, expectHeaders := range tc.expectHeaders {
+				req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/targets", nil)
+				require.NoError(t, err)
+				res, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, res.StatusCode)
+				for key, value := range expectHeaders {
+					assert.Equal(t, value[0], res.Header.Get(key))
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerSharedLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := testSharedLimiter(t, newTestQuotaStore(), testTotalLimits(1))
+	server := httptest.NewServer(
+		func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				ctx := req.Context()
+				id, err := event.NewId(event.IdPrefix)
+				require.NoError(t, err)
+				ctx, err = event.NewRequestInfoContext(ctx, &event.RequestInfo{
+					Id:       id,
+					EventId:  common.GeneratedTraceId(ctx),
+					ClientIp: "127.0.0.1",
+				})
+				require.NoError(t, err)
+				ctx = context.WithValue(ctx, globals.ContextAuthTokenPublicIdKey, "at_1")
+
+				req = req.Clone(ctx)
+
+				next.ServeHTTP(rw, req)
+			})
+		}(ratelimit.Handler(ctx, func() ratelimit.Limiter { return l }, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))),
+	)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/v1/targets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "limit=1, remaining=0, reset=3600", res.Header.Get("RateLimit"))
+
+	// the request is denied by the shared quota, whose window is aligned to
+	// the period of its limit, so the headers reflect the end of the window
+	// instead of the local quota.
+	res, err = http.Get(server.URL + "/v1/targets")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	windowEnd := time.Now().Truncate(time.Hour).Add(time.Hour)
+	resetsIn := int(time.Until(windowEnd).Seconds())
+	assert.Contains(t, []string{
+		fmt.Sprintf("limit=1, remaining=0, reset=%d", resetsIn),
+		fmt.Sprintf("limit=1, remaining=0, reset=%d", resetsIn+1),
+	}, res.Header.Get("RateLimit"))
+	assert.Equal(t, strings.TrimPrefix(res.Header.Get("RateLimit"), "limit=1, remaining=0, reset="), res.Header.Get("Retry-After"))
+}
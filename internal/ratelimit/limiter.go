@@ -4,8 +4,15 @@
 package ratelimit
 
 import (
+	"context"
+	"crypto/sha256"
+	stderrors "errors"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/go-rate"
 )
 
@@ -18,6 +25,30 @@ type Limiter interface {
 	Shutdown() error
 }
 
+// Quota is the quota which decided whether a request is allowed. It's
+// implemented by rate.Quota and the shared quotas of a SharedLimiter.
+type Quota interface {
+	MaxRequests() uint64
+	Remaining() uint64
+	ResetsIn() time.Duration
+}
+
+// quotaLimiter is implemented by Limiters whose requests can be decided by
+// quotas which aren't a rate.Quota. Handler uses AllowQuota instead of Allow
+// for them, so the headers of a response reflect the quota which decided the
+// request.
+type quotaLimiter interface {
+	AllowQuota(resource, action, ip, authToken string) (bool, Quota, error)
+}
+
+// quotaOf returns q as a Quota, which is nil when q is nil.
+func quotaOf(q *rate.Quota) Quota {
+	if q == nil {
+		return nil
+	}
+	return q
+}
+
 // NewLimiter creates a rate.Limiter.
 func NewLimiter(limits []rate.Limit, maxQuotas int) (*rate.Limiter, error) {
 	return rate.NewLimiter(
@@ -27,3 +58,195 @@ func NewLimiter(limits []rate.Limit, maxQuotas int) (*rate.Limiter, error) {
 		rate.WithQuotaStorageCapacityMetric(rateLimitQuotaStorageCapacity),
 	)
 }
+
+// principalsTTL is how long the principals of an auth token are cached by an
+// OverrideLimiter, so changes to the principals of a role apply to the rate
+// limits of existing auth tokens after at most this long.
+const principalsTTL = time.Minute
+
+// PrincipalResolver returns the id of the user of an auth token, and the ids
+// of the roles the user is a principal of. The encrypted token is the token
+// sent with the request, and must be validated against the auth token, since
+// the auth token id alone is not secret. An empty user id is returned when the
+// auth token doesn't exist, isn't valid anymore, or the encrypted token isn't
+// a token of the auth token.
+type PrincipalResolver func(ctx context.Context, authTokenId, encryptedToken string) (userId string, roleIds []string, err error)
+
+// OverrideLimiter is a Limiter with separate Limiters for the requests of
+// users and roles which have overrides. The Limiter methods use the default
+// Limiter, and LimiterFor returns the Limiter for the requests made with an
+// auth token. The principals of auth tokens are only resolved with Resolve,
+// after a request was allowed by the default Limiter.
+type OverrideLimiter struct {
+	Limiter
+
+	overrides  []*overrideLimiter
+	resolver   PrincipalResolver
+	maxEntries int
+
+	l sync.Mutex
+	// principals is keyed by principalsCacheKey, so principals resolved for
+	// a valid token are not used for requests sending its auth token id
+	// with another token.
+	principals map[string]*principals
+}
+
+type overrideLimiter struct {
+	users   map[string]struct{}
+	roles   map[string]struct{}
+	limiter Limiter
+}
+
+type principals struct {
+	userId    string
+	roleIds   []string
+	expiresAt time.Time
+}
+
+// NewOverrideLimiter creates an OverrideLimiter with a default rate.Limiter
+// for limits, and a rate.Limiter for each of the overrides. Each rate.Limiter
+// can store up to maxQuotas quotas. The users and roles of auth tokens are
+// looked up with resolver. Supports the WithSharedQuotas option, which
+// creates SharedLimiters instead, with separate shared quotas for each of the
+// overrides.
+func NewOverrideLimiter(limits []rate.Limit, overrides []*Override, maxQuotas int, resolver PrincipalResolver, opt ...Option) (*OverrideLimiter, error) {
+	const op = "ratelimit.NewOverrideLimiter"
+	if resolver == nil {
+		return nil, errors.New(context.Background(), errors.InvalidParameter, op, "missing principal resolver")
+	}
+	opts := getOpts(opt...)
+	newLimiter := func(limits []rate.Limit, prefix string) (Limiter, error) {
+		if opts.withSharedQuotas != nil {
+			return newSharedLimiter(limits, maxQuotas, opts.withSharedQuotas, prefix)
+		}
+		return NewLimiter(limits, maxQuotas)
+	}
+	def, err := newLimiter(limits, "")
+	if err != nil {
+		return nil, err
+	}
+	l := &OverrideLimiter{
+		Limiter:    def,
+		resolver:   resolver,
+		maxEntries: maxQuotas,
+		principals: make(map[string]*principals),
+	}
+	for _, o := range overrides {
+		ol, err := newLimiter(o.Limits, principalsKey(o.Users, o.Roles))
+		if err != nil {
+			_ = l.Shutdown()
+			return nil, err
+		}
+		users := make(map[string]struct{}, len(o.Users))
+		for _, u := range o.Users {
+			users[u] = struct{}{}
+		}
+		roles := make(map[string]struct{}, len(o.Roles))
+		for _, r := range o.Roles {
+			roles[r] = struct{}{}
+		}
+		l.overrides = append(l.overrides, &overrideLimiter{
+			users:   users,
+			roles:   roles,
+			limiter: ol,
+		})
+	}
+	return l, nil
+}
+
+// LimiterFor returns the Limiter of the first override which matches the
+// cached user of the auth token, or one of the cached roles the user is a
+// principal of. The default Limiter is returned for requests without an auth
+// token, and when the principals of the auth token sent with encryptedToken
+// are not cached. In that case resolve is true, and Resolve should be called
+// once the default Limiter allowed the request, so unknown or forged tokens
+// are limited before they cause a principal lookup.
+func (l *OverrideLimiter) LimiterFor(authTokenId, encryptedToken string) (lim Limiter, resolve bool) {
+	if authTokenId == "" {
+		return l.Limiter, false
+	}
+	now := time.Now()
+	l.l.Lock()
+	p, ok := l.principals[principalsCacheKey(authTokenId, encryptedToken)]
+	l.l.Unlock()
+	if !ok || !now.Before(p.expiresAt) {
+		return l.Limiter, true
+	}
+	return l.limiterForPrincipals(p), false
+}
+
+// Resolve resolves the principals of the auth token sent with encryptedToken
+// and caches them, so LimiterFor returns the Limiter of their override for the
+// following requests made with the token. Principals which can't be resolved
+// are not cached, so they are resolved again for the next request.
+func (l *OverrideLimiter) Resolve(ctx context.Context, authTokenId, encryptedToken string) {
+	const op = "ratelimit.(OverrideLimiter).Resolve"
+	if authTokenId == "" {
+		return
+	}
+	userId, roleIds, err := l.resolver(ctx, authTokenId, encryptedToken)
+	if err != nil {
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to resolve principals of auth token, using default rate limits"))
+		return
+	}
+	now := time.Now()
+	p := &principals{
+		userId:    userId,
+		roleIds:   roleIds,
+		expiresAt: now.Add(principalsTTL),
+	}
+
+	l.l.Lock()
+	defer l.l.Unlock()
+	if len(l.principals) >= l.maxEntries {
+		for key, e := range l.principals {
+			if !now.Before(e.expiresAt) {
+				delete(l.principals, key)
+			}
+		}
+	}
+	// when the cache is still full, the principals are resolved again for
+	// the next request.
+	if len(l.principals) < l.maxEntries {
+		l.principals[principalsCacheKey(authTokenId, encryptedToken)] = p
+	}
+}
+
+func (l *OverrideLimiter) limiterForPrincipals(p *principals) Limiter {
+	if p.userId == "" {
+		return l.Limiter
+	}
+	for _, o := range l.overrides {
+		if _, ok := o.users[p.userId]; ok {
+			return o.limiter
+		}
+		for _, r := range p.roleIds {
+			if _, ok := o.roles[r]; ok {
+				return o.limiter
+			}
+		}
+	}
+	return l.Limiter
+}
+
+// principalsCacheKey returns the key of the cached principals of an auth
+// token sent with encryptedToken. The token is hashed so the cache doesn't
+// hold credentials.
+func principalsCacheKey(authTokenId, encryptedToken string) string {
+	sum := sha256.Sum256([]byte(authTokenId + "_" + encryptedToken))
+	return string(sum[:])
+}
+
+// Shutdown stops the default and the override Limiters.
+func (l *OverrideLimiter) Shutdown() error {
+	var errs []error
+	if err := l.Limiter.Shutdown(); err != nil {
+		errs = append(errs, err)
+	}
+	for _, o := range l.overrides {
+		if err := o.limiter.Shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return stderrors.Join(errs...)
+}
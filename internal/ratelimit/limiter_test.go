@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/go-rate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTargetListLimits(limit uint64) []rate.Limit {
+	return []rate.Limit{
+		&rate.Limited{
+			Resource:    resource.Target.String(),
+			Action:      action.List.String(),
+			Per:         rate.LimitPerTotal,
+			MaxRequests: limit,
+			Period:      time.Minute,
+		},
+		&rate.Limited{
+			Resource:    resource.Target.String(),
+			Action:      action.List.String(),
+			Per:         rate.LimitPerIPAddress,
+			MaxRequests: limit,
+			Period:      time.Minute,
+		},
+		&rate.Limited{
+			Resource:    resource.Target.String(),
+			Action:      action.List.String(),
+			Per:         rate.LimitPerAuthToken,
+			MaxRequests: limit,
+			Period:      time.Minute,
+		},
+	}
+}
+
+func TestOverrideLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	principals := map[string]struct {
+		userId  string
+		roleIds []string
+	}{
+		"at_automation": {"u_automation", []string{"r_default"}},
+		"at_ci":         {"u_ci", []string{"r_default", "r_ci"}},
+		"at_human":      {"u_human", []string{"r_default"}},
+	}
+	var calls int
+	resolver := func(_ context.Context, authTokenId, encryptedToken string) (string, []string, error) {
+		calls++
+		if authTokenId == "at_error" {
+			return "", nil, errors.New("database unavailable")
+		}
+		if encryptedToken != "s1"+authTokenId {
+			return "", nil, nil
+		}
+		p := principals[authTokenId]
+		return p.userId, p.roleIds, nil
+	}
+
+	l, err := ratelimit.NewOverrideLimiter(
+		testTargetListLimits(10),
+		[]*ratelimit.Override{
+			{
+				Users:  []string{"u_automation"},
+				Limits: testTargetListLimits(100),
+			},
+			{
+				Roles:  []string{"r_ci"},
+				Limits: testTargetListLimits(50),
+			},
+		},
+		10,
+		resolver,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Shutdown()) })
+
+	cases := []struct {
+		name           string
+		authTokenId    string
+		encryptedToken string
+		wantLimit      uint64
+	}{
+		{"user", "at_automation", "s1at_automation", 100},
+		{"role", "at_ci", "s1at_ci", 50},
+		{"no-override", "at_human", "s1at_human", 10},
+		{"unknown-auth-token", "at_unknown", "s1at_unknown", 10},
+		{"forged-token", "at_automation", "s1forged", 10},
+		{"resolver-error", "at_error", "s1at_error", 10},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			// the default limiter is used until the principals are resolved
+			got, resolve := l.LimiterFor(tc.authTokenId, tc.encryptedToken)
+			assert.True(resolve)
+			assert.Same(l.Limiter, got)
+
+			l.Resolve(ctx, tc.authTokenId, tc.encryptedToken)
+			got, _ = l.LimiterFor(tc.authTokenId, tc.encryptedToken)
+			allowed, quota, err := got.Allow(resource.Target.String(), action.List.String(), "127.0.0.1", tc.authTokenId)
+			require.NoError(err)
+			assert.True(allowed)
+			assert.Equal(tc.wantLimit, quota.MaxRequests())
+		})
+	}
+
+	t.Run("no-auth-token", func(t *testing.T) {
+		got, resolve := l.LimiterFor("", "")
+		assert.False(t, resolve)
+		assert.Same(t, l.Limiter, got)
+	})
+	t.Run("cached-principals", func(t *testing.T) {
+		calls = 0
+		for i := 0; i < 3; i++ {
+			_, resolve := l.LimiterFor("at_automation", "s1at_automation")
+			assert.False(t, resolve)
+		}
+		// principals of tokens which aren't valid are cached too
+		_, resolve := l.LimiterFor("at_automation", "s1forged")
+		assert.False(t, resolve)
+		assert.Equal(t, 0, calls)
+		// errors aren't cached
+		l.Resolve(ctx, "at_error", "s1at_error")
+		_, resolve = l.LimiterFor("at_error", "s1at_error")
+		assert.True(t, resolve)
+		assert.Equal(t, 1, calls)
+	})
+	t.Run("missing-resolver", func(t *testing.T) {
+		_, err := ratelimit.NewOverrideLimiter(testTargetListLimits(10), nil, 10, nil)
+		require.Error(t, err)
+	})
+	t.Run("invalid-override", func(t *testing.T) {
+		_, err := ratelimit.NewOverrideLimiter(
+			testTargetListLimits(10),
+			[]*ratelimit.Override{{Users: []string{"u_automation"}}},
+			10,
+			resolver,
+		)
+		require.Error(t, err)
+	})
+}
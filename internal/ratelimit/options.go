@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withSharedQuotas *SharedQuotas
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithSharedQuotas provides the SharedQuotas used to enforce the limits
+// across the controllers of a cluster.
+func WithSharedQuotas(q *SharedQuotas) Option {
+	return func(o *options) {
+		o.withSharedQuotas = q
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit
+
+const (
+	addQuotaUsageQuery = `
+		insert into controller_api_rate_limit_quota
+			(quota_key, window_start, expiration_time, used)
+		select *
+		  from unnest(
+			cast(@quota_keys as text[]),
+			cast(@window_starts as timestamptz[]),
+			cast(@expiration_times as timestamptz[]),
+			cast(@used as bigint[])
+		  )
+		on conflict (quota_key, window_start) do update
+		   set used = controller_api_rate_limit_quota.used + excluded.used
+		returning quota_key, window_start, expiration_time, used;
+	`
+
+	deleteExpiredQuotaUsageQuery = `
+		delete from controller_api_rate_limit_quota
+		 where expiration_time < now();
+	`
+)
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+var _ QuotaStore = (*Repository)(nil)
+
+// Repository is a QuotaStore which stores the usage of quotas in the
+// database shared by the controllers of a cluster.
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+}
+
+// NewRepository creates a new rate limit Repository.
+func NewRepository(ctx context.Context, r db.Reader, w db.Writer) (*Repository, error) {
+	const op = "ratelimit.NewRepository"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil writer")
+	}
+	return &Repository{
+		reader: r,
+		writer: w,
+	}, nil
+}
+
+// AddQuotaUsage adds the usage to the usage of the quotas in the database,
+// and returns the total usage of each of the quotas.
+func (r *Repository) AddQuotaUsage(ctx context.Context, usage []*QuotaUsage) ([]*QuotaUsage, error) {
+	const op = "ratelimit.(Repository).AddQuotaUsage"
+	if len(usage) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing usage")
+	}
+
+	keys := make([]string, 0, len(usage))
+	windowStarts := make([]string, 0, len(usage))
+	expirationTimes := make([]string, 0, len(usage))
+	used := make([]string, 0, len(usage))
+	for _, u := range usage {
+		switch {
+		case u.Key == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing quota key")
+		case !u.ExpirationTime.After(u.WindowStart):
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "expiration time must be after window start")
+		}
+		// the keys are hex encoded hashes, and the times don't contain
+		// commas or spaces, so none of the array elements need quoting.
+		keys = append(keys, u.Key)
+		windowStarts = append(windowStarts, u.WindowStart.UTC().Format(time.RFC3339Nano))
+		expirationTimes = append(expirationTimes, u.ExpirationTime.UTC().Format(time.RFC3339Nano))
+		used = append(used, strconv.FormatUint(u.Used, 10))
+	}
+
+	rows, err := r.writer.Query(ctx, addQuotaUsageQuery, []any{
+		sql.Named("quota_keys", "{"+strings.Join(keys, ",")+"}"),
+		sql.Named("window_starts", "{"+strings.Join(windowStarts, ",")+"}"),
+		sql.Named("expiration_times", "{"+strings.Join(expirationTimes, ",")+"}"),
+		sql.Named("used", "{"+strings.Join(used, ",")+"}"),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+
+	totals := make([]*QuotaUsage, 0, len(usage))
+	for rows.Next() {
+		var u QuotaUsage
+		if err := rows.Scan(&u.Key, &u.WindowStart, &u.ExpirationTime, &u.Used); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		totals = append(totals, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return totals, nil
+}
+
+// DeleteExpiredQuotaUsage deletes the usage of quotas whose window ended,
+// and returns the number of deleted quotas.
+func (r *Repository) DeleteExpiredQuotaUsage(ctx context.Context) (int, error) {
+	const op = "ratelimit.(Repository).DeleteExpiredQuotaUsage"
+	n, err := r.writer.Exec(ctx, deleteExpiredQuotaUsageQuery, nil)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(ctx, err, op)
+	}
+	return n, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AddQuotaUsage(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	repo, err := ratelimit.NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+
+	windowStart := time.Now().Truncate(time.Minute)
+	usage := func(key string, used uint64) *ratelimit.QuotaUsage {
+		return &ratelimit.QuotaUsage{
+			Key:            key,
+			WindowStart:    windowStart,
+			ExpirationTime: windowStart.Add(time.Minute),
+			Used:           used,
+		}
+	}
+
+	t.Run("add", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		totals, err := repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{usage("key-1", 3), usage("key-2", 1)})
+		require.NoError(err)
+		require.Len(totals, 2)
+
+		// the usage of other controllers is added to the usage in the database
+		totals, err = repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{usage("key-1", 2)})
+		require.NoError(err)
+		require.Len(totals, 1)
+		assert.Equal("key-1", totals[0].Key)
+		assert.True(windowStart.Equal(totals[0].WindowStart))
+		assert.Equal(uint64(5), totals[0].Used)
+	})
+	t.Run("separate-windows", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		next := usage("key-1", 1)
+		next.WindowStart = next.ExpirationTime
+		next.ExpirationTime = next.WindowStart.Add(time.Minute)
+		totals, err := repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{next})
+		require.NoError(err)
+		require.Len(totals, 1)
+		assert.Equal(uint64(1), totals[0].Used)
+	})
+	t.Run("missing-usage", func(t *testing.T) {
+		_, err := repo.AddQuotaUsage(ctx, nil)
+		require.Error(t, err)
+	})
+	t.Run("missing-key", func(t *testing.T) {
+		_, err := repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{usage("", 1)})
+		require.Error(t, err)
+	})
+	t.Run("invalid-expiration-time", func(t *testing.T) {
+		u := usage("key-1", 1)
+		u.ExpirationTime = u.WindowStart
+		_, err := repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{u})
+		require.Error(t, err)
+	})
+}
+
+func TestRepository_DeleteExpiredQuotaUsage(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	repo, err := ratelimit.NewRepository(ctx, rw, rw)
+	require.NoError(err)
+
+	now := time.Now()
+	_, err = repo.AddQuotaUsage(ctx, []*ratelimit.QuotaUsage{
+		{Key: "expired", WindowStart: now.Add(-2 * time.Minute), ExpirationTime: now.Add(-time.Minute), Used: 1},
+		{Key: "current", WindowStart: now.Add(-time.Minute), ExpirationTime: now.Add(time.Minute), Used: 1},
+	})
+	require.NoError(err)
+
+	n, err := repo.DeleteExpiredQuotaUsage(ctx)
+	require.NoError(err)
+	assert.Equal(1, n)
+	n, err = repo.DeleteExpiredQuotaUsage(ctx)
+	require.NoError(err)
+	assert.Equal(0, n)
+}
+
+func TestNewRepository(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := ratelimit.NewRepository(ctx, nil, rw)
+	require.Error(t, err)
+	_, err = ratelimit.NewRepository(ctx, rw, nil)
+	require.Error(t, err)
+	repo, err := ratelimit.NewRepository(ctx, rw, rw)
+	require.NoError(t, err)
+	assert.NotNil(t, repo)
+}
@@ -0,0 +1,475 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/go-rate"
+)
+
+const (
+	// DefaultSyncInterval is the default interval at which SharedQuotas
+	// synchronizes the usage of quotas with its QuotaStore.
+	DefaultSyncInterval = time.Second
+
+	// deleteExpiredInterval is the interval at which SharedQuotas deletes the
+	// usage of quotas whose window ended from its QuotaStore.
+	deleteExpiredInterval = time.Minute
+)
+
+// QuotaUsage is the number of requests used by a quota during a window.
+type QuotaUsage struct {
+	// Key identifies the quota. It's a hash of the resource, action, limit
+	// type and client identifier of the quota, so it doesn't contain the IP
+	// addresses or auth token ids of clients.
+	Key string
+	// WindowStart is the start of the window, aligned to the period of the
+	// quota's limit.
+	WindowStart time.Time
+	// ExpirationTime is the end of the window.
+	ExpirationTime time.Time
+	// Used is the number of requests allowed during the window.
+	Used uint64
+}
+
+// QuotaStore stores the usage of quotas which are shared by the controllers
+// of a cluster.
+type QuotaStore interface {
+	// AddQuotaUsage adds the usage to the usage of the quotas in the store,
+	// and returns the total usage of each of the quotas.
+	AddQuotaUsage(ctx context.Context, usage []*QuotaUsage) ([]*QuotaUsage, error)
+	// DeleteExpiredQuotaUsage deletes the usage of quotas whose window ended,
+	// and returns the number of deleted quotas.
+	DeleteExpiredQuotaUsage(ctx context.Context) (int, error)
+}
+
+// SharedQuotas tracks the usage of quotas across the controllers of a
+// cluster. The usage of quotas is synchronized with a QuotaStore in the
+// background, so requests never wait for the QuotaStore. When the QuotaStore
+// is unavailable or slower than the sync interval, the usage of the other
+// controllers isn't known until the QuotaStore is available again, and the
+// limits are only enforced with the usage of this controller.
+type SharedQuotas struct {
+	store        QuotaStore
+	syncInterval time.Duration
+	maxEntries   int
+
+	l           sync.Mutex
+	quotas      map[sharedQuotaId]*sharedQuota
+	unavailable bool
+	lastDelete  time.Time
+
+	cancel   context.CancelFunc
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+type sharedQuotaId struct {
+	key         string
+	windowStart int64
+}
+
+type sharedQuota struct {
+	usage       QuotaUsage
+	maxRequests uint64
+
+	// synced is the usage of the cluster at the last sync, syncing is the
+	// usage of this controller which is being added to the QuotaStore, and
+	// pending is the usage of this controller since the last sync.
+	synced  uint64
+	syncing uint64
+	pending uint64
+}
+
+func (q *sharedQuota) used() uint64 {
+	return q.synced + q.syncing + q.pending
+}
+
+// usageOf returns the current usage of the quota. The lock of the
+// SharedQuotas must be held.
+func (q *sharedQuota) usageOf() *sharedQuotaUsage {
+	return &sharedQuotaUsage{
+		maxRequests: q.maxRequests,
+		used:        q.used(),
+		expiration:  q.usage.ExpirationTime,
+	}
+}
+
+// sharedKey is a quota of a request, which is checked by SharedQuotas.
+type sharedKey struct {
+	key   string
+	limit *rate.Limited
+}
+
+// sharedQuotaUsage is the usage of a shared quota when a request was checked.
+// It's the Quota returned for the requests decided by the shared quota.
+type sharedQuotaUsage struct {
+	maxRequests uint64
+	used        uint64
+	expiration  time.Time
+}
+
+var _ Quota = (*sharedQuotaUsage)(nil)
+
+// MaxRequests returns the maximum number of requests of the quota's window.
+func (u *sharedQuotaUsage) MaxRequests() uint64 { return u.maxRequests }
+
+// Remaining returns the number of requests left in the quota's window.
+func (u *sharedQuotaUsage) Remaining() uint64 {
+	if u.used >= u.maxRequests {
+		return 0
+	}
+	return u.maxRequests - u.used
+}
+
+// ResetsIn returns the time until the quota's window ends.
+func (u *sharedQuotaUsage) ResetsIn() time.Duration { return time.Until(u.expiration) }
+
+// NewSharedQuotas creates a SharedQuotas which synchronizes the usage of
+// quotas with store every syncInterval. A sync which takes longer than
+// syncInterval is canceled. Up to maxEntries quotas are tracked, and
+// requests for additional quotas are only limited by the local rate
+// limiter. The sync stops when ctx is done or Stop is called.
+func NewSharedQuotas(ctx context.Context, store QuotaStore, syncInterval time.Duration, maxEntries int) (*SharedQuotas, error) {
+	const op = "ratelimit.NewSharedQuotas"
+	switch {
+	case store == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing quota store")
+	case syncInterval < 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "negative sync interval")
+	case maxEntries <= 0:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "max entries must be greater than zero")
+	}
+	if syncInterval == 0 {
+		syncInterval = DefaultSyncInterval
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &SharedQuotas{
+		store:        store,
+		syncInterval: syncInterval,
+		maxEntries:   maxEntries,
+		quotas:       make(map[sharedQuotaId]*sharedQuota),
+		lastDelete:   time.Now(),
+		cancel:       cancel,
+		stopped:      make(chan struct{}),
+	}
+	go s.run(ctx)
+	return s, nil
+}
+
+// Stop stops the sync of the usage of quotas. It can be called more than
+// once.
+func (s *SharedQuotas) Stop() {
+	s.stopOnce.Do(func() {
+		s.cancel()
+		<-s.stopped
+	})
+}
+
+func (s *SharedQuotas) run(ctx context.Context) {
+	defer close(s.stopped)
+	ticker := time.NewTicker(s.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sync(ctx)
+		}
+	}
+}
+
+// sync adds the usage of this controller since the last sync to the
+// QuotaStore, and updates the quotas with the usage of the cluster.
+func (s *SharedQuotas) sync(ctx context.Context) {
+	now := time.Now()
+
+	s.l.Lock()
+	s.prune(now)
+	var usage []*QuotaUsage
+	syncing := make(map[sharedQuotaId]*sharedQuota)
+	for _, q := range s.quotas {
+		if q.pending == 0 {
+			continue
+		}
+		q.syncing, q.pending = q.pending, 0
+		u := q.usage
+		u.Used = q.syncing
+		usage = append(usage, &u)
+		syncing[sharedQuotaId{key: u.Key, windowStart: u.WindowStart.UnixNano()}] = q
+	}
+	deleteExpired := now.Sub(s.lastDelete) >= deleteExpiredInterval
+	s.l.Unlock()
+
+	syncCtx, cancel := context.WithTimeout(ctx, s.syncInterval)
+	defer cancel()
+
+	var err error
+	var totals []*QuotaUsage
+	if len(usage) > 0 {
+		totals, err = s.store.AddQuotaUsage(syncCtx, usage)
+	}
+	if err == nil && deleteExpired {
+		_, err = s.store.DeleteExpiredQuotaUsage(syncCtx)
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if deleteExpired && err == nil {
+		s.lastDelete = now
+	}
+	if err != nil && len(totals) == 0 {
+		// the usage is added to the QuotaStore by the next sync.
+		for _, q := range syncing {
+			q.pending += q.syncing
+			q.syncing = 0
+		}
+	}
+	for _, t := range totals {
+		q, ok := syncing[sharedQuotaId{key: t.Key, windowStart: t.WindowStart.UnixNano()}]
+		if !ok {
+			continue
+		}
+		q.synced = max(t.Used, q.synced+q.syncing)
+		q.syncing = 0
+	}
+	// quotas missing from the totals keep the usage of this controller.
+	for _, q := range syncing {
+		q.synced += q.syncing
+		q.syncing = 0
+	}
+	s.setUnavailable(ctx, err)
+}
+
+// setUnavailable writes an event when the QuotaStore becomes unavailable or
+// available again. s.l must be held.
+func (s *SharedQuotas) setUnavailable(ctx context.Context, err error) {
+	const op = "ratelimit.(SharedQuotas).setUnavailable"
+	switch {
+	case err != nil && !s.unavailable:
+		s.unavailable = true
+		event.WriteError(ctx, op, err, event.WithInfoMsg("unable to sync shared rate limit quotas, limits are enforced with the usage of this controller only"))
+	case err == nil && s.unavailable:
+		s.unavailable = false
+		event.WriteSysEvent(ctx, op, "synced shared rate limit quotas")
+	}
+}
+
+// allow checks if the quotas of a request have remaining requests in the
+// current window, and adds the request to the usage of the quotas if they
+// do. It returns the quotas the request was added to, and the usage of the
+// quota which decided the request: the quota without remaining requests when
+// the request isn't allowed, or otherwise the quota with the fewest remaining
+// requests. The usage is nil when none of the quotas are tracked.
+func (s *SharedQuotas) allow(now time.Time, keys []*sharedKey) (bool, []*sharedQuota, *sharedQuotaUsage) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	quotas := make([]*sharedQuota, 0, len(keys))
+	for _, k := range keys {
+		windowStart := now.Truncate(k.limit.Period)
+		id := sharedQuotaId{key: k.key, windowStart: windowStart.UnixNano()}
+		q, ok := s.quotas[id]
+		if !ok {
+			if len(s.quotas) >= s.maxEntries {
+				s.prune(now)
+			}
+			if len(s.quotas) >= s.maxEntries {
+				// the quota is only limited by the local rate limiter.
+				continue
+			}
+			q = &sharedQuota{
+				usage: QuotaUsage{
+					Key:            k.key,
+					WindowStart:    windowStart,
+					ExpirationTime: windowStart.Add(k.limit.Period),
+				},
+				maxRequests: k.limit.MaxRequests,
+			}
+			s.quotas[id] = q
+		}
+		if q.used() >= q.maxRequests {
+			return false, nil, q.usageOf()
+		}
+		quotas = append(quotas, q)
+	}
+	var decided *sharedQuotaUsage
+	for _, q := range quotas {
+		q.pending++
+		if u := q.usageOf(); decided == nil || u.Remaining() < decided.Remaining() {
+			decided = u
+		}
+	}
+	return true, quotas, decided
+}
+
+// release removes a request added by allow from the usage of its quotas, when
+// the request is denied by the local rate.Limiter after all. A request whose
+// usage is already being synced stays in the usage of the cluster until the
+// end of the window.
+func (s *SharedQuotas) release(quotas []*sharedQuota) {
+	if len(quotas) == 0 {
+		return
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	for _, q := range quotas {
+		if q.pending > 0 {
+			q.pending--
+		}
+	}
+}
+
+// prune removes the quotas whose window ended. s.l must be held.
+func (s *SharedQuotas) prune(now time.Time) {
+	for id, q := range s.quotas {
+		if !now.Before(q.usage.ExpirationTime) {
+			delete(s.quotas, id)
+		}
+	}
+}
+
+// SharedLimiter is a rate.Limiter which also enforces its limits with the
+// usage of the controllers of a cluster. A request is allowed when the quotas
+// of the request have remaining requests in SharedQuotas, and it's allowed by
+// the local rate.Limiter. The shared quotas are checked first, so requests
+// denied by them don't use the local quotas, and the shared usage of requests
+// denied by the local rate.Limiter is released. The windows of the shared
+// quotas are aligned to the period of their limits.
+type SharedLimiter struct {
+	*rate.Limiter
+
+	quotas *SharedQuotas
+	limits map[string]*rate.Limited
+	prefix string
+}
+
+// NewSharedLimiter creates a SharedLimiter for limits, with a local
+// rate.Limiter which can store up to maxQuotas quotas.
+func NewSharedLimiter(limits []rate.Limit, maxQuotas int, quotas *SharedQuotas) (*SharedLimiter, error) {
+	return newSharedLimiter(limits, maxQuotas, quotas, "")
+}
+
+// newSharedLimiter creates a SharedLimiter whose quotas are identified with
+// prefix, so the quotas of overrides are separate from the default quotas.
+func newSharedLimiter(limits []rate.Limit, maxQuotas int, quotas *SharedQuotas, prefix string) (*SharedLimiter, error) {
+	const op = "ratelimit.newSharedLimiter"
+	if quotas == nil {
+		return nil, errors.New(context.Background(), errors.InvalidParameter, op, "missing shared quotas")
+	}
+	l, err := NewLimiter(limits, maxQuotas)
+	if err != nil {
+		return nil, err
+	}
+	sl := &SharedLimiter{
+		Limiter: l,
+		quotas:  quotas,
+		limits:  make(map[string]*rate.Limited, len(limits)),
+		prefix:  prefix,
+	}
+	for _, limit := range limits {
+		if ll, ok := limit.(*rate.Limited); ok {
+			sl.limits[limitKey(ll.Resource, ll.Action, ll.Per)] = ll
+		}
+	}
+	return sl, nil
+}
+
+var (
+	_ Limiter      = (*SharedLimiter)(nil)
+	_ quotaLimiter = (*SharedLimiter)(nil)
+)
+
+// Allow checks if a request for the given resource and action should be
+// allowed by the shared quotas and the local rate.Limiter. It returns the
+// quota of the local rate.Limiter, which is nil when the request was denied
+// by a shared quota. Use AllowQuota for the quota which decided the request.
+func (l *SharedLimiter) Allow(resource, action, ip, authToken string) (bool, *rate.Quota, error) {
+	allowed, local, _, err := l.allow(resource, action, ip, authToken)
+	return allowed, local, err
+}
+
+// AllowQuota checks if a request for the given resource and action should be
+// allowed like Allow, and returns the quota which decided the request: the
+// shared or local quota which denied it, or the quota with the fewest
+// remaining requests when it's allowed.
+func (l *SharedLimiter) AllowQuota(resource, action, ip, authToken string) (bool, Quota, error) {
+	allowed, _, decided, err := l.allow(resource, action, ip, authToken)
+	return allowed, decided, err
+}
+
+// allow checks the shared quotas of a request before the local rate.Limiter,
+// and returns the local quota and the quota which decided the request.
+func (l *SharedLimiter) allow(resource, action, ip, authToken string) (bool, *rate.Quota, Quota, error) {
+	var quotas []*sharedQuota
+	var shared *sharedQuotaUsage
+	if keys := l.sharedKeys(resource, action, ip, authToken); len(keys) > 0 {
+		var allowed bool
+		allowed, quotas, shared = l.quotas.allow(time.Now(), keys)
+		if !allowed {
+			return false, nil, shared, nil
+		}
+	}
+
+	allowed, local, err := l.Limiter.Allow(resource, action, ip, authToken)
+	if err != nil || !allowed {
+		l.quotas.release(quotas)
+		return allowed, local, quotaOf(local), err
+	}
+	if shared != nil && (local == nil || shared.Remaining() < local.Remaining()) {
+		return true, local, shared, nil
+	}
+	return true, local, quotaOf(local), nil
+}
+
+// sharedKeys returns the shared quotas of a request, which are the quotas of
+// its limited limits.
+func (l *SharedLimiter) sharedKeys(resource, action, ip, authToken string) []*sharedKey {
+	ids := []struct {
+		per rate.LimitPer
+		id  string
+	}{
+		{rate.LimitPerTotal, rate.LimitPerTotal.String()},
+		{rate.LimitPerIPAddress, ip},
+		{rate.LimitPerAuthToken, authToken},
+	}
+	keys := make([]*sharedKey, 0, len(ids))
+	for _, i := range ids {
+		ll, ok := l.limits[limitKey(resource, action, i.per)]
+		if !ok {
+			continue
+		}
+		keys = append(keys, &sharedKey{
+			key:   quotaKey(l.prefix, limitKey(resource, action, i.per), i.id),
+			limit: ll,
+		})
+	}
+	return keys
+}
+
+// Shutdown stops the local rate.Limiter and the SharedQuotas.
+func (l *SharedLimiter) Shutdown() error {
+	l.quotas.Stop()
+	return l.Limiter.Shutdown()
+}
+
+func limitKey(resource, action string, per rate.LimitPer) string {
+	return strings.Join([]string{resource, action, per.String()}, ":")
+}
+
+// quotaKey returns the hex encoded sha256 hash of the parts of a key.
+func quotaKey(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,315 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/ratelimit"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/go-rate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testQuotaStore is an in-memory ratelimit.QuotaStore shared by the
+// SharedQuotas of a test.
+type testQuotaStore struct {
+	mu    sync.Mutex
+	usage map[string]*ratelimit.QuotaUsage
+	err   error
+	// block makes AddQuotaUsage wait until its context is done.
+	block bool
+}
+
+func newTestQuotaStore() *testQuotaStore {
+	return &testQuotaStore{usage: make(map[string]*ratelimit.QuotaUsage)}
+}
+
+func (s *testQuotaStore) AddQuotaUsage(ctx context.Context, usage []*ratelimit.QuotaUsage) ([]*ratelimit.QuotaUsage, error) {
+	s.mu.Lock()
+	err, block := s.err, s.block
+	s.mu.Unlock()
+	if block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	totals := make([]*ratelimit.QuotaUsage, 0, len(usage))
+	for _, u := range usage {
+		id := u.Key + u.WindowStart.String()
+		t, ok := s.usage[id]
+		if !ok {
+			c := *u
+			c.Used = 0
+			t = &c
+			s.usage[id] = t
+		}
+		t.Used += u.Used
+		c := *t
+		totals = append(totals, &c)
+	}
+	return totals, nil
+}
+
+func (s *testQuotaStore) DeleteExpiredQuotaUsage(context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int
+	for id, u := range s.usage {
+		if u.ExpirationTime.Before(time.Now()) {
+			delete(s.usage, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *testQuotaStore) used() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var used uint64
+	for _, u := range s.usage {
+		used += u.Used
+	}
+	return used
+}
+
+func (s *testQuotaStore) set(err error, block bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err, s.block = err, block
+}
+
+// testTotalLimits returns limits for listing targets, which are only limited
+// in total, with a period long enough that a test doesn't cross windows.
+func testTotalLimits(limit uint64) []rate.Limit {
+	return []rate.Limit{
+		&rate.Limited{
+			Resource:    resource.Target.String(),
+			Action:      action.List.String(),
+			Per:         rate.LimitPerTotal,
+			MaxRequests: limit,
+			Period:      time.Hour,
+		},
+		&rate.Unlimited{
+			Resource: resource.Target.String(),
+			Action:   action.List.String(),
+			Per:      rate.LimitPerIPAddress,
+		},
+		&rate.Unlimited{
+			Resource: resource.Target.String(),
+			Action:   action.List.String(),
+			Per:      rate.LimitPerAuthToken,
+		},
+	}
+}
+
+func testSharedLimiter(t *testing.T, store ratelimit.QuotaStore, limits []rate.Limit) *ratelimit.SharedLimiter {
+	t.Helper()
+	quotas, err := ratelimit.NewSharedQuotas(context.Background(), store, 10*time.Millisecond, 100)
+	require.NoError(t, err)
+	l, err := ratelimit.NewSharedLimiter(limits, 100, quotas)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, l.Shutdown()) })
+	return l
+}
+
+func testAllow(t *testing.T, l ratelimit.Limiter, authTokenId string) bool {
+	t.Helper()
+	allowed, _, err := l.Allow(resource.Target.String(), action.List.String(), "127.0.0.1", authTokenId)
+	require.NoError(t, err)
+	return allowed
+}
+
+func TestSharedLimiter(t *testing.T) {
+	t.Run("cluster-wide", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		store := newTestQuotaStore()
+		controller1 := testSharedLimiter(t, store, testTotalLimits(10))
+		controller2 := testSharedLimiter(t, store, testTotalLimits(10))
+
+		for i := 0; i < 8; i++ {
+			assert.True(testAllow(t, controller1, "at_1"))
+		}
+		require.Eventually(func() bool { return store.used() == 8 }, 5*time.Second, 10*time.Millisecond)
+
+		// controller2 learns about the usage of controller1 when it syncs
+		// its own usage.
+		assert.True(testAllow(t, controller2, "at_2"))
+		require.Eventually(func() bool { return store.used() == 9 }, 5*time.Second, 10*time.Millisecond)
+		// the cluster used 9 of 10 requests, so controller2 allows one more
+		// request, although it allowed only one request itself.
+		require.Eventually(func() bool { return !testAllow(t, controller2, "at_2") }, 5*time.Second, 10*time.Millisecond)
+		assert.Eventually(func() bool { return store.used() == 10 }, 5*time.Second, 10*time.Millisecond)
+		assert.False(testAllow(t, controller2, "at_2"))
+	})
+	t.Run("unavailable-store", func(t *testing.T) {
+		assert := assert.New(t)
+		store := newTestQuotaStore()
+		store.set(errors.New("database unavailable"), false)
+		l := testSharedLimiter(t, store, testTotalLimits(5))
+		for i := 0; i < 5; i++ {
+			assert.True(testAllow(t, l, "at_1"))
+		}
+		// the local limits are still enforced
+		assert.False(testAllow(t, l, "at_1"))
+
+		// the usage is added once the store is available again
+		store.set(nil, false)
+		assert.Eventually(func() bool { return store.used() == 5 }, 5*time.Second, 10*time.Millisecond)
+	})
+	t.Run("slow-store", func(t *testing.T) {
+		assert := assert.New(t)
+		store := newTestQuotaStore()
+		store.set(nil, true)
+		l := testSharedLimiter(t, store, testTotalLimits(50))
+		start := time.Now()
+		for i := 0; i < 50; i++ {
+			assert.True(testAllow(t, l, "at_1"))
+		}
+		// requests don't wait for the store
+		assert.Less(time.Since(start), time.Second)
+		assert.False(testAllow(t, l, "at_1"))
+	})
+	t.Run("unlimited", func(t *testing.T) {
+		store := newTestQuotaStore()
+		l := testSharedLimiter(t, store, []rate.Limit{
+			&rate.Unlimited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerTotal},
+			&rate.Unlimited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerIPAddress},
+			&rate.Limited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerAuthToken, MaxRequests: 1, Period: time.Hour},
+		})
+		assert.True(t, testAllow(t, l, "at_1"))
+		assert.False(t, testAllow(t, l, "at_1"))
+		assert.True(t, testAllow(t, l, "at_2"))
+	})
+	t.Run("denied-by-shared-quota", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		store := newTestQuotaStore()
+		l := testSharedLimiter(t, store, testTotalLimits(2))
+		assert.True(testAllow(t, l, "at_1"))
+		assert.True(testAllow(t, l, "at_1"))
+
+		// the shared quota is checked first, so it decides the request and
+		// the local quota isn't used.
+		allowed, quota, err := l.AllowQuota(resource.Target.String(), action.List.String(), "127.0.0.1", "at_1")
+		require.NoError(err)
+		assert.False(allowed)
+		require.NotNil(quota)
+		assert.Equal(uint64(2), quota.MaxRequests())
+		assert.Zero(quota.Remaining())
+		// the window of the shared quota is aligned to the period of its
+		// limit.
+		windowEnd := time.Now().Truncate(time.Hour).Add(time.Hour)
+		assert.InDelta(time.Until(windowEnd).Seconds(), quota.ResetsIn().Seconds(), 1)
+
+		allowed, localQuota, err := l.Allow(resource.Target.String(), action.List.String(), "127.0.0.1", "at_1")
+		require.NoError(err)
+		assert.False(allowed)
+		assert.Nil(localQuota)
+	})
+	t.Run("denied-by-local-limiter", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		store := newTestQuotaStore()
+		quotas, err := ratelimit.NewSharedQuotas(context.Background(), store, 10*time.Millisecond, 100)
+		require.NoError(err)
+		// the local limiter can only store the quota of one auth token.
+		l, err := ratelimit.NewSharedLimiter([]rate.Limit{
+			&rate.Unlimited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerTotal},
+			&rate.Unlimited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerIPAddress},
+			&rate.Limited{Resource: resource.Target.String(), Action: action.List.String(), Per: rate.LimitPerAuthToken, MaxRequests: 10, Period: time.Hour},
+		}, 1, quotas)
+		require.NoError(err)
+		t.Cleanup(func() { require.NoError(l.Shutdown()) })
+
+		assert.True(testAllow(t, l, "at_1"))
+		_, _, err = l.AllowQuota(resource.Target.String(), action.List.String(), "127.0.0.1", "at_2")
+		require.Error(err)
+		var errFull *rate.ErrLimiterFull
+		assert.ErrorAs(err, &errFull)
+
+		// the shared usage of the request denied by the local limiter is
+		// released.
+		require.Eventually(func() bool { return store.used() == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Never(func() bool { return store.used() > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+	})
+	t.Run("overrides", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		store := newTestQuotaStore()
+		quotas, err := ratelimit.NewSharedQuotas(context.Background(), store, 10*time.Millisecond, 100)
+		require.NoError(err)
+		l, err := ratelimit.NewOverrideLimiter(
+			testTotalLimits(1),
+			[]*ratelimit.Override{{Users: []string{"u_automation"}, Limits: testTotalLimits(2)}},
+			100,
+			func(_ context.Context, authTokenId, _ string) (string, []string, error) {
+				if authTokenId == "at_automation" {
+					return "u_automation", nil, nil
+				}
+				return "u_human", nil, nil
+			},
+			ratelimit.WithSharedQuotas(quotas),
+		)
+		require.NoError(err)
+		t.Cleanup(func() { require.NoError(l.Shutdown()) })
+
+		l.Resolve(context.Background(), "at_automation", "s1automation")
+		automation, _ := l.LimiterFor("at_automation", "s1automation")
+		require.IsType(&ratelimit.SharedLimiter{}, automation)
+		l.Resolve(context.Background(), "at_human", "s1human")
+		human, _ := l.LimiterFor("at_human", "s1human")
+		require.IsType(&ratelimit.SharedLimiter{}, human)
+
+		// the quotas of the override are separate from the default quotas
+		assert.True(testAllow(t, human, "at_human"))
+		assert.True(testAllow(t, automation, "at_automation"))
+		assert.True(testAllow(t, automation, "at_automation"))
+		assert.False(testAllow(t, automation, "at_automation"))
+		assert.False(testAllow(t, human, "at_human"))
+	})
+}
+
+func TestNewSharedQuotas(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name         string
+		store        ratelimit.QuotaStore
+		syncInterval time.Duration
+		maxEntries   int
+		wantErr      bool
+	}{
+		{"valid", newTestQuotaStore(), time.Second, 10, false},
+		{"default-sync-interval", newTestQuotaStore(), 0, 10, false},
+		{"missing-store", nil, time.Second, 10, true},
+		{"negative-sync-interval", newTestQuotaStore(), -time.Second, 10, true},
+		{"zero-max-entries", newTestQuotaStore(), time.Second, 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := ratelimit.NewSharedQuotas(ctx, tc.store, tc.syncInterval, tc.maxEntries)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			q.Stop()
+			// Stop can be called more than once
+			q.Stop()
+		})
+	}
+	t.Run("missing-shared-quotas", func(t *testing.T) {
+		_, err := ratelimit.NewSharedLimiter(testTotalLimits(1), 10, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// Ssh channel types of a ChannelRecording.
+const (
+	ChannelTypeSession        = "session"
+	ChannelTypeX11            = "x11"
+	ChannelTypeForwardedTcpip = "forwarded-tcpip"
+	ChannelTypeDirectTcpip    = "direct-tcpip"
+	ChannelTypeUnknown        = "unknown"
+)
+
+// Programs of a ChannelRecording with the session channel type.
+const (
+	ProgramNone      = "none"
+	ProgramShell     = "shell"
+	ProgramExec      = "exec"
+	ProgramSubsystem = "subsystem"
+)
+
+// Exec programs of a ChannelRecording with the exec program.
+const (
+	ExecProgramUnknown = "unknown"
+	ExecProgramScp     = "scp"
+	ExecProgramRsync   = "rsync"
+)
+
+// A ChannelRecording is the recording of an ssh channel of a recorded
+// connection.
+type ChannelRecording struct {
+	*store.ChannelRecording
+	tableName string `gorm:"-"`
+
+	// Program is the program run in a channel with the session channel type.
+	// It is empty for all other channel types.
+	Program string `gorm:"-"`
+
+	// SubsystemName is the name of the subsystem when Program is subsystem.
+	SubsystemName string `gorm:"-"`
+
+	// ExecProgram is the program run when Program is exec.
+	ExecProgram string `gorm:"-"`
+}
+
+func allocChannelRecording() *ChannelRecording {
+	return &ChannelRecording{
+		ChannelRecording: &store.ChannelRecording{},
+	}
+}
+
+func (cr *ChannelRecording) clone() *ChannelRecording {
+	cp := proto.Clone(cr.ChannelRecording)
+	return &ChannelRecording{
+		ChannelRecording: cp.(*store.ChannelRecording),
+		Program:          cr.Program,
+		SubsystemName:    cr.SubsystemName,
+		ExecProgram:      cr.ExecProgram,
+	}
+}
+
+// TableName returns the table name for the channel recording.
+func (cr *ChannelRecording) TableName() string {
+	if cr.tableName != "" {
+		return cr.tableName
+	}
+	return "recording_channel_ssh"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (cr *ChannelRecording) SetTableName(n string) {
+	cr.tableName = n
+}
+
+// sessionChannel records the program run in a channel recording with the
+// session channel type.
+type sessionChannel struct {
+	RecordingChannelId string `gorm:"primary_key"`
+	Program            string
+}
+
+// TableName returns the table name for gorm.
+func (*sessionChannel) TableName() string {
+	return "recording_channel_ssh_session_channel"
+}
+
+// sessionChannelSubsystem records the subsystem requested in a channel
+// recording with the subsystem program.
+type sessionChannelSubsystem struct {
+	RecordingChannelId string `gorm:"primary_key"`
+	SubsystemName      string
+}
+
+// TableName returns the table name for gorm.
+func (*sessionChannelSubsystem) TableName() string {
+	return "recording_channel_ssh_session_channel_program_subsystem"
+}
+
+// sessionChannelExec records the program executed in a channel recording with
+// the exec program.
+type sessionChannelExec struct {
+	RecordingChannelId string `gorm:"primary_key"`
+	ExecProgram        string
+}
+
+// TableName returns the table name for gorm.
+func (*sessionChannelExec) TableName() string {
+	return "recording_channel_ssh_session_channel_program_exec"
+}
+
+// channelRecordingAgg is a view of an ssh channel recording with its program.
+type channelRecordingAgg struct {
+	PublicId              string `gorm:"primary_key"`
+	RecordingConnectionId string
+	CreateTime            *timestamp.Timestamp
+	UpdateTime            *timestamp.Timestamp
+	StartTime             *timestamp.Timestamp
+	EndTime               *timestamp.Timestamp
+	BytesUp               uint64
+	BytesDown             uint64
+	ChannelType           string
+	ChannelProgram        string
+}
+
+// TableName returns the table name for gorm.
+func (*channelRecordingAgg) TableName() string {
+	return "recording_channel_ssh_aggregate"
+}
+
+func (agg *channelRecordingAgg) toChannelRecording() *ChannelRecording {
+	cr := allocChannelRecording()
+	cr.PublicId = agg.PublicId
+	cr.RecordingConnectionId = agg.RecordingConnectionId
+	cr.CreateTime = agg.CreateTime
+	cr.UpdateTime = agg.UpdateTime
+	cr.StartTime = agg.StartTime
+	cr.EndTime = agg.EndTime
+	cr.BytesUp = agg.BytesUp
+	cr.BytesDown = agg.BytesDown
+	cr.ChannelType = agg.ChannelType
+	cr.Program = agg.ChannelProgram
+	return cr
+}
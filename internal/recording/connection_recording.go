@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// A ConnectionRecording is the recording of a connection of a recorded
+// session.
+type ConnectionRecording struct {
+	*store.ConnectionRecording
+	tableName string `gorm:"-"`
+
+	// ChannelRecordings are the channel recordings of the connection
+	// recording. They are only set by LookupSessionRecording.
+	ChannelRecordings []*ChannelRecording `gorm:"-"`
+}
+
+func allocConnectionRecording() *ConnectionRecording {
+	return &ConnectionRecording{
+		ConnectionRecording: &store.ConnectionRecording{},
+	}
+}
+
+func (cr *ConnectionRecording) clone() *ConnectionRecording {
+	cp := proto.Clone(cr.ConnectionRecording)
+	return &ConnectionRecording{
+		ConnectionRecording: cp.(*store.ConnectionRecording),
+	}
+}
+
+// TableName returns the table name for the connection recording.
+func (cr *ConnectionRecording) TableName() string {
+	if cr.tableName != "" {
+		return cr.tableName
+	}
+	return "recording_connection"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (cr *ConnectionRecording) SetTableName(n string) {
+	cr.tableName = n
+}
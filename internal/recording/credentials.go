@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+)
+
+// The credential history views contain the values of the credentials and
+// credential libraries used by a recorded session at the time the recording
+// was created. The purposes of a credential are aggregated into a single
+// column separated by a '|'.
+
+func splitPurposes(p string) []string {
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "|")
+}
+
+type staticJsonCredentialAgg struct {
+	RecordingId      string
+	PublicId         string
+	Name             string
+	Description      string
+	ObjectHmac       []byte
+	StorePublicId    string
+	StoreProjectId   string
+	StoreName        string
+	StoreDescription string
+	Purposes         string
+}
+
+// TableName returns the table name for gorm.
+func (*staticJsonCredentialAgg) TableName() string {
+	return "credential_static_json_credential_hst_aggregate"
+}
+
+func (agg *staticJsonCredentialAgg) toMeta() bsr.StaticJsonCredential {
+	return bsr.StaticJsonCredential{
+		PublicId:    agg.PublicId,
+		Name:        agg.Name,
+		Description: agg.Description,
+		ObjectHmac:  agg.ObjectHmac,
+		Purposes:    splitPurposes(agg.Purposes),
+		CredentialStore: bsr.StaticCredentialStore{
+			PublicId:    agg.StorePublicId,
+			ProjectId:   agg.StoreProjectId,
+			Name:        agg.StoreName,
+			Description: agg.StoreDescription,
+		},
+	}
+}
+
+type staticUsernamePasswordCredentialAgg struct {
+	RecordingId      string
+	PublicId         string
+	Name             string
+	Description      string
+	Username         string
+	PasswordHmac     []byte
+	StorePublicId    string
+	StoreProjectId   string
+	StoreName        string
+	StoreDescription string
+	Purposes         string
+}
+
+// TableName returns the table name for gorm.
+func (*staticUsernamePasswordCredentialAgg) TableName() string {
+	return "credential_static_username_password_credential_hst_aggregate"
+}
+
+func (agg *staticUsernamePasswordCredentialAgg) toMeta() bsr.StaticUsernamePasswordCredential {
+	return bsr.StaticUsernamePasswordCredential{
+		PublicId:     agg.PublicId,
+		Name:         agg.Name,
+		Description:  agg.Description,
+		Username:     agg.Username,
+		PasswordHmac: agg.PasswordHmac,
+		Purposes:     splitPurposes(agg.Purposes),
+		CredentialStore: bsr.StaticCredentialStore{
+			PublicId:    agg.StorePublicId,
+			ProjectId:   agg.StoreProjectId,
+			Name:        agg.StoreName,
+			Description: agg.StoreDescription,
+		},
+	}
+}
+
+type staticSshPrivateKeyCredentialAgg struct {
+	RecordingId              string
+	PublicId                 string
+	Name                     string
+	Description              string
+	Username                 string
+	PrivateKeyHmac           []byte
+	PrivateKeyPassphraseHmac []byte
+	StorePublicId            string
+	StoreProjectId           string
+	StoreName                string
+	StoreDescription         string
+	Purposes                 string
+}
+
+// TableName returns the table name for gorm.
+func (*staticSshPrivateKeyCredentialAgg) TableName() string {
+	return "credential_static_ssh_private_key_credential_hst_aggregate"
+}
+
+func (agg *staticSshPrivateKeyCredentialAgg) toMeta() bsr.StaticSshPrivateKeyCredential {
+	return bsr.StaticSshPrivateKeyCredential{
+		PublicId:                 agg.PublicId,
+		Name:                     agg.Name,
+		Description:              agg.Description,
+		Username:                 agg.Username,
+		PrivateKeyHmac:           agg.PrivateKeyHmac,
+		PrivateKeyPassphraseHmac: agg.PrivateKeyPassphraseHmac,
+		Purposes:                 splitPurposes(agg.Purposes),
+		CredentialStore: bsr.StaticCredentialStore{
+			PublicId:    agg.StorePublicId,
+			ProjectId:   agg.StoreProjectId,
+			Name:        agg.StoreName,
+			Description: agg.StoreDescription,
+		},
+	}
+}
+
+// vaultCredentialStore returns the credential store of a vault credential
+// library.
+func vaultCredentialStore(publicId, projectId, name, description, address, namespace, tlsServerName string, tlsSkipVerify bool, workerFilter string) bsr.VaultCredentialStore {
+	return bsr.VaultCredentialStore{
+		PublicId:      publicId,
+		ProjectId:     projectId,
+		Name:          name,
+		Description:   description,
+		VaultAddress:  address,
+		Namespace:     namespace,
+		TlsServerName: tlsServerName,
+		TlsSkipVerify: tlsSkipVerify,
+		WorkerFilter:  workerFilter,
+	}
+}
+
+type vaultGenericLibraryAgg struct {
+	RecordingId        string
+	PublicId           string
+	Name               string
+	Description        string
+	VaultPath          string
+	HttpMethod         string
+	HttpRequestBody    []byte
+	CredentialType     string
+	StorePublicId      string
+	StoreProjectId     string
+	StoreName          string
+	StoreDescription   string
+	StoreVaultAddress  string
+	StoreNamespace     string
+	StoreTlsServerName string
+	StoreTlsSkipVerify bool
+	StoreWorkerFilter  string
+	Purposes           string
+}
+
+// TableName returns the table name for gorm.
+func (*vaultGenericLibraryAgg) TableName() string {
+	return "credential_vault_library_hst_aggregate"
+}
+
+func (agg *vaultGenericLibraryAgg) toMeta() bsr.VaultGenericLibrary {
+	return bsr.VaultGenericLibrary{
+		PublicId:        agg.PublicId,
+		Name:            agg.Name,
+		Description:     agg.Description,
+		VaultPath:       agg.VaultPath,
+		HttpMethod:      agg.HttpMethod,
+		HttpRequestBody: agg.HttpRequestBody,
+		CredentialType:  agg.CredentialType,
+		Purposes:        splitPurposes(agg.Purposes),
+		CredentialStore: vaultCredentialStore(agg.StorePublicId, agg.StoreProjectId, agg.StoreName, agg.StoreDescription,
+			agg.StoreVaultAddress, agg.StoreNamespace, agg.StoreTlsServerName, agg.StoreTlsSkipVerify, agg.StoreWorkerFilter),
+	}
+}
+
+type vaultSshCertificateLibraryAgg struct {
+	RecordingId        string
+	PublicId           string
+	Name               string
+	Description        string
+	VaultPath          string
+	Username           string
+	KeyType            string
+	KeyBits            int
+	Ttl                string
+	CriticalOptions    []byte
+	Extensions         []byte
+	CredentialType     string
+	StorePublicId      string
+	StoreProjectId     string
+	StoreName          string
+	StoreDescription   string
+	StoreVaultAddress  string
+	StoreNamespace     string
+	StoreTlsServerName string
+	StoreTlsSkipVerify bool
+	StoreWorkerFilter  string
+	Purposes           string
+}
+
+// TableName returns the table name for gorm.
+func (*vaultSshCertificateLibraryAgg) TableName() string {
+	return "credential_vault_ssh_cert_library_hst_aggregate"
+}
+
+func (agg *vaultSshCertificateLibraryAgg) toMeta() bsr.VaultSshCertificateLibrary {
+	return bsr.VaultSshCertificateLibrary{
+		PublicId:        agg.PublicId,
+		Name:            agg.Name,
+		Description:     agg.Description,
+		VaultPath:       agg.VaultPath,
+		Username:        agg.Username,
+		KeyType:         agg.KeyType,
+		KeyBits:         agg.KeyBits,
+		Ttl:             agg.Ttl,
+		CriticalOptions: agg.CriticalOptions,
+		Extensions:      agg.Extensions,
+		CredentialType:  agg.CredentialType,
+		Purposes:        splitPurposes(agg.Purposes),
+		CredentialStore: vaultCredentialStore(agg.StorePublicId, agg.StoreProjectId, agg.StoreName, agg.StoreDescription,
+			agg.StoreVaultAddress, agg.StoreNamespace, agg.StoreTlsServerName, agg.StoreTlsSkipVerify, agg.StoreWorkerFilter),
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+// Package recording provides the session, connection and channel recordings
+// of sessions to targets with session recording enabled.
+//
+// A session recording is created when the first connection of a recorded
+// session is authorized. Every authorized connection of the session gets a
+// connection recording. The worker proxying the session records it to the
+// storage bucket of the target and reports the summaries of the session,
+// connection and channel recordings when the session has ended. The values of
+// the user, target, host and credentials of the session at the time the
+// recording was created are kept with the recording.
+//
+// # Repository
+//
+// A repository provides methods for creating, closing and retrieving
+// recordings. A new repository should be created for each transaction. For
+// example:
+//
+//	var wrapper wrapping.Wrapper
+//	... init wrapper...
+//
+//	// db implements both the reader and writer interfaces.
+//	db, _ := db.Open(db.Postgres, url)
+//
+//	var repo *recording.Repository
+//
+//	repo, _ = recording.NewRepository(ctx, db, db, kms)
+//
+//	cr, created, _ := repo.StartConnectionRecording(ctx, sessionId, connectionId)
+//	sr, _ := repo.LookupSessionRecording(ctx, cr.GetRecordingSessionId())
+package recording
@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	storageplugin "github.com/hashicorp/boundary/internal/storage/plugin"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/session_recordings"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	ua "go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	deleteExpiredRecordingsJobName        = "recording_delete_expired_session_recordings"
+	deleteExpiredRecordingsJobRunInterval = time.Hour
+)
+
+// expiredSessionRecording is a session recording with the settings of the
+// storage policy it inherits.
+type expiredSessionRecording struct {
+	PublicId              string `gorm:"primary_key"`
+	StorageBucketId       string
+	StorageBucketScopeId  string
+	StorageBucketPluginId string
+	StoragePolicyId       string
+	State                 string
+	CreateTime            *timestamp.Timestamp
+	EndTime               *timestamp.Timestamp
+	DeleteAfterDays       int32
+}
+
+// TableName returns the view name for gorm.
+func (*expiredSessionRecording) TableName() string {
+	return "recording_session_storage_policy"
+}
+
+// DeleteExpiredRecordingsJob is the recurring job that deletes the session
+// recordings which expired according to the storage policy they inherit.
+// The objects of a session recording are deleted from its storage bucket by
+// the storage plugin of the bucket before the session recording is removed
+// from the database. An audit event is written for every deleted session
+// recording.
+// The DeleteExpiredRecordingsJob is not thread safe,
+// an attempt to Run the job concurrently will result in an JobAlreadyRunning error.
+type DeleteExpiredRecordingsJob struct {
+	reader  db.Reader
+	writer  db.Writer
+	kms     *kms.Kms
+	plugins map[string]plgpb.StoragePluginServiceClient
+	limit   int
+
+	running       ua.Bool
+	numRecordings int
+	numProcessed  int
+}
+
+// newDeleteExpiredRecordingsJob creates a new in-memory DeleteExpiredRecordingsJob.
+//
+// WithLimit is the only supported option.
+func newDeleteExpiredRecordingsJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms, plgm map[string]plgpb.StoragePluginServiceClient, opt ...Option) (*DeleteExpiredRecordingsJob, error) {
+	const op = "recording.newDeleteExpiredRecordingsJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	case plgm == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing plugin manager")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &DeleteExpiredRecordingsJob{
+		reader:  r,
+		writer:  w,
+		kms:     kms,
+		plugins: plgm,
+		limit:   opts.withLimit,
+	}, nil
+}
+
+// Status returns the current status of the delete expired recordings job.
+// Total is the total number of expired session recordings found. Completed
+// is the number of session recordings already processed.
+func (r *DeleteExpiredRecordingsJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: r.numProcessed,
+		Total:     r.numRecordings,
+	}
+}
+
+// Run queries the database for expired session recordings, deletes their
+// objects using the storage plugin of their storage bucket and then deletes
+// them from the database. Session recordings which cannot be deleted are
+// skipped and retried on the next run. Can not be run in parallel, if Run is
+// invoked while already running an error with code JobAlreadyRunning will be
+// returned.
+func (r *DeleteExpiredRecordingsJob) Run(ctx context.Context) error {
+	const op = "recording.(DeleteExpiredRecordingsJob).Run"
+	if !r.running.CAS(r.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer r.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	var expired []*expiredSessionRecording
+	if err := r.reader.SearchWhere(ctx, &expired, expiredSessionRecordingsWhere, nil, db.WithLimit(r.limit)); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numProcessed and numRecordings for status report
+	r.numProcessed, r.numRecordings = 0, len(expired)
+	if len(expired) == 0 {
+		// Nothing to do, return early
+		return nil
+	}
+
+	storageRepo, err := storageplugin.NewRepository(ctx, r.reader, r.writer, r.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, sr := range expired {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		if err := r.deleteRecording(ctx, storageRepo, sr); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("deleting expired session recording", "session recording id", sr.PublicId))
+		}
+		r.numProcessed++
+	}
+	return nil
+}
+
+// NextRunIn returns the default run frequency of the delete expired
+// recordings job.
+func (r *DeleteExpiredRecordingsJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return deleteExpiredRecordingsJobRunInterval, nil
+}
+
+// Name is the unique name of the job.
+func (r *DeleteExpiredRecordingsJob) Name() string {
+	return deleteExpiredRecordingsJobName
+}
+
+// Description is the human readable description of the job.
+func (r *DeleteExpiredRecordingsJob) Description() string {
+	return "Periodically deletes session recordings which expired according to their storage policy."
+}
+
+// deleteRecording deletes the objects of sr from its storage bucket and then
+// deletes sr from the database. Session recordings of storage buckets whose
+// storage plugin is not available to the controller, or does not support
+// deleting objects, are skipped.
+func (r *DeleteExpiredRecordingsJob) deleteRecording(ctx context.Context, storageRepo *storageplugin.Repository, sr *expiredSessionRecording) error {
+	const op = "recording.(DeleteExpiredRecordingsJob).deleteRecording"
+	client, ok := r.plugins[sr.StorageBucketPluginId]
+	if !ok || client == nil {
+		event.WriteSysEvent(ctx, op, "skipping expired session recording, storage plugin not available",
+			"session recording id", sr.PublicId, "plugin id", sr.StorageBucketPluginId)
+		return nil
+	}
+
+	sb, plg, err := storageRepo.LookupStorageBucket(ctx, sr.StorageBucketId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if sb == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("storage bucket %s not found", sr.StorageBucketId))
+	}
+	bucket, err := storageplugin.ToPluginStorageBucket(ctx, sb, plg)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	resp, err := client.DeleteObjects(ctx, &plgpb.DeleteObjectsRequest{
+		Bucket:    bucket,
+		KeyPrefix: fmt.Sprintf("%s.bsr/", sr.PublicId),
+		Recursive: true,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			event.WriteSysEvent(ctx, op, "skipping expired session recording, storage plugin does not support deleting objects",
+				"session recording id", sr.PublicId, "plugin id", sr.StorageBucketPluginId)
+			return nil
+		}
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete session recording objects"))
+	}
+
+	rec := allocSessionRecording()
+	rec.PublicId = sr.PublicId
+	if _, err := r.writer.Delete(ctx, rec); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to delete session recording"))
+	}
+
+	details := &pb.SessionRecording{
+		Id:              sr.PublicId,
+		Scope:           &scopes.ScopeInfo{Id: sr.StorageBucketScopeId},
+		StorageBucketId: sr.StorageBucketId,
+		State:           sr.State,
+		CreatedTime:     sr.CreateTime.GetTimestamp(),
+		EndTime:         sr.EndTime.GetTimestamp(),
+	}
+	if err := event.WriteAudit(ctx, op, event.WithRequest(&event.Request{
+		Operation: "delete",
+		Endpoint:  deleteExpiredRecordingsJobName,
+		Details:   details,
+	})); err != nil {
+		// error was NOT event'd above...
+		_ = errors.Wrap(ctx, err, op, errors.WithCode(errors.Internal), errors.WithMsg("error writing session recording deletion audit event"))
+	}
+	event.WriteSysEvent(ctx, op, "deleted expired session recording",
+		"session recording id", sr.PublicId, "storage policy id", sr.StoragePolicyId, "objects deleted", resp.GetObjectsDeleted())
+	return nil
+}
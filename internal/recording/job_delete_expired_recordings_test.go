@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/plugin/loopback"
+	spolicy "github.com/hashicorp/boundary/internal/policy/storage"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/session"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeleteExpiredRecordingsJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	plgm := map[string]plgpb.StoragePluginServiceClient{}
+
+	type args struct {
+		r    db.Reader
+		w    db.Writer
+		kms  *kms.Kms
+		plgm map[string]plgpb.StoragePluginServiceClient
+	}
+	tests := []struct {
+		name        string
+		args        args
+		options     []Option
+		wantLimit   int
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name:        "nil reader",
+			args:        args{w: rw, kms: kmsCache, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil writer",
+			args:        args{r: rw, kms: kmsCache, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil kms",
+			args:        args{r: rw, w: rw, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil plugins",
+			args:        args{r: rw, w: rw, kms: kmsCache},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "valid",
+			args:      args{r: rw, w: rw, kms: kmsCache, plgm: plgm},
+			wantLimit: db.DefaultLimit,
+		},
+		{
+			name:      "valid with limit",
+			args:      args{r: rw, w: rw, kms: kmsCache, plgm: plgm},
+			options:   []Option{WithLimit(100)},
+			wantLimit: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			got, err := newDeleteExpiredRecordingsJob(ctx, tt.args.r, tt.args.w, tt.args.kms, tt.args.plgm, tt.options...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.args.r, got.reader)
+			assert.Equal(tt.args.w, got.writer)
+			assert.Equal(tt.args.kms, got.kms)
+			assert.Equal(tt.wantLimit, got.limit)
+		})
+	}
+}
+
+func TestDeleteExpiredRecordingsJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var deleted []*plgpb.DeleteObjectsRequest
+	plgServer := &loopback.TestPluginStorageServer{
+		DeleteObjectsFn: func(_ context.Context, req *plgpb.DeleteObjectsRequest) (*plgpb.DeleteObjectsResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			deleted = append(deleted, req)
+			return &plgpb.DeleteObjectsResponse{ObjectsDeleted: 1}, nil
+		},
+	}
+	plgm := map[string]plgpb.StoragePluginServiceClient{}
+
+	// newRecording creates a session recording in a new org and registers
+	// the storage plugin of its storage bucket. If expired is set, the
+	// session recording is marked as having ended nine days ago.
+	newRecording := func(t *testing.T, expired bool) *SessionRecording {
+		t.Helper()
+		s := TestRecordedSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, _, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+		require.NoError(t, err)
+		sr, err := repo.LookupSessionRecording(ctx, cr.GetRecordingSessionId())
+		require.NoError(t, err)
+		require.NotNil(t, sr)
+		if expired {
+			_, err := rw.Exec(ctx, `
+update recording_session
+   set start_time = now() - interval '10 days',
+       end_time   = now() - interval '9 days',
+       state      = 'available'
+ where public_id = ?`, []any{sr.GetPublicId()})
+			require.NoError(t, err)
+		}
+		row := &expiredSessionRecording{}
+		require.NoError(t, rw.LookupWhere(ctx, row, "public_id = ?", []any{sr.GetPublicId()}))
+		plgm[row.StorageBucketPluginId] = loopback.NewWrappingPluginStorageClient(plgServer)
+		return sr
+	}
+	exists := func(t *testing.T, id string) bool {
+		t.Helper()
+		sr, err := repo.LookupSessionRecording(ctx, id)
+		require.NoError(t, err)
+		return sr != nil
+	}
+
+	expiredRec := newRecording(t, true)
+	activeRec := newRecording(t, false)
+	heldRec := newRecording(t, true)
+	noPolicyRec := newRecording(t, true)
+	spolicy.TestPolicy(t, rw, expiredRec.StorageBucketScopeId, spolicy.WithDeleteAfterDays(1))
+	spolicy.TestPolicy(t, rw, activeRec.StorageBucketScopeId, spolicy.WithDeleteAfterDays(1))
+	spolicy.TestPolicy(t, rw, heldRec.StorageBucketScopeId, spolicy.WithDeleteAfterDays(1), spolicy.WithLegalHold(true))
+
+	job, err := newDeleteExpiredRecordingsJob(ctx, rw, rw, kmsCache, plgm)
+	require.NoError(t, err)
+	sched := scheduler.TestScheduler(t, conn, wrapper)
+	require.NoError(t, sched.RegisterJob(ctx, job))
+
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numRecordings)
+	assert.Equal(t, 1, job.numProcessed)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, expiredRec.GetPublicId()+".bsr/", deleted[0].GetKeyPrefix())
+	assert.True(t, deleted[0].GetRecursive())
+	assert.False(t, exists(t, expiredRec.GetPublicId()))
+	assert.True(t, exists(t, activeRec.GetPublicId()))
+	assert.True(t, exists(t, heldRec.GetPublicId()))
+	assert.True(t, exists(t, noPolicyRec.GetPublicId()))
+
+	// The global storage policy applies to session recordings of scopes
+	// without a storage policy.
+	spolicy.TestPolicy(t, rw, "global", spolicy.WithDeleteAfterDays(1))
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numRecordings)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, noPolicyRec.GetPublicId()+".bsr/", deleted[1].GetKeyPrefix())
+	assert.False(t, exists(t, noPolicyRec.GetPublicId()))
+	assert.True(t, exists(t, heldRec.GetPublicId()))
+
+	// Session recordings are kept if their storage plugin does not support
+	// deleting objects.
+	plgServer.DeleteObjectsFn = nil
+	unsupportedRec := newRecording(t, true)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.numRecordings)
+	assert.True(t, exists(t, unsupportedRec.GetPublicId()))
+}
@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/bsr/search"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	storageplugin "github.com/hashicorp/boundary/internal/storage/plugin"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	indexRecordingsJobName        = "recording_index_session_recordings"
+	indexRecordingsJobRunInterval = 5 * time.Minute
+)
+
+// Search index states.
+const (
+	searchIndexStateIndexed = "indexed"
+	searchIndexStateFailed  = "failed"
+)
+
+// unindexedSessionRecording is an available session recording which has no
+// search index yet.
+type unindexedSessionRecording struct {
+	PublicId              string `gorm:"primary_key"`
+	StorageBucketId       string
+	StorageBucketPluginId string
+}
+
+// TableName returns the view name for gorm.
+func (*unindexedSessionRecording) TableName() string {
+	return "recording_session_storage_policy"
+}
+
+// IndexRecordingsJob is the recurring job that builds the search index of
+// session recordings once they are available. The index contains the output
+// of the shell and exec channels of a session recording and is written to
+// the storage bucket of the session recording, alongside its BSR. Session
+// recordings which cannot be indexed are marked as failed and are not
+// retried.
+// The IndexRecordingsJob is not thread safe,
+// an attempt to Run the job concurrently will result in an JobAlreadyRunning error.
+type IndexRecordingsJob struct {
+	reader  db.Reader
+	writer  db.Writer
+	kms     *kms.Kms
+	plugins map[string]plgpb.StoragePluginServiceClient
+	limit   int
+
+	running       ua.Bool
+	numRecordings int
+	numProcessed  int
+}
+
+// newIndexRecordingsJob creates a new in-memory IndexRecordingsJob.
+//
+// WithLimit is the only supported option.
+func newIndexRecordingsJob(ctx context.Context, r db.Reader, w db.Writer, kms *kms.Kms, plgm map[string]plgpb.StoragePluginServiceClient, opt ...Option) (*IndexRecordingsJob, error) {
+	const op = "recording.newIndexRecordingsJob"
+	switch {
+	case r == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Reader")
+	case w == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing db.Writer")
+	case kms == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing kms")
+	case plgm == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing plugin manager")
+	}
+
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &IndexRecordingsJob{
+		reader:  r,
+		writer:  w,
+		kms:     kms,
+		plugins: plgm,
+		limit:   opts.withLimit,
+	}, nil
+}
+
+// Status returns the current status of the index recordings job. Total is
+// the total number of session recordings found to index. Completed is the
+// number of session recordings already processed.
+func (r *IndexRecordingsJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: r.numProcessed,
+		Total:     r.numRecordings,
+	}
+}
+
+// Run queries the database for available session recordings without a
+// search index, builds their index and records the result in the database.
+// Session recordings of storage buckets whose storage plugin is not
+// available to the controller are skipped until it is. Nothing is indexed
+// if no BSR KMS is configured. Can not be run in parallel, if Run is
+// invoked while already running an error with code JobAlreadyRunning will be
+// returned.
+func (r *IndexRecordingsJob) Run(ctx context.Context) error {
+	const op = "recording.(IndexRecordingsJob).Run"
+	if !r.running.CAS(r.running.Load(), true) {
+		return errors.New(ctx, errors.JobAlreadyRunning, op, "job already running")
+	}
+	defer r.running.Store(false)
+
+	// Verify context is not done before running
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	r.numProcessed, r.numRecordings = 0, 0
+	bsrWrapper := r.kms.GetExternalWrappers(ctx).Bsr()
+	if bsrWrapper == nil || len(r.plugins) == 0 {
+		// Nothing can be indexed, return early
+		return nil
+	}
+	pluginIds := make([]string, 0, len(r.plugins))
+	for id := range r.plugins {
+		pluginIds = append(pluginIds, id)
+	}
+
+	var unindexed []*unindexedSessionRecording
+	if err := r.reader.SearchWhere(ctx, &unindexed, unindexedSessionRecordingsWhere, []any{pluginIds}, db.WithLimit(r.limit), db.WithOrder("create_time asc")); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	// Set numRecordings for status report
+	r.numRecordings = len(unindexed)
+	if len(unindexed) == 0 {
+		// Nothing to do, return early
+		return nil
+	}
+
+	repo, err := NewRepository(ctx, r.reader, r.writer, r.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	storageRepo, err := storageplugin.NewRepository(ctx, r.reader, r.writer, r.kms)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	for _, sr := range unindexed {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+		state, details := searchIndexStateIndexed, sql.NullString{}
+		if err := r.indexRecording(ctx, repo, storageRepo, bsrWrapper, sr); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("indexing session recording", "session recording id", sr.PublicId))
+			state, details = searchIndexStateFailed, sql.NullString{String: err.Error(), Valid: true}
+		}
+		if _, err := r.writer.Exec(ctx, insertSearchIndexQuery, []any{
+			sql.Named("recording_session_id", sr.PublicId),
+			sql.Named("state", state),
+			sql.Named("error_details", details),
+		}); err != nil {
+			event.WriteError(ctx, op, err, event.WithInfoMsg("recording session recording search index state", "session recording id", sr.PublicId))
+		}
+		r.numProcessed++
+	}
+	return nil
+}
+
+// NextRunIn returns the default run frequency of the index recordings job.
+func (r *IndexRecordingsJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return indexRecordingsJobRunInterval, nil
+}
+
+// Name is the unique name of the job.
+func (r *IndexRecordingsJob) Name() string {
+	return indexRecordingsJobName
+}
+
+// Description is the human readable description of the job.
+func (r *IndexRecordingsJob) Description() string {
+	return "Periodically builds the search index of available session recordings."
+}
+
+// indexRecording builds the search index of the shell and exec channels of
+// sr from its BSR and writes it to the storage bucket of sr.
+func (r *IndexRecordingsJob) indexRecording(ctx context.Context, repo *Repository, storageRepo *storageplugin.Repository, bsrWrapper wrapping.Wrapper, sr *unindexedSessionRecording) error {
+	const op = "recording.(IndexRecordingsJob).indexRecording"
+	rec, err := repo.LookupSessionRecording(ctx, sr.PublicId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if rec == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("session recording %s not found", sr.PublicId))
+	}
+	var channels []search.Channel
+	for _, cr := range rec.ConnectionRecordings {
+		for _, ch := range cr.ChannelRecordings {
+			if ch.ChannelType != ChannelTypeSession {
+				continue
+			}
+			if ch.Program != ProgramShell && ch.Program != ProgramExec {
+				continue
+			}
+			channels = append(channels, search.Channel{
+				ConnectionRecordingId: cr.GetPublicId(),
+				ChannelRecordingId:    ch.GetPublicId(),
+			})
+		}
+	}
+
+	sb, plg, err := storageRepo.LookupStorageBucket(ctx, sr.StorageBucketId)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if sb == nil {
+		return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("storage bucket %s not found", sr.StorageBucketId))
+	}
+	bucket, err := storageplugin.ToPluginStorageBucket(ctx, sb, plg)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+
+	dir, err := os.MkdirTemp("", "boundary-index-")
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer os.RemoveAll(dir)
+	rs, err := local.New(ctx, dir, map[string]plgpb.StoragePluginServiceClient{plg.GetName(): r.plugins[sr.StorageBucketPluginId]}, false)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	remote, err := rs.NewRemoteFS(ctx, bucket)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	session, err := bsr.OpenSession(ctx, sr.PublicId, remote, bsrkms.NewKeyUnwrapCallbackFunc(ctx, bsrWrapper))
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to open session recording"))
+	}
+	defer session.Close(ctx)
+
+	idx, err := search.Build(ctx, session, channels...)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to build search index"))
+	}
+	syncing, err := rs.NewSyncingFS(ctx, bucket)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err := search.Write(ctx, syncing, idx); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to write search index"))
+	}
+	return nil
+}
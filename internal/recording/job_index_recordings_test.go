@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	"github.com/hashicorp/boundary/internal/bsr/search"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/plugin/loopback"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	storageplugin "github.com/hashicorp/boundary/internal/storage/plugin"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIndexRecordingsJob(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	plgm := map[string]plgpb.StoragePluginServiceClient{}
+
+	type args struct {
+		r    db.Reader
+		w    db.Writer
+		kms  *kms.Kms
+		plgm map[string]plgpb.StoragePluginServiceClient
+	}
+	tests := []struct {
+		name        string
+		args        args
+		options     []Option
+		wantLimit   int
+		wantErr     bool
+		wantErrCode errors.Code
+	}{
+		{
+			name:        "nil reader",
+			args:        args{w: rw, kms: kmsCache, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil writer",
+			args:        args{r: rw, kms: kmsCache, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil kms",
+			args:        args{r: rw, w: rw, plgm: plgm},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:        "nil plugins",
+			args:        args{r: rw, w: rw, kms: kmsCache},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
+		{
+			name:      "valid",
+			args:      args{r: rw, w: rw, kms: kmsCache, plgm: plgm},
+			wantLimit: db.DefaultLimit,
+		},
+		{
+			name:      "valid with limit",
+			args:      args{r: rw, w: rw, kms: kmsCache, plgm: plgm},
+			options:   []Option{WithLimit(100)},
+			wantLimit: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			got, err := newIndexRecordingsJob(ctx, tt.args.r, tt.args.w, tt.args.kms, tt.args.plgm, tt.options...)
+			if tt.wantErr {
+				require.Error(err)
+				assert.Nil(got)
+				assert.Truef(errors.Match(errors.T(tt.wantErrCode), err), "Unexpected error %s", err)
+				return
+			}
+			require.NoError(err)
+			require.NotNil(got)
+			assert.Equal(tt.args.r, got.reader)
+			assert.Equal(tt.args.w, got.writer)
+			assert.Equal(tt.args.kms, got.kms)
+			assert.Equal(tt.wantLimit, got.limit)
+		})
+	}
+}
+
+func TestIndexRecordingsJob_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	storageRepo, err := storageplugin.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(t, err)
+	bsrWrapper := bsrkms.TestWrapper(t)
+
+	// indexState returns the state and error details of the search index
+	// of the session recording, which are empty if it was not indexed.
+	indexState := func(t *testing.T, id string) (string, string) {
+		t.Helper()
+		rows, err := rw.Query(ctx, `select state, error_details from recording_session_search_index where recording_session_id = ?`, []any{id})
+		require.NoError(t, err)
+		defer rows.Close()
+		var state string
+		var details sql.NullString
+		for rows.Next() {
+			require.NoError(t, rows.Scan(&state, &details))
+		}
+		require.NoError(t, rows.Err())
+		return state, details.String
+	}
+
+	plgm := map[string]plgpb.StoragePluginServiceClient{}
+	indexedRec, plugins := TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+	for id, client := range plugins {
+		plgm[id] = client
+	}
+	// The BSR of this session recording cannot be retrieved since the
+	// storage plugin used by the job has no storage buckets.
+	failedRec, plugins := TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+	for id := range plugins {
+		lp, err := loopback.NewLoopbackPlugin()
+		require.NoError(t, err)
+		plgm[id] = loopback.NewWrappingPluginStorageClient(lp)
+	}
+	// The storage plugin of this session recording is not available.
+	unavailableRec, _ := TestAvailableSessionRecording(t, conn, wrapper, iamRepo, bsrWrapper)
+	// This session recording is still being recorded.
+	s := TestRecordedSession(t, conn, wrapper, iamRepo)
+	c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+	cr, _, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+	require.NoError(t, err)
+	startedId := cr.GetRecordingSessionId()
+
+	job, err := newIndexRecordingsJob(ctx, rw, rw, kmsCache, plgm)
+	require.NoError(t, err)
+	sched := scheduler.TestScheduler(t, conn, wrapper)
+	require.NoError(t, sched.RegisterJob(ctx, job))
+
+	// Nothing is indexed without a BSR KMS.
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.numRecordings)
+	state, _ := indexState(t, indexedRec.GetPublicId())
+	assert.Empty(t, state)
+
+	require.NoError(t, kmsCache.AddExternalWrappers(ctx, kms.WithBsrWrapper(bsrWrapper)))
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 2, job.numRecordings)
+	assert.Equal(t, 2, job.numProcessed)
+
+	state, details := indexState(t, indexedRec.GetPublicId())
+	assert.Equal(t, searchIndexStateIndexed, state)
+	assert.Empty(t, details)
+	state, details = indexState(t, failedRec.GetPublicId())
+	assert.Equal(t, searchIndexStateFailed, state)
+	assert.Contains(t, details, "unable to open session recording")
+	state, _ = indexState(t, unavailableRec.GetPublicId())
+	assert.Empty(t, state)
+	state, _ = indexState(t, startedId)
+	assert.Empty(t, state)
+
+	// The index is written to the storage bucket of the session recording.
+	sb, plg, err := storageRepo.LookupStorageBucket(ctx, indexedRec.GetStorageBucketId())
+	require.NoError(t, err)
+	bucket, err := storageplugin.ToPluginStorageBucket(ctx, sb, plg)
+	require.NoError(t, err)
+	rs, err := local.New(ctx, t.TempDir(), map[string]plgpb.StoragePluginServiceClient{plg.GetName(): plgm[plg.GetPublicId()]}, false)
+	require.NoError(t, err)
+	fs, err := rs.NewRemoteFS(ctx, bucket)
+	require.NoError(t, err)
+	idx, err := search.Read(ctx, fs, indexedRec.GetPublicId())
+	require.NoError(t, err)
+	lines := idx.Search("hello")
+	require.Len(t, lines, 1)
+	assert.Equal(t, indexedRec.ConnectionRecordings[0].GetPublicId(), lines[0].ConnectionRecordingId)
+	assert.Equal(t, indexedRec.ConnectionRecordings[0].ChannelRecordings[0].GetPublicId(), lines[0].ChannelRecordingId)
+
+	// Session recordings are only indexed once.
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.numRecordings)
+}
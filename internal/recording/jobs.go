@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+// RegisterJobs registers the session recording related jobs with the
+// provided scheduler.
+func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, r db.Reader, w db.Writer, kms *kms.Kms, plgm map[string]plgpb.StoragePluginServiceClient) error {
+	const op = "recording.RegisterJobs"
+	deleteExpiredJob, err := newDeleteExpiredRecordingsJob(ctx, r, w, kms, plgm)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, deleteExpiredJob); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("delete expired session recordings job"))
+	}
+	indexJob, err := newIndexRecordingsJob(ctx, r, w, kms, plgm)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if err = scheduler.RegisterJob(ctx, indexJob); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("index session recordings job"))
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetOpts(t *testing.T) {
+	t.Parallel()
+	t.Run("WithLimit", func(t *testing.T) {
+		opts := getOpts(WithLimit(5))
+		testOpts := getDefaultOptions()
+		testOpts.withLimit = 5
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithStartPageAfterItem", func(t *testing.T) {
+		assert := assert.New(t)
+		updateTime := time.Now()
+		createTime := time.Now()
+		opts := getOpts(WithStartPageAfterItem(&SessionRecording{
+			SessionRecording: &store.SessionRecording{
+				PublicId:   "sr_1234567890",
+				CreateTime: timestamp.New(createTime),
+				UpdateTime: timestamp.New(updateTime),
+			},
+		}))
+		assert.Equal(opts.withStartPageAfterItem.GetPublicId(), "sr_1234567890")
+		assert.Equal(opts.withStartPageAfterItem.GetUpdateTime(), timestamp.New(updateTime))
+		assert.Equal(opts.withStartPageAfterItem.GetCreateTime(), timestamp.New(createTime))
+	})
+	t.Run("WithSearchIndexed", func(t *testing.T) {
+		opts := getOpts(WithSearchIndexed(true))
+		testOpts := getDefaultOptions()
+		testOpts.withSearchIndexed = true
+		assert.Equal(t, opts, testOpts)
+	})
+}
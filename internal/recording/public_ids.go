@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+func newSessionRecordingId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.SessionRecordingPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "recording.newSessionRecordingId")
+	}
+	return id, nil
+}
+
+func newConnectionRecordingId(ctx context.Context) (string, error) {
+	id, err := db.NewPublicId(ctx, globals.ConnectionRecordingPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "recording.newConnectionRecordingId")
+	}
+	return id, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+const (
+	// recordedSessionStorageBucketQuery returns the storage bucket of the
+	// target of a session when the target has session recording enabled.
+	recordedSessionStorageBucketQuery = `
+select ts.storage_bucket_id
+  from session s
+  join target_ssh ts
+    on ts.public_id = s.target_id
+ where s.public_id = @session_id
+   and ts.enable_session_recording = true
+   and ts.storage_bucket_id is not null;
+`
+
+	// sessionRecordingCompressionQuery returns the recording compression and
+	// level of the target of the session of a session recording.
+	sessionRecordingCompressionQuery = `
+select ts.recording_compression,
+       ts.recording_compression_level
+  from recording_session rs
+  join session s
+    on s.public_id = rs.session_id
+  join target_ssh ts
+    on ts.public_id = s.target_id
+ where rs.public_id = @recording_session_id;
+`
+
+	// expiredSessionRecordingsWhere selects the session recordings which
+	// ended longer ago than the delete after days of the storage policy they
+	// inherit. Session recordings which are still being recorded or are
+	// under a legal hold are never selected.
+	expiredSessionRecordingsWhere = `
+state != 'started'
+and legal_hold = false
+and delete_after_days > 0
+and coalesce(end_time, create_time) + make_interval(days => delete_after_days) <= now()
+`
+
+	// unindexedSessionRecordingsWhere selects the available session
+	// recordings which have no search index and whose storage bucket uses one
+	// of the provided storage plugins.
+	unindexedSessionRecordingsWhere = `
+state = 'available'
+and storage_bucket_plugin_id in (?)
+and public_id not in (select recording_session_id from recording_session_search_index)
+`
+
+	// insertSearchIndexQuery records the result of building the search index
+	// of a session recording.
+	insertSearchIndexQuery = `
+insert into recording_session_search_index
+  (recording_session_id, state, error_details)
+values
+  (@recording_session_id, @state, @error_details);
+`
+
+	// searchIndexedWhere restricts session recordings to the ones with a
+	// search index.
+	searchIndexedWhere = `public_id in (select recording_session_id from recording_session_search_index where state = 'indexed')`
+)
@@ -0,0 +1,472 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// StartConnectionRecording creates a connection recording for the session
+// connection connectionId of the session sessionId. The session recording
+// of the session is created with the first connection recording. The
+// returned bool is true if the session recording was created by this call.
+// Returns nil, false, nil if the target of the session does not have session
+// recording enabled.
+func (r *Repository) StartConnectionRecording(ctx context.Context, sessionId, connectionId string, _ ...Option) (*ConnectionRecording, bool, error) {
+	const op = "recording.(Repository).StartConnectionRecording"
+	switch {
+	case sessionId == "":
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case connectionId == "":
+		return nil, false, errors.New(ctx, errors.InvalidParameter, op, "missing connection id")
+	}
+
+	crId, err := newConnectionRecordingId(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+	srId, err := newSessionRecordingId(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op)
+	}
+
+	var newConnection *ConnectionRecording
+	var created bool
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			created = false
+			newConnection = nil
+
+			sr := allocSessionRecording()
+			if err := reader.LookupWhere(ctx, sr, "session_id = ?", []any{sessionId}); err != nil {
+				if !errors.IsNotFoundError(err) {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up session recording"))
+				}
+				rows, err := reader.Query(ctx, recordedSessionStorageBucketQuery, []any{sql.Named("session_id", sessionId)})
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up storage bucket"))
+				}
+				var storageBucketId string
+				for rows.Next() {
+					if err := rows.Scan(&storageBucketId); err != nil {
+						_ = rows.Close()
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan storage bucket id"))
+					}
+				}
+				if err := rows.Err(); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up storage bucket"))
+				}
+				if storageBucketId == "" {
+					// session recording is not enabled for the target
+					return nil
+				}
+
+				sr = allocSessionRecording()
+				sr.PublicId = srId
+				sr.SessionId = sessionId
+				sr.StorageBucketId = storageBucketId
+				var rowsAffected int64
+				onConflict := &db.OnConflict{
+					Target: db.Columns{"session_id"},
+					Action: db.DoNothing(true),
+				}
+				if err := w.Create(ctx, sr, db.WithOnConflict(onConflict), db.WithReturnRowsAffected(&rowsAffected)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create session recording"))
+				}
+				if rowsAffected == 0 {
+					// another connection of the session created the session
+					// recording first
+					sr = allocSessionRecording()
+					if err := reader.LookupWhere(ctx, sr, "session_id = ?", []any{sessionId}); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up session recording"))
+					}
+				} else {
+					created = true
+				}
+			}
+
+			cr := allocConnectionRecording()
+			cr.PublicId = crId
+			cr.SessionId = sessionId
+			cr.SessionConnectionId = connectionId
+			cr.RecordingSessionId = sr.GetPublicId()
+			if err := w.Create(ctx, cr); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create connection recording"))
+			}
+			newConnection = cr
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, false, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", connectionId)))
+	}
+	return newConnection, created, nil
+}
+
+// LookupRecordingCompression returns the compression and compression level
+// the target of the session recording sessionRecordingId uses for its
+// recordings. An empty compression and a zero level are returned if the
+// target does not set them.
+func (r *Repository) LookupRecordingCompression(ctx context.Context, sessionRecordingId string, _ ...Option) (string, uint32, error) {
+	const op = "recording.(Repository).LookupRecordingCompression"
+	if sessionRecordingId == "" {
+		return "", 0, errors.New(ctx, errors.InvalidParameter, op, "missing session recording id")
+	}
+	rows, err := r.reader.Query(ctx, sessionRecordingCompressionQuery, []any{sql.Named("recording_session_id", sessionRecordingId)})
+	if err != nil {
+		return "", 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up recording compression"))
+	}
+	defer rows.Close()
+	var compression sql.NullString
+	var level sql.NullInt32
+	found := false
+	for rows.Next() {
+		if err := rows.Scan(&compression, &level); err != nil {
+			return "", 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan recording compression"))
+		}
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", 0, errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up recording compression"))
+	}
+	if !found {
+		return "", 0, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("session recording %s not found", sessionRecordingId))
+	}
+	return compression.String, uint32(level.Int32), nil
+}
+
+// LookupSessionRecording returns the session recording for id with its
+// connection and channel recordings and the metadata of the recorded
+// session. The id can be the public id of the session recording, of the
+// recorded session or of one of the channel recordings of the session
+// recording. Returns nil, nil if no session recording is found.
+func (r *Repository) LookupSessionRecording(ctx context.Context, id string, _ ...Option) (*SessionRecording, error) {
+	const op = "recording.(Repository).LookupSessionRecording"
+	if id == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing id")
+	}
+
+	where := "public_id = ?"
+	switch {
+	case strings.HasPrefix(id, globals.SessionPrefix+"_"):
+		where = "session_id = ?"
+	case strings.HasPrefix(id, globals.ChannelRecordingPrefix+"_"):
+		where = `public_id = (
+			select rcn.recording_session_id
+			  from recording_channel rch
+			  join recording_connection rcn
+			    on rcn.public_id = rch.recording_connection_id
+			 where rch.public_id = ?
+		)`
+	}
+
+	var sr *SessionRecording
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, _ db.Writer) error {
+			agg := &sessionRecordingAgg{}
+			if err := reader.LookupWhere(ctx, agg, where, []any{id}); err != nil {
+				if errors.IsNotFoundError(err) {
+					sr = nil
+					return nil
+				}
+				return errors.Wrap(ctx, err, op)
+			}
+			var err error
+			if sr, err = agg.toSessionRecording(ctx); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+			if err := lookupCredentials(ctx, reader, sr); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+
+			var connections []*ConnectionRecording
+			if err := reader.SearchWhere(ctx, &connections, "recording_session_id = ?", []any{sr.GetPublicId()},
+				db.WithLimit(-1), db.WithOrder("create_time asc, public_id asc")); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up connection recordings"))
+			}
+			if len(connections) == 0 {
+				return nil
+			}
+			connectionIds := make([]string, 0, len(connections))
+			byId := make(map[string]*ConnectionRecording, len(connections))
+			for _, cr := range connections {
+				connectionIds = append(connectionIds, cr.GetPublicId())
+				byId[cr.GetPublicId()] = cr
+			}
+
+			var channelAggs []*channelRecordingAgg
+			if err := reader.SearchWhere(ctx, &channelAggs, "recording_connection_id in (?)", []any{connectionIds},
+				db.WithLimit(-1), db.WithOrder("create_time asc, public_id asc")); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up channel recordings"))
+			}
+			var subsystemChannels, execChannels []string
+			channels := make(map[string]*ChannelRecording, len(channelAggs))
+			for _, ca := range channelAggs {
+				ch := ca.toChannelRecording()
+				channels[ch.GetPublicId()] = ch
+				switch ch.Program {
+				case ProgramSubsystem:
+					subsystemChannels = append(subsystemChannels, ch.GetPublicId())
+				case ProgramExec:
+					execChannels = append(execChannels, ch.GetPublicId())
+				}
+				cr := byId[ch.GetRecordingConnectionId()]
+				cr.ChannelRecordings = append(cr.ChannelRecordings, ch)
+			}
+			if len(subsystemChannels) > 0 {
+				var subsystems []*sessionChannelSubsystem
+				if err := reader.SearchWhere(ctx, &subsystems, "recording_channel_id in (?)", []any{subsystemChannels}, db.WithLimit(-1)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up channel subsystems"))
+				}
+				for _, s := range subsystems {
+					channels[s.RecordingChannelId].SubsystemName = s.SubsystemName
+				}
+			}
+			if len(execChannels) > 0 {
+				var execs []*sessionChannelExec
+				if err := reader.SearchWhere(ctx, &execs, "recording_channel_id in (?)", []any{execChannels}, db.WithLimit(-1)); err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up channel exec programs"))
+				}
+				for _, e := range execs {
+					channels[e.RecordingChannelId].ExecProgram = e.ExecProgram
+				}
+			}
+			sr.ConnectionRecordings = connections
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", id)))
+	}
+	return sr, nil
+}
+
+// lookupCredentials sets the credentials and credential libraries of the
+// recorded session in the session meta of sr.
+func lookupCredentials(ctx context.Context, reader db.Reader, sr *SessionRecording) error {
+	const op = "recording.lookupCredentials"
+	where, args := "recording_id = ?", []any{sr.GetPublicId()}
+
+	var jsonCreds []*staticJsonCredentialAgg
+	if err := reader.SearchWhere(ctx, &jsonCreds, where, args, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up static json credentials"))
+	}
+	for _, c := range jsonCreds {
+		sr.SessionMeta.StaticJSONCredentials = append(sr.SessionMeta.StaticJSONCredentials, c.toMeta())
+	}
+
+	var upCreds []*staticUsernamePasswordCredentialAgg
+	if err := reader.SearchWhere(ctx, &upCreds, where, args, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up static username password credentials"))
+	}
+	for _, c := range upCreds {
+		sr.SessionMeta.StaticUsernamePasswordCredentials = append(sr.SessionMeta.StaticUsernamePasswordCredentials, c.toMeta())
+	}
+
+	var sshCreds []*staticSshPrivateKeyCredentialAgg
+	if err := reader.SearchWhere(ctx, &sshCreds, where, args, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up static ssh private key credentials"))
+	}
+	for _, c := range sshCreds {
+		sr.SessionMeta.StaticSshPrivateKeyCredentials = append(sr.SessionMeta.StaticSshPrivateKeyCredentials, c.toMeta())
+	}
+
+	var genericLibs []*vaultGenericLibraryAgg
+	if err := reader.SearchWhere(ctx, &genericLibs, where, args, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up vault generic credential libraries"))
+	}
+	for _, l := range genericLibs {
+		sr.SessionMeta.VaultGenericLibraries = append(sr.SessionMeta.VaultGenericLibraries, l.toMeta())
+	}
+
+	var sshCertLibs []*vaultSshCertificateLibraryAgg
+	if err := reader.SearchWhere(ctx, &sshCertLibs, where, args, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up vault ssh certificate credential libraries"))
+	}
+	for _, l := range sshCertLibs {
+		sr.SessionMeta.VaultSshCertificateLibraries = append(sr.SessionMeta.VaultSshCertificateLibraries, l.toMeta())
+	}
+	return nil
+}
+
+// ListSessionRecordings returns a slice of SessionRecordings stored in
+// storage buckets in the scope IDs ordered by create time and public id. The
+// connection recordings and credentials of the returned session recordings
+// are not set. WithLimit, WithStartPageAfterItem and WithSearchIndexed are
+// the only options supported.
+func (r *Repository) ListSessionRecordings(ctx context.Context, scopeIds []string, opt ...Option) ([]*SessionRecording, error) {
+	const op = "recording.(Repository).ListSessionRecordings"
+	if len(scopeIds) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no scope id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	where, args := "storage_bucket_scope_id in (?)", []any{scopeIds}
+	if opts.withStartPageAfterItem != nil {
+		where += " and (create_time, public_id) > (?, ?)"
+		args = append(args, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+	if opts.withSearchIndexed {
+		where += " and " + searchIndexedWhere
+	}
+	var aggs []*sessionRecordingAgg
+	if err := r.reader.SearchWhere(ctx, &aggs, where, args, db.WithLimit(limit), db.WithOrder("create_time asc, public_id asc")); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	recordings := make([]*SessionRecording, 0, len(aggs))
+	for _, agg := range aggs {
+		sr, err := agg.toSessionRecording(ctx)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, op)
+		}
+		recordings = append(recordings, sr)
+	}
+	return recordings, nil
+}
+
+// CloseSessionRecording records the start time, end time and state of the
+// session recording sr and the summaries of its connection and channel
+// recordings. The state of the session recording is set to unknown if
+// sr.ErrorDetails is set, otherwise it is set to available. Every
+// connection recording in sr must belong to sr. The channel recordings in
+// sr are created.
+func (r *Repository) CloseSessionRecording(ctx context.Context, sr *SessionRecording, _ ...Option) (*SessionRecording, error) {
+	const op = "recording.(Repository).CloseSessionRecording"
+	switch {
+	case sr == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session recording")
+	case sr.SessionRecording == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing embedded session recording")
+	case sr.GetPublicId() == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing public id")
+	case sr.GetStartTime() == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing start time")
+	case sr.GetEndTime() == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing end time")
+	case !sr.GetEndTime().AsTime().After(sr.GetStartTime().AsTime()):
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "end time must be after start time")
+	}
+	for _, cr := range sr.ConnectionRecordings {
+		switch {
+		case cr == nil || cr.ConnectionRecording == nil:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing connection recording")
+		case cr.GetPublicId() == "":
+			return nil, errors.New(ctx, errors.InvalidParameter, op, "missing connection recording public id")
+		case cr.GetStartTime() == nil || cr.GetEndTime() == nil:
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("missing times for connection recording %s", cr.GetPublicId()))
+		}
+		for _, ch := range cr.ChannelRecordings {
+			switch {
+			case ch == nil || ch.ChannelRecording == nil:
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "missing channel recording")
+			case ch.GetPublicId() == "":
+				return nil, errors.New(ctx, errors.InvalidParameter, op, "missing channel recording public id")
+			case ch.GetChannelType() == "":
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("missing channel type for channel recording %s", ch.GetPublicId()))
+			case ch.GetStartTime() == nil || ch.GetEndTime() == nil:
+				return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("missing times for channel recording %s", ch.GetPublicId()))
+			}
+		}
+	}
+
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current := allocSessionRecording()
+			current.PublicId = sr.GetPublicId()
+			if err := reader.LookupByPublicId(ctx, current); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up session recording"))
+			}
+			if current.GetState() != string(StateStarted) {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("session recording %s is already closed", sr.GetPublicId()))
+			}
+
+			var existing []*ConnectionRecording
+			if err := reader.SearchWhere(ctx, &existing, "recording_session_id = ?", []any{sr.GetPublicId()}, db.WithLimit(-1)); err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up connection recordings"))
+			}
+			owned := make(map[string]bool, len(existing))
+			for _, cr := range existing {
+				owned[cr.GetPublicId()] = true
+			}
+
+			for _, cr := range sr.ConnectionRecordings {
+				if !owned[cr.GetPublicId()] {
+					return errors.New(ctx, errors.InvalidParameter, op,
+						fmt.Sprintf("connection recording %s does not belong to session recording %s", cr.GetPublicId(), sr.GetPublicId()))
+				}
+				updateConnection := cr.clone()
+				rowsUpdated, err := w.Update(ctx, updateConnection, []string{"StartTime", "EndTime", "BytesUp", "BytesDown"}, nil)
+				if err != nil {
+					return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update connection recording"))
+				}
+				if rowsUpdated != 1 {
+					return errors.New(ctx, errors.MultipleRecords, op, fmt.Sprintf("updated connection recording and %d rows updated", rowsUpdated))
+				}
+
+				for _, ch := range cr.ChannelRecordings {
+					newChannel := ch.clone()
+					newChannel.RecordingConnectionId = cr.GetPublicId()
+					if err := w.Create(ctx, newChannel); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create channel recording"))
+					}
+					if ch.GetChannelType() != ChannelTypeSession {
+						continue
+					}
+					program := ch.Program
+					if program == "" {
+						program = ProgramNone
+					}
+					if err := w.Create(ctx, &sessionChannel{RecordingChannelId: ch.GetPublicId(), Program: program}); err != nil {
+						return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create channel program"))
+					}
+					switch program {
+					case ProgramSubsystem:
+						if err := w.Create(ctx, &sessionChannelSubsystem{RecordingChannelId: ch.GetPublicId(), SubsystemName: ch.SubsystemName}); err != nil {
+							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create channel subsystem"))
+						}
+					case ProgramExec:
+						execProgram := ch.ExecProgram
+						if execProgram == "" {
+							execProgram = ExecProgramUnknown
+						}
+						if err := w.Create(ctx, &sessionChannelExec{RecordingChannelId: ch.GetPublicId(), ExecProgram: execProgram}); err != nil {
+							return errors.Wrap(ctx, err, op, errors.WithMsg("unable to create channel exec program"))
+						}
+					}
+				}
+			}
+
+			updateSession := sr.clone()
+			fieldMask := []string{"StartTime", "EndTime", "State"}
+			updateSession.State = string(StateAvailable)
+			if sr.GetErrorDetails() != "" {
+				updateSession.State = string(StateUnknown)
+				fieldMask = append(fieldMask, "ErrorDetails")
+			}
+			rowsUpdated, err := w.Update(ctx, updateSession, fieldMask, nil)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to update session recording"))
+			}
+			if rowsUpdated != 1 {
+				return errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("session recording %s not found", sr.GetPublicId()))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for %s", sr.GetPublicId())))
+	}
+	return r.LookupSessionRecording(ctx, sr.GetPublicId())
+}
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_StartConnectionRecording(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("not-recorded", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, created, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+		require.NoError(err)
+		assert.False(created)
+		assert.Nil(cr)
+	})
+
+	t.Run("missing-session-id", func(t *testing.T) {
+		_, _, err := repo.StartConnectionRecording(ctx, "", "sc_1234567890")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("missing-connection-id", func(t *testing.T) {
+		_, _, err := repo.StartConnectionRecording(ctx, "s_1234567890", "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("recorded", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestRecordedSession(t, conn, wrapper, iamRepo)
+		c1 := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		c2 := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2223, "127.0.0.1")
+
+		cr1, created, err := repo.StartConnectionRecording(ctx, s.PublicId, c1.PublicId)
+		require.NoError(err)
+		require.NotNil(cr1)
+		assert.True(created)
+		assert.Equal(s.PublicId, cr1.GetSessionId())
+		assert.Equal(c1.PublicId, cr1.GetSessionConnectionId())
+
+		cr2, created, err := repo.StartConnectionRecording(ctx, s.PublicId, c2.PublicId)
+		require.NoError(err)
+		require.NotNil(cr2)
+		assert.False(created)
+		assert.Equal(cr1.GetRecordingSessionId(), cr2.GetRecordingSessionId())
+
+		sr, err := repo.LookupSessionRecording(ctx, cr1.GetRecordingSessionId())
+		require.NoError(err)
+		require.NotNil(sr)
+		assert.Equal(string(StateStarted), sr.GetState())
+		assert.Equal(s.PublicId, sr.GetSessionId())
+		assert.Equal(s.PublicId, sr.SessionMeta.PublicId)
+		assert.Equal(s.UserId, sr.SessionMeta.User.PublicId)
+		assert.Equal(s.TargetId, sr.SessionMeta.Target.PublicId)
+		assert.Equal(s.HostId, sr.SessionMeta.StaticHost.PublicId)
+		assert.Len(sr.ConnectionRecordings, 2)
+
+		bySession, err := repo.LookupSessionRecording(ctx, s.PublicId)
+		require.NoError(err)
+		require.NotNil(bySession)
+		assert.Equal(sr.GetPublicId(), bySession.GetPublicId())
+	})
+}
+
+func TestRepository_LookupSessionRecording(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("missing-id", func(t *testing.T) {
+		_, err := repo.LookupSessionRecording(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		sr, err := repo.LookupSessionRecording(ctx, "sr_1234567890")
+		require.NoError(t, err)
+		assert.Nil(t, sr)
+
+		sr, err = repo.LookupSessionRecording(ctx, "chr_1234567890")
+		require.NoError(t, err)
+		assert.Nil(t, sr)
+	})
+}
+
+func TestRepository_LookupRecordingCompression(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("missing-id", func(t *testing.T) {
+		_, _, err := repo.LookupRecordingCompression(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		_, _, err := repo.LookupRecordingCompression(ctx, "sr_1234567890")
+		assert.True(t, errors.Match(errors.T(errors.RecordNotFound), err))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestRecordedSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, _, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+		require.NoError(err)
+		require.NotNil(cr)
+
+		compression, level, err := repo.LookupRecordingCompression(ctx, cr.GetRecordingSessionId())
+		require.NoError(err)
+		assert.Empty(compression)
+		assert.Zero(level)
+
+		_, err = rw.Exec(ctx, "update target_ssh set recording_compression = 'zstd', recording_compression_level = 3 where public_id = ?", []any{s.TargetId})
+		require.NoError(err)
+		compression, level, err = repo.LookupRecordingCompression(ctx, cr.GetRecordingSessionId())
+		require.NoError(err)
+		assert.Equal("zstd", compression)
+		assert.Equal(uint32(3), level)
+	})
+}
+
+func TestRepository_ListSessionRecordings(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	_, err = repo.ListSessionRecordings(ctx, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+	var scopeIds []string
+	var want []string
+	for i := 0; i < 3; i++ {
+		s := TestRecordedSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, _, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+		require.NoError(t, err)
+		sr, err := repo.LookupSessionRecording(ctx, cr.GetRecordingSessionId())
+		require.NoError(t, err)
+		scopeIds = append(scopeIds, sr.StorageBucketScopeId)
+		want = append(want, sr.GetPublicId())
+	}
+
+	got, err := repo.ListSessionRecordings(ctx, scopeIds)
+	require.NoError(t, err)
+	var gotIds []string
+	for _, sr := range got {
+		gotIds = append(gotIds, sr.GetPublicId())
+	}
+	assert.Equal(t, want, gotIds)
+
+	page1, err := repo.ListSessionRecordings(ctx, scopeIds, WithLimit(2))
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+	page2, err := repo.ListSessionRecordings(ctx, scopeIds, WithLimit(2), WithStartPageAfterItem(page1[1]))
+	require.NoError(t, err)
+	require.Len(t, page2, 1)
+	assert.Equal(t, want[2], page2[0].GetPublicId())
+
+	for i, state := range []string{searchIndexStateIndexed, searchIndexStateFailed} {
+		details := sql.NullString{}
+		if state == searchIndexStateFailed {
+			details = sql.NullString{String: "unable to open session recording", Valid: true}
+		}
+		_, err := rw.Exec(ctx, insertSearchIndexQuery, []any{
+			sql.Named("recording_session_id", want[i]),
+			sql.Named("state", state),
+			sql.Named("error_details", details),
+		})
+		require.NoError(t, err)
+	}
+	indexed, err := repo.ListSessionRecordings(ctx, scopeIds, WithSearchIndexed(true))
+	require.NoError(t, err)
+	require.Len(t, indexed, 1)
+	assert.Equal(t, want[0], indexed[0].GetPublicId())
+}
+
+func TestRepository_CloseSessionRecording(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+	end := start.Add(30 * time.Second)
+
+	newRecording := func(t *testing.T) (*ConnectionRecording, *ConnectionRecording) {
+		s := TestRecordedSession(t, conn, wrapper, iamRepo)
+		c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		cr, created, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		other := TestRecordedSession(t, conn, wrapper, iamRepo)
+		oc := session.TestConnection(t, conn, other.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+		ocr, _, err := repo.StartConnectionRecording(ctx, other.PublicId, oc.PublicId)
+		require.NoError(t, err)
+		return cr, ocr
+	}
+	closedConnection := func(cr *ConnectionRecording, channels ...*ChannelRecording) *ConnectionRecording {
+		c := cr.clone()
+		c.StartTime = timestamp.New(start)
+		c.EndTime = timestamp.New(end)
+		c.BytesUp = 10
+		c.BytesDown = 20
+		c.ChannelRecordings = channels
+		return c
+	}
+	newChannel := func(t *testing.T, channelType, program, subsystem, execProgram string) *ChannelRecording {
+		id, err := db.NewPublicId(ctx, globals.ChannelRecordingPrefix)
+		require.NoError(t, err)
+		return &ChannelRecording{
+			ChannelRecording: &store.ChannelRecording{
+				PublicId:    id,
+				StartTime:   timestamp.New(start),
+				EndTime:     timestamp.New(end),
+				BytesUp:     5,
+				BytesDown:   10,
+				ChannelType: channelType,
+			},
+			Program:       program,
+			SubsystemName: subsystem,
+			ExecProgram:   execProgram,
+		}
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := repo.CloseSessionRecording(ctx, nil)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+
+		sr := allocSessionRecording()
+		sr.PublicId = "sr_1234567890"
+		sr.StartTime = timestamp.New(end)
+		sr.EndTime = timestamp.New(start)
+		_, err = repo.CloseSessionRecording(ctx, sr)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("foreign-connection", func(t *testing.T) {
+		cr, other := newRecording(t)
+		sr := allocSessionRecording()
+		sr.PublicId = cr.GetRecordingSessionId()
+		sr.StartTime = timestamp.New(start)
+		sr.EndTime = timestamp.New(end)
+		sr.ConnectionRecordings = []*ConnectionRecording{closedConnection(other)}
+		_, err := repo.CloseSessionRecording(ctx, sr)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	t.Run("available", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		cr, _ := newRecording(t)
+		sr := allocSessionRecording()
+		sr.PublicId = cr.GetRecordingSessionId()
+		sr.StartTime = timestamp.New(start)
+		sr.EndTime = timestamp.New(end)
+		sr.ConnectionRecordings = []*ConnectionRecording{closedConnection(cr,
+			newChannel(t, ChannelTypeSession, ProgramShell, "", ""),
+			newChannel(t, ChannelTypeSession, ProgramSubsystem, "sftp", ""),
+			newChannel(t, ChannelTypeSession, ProgramExec, "", ExecProgramScp),
+			newChannel(t, ChannelTypeSession, "", "", ""),
+			newChannel(t, ChannelTypeDirectTcpip, "", "", ""),
+		)}
+		got, err := repo.CloseSessionRecording(ctx, sr)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(string(StateAvailable), got.GetState())
+		assert.Empty(got.GetErrorDetails())
+		assert.Equal(uint64(10), got.GetBytesUp())
+		assert.Equal(uint64(20), got.GetBytesDown())
+		require.Len(got.ConnectionRecordings, 1)
+		channels := got.ConnectionRecordings[0].ChannelRecordings
+		require.Len(channels, 5)
+		byId := make(map[string]*ChannelRecording)
+		for _, ch := range channels {
+			byId[ch.GetPublicId()] = ch
+		}
+		for _, want := range sr.ConnectionRecordings[0].ChannelRecordings {
+			ch := byId[want.GetPublicId()]
+			require.NotNil(ch)
+			assert.Equal(want.GetChannelType(), ch.GetChannelType())
+			assert.Equal(want.SubsystemName, ch.SubsystemName)
+			assert.Equal(want.ExecProgram, ch.ExecProgram)
+			switch {
+			case want.GetChannelType() != ChannelTypeSession:
+				assert.Empty(ch.Program)
+			case want.Program == "":
+				assert.Equal(ProgramNone, ch.Program)
+			default:
+				assert.Equal(want.Program, ch.Program)
+			}
+		}
+
+		byChannel, err := repo.LookupSessionRecording(ctx, channels[0].GetPublicId())
+		require.NoError(err)
+		require.NotNil(byChannel)
+		assert.Equal(got.GetPublicId(), byChannel.GetPublicId())
+		assert.Len(byChannel.ConnectionRecordings, 1)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		cr, _ := newRecording(t)
+		sr := allocSessionRecording()
+		sr.PublicId = cr.GetRecordingSessionId()
+		sr.StartTime = timestamp.New(start)
+		sr.EndTime = timestamp.New(end)
+		sr.ErrorDetails = "upload failed"
+		sr.ConnectionRecordings = []*ConnectionRecording{closedConnection(cr)}
+		got, err := repo.CloseSessionRecording(ctx, sr)
+		require.NoError(err)
+		assert.Equal(string(StateUnknown), got.GetState())
+		assert.Equal("upload failed", got.GetErrorDetails())
+
+		// a session recording can only be closed once
+		_, err = repo.CloseSessionRecording(ctx, sr)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
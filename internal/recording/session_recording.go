@@ -0,0 +1,286 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/db/sentinel"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// State is the state of a session recording.
+type State string
+
+// Session recording states.
+const (
+	// StateStarted is the state of a session recording while the session is
+	// being recorded.
+	StateStarted State = "started"
+
+	// StateAvailable is the state of a session recording which was
+	// successfully written to its storage bucket.
+	StateAvailable State = "available"
+
+	// StateUnknown is the state of a session recording which ended with an
+	// error. The error details of the recording describe the error.
+	StateUnknown State = "unknown"
+)
+
+// A SessionRecording is the recording of a session.
+type SessionRecording struct {
+	*store.SessionRecording
+	tableName string `gorm:"-"`
+
+	// StorageBucketScopeId is the id of the scope of the storage bucket the
+	// recording is stored in. It is only set when the recording is read from
+	// the repository.
+	StorageBucketScopeId string `gorm:"-"`
+
+	// SessionMeta contains the values of the user, target, host and
+	// credentials of the recorded session at the time the recording was
+	// created. It is only set when the recording is read from the
+	// repository. The credentials are only set by LookupSessionRecording.
+	SessionMeta *bsr.SessionMeta `gorm:"-"`
+
+	// ConnectionRecordings are the connection recordings of the session
+	// recording. They are only set by LookupSessionRecording.
+	ConnectionRecordings []*ConnectionRecording `gorm:"-"`
+}
+
+func allocSessionRecording() *SessionRecording {
+	return &SessionRecording{
+		SessionRecording: &store.SessionRecording{},
+	}
+}
+
+func (sr *SessionRecording) clone() *SessionRecording {
+	cp := proto.Clone(sr.SessionRecording)
+	return &SessionRecording{
+		SessionRecording: cp.(*store.SessionRecording),
+	}
+}
+
+// TableName returns the table name for the session recording.
+func (sr *SessionRecording) TableName() string {
+	if sr.tableName != "" {
+		return sr.tableName
+	}
+	return "recording_session"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (sr *SessionRecording) SetTableName(n string) {
+	sr.tableName = n
+}
+
+// GetBytesUp returns the number of bytes sent from the clients to the
+// endpoint in all connection recordings of the session recording.
+func (sr *SessionRecording) GetBytesUp() uint64 {
+	var n uint64
+	for _, cr := range sr.ConnectionRecordings {
+		n += cr.GetBytesUp()
+	}
+	return n
+}
+
+// GetBytesDown returns the number of bytes sent from the endpoint to the
+// clients in all connection recordings of the session recording.
+func (sr *SessionRecording) GetBytesDown() uint64 {
+	var n uint64
+	for _, cr := range sr.ConnectionRecordings {
+		n += cr.GetBytesDown()
+	}
+	return n
+}
+
+// sessionRecordingAgg is a view of a session recording with its storage
+// bucket scope and the history of the user, target and host of the session.
+type sessionRecordingAgg struct {
+	PublicId             string `gorm:"primary_key"`
+	StorageBucketId      string
+	SessionId            string
+	CreateTime           *timestamp.Timestamp
+	UpdateTime           *timestamp.Timestamp
+	StartTime            *timestamp.Timestamp
+	EndTime              *timestamp.Timestamp
+	State                string
+	ErrorDetails         string
+	Endpoint             string
+	StorageBucketScopeId string
+
+	UserHistoryPublicId    string
+	UserHistoryName        string
+	UserHistoryDescription string
+
+	UserScopeHistoryPublicId            string
+	UserScopeHistoryName                string
+	UserScopeHistoryDescription         string
+	UserScopeHistoryType                string
+	UserScopeHistoryParentId            string
+	UserScopeHistoryPrimaryAuthMethodId string
+
+	TargetHistoryPublicId               string
+	TargetHistoryName                   string
+	TargetHistoryDescription            string
+	TargetHistoryDefaultPort            uint32
+	TargetHistorySessionMaxSeconds      uint32
+	TargetHistorySessionConnectionLimit int32
+	TargetHistoryWorkerFilter           string
+	TargetHistoryIngressWorkerFilter    string
+	TargetHistoryEgressWorkerFilter     string
+	TargetHistoryDefaultClientPort      uint32
+	TargetHistoryEnableSessionRecording bool
+	TargetHistoryStorageBucketId        string
+
+	TargetScopeHistoryPublicId            string
+	TargetScopeHistoryName                string
+	TargetScopeHistoryDescription         string
+	TargetScopeHistoryType                string
+	TargetScopeHistoryParentId            string
+	TargetScopeHistoryPrimaryAuthMethodId string
+
+	StaticCatalogHistoryPublicId    string
+	StaticCatalogHistoryProjectId   string
+	StaticCatalogHistoryName        string
+	StaticCatalogHistoryDescription string
+
+	StaticHostHistoryPublicId    string
+	StaticHostHistoryName        string
+	StaticHostHistoryDescription string
+	StaticHostHistoryAddress     string
+
+	PluginCatalogHistoryPublicId    string
+	PluginCatalogHistoryProjectId   string
+	PluginCatalogHistoryName        string
+	PluginCatalogHistoryDescription string
+	PluginCatalogHistoryAttributes  []byte
+	PluginCatalogHistoryPluginId    string
+
+	PluginHostHistoryPublicId     string
+	PluginHostHistoryName         string
+	PluginHostHistoryDescription  string
+	PluginHostHistoryExternalId   string
+	PluginHostHistoryExternalName string
+}
+
+// TableName returns the table name for gorm.
+func (agg *sessionRecordingAgg) TableName() string {
+	return "session_recording_aggregate"
+}
+
+// GetPublicId returns the public id of the session recording.
+func (agg *sessionRecordingAgg) GetPublicId() string {
+	return agg.PublicId
+}
+
+func (agg *sessionRecordingAgg) toSessionRecording(ctx context.Context) (*SessionRecording, error) {
+	const op = "recording.(sessionRecordingAgg).toSessionRecording"
+	sr := allocSessionRecording()
+	sr.PublicId = agg.PublicId
+	sr.StorageBucketId = agg.StorageBucketId
+	sr.SessionId = agg.SessionId
+	sr.CreateTime = agg.CreateTime
+	sr.UpdateTime = agg.UpdateTime
+	sr.StartTime = agg.StartTime
+	sr.EndTime = agg.EndTime
+	sr.State = agg.State
+	if agg.ErrorDetails != sentinel.NoErrorDetails {
+		sr.ErrorDetails = agg.ErrorDetails
+	}
+	sr.Endpoint = agg.Endpoint
+	sr.StorageBucketScopeId = agg.StorageBucketScopeId
+
+	sr.SessionMeta = &bsr.SessionMeta{
+		PublicId: agg.SessionId,
+		Endpoint: agg.Endpoint,
+		User: &bsr.User{
+			PublicId: agg.UserHistoryPublicId,
+			Scope: bsr.Scope{
+				PublicId:            agg.UserScopeHistoryPublicId,
+				Name:                agg.UserScopeHistoryName,
+				Description:         agg.UserScopeHistoryDescription,
+				Type:                agg.UserScopeHistoryType,
+				ParentId:            agg.UserScopeHistoryParentId,
+				PrimaryAuthMethodId: agg.UserScopeHistoryPrimaryAuthMethodId,
+			},
+			Name:        agg.UserHistoryName,
+			Description: agg.UserHistoryDescription,
+		},
+		Target: &bsr.Target{
+			PublicId: agg.TargetHistoryPublicId,
+			Scope: bsr.Scope{
+				PublicId:            agg.TargetScopeHistoryPublicId,
+				Name:                agg.TargetScopeHistoryName,
+				Description:         agg.TargetScopeHistoryDescription,
+				Type:                agg.TargetScopeHistoryType,
+				ParentId:            agg.TargetScopeHistoryParentId,
+				PrimaryAuthMethodId: agg.TargetScopeHistoryPrimaryAuthMethodId,
+			},
+			Name:                   agg.TargetHistoryName,
+			Description:            agg.TargetHistoryDescription,
+			DefaultPort:            agg.TargetHistoryDefaultPort,
+			DefaultClientPort:      agg.TargetHistoryDefaultClientPort,
+			SessionMaxSeconds:      agg.TargetHistorySessionMaxSeconds,
+			SessionConnectionLimit: agg.TargetHistorySessionConnectionLimit,
+			WorkerFilter:           agg.TargetHistoryWorkerFilter,
+			EgressWorkerFilter:     agg.TargetHistoryEgressWorkerFilter,
+			IngressWorkerFilter:    agg.TargetHistoryIngressWorkerFilter,
+			EnableSessionRecording: agg.TargetHistoryEnableSessionRecording,
+			StorageBucketId:        agg.TargetHistoryStorageBucketId,
+		},
+	}
+
+	switch {
+	case agg.StaticHostHistoryPublicId != "":
+		sr.SessionMeta.StaticHost = &bsr.StaticHost{
+			PublicId: agg.StaticHostHistoryPublicId,
+			Catalog: bsr.StaticHostCatalog{
+				PublicId:    agg.StaticCatalogHistoryPublicId,
+				ProjectId:   agg.StaticCatalogHistoryProjectId,
+				Name:        agg.StaticCatalogHistoryName,
+				Description: agg.StaticCatalogHistoryDescription,
+			},
+			Name:        agg.StaticHostHistoryName,
+			Description: agg.StaticHostHistoryDescription,
+			Address:     agg.StaticHostHistoryAddress,
+		}
+	case agg.PluginHostHistoryPublicId != "":
+		var attrs string
+		if len(agg.PluginCatalogHistoryAttributes) > 0 {
+			st := &structpb.Struct{}
+			if err := proto.Unmarshal(agg.PluginCatalogHistoryAttributes, st); err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to unmarshal host catalog attributes"))
+			}
+			b, err := protojson.Marshal(st)
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to marshal host catalog attributes"))
+			}
+			attrs = string(b)
+		}
+		sr.SessionMeta.DynamicHost = &bsr.DynamicHost{
+			PublicId: agg.PluginHostHistoryPublicId,
+			Catalog: bsr.DynamicHostCatalog{
+				PublicId:    agg.PluginCatalogHistoryPublicId,
+				ProjectId:   agg.PluginCatalogHistoryProjectId,
+				Name:        agg.PluginCatalogHistoryName,
+				Description: agg.PluginCatalogHistoryDescription,
+				PluginId:    agg.PluginCatalogHistoryPluginId,
+				Attributes:  attrs,
+			},
+			Name:         agg.PluginHostHistoryName,
+			Description:  agg.PluginHostHistoryDescription,
+			ExternalId:   agg.PluginHostHistoryExternalId,
+			ExternalName: agg.PluginHostHistoryExternalName,
+		}
+	}
+	return sr, nil
+}
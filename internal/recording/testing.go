@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package recording
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/bsr"
+	bsrkms "github.com/hashicorp/boundary/internal/bsr/kms"
+	bsrssh "github.com/hashicorp/boundary/internal/bsr/ssh"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/plugin"
+	"github.com/hashicorp/boundary/internal/plugin/loopback"
+	"github.com/hashicorp/boundary/internal/recording/store"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/internal/storage/local"
+	storageplugin "github.com/hashicorp/boundary/internal/storage/plugin"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-uuid"
+	"github.com/stretchr/testify/require"
+	xssh "golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestRecordedSession creates a session for an ssh target with session
+// recording enabled and a storage bucket in the org of the target. The
+// session is inserted into the database and returned.
+func TestRecordedSession(t testing.TB, conn *db.DB, wrapper wrapping.Wrapper, iamRepo *iam.Repository) *session.Session {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	name, err := uuid.GenerateUUID()
+	require.NoError(err)
+	plg := plugin.TestPlugin(t, conn, name, plugin.WithStorageFlag(true))
+	sb := storageplugin.TestStorageBucket(t, conn, wrapper, plg, org.GetPublicId())
+
+	cats := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)
+	hosts := static.TestHosts(t, conn, cats[0].GetPublicId(), 1)
+	sets := static.TestSets(t, conn, cats[0].GetPublicId(), 1)
+	_ = static.TestSetMembers(t, conn, sets[0].GetPublicId(), hosts)
+
+	tar := ssh.TestTarget(ctx, t, conn, proj.GetPublicId(), "test recorded target",
+		target.WithDefaultPort(22),
+		target.WithEnableSessionRecording(true),
+		target.WithStorageBucketId(sb.GetPublicId()),
+		target.WithHostSources([]string{sets[0].GetPublicId()}))
+
+	authMethod := password.TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	acct := password.TestAccount(t, conn, authMethod.GetPublicId(), "name1")
+	user := iam.TestUser(t, iamRepo, org.GetPublicId(), iam.WithAccountIds(acct.GetPublicId()))
+
+	authTokenRepo, err := authtoken.NewRepository(ctx, rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(err)
+	at, err := authTokenRepo.CreateAuthToken(ctx, user, acct.GetPublicId())
+	require.NoError(err)
+
+	exp := &timestamp.Timestamp{Timestamp: timestamppb.New(time.Now().Add(time.Hour))}
+	return session.TestSession(t, conn, wrapper, session.ComposedOf{
+		UserId:          user.GetPublicId(),
+		HostId:          hosts[0].GetPublicId(),
+		TargetId:        tar.GetPublicId(),
+		HostSetId:       sets[0].GetPublicId(),
+		AuthTokenId:     at.GetPublicId(),
+		ProjectId:       proj.GetPublicId(),
+		Endpoint:        "tcp://127.0.0.1:22",
+		ExpirationTime:  exp,
+		ConnectionLimit: tar.GetSessionConnectionLimit(),
+	})
+}
+
+// TestAvailableSessionRecording creates a session recording of a new
+// recorded session with a single connection recording and exec channel
+// recording and closes it. The channel ran "ls", which output "hello". The
+// BSR of the session recording is written to its storage bucket, which is
+// provided by a loopback storage plugin, using bsrWrapper. The session
+// recording and the storage plugin client keyed on the public id of the
+// storage plugin of the storage bucket are returned.
+func TestAvailableSessionRecording(t testing.TB, conn *db.DB, wrapper wrapping.Wrapper, iamRepo *iam.Repository, bsrWrapper wrapping.Wrapper) (*SessionRecording, map[string]plgpb.StoragePluginServiceClient) {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+	storageRepo, err := storageplugin.NewRepository(ctx, rw, rw, kmsCache)
+	require.NoError(err)
+
+	s := TestRecordedSession(t, conn, wrapper, iamRepo)
+	c := session.TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222, "127.0.0.1")
+	cr, _, err := repo.StartConnectionRecording(ctx, s.PublicId, c.PublicId)
+	require.NoError(err)
+	sr, err := repo.LookupSessionRecording(ctx, cr.GetRecordingSessionId())
+	require.NoError(err)
+	sb, plg, err := storageRepo.LookupStorageBucket(ctx, sr.GetStorageBucketId())
+	require.NoError(err)
+	bucket, err := storageplugin.ToPluginStorageBucket(ctx, sb, plg)
+	require.NoError(err)
+
+	lp, err := loopback.NewLoopbackPlugin(loopback.WithMockBuckets(map[loopback.BucketName]loopback.Bucket{
+		loopback.BucketName(bucket.GetBucketName()): {},
+	}))
+	require.NoError(err)
+	client := loopback.NewWrappingPluginStorageClient(lp)
+	rs, err := local.New(ctx, t.TempDir(), map[string]plgpb.StoragePluginServiceClient{plg.GetName(): client}, false)
+	require.NoError(err)
+	fs, err := rs.NewSyncingFS(ctx, bucket)
+	require.NoError(err)
+
+	channelId, err := db.NewPublicId(ctx, globals.ChannelRecordingPrefix)
+	require.NoError(err)
+	testWriteChannelRecording(t, fs, bsrWrapper, s.PublicId, sr.GetPublicId(), cr.GetPublicId(), channelId)
+
+	now := time.Now()
+	closing := &ConnectionRecording{
+		ConnectionRecording: &store.ConnectionRecording{
+			PublicId:           cr.GetPublicId(),
+			RecordingSessionId: sr.GetPublicId(),
+			StartTime:          timestamp.New(now.Add(-time.Minute)),
+			EndTime:            timestamp.New(now),
+		},
+	}
+	closing.ChannelRecordings = []*ChannelRecording{{
+		ChannelRecording: &store.ChannelRecording{
+			PublicId:    channelId,
+			StartTime:   timestamp.New(now.Add(-time.Minute)),
+			EndTime:     timestamp.New(now),
+			ChannelType: ChannelTypeSession,
+		},
+		Program:     ProgramExec,
+		ExecProgram: ExecProgramUnknown,
+	}}
+	sr.StartTime = timestamp.New(now.Add(-time.Minute))
+	sr.EndTime = timestamp.New(now)
+	sr.ConnectionRecordings = []*ConnectionRecording{closing}
+	_, err = repo.CloseSessionRecording(ctx, sr)
+	require.NoError(err)
+
+	sr, err = repo.LookupSessionRecording(ctx, sr.GetPublicId())
+	require.NoError(err)
+	return sr, map[string]plgpb.StoragePluginServiceClient{plg.GetPublicId(): client}
+}
+
+// testWriteChannelRecording writes a session recording with a single exec
+// channel recording to fs. The channel runs "ls", which outputs "hello".
+func testWriteChannelRecording(t testing.TB, fs storage.FS, bsrWrapper wrapping.Wrapper, sessionId, recordingId, connectionId, channelId string) {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	ts := bsr.NewTimestamp(time.Now())
+
+	keys, err := bsrkms.CreateKeys(ctx, bsrWrapper, recordingId)
+	require.NoError(err)
+	sesh, err := bsr.NewSession(ctx, &bsr.SessionRecordingMeta{Id: recordingId, Protocol: bsrssh.Protocol},
+		bsr.TestSessionMeta(sessionId), fs, keys, bsr.WithSupportsMultiplex(true))
+	require.NoError(err)
+	conn, err := sesh.NewConnection(ctx, &bsr.ConnectionRecordingMeta{Id: connectionId})
+	require.NoError(err)
+	ch, err := conn.NewChannel(ctx, &bsr.ChannelRecordingMeta{Id: channelId, Type: ChannelTypeSession})
+	require.NoError(err)
+
+	exec, err := bsrssh.NewExecRequest(ctx, bsr.Inbound, ts, &xssh.Request{
+		Type:    bsrssh.ExecRequestType,
+		Payload: xssh.Marshal(struct{ Command string }{"ls"}),
+	})
+	require.NoError(err)
+	data, err := bsrssh.NewDataChunk(ctx, bsr.Outbound, ts, []byte("hello\n"))
+	require.NoError(err)
+	for _, dir := range []bsr.Direction{bsr.Inbound, bsr.Outbound} {
+		mw, err := ch.NewMessagesWriter(ctx, dir)
+		require.NoError(err)
+		rw, err := ch.NewRequestsWriter(ctx, dir)
+		require.NoError(err)
+		for _, f := range []struct {
+			w      io.Writer
+			chunks []bsr.Chunk
+		}{
+			{w: mw, chunks: []bsr.Chunk{data}},
+			{w: rw, chunks: []bsr.Chunk{exec}},
+		} {
+			_, err := f.w.Write(bsr.Magic.Bytes())
+			require.NoError(err)
+			enc, err := bsr.NewChunkEncoder(ctx, f.w, bsr.NoCompression, bsr.NoEncryption)
+			require.NoError(err)
+			h, err := bsr.NewHeader(ctx, bsrssh.Protocol, dir, ts, bsr.NoCompression, bsr.NoEncryption, sessionId)
+			require.NoError(err)
+			chunks := []bsr.Chunk{h}
+			for _, c := range f.chunks {
+				if c.GetDirection() == dir {
+					chunks = append(chunks, c)
+				}
+			}
+			end, err := bsr.NewEnd(ctx, bsrssh.Protocol, dir, ts)
+			require.NoError(err)
+			for _, c := range append(chunks, end) {
+				_, err := enc.Encode(ctx, c)
+				require.NoError(err)
+			}
+			require.NoError(enc.Close())
+		}
+	}
+
+	require.NoError(ch.EncodeSummary(ctx, &bsrssh.ChannelSummary{
+		ChannelSummary: &bsr.BaseChannelSummary{
+			Id:                    channelId,
+			ConnectionRecordingId: connectionId,
+			ChannelType:           ChannelTypeSession,
+		},
+		SessionProgram:        bsrssh.Exec,
+		ExecProgram:           bsrssh.Unknown,
+		FileTransferDirection: bsrssh.FileTransferNotApplicable,
+	}))
+	require.NoError(ch.Close(ctx))
+	require.NoError(conn.EncodeSummary(ctx, &bsr.BaseConnectionSummary{Id: connectionId, ChannelCount: 1}))
+	require.NoError(conn.Close(ctx))
+	require.NoError(sesh.EncodeSummary(ctx, &bsr.BaseSessionSummary{Id: recordingId, ConnectionCount: 1}))
+	require.NoError(sesh.Close(ctx))
+}
+
+// TestIndexSessionRecordings builds the search index of the available
+// session recordings using the storage plugin clients in plgm, which are
+// keyed on the public id of the storage plugin. The BSR wrapper of kms is
+// used to open the session recordings.
+func TestIndexSessionRecordings(t testing.TB, conn *db.DB, kms *kms.Kms, plgm map[string]plgpb.StoragePluginServiceClient) {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	rw := db.New(conn)
+	job, err := newIndexRecordingsJob(ctx, rw, rw, kms, plgm)
+	require.NoError(err)
+	require.NoError(job.Run(ctx))
+}
@@ -167,14 +167,14 @@ func lookupWorker(ctx context.Context, reader db.Reader, id string) (*Worker, er
 
 // ListWorkers will return a listing of Workers and honor the WithLimit option.
 // Supported options: WithWorkerType, WithActiveWorkers, WithLiveness,
-// WithWorkerPool, WithLimit
+// WithWorkerPool, WithLimit, WithStartPageAfterItem
 //
 // If WithLiveness is zero the default liveness value is used, if it is negative
 // then the last status update time is ignored.
 // If WithLimit < 0, then unlimited results are returned. If WithLimit == 0, then
 // default limits are used for results.  WithWorkerPool can be provided with a
 // non-zero length slice of worker ids to restrict the returned workers to only
-// ones with the ids provided.
+// ones with the ids provided. Workers are ordered by create time and public id.
 func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...Option) ([]*Worker, error) {
 	const op = "server.(Repository).ListWorkers"
 
@@ -198,19 +198,20 @@ func (r *Repository) ListWorkers(ctx context.Context, scopeIds []string, opt ...
 	newOpts = append(newOpts, WithWorkerType(opts.withWorkerType))
 	newOpts = append(newOpts, WithActiveWorkers(opts.withActiveWorkers))
 	newOpts = append(newOpts, WithWorkerPool(opts.withWorkerPool))
+	newOpts = append(newOpts, WithStartPageAfterItem(opts.withStartPageAfterItem))
 	return ListWorkers(ctx, r.reader, scopeIds, newOpts...)
 }
 
 // ListWorkers will return a listing of Workers and honor the WithLimit option.
 // Supported options: WithWorkerType, WithActiveWorkers, WithLiveness,
-// WithWorkerPool, WithLimit
+// WithWorkerPool, WithLimit, WithStartPageAfterItem
 //
 // If WithLiveness is zero the default liveness value is used, if it is negative
 // then the last status update time is ignored.
 // If WithLimit < 0, then unlimited results are returned. If WithLimit == 0, then
 // default limits are used for results.  WithWorkerPool can be provided with a
 // non-zero length slice of worker ids to restrict the returned workers to only
-// ones with the ids provided.
+// ones with the ids provided. Workers are ordered by create time and public id.
 func ListWorkers(ctx context.Context, reader db.Reader, scopeIds []string, opt ...Option) ([]*Worker, error) {
 	const op = "server.ListWorkers"
 	switch {
@@ -253,6 +254,11 @@ func ListWorkers(ctx context.Context, reader db.Reader, scopeIds []string, opt .
 		whereArgs = append(whereArgs, opts.withWorkerPool)
 	}
 
+	if opts.withStartPageAfterItem != nil {
+		where = append(where, "(create_time, public_id) > (?, ?)")
+		whereArgs = append(whereArgs, opts.withStartPageAfterItem.GetCreateTime(), opts.withStartPageAfterItem.GetPublicId())
+	}
+
 	limit := db.DefaultLimit
 	if opts.withLimit != 0 {
 		// non-zero signals an override of the default limit for the repo.
@@ -266,6 +272,7 @@ func ListWorkers(ctx context.Context, reader db.Reader, scopeIds []string, opt .
 		strings.Join(where, " and "),
 		whereArgs,
 		db.WithLimit(limit),
+		db.WithOrder("create_time asc, public_id asc"),
 	); err != nil {
 		return nil, errors.Wrap(ctx, err, op, errors.WithMsg("error searching for workers"))
 	}
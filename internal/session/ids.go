@@ -20,6 +20,9 @@ const (
 
 	// ConnectionStatePrefix for connection state PK ids
 	ConnectionStatePrefix = "scs"
+
+	// WatchPrefix for watch PK ids
+	WatchPrefix = "sw"
 )
 
 func newId(ctx context.Context) (string, error) {
@@ -57,3 +60,12 @@ func newConnectionStateId(ctx context.Context) (string, error) {
 	}
 	return id, nil
 }
+
+func newWatchId(ctx context.Context) (string, error) {
+	const op = "session.newWatchId"
+	id, err := db.NewPublicId(ctx, WatchPrefix)
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op)
+	}
+	return id, nil
+}
@@ -36,4 +36,9 @@ func Test_Ids(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, ConnectionStatePrefix+"_"))
 	})
+	t.Run("sw", func(t *testing.T) {
+		id, err := newWatchId(ctx)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, WatchPrefix+"_"))
+	})
 }
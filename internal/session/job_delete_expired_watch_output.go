@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler"
+)
+
+type deleteExpiredWatchOutputJob struct {
+	repo *Repository
+
+	// the amount of time that the output of a watched session is kept
+	// before it is deleted.
+	threshold time.Duration
+
+	// the number of output rows deleted in the most recent run
+	deletedInRun int
+}
+
+func newDeleteExpiredWatchOutputJob(ctx context.Context, repo *Repository, threshold time.Duration) (*deleteExpiredWatchOutputJob, error) {
+	const op = "session.newDeleteExpiredWatchOutputJob"
+	switch {
+	case repo == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing repository")
+	}
+
+	return &deleteExpiredWatchOutputJob{
+		repo:      repo,
+		threshold: threshold,
+	}, nil
+}
+
+// Status reports the job’s current status.  The status is periodically persisted by
+// the scheduler when a job is running, and will be used to verify a job is making progress.
+func (d *deleteExpiredWatchOutputJob) Status() scheduler.JobStatus {
+	return scheduler.JobStatus{
+		Completed: d.deletedInRun,
+		Total:     d.deletedInRun,
+	}
+}
+
+// Run performs the required work depending on the implementation.
+// The context is used to notify the job that it should exit early.
+func (d *deleteExpiredWatchOutputJob) Run(ctx context.Context) error {
+	const op = "session.(deleteExpiredWatchOutputJob).Run"
+	d.deletedInRun = 0
+	var err error
+
+	d.deletedInRun, err = d.repo.deleteWatchOutputCreatedBefore(ctx, d.threshold)
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// NextRunIn returns the duration until the next job run should be scheduled.
+// The output of watched sessions is deleted often since it is only needed
+// until the watches have listed it.
+func (d *deleteExpiredWatchOutputJob) NextRunIn(_ context.Context) (time.Duration, error) {
+	return d.threshold, nil
+}
+
+// Name is the unique name of the job.
+func (d *deleteExpiredWatchOutputJob) Name() string {
+	return "delete_expired_session_watch_output"
+}
+
+// Description is the human readable description of the job.
+func (d *deleteExpiredWatchOutputJob) Description() string {
+	return "Delete the output of watched sessions once it has expired"
+}
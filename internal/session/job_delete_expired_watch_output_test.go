@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteExpiredWatchOutputJob(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	connRepo, err := NewConnectionRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+	worker := server.TestKmsWorker(t, conn, wrapper)
+
+	s := TestDefaultSession(t, conn, wrapper, iamRepo)
+	s, _, err = repo.ActivateSession(ctx, s.PublicId, s.Version, TestTofu(t))
+	require.NoError(t, err)
+	_, _, _, err = AuthorizeConnection(ctx, repo, connRepo, s.PublicId, worker.PublicId)
+	require.NoError(t, err)
+	w, err := repo.CreateWatch(ctx, s.PublicId, s.UserId)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddWatchOutput(ctx, s.PublicId, worker.PublicId, []byte("expired")))
+	_, err = rw.Exec(ctx, "update session_watch_output set create_time = now() - interval '2 minutes' where session_id = ?", []any{s.PublicId})
+	require.NoError(t, err)
+	require.NoError(t, repo.AddWatchOutput(ctx, s.PublicId, worker.PublicId, []byte("current")))
+
+	job, err := newDeleteExpiredWatchOutputJob(ctx, repo, watchOutputExpiration)
+	require.NoError(t, err)
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, job.deletedInRun)
+
+	output, err := repo.ListWatchOutput(ctx, w.PublicId, 0)
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, []byte("current"), output[0].Data)
+
+	// running the job again deletes nothing
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 0, job.deletedInRun)
+}
@@ -15,7 +15,13 @@ import (
 	"github.com/hashicorp/boundary/internal/scheduler"
 )
 
-const deleteTerminatedThreshold = time.Hour
+const (
+	deleteTerminatedThreshold = time.Hour
+
+	// watchOutputExpiration is how long the output of watched sessions is
+	// kept, which gives the watches plenty of time to list it.
+	watchOutputExpiration = time.Minute
+)
 
 // RegisterJobs registers session related jobs with the provided scheduler.
 func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, w db.Writer, r db.Reader, k *kms.Kms, gracePeriod *atomic.Int64) error {
@@ -45,5 +51,13 @@ func RegisterJobs(ctx context.Context, scheduler *scheduler.Scheduler, w db.Writ
 		return fmt.Errorf("error registering delete terminated session job: %w", err)
 	}
 
+	deleteExpiredWatchOutputJob, err := newDeleteExpiredWatchOutputJob(ctx, repo, watchOutputExpiration)
+	if err != nil {
+		return fmt.Errorf("error creating delete expired session watch output job: %w", err)
+	}
+	if err = scheduler.RegisterJob(ctx, deleteExpiredWatchOutputJob); err != nil {
+		return fmt.Errorf("error registering delete expired session watch output job: %w", err)
+	}
+
 	return nil
 }
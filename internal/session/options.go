@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 )
 
@@ -40,6 +41,9 @@ type options struct {
 	withPermissions              *perms.UserPermissions
 	withIgnoreDecryptionFailures bool
 	withRandomReader             io.Reader
+	withStartPageAfterItem       pagination.Item
+	withUpdatedAfter             time.Time
+	withTx                       TxFunc
 }
 
 func getDefaultOptions() options {
@@ -149,3 +153,33 @@ func WithRandomReader(rand io.Reader) Option {
 		o.withRandomReader = rand
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.withStartPageAfterItem = item
+	}
+}
+
+// WithUpdatedAfter is used to only list sessions updated after the provided
+// time.
+func WithUpdatedAfter(t time.Time) Option {
+	return func(o *options) {
+		o.withUpdatedAfter = t
+	}
+}
+
+// TxFunc is a function which makes changes in an inflight transaction.
+type TxFunc func(db.Reader, db.Writer) error
+
+// WithTx provides a function which is run in the same transaction as the
+// changes made by the repository, after those changes. If the function
+// returns an error, the transaction is rolled back. It allows the caller to
+// make changes owned by other repositories atomically with the changes of
+// this repository.
+func WithTx(fn TxFunc) Option {
+	return func(o *options) {
+		o.withTx = fn
+	}
+}
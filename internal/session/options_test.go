@@ -6,6 +6,7 @@ package session
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
@@ -94,4 +95,19 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withRandomReader = reader
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithUpdatedAfter", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := getOpts(WithUpdatedAfter(now))
+		testOpts := getDefaultOptions()
+		testOpts.withUpdatedAfter = now
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithTx", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getDefaultOptions()
+		assert.Nil(opts.withTx)
+		opts = getOpts(WithTx(func(db.Reader, db.Writer) error { return nil }))
+		assert.NotNil(opts.withTx)
+	})
 }
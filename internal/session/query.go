@@ -180,6 +180,16 @@ where
           where
             us.public_id = cs.session_id
           ) then 'canceled' 
+        -- sessions with a connection closed for being idle
+        when us.public_id in (
+          select
+            session_id
+          from
+            session_connection sc
+          where
+            sc.session_id = us.public_id and
+            sc.closed_reason = 'idle timeout'
+          ) then 'idle timeout'
         -- default: session connection limit reached.
         else 'connection limit'
       end
@@ -211,6 +221,16 @@ where
             canceling_session cs
           where 
             us.public_id = cs.session_id 
+        ) or
+        -- idle sessions
+        us.public_id in (
+          select
+            session_id
+          from
+            session_connection sc
+          where
+            sc.session_id = us.public_id and
+            sc.closed_reason = 'idle timeout'
         )
       ) and 
       -- make sure there are no existing connections
@@ -236,6 +256,7 @@ where
 	//	* sessions that are expired and all their connections are closed.
 	// 	* sessions that are canceling and all their connections are closed
 	//  * sessions that have exhausted their connection limit and all their connections are closed.
+	//  * sessions that had a connection closed for being idle and all their connections are closed.
 	termSessionsUpdate = `
 with canceling_session(session_id) as
 (
@@ -261,6 +282,16 @@ update session us
 			where
 				us.public_id = cs.session_id
 			) then 'canceled'
+		-- sessions with a connection closed for being idle
+		when us.public_id in (
+			select
+				session_id
+			from
+				session_connection sc
+			where
+				sc.session_id = us.public_id and
+				sc.closed_reason = 'idle timeout'
+			) then 'idle timeout'
 		-- default: session connection limit reached.
 		else 'connection limit'
 	end
@@ -289,6 +320,16 @@ where
 				canceling_session cs
 			where
 				us.public_id = cs.session_id
+		) or
+		-- idle sessions
+		us.public_id in (
+			select
+				session_id
+			from
+				session_connection sc
+			where
+				sc.session_id = us.public_id and
+				sc.closed_reason = 'idle timeout'
 		)
 	) and
 	-- make sure there are no existing connections
@@ -403,6 +444,41 @@ and
 and
 	session_state.start_time < wt_sub_seconds_from_now(@threshold_seconds)
 ;
+`
+	deletedSessionIds = `
+select public_id
+  from session_deleted
+ where delete_time > @since
+;
+`
+	// cancelUserSessions sets the state of every session of a user, which is
+	// neither canceling nor terminated, to canceling.
+	cancelUserSessions = `
+insert into session_state (session_id, state)
+select s.public_id, 'canceling'
+  from session s
+ where s.user_id = @user_id
+   and s.public_id not in (select session_id
+                             from session_state
+                            where state in ('canceling', 'terminated'))
+returning session_id;
+`
+	// cancelAuthMethodSessions sets the state of every session created with an
+	// auth token issued by an auth method, which is neither canceling nor
+	// terminated, to canceling.
+	cancelAuthMethodSessions = `
+insert into session_state (session_id, state)
+select s.public_id, 'canceling'
+  from session s
+  join auth_token at
+    on at.public_id = s.auth_token_id
+  join auth_account aa
+    on aa.public_id = at.auth_account_id
+ where aa.auth_method_id = @auth_method_id
+   and s.public_id not in (select session_id
+                             from session_state
+                            where state in ('canceling', 'terminated'))
+returning session_id;
 `
 	sessionCredentialRewrapQuery = `
 select distinct
@@ -423,6 +499,95 @@ update session_credential
 where session_id = ?
 	and credential_sha256 = ?;
 `
+
+	createWatchQuery = `
+insert into session_watch
+  (public_id, session_id, user_id)
+select @public_id, @session_id, @user_id
+ where exists (select 1
+                 from session_state
+                where session_id = @session_id
+                  and state in ('pending', 'active')
+                  and end_time is null)
+returning create_time, update_time;
+`
+	lookupWatchQuery = `
+select public_id, session_id, user_id, create_time, update_time
+  from session_watch
+ where public_id = @public_id;
+`
+	touchWatchQuery = `
+update session_watch
+   set update_time = now()
+ where public_id = @public_id;
+`
+	listWatchOutputQuery = `
+select o.position, o.key_id, o.data, s.project_id
+  from session_watch_output o
+  join session s
+    on s.public_id = o.session_id
+ where o.session_id = (select session_id from session_watch where public_id = @public_id)
+   and o.position > @position
+ order by o.position
+ limit @limit;
+`
+	// watchedSessionProjectIdQuery only returns the project id of a session
+	// if it is being watched, since the worker may forward output for a short
+	// time after the last watch of the session stopped polling, and only if
+	// the worker proxies a connection of the session.
+	watchedSessionProjectIdQuery = `
+select project_id
+  from session
+ where public_id = @session_id
+   and exists (select 1
+                 from session_watch
+                where session_id = @session_id
+                  and update_time > now() - interval '30 seconds')
+   and exists (select 1
+                 from session_connection
+                where session_id = @session_id
+                  and worker_id = @worker_id);
+`
+	addWatchOutputQuery = `
+insert into session_watch_output
+  (session_id, data, key_id)
+values
+  (@session_id, @data, @key_id);
+`
+	deleteExpiredWatchOutputQuery = `
+delete from session_watch_output
+ where create_time < wt_sub_seconds_from_now(@threshold_seconds);
+`
+	sessionWatchOutputRewrapQuery = `
+select o.position, o.key_id, o.data
+  from session s
+  join session_watch_output o
+    on o.session_id = s.public_id
+ where s.project_id = ?
+   and o.key_id = ?;
+`
+	sessionWatchOutputRewrapUpdate = `
+update session_watch_output
+   set data = ?,
+       key_id = ?
+ where position = ?;
+`
+	listWatchedSessionIdsQuery = `
+select distinct session_id
+  from session_watch
+ where session_id in (@session_ids)
+   and update_time > now() - interval '30 seconds';
+`
+
+	// sessionTargetHostKeysQuery selects the host keys of the ssh target of
+	// a session.
+	sessionTargetHostKeysQuery = `
+select ts.host_keys
+  from session s
+  join target_ssh ts
+    on ts.public_id = s.target_id
+ where s.public_id = @session_id;
+`
 )
 
 const (
@@ -276,7 +276,7 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, opt ..
 
 // ListSessions lists sessions. Sessions returned will be limited by the list
 // permissions of the repository. Supports the WithTerminated, WithLimit,
-// WithOrderByCreateTime options.
+// WithOrderByCreateTime and WithStartPageAfterItem options.
 func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Session, error) {
 	const op = "session.(Repository).ListSessions"
 	opts := getOpts(opt...)
@@ -297,6 +297,19 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 			whereClause = "where termination_reason is null"
 		}
 	}
+	if opts.withStartPageAfterItem != nil {
+		whereClause += " and (create_time, public_id) > (@last_item_create_time, @last_item_id)"
+		args = append(args,
+			sql.Named("last_item_create_time", opts.withStartPageAfterItem.GetCreateTime()),
+			sql.Named("last_item_id", opts.withStartPageAfterItem.GetPublicId()),
+		)
+	}
+	if !opts.withUpdatedAfter.IsZero() {
+		// State changes are recorded in session_state and don't update the
+		// session, so they need to be checked separately.
+		whereClause += " and (update_time > @updated_after or public_id in (select session_id from session_state where start_time > @updated_after))"
+		args = append(args, sql.Named("updated_after", timestamp.New(opts.withUpdatedAfter)))
+	}
 
 	var limit string
 	switch {
@@ -310,11 +323,11 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	var withOrder string
 	switch opts.withOrderByCreateTime {
 	case db.AscendingOrderBy:
-		withOrder = "order by create_time asc"
+		withOrder = "order by create_time asc, public_id asc"
 	case db.DescendingOrderBy:
 		fallthrough
 	default:
-		withOrder = "order by create_time"
+		withOrder = "order by create_time, public_id"
 	}
 
 	q := sessionList
@@ -341,6 +354,29 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 	return sessions, nil
 }
 
+// ListDeletedIds lists the public ids of sessions deleted after the provided
+// time. It is used to report removed sessions when refreshing a list.
+func (r *Repository) ListDeletedIds(ctx context.Context, since time.Time) ([]string, error) {
+	const op = "session.(Repository).ListDeletedIds"
+	rows, err := r.reader.Query(ctx, deletedSessionIds, []any{sql.Named("since", timestamp.New(since))})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // DeleteSession will delete a session from the repository.
 func (r *Repository) DeleteSession(ctx context.Context, publicId string, _ ...Option) (int, error) {
 	const op = "session.(Repository).DeleteSession"
@@ -404,6 +440,92 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// CancelUserSessions sets the state of every session of userId, which is not
+// already canceling or terminated, to "canceling" and returns the ids of the
+// canceled sessions.
+// Supported Options:
+//   - WithTx
+func (r *Repository) CancelUserSessions(ctx context.Context, userId string, opt ...Option) ([]string, error) {
+	const op = "session.(Repository).CancelUserSessions"
+	if userId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	ids, err := r.cancelSessions(ctx, cancelUserSessions, []any{sql.Named("user_id", userId)}, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
+// CancelAuthMethodSessions sets the state of every session created with an
+// auth token issued by authMethodId, which is not already canceling or
+// terminated, to "canceling" and returns the ids of the canceled sessions.
+// Supported Options:
+//   - WithTx
+func (r *Repository) CancelAuthMethodSessions(ctx context.Context, authMethodId string, opt ...Option) ([]string, error) {
+	const op = "session.(Repository).CancelAuthMethodSessions"
+	if authMethodId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing auth method id")
+	}
+	ids, err := r.cancelSessions(ctx, cancelAuthMethodSessions, []any{sql.Named("auth_method_id", authMethodId)}, opt...)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
+// cancelSessions cancels sessions with query and then runs the function
+// passed with WithTx in the same transaction.
+func (r *Repository) cancelSessions(ctx context.Context, query string, args []any, opt ...Option) ([]string, error) {
+	const op = "session.(Repository).cancelSessions"
+	opts := getOpts(opt...)
+	var ids []string
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			ids, err = queryIds(ctx, w, query, args...)
+			if err != nil {
+				return errors.Wrap(ctx, err, op, errors.WithMsg("unable to cancel sessions"))
+			}
+			if opts.withTx != nil {
+				if err := opts.withTx(reader, w); err != nil {
+					return errors.Wrap(ctx, err, op)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
+// queryIds runs query with w and returns the ids of the returned rows.
+func queryIds(ctx context.Context, w db.Writer, query string, args ...any) ([]string, error) {
+	const op = "session.queryIds"
+	rows, err := w.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 // TerminateCompletedSessions will terminate sessions in the repo based on:
 //   - sessions that have exhausted their connection limit and all their connections are closed.
 //   - sessions that are expired and all their connections are closed.
@@ -885,3 +1007,34 @@ func decryptAndMaybeUpdateSession(ctx context.Context, kmsRepo kms.GetWrapperer,
 	}
 	return nil
 }
+
+// LookupTargetHostKeys returns the host keys of the ssh target of the session
+// sessionId in the OpenSSH authorized_keys format. An empty string is
+// returned if the target has no host keys. A RecordNotFound error is returned
+// if the session does not exist or its target is not an ssh target.
+func (r *Repository) LookupTargetHostKeys(ctx context.Context, sessionId string, _ ...Option) (string, error) {
+	const op = "session.(Repository).LookupTargetHostKeys"
+	if sessionId == "" {
+		return "", errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	}
+	rows, err := r.reader.Query(ctx, sessionTargetHostKeysQuery, []any{sql.Named("session_id", sessionId)})
+	if err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up target host keys"))
+	}
+	defer rows.Close()
+	var hostKeys sql.NullString
+	found := false
+	for rows.Next() {
+		if err := rows.Scan(&hostKeys); err != nil {
+			return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to scan target host keys"))
+		}
+		found = true
+	}
+	if err := rows.Err(); err != nil {
+		return "", errors.Wrap(ctx, err, op, errors.WithMsg("unable to look up target host keys"))
+	}
+	if !found {
+		return "", errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("ssh target of session %s not found", sessionId))
+	}
+	return hostKeys.String, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/server"
 	"github.com/hashicorp/boundary/internal/target"
+	targetssh "github.com/hashicorp/boundary/internal/target/ssh"
 	"github.com/hashicorp/boundary/internal/target/tcp"
 	tcpStore "github.com/hashicorp/boundary/internal/target/tcp/store"
 	"github.com/hashicorp/boundary/internal/types/action"
@@ -923,6 +924,37 @@ func TestRepository_TerminateCompletedSessions(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "sessions-with-idle-connections",
+			setup: func(t testing.TB) testArgs {
+				cnt := 5
+				wantTermed := map[string]TerminationReason{}
+				sessions := make([]*Session, 0, 5)
+				for i := 0; i < cnt; i++ {
+					// make one with unlimited connections and a connection
+					// closed for being idle
+					s := setupFn(t, -1, time.Hour+1, true)
+					conns, err := connRepo.ListConnectionsBySessionId(context.Background(), s.PublicId)
+					require.NoError(t, err)
+					require.Len(t, conns, 1)
+					_, err = connRepo.closeConnections(context.Background(), []CloseWith{{
+						ConnectionId: conns[0].PublicId,
+						BytesUp:      1,
+						BytesDown:    1,
+						ClosedReason: ConnectionIdleTimeout,
+					}})
+					require.NoError(t, err)
+					// make one with unlimited connections closed by the user
+					s2 := setupFn(t, -1, time.Hour+1, false)
+					sessions = append(sessions, s, s2)
+					wantTermed[s.PublicId] = IdleTimeout
+				}
+				return testArgs{
+					sessions:   sessions,
+					wantTermed: wantTermed,
+				}
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1156,6 +1188,134 @@ func TestRepository_CancelSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CancelUserSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	authTokenRepo, err := authtoken.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	t.Run("missing-user-id", func(t *testing.T) {
+		got, err := repo.CancelUserSessions(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("cancel", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		other := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+		got, err := repo.CancelUserSessions(ctx, s.UserId)
+		require.NoError(err)
+		assert.Equal([]string{s.PublicId}, got)
+
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusCanceling, found.States[0].Status)
+		found, _, err = repo.LookupSession(ctx, other.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusPending, found.States[0].Status)
+
+		// canceling again is a no-op
+		got, err = repo.CancelUserSessions(ctx, s.UserId)
+		require.NoError(err)
+		assert.Empty(got)
+	})
+	t.Run("with-tx", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+		var gotTokens []string
+		got, err := repo.CancelUserSessions(ctx, s.UserId, WithTx(func(r db.Reader, w db.Writer) error {
+			var err error
+			gotTokens, err = authTokenRepo.DeleteUserAuthTokens(ctx, s.UserId, authtoken.WithReaderWriter(r, w))
+			return err
+		}))
+		require.NoError(err)
+		assert.Equal([]string{s.PublicId}, got)
+		assert.Equal([]string{s.AuthTokenId}, gotTokens)
+
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusCanceling, found.States[0].Status)
+		at, err := authTokenRepo.LookupAuthToken(ctx, s.AuthTokenId)
+		require.NoError(err)
+		assert.Nil(at)
+	})
+	t.Run("with-tx-error", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+		got, err := repo.CancelUserSessions(ctx, s.UserId, WithTx(func(db.Reader, db.Writer) error {
+			return errors.New(ctx, errors.Internal, "test", "failed")
+		}))
+		require.Error(err)
+		assert.Nil(got)
+
+		// the sessions are not canceled when the function fails
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusPending, found.States[0].Status)
+	})
+}
+
+func TestRepository_CancelAuthMethodSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	authTokenRepo, err := authtoken.NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	t.Run("missing-auth-method-id", func(t *testing.T) {
+		got, err := repo.CancelAuthMethodSessions(ctx, "")
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(t, got)
+	})
+	t.Run("cancel", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		other := TestDefaultSession(t, conn, wrapper, iamRepo)
+		at, err := authTokenRepo.LookupAuthToken(ctx, s.AuthTokenId)
+		require.NoError(err)
+
+		var gotTokens []string
+		got, err := repo.CancelAuthMethodSessions(ctx, at.GetAuthMethodId(), WithTx(func(r db.Reader, w db.Writer) error {
+			var err error
+			gotTokens, err = authTokenRepo.DeleteAuthMethodAuthTokens(ctx, at.GetAuthMethodId(), authtoken.WithReaderWriter(r, w))
+			return err
+		}))
+		require.NoError(err)
+		assert.Equal([]string{s.PublicId}, got)
+		assert.Equal([]string{s.AuthTokenId}, gotTokens)
+
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusCanceling, found.States[0].Status)
+		at, err = authTokenRepo.LookupAuthToken(ctx, s.AuthTokenId)
+		require.NoError(err)
+		assert.Nil(at)
+
+		found, _, err = repo.LookupSession(ctx, other.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusPending, found.States[0].Status)
+		at, err = authTokenRepo.LookupAuthToken(ctx, other.AuthTokenId)
+		require.NoError(err)
+		assert.NotNil(at)
+	})
+}
+
 func TestRepository_CancelSessionViaFKNull(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1583,6 +1743,83 @@ func testSessionCredentialParams(t *testing.T, conn *db.DB, wrapper wrapping.Wra
 	return params
 }
 
+func TestRepository_ListDeletedIds(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+	repo, err := NewRepository(ctx, rw, rw, kms)
+	require.NoError(t, err)
+
+	s1 := TestDefaultSession(t, conn, wrapper, iamRepo)
+	s2 := TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	// Use the database clock to avoid issues with clock skew.
+	var before time.Time
+	rows, err := rw.Query(ctx, "select now()", nil)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&before))
+	require.NoError(t, rows.Close())
+
+	ids, err := repo.ListDeletedIds(ctx, before.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.NotContains(t, ids, s1.PublicId)
+
+	_, err = repo.DeleteSession(ctx, s1.PublicId)
+	require.NoError(t, err)
+
+	ids, err = repo.ListDeletedIds(ctx, before.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Contains(t, ids, s1.PublicId)
+	assert.NotContains(t, ids, s2.PublicId)
+
+	ids, err = repo.ListDeletedIds(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestRepository_ListSessions_WithUpdatedAfter(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	ctx := context.Background()
+
+	s := TestDefaultSession(t, conn, wrapper, iamRepo)
+	listPerms := &perms.UserPermissions{
+		UserId: s.UserId,
+		Permissions: []perms.Permission{
+			{
+				ScopeId:  s.ProjectId,
+				Resource: resource.Session,
+				Action:   action.List,
+			},
+		},
+	}
+	repo, err := NewRepository(ctx, rw, rw, kms, WithPermissions(listPerms))
+	require.NoError(t, err)
+
+	updatedAfter := s.UpdateTime.AsTime()
+	got, err := repo.ListSessions(ctx, WithUpdatedAfter(updatedAfter))
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// A state transition must be included even though it doesn't update
+	// the session itself.
+	_, err = repo.CancelSession(ctx, s.PublicId, s.Version)
+	require.NoError(t, err)
+	got, err = repo.ListSessions(ctx, WithUpdatedAfter(updatedAfter))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, s.PublicId, got[0].PublicId)
+}
+
 func TestRepository_deleteTargetFKey(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -1854,3 +2091,47 @@ func TestRepository_CheckIfNoLongerActive(t *testing.T) {
 	}
 	assert.ElementsMatch(t, gotIds, []string{unrecognizedSessionId, terminatedSession.PublicId, cancelingSess.PublicId})
 }
+
+func TestRepository_LookupTargetHostKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+
+	const hostKeys = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII4/60BKOPLxmKVsPQngvO7sS2NP34EemdH4CKFvTPzU"
+	testSshSession := func(t *testing.T, opt ...target.Option) *Session {
+		t.Helper()
+		sess := TestDefaultSession(t, conn, wrapper, iamRepo)
+		tar := targetssh.TestTarget(ctx, t, conn, sess.ProjectId, "ssh target "+sess.PublicId, opt...)
+		_, err := rw.Exec(ctx, "update session set target_id = ? where public_id = ?", []any{tar.GetPublicId(), sess.PublicId})
+		require.NoError(t, err)
+		return sess
+	}
+
+	t.Run("host-keys", func(t *testing.T) {
+		sess := testSshSession(t, target.WithHostKeys(hostKeys))
+		got, err := repo.LookupTargetHostKeys(ctx, sess.PublicId)
+		require.NoError(t, err)
+		assert.Equal(t, hostKeys, got)
+	})
+	t.Run("no-host-keys", func(t *testing.T) {
+		sess := testSshSession(t)
+		got, err := repo.LookupTargetHostKeys(ctx, sess.PublicId)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+	t.Run("tcp-target", func(t *testing.T) {
+		sess := TestDefaultSession(t, conn, wrapper, iamRepo)
+		_, err := repo.LookupTargetHostKeys(ctx, sess.PublicId)
+		assert.True(t, errors.IsNotFoundError(err))
+	})
+	t.Run("missing-session-id", func(t *testing.T) {
+		_, err := repo.LookupTargetHostKeys(ctx, "")
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
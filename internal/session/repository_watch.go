@@ -0,0 +1,305 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/hashicorp/go-kms-wrapping/v2/extras/structwrapping"
+)
+
+// Watch is a user watching the live output of a session.
+type Watch struct {
+	PublicId   string
+	SessionId  string
+	UserId     string
+	CreateTime time.Time
+	// UpdateTime is the last time the output of the session was polled by
+	// the watch.
+	UpdateTime time.Time
+}
+
+// WatchOutput is output of a watched session forwarded by a worker.
+type WatchOutput struct {
+	// Position is the position of the output in the stream of output of
+	// watched sessions, and increases with each output added.
+	Position uint64
+	// Data contains a sequence of BSR data chunks.
+	Data []byte
+}
+
+// watchOutput is the stored form of WatchOutput. The output is encrypted with
+// the sessions key of the project of the session.
+type watchOutput struct {
+	Position uint64
+	KeyId    string
+	Data     []byte `wrapping:"pt,data"`
+	CtData   []byte `wrapping:"ct,data"`
+}
+
+func (o *watchOutput) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(watchOutput).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, o, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Encrypt))
+	}
+	var err error
+	o.KeyId, err = cipher.KeyId(ctx)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to discover wrapper key id"))
+	}
+	return nil
+}
+
+func (o *watchOutput) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "session.(watchOutput).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, o, nil); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+// CreateWatch creates a watch of the output of a session by a user. Only
+// pending and active sessions can be watched.
+func (r *Repository) CreateWatch(ctx context.Context, sessionId, userId string, _ ...Option) (*Watch, error) {
+	const op = "session.(Repository).CreateWatch"
+	switch {
+	case sessionId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case userId == "":
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing user id")
+	}
+	id, err := newWatchId(ctx)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	w := &Watch{
+		PublicId:  id,
+		SessionId: sessionId,
+		UserId:    userId,
+	}
+	rows, err := r.writer.Query(ctx, createWatchQuery, []any{
+		sql.Named("public_id", id),
+		sql.Named("session_id", sessionId),
+		sql.Named("user_id", userId),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var created bool
+	for rows.Next() {
+		if err := rows.Scan(&w.CreateTime, &w.UpdateTime); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		created = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if !created {
+		return nil, errors.New(ctx, errors.InvalidSessionState, op, fmt.Sprintf("session %s is not pending or active", sessionId))
+	}
+	return w, nil
+}
+
+// LookupWatch returns the watch for the id. Returns nil, nil if no watch is
+// found.
+func (r *Repository) LookupWatch(ctx context.Context, watchId string, _ ...Option) (*Watch, error) {
+	const op = "session.(Repository).LookupWatch"
+	if watchId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing watch id")
+	}
+	rows, err := r.reader.Query(ctx, lookupWatchQuery, []any{sql.Named("public_id", watchId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var w *Watch
+	for rows.Next() {
+		w = &Watch{}
+		if err := rows.Scan(&w.PublicId, &w.SessionId, &w.UserId, &w.CreateTime, &w.UpdateTime); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return w, nil
+}
+
+// ListWatchOutput returns the output of the session of the watch after the
+// position, ordered by position. Listing the output keeps the watch active,
+// and workers stop forwarding the output of a session once none of its
+// watches have listed its output for a while. Supports the WithLimit option.
+func (r *Repository) ListWatchOutput(ctx context.Context, watchId string, afterPosition uint64, opt ...Option) ([]*WatchOutput, error) {
+	const op = "session.(Repository).ListWatchOutput"
+	if watchId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing watch id")
+	}
+	opts := getOpts(opt...)
+	var limit any = r.defaultLimit
+	switch {
+	case opts.withLimit < 0:
+		// a negative limit signals that all output should be returned.
+		limit = nil
+	case opts.withLimit > 0:
+		limit = opts.withLimit
+	}
+
+	n, err := r.writer.Exec(ctx, touchWatchQuery, []any{sql.Named("public_id", watchId)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	if n == 0 {
+		return nil, errors.New(ctx, errors.RecordNotFound, op, fmt.Sprintf("watch %s not found", watchId))
+	}
+
+	rows, err := r.reader.Query(ctx, listWatchOutputQuery, []any{
+		sql.Named("public_id", watchId),
+		sql.Named("position", afterPosition),
+		sql.Named("limit", limit),
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var projectId string
+	var stored []*watchOutput
+	for rows.Next() {
+		var o watchOutput
+		if err := rows.Scan(&o.Position, &o.KeyId, &o.CtData, &projectId); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		stored = append(stored, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+
+	// The output of a session is usually encrypted with a single key
+	// version, so the wrappers are only fetched once per key version.
+	wrappers := make(map[string]wrapping.Wrapper)
+	output := make([]*WatchOutput, 0, len(stored))
+	for _, o := range stored {
+		wrapper, ok := wrappers[o.KeyId]
+		if !ok {
+			wrapper, err = r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeSessions, kms.WithKeyId(o.KeyId))
+			if err != nil {
+				return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get sessions wrapper"))
+			}
+			wrappers[o.KeyId] = wrapper
+		}
+		if err := o.decrypt(ctx, wrapper); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to decrypt output"))
+		}
+		output = append(output, &WatchOutput{
+			Position: o.Position,
+			Data:     o.Data,
+		})
+	}
+	return output, nil
+}
+
+// AddWatchOutput adds output forwarded by a worker to the output of a
+// session. The output is dropped if the session is not being watched, or if
+// the worker does not proxy a connection of the session. The output is
+// encrypted with the sessions key of the project of the session, and is
+// deleted by a scheduled job after a minute, which gives the watches plenty
+// of time to list it.
+func (r *Repository) AddWatchOutput(ctx context.Context, sessionId, workerId string, data []byte, _ ...Option) error {
+	const op = "session.(Repository).AddWatchOutput"
+	switch {
+	case sessionId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing session id")
+	case workerId == "":
+		return errors.New(ctx, errors.InvalidParameter, op, "missing worker id")
+	case len(data) == 0:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing data")
+	}
+
+	rows, err := r.reader.Query(ctx, watchedSessionProjectIdQuery, []any{
+		sql.Named("session_id", sessionId),
+		sql.Named("worker_id", workerId),
+	})
+	if err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var projectId string
+	for rows.Next() {
+		if err := rows.Scan(&projectId); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if projectId == "" {
+		return nil
+	}
+
+	sessionWrapper, err := r.kms.GetWrapper(ctx, projectId, kms.KeyPurposeSessions)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to get sessions wrapper"))
+	}
+	o := &watchOutput{
+		Data: data,
+	}
+	if err := o.encrypt(ctx, sessionWrapper); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("unable to encrypt output"))
+	}
+	if _, err := r.writer.Exec(ctx, addWatchOutputQuery, []any{
+		sql.Named("session_id", sessionId),
+		sql.Named("data", o.CtData),
+		sql.Named("key_id", o.KeyId),
+	}); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	return nil
+}
+
+// deleteWatchOutputCreatedBefore deletes the output of watched sessions which
+// was added more than threshold ago, and returns the number of deleted rows.
+func (r *Repository) deleteWatchOutputCreatedBefore(ctx context.Context, threshold time.Duration) (int, error) {
+	const op = "session.(Repository).deleteWatchOutputCreatedBefore"
+	n, err := r.writer.Exec(ctx, deleteExpiredWatchOutputQuery, []any{
+		sql.Named("threshold_seconds", threshold.Seconds()),
+	})
+	if err != nil {
+		return 0, errors.Wrap(ctx, err, op, errors.WithMsg("error deleting expired output"))
+	}
+	return n, nil
+}
+
+// ListWatchedSessionIds returns the ids of the provided sessions which are
+// being watched.
+func (r *Repository) ListWatchedSessionIds(ctx context.Context, sessionIds []string, _ ...Option) ([]string, error) {
+	const op = "session.(Repository).ListWatchedSessionIds"
+	if len(sessionIds) == 0 {
+		return nil, nil
+	}
+	rows, err := r.reader.Query(ctx, listWatchedSessionIdsQuery, []any{sql.Named("session_ids", sessionIds)})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
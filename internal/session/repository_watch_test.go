@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Watch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	connRepo, err := NewConnectionRepository(ctx, rw, rw, testKms)
+	require.NoError(t, err)
+	worker := server.TestKmsWorker(t, conn, wrapper)
+	otherWorker := server.TestKmsWorker(t, conn, wrapper)
+	// activeSession returns an active session with a connection proxied by
+	// the worker
+	activeSession := func(t *testing.T) *Session {
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		s, _, err := repo.ActivateSession(ctx, s.PublicId, s.Version, TestTofu(t))
+		require.NoError(t, err)
+		_, _, _, err = AuthorizeConnection(ctx, repo, connRepo, s.PublicId, worker.PublicId)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("missing-params", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.CreateWatch(ctx, "", "u_1234567890")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		_, err = repo.CreateWatch(ctx, "s_1234567890", "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		_, err = repo.LookupWatch(ctx, "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		_, err = repo.ListWatchOutput(ctx, "", 0)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = repo.AddWatchOutput(ctx, "", worker.PublicId, []byte("output"))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = repo.AddWatchOutput(ctx, "s_1234567890", "", []byte("output"))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		err = repo.AddWatchOutput(ctx, "s_1234567890", worker.PublicId, nil)
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+
+	t.Run("watch-output", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := activeSession(t)
+		other := activeSession(t)
+
+		// output of sessions which are not watched is dropped
+		require.NoError(repo.AddWatchOutput(ctx, s.PublicId, worker.PublicId, []byte("dropped")))
+		got, err := repo.ListWatchedSessionIds(ctx, []string{s.PublicId, other.PublicId})
+		require.NoError(err)
+		assert.Empty(got)
+
+		w, err := repo.CreateWatch(ctx, s.PublicId, s.UserId)
+		require.NoError(err)
+		assert.Equal(s.PublicId, w.SessionId)
+		assert.Equal(s.UserId, w.UserId)
+		assert.False(w.CreateTime.IsZero())
+
+		found, err := repo.LookupWatch(ctx, w.PublicId)
+		require.NoError(err)
+		assert.Equal(w.PublicId, found.PublicId)
+		assert.Equal(w.SessionId, found.SessionId)
+
+		got, err = repo.ListWatchedSessionIds(ctx, []string{s.PublicId, other.PublicId})
+		require.NoError(err)
+		assert.Equal([]string{s.PublicId}, got)
+
+		require.NoError(repo.AddWatchOutput(ctx, s.PublicId, worker.PublicId, []byte("first")))
+		require.NoError(repo.AddWatchOutput(ctx, other.PublicId, worker.PublicId, []byte("other")))
+		// output from workers which do not proxy the session is dropped
+		require.NoError(repo.AddWatchOutput(ctx, s.PublicId, otherWorker.PublicId, []byte("injected")))
+		require.NoError(repo.AddWatchOutput(ctx, s.PublicId, worker.PublicId, []byte("second")))
+
+		output, err := repo.ListWatchOutput(ctx, w.PublicId, 0)
+		require.NoError(err)
+		require.Len(output, 2)
+		assert.Equal([]byte("first"), output[0].Data)
+		assert.Equal([]byte("second"), output[1].Data)
+		assert.Less(output[0].Position, output[1].Position)
+
+		output, err = repo.ListWatchOutput(ctx, w.PublicId, output[0].Position)
+		require.NoError(err)
+		require.Len(output, 1)
+		assert.Equal([]byte("second"), output[0].Data)
+
+		output, err = repo.ListWatchOutput(ctx, w.PublicId, 0, WithLimit(1))
+		require.NoError(err)
+		require.Len(output, 1)
+		assert.Equal([]byte("first"), output[0].Data)
+
+		// the output is encrypted at rest
+		rows, err := rw.Query(ctx, "select data, key_id from session_watch_output where session_id = ?", []any{s.PublicId})
+		require.NoError(err)
+		var stored int
+		for rows.Next() {
+			var data []byte
+			var keyId string
+			require.NoError(rows.Scan(&data, &keyId))
+			assert.NotContains(string(data), "first")
+			assert.NotContains(string(data), "second")
+			assert.NotEmpty(keyId)
+			stored++
+		}
+		require.NoError(rows.Err())
+		assert.Equal(2, stored)
+
+		// watches which stopped polling are not active
+		_, err = rw.Exec(ctx, "update session_watch set update_time = now() - interval '1 minute' where public_id = ?", []any{w.PublicId})
+		require.NoError(err)
+		got, err = repo.ListWatchedSessionIds(ctx, []string{s.PublicId})
+		require.NoError(err)
+		assert.Empty(got)
+
+		// polling activates the watch again
+		_, err = repo.ListWatchOutput(ctx, w.PublicId, 0)
+		require.NoError(err)
+		got, err = repo.ListWatchedSessionIds(ctx, []string{s.PublicId})
+		require.NoError(err)
+		assert.Equal([]string{s.PublicId}, got)
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		found, err := repo.LookupWatch(ctx, "sw_1234567890")
+		require.NoError(err)
+		assert.Nil(found)
+		_, err = repo.ListWatchOutput(ctx, "sw_1234567890", 0)
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "Unexpected error %s", err)
+	})
+
+	t.Run("canceled-session", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		_, err := repo.CancelSession(ctx, s.PublicId, s.Version)
+		require.NoError(err)
+		_, err = repo.CreateWatch(ctx, s.PublicId, s.UserId)
+		assert.Truef(errors.Match(errors.T(errors.InvalidSessionState), err), "Unexpected error %s", err)
+	})
+}
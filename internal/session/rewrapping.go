@@ -15,6 +15,7 @@ import (
 func init() {
 	kms.RegisterTableRewrapFn(defaultSessionTableName, sessionRewrapFn)
 	kms.RegisterTableRewrapFn("session_credential", sessionCredentialRewrapFn)
+	kms.RegisterTableRewrapFn("session_watch_output", sessionWatchOutputRewrapFn)
 }
 
 func rewrapParameterChecks(ctx context.Context, dataKeyVersionId string, scopeId string, reader db.Reader, writer db.Writer, kmsRepo kms.GetWrapperer) string {
@@ -125,3 +126,50 @@ func sessionRewrapFn(ctx context.Context, dataKeyVersionId string, scopeId strin
 	}
 	return nil
 }
+
+func sessionWatchOutputRewrapFn(ctx context.Context, dataKeyVersionId, scopeId string, reader db.Reader, writer db.Writer, kmsRepo kms.GetWrapperer) error {
+	const op = "session.sessionWatchOutputRewrapFn"
+	if errStr := rewrapParameterChecks(ctx, dataKeyVersionId, scopeId, reader, writer, kmsRepo); errStr != "" {
+		return errors.New(ctx, errors.InvalidParameter, op, errStr)
+	}
+	var output []*watchOutput
+	rows, err := reader.Query(ctx, sessionWatchOutputRewrapQuery, []any{scopeId, dataKeyVersionId})
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to query sql for rows that need rewrapping"))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		o := &watchOutput{}
+		if err := rows.Scan(
+			&o.Position,
+			&o.KeyId,
+			&o.CtData,
+		); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to scan row"))
+		}
+		output = append(output, o)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to iterate over retrieved rows"))
+	}
+	wrapper, err := kmsRepo.GetWrapper(ctx, scopeId, kms.KeyPurposeSessions)
+	if err != nil {
+		return errors.Wrap(ctx, err, op, errors.WithMsg("failed to fetch kms wrapper for rewrapping"))
+	}
+	for _, o := range output {
+		if err := o.decrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to decrypt session watch output"))
+		}
+		if err := o.encrypt(ctx, wrapper); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to re-encrypt session watch output"))
+		}
+		if _, err := writer.Exec(ctx, sessionWatchOutputRewrapUpdate, []any{
+			o.CtData,
+			o.KeyId,
+			o.Position,
+		}); err != nil {
+			return errors.Wrap(ctx, err, op, errors.WithMsg("failed to update session watch output row with rewrapped fields"))
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package session
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -234,3 +235,74 @@ func TestRewrap_sessionRewrapFn(t *testing.T) {
 		assert.Empty(t, got.KeyId)
 	})
 }
+
+func TestRewrap_sessionWatchOutputRewrapFn(t *testing.T) {
+	ctx := context.Background()
+	t.Run("errors-on-query-error", func(t *testing.T) {
+		conn, mock := db.TestSetupWithMock(t)
+		wrapper := db.TestWrapper(t)
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_schema_version" WHERE 1=1 ORDER BY "kms_schema_version"\."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		mock.ExpectQuery(
+			`SELECT \* FROM "kms_oplog_schema_version" WHERE 1=1 ORDER BY "kms_oplog_schema_version"."version" LIMIT 1`,
+		).WillReturnRows(sqlmock.NewRows([]string{"version", "create_time"}).AddRow(migrations.Version, time.Now()))
+		kmsCache := kms.TestKms(t, conn, wrapper)
+		rw := db.New(conn)
+		mock.ExpectQuery(
+			`select o\.position, o\.key_id, o\.data from session s join session_watch_output o on o\.session_id = s\.public_id where s\.project_id = \$1 and o\.key_id = \$2`,
+		).WillReturnError(errors.New("Query error"))
+		err := sessionWatchOutputRewrapFn(ctx, "some_id", "some_scope", rw, rw, kmsCache)
+		require.Error(t, err)
+	})
+	t.Run("success", func(t *testing.T) {
+		conn, _ := db.TestSetup(t, "postgres")
+		rootWrapper := db.TestWrapper(t)
+		kmsCache := kms.TestKms(t, conn, rootWrapper)
+		rw := db.New(conn)
+
+		sess := TestDefaultSession(t, conn, rootWrapper, iam.TestRepo(t, conn, rootWrapper))
+
+		kmsWrapper, err := kmsCache.GetWrapper(ctx, sess.ProjectId, kms.KeyPurposeSessions)
+		require.NoError(t, err)
+		o := &watchOutput{
+			Data: []byte("output"),
+		}
+		require.NoError(t, o.encrypt(ctx, kmsWrapper))
+		_, err = rw.Exec(ctx, addWatchOutputQuery, []any{
+			sql.Named("session_id", sess.PublicId),
+			sql.Named("data", o.CtData),
+			sql.Named("key_id", o.KeyId),
+		})
+		require.NoError(t, err)
+
+		// now things are stored in the db, we can rotate and rewrap
+		require.NoError(t, kmsCache.RotateKeys(ctx, sess.ProjectId))
+		require.NoError(t, sessionWatchOutputRewrapFn(ctx, o.KeyId, sess.ProjectId, rw, rw, kmsCache))
+
+		// now we pull the output back from the db, decrypt it with the new key, and ensure things match
+		got := &watchOutput{}
+		rows, err := rw.Query(ctx, `select data, key_id from session_watch_output where session_id = ?`, []any{sess.PublicId})
+		require.NoError(t, err)
+		rowCount := 0
+		for rows.Next() {
+			rowCount++
+			require.NoError(t, rows.Scan(&got.CtData, &got.KeyId))
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, 1, rowCount)
+
+		kmsWrapper2, err := kmsCache.GetWrapper(ctx, sess.ProjectId, kms.KeyPurposeSessions, kms.WithKeyId(got.KeyId))
+		require.NoError(t, err)
+
+		newKeyVersionId, err := kmsWrapper2.KeyId(ctx)
+		require.NoError(t, err)
+
+		// decrypt with the new key version and check to make sure things match
+		require.NoError(t, got.decrypt(ctx, kmsWrapper2))
+		assert.NotEqual(t, o.KeyId, got.KeyId)
+		assert.Equal(t, newKeyVersionId, got.KeyId)
+		assert.Equal(t, "output", string(got.Data))
+		assert.NotEqual(t, o.CtData, got.CtData)
+	})
+}
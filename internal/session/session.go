@@ -50,6 +50,8 @@ type ComposedOf struct {
 	ExpirationTime *timestamp.Timestamp
 	// Max connections for the session
 	ConnectionLimit int32
+	// Seconds a connection of the session can be idle before it is closed
+	IdleTimeoutSeconds uint32
 	// Ingress and egress worker filters. Active filters when the session was created, used to
 	// validate the session via the same set of rules at consumption time as
 	// existed at creation time. Round tripping it through here saves a lookup
@@ -106,6 +108,9 @@ type Session struct {
 	Endpoint string `json:"-" gorm:"default:null"`
 	// Maximum number of connections in a session
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
+	// Seconds a connection of the session can carry no traffic before the
+	// worker closes it. 0 disables the idle timeout.
+	IdleTimeoutSeconds uint32 `json:"idle_timeout_seconds,omitempty" gorm:"default:null"`
 
 	// Worker filters
 	WorkerFilter        string `json:"-" gorm:"default:null"`
@@ -152,6 +157,14 @@ func (s Session) GetUserId() string {
 	return s.UserId
 }
 
+func (s Session) GetCreateTime() *timestamp.Timestamp {
+	return s.CreateTime
+}
+
+func (s Session) GetUpdateTime() *timestamp.Timestamp {
+	return s.UpdateTime
+}
+
 var (
 	_ Cloneable                     = (*Session)(nil)
 	_ db.VetForWriter               = (*Session)(nil)
@@ -171,6 +184,7 @@ func New(ctx context.Context, c ComposedOf, _ ...Option) (*Session, error) {
 		Endpoint:            c.Endpoint,
 		ExpirationTime:      c.ExpirationTime,
 		ConnectionLimit:     c.ConnectionLimit,
+		IdleTimeoutSeconds:  c.IdleTimeoutSeconds,
 		WorkerFilter:        c.WorkerFilter,
 		EgressWorkerFilter:  c.EgressWorkerFilter,
 		IngressWorkerFilter: c.IngressWorkerFilter,
@@ -203,6 +217,7 @@ func (s *Session) Clone() any {
 		Version:             s.Version,
 		Endpoint:            s.Endpoint,
 		ConnectionLimit:     s.ConnectionLimit,
+		IdleTimeoutSeconds:  s.IdleTimeoutSeconds,
 		WorkerFilter:        s.WorkerFilter,
 		EgressWorkerFilter:  s.EgressWorkerFilter,
 		IngressWorkerFilter: s.IngressWorkerFilter,
@@ -319,6 +334,8 @@ func (s *Session) VetForWrite(ctx context.Context, _ db.Reader, opType db.OpType
 			return errors.New(ctx, errors.InvalidParameter, op, "expiration time is immutable")
 		case contains(opts.WithFieldMaskPaths, "ConnectionLimit"):
 			return errors.New(ctx, errors.InvalidParameter, op, "connection limit is immutable")
+		case contains(opts.WithFieldMaskPaths, "IdleTimeoutSeconds"):
+			return errors.New(ctx, errors.InvalidParameter, op, "idle timeout seconds is immutable")
 		case contains(opts.WithFieldMaskPaths, "WorkerFilter"):
 			return errors.New(ctx, errors.InvalidParameter, op, "worker filter is immutable")
 		case contains(opts.WithFieldMaskPaths, "EgressWorkerFilter"):
@@ -22,6 +22,7 @@ const (
 	SystemError        TerminationReason = "system error"
 	ConnectionLimit    TerminationReason = "connection limit"
 	SessionCanceled    TerminationReason = "canceled"
+	IdleTimeout        TerminationReason = "idle timeout"
 )
 
 // String representation of the termination reason
@@ -46,6 +47,8 @@ func convertToReason(ctx context.Context, s string) (TerminationReason, error) {
 		return SystemError, nil
 	case ConnectionLimit.String():
 		return ConnectionLimit, nil
+	case IdleTimeout.String():
+		return IdleTimeout, nil
 	default:
 		return "", errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid reason", s))
 	}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+/*
+Package local provides a storage.RecordingStorage which uses a directory on
+the local filesystem of a worker as a cache for session recordings.
+
+Containers are created as directories and files as regular files within the
+directory of the storage. When a file is closed it is synced to the storage
+bucket of its root container using the storage plugin of the bucket. The key
+of the object is the path of the file relative to the directory of the
+storage. Once all files of a root container have been synced, closing the
+root container removes its directory.
+
+	rs, err := local.New(ctx, path, plgClients, false)
+	if err != nil {
+		return err
+	}
+	fs, err := rs.NewSyncingFS(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	c, err := fs.New(ctx, "sr_123456789.bsr")
+*/
+package local
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+// remoteFS is a read only storage.FS which retrieves files from a storage
+// bucket.
+type remoteFS struct {
+	rs     *RecordingStorage
+	bucket *storagebuckets.StorageBucket
+	client plgpb.StoragePluginServiceClient
+}
+
+// New is not supported by a remote FS.
+func (rfs *remoteFS) New(_ context.Context, name string) (storage.Container, error) {
+	const op = "local.(remoteFS).New"
+	return nil, fmt.Errorf("%s: unable to create %q: %w", op, name, ErrReadOnly)
+}
+
+// Open opens the named root container. Containers are prefixes of the keys
+// of objects in the storage bucket, so opening a container does not check
+// that any object exists.
+func (rfs *remoteFS) Open(_ context.Context, name string) (storage.Container, error) {
+	const op = "local.(remoteFS).Open"
+	if _, err := joinPath("", name); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &remoteContainer{
+		fs:  rfs,
+		key: name,
+	}, nil
+}
+
+// remoteContainer is a read only storage.Container of a remote FS.
+type remoteContainer struct {
+	fs  *remoteFS
+	key string
+}
+
+// Close closes the container.
+func (c *remoteContainer) Close() error {
+	return nil
+}
+
+// Create is not supported by a remote container.
+func (c *remoteContainer) Create(_ context.Context, name string) (storage.File, error) {
+	const op = "local.(remoteContainer).Create"
+	return nil, fmt.Errorf("%s: unable to create %q: %w", op, name, ErrReadOnly)
+}
+
+// OpenFile retrieves the named object from the storage bucket into a
+// temporary file and returns the file. Only the ReadOnly access mode is
+// supported.
+func (c *remoteContainer) OpenFile(ctx context.Context, name string, opt ...storage.Option) (storage.File, error) {
+	const op = "local.(remoteContainer).OpenFile"
+	if _, err := joinPath("", name); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	opts := storage.GetOpts(opt...)
+	if opts.WithCreateFile || opts.WithFileAccessMode != storage.ReadOnly {
+		return nil, fmt.Errorf("%s: %w", op, ErrReadOnly)
+	}
+
+	key := path.Join(c.key, name)
+	stream, err := c.fs.client.GetObject(ctx, &plgpb.GetObjectRequest{
+		Bucket: c.fs.bucket,
+		Key:    key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to get %q: %w", op, key, err)
+	}
+	f, err := c.fs.rs.CreateTemp(ctx, "remote-*")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("%s: unable to get %q: %w", op, key, err)
+		}
+		if _, err := f.Write(resp.GetFileChunk()); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &remoteFile{TempFile: f}, nil
+}
+
+// SubContainer opens a container in this container. Only the ReadOnly
+// access mode is supported.
+func (c *remoteContainer) SubContainer(_ context.Context, name string, opt ...storage.Option) (storage.Container, error) {
+	const op = "local.(remoteContainer).SubContainer"
+	if _, err := joinPath("", name); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	opts := storage.GetOpts(opt...)
+	if opts.WithCreateFile || opts.WithFileAccessMode != storage.ReadOnly {
+		return nil, fmt.Errorf("%s: %w", op, ErrReadOnly)
+	}
+	return &remoteContainer{
+		fs:  c.fs,
+		key: path.Join(c.key, name),
+	}, nil
+}
+
+// remoteFile is a read only storage.File retrieved from a storage bucket.
+// It is removed from the local filesystem when closed.
+type remoteFile struct {
+	storage.TempFile
+}
+
+// Write is not supported by a remote file.
+func (f *remoteFile) Write([]byte) (int, error) {
+	const op = "local.(remoteFile).Write"
+	return 0, fmt.Errorf("%s: %w", op, ErrReadOnly)
+}
+
+// WriteString is not supported by a remote file.
+func (f *remoteFile) WriteString(string) (int, error) {
+	const op = "local.(remoteFile).WriteString"
+	return 0, fmt.Errorf("%s: %w", op, ErrReadOnly)
+}
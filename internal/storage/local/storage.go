@@ -0,0 +1,179 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/plugin/loopback"
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+const (
+	defaultContainerPerm = os.FileMode(0o700)
+	defaultFilePerm      = os.FileMode(0o600)
+
+	recordingsDir = "recordings"
+	tempDir       = "tmp"
+)
+
+var (
+	// ErrReadOnly is returned when attempting to write to a read only file or
+	// container.
+	ErrReadOnly = errors.New("read only")
+
+	// ErrClosed is returned when using a closed file or container.
+	ErrClosed = errors.New("closed")
+
+	// ErrUnknownPlugin is returned when no storage plugin client is
+	// available for the plugin of a storage bucket.
+	ErrUnknownPlugin = errors.New("unknown storage plugin")
+)
+
+// RecordingStorage is a storage.RecordingStorage which caches files in a
+// local directory and syncs them to storage buckets.
+type RecordingStorage struct {
+	path       string
+	plgClients map[string]plgpb.StoragePluginServiceClient
+}
+
+var _ storage.RecordingStorage = (*RecordingStorage)(nil)
+
+// New creates a RecordingStorage in the directory path. The directory is
+// created if it does not exist and any temporary files left in it are
+// removed. plgClients are the storage plugin clients keyed on the lower case
+// name of the plugin. If enableLoopback is true, a loopback storage plugin
+// is added to the plugin clients.
+func New(ctx context.Context, path string, plgClients map[string]plgpb.StoragePluginServiceClient, enableLoopback bool) (*RecordingStorage, error) {
+	const op = "local.New"
+	if path == "" {
+		return nil, fmt.Errorf("%s: missing path", op)
+	}
+	if err := os.MkdirAll(filepath.Join(path, recordingsDir), defaultContainerPerm); err != nil {
+		return nil, fmt.Errorf("%s: unable to create recordings directory: %w", op, err)
+	}
+	if err := os.RemoveAll(filepath.Join(path, tempDir)); err != nil {
+		return nil, fmt.Errorf("%s: unable to remove temporary files: %w", op, err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, tempDir), defaultContainerPerm); err != nil {
+		return nil, fmt.Errorf("%s: unable to create temporary directory: %w", op, err)
+	}
+
+	clients := make(map[string]plgpb.StoragePluginServiceClient, len(plgClients)+1)
+	for k, v := range plgClients {
+		clients[strings.ToLower(k)] = v
+	}
+	if enableLoopback {
+		lp, err := loopback.NewLoopbackPlugin()
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to create loopback plugin: %w", op, err)
+		}
+		clients["loopback"] = loopback.NewWrappingPluginStorageClient(lp)
+	}
+	return &RecordingStorage{
+		path:       path,
+		plgClients: clients,
+	}, nil
+}
+
+// NewSyncingFS returns an FS that creates files in the local directory of
+// the storage and syncs them to bucket when they are closed.
+func (rs *RecordingStorage) NewSyncingFS(ctx context.Context, bucket *storagebuckets.StorageBucket, _ ...storage.Option) (storage.FS, error) {
+	const op = "local.(RecordingStorage).NewSyncingFS"
+	client, err := rs.pluginClient(bucket)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &syncingFS{
+		path:   filepath.Join(rs.path, recordingsDir),
+		bucket: bucket,
+		client: client,
+	}, nil
+}
+
+// NewRemoteFS returns a read only FS that retrieves files from bucket.
+func (rs *RecordingStorage) NewRemoteFS(ctx context.Context, bucket *storagebuckets.StorageBucket, _ ...storage.Option) (storage.FS, error) {
+	const op = "local.(RecordingStorage).NewRemoteFS"
+	client, err := rs.pluginClient(bucket)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &remoteFS{
+		rs:     rs,
+		bucket: bucket,
+		client: client,
+	}, nil
+}
+
+// PluginClients returns the storage plugin clients keyed on the lower case
+// name of the plugin.
+func (rs *RecordingStorage) PluginClients() map[string]plgpb.StoragePluginServiceClient {
+	return rs.plgClients
+}
+
+// CreateTemp creates a temporary file in the temporary directory of the
+// storage. The name of the file is generated from the pattern p as in
+// os.CreateTemp. The file is removed when it is closed.
+func (rs *RecordingStorage) CreateTemp(ctx context.Context, p string) (storage.TempFile, error) {
+	const op = "local.(RecordingStorage).CreateTemp"
+	f, err := os.CreateTemp(filepath.Join(rs.path, tempDir), p)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &tempFile{File: f}, nil
+}
+
+func (rs *RecordingStorage) pluginClient(bucket *storagebuckets.StorageBucket) (plgpb.StoragePluginServiceClient, error) {
+	switch {
+	case bucket == nil:
+		return nil, fmt.Errorf("missing storage bucket")
+	case bucket.GetBucketName() == "":
+		return nil, fmt.Errorf("missing storage bucket name")
+	}
+	name := strings.ToLower(bucket.GetPlugin().GetName())
+	client, ok := rs.plgClients[name]
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrUnknownPlugin)
+	}
+	return client, nil
+}
+
+// joinPath joins a parent path with the single name child container or
+// file. The name cannot contain any path separators or refer to the parent.
+func joinPath(parentPath, name string) (string, error) {
+	switch {
+	case name == "":
+		return "", fmt.Errorf("missing name")
+	case strings.ContainsAny(name, `/\`):
+		return "", fmt.Errorf("name contains path separator")
+	case name == "." || name == "..":
+		return "", fmt.Errorf("invalid name %q", name)
+	}
+	return filepath.Join(parentPath, name), nil
+}
+
+// tempFile is a storage.TempFile which is removed when it is closed.
+type tempFile struct {
+	*os.File
+}
+
+// Close closes and removes the file.
+func (f *tempFile) Close() error {
+	const op = "local.(tempFile).Close"
+	closeErr := f.File.Close()
+	if err := os.Remove(f.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if closeErr != nil && !errors.Is(closeErr, os.ErrClosed) {
+		return fmt.Errorf("%s: %w", op, closeErr)
+	}
+	return nil
+}
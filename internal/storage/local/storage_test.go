@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testBucket() *storagebuckets.StorageBucket {
+	return &storagebuckets.StorageBucket{
+		Id:         "sb_1234567890",
+		BucketName: "default",
+		Plugin:     &plugins.PluginInfo{Name: "loopback"},
+		Attributes: &structpb.Struct{},
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing-path", func(t *testing.T) {
+		_, err := New(ctx, "", nil, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("removes-temp-files", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		dir := t.TempDir()
+		rs, err := New(ctx, dir, nil, true)
+		require.NoError(err)
+		assert.Contains(rs.PluginClients(), "loopback")
+
+		f, err := rs.CreateTemp(ctx, "test-*")
+		require.NoError(err)
+		_, err = f.Write([]byte("leftover"))
+		require.NoError(err)
+
+		_, err = New(ctx, dir, nil, false)
+		require.NoError(err)
+		_, err = os.Stat(f.(*tempFile).Name())
+		assert.ErrorIs(err, os.ErrNotExist)
+	})
+}
+
+func TestRecordingStorage_CreateTemp(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	rs, err := New(ctx, t.TempDir(), nil, false)
+	require.NoError(err)
+
+	f, err := rs.CreateTemp(ctx, "test-*")
+	require.NoError(err)
+	_, err = f.WriteString("temp")
+	require.NoError(err)
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(err)
+	b, err := io.ReadAll(f)
+	require.NoError(err)
+	assert.Equal("temp", string(b))
+
+	name := f.(*tempFile).Name()
+	require.NoError(f.Close())
+	_, err = os.Stat(name)
+	assert.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestRecordingStorage_UnknownPlugin(t *testing.T) {
+	ctx := context.Background()
+	rs, err := New(ctx, t.TempDir(), nil, false)
+	require.NoError(t, err)
+
+	_, err = rs.NewSyncingFS(ctx, testBucket())
+	assert.ErrorIs(t, err, ErrUnknownPlugin)
+	_, err = rs.NewRemoteFS(ctx, testBucket())
+	assert.ErrorIs(t, err, ErrUnknownPlugin)
+}
+
+func TestRecordingStorage_SyncingFS(t *testing.T) {
+	ctx := context.Background()
+	assert, require := assert.New(t), require.New(t)
+	dir := t.TempDir()
+	rs, err := New(ctx, dir, nil, true)
+	require.NoError(err)
+
+	sfs, err := rs.NewSyncingFS(ctx, testBucket())
+	require.NoError(err)
+	root, err := sfs.New(ctx, "sr_123.bsr")
+	require.NoError(err)
+	_, err = sfs.New(ctx, "sr_123.bsr")
+	assert.Error(err)
+	_, err = root.SubContainer(ctx, "../escape", storage.WithCreateFile(), storage.WithFileAccessMode(storage.WriteOnly))
+	assert.Error(err)
+
+	sync, err := root.OpenFile(ctx, "sync", storage.WithCreateFile(), storage.WithFileAccessMode(storage.WriteOnly), storage.WithCloseSyncMode(storage.Synchronous))
+	require.NoError(err)
+	_, err = sync.WriteString("synchronous")
+	require.NoError(err)
+	require.NoError(sync.Close())
+
+	sub, err := root.SubContainer(ctx, "cr_123.connection", storage.WithCreateFile(), storage.WithFileAccessMode(storage.WriteOnly))
+	require.NoError(err)
+	async, err := sub.Create(ctx, "async")
+	require.NoError(err)
+	_, err = async.Write([]byte("asynchronous"))
+	require.NoError(err)
+	require.NoError(async.Close())
+
+	nosync, err := sub.OpenFile(ctx, "nosync", storage.WithCreateFile(), storage.WithFileAccessMode(storage.WriteOnly), storage.WithCloseSyncMode(storage.NoSync))
+	require.NoError(err)
+	_, err = nosync.WriteString("not synced")
+	require.NoError(err)
+	require.NoError(nosync.Close())
+
+	require.NoError(sub.Close())
+	_, err = sub.Create(ctx, "closed")
+	assert.ErrorIs(err, ErrClosed)
+	require.NoError(root.Close())
+
+	// the local directory is removed once all files are synced
+	_, err = os.Stat(filepath.Join(dir, recordingsDir, "sr_123.bsr"))
+	assert.ErrorIs(err, os.ErrNotExist)
+
+	rfs, err := rs.NewRemoteFS(ctx, testBucket())
+	require.NoError(err)
+	_, err = rfs.New(ctx, "sr_456.bsr")
+	assert.ErrorIs(err, ErrReadOnly)
+	rroot, err := rfs.Open(ctx, "sr_123.bsr")
+	require.NoError(err)
+
+	readFile := func(c storage.Container, name string) string {
+		f, err := c.OpenFile(ctx, name)
+		require.NoError(err)
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		require.NoError(err)
+		return string(b)
+	}
+	assert.Equal("synchronous", readFile(rroot, "sync"))
+	rsub, err := rroot.SubContainer(ctx, "cr_123.connection")
+	require.NoError(err)
+	assert.Equal("asynchronous", readFile(rsub, "async"))
+	_, err = rsub.OpenFile(ctx, "nosync")
+	assert.Error(err)
+	_, err = rsub.OpenFile(ctx, "async", storage.WithFileAccessMode(storage.ReadWrite))
+	assert.ErrorIs(err, ErrReadOnly)
+}
@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/storage"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	plgpb "github.com/hashicorp/boundary/sdk/pbs/plugin"
+)
+
+// asyncSyncAttempts is the number of times an asynchronously synced file is
+// put into its storage bucket before giving up.
+const asyncSyncAttempts = 5
+
+// asyncSyncBackoff is the time waited before retrying to put an
+// asynchronously synced file. It is doubled after each attempt.
+var asyncSyncBackoff = 500 * time.Millisecond
+
+// syncingFS is a storage.FS which syncs files to a storage bucket when they
+// are closed.
+type syncingFS struct {
+	path   string
+	bucket *storagebuckets.StorageBucket
+	client plgpb.StoragePluginServiceClient
+}
+
+// New creates the named root container.
+func (sfs *syncingFS) New(ctx context.Context, name string) (storage.Container, error) {
+	const op = "local.(syncingFS).New"
+	p, err := joinPath(sfs.path, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Mkdir(p, defaultContainerPerm); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &syncingContainer{
+		root: &syncingRoot{
+			fs:   sfs,
+			path: p,
+		},
+		path: p,
+		key:  name,
+	}, nil
+}
+
+// Open is not supported by a syncing FS since the files of its containers
+// are removed once they are synced.
+func (sfs *syncingFS) Open(_ context.Context, name string) (storage.Container, error) {
+	const op = "local.(syncingFS).Open"
+	return nil, fmt.Errorf("%s: unable to open %q: %w", op, name, errors.ErrUnsupported)
+}
+
+// syncingRoot tracks the files of a root container which are being synced
+// asynchronously.
+type syncingRoot struct {
+	fs   *syncingFS
+	path string
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *syncingRoot) addError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+// put puts the local file p into the storage bucket using key.
+func (r *syncingRoot) put(ctx context.Context, key, p string) error {
+	_, err := r.fs.client.PutObject(ctx, &plgpb.PutObjectRequest{
+		Bucket: r.fs.bucket,
+		Key:    key,
+		Path:   p,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to put %q into storage bucket %q: %w", key, r.fs.bucket.GetId(), err)
+	}
+	return nil
+}
+
+// putAsync puts the local file p into the storage bucket in the background,
+// retrying with a backoff if putting the file fails.
+func (r *syncingRoot) putAsync(key, p string) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		// The file is synced even if the context of the caller is
+		// canceled, for instance when the worker is shutting down.
+		ctx := context.Background()
+		backoff := asyncSyncBackoff
+		var err error
+		for i := 0; i < asyncSyncAttempts; i++ {
+			if err = r.put(ctx, key, p); err == nil {
+				return
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		r.addError(err)
+	}()
+}
+
+// syncingContainer is a storage.Container backed by a local directory.
+type syncingContainer struct {
+	root *syncingRoot
+	path string
+	key  string
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Close closes the container. Closing the root container waits for all
+// files being synced asynchronously and removes the local directory of the
+// container if all of its files have been synced.
+func (c *syncingContainer) Close() error {
+	const op = "local.(syncingContainer).Close"
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.path != c.root.path {
+		return nil
+	}
+
+	c.root.wg.Wait()
+	c.root.mu.Lock()
+	defer c.root.mu.Unlock()
+	if len(c.root.errs) > 0 {
+		return fmt.Errorf("%s: %w", op, errors.Join(c.root.errs...))
+	}
+	if err := os.RemoveAll(c.path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Create creates a new file in the container which is synced
+// asynchronously when closed.
+func (c *syncingContainer) Create(ctx context.Context, name string) (storage.File, error) {
+	const op = "local.(syncingContainer).Create"
+	f, err := c.OpenFile(ctx, name, storage.WithCreateFile(), storage.WithFileAccessMode(storage.ReadWrite))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return f, nil
+}
+
+// OpenFile opens a file in the container.
+// Supports the following options:
+//   - WithCloseSyncMode: Sets how the file is synced when closed.
+//   - WithFileAccessMode: Sets the access mode for the file.
+//   - WithCreateFile: Creates the file if it does not exist. If the file
+//     exists, it is truncated.
+func (c *syncingContainer) OpenFile(ctx context.Context, name string, opt ...storage.Option) (storage.File, error) {
+	const op = "local.(syncingContainer).OpenFile"
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+	p, err := joinPath(c.path, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	opts := storage.GetOpts(opt...)
+	if opts.WithCreateFile && opts.WithFileAccessMode == storage.ReadOnly {
+		return nil, fmt.Errorf("%s: cannot create file in read-only mode: %w", op, ErrReadOnly)
+	}
+
+	var flag int
+	switch opts.WithFileAccessMode {
+	case storage.WriteOnly:
+		flag = os.O_WRONLY
+	case storage.ReadWrite:
+		flag = os.O_RDWR
+	default:
+		flag = os.O_RDONLY
+	}
+	switch {
+	case opts.WithCreateFile:
+		flag |= os.O_CREATE | os.O_TRUNC
+	case opts.WithFileAccessMode != storage.ReadOnly:
+		flag |= os.O_APPEND
+	}
+	f, err := os.OpenFile(p, flag, defaultFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &syncingFile{
+		File:       f,
+		root:       c.root,
+		key:        path.Join(c.key, name),
+		syncMode:   opts.WithCloseSyncMode,
+		accessMode: opts.WithFileAccessMode,
+	}, nil
+}
+
+// SubContainer creates or opens a container in this container.
+func (c *syncingContainer) SubContainer(_ context.Context, name string, opt ...storage.Option) (storage.Container, error) {
+	const op = "local.(syncingContainer).SubContainer"
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+	p, err := joinPath(c.path, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	opts := storage.GetOpts(opt...)
+	switch {
+	case opts.WithCreateFile && opts.WithFileAccessMode == storage.ReadOnly:
+		return nil, fmt.Errorf("%s: cannot create container in read-only mode: %w", op, ErrReadOnly)
+	case opts.WithCreateFile:
+		if err := os.Mkdir(p, defaultContainerPerm); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	default:
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if !fi.IsDir() {
+			return nil, fmt.Errorf("%s: %q is not a container", op, name)
+		}
+	}
+	return &syncingContainer{
+		root: c.root,
+		path: p,
+		key:  path.Join(c.key, name),
+	}, nil
+}
+
+// syncingFile is a storage.File which is synced to the storage bucket of
+// its root container when it is closed.
+type syncingFile struct {
+	*os.File
+	root       *syncingRoot
+	key        string
+	syncMode   storage.SyncMode
+	accessMode storage.AccessMode
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Stat returns the FileInfo structure describing the file.
+func (f *syncingFile) Stat() (fs.FileInfo, error) {
+	return f.File.Stat()
+}
+
+// Write writes b to the file.
+func (f *syncingFile) Write(b []byte) (int, error) {
+	const op = "local.(syncingFile).Write"
+	if f.accessMode == storage.ReadOnly {
+		return 0, fmt.Errorf("%s: %w", op, ErrReadOnly)
+	}
+	return f.File.Write(b)
+}
+
+// WriteString writes s to the file.
+func (f *syncingFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+// Close closes the file and syncs it according to its sync mode. Files
+// opened read only are not synced.
+func (f *syncingFile) Close() error {
+	const op = "local.(syncingFile).Close"
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	if err := f.File.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if f.accessMode == storage.ReadOnly {
+		return nil
+	}
+
+	p, err := filepath.Abs(f.Name())
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	switch f.syncMode {
+	case storage.Synchronous:
+		if err := f.root.put(context.Background(), f.key, p); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	case storage.NoSync:
+	default:
+		f.root.putAsync(f.key, p)
+	}
+	return nil
+}
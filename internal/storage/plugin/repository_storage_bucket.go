@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	plg "github.com/hashicorp/boundary/internal/plugin"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/plugins"
+	"github.com/hashicorp/boundary/sdk/pbs/controller/api/resources/storagebuckets"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// LookupStorageBucket returns the StorageBucket for id along with the plugin
+// which manages it. The secrets of the storage bucket are decrypted and set
+// on the returned StorageBucket. Returns nil, nil, nil when no StorageBucket
+// is found. All options are ignored.
+func (r *Repository) LookupStorageBucket(ctx context.Context, id string, _ ...Option) (*StorageBucket, *plg.Plugin, error) {
+	const op = "plugin.(Repository).LookupStorageBucket"
+	if id == "" {
+		return nil, nil, errors.New(ctx, errors.InvalidParameter, op, "no public id")
+	}
+	agg := &storageBucketAgg{}
+	agg.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, agg); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("failed for: %s", id)))
+	}
+	sb, sbs := agg.toStorageBucketAndSecret()
+	if sbs != nil {
+		wrapper, err := r.kms.GetWrapper(ctx, sb.GetScopeId(), kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op, errors.WithMsg("unable to get db wrapper"))
+		}
+		if err := sbs.decrypt(ctx, wrapper); err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op)
+		}
+		persisted, err := sbs.toPersisted(ctx)
+		if err != nil {
+			return nil, nil, errors.Wrap(ctx, err, op)
+		}
+		sb.Secrets = persisted.GetData()
+	}
+	p, err := r.getPlugin(ctx, sb.GetPluginId())
+	if err != nil {
+		return nil, nil, errors.Wrap(ctx, err, op)
+	}
+	return sb, p, nil
+}
+
+func (r *Repository) getPlugin(ctx context.Context, plgId string) (*plg.Plugin, error) {
+	const op = "plugin.(Repository).getPlugin"
+	if plgId == "" {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "no plugin id")
+	}
+	p := plg.NewPlugin()
+	p.PublicId = plgId
+	if err := r.reader.LookupByPublicId(ctx, p); err != nil {
+		return nil, errors.Wrap(ctx, err, op, errors.WithMsg(fmt.Sprintf("unable to get storage plugin with id %q", plgId)))
+	}
+	return p, nil
+}
+
+// ToPluginStorageBucket returns the storage bucket, with its secrets if
+// available, in the format expected by the storage plugin system.
+func ToPluginStorageBucket(ctx context.Context, in *StorageBucket, p *plg.Plugin) (*storagebuckets.StorageBucket, error) {
+	const op = "plugin.ToPluginStorageBucket"
+	switch {
+	case in == nil || in.StorageBucket == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil storage bucket")
+	case p == nil:
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "nil plugin")
+	}
+	attrs := &structpb.Struct{}
+	if len(in.GetAttributes()) > 0 {
+		if err := proto.Unmarshal(in.GetAttributes(), attrs); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithCode(errors.InvalidParameter), errors.WithMsg("unable to unmarshal attributes"))
+		}
+	}
+	return &storagebuckets.StorageBucket{
+		Id:       in.GetPublicId(),
+		ScopeId:  in.GetScopeId(),
+		PluginId: in.GetPluginId(),
+		Plugin: &plugins.PluginInfo{
+			Id:          p.GetPublicId(),
+			Name:        p.GetName(),
+			Description: p.GetDescription(),
+		},
+		BucketName:   in.GetBucketName(),
+		BucketPrefix: in.GetBucketPrefix(),
+		WorkerFilter: in.GetWorkerFilter(),
+		Attributes:   attrs,
+		Secrets:      in.Secrets,
+	}, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	plg "github.com/hashicorp/boundary/internal/plugin"
+	wrapping "github.com/hashicorp/go-kms-wrapping/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestStorageBucket creates a storage bucket for the provided plugin in the
+// provided scope and inserts it into the database. The scope must be the
+// global scope or an org.
+//
+// Supported options: WithName, WithDescription, WithAttributes, WithSecrets,
+// WithBucketPrefix, WithWorkerFilter
+func TestStorageBucket(t testing.TB, conn *db.DB, wrapper wrapping.Wrapper, p *plg.Plugin, scopeId string, opt ...Option) *StorageBucket {
+	t.Helper()
+	ctx := context.Background()
+	require := require.New(t)
+	opts := getOpts(opt...)
+	w := db.New(conn)
+
+	id, err := newStorageBucketId(ctx)
+	require.NoError(err)
+
+	attrs, err := proto.Marshal(opts.withAttributes)
+	require.NoError(err)
+
+	secrets := opts.withSecrets
+	if secrets == nil {
+		secrets = &structpb.Struct{Fields: map[string]*structpb.Value{
+			"secret": structpb.NewStringValue("test-secret"),
+		}}
+	}
+	workerFilter := opts.withWorkerFilter
+	if workerFilter == "" {
+		workerFilter = `"test" in "/tags/type"`
+	}
+
+	sb := allocStorageBucket()
+	sb.PublicId = id
+	sb.ScopeId = scopeId
+	sb.PluginId = p.GetPublicId()
+	sb.Name = opts.withName
+	sb.Description = opts.withDescription
+	sb.BucketName = "test-bucket-" + id
+	sb.BucketPrefix = opts.withBucketPrefix
+	sb.WorkerFilter = workerFilter
+	sb.Attributes = attrs
+
+	sbs, err := newStorageBucketSecret(ctx, id, secrets)
+	require.NoError(err)
+	databaseWrapper, err := kms.TestKms(t, conn, wrapper).GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	require.NoError(err)
+	sb.SecretsHmac, err = sbs.hmacSecrets(ctx, databaseWrapper)
+	require.NoError(err)
+	require.NoError(sbs.encrypt(ctx, databaseWrapper))
+
+	require.NoError(w.Create(ctx, sb))
+	require.NoError(w.Create(ctx, sbs))
+
+	sb.Secrets = secrets
+	return sb
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/boundary/globals"
 	intglobals "github.com/hashicorp/boundary/internal/globals"
+	"github.com/hashicorp/boundary/internal/pagination"
 	"github.com/hashicorp/boundary/internal/perms"
 )
 
@@ -26,32 +27,38 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	WithName                   string
-	WithDescription            string
-	WithDefaultPort            uint32
-	WithDefaultClientPort      uint32
-	WithLimit                  int
-	WithProjectId              string
-	WithProjectIds             []string
-	WithProjectName            string
-	WithUserId                 string
-	WithType                   globals.Subtype
-	WithHostSources            []string
-	WithCredentialLibraries    []*CredentialLibrary
-	WithStaticCredentials      []*StaticCredential
-	WithSessionMaxSeconds      uint32
-	WithSessionConnectionLimit int32
-	WithPermissions            []perms.Permission
-	WithPublicId               string
-	WithWorkerFilter           string
-	WithTestWorkerFilter       string
-	WithEgressWorkerFilter     string
-	WithIngressWorkerFilter    string
-	WithTargetIds              []string
-	WithAddress                string
-	WithStorageBucketId        string
-	WithEnableSessionRecording bool
-	WithNetResolver            intglobals.NetIpResolver
+	WithName                      string
+	WithDescription               string
+	WithDefaultPort               uint32
+	WithDefaultClientPort         uint32
+	WithLimit                     int
+	WithProjectId                 string
+	WithProjectIds                []string
+	WithProjectName               string
+	WithUserId                    string
+	WithType                      globals.Subtype
+	WithHostSources               []string
+	WithCredentialLibraries       []*CredentialLibrary
+	WithStaticCredentials         []*StaticCredential
+	WithSessionMaxSeconds         uint32
+	WithSessionConnectionLimit    int32
+	WithSessionIdleTimeoutSeconds uint32
+	WithPermissions               []perms.Permission
+	WithPublicId                  string
+	WithWorkerFilter              string
+	WithTestWorkerFilter          string
+	WithEgressWorkerFilter        string
+	WithIngressWorkerFilter       string
+	WithTargetIds                 []string
+	WithAddress                   string
+	WithStorageBucketId           string
+	WithEnableSessionRecording    bool
+	WithRecordingCompression      string
+	WithRecordingCompressionLevel uint32
+	WithHostKeys                  string
+	WithNetResolver               intglobals.NetIpResolver
+	WithStartPageAfterItem        pagination.Item
+	WithUpdatedAfter              time.Time
 }
 
 func getDefaultOptions() options {
@@ -187,6 +194,14 @@ func WithSessionConnectionLimit(limit int32) Option {
 	}
 }
 
+// WithSessionIdleTimeoutSeconds provides an option for the number of seconds
+// a connection of a session can carry no traffic before it is closed.
+func WithSessionIdleTimeoutSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.WithSessionIdleTimeoutSeconds = secs
+	}
+}
+
 // WithPublicId provides an optional public id
 func WithPublicId(id string) Option {
 	return func(o *options) {
@@ -259,9 +274,49 @@ func WithStorageBucketId(id string) Option {
 	}
 }
 
+// WithRecordingCompression provides an option to set the compression of the
+// session recordings of a target
+func WithRecordingCompression(c string) Option {
+	return func(o *options) {
+		o.WithRecordingCompression = c
+	}
+}
+
+// WithRecordingCompressionLevel provides an option to set the level of the
+// compression of the session recordings of a target
+func WithRecordingCompressionLevel(l uint32) Option {
+	return func(o *options) {
+		o.WithRecordingCompressionLevel = l
+	}
+}
+
+// WithHostKeys provides an option to set the public keys of the hosts of a
+// target in the OpenSSH authorized_keys format
+func WithHostKeys(k string) Option {
+	return func(o *options) {
+		o.WithHostKeys = k
+	}
+}
+
 // WithNetResolver provides an option to specify a custom DNS resolver
 func WithNetResolver(resolver intglobals.NetIpResolver) Option {
 	return func(o *options) {
 		o.WithNetResolver = resolver
 	}
 }
+
+// WithStartPageAfterItem is used to paginate over the results.
+// The next page will start after the provided item.
+func WithStartPageAfterItem(item pagination.Item) Option {
+	return func(o *options) {
+		o.WithStartPageAfterItem = item
+	}
+}
+
+// WithUpdatedAfter is used to only list targets updated after the provided
+// time.
+func WithUpdatedAfter(t time.Time) Option {
+	return func(o *options) {
+		o.WithUpdatedAfter = t
+	}
+}
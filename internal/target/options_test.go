@@ -5,6 +5,7 @@ package target
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/credential"
@@ -233,4 +234,40 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.WithEnableSessionRecording = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithRecordingCompression", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithRecordingCompression("zstd"))
+		testOpts := getDefaultOptions()
+		testOpts.WithRecordingCompression = "zstd"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithRecordingCompressionLevel", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithRecordingCompressionLevel(3))
+		testOpts := getDefaultOptions()
+		testOpts.WithRecordingCompressionLevel = 3
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithHostKeys", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithHostKeys("ssh-ed25519 AAAA"))
+		testOpts := getDefaultOptions()
+		testOpts.WithHostKeys = "ssh-ed25519 AAAA"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithSessionIdleTimeoutSeconds", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := GetOpts(WithSessionIdleTimeoutSeconds(300))
+		testOpts := getDefaultOptions()
+		testOpts.WithSessionIdleTimeoutSeconds = 300
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithUpdatedAfter", func(t *testing.T) {
+		assert := assert.New(t)
+		now := time.Now()
+		opts := GetOpts(WithUpdatedAfter(now))
+		testOpts := getDefaultOptions()
+		testOpts.WithUpdatedAfter = now
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -57,5 +57,12 @@ order by action, source_id;
 select public_id, project_id from target
 %s
 ;
+`
+
+	deletedTargetIds = `
+select public_id
+  from target_deleted
+ where delete_time > @since
+;
 `
 )
@@ -8,9 +8,11 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/boundary"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -230,7 +232,8 @@ func (r *Repository) FetchAuthzProtectedEntitiesByScope(ctx context.Context, pro
 // ListTargets lists targets in a project based on the data in the WithPermissions option
 // provided to the Repository constructor. If no permissions are available, this function
 // is a no-op.
-// Supports WithLimit which overrides the limit set in the Repository object.
+// Supports WithLimit which overrides the limit set in the Repository object,
+// and WithStartPageAfterItem. Targets are ordered by create time and public id.
 func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target, error) {
 	const op = "target.(Repository).ListTargets"
 
@@ -249,12 +252,26 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		limit = opts.WithLimit
 	}
 
+	whereClause := strings.Join(where, " or ")
+	if opts.WithStartPageAfterItem != nil {
+		whereClause = fmt.Sprintf("(%s) and (create_time, public_id) > (@last_item_create_time, @last_item_id)", whereClause)
+		args = append(args,
+			sql.Named("last_item_create_time", opts.WithStartPageAfterItem.GetCreateTime()),
+			sql.Named("last_item_id", opts.WithStartPageAfterItem.GetPublicId()),
+		)
+	}
+	if !opts.WithUpdatedAfter.IsZero() {
+		whereClause = fmt.Sprintf("(%s) and update_time > @updated_after", whereClause)
+		args = append(args, sql.Named("updated_after", timestamp.New(opts.WithUpdatedAfter)))
+	}
+
 	var foundTargets []*targetView
 	err := r.reader.SearchWhere(ctx,
 		&foundTargets,
-		strings.Join(where, " or "),
+		whereClause,
 		args,
 		db.WithLimit(limit),
+		db.WithOrder("create_time asc, public_id asc"),
 	)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, op)
@@ -302,6 +319,29 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	return targets, nil
 }
 
+// ListDeletedIds lists the public ids of targets deleted after the provided
+// time. It is used to report removed targets when refreshing a list.
+func (r *Repository) ListDeletedIds(ctx context.Context, since time.Time) ([]string, error) {
+	const op = "target.(Repository).ListDeletedIds"
+	rows, err := r.reader.Query(ctx, deletedTargetIds, []any{sql.Named("since", timestamp.New(since))})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(ctx, err, op, errors.WithMsg("scan row failed"))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(ctx, err, op)
+	}
+	return ids, nil
+}
+
 func (r *Repository) listPermissionWhereClauses() ([]string, []any) {
 	var where []string
 	var args []any
@@ -521,6 +561,7 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 		case strings.EqualFold("defaultclientport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
+		case strings.EqualFold("sessionidletimeoutseconds", f):
 		case strings.EqualFold("workerfilter", f):
 		case strings.EqualFold("egressworkerfilter", f):
 		case strings.EqualFold("ingressworkerfilter", f):
@@ -529,6 +570,9 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 			addressEndpoint = target.GetAddress()
 		case strings.EqualFold("storagebucketid", f):
 		case strings.EqualFold("enablesessionrecording", f):
+		case strings.EqualFold("recordingcompression", f):
+		case strings.EqualFold("recordingcompressionlevel", f):
+		case strings.EqualFold("hostkeys", f):
 		default:
 			return nil, db.NoRowsAffected, errors.New(ctx, errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -537,21 +581,25 @@ func (r *Repository) UpdateTarget(ctx context.Context, target Target, version ui
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbw.BuildUpdatePaths(
 		map[string]any{
-			"Name":                   target.GetName(),
-			"Description":            target.GetDescription(),
-			"DefaultPort":            target.GetDefaultPort(),
-			"DefaultClientPort":      target.GetDefaultClientPort(),
-			"SessionMaxSeconds":      target.GetSessionMaxSeconds(),
-			"SessionConnectionLimit": target.GetSessionConnectionLimit(),
-			"WorkerFilter":           target.GetWorkerFilter(),
-			"EgressWorkerFilter":     target.GetEgressWorkerFilter(),
-			"IngressWorkerFilter":    target.GetIngressWorkerFilter(),
-			"Address":                target.GetAddress(),
-			"StorageBucketId":        target.GetStorageBucketId(),
-			"EnableSessionRecording": target.GetEnableSessionRecording(),
+			"Name":                      target.GetName(),
+			"Description":               target.GetDescription(),
+			"DefaultPort":               target.GetDefaultPort(),
+			"DefaultClientPort":         target.GetDefaultClientPort(),
+			"SessionMaxSeconds":         target.GetSessionMaxSeconds(),
+			"SessionConnectionLimit":    target.GetSessionConnectionLimit(),
+			"SessionIdleTimeoutSeconds": target.GetSessionIdleTimeoutSeconds(),
+			"WorkerFilter":              target.GetWorkerFilter(),
+			"EgressWorkerFilter":        target.GetEgressWorkerFilter(),
+			"IngressWorkerFilter":       target.GetIngressWorkerFilter(),
+			"Address":                   target.GetAddress(),
+			"StorageBucketId":           target.GetStorageBucketId(),
+			"EnableSessionRecording":    target.GetEnableSessionRecording(),
+			"RecordingCompression":      target.GetRecordingCompression(),
+			"RecordingCompressionLevel": target.GetRecordingCompressionLevel(),
+			"HostKeys":                  target.GetHostKeys(),
 		},
 		fieldMaskPaths,
-		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "EnableSessionRecording"},
+		[]string{"SessionMaxSeconds", "SessionConnectionLimit", "SessionIdleTimeoutSeconds", "EnableSessionRecording"},
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
 		return nil, db.NoRowsAffected, errors.New(ctx, errors.EmptyFieldMask, op, "empty field mask")
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"golang.org/x/crypto/ssh"
+)
+
+// ParseHostKeys parses the host keys of an ssh target. keys contains public
+// keys in the OpenSSH authorized_keys format, one per line. Empty lines and
+// comments are ignored. An error is returned if keys does not contain any
+// public key or contains a line which is not a public key.
+func ParseHostKeys(ctx context.Context, keys string) ([]ssh.PublicKey, error) {
+	const op = "ssh.ParseHostKeys"
+	var ret []ssh.PublicKey
+	for i, line := range strings.Split(keys, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("line %d of host keys is not a public key in the authorized_keys format", i+1))
+		}
+		ret = append(ret, k)
+	}
+	if len(ret) == 0 {
+		return nil, errors.New(ctx, errors.InvalidParameter, op, "missing host keys")
+	}
+	return ret, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHostKey is an ed25519 public key in the authorized_keys format.
+const testHostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAII4/60BKOPLxmKVsPQngvO7sS2NP34EemdH4CKFvTPzU"
+
+func TestParseHostKeys(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		keys    string
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "one key",
+			keys:    testHostKey,
+			wantLen: 1,
+		},
+		{
+			name:    "keys with comments and empty lines",
+			keys:    "# web hosts\n" + testHostKey + " host-1\n\n" + testHostKey + " host-2\n",
+			wantLen: 2,
+		},
+		{
+			name:    "empty",
+			keys:    "",
+			wantErr: true,
+		},
+		{
+			name:    "only comments",
+			keys:    "# no keys\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid line",
+			keys:    testHostKey + "\nnot a key\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := ssh.ParseHostKeys(ctx, tt.keys)
+			if tt.wantErr {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+				return
+			}
+			require.NoError(err)
+			assert.Len(got, tt.wantLen)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/bsr"
+	"github.com/hashicorp/boundary/internal/credential"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/target"
+)
+
+type targetHooks struct{}
+
+func init() {
+	target.Register(Subtype, targetHooks{}, TargetPrefix)
+}
+
+const (
+	// TargetPrefix is the prefix for public ids of a ssh.Target.
+	TargetPrefix = globals.SshTargetPrefix
+
+	// DefaultPort is the port used by an ssh.Target when none is provided.
+	DefaultPort = 22
+)
+
+// Vet validates that the given target.Target is a ssh.Target and that it
+// has a Target store.
+func (h targetHooks) Vet(ctx context.Context, t target.Target) error {
+	const op = "ssh.vet"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a ssh.Target")
+	}
+
+	if tt == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	}
+
+	if tt.Target == nil {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+	if tt.GetDefaultPort() == 0 {
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target default port")
+	}
+	if tt.GetDefaultPort() > math.MaxUint16 {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid default port number")
+	}
+	if tt.GetDefaultClientPort() > math.MaxUint16 {
+		return errors.New(ctx, errors.InvalidParameter, op, "invalid default client port number")
+	}
+	if err := vetRecordingCompression(ctx, tt); err != nil {
+		return errors.Wrap(ctx, err, op)
+	}
+	if tt.GetHostKeys() != "" {
+		if _, err := ParseHostKeys(ctx, tt.GetHostKeys()); err != nil {
+			return errors.Wrap(ctx, err, op)
+		}
+	}
+	return nil
+}
+
+// vetRecordingCompression validates the compression of the session
+// recordings of t and its level.
+func vetRecordingCompression(ctx context.Context, t *Target) error {
+	const op = "ssh.vetRecordingCompression"
+	c := bsr.NoCompression
+	if t.GetRecordingCompression() != "" {
+		var err error
+		if c, err = bsr.ParseCompression(t.GetRecordingCompression()); err != nil {
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid recording compression %q", t.GetRecordingCompression()))
+		}
+	}
+	if !bsr.ValidCompressionLevel(c, int(t.GetRecordingCompressionLevel())) {
+		return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid recording compression level %d for %s", t.GetRecordingCompressionLevel(), c))
+	}
+	return nil
+}
+
+// VetForUpdate validates that the given target.Target is a ssh.Target,
+// and that it has a Target store and that it isn't attempting to clear or
+// set to zero the default port.
+func (h targetHooks) VetForUpdate(ctx context.Context, t target.Target, paths []string) error {
+	const op = "ssh.vetForUpdate"
+
+	tt, ok := t.(*Target)
+	if !ok {
+		return errors.New(ctx, errors.InvalidParameter, op, "target is not a ssh.Target")
+	}
+
+	switch {
+	case tt == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target")
+	case tt.Target == nil:
+		return errors.New(ctx, errors.InvalidParameter, op, "missing target store")
+	}
+
+	for _, f := range paths {
+		if strings.EqualFold("defaultport", f) {
+			if tt.GetDefaultPort() == 0 {
+				return errors.New(ctx, errors.InvalidParameter, op, "clearing or setting default port to zero")
+			}
+			if tt.GetDefaultPort() > math.MaxUint16 {
+				return errors.New(ctx, errors.InvalidParameter, op, "invalid default port number")
+			}
+		}
+		if strings.EqualFold("defaultclientport", f) {
+			if tt.GetDefaultClientPort() > math.MaxUint16 {
+				return errors.New(ctx, errors.InvalidParameter, op, "invalid default client port number")
+			}
+		}
+		if strings.EqualFold("recordingcompression", f) && tt.GetRecordingCompression() != "" {
+			if _, err := bsr.ParseCompression(tt.GetRecordingCompression()); err != nil {
+				return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("invalid recording compression %q", tt.GetRecordingCompression()))
+			}
+		}
+		if strings.EqualFold("hostkeys", f) && tt.GetHostKeys() != "" {
+			if _, err := ParseHostKeys(ctx, tt.GetHostKeys()); err != nil {
+				return errors.Wrap(ctx, err, op)
+			}
+		}
+	}
+
+	return nil
+}
+
+// VetCredentialSources checks that all the provided credential sources have a
+// CredentialPurpose of BrokeredPurpose or InjectedApplicationPurpose. Any other
+// CredentialPurpose will result in an error.
+func (h targetHooks) VetCredentialSources(ctx context.Context, libs []*target.CredentialLibrary, creds []*target.StaticCredential) error {
+	const op = "ssh.VetCredentialSources"
+
+	for _, c := range libs {
+		switch credential.Purpose(c.GetCredentialPurpose()) {
+		case credential.BrokeredPurpose, credential.InjectedApplicationPurpose:
+		default:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("ssh.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	for _, c := range creds {
+		switch credential.Purpose(c.GetCredentialPurpose()) {
+		case credential.BrokeredPurpose, credential.InjectedApplicationPurpose:
+		default:
+			return errors.New(ctx, errors.InvalidParameter, op, fmt.Sprintf("ssh.Target only supports credential purposes: %q and %q", credential.BrokeredPurpose, credential.InjectedApplicationPurpose))
+		}
+	}
+	return nil
+}
@@ -30,6 +30,7 @@ type Target interface {
 	GetUpdateTime() *timestamp.Timestamp
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
+	GetSessionIdleTimeoutSeconds() uint32
 	GetWorkerFilter() string
 	GetEgressWorkerFilter() string
 	GetIngressWorkerFilter() string
@@ -38,6 +39,9 @@ type Target interface {
 	GetCredentialSources() []CredentialSource
 	GetStorageBucketId() string
 	GetEnableSessionRecording() bool
+	GetRecordingCompression() string
+	GetRecordingCompressionLevel() uint32
+	GetHostKeys() string
 	Clone() Target
 	SetPublicId(context.Context, string) error
 	SetProjectId(string)
@@ -50,6 +54,7 @@ type Target interface {
 	SetUpdateTime(*timestamp.Timestamp)
 	SetSessionMaxSeconds(uint32)
 	SetSessionConnectionLimit(int32)
+	SetSessionIdleTimeoutSeconds(uint32)
 	SetWorkerFilter(string)
 	SetEgressWorkerFilter(string)
 	SetIngressWorkerFilter(string)
@@ -58,6 +63,9 @@ type Target interface {
 	SetCredentialSources([]CredentialSource)
 	SetStorageBucketId(string)
 	SetEnableSessionRecording(bool)
+	SetRecordingCompression(string)
+	SetRecordingCompressionLevel(uint32)
+	SetHostKeys(string)
 	Oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -172,6 +180,7 @@ func (t *targetView) targetSubtype(ctx context.Context, address string) (Target,
 	tt.SetUpdateTime(t.UpdateTime)
 	tt.SetSessionMaxSeconds(t.SessionMaxSeconds)
 	tt.SetSessionConnectionLimit(t.SessionConnectionLimit)
+	tt.SetSessionIdleTimeoutSeconds(t.SessionIdleTimeoutSeconds)
 	tt.SetWorkerFilter(t.WorkerFilter)
 	tt.SetEgressWorkerFilter(t.EgressWorkerFilter)
 	tt.SetIngressWorkerFilter(t.IngressWorkerFilter)
@@ -180,5 +189,8 @@ func (t *targetView) targetSubtype(ctx context.Context, address string) (Target,
 	tt.SetCredentialSources(t.CredentialSources)
 	tt.SetEnableSessionRecording(t.EnableSessionRecording)
 	tt.SetStorageBucketId(t.StorageBucketId)
+	tt.SetRecordingCompression(t.RecordingCompression)
+	tt.SetRecordingCompressionLevel(t.RecordingCompressionLevel)
+	tt.SetHostKeys(t.HostKeys)
 	return tt, nil
 }
@@ -122,6 +122,10 @@ func (t *Target) GetSessionConnectionLimit() int32 {
 	return t.SessionConnectionLimit
 }
 
+func (t *Target) GetSessionIdleTimeoutSeconds() uint32 {
+	return 0
+}
+
 func (t *Target) GetWorkerFilter() string {
 	return t.WorkerFilter
 }
@@ -154,6 +158,18 @@ func (t *Target) GetStorageBucketId() string {
 	return ""
 }
 
+func (t *Target) GetRecordingCompression() string {
+	return ""
+}
+
+func (t *Target) GetRecordingCompressionLevel() uint32 {
+	return 0
+}
+
+func (t *Target) GetHostKeys() string {
+	return ""
+}
+
 func (t *Target) Clone() target.Target {
 	cp := proto.Clone(t.Target)
 	return &Target{
@@ -209,6 +225,8 @@ func (t *Target) SetSessionConnectionLimit(l int32) {
 	t.SessionConnectionLimit = l
 }
 
+func (t *Target) SetSessionIdleTimeoutSeconds(_ uint32) {}
+
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
@@ -237,6 +255,12 @@ func (t *Target) SetEnableSessionRecording(_ bool) {}
 
 func (t *Target) SetStorageBucketId(_ string) {}
 
+func (t *Target) SetRecordingCompression(_ string) {}
+
+func (t *Target) SetRecordingCompressionLevel(_ uint32) {}
+
+func (t *Target) SetHostKeys(_ string) {}
+
 func (t *Target) Oplog(op oplog.OpType) oplog.Metadata {
 	return oplog.Metadata{
 		"resource-public-id": []string{t.PublicId},
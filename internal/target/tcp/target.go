@@ -54,16 +54,17 @@ func (h targetHooks) NewTarget(ctx context.Context, projectId string, opt ...tar
 	}
 	t := &Target{
 		Target: &store.Target{
-			ProjectId:              projectId,
-			Name:                   opts.WithName,
-			Description:            opts.WithDescription,
-			DefaultPort:            opts.WithDefaultPort,
-			DefaultClientPort:      opts.WithDefaultClientPort,
-			SessionConnectionLimit: opts.WithSessionConnectionLimit,
-			SessionMaxSeconds:      opts.WithSessionMaxSeconds,
-			WorkerFilter:           opts.WithWorkerFilter,
-			EgressWorkerFilter:     opts.WithEgressWorkerFilter,
-			IngressWorkerFilter:    opts.WithIngressWorkerFilter,
+			ProjectId:                 projectId,
+			Name:                      opts.WithName,
+			Description:               opts.WithDescription,
+			DefaultPort:               opts.WithDefaultPort,
+			DefaultClientPort:         opts.WithDefaultClientPort,
+			SessionConnectionLimit:    opts.WithSessionConnectionLimit,
+			SessionMaxSeconds:         opts.WithSessionMaxSeconds,
+			SessionIdleTimeoutSeconds: opts.WithSessionIdleTimeoutSeconds,
+			WorkerFilter:              opts.WithWorkerFilter,
+			EgressWorkerFilter:        opts.WithEgressWorkerFilter,
+			IngressWorkerFilter:       opts.WithIngressWorkerFilter,
 		},
 		Address: opts.WithAddress,
 	}
@@ -156,6 +157,18 @@ func (t *Target) GetStorageBucketId() string {
 	return ""
 }
 
+func (t *Target) GetRecordingCompression() string {
+	return ""
+}
+
+func (t *Target) GetRecordingCompressionLevel() uint32 {
+	return 0
+}
+
+func (t *Target) GetHostKeys() string {
+	return ""
+}
+
 func (t *Target) SetPublicId(ctx context.Context, publicId string) error {
 	const op = "tcp.(Target).SetPublicId"
 	if !strings.HasPrefix(publicId, TargetPrefix+"_") {
@@ -206,6 +219,10 @@ func (t *Target) SetSessionConnectionLimit(limit int32) {
 	t.SessionConnectionLimit = limit
 }
 
+func (t *Target) SetSessionIdleTimeoutSeconds(s uint32) {
+	t.SessionIdleTimeoutSeconds = s
+}
+
 func (t *Target) SetWorkerFilter(filter string) {
 	t.WorkerFilter = filter
 }
@@ -230,5 +247,8 @@ func (t *Target) SetCredentialSources(sources []target.CredentialSource) {
 	t.CredentialSources = sources
 }
 
-func (t *Target) SetEnableSessionRecording(_ bool) {}
-func (t *Target) SetStorageBucketId(_ string)      {}
+func (t *Target) SetEnableSessionRecording(_ bool)      {}
+func (t *Target) SetStorageBucketId(_ string)           {}
+func (t *Target) SetRecordingCompression(_ string)      {}
+func (t *Target) SetRecordingCompressionLevel(_ uint32) {}
+func (t *Target) SetHostKeys(_ string)                  {}
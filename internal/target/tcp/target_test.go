@@ -65,6 +65,29 @@ func TestTarget_Create(t *testing.T) {
 			}(),
 			create: true,
 		},
+		{
+			name: "valid-session-idle-timeout",
+			args: args{
+				projectId: prj.PublicId,
+				opt: []target.Option{
+					target.WithName("valid-session-idle-timeout"),
+					target.WithSessionIdleTimeoutSeconds(300),
+				},
+			},
+			want: func() target.Target {
+				t, _ := target.New(
+					ctx,
+					tcp.Subtype,
+					prj.PublicId,
+					target.WithName("valid-session-idle-timeout"),
+					target.WithSessionMaxSeconds(uint32((8 * time.Hour).Seconds())),
+					target.WithSessionConnectionLimit(-1),
+					target.WithSessionIdleTimeoutSeconds(300),
+				)
+				return t
+			}(),
+			create: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4,7 +4,9 @@
 package cluster
 
 import (
-	// Enable tcp target support.
+	// Enable tcp and ssh target support.
+	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/ssh"
 	_ "github.com/hashicorp/boundary/internal/daemon/controller/handlers/targets/tcp"
+	_ "github.com/hashicorp/boundary/internal/target/ssh"
 	_ "github.com/hashicorp/boundary/internal/target/tcp"
 )
@@ -68,6 +68,15 @@ const (
 	ListScopeKeyVersionDestructionJobs Type = 54
 	DestroyScopeKeyVersion             Type = 55
 	Download                           Type = 56
+	Search                             Type = 57
+	Unlock                             Type = 58
+	EnrollTotp                         Type = 59
+	VerifyTotp                         Type = 60
+	RevokeTokens                       Type = 61
+	Approve                            Type = 62
+	Deny                               Type = 63
+	ExplainPermissions                 Type = 64
+	Watch                              Type = 65
 
 	// When adding new actions, be sure to update:
 	//
@@ -132,6 +141,15 @@ var Map = map[string]Type{
 	ListScopeKeyVersionDestructionJobs.String(): ListScopeKeyVersionDestructionJobs,
 	DestroyScopeKeyVersion.String():             DestroyScopeKeyVersion,
 	Download.String():                           Download,
+	Search.String():                             Search,
+	Unlock.String():                             Unlock,
+	EnrollTotp.String():                         EnrollTotp,
+	VerifyTotp.String():                         VerifyTotp,
+	RevokeTokens.String():                       RevokeTokens,
+	Approve.String():                            Approve,
+	Deny.String():                               Deny,
+	ExplainPermissions.String():                 ExplainPermissions,
+	Watch.String():                              Watch,
 }
 
 var DeprecatedMap = map[string]Type{
@@ -202,6 +220,15 @@ func (a Type) String() string {
 		"list-key-version-destruction-jobs",
 		"destroy-key-version",
 		"download",
+		"search",
+		"unlock",
+		"enroll-totp",
+		"verify-totp",
+		"revoke-tokens",
+		"approve",
+		"deny",
+		"explain-permissions",
+		"watch",
 	}[a]
 }
 
@@ -138,6 +138,42 @@ func TestAction(t *testing.T) {
 			action: Download,
 			want:   "download",
 		},
+		{
+			action: Search,
+			want:   "search",
+		},
+		{
+			action: Unlock,
+			want:   "unlock",
+		},
+		{
+			action: EnrollTotp,
+			want:   "enroll-totp",
+		},
+		{
+			action: VerifyTotp,
+			want:   "verify-totp",
+		},
+		{
+			action: RevokeTokens,
+			want:   "revoke-tokens",
+		},
+		{
+			action: Approve,
+			want:   "approve",
+		},
+		{
+			action: Deny,
+			want:   "deny",
+		},
+		{
+			action: ExplainPermissions,
+			want:   "explain-permissions",
+		},
+		{
+			action: Watch,
+			want:   "watch",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
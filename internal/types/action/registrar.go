@@ -71,3 +71,13 @@ func ActionSetForResource(r resource.Type) (ActionSet, error) {
 	}
 	return a.valid, nil
 }
+
+// IdActionSetForResource returns the ActionSet of actions on individual
+// resources registered for r or an error if r has not been registered.
+func IdActionSetForResource(r resource.Type) (ActionSet, error) {
+	a, err := byResourceRegistrar.get(r)
+	if err != nil {
+		return nil, err
+	}
+	return a.individual, nil
+}
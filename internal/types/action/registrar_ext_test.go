@@ -68,4 +68,13 @@ func TestRegisterResource(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("IdActionSetForResource", func(t *testing.T) {
+		got, err := action.IdActionSetForResource(resource.Session)
+		require.NoError(t, err)
+		assert.Equal(t, action.NewActionSet(action.Read, action.Create), got)
+
+		_, err = action.IdActionSetForResource(resource.Target)
+		require.EqualError(t, err, "resource not found: target")
+	})
 }
@@ -34,6 +34,9 @@ const (
 	CredentialLibrary
 	Credential
 	StorageBucket
+	Alias
+	Policy
+	AccessRequest
 	// NOTE: When adding a new type, be sure to update:
 	//
 	// * The Grant.validateType function and test
@@ -72,6 +75,9 @@ func (r Type) String() string {
 		"credential-library",
 		"credential",
 		"storage-bucket",
+		"alias",
+		"policy",
+		"access-request",
 	}[r]
 }
 
@@ -79,6 +85,10 @@ func (r Type) PluralString() string {
 	switch r {
 	case CredentialLibrary:
 		return "credential-libraries"
+	case Alias:
+		return "aliases"
+	case Policy:
+		return "policies"
 	default:
 		return r.String() + "s"
 	}
@@ -88,6 +98,10 @@ func FromPlural(s string) (Type, bool) {
 	switch s {
 	case "credential-libraries":
 		return CredentialLibrary, true
+	case "aliases":
+		return Alias, true
+	case "policies":
+		return Policy, true
 	default:
 		t, ok := Map[strings.TrimSuffix(s, "s")]
 		return t, ok
@@ -117,6 +131,9 @@ var Map = map[string]Type{
 	CredentialLibrary.String(): CredentialLibrary,
 	Credential.String():        Credential,
 	StorageBucket.String():     StorageBucket,
+	Alias.String():             Alias,
+	Policy.String():            Policy,
+	AccessRequest.String():     AccessRequest,
 }
 
 // Parent returns the parent type for a given type; if there is no parent, it
@@ -159,7 +176,10 @@ func TopLevelType(typ Type) bool {
 		Target,
 		User,
 		StorageBucket,
-		Worker:
+		Worker,
+		Alias,
+		Policy,
+		AccessRequest:
 		return true
 	}
 	return false
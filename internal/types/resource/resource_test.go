@@ -122,6 +122,21 @@ func Test_Resource(t *testing.T) {
 			want:       Credential,
 			parent:     CredentialStore,
 		},
+		{
+			typeString:   "alias",
+			want:         Alias,
+			topLevelType: true,
+		},
+		{
+			typeString:   "policy",
+			want:         Policy,
+			topLevelType: true,
+		},
+		{
+			typeString:   "access-request",
+			want:         AccessRequest,
+			topLevelType: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
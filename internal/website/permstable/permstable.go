@@ -65,6 +65,7 @@ var table = &Table{
 
 func main() {
 	table.Body.Resources = append(table.Body.Resources,
+		accessRequest,
 		account,
 		authMethod,
 		authToken,
@@ -316,6 +317,73 @@ func rudActions(typ string, pin bool) []*Action {
 	return ret
 }
 
+var accessRequest = &Resource{
+	Type:   "Access Request",
+	Scopes: infraScope,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/access-requests",
+			Params: map[string]string{
+				"Type": "access-request",
+			},
+			Actions: clActions("an access request"),
+		},
+		{
+			Path: "/access-requests/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "access-request",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read an access request",
+					Examples: []string{
+						"id=<id>;actions=read",
+					},
+				},
+				{
+					Name:        "cancel",
+					Description: "Cancel an access request",
+					Examples: []string{
+						"id=<id>;actions=cancel",
+					},
+				},
+				{
+					Name:        "approve",
+					Description: "Approve an access request, which grants its user access to its target until it expires",
+					Examples: []string{
+						"id=<id>;actions=approve",
+						"id=*;type=access-request;actions=approve",
+					},
+				},
+				{
+					Name:        "deny",
+					Description: "Deny an access request",
+					Examples: []string{
+						"id=<id>;actions=deny",
+						"id=*;type=access-request;actions=deny",
+					},
+				},
+				{
+					Name:        "read:self",
+					Description: "Read an access request, which must be associated with the calling user",
+					Examples: []string{
+						"id=*;type=access-request;actions=read:self",
+					},
+				},
+				{
+					Name:        "cancel:self",
+					Description: "Cancel an access request, which must be associated with the calling user",
+					Examples: []string{
+						"id=*;type=access-request;actions=cancel:self",
+					},
+				},
+			},
+		},
+	},
+}
+
 var account = &Resource{
 	Type:   "Account",
 	Scopes: iamScopes,
@@ -352,6 +420,30 @@ var account = &Resource{
 						"id=<pin>;type=<type>;actions=change-password",
 					},
 				},
+				&Action{
+					Name:        "unlock",
+					Description: "Unlock an account which is locked after too many failed authentication attempts",
+					Examples: []string{
+						"id=<id>;actions=unlock",
+						"id=<pin>;type=<type>;actions=unlock",
+					},
+				},
+				&Action{
+					Name:        "enroll-totp",
+					Description: "Enroll a TOTP second factor for a password account",
+					Examples: []string{
+						"id=<id>;actions=enroll-totp",
+						"id=<pin>;type=<type>;actions=enroll-totp",
+					},
+				},
+				&Action{
+					Name:        "verify-totp",
+					Description: "Verify the TOTP enrollment of a password account",
+					Examples: []string{
+						"id=<id>;actions=verify-totp",
+						"id=<pin>;type=<type>;actions=verify-totp",
+					},
+				},
 			),
 		},
 	},
@@ -383,6 +475,13 @@ var authMethod = &Resource{
 						"id=<id>;actions=authenticate",
 					},
 				},
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens issued by an auth method and cancel the sessions created with them",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+					},
+				},
 			),
 		},
 	},
@@ -737,6 +836,13 @@ var session = &Resource{
 						"id=*;type=session;actions=cancel:self",
 					},
 				},
+				{
+					Name:        "watch",
+					Description: "Watch the live output of an active SSH session",
+					Examples: []string{
+						"id=<id>;actions=watch",
+					},
+				},
 			},
 		},
 	},
@@ -922,6 +1028,20 @@ var user = &Resource{
 						"id=<id>;actions=remove-accounts",
 					},
 				},
+				&Action{
+					Name:        "revoke-tokens",
+					Description: "Revoke all auth tokens of a user and cancel their sessions",
+					Examples: []string{
+						"id=<id>;actions=revoke-tokens",
+					},
+				},
+				&Action{
+					Name:        "explain-permissions",
+					Description: "Explain the permissions a user has on a resource",
+					Examples: []string{
+						"id=<id>;actions=explain-permissions",
+					},
+				},
 			),
 		},
 	},
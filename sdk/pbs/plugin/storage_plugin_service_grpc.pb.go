@@ -29,6 +29,7 @@ const (
 	StoragePluginService_HeadObject_FullMethodName            = "/plugin.v1.StoragePluginService/HeadObject"
 	StoragePluginService_GetObject_FullMethodName             = "/plugin.v1.StoragePluginService/GetObject"
 	StoragePluginService_PutObject_FullMethodName             = "/plugin.v1.StoragePluginService/PutObject"
+	StoragePluginService_DeleteObjects_FullMethodName         = "/plugin.v1.StoragePluginService/DeleteObjects"
 )
 
 // StoragePluginServiceClient is the client API for StoragePluginService service.
@@ -54,6 +55,10 @@ type StoragePluginServiceClient interface {
 	// PutObject is a hook that reads a file stored on local disk and
 	// stores it to an external object store.
 	PutObject(ctx context.Context, in *PutObjectRequest, opts ...grpc.CallOption) (*PutObjectResponse, error)
+	// DeleteObjects is a hook that deletes the object with the given key or,
+	// when recursive is set, every object whose key starts with the given
+	// prefix.
+	DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error)
 }
 
 type storagePluginServiceClient struct {
@@ -150,6 +155,15 @@ func (c *storagePluginServiceClient) PutObject(ctx context.Context, in *PutObjec
 	return out, nil
 }
 
+func (c *storagePluginServiceClient) DeleteObjects(ctx context.Context, in *DeleteObjectsRequest, opts ...grpc.CallOption) (*DeleteObjectsResponse, error) {
+	out := new(DeleteObjectsResponse)
+	err := c.cc.Invoke(ctx, StoragePluginService_DeleteObjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoragePluginServiceServer is the server API for StoragePluginService service.
 // All implementations must embed UnimplementedStoragePluginServiceServer
 // for forward compatibility
@@ -173,6 +187,10 @@ type StoragePluginServiceServer interface {
 	// PutObject is a hook that reads a file stored on local disk and
 	// stores it to an external object store.
 	PutObject(context.Context, *PutObjectRequest) (*PutObjectResponse, error)
+	// DeleteObjects is a hook that deletes the object with the given key or,
+	// when recursive is set, every object whose key starts with the given
+	// prefix.
+	DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error)
 	mustEmbedUnimplementedStoragePluginServiceServer()
 }
 
@@ -201,6 +219,9 @@ func (UnimplementedStoragePluginServiceServer) GetObject(*GetObjectRequest, Stor
 func (UnimplementedStoragePluginServiceServer) PutObject(context.Context, *PutObjectRequest) (*PutObjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutObject not implemented")
 }
+func (UnimplementedStoragePluginServiceServer) DeleteObjects(context.Context, *DeleteObjectsRequest) (*DeleteObjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObjects not implemented")
+}
 func (UnimplementedStoragePluginServiceServer) mustEmbedUnimplementedStoragePluginServiceServer() {}
 
 // UnsafeStoragePluginServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -343,6 +364,24 @@ func _StoragePluginService_PutObject_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoragePluginService_DeleteObjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoragePluginServiceServer).DeleteObjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StoragePluginService_DeleteObjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoragePluginServiceServer).DeleteObjects(ctx, req.(*DeleteObjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StoragePluginService_ServiceDesc is the grpc.ServiceDesc for StoragePluginService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -374,6 +413,10 @@ var StoragePluginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutObject",
 			Handler:    _StoragePluginService_PutObject_Handler,
 		},
+		{
+			MethodName: "DeleteObjects",
+			Handler:    _StoragePluginService_DeleteObjects_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{